@@ -13,13 +13,18 @@ import (
 	"github.com/bissquit/incident-garden/internal/config"
 )
 
+// version is the build version reported by /healthz, set at build time:
+// go build -ldflags "-X main.version=$(git rev-parse --short HEAD)".
+// Empty falls back to version.Version.
+var version string
+
 func main() {
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
-	application, err := app.New(cfg)
+	application, err := app.New(cfg, app.WithBuildVersion(version))
 	if err != nil {
 		log.Fatalf("failed to initialize app: %v", err)
 	}
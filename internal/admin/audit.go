@@ -0,0 +1,132 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+)
+
+// Audit log action and target type of settings changes. Other modules record
+// their admin actions through their own AuditRecorder interfaces: user.update
+// (identity), events.purge (events), services.bulk_archive (catalog),
+// webhooks.complete_rotation (webhooks).
+const (
+	AuditActionSettingsUpdate = "settings.update"
+	AuditTargetSettings       = "settings"
+)
+
+// AuditEntry is an admin action in the audit log.
+type AuditEntry struct {
+	ID          string                 `json:"id"`
+	ActorUserID *string                `json:"actor_user_id"` // nil once the actor is deleted
+	Action      string                 `json:"action"`
+	TargetType  string                 `json:"target_type"`
+	TargetID    string                 `json:"target_id,omitempty"`
+	Details     map[string]interface{} `json:"details"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// AuditFilter selects audit entries; empty fields match all.
+// From is inclusive, To is exclusive.
+type AuditFilter struct {
+	Action string
+	From   time.Time
+	To     time.Time
+	Limit  int
+	Offset int
+}
+
+// AuditRepository stores the admin audit log.
+// This interface is implemented by postgres.AuditRepository.
+type AuditRepository interface {
+	// CreateAuditEntry stores an entry and sets its ID and CreatedAt.
+	CreateAuditEntry(ctx context.Context, entry *AuditEntry) error
+	// ListAuditEntries returns matching entries, newest first, with their total count.
+	ListAuditEntries(ctx context.Context, filter AuditFilter) ([]AuditEntry, int, error)
+}
+
+// AuditLogger records admin actions with the acting user taken from the request context.
+// Handlers call it after the action succeeded; an entry that can't be stored is logged
+// and the request still succeeds, as the action is already done.
+type AuditLogger struct {
+	repo AuditRepository
+}
+
+// NewAuditLogger creates a new admin audit logger.
+func NewAuditLogger(repo AuditRepository) *AuditLogger {
+	return &AuditLogger{repo: repo}
+}
+
+// Record stores an admin action with free-form details.
+func (l *AuditLogger) Record(ctx context.Context, action, targetType, targetID string, details map[string]interface{}) {
+	if details == nil {
+		details = make(map[string]interface{})
+	}
+	entry := &AuditEntry{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Details:    details,
+	}
+	if actor := httputil.GetUserID(ctx); actor != "" {
+		entry.ActorUserID = &actor
+	}
+	if err := l.repo.CreateAuditEntry(ctx, entry); err != nil {
+		slog.ErrorContext(ctx, "failed to record admin audit entry", "action", action, "target_id", targetID, "error", err)
+	}
+}
+
+// RecordChange stores an admin action with the fields that differ between before
+// and after as details, field → {from, to}. Nothing is stored if no field changed.
+func (l *AuditLogger) RecordChange(ctx context.Context, action, targetType, targetID string, before, after interface{}) {
+	changes := Diff(before, after)
+	if len(changes) == 0 {
+		return
+	}
+	details := make(map[string]interface{}, len(changes))
+	for field, change := range changes {
+		details[field] = change
+	}
+	l.Record(ctx, action, targetType, targetID, details)
+}
+
+// ListEntries returns audit entries matching the filter with their total count.
+func (l *AuditLogger) ListEntries(ctx context.Context, filter AuditFilter) ([]AuditEntry, int, error) {
+	return l.repo.ListAuditEntries(ctx, filter)
+}
+
+// Diff compares the JSON fields of before and after, structs or maps, and
+// returns the changed ones. A field missing on one side is compared as null.
+func Diff(before, after interface{}) map[string]domain.FieldChange {
+	from := jsonFields(before)
+	to := jsonFields(after)
+
+	changes := make(map[string]domain.FieldChange)
+	for field, value := range from {
+		if !reflect.DeepEqual(value, to[field]) {
+			changes[field] = domain.FieldChange{From: value, To: to[field]}
+		}
+	}
+	for field, value := range to {
+		if _, ok := from[field]; !ok && value != nil {
+			changes[field] = domain.FieldChange{From: nil, To: value}
+		}
+	}
+	return changes
+}
+
+// jsonFields returns the top-level JSON fields of v, empty if v is not a JSON object.
+func jsonFields(v interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(data, &fields)
+	return fields
+}
@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAuditRepo struct {
+	entries []*AuditEntry
+	err     error
+}
+
+func (r *stubAuditRepo) CreateAuditEntry(_ context.Context, entry *AuditEntry) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *stubAuditRepo) ListAuditEntries(_ context.Context, _ AuditFilter) ([]AuditEntry, int, error) {
+	return nil, 0, nil
+}
+
+func TestDiff(t *testing.T) {
+	before := Settings{
+		StatusPageSettings: domain.StatusPageSettings{StatusPageTitle: "Status", LogoURL: "https://example.com/logo.png"},
+		MaxChannelsPerUser: 10,
+	}
+	after := before
+	after.StatusPageTitle = "Acme Status"
+	after.MaxChannelsPerUser = 5
+
+	changes := Diff(before, after)
+	assert.Equal(t, map[string]domain.FieldChange{
+		"status_page_title":     {From: "Status", To: "Acme Status"},
+		"max_channels_per_user": {From: float64(10), To: float64(5)},
+	}, changes)
+
+	assert.Empty(t, Diff(before, before))
+}
+
+func TestDiff_Maps(t *testing.T) {
+	changes := Diff(
+		map[string]interface{}{"role": "user", "is_active": true},
+		map[string]interface{}{"role": "operator", "is_active": true, "note": "added"},
+	)
+	assert.Equal(t, map[string]domain.FieldChange{
+		"role": {From: "user", To: "operator"},
+		"note": {From: nil, To: "added"},
+	}, changes)
+}
+
+func TestAuditLogger_Record(t *testing.T) {
+	repo := &stubAuditRepo{}
+	logger := NewAuditLogger(repo)
+	ctx := context.WithValue(context.Background(), httputil.UserIDKey, "admin-1")
+
+	logger.Record(ctx, "events.purge", "events", "", nil)
+
+	require.Len(t, repo.entries, 1)
+	entry := repo.entries[0]
+	require.NotNil(t, entry.ActorUserID)
+	assert.Equal(t, "admin-1", *entry.ActorUserID)
+	assert.Equal(t, "events.purge", entry.Action)
+	assert.NotNil(t, entry.Details, "details are stored as an empty object")
+}
+
+func TestAuditLogger_RecordChange(t *testing.T) {
+	repo := &stubAuditRepo{}
+	logger := NewAuditLogger(repo)
+
+	logger.RecordChange(context.Background(), "user.update", "user", "u-1",
+		map[string]interface{}{"role": "user"}, map[string]interface{}{"role": "user"})
+	assert.Empty(t, repo.entries, "no entry without changes")
+
+	logger.RecordChange(context.Background(), "user.update", "user", "u-1",
+		map[string]interface{}{"role": "user"}, map[string]interface{}{"role": "admin"})
+	require.Len(t, repo.entries, 1)
+	assert.Nil(t, repo.entries[0].ActorUserID, "no actor outside a request")
+	assert.Equal(t, domain.FieldChange{From: "user", To: "admin"}, repo.entries[0].Details["role"])
+}
+
+func TestAuditLogger_RecordRepositoryError(t *testing.T) {
+	logger := NewAuditLogger(&stubAuditRepo{err: errors.New("db down")})
+	assert.NotPanics(t, func() {
+		logger.Record(context.Background(), "settings.update", "settings", "", nil)
+	})
+}
@@ -0,0 +1,135 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/go-chi/chi/v5"
+)
+
+// Pagination of the audit log.
+const (
+	DefaultAuditLogLimit = 50
+	MaxAuditLogLimit     = 100
+)
+
+var errorMappings = []httputil.ErrorMapping{
+	{Error: ErrInvalidSettings, Status: http.StatusBadRequest, Message: ""},
+}
+
+// Handler serves the admin settings and audit log API.
+type Handler struct {
+	service *Service
+	audit   *AuditLogger
+}
+
+// NewHandler creates a new admin handler.
+func NewHandler(service *Service, audit *AuditLogger) *Handler {
+	return &Handler{service: service, audit: audit}
+}
+
+// RegisterAdminRoutes registers routes for settings and the audit log (admin only).
+func (h *Handler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/admin/settings", h.GetSettings)
+	r.Patch("/admin/settings", h.UpdateSettings)
+	r.Get("/admin/audit-log", h.ListAuditLog)
+}
+
+// GetSettings handles GET /admin/settings.
+func (h *Handler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.service.GetSettings(r.Context())
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, settings)
+}
+
+// UpdateSettings handles PATCH /admin/settings.
+func (h *Handler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	var input UpdateSettingsInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	before, err := h.service.GetSettings(r.Context())
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(r.Context(), input, httputil.GetUserID(r.Context()))
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+	h.audit.RecordChange(r.Context(), AuditActionSettingsUpdate, AuditTargetSettings, "", before, settings)
+
+	httputil.Success(w, http.StatusOK, settings)
+}
+
+// ListAuditLog handles GET /admin/audit-log.
+func (h *Handler) ListAuditLog(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := AuditFilter{
+		Action: query.Get("action"),
+		Limit:  DefaultAuditLogLimit,
+	}
+
+	if from := query.Get("from"); from != "" {
+		parsed, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			httputil.Error(w, http.StatusBadRequest, "from must be an RFC 3339 timestamp")
+			return
+		}
+		filter.From = parsed
+	}
+	if to := query.Get("to"); to != "" {
+		parsed, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			httputil.Error(w, http.StatusBadRequest, "to must be an RFC 3339 timestamp")
+			return
+		}
+		filter.To = parsed
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		httputil.Error(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	if l := query.Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 {
+			httputil.Error(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		filter.Limit = min(parsed, MaxAuditLogLimit)
+	}
+
+	if o := query.Get("offset"); o != "" {
+		parsed, err := strconv.Atoi(o)
+		if err != nil || parsed < 0 {
+			httputil.Error(w, http.StatusBadRequest, "offset must be a non-negative integer")
+			return
+		}
+		filter.Offset = parsed
+	}
+
+	entries, total, err := h.audit.ListEntries(r.Context(), filter)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, map[string]interface{}{
+		"entries": entries,
+		"total":   total,
+		"limit":   filter.Limit,
+		"offset":  filter.Offset,
+	})
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/admin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AuditRepository implements the admin.AuditRepository interface using PostgreSQL.
+type AuditRepository struct {
+	db *pgxpool.Pool
+}
+
+var _ admin.AuditRepository = (*AuditRepository)(nil)
+
+// NewAuditRepository creates a new PostgreSQL audit log repository.
+func NewAuditRepository(db *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// CreateAuditEntry stores an admin audit entry and sets its ID and CreatedAt.
+func (r *AuditRepository) CreateAuditEntry(ctx context.Context, entry *admin.AuditEntry) error {
+	query := `
+		INSERT INTO admin_audit_log (actor_user_id, action, target_type, target_id, details)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		RETURNING id, created_at
+	`
+	err := r.db.QueryRow(ctx, query,
+		entry.ActorUserID,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		entry.Details,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListAuditEntries returns matching audit entries, newest first, with their total count.
+func (r *AuditRepository) ListAuditEntries(ctx context.Context, filter admin.AuditFilter) ([]admin.AuditEntry, int, error) {
+	where := `
+		WHERE ($1 = '' OR action = $1)
+		  AND ($2::timestamp IS NULL OR created_at >= $2)
+		  AND ($3::timestamp IS NULL OR created_at < $3)
+	`
+	args := []interface{}{filter.Action, optionalTime(filter.From), optionalTime(filter.To)}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM admin_audit_log` + where
+	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count audit entries: %w", err)
+	}
+
+	query := `
+		SELECT id, actor_user_id, action, target_type, COALESCE(target_id, ''), details, created_at
+		FROM admin_audit_log` + where + `
+		ORDER BY created_at DESC, id
+		LIMIT $4 OFFSET $5
+	`
+	rows, err := r.db.Query(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]admin.AuditEntry, 0)
+	for rows.Next() {
+		var e admin.AuditEntry
+		if err := rows.Scan(&e.ID, &e.ActorUserID, &e.Action, &e.TargetType, &e.TargetID, &e.Details, &e.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("scan audit entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate audit entries: %w", err)
+	}
+	return entries, total, nil
+}
+
+// optionalTime returns nil for the zero time, so the filter condition matches all rows.
+// created_at is a UTC timestamp without time zone, so t is converted to UTC.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
@@ -0,0 +1,70 @@
+// Package postgres provides PostgreSQL storage for the admin module.
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bissquit/incident-garden/internal/admin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SettingsRepository implements the admin.SettingsRepository interface using PostgreSQL.
+type SettingsRepository struct {
+	db *pgxpool.Pool
+}
+
+var _ admin.SettingsRepository = (*SettingsRepository)(nil)
+
+// NewSettingsRepository creates a new PostgreSQL settings repository.
+func NewSettingsRepository(db *pgxpool.Pool) *SettingsRepository {
+	return &SettingsRepository{db: db}
+}
+
+// GetSetting returns the value of a setting.
+func (r *SettingsRepository) GetSetting(ctx context.Context, key string) (string, error) {
+	var value string
+	err := r.db.QueryRow(ctx, `SELECT value FROM admin_settings WHERE key = $1`, key).Scan(&value)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", admin.ErrSettingNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("get setting %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// UpsertSetting creates or replaces the value of a setting.
+func (r *SettingsRepository) UpsertSetting(ctx context.Context, key, value, updatedBy string) error {
+	query := `
+		INSERT INTO admin_settings (key, value, updated_by, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (key) DO UPDATE
+		SET value = EXCLUDED.value, updated_by = EXCLUDED.updated_by, updated_at = NOW()
+	`
+	if _, err := r.db.Exec(ctx, query, key, value, updatedBy); err != nil {
+		return fmt.Errorf("upsert setting %s: %w", key, err)
+	}
+	return nil
+}
+
+// ListSettings returns all stored settings by key.
+func (r *SettingsRepository) ListSettings(ctx context.Context) (map[string]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT key, value FROM admin_settings`)
+	if err != nil {
+		return nil, fmt.Errorf("list settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("scan setting: %w", err)
+		}
+		settings[key] = value
+	}
+	return settings, rows.Err()
+}
@@ -0,0 +1,205 @@
+// Package admin manages admin-editable settings (status page branding, user limits)
+// and the audit log of admin actions.
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// Setting keys of the status page branding.
+const (
+	KeyStatusPageTitle = "status_page_title"
+	KeyLogoURL         = "logo_url"
+	KeyFaviconURL      = "favicon_url"
+	KeyCustomCSS       = "custom_css"
+)
+
+// KeyMaxChannelsPerUser limits notification channels a non-admin user may create.
+const KeyMaxChannelsPerUser = "max_channels_per_user"
+
+// DefaultMaxChannelsPerUser is used until an admin sets max_channels_per_user.
+const DefaultMaxChannelsPerUser = 10
+
+// Limits of the status page settings.
+const (
+	MaxTitleLength   = 200
+	MaxCustomCSSSize = 64 * 1024
+)
+
+// Settings errors.
+var (
+	ErrInvalidSettings = errors.New("invalid settings")
+	ErrSettingNotFound = errors.New("setting not found")
+)
+
+// scriptTagRegex matches <script> elements and stray opening or closing script tags.
+var scriptTagRegex = regexp.MustCompile(`(?is)<script\b[^>]*>.*?</script\s*>|</?script\b[^>]*>?`)
+
+// SettingsRepository stores settings as key/value pairs.
+// This interface is implemented by postgres.SettingsRepository.
+type SettingsRepository interface {
+	// GetSetting returns ErrSettingNotFound for a key that was never set.
+	GetSetting(ctx context.Context, key string) (string, error)
+	UpsertSetting(ctx context.Context, key, value, updatedBy string) error
+	ListSettings(ctx context.Context) (map[string]string, error)
+}
+
+// Settings holds all admin settings: the public status page branding and limits.
+type Settings struct {
+	domain.StatusPageSettings
+	MaxChannelsPerUser int `json:"max_channels_per_user"`
+}
+
+// UpdateSettingsInput is a partial update of the settings:
+// nil fields are left unchanged, an empty string clears a status page setting.
+type UpdateSettingsInput struct {
+	StatusPageTitle    *string `json:"status_page_title"`
+	LogoURL            *string `json:"logo_url"`
+	FaviconURL         *string `json:"favicon_url"`
+	CustomCSS          *string `json:"custom_css"`
+	MaxChannelsPerUser *int    `json:"max_channels_per_user"`
+}
+
+// Service manages the admin settings.
+type Service struct {
+	repo SettingsRepository
+}
+
+// NewService creates a new settings service.
+func NewService(repo SettingsRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// GetSettings returns all admin settings; unset keys are empty or default.
+func (s *Service) GetSettings(ctx context.Context) (*Settings, error) {
+	values, err := s.repo.ListSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Settings{
+		StatusPageSettings: domain.StatusPageSettings{
+			StatusPageTitle: values[KeyStatusPageTitle],
+			LogoURL:         values[KeyLogoURL],
+			FaviconURL:      values[KeyFaviconURL],
+			CustomCSS:       values[KeyCustomCSS],
+		},
+		MaxChannelsPerUser: parseMaxChannels(values[KeyMaxChannelsPerUser]),
+	}, nil
+}
+
+// GetStatusPageSettings returns the status page branding; unset keys are empty.
+func (s *Service) GetStatusPageSettings(ctx context.Context) (*domain.StatusPageSettings, error) {
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &settings.StatusPageSettings, nil
+}
+
+// GetMaxChannelsPerUser returns the notification channel limit of non-admin users.
+func (s *Service) GetMaxChannelsPerUser(ctx context.Context) (int, error) {
+	value, err := s.repo.GetSetting(ctx, KeyMaxChannelsPerUser)
+	if errors.Is(err, ErrSettingNotFound) {
+		return DefaultMaxChannelsPerUser, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return parseMaxChannels(value), nil
+}
+
+// parseMaxChannels parses a stored channel limit; missing or broken values mean the default.
+func parseMaxChannels(value string) int {
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 {
+		return DefaultMaxChannelsPerUser
+	}
+	return limit
+}
+
+// UpdateSettings validates and stores the given settings and returns the result.
+// custom_css is stored sanitized; nothing is stored if any value is invalid.
+func (s *Service) UpdateSettings(ctx context.Context, input UpdateSettingsInput, updatedBy string) (*Settings, error) {
+	if input.CustomCSS != nil {
+		css := SanitizeCSS(*input.CustomCSS)
+		input.CustomCSS = &css
+	}
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	for _, setting := range []struct {
+		key   string
+		value *string
+	}{
+		{KeyStatusPageTitle, input.StatusPageTitle},
+		{KeyLogoURL, input.LogoURL},
+		{KeyFaviconURL, input.FaviconURL},
+		{KeyCustomCSS, input.CustomCSS},
+		{KeyMaxChannelsPerUser, formatOptionalInt(input.MaxChannelsPerUser)},
+	} {
+		if setting.value == nil {
+			continue
+		}
+		if err := s.repo.UpsertSetting(ctx, setting.key, *setting.value, updatedBy); err != nil {
+			return nil, fmt.Errorf("save %s: %w", setting.key, err)
+		}
+	}
+
+	return s.GetSettings(ctx)
+}
+
+func formatOptionalInt(v *int) *string {
+	if v == nil {
+		return nil
+	}
+	s := strconv.Itoa(*v)
+	return &s
+}
+
+// Validate checks the title length, the custom CSS size, the channel limit and that the
+// logo and favicon URLs are absolute http(s): they are loaded by every visitor's browser.
+func (in *UpdateSettingsInput) Validate() error {
+	if in.MaxChannelsPerUser != nil && *in.MaxChannelsPerUser < 1 {
+		return fmt.Errorf("%w: max_channels_per_user must be at least 1", ErrInvalidSettings)
+	}
+	if in.StatusPageTitle != nil && utf8.RuneCountInString(*in.StatusPageTitle) > MaxTitleLength {
+		return fmt.Errorf("%w: status_page_title must be at most %d characters", ErrInvalidSettings, MaxTitleLength)
+	}
+	if in.CustomCSS != nil && len(*in.CustomCSS) > MaxCustomCSSSize {
+		return fmt.Errorf("%w: custom_css must be at most %d bytes", ErrInvalidSettings, MaxCustomCSSSize)
+	}
+	for name, value := range map[string]*string{
+		KeyLogoURL:    in.LogoURL,
+		KeyFaviconURL: in.FaviconURL,
+	} {
+		if value == nil || *value == "" {
+			continue
+		}
+		u, err := url.Parse(*value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %s must be an absolute http(s) URL", ErrInvalidSettings, name)
+		}
+	}
+	return nil
+}
+
+// SanitizeCSS removes <script> tags from custom CSS. Removal repeats until nothing
+// matches, so tags split by an inner tag ("<scr<script></script>ipt>") are removed too.
+func SanitizeCSS(css string) string {
+	for {
+		sanitized := scriptTagRegex.ReplaceAllString(css, "")
+		if sanitized == css {
+			return sanitized
+		}
+		css = sanitized
+	}
+}
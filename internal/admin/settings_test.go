@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeCSS(t *testing.T) {
+	tests := []struct {
+		name string
+		css  string
+		want string
+	}{
+		{name: "plain css", css: "body { color: #333; }", want: "body { color: #333; }"},
+		{name: "script element", css: "a{}<script>alert(1)</script>b{}", want: "a{}b{}"},
+		{name: "attributes and case", css: `a{}<SCRIPT src="https://evil.example.com/x.js"></Script >`, want: "a{}"},
+		{name: "multiline", css: "a{}<script>\nalert(1)\n</script>", want: "a{}"},
+		{name: "unclosed", css: "a{}<script>alert(1)", want: "a{}alert(1)"},
+		{name: "stray closing tag", css: "a{}</script>", want: "a{}"},
+		{name: "nested", css: "<scr<script></script>ipt>alert(1)</script>", want: "alert(1)"},
+		{name: "similar tag kept", css: "<scripts>", want: "<scripts>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SanitizeCSS(tt.css))
+		})
+	}
+}
+
+func TestUpdateSettingsInput_Validate(t *testing.T) {
+	str := func(s string) *string { return &s }
+	intPtr := func(i int) *int { return &i }
+
+	tests := []struct {
+		name    string
+		input   UpdateSettingsInput
+		wantErr bool
+	}{
+		{name: "empty", input: UpdateSettingsInput{}},
+		{name: "all set", input: UpdateSettingsInput{
+			StatusPageTitle: str("Acme Status"),
+			LogoURL:         str("https://cdn.example.com/logo.png"),
+			FaviconURL:      str("http://cdn.example.com/favicon.ico"),
+			CustomCSS:       str("body { color: #333; }"),
+		}},
+		{name: "cleared", input: UpdateSettingsInput{StatusPageTitle: str(""), LogoURL: str(""), FaviconURL: str("")}},
+		{name: "title at limit", input: UpdateSettingsInput{StatusPageTitle: str(strings.Repeat("я", MaxTitleLength))}},
+		{name: "title too long", input: UpdateSettingsInput{StatusPageTitle: str(strings.Repeat("a", MaxTitleLength+1))}, wantErr: true},
+		{name: "relative logo url", input: UpdateSettingsInput{LogoURL: str("/logo.png")}, wantErr: true},
+		{name: "javascript favicon url", input: UpdateSettingsInput{FaviconURL: str("javascript:alert(1)")}, wantErr: true},
+		{name: "channel limit", input: UpdateSettingsInput{MaxChannelsPerUser: intPtr(1)}},
+		{name: "zero channel limit", input: UpdateSettingsInput{MaxChannelsPerUser: intPtr(0)}, wantErr: true},
+		{name: "css too large", input: UpdateSettingsInput{CustomCSS: str(strings.Repeat("a", MaxCustomCSSSize+1))}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidSettings), "got %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -11,25 +11,47 @@ import (
 	"sync"
 	"time"
 
+	"github.com/bissquit/incident-garden/internal/admin"
+	adminpostgres "github.com/bissquit/incident-garden/internal/admin/postgres"
 	"github.com/bissquit/incident-garden/internal/catalog"
 	catalogpostgres "github.com/bissquit/incident-garden/internal/catalog/postgres"
 	"github.com/bissquit/incident-garden/internal/config"
+	"github.com/bissquit/incident-garden/internal/dashboard"
 	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/embed"
+	embedpostgres "github.com/bissquit/incident-garden/internal/embed/postgres"
 	"github.com/bissquit/incident-garden/internal/events"
 	eventspostgres "github.com/bissquit/incident-garden/internal/events/postgres"
+	"github.com/bissquit/incident-garden/internal/feed"
+	"github.com/bissquit/incident-garden/internal/graphql"
+	"github.com/bissquit/incident-garden/internal/healthcheck"
+	healthcheckpostgres "github.com/bissquit/incident-garden/internal/healthcheck/postgres"
+	"github.com/bissquit/incident-garden/internal/idempotency"
+	idempotencypostgres "github.com/bissquit/incident-garden/internal/idempotency/postgres"
 	"github.com/bissquit/incident-garden/internal/identity"
 	"github.com/bissquit/incident-garden/internal/identity/jwt"
+	"github.com/bissquit/incident-garden/internal/identity/oidc"
 	identitypostgres "github.com/bissquit/incident-garden/internal/identity/postgres"
 	"github.com/bissquit/incident-garden/internal/notifications"
 	"github.com/bissquit/incident-garden/internal/notifications/email"
 	"github.com/bissquit/incident-garden/internal/notifications/mattermost"
 	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/bissquit/incident-garden/internal/notifications/slack"
 	"github.com/bissquit/incident-garden/internal/notifications/telegram"
+	"github.com/bissquit/incident-garden/internal/notifications/webhook"
 	"github.com/bissquit/incident-garden/internal/pkg/ctxlog"
 	"github.com/bissquit/incident-garden/internal/pkg/httputil"
 	"github.com/bissquit/incident-garden/internal/pkg/metrics"
 	"github.com/bissquit/incident-garden/internal/pkg/postgres"
+	"github.com/bissquit/incident-garden/internal/pkg/tracing"
+	"github.com/bissquit/incident-garden/internal/sse"
 	"github.com/bissquit/incident-garden/internal/version"
+	"github.com/bissquit/incident-garden/internal/webhooks"
+	"github.com/bissquit/incident-garden/internal/webhooks/opsgenie"
+	"github.com/bissquit/incident-garden/internal/webhooks/pagerduty"
+	webhookspostgres "github.com/bissquit/incident-garden/internal/webhooks/postgres"
+	"github.com/bissquit/incident-garden/internal/webhooks/prometheus"
+	slackcommand "github.com/bissquit/incident-garden/internal/webhooks/slack"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -38,18 +60,54 @@ import (
 
 // App represents the application instance.
 type App struct {
-	config             *config.Config
-	logger             *slog.Logger
-	db                 *pgxpool.Pool
-	server             *http.Server
-	metricsServer      *http.Server
-	metricsCancel      context.CancelFunc
-	notificationWorker *notifications.Worker
+	config              *config.Config
+	logger              *slog.Logger
+	db                  *pgxpool.Pool
+	server              *http.Server
+	metricsServer       *http.Server
+	metricsCancel       context.CancelFunc
+	tracingShutdown     func(context.Context) error
+	notificationWorker  *notifications.Worker
+	reminderScheduler   *notifications.ReminderScheduler
+	telegramBot         *telegram.Bot
+	slaChecker          *catalog.SLAChecker
+	escalationChecker   *events.EscalationChecker
+	recurrenceScheduler *events.RecurrenceScheduler
+	healthChecker       *healthcheck.HealthChecker
+	idempotencyEvictor  *idempotency.Evictor
+	broadcaster         *sse.Broadcaster
+	eventWatcher        *events.Watcher
+	buildVersion        string
+	iconValidator       catalog.IconValidator
+}
+
+// Option configures an App.
+type Option func(*App)
+
+// WithBuildVersion sets the version reported by /healthz, e.g. a git SHA
+// embedded via -ldflags. Empty keeps version.Version.
+func WithBuildVersion(v string) Option {
+	return func(a *App) {
+		if v != "" {
+			a.buildVersion = v
+		}
+	}
+}
+
+// WithIconValidator replaces the validator of service icon URLs, e.g. with one that
+// allows the loopback icon servers of tests. Nil keeps the default.
+func WithIconValidator(v catalog.IconValidator) Option {
+	return func(a *App) {
+		if v != nil {
+			a.iconValidator = v
+		}
+	}
 }
 
 // New creates a new application instance.
-func New(cfg *config.Config) (*App, error) {
+func New(cfg *config.Config, opts ...Option) (*App, error) {
 	logger := initLogger(cfg.Log)
+	slog.SetDefault(logger)
 
 	connectCtx, connectCancel := context.WithTimeout(context.Background(), cfg.Database.ConnectTimeout)
 	defer connectCancel()
@@ -65,13 +123,31 @@ func New(cfg *config.Config) (*App, error) {
 		return nil, fmt.Errorf("connect to database: %w", err)
 	}
 
+	tracingShutdown, err := tracing.Setup(context.Background(), tracing.Config{
+		Endpoint:    cfg.Tracing.Endpoint,
+		ServiceName: cfg.Tracing.ServiceName,
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("setup tracing: %w", err)
+	}
+	if cfg.Tracing.Endpoint != "" {
+		logger.Info("tracing configured", "endpoint", cfg.Tracing.Endpoint, "service_name", cfg.Tracing.ServiceName)
+	}
+
 	metricsCtx, metricsCancel := context.WithCancel(context.Background())
 
 	app := &App{
-		config:        cfg,
-		logger:        logger,
-		db:            db,
-		metricsCancel: metricsCancel,
+		config:          cfg,
+		logger:          logger,
+		db:              db,
+		metricsCancel:   metricsCancel,
+		tracingShutdown: tracingShutdown,
+		buildVersion:    version.Version,
+		iconValidator:   catalog.NewHTTPIconValidator(catalog.DefaultIconCheckTimeout),
+	}
+	for _, opt := range opts {
+		opt(app)
 	}
 
 	go app.collectDBMetrics(metricsCtx)
@@ -80,6 +156,7 @@ func New(cfg *config.Config) (*App, error) {
 	if err != nil {
 		db.Close()
 		metricsCancel()
+		_ = tracingShutdown(context.Background())
 		return nil, fmt.Errorf("setup router: %w", err)
 	}
 
@@ -146,6 +223,35 @@ func (a *App) Shutdown(ctx context.Context) error {
 	if a.notificationWorker != nil {
 		a.notificationWorker.Stop()
 	}
+	if a.telegramBot != nil {
+		a.telegramBot.Stop()
+	}
+	if a.reminderScheduler != nil {
+		a.reminderScheduler.Stop()
+	}
+	if a.slaChecker != nil {
+		a.slaChecker.Stop()
+	}
+	if a.escalationChecker != nil {
+		a.escalationChecker.Stop()
+	}
+	if a.recurrenceScheduler != nil {
+		a.recurrenceScheduler.Stop()
+	}
+	if a.healthChecker != nil {
+		a.healthChecker.Stop()
+	}
+	if a.idempotencyEvictor != nil {
+		a.idempotencyEvictor.Stop()
+	}
+
+	// Close live status streams, otherwise server.Shutdown waits for them until ctx expires
+	if a.broadcaster != nil {
+		a.broadcaster.Close()
+	}
+	if a.eventWatcher != nil {
+		a.eventWatcher.Close()
+	}
 
 	// Shutdown both servers in parallel
 	var wg sync.WaitGroup
@@ -174,14 +280,27 @@ func (a *App) Shutdown(ctx context.Context) error {
 
 	wg.Wait()
 
+	// Flush spans of the last requests
+	if err := a.tracingShutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("shutdown tracing: %w", err))
+	}
+
 	a.db.Close()
 
 	return errors.Join(errs...)
 }
 
 func (a *App) collectDBMetrics(ctx context.Context) {
+	collector := metrics.NewCollector(a.db)
+	collect := func() {
+		metrics.RecordDBPoolMetrics(a.db)
+		if err := collector.Collect(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "failed to collect metrics", "error", err)
+		}
+	}
+
 	// Collect immediately on start
-	metrics.RecordDBPoolMetrics(a.db)
+	collect()
 
 	ticker := time.NewTicker(15 * time.Second)
 	defer ticker.Stop()
@@ -189,7 +308,7 @@ func (a *App) collectDBMetrics(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			metrics.RecordDBPoolMetrics(a.db)
+			collect()
 		case <-ctx.Done():
 			return
 		}
@@ -205,7 +324,7 @@ func (a *App) collectQueueMetrics(ctx context.Context, repo notifications.Reposi
 		case <-ticker.C:
 			stats, err := repo.GetQueueStats(ctx)
 			if err != nil {
-				slog.Error("failed to get queue stats", "error", err)
+				slog.ErrorContext(ctx, "failed to get queue stats", "error", err)
 				continue
 			}
 			notifications.RecordQueueStats(stats)
@@ -226,6 +345,12 @@ func (a *App) NotificationWorker() *notifications.Worker {
 	return a.notificationWorker
 }
 
+// Broadcaster returns the live status broadcaster.
+// Used in tests to inspect connected SSE clients.
+func (a *App) Broadcaster() *sse.Broadcaster {
+	return a.broadcaster
+}
+
 // identityEmailAdapter wraps email.Sender to implement identity.EmailSender.
 // Breaks circular dependency: identity module needs to send emails but cannot
 // import notifications module (which already depends on identity).
@@ -244,16 +369,20 @@ func (a *identityEmailAdapter) SendEmail(ctx context.Context, to, subject, body
 func (a *App) setupRouter(ctx context.Context) (*chi.Mux, *notifications.Worker, error) {
 	r := chi.NewRouter()
 
-	// Metrics middleware must be first to measure full request time
+	// Tracing and metrics middleware must be first to measure full request time
+	r.Use(tracing.Middleware(append([]string{"/healthz", "/readyz"}, streamPaths()...)...))
 	r.Use(httputil.MetricsMiddleware)
 
+	// Before CORS so that preflight responses carry X-Request-ID too
+	r.Use(httputil.RequestIDMiddleware)
 	// CORS must be early to handle preflight requests before other middleware
 	r.Use(httputil.CORSMiddleware(a.config.CORS.AllowedOrigins))
-	r.Use(middleware.RequestID)
 	r.Use(httputil.RequestLoggerMiddleware(a.logger))
+	// Keeps the TCP peer for the admin IP allowlist before RealIP rewrites RemoteAddr
+	r.Use(httputil.PeerAddrMiddleware)
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(skipPaths(middleware.Timeout(60*time.Second), streamPaths()...))
 
 	r.Get("/healthz", a.healthzHandler)
 	r.Get("/readyz", a.readyzHandler)
@@ -287,8 +416,19 @@ func (a *App) setupRouter(ctx context.Context) (*chi.Mux, *notifications.Worker,
 </html>`))
 	})
 
-	catalogRepo := catalogpostgres.NewRepository(a.db)
-	catalogService := catalog.NewService(catalogRepo)
+	catalogRepo := catalogpostgres.NewTracedRepository(catalogpostgres.NewRepository(a.db))
+	catalogService := catalog.NewService(catalogRepo, a.iconValidator)
+	dependencyService := catalog.NewDependencyService(catalogpostgres.NewDependencyRepository(a.db), catalogService)
+
+	// Live status updates (SSE); handlers publish after committing changes
+	a.broadcaster = sse.NewBroadcaster(sse.Config{}, catalogService)
+	for _, prefix := range apiPrefixes {
+		r.Get(prefix+statusStreamRoute, a.broadcaster.ServeHTTP)
+	}
+
+	// Admin settings (status page branding, per-user limits) and the admin audit log
+	adminService := admin.NewService(adminpostgres.NewSettingsRepository(a.db))
+	auditLogger := admin.NewAuditLogger(adminpostgres.NewAuditRepository(a.db))
 
 	// Setup notifications first (needed for identity hook)
 	notificationsRepo := notificationspostgres.NewRepository(a.db)
@@ -298,7 +438,7 @@ func (a *App) setupRouter(ctx context.Context) (*chi.Mux, *notifications.Worker,
 	var notificationWorker *notifications.Worker
 	var identityEmailSender identity.EmailSender
 
-	slog.Info("notifications configured",
+	slog.InfoContext(ctx, "notifications configured",
 		"enabled", a.config.Notifications.Enabled,
 		"email_enabled", a.config.Notifications.Email.Enabled,
 		"telegram_enabled", a.config.Notifications.Telegram.Enabled,
@@ -319,7 +459,7 @@ func (a *App) setupRouter(ctx context.Context) (*chi.Mux, *notifications.Worker,
 		}
 
 		if !a.config.Notifications.Email.Enabled {
-			slog.Warn("email sender is disabled: email notifications and verification codes will not be sent")
+			slog.WarnContext(ctx, "email sender is disabled: email notifications and verification codes will not be sent")
 		}
 
 		// Setup identity email adapter for password reset
@@ -338,13 +478,19 @@ func (a *App) setupRouter(ctx context.Context) (*chi.Mux, *notifications.Worker,
 		}
 
 		if !a.config.Notifications.Telegram.Enabled {
-			slog.Warn("telegram sender is disabled: telegram notifications will not be sent")
+			slog.WarnContext(ctx, "telegram sender is disabled: telegram notifications will not be sent")
 		}
 
-		// Mattermost is always available (webhook URL is set per-channel by user)
+		// Mattermost, Slack and webhook are always available (URL is set per-channel by user)
 		mattermostSender := mattermost.NewSender(mattermost.Config{})
+		slackSender := slack.NewSender(slack.Config{})
+		webhookSender := webhook.NewSender(webhook.Config{
+			Timeout:        a.config.Notifications.Webhook.Timeout,
+			MaxAttempts:    a.config.Notifications.Webhook.MaxAttempts,
+			InitialBackoff: a.config.Notifications.Webhook.InitialBackoff,
+		})
 
-		dispatcher := notifications.NewDispatcher(notificationsRepo, emailSender, telegramSender, mattermostSender)
+		dispatcher := notifications.NewDispatcher(notificationsRepo, emailSender, telegramSender, mattermostSender, slackSender, webhookSender)
 
 		renderer, err := notifications.NewRenderer()
 		if err != nil {
@@ -355,7 +501,7 @@ func (a *App) setupRouter(ctx context.Context) (*chi.Mux, *notifications.Worker,
 			MaxAttempts: a.config.Notifications.Retry.MaxAttempts,
 		}
 
-		notifier = notifications.NewNotifierWithConfig(
+		eventNotifier := notifications.NewNotifierWithConfig(
 			notificationsRepo,
 			renderer,
 			dispatcher,
@@ -363,6 +509,7 @@ func (a *App) setupRouter(ctx context.Context) (*chi.Mux, *notifications.Worker,
 			a.config.Notifications.BaseURL,
 			notifierConfig,
 		)
+		notifier = eventNotifier
 
 		// Create and start notification worker
 		workerConfig := notifications.WorkerConfig{
@@ -373,11 +520,25 @@ func (a *App) setupRouter(ctx context.Context) (*chi.Mux, *notifications.Worker,
 			MaxBackoff:        a.config.Notifications.Retry.MaxBackoff,
 			BackoffMultiplier: a.config.Notifications.Retry.BackoffMultiplier,
 			NumWorkers:        a.config.Notifications.Worker.NumWorkers,
+			ShutdownTimeout:   a.config.Notifications.Worker.ShutdownTimeout,
 		}
 
 		notificationWorker = notifications.NewWorker(workerConfig, notificationsRepo, dispatcher, renderer)
 		notificationWorker.Start(ctx)
 
+		// Remind subscribers about scheduled maintenance that starts soon
+		a.reminderScheduler = notifications.NewReminderScheduler(
+			a.config.Notifications.Reminder.Window,
+			a.config.Notifications.Reminder.PollInterval,
+			notificationsRepo, eventNotifier)
+		a.reminderScheduler.Start(ctx)
+
+		// Alert subscribers of services whose monthly uptime falls below the SLA target
+		a.slaChecker = catalog.NewSLAChecker(catalog.SLAConfig{
+			PollInterval: a.config.Notifications.SLA.PollInterval,
+		}, catalogService, eventNotifier)
+		a.slaChecker.Start(ctx)
+
 		// Start queue metrics collection
 		go a.collectQueueMetrics(ctx, notificationsRepo)
 
@@ -388,6 +549,16 @@ func (a *App) setupRouter(ctx context.Context) (*chi.Mux, *notifications.Worker,
 		}
 
 		notificationsService = notifications.NewService(notificationsRepo, dispatcher, catalogService, channelConfig)
+
+		// Answer bot commands of chats with a verified Telegram channel (one replica only)
+		if a.config.Notifications.Telegram.Enabled && a.config.Notifications.Telegram.BotEnabled {
+			a.telegramBot = telegram.NewBot(telegram.BotConfig{
+				BotToken:    a.config.Notifications.Telegram.BotToken,
+				APIUrl:      a.config.Notifications.Telegram.APIUrl,
+				PollTimeout: a.config.Notifications.Telegram.PollTimeout,
+			}, notificationsService, catalogService, telegramSender)
+			a.telegramBot.Start(ctx)
+		}
 	} else {
 		// Notifications disabled - create service with nil dispatcher
 		channelConfig := &notifications.ChannelConfig{
@@ -398,7 +569,7 @@ func (a *App) setupRouter(ctx context.Context) (*chi.Mux, *notifications.Worker,
 
 		notificationsService = notifications.NewService(notificationsRepo, nil, catalogService, channelConfig)
 	}
-	notificationsHandler = notifications.NewHandler(notificationsService)
+	notificationsHandler = notifications.NewHandler(notificationsService, adminService)
 
 	// Setup identity with notifications hook
 	identityRepo := identitypostgres.NewRepository(a.db)
@@ -408,74 +579,344 @@ func (a *App) setupRouter(ctx context.Context) (*chi.Mux, *notifications.Worker,
 		RefreshTokenDuration: a.config.JWT.RefreshTokenDuration,
 	}, identityRepo)
 	identityService := identity.NewService(identityRepo, jwtAuth, notificationsService, identityEmailSender, a.config.App.FrontendURL)
+	var oidcProvider identity.OIDCProvider
+	if a.config.OIDC.Issuer != "" {
+		oidcProvider = oidc.NewProvider(oidc.Config{
+			Issuer:       a.config.OIDC.Issuer,
+			ClientID:     a.config.OIDC.ClientID,
+			ClientSecret: a.config.OIDC.ClientSecret,
+			RedirectURL:  a.config.OIDC.RedirectURL,
+		})
+	}
+	slog.InfoContext(ctx, "oidc login configured", "enabled", oidcProvider != nil, "issuer", a.config.OIDC.Issuer)
 	identityHandler := identity.NewHandler(identityService, identity.CookieSettings{
 		Secure:               a.config.Cookie.Secure,
 		Domain:               a.config.Cookie.Domain,
 		AccessTokenDuration:  a.config.JWT.AccessTokenDuration,
 		RefreshTokenDuration: a.config.JWT.RefreshTokenDuration,
-	})
+	}, oidcProvider, auditLogger)
 
 	// Setup events with notifier
-	eventsRepo := eventspostgres.NewRepository(a.db)
-	eventsService := events.NewService(eventsRepo, catalogService, catalogService, notifier)
-	eventsHandler := events.NewHandler(eventsService)
+	eventsRepo := eventspostgres.NewTracedRepository(eventspostgres.NewRepository(a.db))
+	eventsService := events.NewService(eventsRepo, catalogService, catalogService, notifier, identityRepo, dependencyService)
+
+	// Watch stream of event changes, fed by LISTEN/NOTIFY
+	a.eventWatcher = events.NewWatcher(events.WatcherConfig{}, eventspostgres.NewListener(a.db), eventsService)
+	a.eventWatcher.Start(ctx)
+	for _, prefix := range apiPrefixes {
+		r.Get(prefix+watchEventsRoute, a.eventWatcher.ServeHTTP)
+	}
 
-	catalogHandler := catalog.NewHandler(catalogService, eventsService)
+	if a.config.Escalation.Enabled {
+		a.escalationChecker = events.NewEscalationChecker(events.EscalationConfig{
+			Thresholds: map[domain.Severity]time.Duration{
+				domain.SeverityMinor: a.config.Escalation.MinorAfter,
+				domain.SeverityMajor: a.config.Escalation.MajorAfter,
+			},
+			PollInterval: a.config.Escalation.PollInterval,
+		}, eventsRepo, eventsService)
+		a.escalationChecker.Start(ctx)
+	}
 
-	r.Route("/api/v1", func(r chi.Router) {
+	a.recurrenceScheduler = events.NewRecurrenceScheduler(events.RecurrenceConfig{
+		LeadTime:     a.config.Recurrence.LeadTime,
+		PollInterval: a.config.Recurrence.PollInterval,
+	}, eventsRepo, eventsService)
+	a.recurrenceScheduler.Start(ctx)
+
+	// Poll health check URLs of services; failing services get a synthetic incident
+	healthCheckConfig := healthcheck.DefaultConfig()
+	healthCheckConfig.Timeout = a.config.HealthCheck.Timeout
+	healthCheckConfig.PollInterval = a.config.HealthCheck.PollInterval
+	a.healthChecker = healthcheck.NewHealthChecker(healthCheckConfig, healthcheckpostgres.NewRepository(a.db), eventsService)
+	a.healthChecker.Start(ctx)
+
+	// Retried event updates with the same Idempotency-Key replay the stored response
+	idempotencyStore := idempotencypostgres.NewStore(a.db)
+	a.idempotencyEvictor = idempotency.NewEvictor(idempotencyStore, a.config.Idempotency.EvictInterval)
+	a.idempotencyEvictor.Start(ctx)
+
+	// Global admin Slack alerts work independently of subscriber notifications
+	var adminAlerter events.AdminAlerter
+	if a.config.Notifications.SlackAdminWebhookURL != "" {
+		adminAlerter = notifications.NewAdminAlerter(a.config.Notifications.SlackAdminWebhookURL, a.config.Notifications.BaseURL)
+	}
+	adminHandler := admin.NewHandler(adminService, auditLogger)
+	eventsHandler := events.NewHandler(eventsService, a.broadcaster, adminAlerter, adminService, auditLogger)
+
+	catalogHandler := catalog.NewHandler(catalogService, dependencyService, eventsService, a.broadcaster, auditLogger)
+	feedHandler := feed.NewHandler(eventsService, catalogService, a.config.Notifications.BaseURL)
+	dashboardHandler := dashboard.NewHandler(eventsService, catalogService, notificationsService)
+	embedHandler := embed.NewHandler(embedpostgres.NewStore(a.db))
+
+	// Incoming alert webhooks; each source is enabled by configuring its secret or token
+	webhooksRepo := webhookspostgres.NewRepository(a.db)
+	webhooksService := webhooks.NewService(webhooksRepo, eventsService, catalogService)
+	// Authenticated webhook requests are recorded for the admin delivery log and replay
+	deliveryLog := webhooks.NewDeliveryLog(webhooksRepo)
+	deliveryHandler := webhooks.NewDeliveryHandler(deliveryLog)
+	var pagerDutyHandler *pagerduty.WebhookHandler
+	// Without PagerDuty there is no signing secret to rotate
+	pagerDutySecrets := webhooks.NewSigningSecrets(nil, nil)
+	if a.config.Webhooks.PagerDuty.Secret != "" {
+		pagerDutyHandler = pagerduty.NewWebhookHandler(webhooksService, pagerduty.Config{
+			Secret:         a.config.Webhooks.PagerDuty.Secret,
+			PreviousSecret: a.config.Webhooks.PagerDuty.SecretPrevious,
+			Rotations:      webhooksRepo,
+		}, a.broadcaster)
+		pagerDutySecrets = pagerDutyHandler.Secrets()
+	}
+	rotationHandler := webhooks.NewRotationHandler(pagerDutySecrets, pagerduty.Source, auditLogger)
+	var opsGenieHandler *opsgenie.WebhookHandler
+	if a.config.Webhooks.OpsGenie.Token != "" {
+		opsGenieHandler = opsgenie.NewWebhookHandler(webhooksService, opsgenie.Config{
+			Token:     a.config.Webhooks.OpsGenie.Token,
+			TagPrefix: a.config.Webhooks.OpsGenie.TagPrefix,
+		}, a.broadcaster)
+	}
+	var prometheusHandler *prometheus.WebhookHandler
+	if promConfig := a.config.Webhooks.Prometheus; promConfig.Token != "" {
+		severityMap := make(map[string]domain.ServiceStatus, len(promConfig.SeverityMap))
+		for severity, status := range promConfig.SeverityMap {
+			severityMap[severity] = domain.ServiceStatus(status)
+		}
+		prometheusHandler = prometheus.NewWebhookHandler(webhooksService, prometheus.Config{
+			Token:         promConfig.Token,
+			ServiceLabel:  promConfig.ServiceLabel,
+			SeverityLabel: promConfig.SeverityLabel,
+			SeverityMap:   severityMap,
+			DefaultStatus: domain.ServiceStatus(promConfig.DefaultStatus),
+		}, a.broadcaster)
+	}
+	var slackCommandHandler *slackcommand.SlashCommandHandler
+	if a.config.Webhooks.Slack.SigningSecret != "" {
+		slackCommandHandler = slackcommand.NewSlashCommandHandler(catalogService, eventsService, slackcommand.Config{
+			SigningSecret: a.config.Webhooks.Slack.SigningSecret,
+		})
+	}
+	pagerDutyRotation, err := pagerDutySecrets.RotationActive(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to check webhook secret rotation", "error", err)
+	}
+	slog.InfoContext(ctx, "webhooks configured",
+		"pagerduty_enabled", pagerDutyHandler != nil,
+		"pagerduty_secret_rotation", pagerDutyRotation,
+		"opsgenie_enabled", opsGenieHandler != nil,
+		"prometheus_enabled", prometheusHandler != nil,
+		"slack_command_enabled", slackCommandHandler != nil,
+	)
+
+	graphqlHandler, err := graphql.NewHandler(catalogService, eventsService, a.broadcaster)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create graphql handler: %w", err)
+	}
+
+	var rateLimiter *httputil.RateLimiter
+	if a.config.RateLimit.Enabled {
+		rateLimiter = httputil.NewRateLimiter(map[domain.Role]int{
+			domain.RoleAdmin:    a.config.RateLimit.AdminPerMinute,
+			domain.RoleOperator: a.config.RateLimit.OperatorPerMinute,
+			domain.RoleUser:     a.config.RateLimit.UserPerMinute,
+		})
+		go rateLimiter.Run(ctx, time.Minute)
+	}
+	slog.InfoContext(ctx, "rate limiting configured",
+		"enabled", a.config.RateLimit.Enabled,
+		"admin_per_minute", a.config.RateLimit.AdminPerMinute,
+		"operator_per_minute", a.config.RateLimit.OperatorPerMinute,
+		"user_per_minute", a.config.RateLimit.UserPerMinute,
+	)
+
+	var adminIPAllowlist func(http.Handler) http.Handler
+	if len(a.config.Admin.IPAllowlist) > 0 {
+		adminIPAllowlist, err = httputil.IPAllowlistMiddleware(a.config.Admin.IPAllowlist, a.config.Admin.TrustedProxies)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ADMIN_IP_ALLOWLIST: %w", err)
+		}
+	}
+	slog.InfoContext(ctx, "admin IP allowlist configured",
+		"enabled", adminIPAllowlist != nil,
+		"allowlist", a.config.Admin.IPAllowlist,
+		"trusted_proxies", a.config.Admin.TrustedProxies,
+	)
+
+	apiRoutes := func(r chi.Router) {
 		identityHandler.RegisterRoutes(r)
 
 		eventsHandler.RegisterPublicRoutes(r)
 		eventsHandler.RegisterPublicEventRoutes(r)
+		feedHandler.RegisterRoutes(r)
+		embedHandler.RegisterPublicRoutes(r)
 
 		r.Get("/notifications/config", notificationsHandler.GetNotificationsConfig)
+		notificationsHandler.RegisterPublicRoutes(r)
+
+		if pagerDutyHandler != nil {
+			pagerDutyHandler.RegisterRoutes(r, deliveryLog.Middleware(pagerduty.Source))
+		}
+		if opsGenieHandler != nil {
+			opsGenieHandler.RegisterRoutes(r, deliveryLog.Middleware(opsgenie.Source))
+		}
+		if prometheusHandler != nil {
+			prometheusHandler.RegisterRoutes(r, deliveryLog.Middleware(prometheus.Source))
+		}
+		if slackCommandHandler != nil {
+			slackCommandHandler.RegisterRoutes(r)
+		}
+
+		r.Group(func(r chi.Router) {
+			r.Use(httputil.OptionalAuthMiddleware(identityService))
+			// Mutations are writes too; anonymous requests are not limited
+			if rateLimiter != nil {
+				r.Use(rateLimiter.Middleware)
+			}
+			graphqlHandler.RegisterRoutes(r)
+		})
 
 		r.Group(func(r chi.Router) {
 			r.Use(httputil.AuthMiddleware(identityService))
+			if rateLimiter != nil {
+				r.Use(rateLimiter.Middleware)
+			}
 
 			identityHandler.RegisterProtectedRoutes(r)
 			notificationsHandler.RegisterRoutes(r)
 
 			r.Group(func(r chi.Router) {
 				r.Use(httputil.RequireRole(domain.RoleOperator))
-				eventsHandler.RegisterOperatorRoutes(r)
+				eventsHandler.RegisterOperatorRoutes(r, idempotency.Middleware(idempotencyStore, a.config.Idempotency.KeyTTL))
 				catalogHandler.RegisterOperatorRoutes(r)
+				notificationsHandler.RegisterOperatorRoutes(r)
+				dashboardHandler.RegisterOperatorRoutes(r)
 			})
 
 			r.Group(func(r chi.Router) {
 				r.Use(httputil.RequireRole(domain.RoleAdmin))
+				if adminIPAllowlist != nil {
+					r.Use(adminIPAllowlist)
+				}
 				catalogHandler.RegisterRoutes(r)
 				eventsHandler.RegisterAdminRoutes(r)
 				identityHandler.RegisterAdminRoutes(r)
+				notificationsHandler.RegisterAdminRoutes(r)
+				embedHandler.RegisterAdminRoutes(r)
+				adminHandler.RegisterAdminRoutes(r)
+				deliveryHandler.RegisterAdminRoutes(r)
+				rotationHandler.RegisterAdminRoutes(r)
 			})
 		})
 
 		r.Get("/services", catalogHandler.ListServices)
-		r.Get("/services/{slug}", catalogHandler.GetService)
+		r.With(httputil.ETagMiddleware).Get("/services/{slug}", catalogHandler.GetService)
 		r.Get("/groups", catalogHandler.ListGroups)
 		r.Get("/groups/{slug}", catalogHandler.GetGroup)
 
 		catalogHandler.RegisterPublicServiceRoutes(r)
+	}
+
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Use(httputil.VersionMiddleware(httputil.APIVersion1))
+		apiRoutes(r)
+	})
+	// v2 mirrors v1; handlers diverge on httputil.CurrentAPIVersion when needed
+	r.Route("/api/v2", func(r chi.Router) {
+		r.Use(httputil.VersionMiddleware(httputil.APIVersion2))
+		apiRoutes(r)
 	})
 
 	return r, notificationWorker, nil
 }
 
-func (a *App) healthzHandler(w http.ResponseWriter, _ *http.Request) {
-	httputil.Text(w, http.StatusOK, "OK")
+// apiPrefixes are the mounted API versions.
+var apiPrefixes = []string{"/api/v1", "/api/v2"}
+
+// statusStreamRoute is the long-lived SSE endpoint excluded from the request timeout.
+const statusStreamRoute = "/status/stream"
+
+// watchEventsRoute is the long-lived event watch stream excluded from the request timeout.
+const watchEventsRoute = "/watch/events"
+
+// streamPaths returns the stream endpoints of every API version.
+func streamPaths() []string {
+	paths := make([]string, 0, 2*len(apiPrefixes))
+	for _, prefix := range apiPrefixes {
+		paths = append(paths, prefix+statusStreamRoute, prefix+watchEventsRoute)
+	}
+	return paths
 }
 
-func (a *App) readyzHandler(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+// skipPaths applies middleware mw to all requests except the given paths.
+func skipPaths(mw func(http.Handler) http.Handler, paths ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		wrapped := mw(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, p := range paths {
+				if r.URL.Path == p {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			wrapped.ServeHTTP(w, r)
+		})
+	}
+}
+
+// healthResponse is the body of /healthz and /readyz.
+type healthResponse struct {
+	Status              string `json:"status"` // ok or degraded
+	DB                  string `json:"db"`     // ok or error
+	NotificationsWorker string `json:"notifications_worker,omitempty"`
+	Version             string `json:"version,omitempty"`
+}
+
+// healthCheckTimeout bounds the database ping of health checks.
+const healthCheckTimeout = 2 * time.Second
+
+// checkDB pings the database and returns "ok" or "error". The reason is only logged:
+// the health endpoints are public and driver errors name hosts and users.
+func (a *App) checkDB(ctx context.Context) string {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
 	defer cancel()
 
 	if err := a.db.Ping(ctx); err != nil {
-		ctxlog.FromContext(r.Context()).Error("readiness check failed", "error", err)
-		httputil.Text(w, http.StatusServiceUnavailable, "Database unavailable")
+		ctxlog.FromContext(ctx).Error("database health check failed", "error", err)
+		return "error"
+	}
+	return "ok"
+}
+
+func (a *App) healthzHandler(w http.ResponseWriter, r *http.Request) {
+	resp := healthResponse{Status: "ok", DB: a.checkDB(r.Context()), Version: a.buildVersion}
+	if resp.DB != "ok" {
+		resp.Status = "degraded"
+		httputil.JSON(w, http.StatusServiceUnavailable, resp)
 		return
 	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
 
-	httputil.Text(w, http.StatusOK, "OK")
+// readyzHandler additionally requires the notification worker to be running,
+// unless notifications are disabled.
+func (a *App) readyzHandler(w http.ResponseWriter, r *http.Request) {
+	resp := healthResponse{Status: "ok", DB: a.checkDB(r.Context())}
+
+	workerOK := true
+	switch {
+	case a.notificationWorker == nil:
+		resp.NotificationsWorker = "disabled"
+	case a.notificationWorker.Running():
+		resp.NotificationsWorker = "running"
+	default:
+		resp.NotificationsWorker = "stopped"
+		workerOK = false
+	}
+
+	if resp.DB != "ok" || !workerOK {
+		resp.Status = "degraded"
+		httputil.JSON(w, http.StatusServiceUnavailable, resp)
+		return
+	}
+	httputil.JSON(w, http.StatusOK, resp)
 }
 
 func (a *App) versionHandler(w http.ResponseWriter, _ *http.Request) {
@@ -486,6 +927,7 @@ func (a *App) versionHandler(w http.ResponseWriter, _ *http.Request) {
 	})
 }
 
+// initLogger builds the application logger. Records logged with a request context carry its request_id.
 func initLogger(cfg config.LogConfig) *slog.Logger {
 	var level slog.Level
 	switch cfg.Level {
@@ -510,5 +952,5 @@ func initLogger(cfg config.LogConfig) *slog.Logger {
 		handler = slog.NewTextHandler(os.Stdout, opts)
 	}
 
-	return slog.New(handler)
+	return slog.New(ctxlog.NewHandler(handler))
 }
@@ -0,0 +1,308 @@
+package catalog
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// MaxImportRows is the maximum number of services in one CSV import.
+const MaxImportRows = 500
+
+// CSV import errors.
+var (
+	ErrInvalidCSV        = errors.New("invalid csv")
+	ErrTooManyImportRows = fmt.Errorf("csv must contain at most %d rows", MaxImportRows)
+)
+
+// serviceCSVColumns are the columns of a service import file; name and slug are required.
+var serviceCSVColumns = []string{"name", "slug", "description", "group_slugs", "tags"}
+
+// ServiceCSVRow is a service read from an import file. Row is the line number
+// in the file, the header being row 1. An empty Slug is generated from the name.
+// GroupSlugs and Tags are separated by semicolons, tags in key=value form:
+// "team=payments;tier=1".
+type ServiceCSVRow struct {
+	Row         int
+	Name        string
+	Slug        string
+	Description string
+	GroupSlugs  []string
+	Tags        string
+}
+
+// ParseServiceCSV reads services from a CSV file with a header row. Columns are
+// matched by name in any order; unknown columns are an error. Row values are
+// not validated here, see Service.ImportServices.
+func ParseServiceCSV(r io.Reader) ([]ServiceCSVRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidCSV)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCSV, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !containsString(serviceCSVColumns, name) {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidCSV, name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("%w: duplicate column %q", ErrInvalidCSV, name)
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"name", "slug"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidCSV, required)
+		}
+	}
+
+	var rows []ServiceCSVRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCSV, err)
+		}
+		if len(rows) == MaxImportRows {
+			return nil, ErrTooManyImportRows
+		}
+
+		line, _ := reader.FieldPos(0)
+		value := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		rows = append(rows, ServiceCSVRow{
+			Row:         line,
+			Name:        value("name"),
+			Slug:        value("slug"),
+			Description: value("description"),
+			GroupSlugs:  splitList(value("group_slugs")),
+			Tags:        value("tags"),
+		})
+	}
+	return rows, nil
+}
+
+// ImportError describes why a row of an import file was skipped.
+type ImportError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportResult summarizes a service import.
+type ImportResult struct {
+	Created int           `json:"created"`
+	Skipped int           `json:"skipped"`
+	Errors  []ImportError `json:"errors"`
+}
+
+// ImportServices creates the valid rows as operational services placed after
+// the existing ones, with their groups and tags, in a single transaction.
+// Invalid rows are skipped and reported: missing name, invalid or duplicate
+// slug (existing, repeated in the file or created concurrently), unknown group
+// slug, malformed tags.
+func (s *Service) ImportServices(ctx context.Context, rows []ServiceCSVRow, importedBy string) (*ImportResult, error) {
+	existing, err := s.repo.ListServices(ctx, ServiceFilter{IncludeArchived: true})
+	if err != nil {
+		return nil, fmt.Errorf("list services: %w", err)
+	}
+	slugs := make(map[string]bool, len(existing)+len(rows))
+	for _, svc := range existing {
+		slugs[svc.Slug] = true
+	}
+
+	groups, err := s.repo.ListGroups(ctx, GroupFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("list groups: %w", err)
+	}
+	groupIDs := make(map[string]string, len(groups))
+	for _, g := range groups {
+		groupIDs[g.Slug] = g.ID
+	}
+
+	result := &ImportResult{Errors: []ImportError{}}
+	type serviceWithTags struct {
+		row     int
+		service *domain.Service
+		tags    []domain.ServiceTag
+	}
+	var valid []serviceWithTags
+	for _, row := range rows {
+		service, tags, err := importRow(row, slugs, groupIDs)
+		if err != nil {
+			result.Errors = append(result.Errors, ImportError{Row: row.Row, Message: err.Error()})
+			continue
+		}
+		slugs[service.Slug] = true
+		valid = append(valid, serviceWithTags{row: row.Row, service: service, tags: tags})
+	}
+	if len(valid) == 0 {
+		result.Skipped = len(result.Errors)
+		return result, nil
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	maxOrder, err := s.repo.GetMaxServiceOrderTx(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range valid {
+		v.service.Order = maxOrder + 1 + result.Created
+		err := s.importServiceTx(ctx, tx, v.service, v.tags, importedBy)
+		// Taken by a service created since the slugs were listed
+		if errors.Is(err, ErrSlugExists) {
+			result.Errors = append(result.Errors, ImportError{Row: v.row, Message: "duplicate slug"})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Created++
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	sort.SliceStable(result.Errors, func(i, j int) bool { return result.Errors[i].Row < result.Errors[j].Row })
+	result.Skipped = len(result.Errors)
+	return result, nil
+}
+
+// importServiceTx creates an imported service with its groups and tags in a savepoint
+// of tx, so that a slug conflict skips the row without aborting the import.
+func (s *Service) importServiceTx(ctx context.Context, tx pgx.Tx, service *domain.Service, tags []domain.ServiceTag, importedBy string) error {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin savepoint: %w", err)
+	}
+	defer func() {
+		_ = savepoint.Rollback(ctx)
+	}()
+
+	if err := s.repo.CreateServiceTx(ctx, savepoint, service); err != nil {
+		if errors.Is(err, ErrSlugExists) {
+			return err
+		}
+		return fmt.Errorf("create service %s: %w", service.Slug, err)
+	}
+	if len(service.GroupIDs) > 0 {
+		if err := s.repo.SetServiceGroupsTx(ctx, savepoint, service.ID, service.GroupIDs, importedBy); err != nil {
+			return fmt.Errorf("set service groups: %w", err)
+		}
+	}
+	if len(tags) > 0 {
+		if err := s.repo.SetServiceTagsTx(ctx, savepoint, service.ID, tags); err != nil {
+			return fmt.Errorf("set service tags: %w", err)
+		}
+	}
+
+	if err := savepoint.Commit(ctx); err != nil {
+		return fmt.Errorf("release savepoint: %w", err)
+	}
+	return nil
+}
+
+// importRow validates a row against the taken slugs and the group slug to ID map.
+// The returned error message is reported to the client as is.
+func importRow(row ServiceCSVRow, slugs map[string]bool, groupIDs map[string]string) (*domain.Service, []domain.ServiceTag, error) {
+	if row.Name == "" {
+		return nil, nil, errors.New("name is required")
+	}
+	if len(row.Name) > 255 {
+		return nil, nil, errors.New("name must be at most 255 characters")
+	}
+	slug := row.Slug
+	if slug == "" {
+		slug = domain.GenerateSlug(row.Name)
+	}
+	if len(slug) > 255 {
+		return nil, nil, errors.New("slug must be at most 255 characters")
+	}
+	if err := validateSlug(slug); err != nil {
+		return nil, nil, err
+	}
+	if slugs[slug] {
+		return nil, nil, errors.New("duplicate slug")
+	}
+
+	service := &domain.Service{
+		Name:        row.Name,
+		Slug:        slug,
+		Description: row.Description,
+		Status:      domain.ServiceStatusOperational,
+	}
+	for _, groupSlug := range row.GroupSlugs {
+		id, ok := groupIDs[groupSlug]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown group slug: %s", groupSlug)
+		}
+		if !containsString(service.GroupIDs, id) {
+			service.GroupIDs = append(service.GroupIDs, id)
+		}
+	}
+
+	var tags []domain.ServiceTag
+	seen := make(map[string]bool)
+	for _, pair := range splitList(row.Tags) {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || len(key) > 100 {
+			return nil, nil, fmt.Errorf("invalid tag: %s", pair)
+		}
+		if seen[key] {
+			return nil, nil, fmt.Errorf("duplicate tag: %s", key)
+		}
+		seen[key] = true
+		tags = append(tags, domain.ServiceTag{Key: key, Value: strings.TrimSpace(value)})
+	}
+
+	return service, tags, nil
+}
+
+// splitList splits a semicolon-separated value, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func containsString(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package catalog
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseServiceCSV(t *testing.T) {
+	input := "Slug,Name,group_slugs,tags,description\n" +
+		"api,API,backend; core,team=payments;tier=1,Public API\n" +
+		"\"web\",\"Web, site\",,,\n"
+
+	rows, err := ParseServiceCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseServiceCSV() error = %v", err)
+	}
+
+	want := []ServiceCSVRow{
+		{Row: 2, Name: "API", Slug: "api", Description: "Public API", GroupSlugs: []string{"backend", "core"}, Tags: "team=payments;tier=1"},
+		{Row: 3, Name: "Web, site", Slug: "web"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("ParseServiceCSV() = %+v, want %+v", rows, want)
+	}
+}
+
+func TestParseServiceCSV_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"empty", "", ErrInvalidCSV},
+		{"missing slug column", "name,description\nAPI,x\n", ErrInvalidCSV},
+		{"unknown column", "name,slug,owner\nAPI,api,me\n", ErrInvalidCSV},
+		{"duplicate column", "name,slug,name\nAPI,api,API\n", ErrInvalidCSV},
+		{"wrong field count", "name,slug\nAPI,api,extra\n", ErrInvalidCSV},
+		{"too many rows", "name,slug\n" + strings.Repeat("a,a\n", MaxImportRows+1), ErrTooManyImportRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseServiceCSV(strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseServiceCSV() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImportRow(t *testing.T) {
+	slugs := map[string]bool{"existing": true}
+	groupIDs := map[string]string{"backend": "group-1"}
+
+	tests := []struct {
+		name    string
+		row     ServiceCSVRow
+		wantErr string
+	}{
+		{"valid", ServiceCSVRow{Name: "API", Slug: "api", GroupSlugs: []string{"backend", "backend"}, Tags: "team=a; tier = 1"}, ""},
+		{"missing name", ServiceCSVRow{Slug: "api"}, "name is required"},
+		{"invalid slug", ServiceCSVRow{Name: "API", Slug: "Api"}, ErrInvalidSlug.Error()},
+		{"duplicate slug", ServiceCSVRow{Name: "API", Slug: "existing"}, "duplicate slug"},
+		{"unknown group", ServiceCSVRow{Name: "API", Slug: "api", GroupSlugs: []string{"frontend"}}, "unknown group slug: frontend"},
+		{"tag without value", ServiceCSVRow{Name: "API", Slug: "api", Tags: "team"}, "invalid tag: team"},
+		{"duplicate tag", ServiceCSVRow{Name: "API", Slug: "api", Tags: "team=a;team=b"}, "duplicate tag: team"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, tags, err := importRow(tt.row, slugs, groupIDs)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("importRow() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("importRow() error = %v", err)
+			}
+			if !reflect.DeepEqual(service.GroupIDs, []string{"group-1"}) {
+				t.Errorf("GroupIDs = %v, want [group-1]", service.GroupIDs)
+			}
+			if len(tags) != 2 || tags[1].Key != "tier" || tags[1].Value != "1" {
+				t.Errorf("tags = %+v", tags)
+			}
+		})
+	}
+}
+
+func TestImportRow_GeneratesSlug(t *testing.T) {
+	service, _, err := importRow(ServiceCSVRow{Name: "Payments API"}, map[string]bool{}, map[string]string{})
+	if err != nil {
+		t.Fatalf("importRow() error = %v", err)
+	}
+	if !strings.HasPrefix(service.Slug, "payments-api-") {
+		t.Errorf("Slug = %q, want payments-api-<suffix>", service.Slug)
+	}
+}
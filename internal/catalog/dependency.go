@@ -0,0 +1,93 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// Dependency errors.
+var (
+	ErrSelfDependency        = errors.New("service cannot depend on itself")
+	ErrDependencyNotFound    = errors.New("dependency service not found")
+	ErrDependencyCycle       = errors.New("dependency would create a cycle")
+	ErrInvalidDependencyType = errors.New("invalid dependency type: must be hard or soft")
+)
+
+// DependencyRepository stores dependencies between services.
+// This interface is implemented by postgres.DependencyRepository.
+type DependencyRepository interface {
+	// ReplaceDependencies replaces all dependencies of serviceID. Only ServiceID and Type of deps are used.
+	ReplaceDependencies(ctx context.Context, serviceID string, deps []domain.ServiceDependency) error
+	// ListDependencies returns the services serviceID depends on (upstream).
+	ListDependencies(ctx context.Context, serviceID string) ([]domain.ServiceDependency, error)
+	// ListDependents returns the services depending on serviceID (downstream).
+	ListDependents(ctx context.Context, serviceID string) ([]domain.ServiceDependency, error)
+	// HasDependencyPath reports whether fromID depends on toID, directly or transitively.
+	HasDependencyPath(ctx context.Context, fromID, toID string) (bool, error)
+}
+
+// DependencyService provides business logic for service dependencies.
+type DependencyService struct {
+	repo     DependencyRepository
+	services *Service
+}
+
+// NewDependencyService creates a new dependency service.
+func NewDependencyService(repo DependencyRepository, services *Service) *DependencyService {
+	return &DependencyService{repo: repo, services: services}
+}
+
+// SetDependencies replaces the upstream dependencies of a service.
+// Dependencies must exist, be unique and must not lead back to the service.
+func (s *DependencyService) SetDependencies(ctx context.Context, serviceID string, deps []domain.ServiceDependency) error {
+	ids := make([]string, 0, len(deps))
+	seen := make(map[string]bool, len(deps))
+	for _, dep := range deps {
+		if dep.ServiceID == serviceID {
+			return ErrSelfDependency
+		}
+		if !dep.Type.IsValid() {
+			return ErrInvalidDependencyType
+		}
+		if seen[dep.ServiceID] {
+			return fmt.Errorf("%w: %s", ErrDuplicateServiceID, dep.ServiceID)
+		}
+		seen[dep.ServiceID] = true
+		ids = append(ids, dep.ServiceID)
+	}
+
+	if len(ids) > 0 {
+		missing, err := s.services.ValidateServicesExist(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("validate services: %w", err)
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("%w: %s", ErrDependencyNotFound, missing[0])
+		}
+	}
+
+	for _, id := range ids {
+		cycle, err := s.repo.HasDependencyPath(ctx, id, serviceID)
+		if err != nil {
+			return fmt.Errorf("check dependency cycle: %w", err)
+		}
+		if cycle {
+			return fmt.Errorf("%w: %s already depends on the service", ErrDependencyCycle, id)
+		}
+	}
+
+	return s.repo.ReplaceDependencies(ctx, serviceID, deps)
+}
+
+// ListDependencies returns the services a service depends on.
+func (s *DependencyService) ListDependencies(ctx context.Context, serviceID string) ([]domain.ServiceDependency, error) {
+	return s.repo.ListDependencies(ctx, serviceID)
+}
+
+// ListDependents returns the services depending on a service.
+func (s *DependencyService) ListDependents(ctx context.Context, serviceID string) ([]domain.ServiceDependency, error) {
+	return s.repo.ListDependents(ctx, serviceID)
+}
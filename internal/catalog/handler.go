@@ -4,13 +4,19 @@ package catalog
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
+	"github.com/bissquit/incident-garden/internal/catalog/uptime"
 	"github.com/bissquit/incident-garden/internal/domain"
 	"github.com/bissquit/incident-garden/internal/events"
 	"github.com/bissquit/incident-garden/internal/pkg/ctxlog"
 	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/bissquit/incident-garden/internal/sse"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 )
@@ -20,6 +26,18 @@ type EventsServiceReader interface {
 	ListEventsByServiceID(ctx context.Context, serviceID string, filter events.ServiceEventFilter) ([]*domain.Event, int, error)
 }
 
+// AuditRecorder records admin actions in the audit log.
+// This interface is implemented by admin.AuditLogger.
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, details map[string]interface{})
+}
+
+// Audit log action and target type of bulk service archiving.
+const (
+	auditActionServicesBulkArchive = "services.bulk_archive"
+	auditTargetServices            = "services"
+)
+
 // Pagination constants.
 const (
 	DefaultStatusLogLimit = 50
@@ -33,25 +51,52 @@ var errorMappings = []httputil.ErrorMapping{
 	{Error: ErrGroupNotFound, Status: http.StatusNotFound},
 	{Error: ErrSlugExists, Status: http.StatusConflict},
 	{Error: ErrInvalidSlug, Status: http.StatusBadRequest},
+	{Error: ErrInvalidServiceURL, Status: http.StatusBadRequest},
+	{Error: ErrInvalidCustomField, Status: http.StatusBadRequest},
+	{Error: ErrInvalidHealthCheck, Status: http.StatusBadRequest},
+	{Error: ErrInvalidIcon, Status: http.StatusUnprocessableEntity},
 	{Error: ErrServiceHasActiveEvents, Status: http.StatusConflict},
 	{Error: ErrGroupHasActiveEvents, Status: http.StatusConflict},
+	{Error: ErrSlugChangeActiveEvents, Status: http.StatusConflict},
 	{Error: ErrGroupHasServices, Status: http.StatusConflict},
+	{Error: ErrGroupHasChildren, Status: http.StatusConflict},
+	{Error: ErrParentGroupNotFound, Status: http.StatusBadRequest},
+	{Error: ErrGroupCycle, Status: http.StatusConflict},
+	{Error: ErrGroupNestingDepth, Status: http.StatusConflict},
+	{Error: ErrParentGroupArchived, Status: http.StatusConflict},
 	{Error: ErrAlreadyArchived, Status: http.StatusConflict},
 	{Error: ErrNotArchived, Status: http.StatusConflict},
+	{Error: ErrDuplicateOrder, Status: http.StatusConflict},
+	{Error: ErrDuplicateServiceID, Status: http.StatusBadRequest},
+	{Error: ErrSelfDependency, Status: http.StatusBadRequest},
+	{Error: ErrDependencyNotFound, Status: http.StatusBadRequest},
+	{Error: ErrInvalidDependencyType, Status: http.StatusBadRequest},
+	{Error: ErrDependencyCycle, Status: http.StatusConflict},
+	{Error: ErrInvalidSLATarget, Status: http.StatusBadRequest},
+	{Error: ErrInvalidCSV, Status: http.StatusBadRequest},
+	{Error: ErrTooManyImportRows, Status: http.StatusBadRequest},
 }
 
 // Handler handles HTTP requests for the catalog module.
 type Handler struct {
 	service       *Service
+	dependencies  *DependencyService
 	eventsService EventsServiceReader
+	publisher     sse.Publisher
+	audit         AuditRecorder
 	validator     *validator.Validate
 }
 
 // NewHandler creates a new catalog handler.
-func NewHandler(service *Service, eventsService EventsServiceReader) *Handler {
+// publisher may be nil, in which case no live updates are pushed.
+// audit may be nil, in which case bulk archiving is not audited.
+func NewHandler(service *Service, dependencies *DependencyService, eventsService EventsServiceReader, publisher sse.Publisher, audit AuditRecorder) *Handler {
 	return &Handler{
 		service:       service,
+		dependencies:  dependencies,
 		eventsService: eventsService,
+		publisher:     publisher,
+		audit:         audit,
 		validator:     validator.New(),
 	}
 }
@@ -64,48 +109,66 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 		r.Get("/{slug}", h.GetGroup)
 		r.Patch("/{slug}", h.UpdateGroup)
 		r.Delete("/{slug}", h.DeleteGroup)
-		r.Post("/{slug}/restore", h.RestoreGroup)
 	})
 
 	r.Route("/services", func(r chi.Router) {
 		r.Get("/", h.ListServices)
 		r.Post("/", h.CreateService)
+		r.Post("/archive", h.BulkArchiveServices)
+		r.Put("/order", h.ReorderServices)
 		r.Get("/{slug}", h.GetService)
 		r.Patch("/{slug}", h.UpdateService)
 		r.Delete("/{slug}", h.DeleteService)
-		r.Post("/{slug}/restore", h.RestoreService)
 		r.Get("/{slug}/tags", h.GetServiceTags)
 		r.Put("/{slug}/tags", h.UpdateServiceTags)
+		r.Put("/{slug}/dependencies", h.UpdateServiceDependencies)
+		r.Put("/{slug}/sla", h.UpdateServiceSLA)
 	})
+
+	r.Post("/admin/services/import", h.ImportServices)
 }
 
 // RegisterOperatorRoutes registers routes that require operator role.
 func (h *Handler) RegisterOperatorRoutes(r chi.Router) {
 	r.Get("/services/{slug}/status-log", h.GetServiceStatusLog)
+	r.Get("/services/{slug}/group-history", h.GetServiceGroupHistory)
+	r.Post("/services/{slug}/restore", h.RestoreService)
+	r.Post("/groups/{slug}/restore", h.RestoreGroup)
 }
 
 // RegisterPublicServiceRoutes registers public routes for services.
 func (h *Handler) RegisterPublicServiceRoutes(r chi.Router) {
 	r.Get("/services/{slug}/events", h.GetServiceEvents)
+	r.Get("/services/{slug}/uptime", h.GetServiceUptime)
+	r.Get("/services/{slug}/timeline", h.GetServiceTimeline)
+	r.Get("/services/{slug}/sla", h.GetServiceSLA)
+	r.Get("/services/{slug}/dependencies", h.GetServiceDependencies)
+	r.Get("/services/{slug}/dependents", h.GetServiceDependents)
 }
 
 // CreateGroupRequest represents the request body for creating a service group.
 type CreateGroupRequest struct {
-	Name        string `json:"name" validate:"required,min=1,max=255"`
-	Slug        string `json:"slug" validate:"required,min=1,max=255"`
-	Description string `json:"description"`
-	Order       int    `json:"order"`
+	Name          string  `json:"name" validate:"required,min=1,max=255"`
+	Slug          string  `json:"slug" validate:"omitempty,max=255"` // empty generates it from the name
+	Description   string  `json:"description"`
+	Order         *int    `json:"order"`                                     // nil places the group last
+	ParentGroupID *string `json:"parent_group_id" validate:"omitempty,uuid"` // nil creates a top-level group
 }
 
 // ToDomain converts the request to a domain model.
 func (r *CreateGroupRequest) ToDomain() *domain.ServiceGroup {
-	return &domain.ServiceGroup{
-		Name:        r.Name,
-		Slug:        r.Slug,
-		Description: r.Description,
-		ServiceIDs:  make([]string, 0),
-		Order:       r.Order,
+	group := &domain.ServiceGroup{
+		Name:          r.Name,
+		Slug:          r.Slug,
+		Description:   r.Description,
+		ServiceIDs:    make([]string, 0),
+		ParentGroupID: r.ParentGroupID,
+		Children:      make([]domain.ServiceGroup, 0),
 	}
+	if r.Order != nil {
+		group.Order = *r.Order
+	}
+	return group
 }
 
 // UpdateGroupRequest represents the request body for updating a service group.
@@ -115,17 +178,26 @@ type UpdateGroupRequest struct {
 	Description string    `json:"description"`
 	Order       int       `json:"order"`
 	ServiceIDs  *[]string `json:"service_ids"`
+	// ParentGroupID: omitted keeps the parent, "" makes the group top-level.
+	ParentGroupID *string `json:"parent_group_id" validate:"omitempty,uuid|len=0"`
 }
 
 // CreateServiceRequest represents the request body for creating a service.
 type CreateServiceRequest struct {
-	Name        string            `json:"name" validate:"required,min=1,max=255"`
-	Slug        string            `json:"slug" validate:"required,min=1,max=255"`
-	Description string            `json:"description"`
-	Status      string            `json:"status" validate:"omitempty,oneof=operational degraded partial_outage major_outage maintenance"`
-	GroupIDs    []string          `json:"group_ids"`
-	Order       int               `json:"order"`
-	Tags        map[string]string `json:"tags"`
+	Name             string            `json:"name" validate:"required,min=1,max=255"`
+	Slug             string            `json:"slug" validate:"omitempty,max=255"` // empty generates it from the name
+	Description      string            `json:"description"`
+	Status           string            `json:"status" validate:"omitempty,oneof=operational degraded partial_outage major_outage maintenance"`
+	GroupIDs         []string          `json:"group_ids"`
+	Order            *int              `json:"order"` // nil places the service last
+	Tags             map[string]string `json:"tags"`
+	ExternalURL      string            `json:"external_url" validate:"max=2048"`
+	DocumentationURL string            `json:"documentation_url" validate:"max=2048"`
+	CustomFields     map[string]string `json:"custom_fields"`
+	IconURL          string            `json:"icon_url" validate:"max=2048"`
+	HealthCheckURL   string            `json:"health_check_url" validate:"max=2048"`
+	// HealthCheckIntervalSeconds enables polling of HealthCheckURL; 0 = disabled.
+	HealthCheckIntervalSeconds int `json:"health_check_interval_seconds" validate:"min=0"`
 }
 
 // ToDomain converts the request to a domain model.
@@ -140,25 +212,48 @@ func (r *CreateServiceRequest) ToDomain() *domain.Service {
 		groupIDs = make([]string, 0)
 	}
 
-	return &domain.Service{
-		Name:        r.Name,
-		Slug:        r.Slug,
-		Description: r.Description,
-		Status:      status,
-		GroupIDs:    groupIDs,
-		Order:       r.Order,
+	service := &domain.Service{
+		Name:             r.Name,
+		Slug:             r.Slug,
+		Description:      r.Description,
+		Status:           status,
+		GroupIDs:         groupIDs,
+		ExternalURL:      r.ExternalURL,
+		DocumentationURL: r.DocumentationURL,
+		CustomFields:     r.CustomFields,
+		IconURL:          r.IconURL,
+
+		HealthCheckURL:             r.HealthCheckURL,
+		HealthCheckIntervalSeconds: r.HealthCheckIntervalSeconds,
+	}
+	if r.Order != nil {
+		service.Order = *r.Order
 	}
+	return service
 }
 
 // UpdateServiceRequest represents the request body for updating a service.
 type UpdateServiceRequest struct {
-	Name        string   `json:"name" validate:"required,min=1,max=255"`
-	Slug        string   `json:"slug" validate:"required,min=1,max=255"`
-	Description string   `json:"description"`
-	Status      string   `json:"status" validate:"required,oneof=operational degraded partial_outage major_outage maintenance"`
-	GroupIDs    []string `json:"group_ids"`
-	Order       int      `json:"order"`
-	Reason      string   `json:"reason"` // Reason for status change (recorded in audit log)
+	Name             string            `json:"name" validate:"required,min=1,max=255"`
+	Slug             string            `json:"slug" validate:"required,min=1,max=255"`
+	Description      string            `json:"description"`
+	Status           string            `json:"status" validate:"required,oneof=operational degraded partial_outage major_outage maintenance"`
+	GroupIDs         []string          `json:"group_ids"`
+	Order            int               `json:"order"`
+	Reason           string            `json:"reason"`                                          // Reason for status change (recorded in audit log)
+	ExternalURL      *string           `json:"external_url" validate:"omitempty,max=2048"`      // nil keeps, "" clears
+	DocumentationURL *string           `json:"documentation_url" validate:"omitempty,max=2048"` // nil keeps, "" clears
+	CustomFields     map[string]string `json:"custom_fields"`                                   // nil keeps, {} clears
+	IconURL          *string           `json:"icon_url" validate:"omitempty,max=2048"`          // nil keeps, "" clears
+	HealthCheckURL   *string           `json:"health_check_url" validate:"omitempty,max=2048"`  // nil keeps, "" clears
+	// HealthCheckIntervalSeconds: nil keeps, 0 disables the check.
+	HealthCheckIntervalSeconds *int `json:"health_check_interval_seconds" validate:"omitempty,min=0"`
+}
+
+// ServiceDependencyRequest is a single dependency of UpdateServiceDependencies.
+type ServiceDependencyRequest struct {
+	ServiceID string                `json:"service_id" validate:"required,uuid"`
+	Type      domain.DependencyType `json:"type" validate:"required,oneof=hard soft"`
 }
 
 // UpdateServiceTagsRequest represents the request body for updating service tags.
@@ -166,6 +261,17 @@ type UpdateServiceTagsRequest struct {
 	Tags map[string]string `json:"tags" validate:"required"`
 }
 
+// BulkArchiveServicesRequest represents the request body for archiving services in bulk.
+type BulkArchiveServicesRequest struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=100,dive,uuid"`
+}
+
+// BulkArchiveServicesResponse represents the result of a bulk archive operation.
+type BulkArchiveServicesResponse struct {
+	Archived []string    `json:"archived"`
+	Failed   []BulkError `json:"failed"`
+}
+
 // CreateGroup handles POST /groups request.
 func (h *Handler) CreateGroup(w http.ResponseWriter, r *http.Request) {
 	var req CreateGroupRequest
@@ -180,7 +286,7 @@ func (h *Handler) CreateGroup(w http.ResponseWriter, r *http.Request) {
 	}
 
 	group := req.ToDomain()
-	if err := h.service.CreateGroup(r.Context(), group); err != nil {
+	if err := h.service.CreateGroup(r.Context(), group, req.Order == nil); err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
 	}
@@ -192,7 +298,7 @@ func (h *Handler) CreateGroup(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetGroup(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 
-	group, err := h.service.GetGroupBySlug(r.Context(), slug)
+	group, err := h.service.GetGroupBySlugWithEffectiveStatus(r.Context(), slug)
 	if err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
@@ -209,7 +315,7 @@ func (h *Handler) ListGroups(w http.ResponseWriter, r *http.Request) {
 		filter.IncludeArchived = true
 	}
 
-	groups, err := h.service.ListGroups(r.Context(), filter)
+	groups, err := h.service.ListGroupsWithEffectiveStatus(r.Context(), filter)
 	if err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
@@ -243,6 +349,12 @@ func (h *Handler) UpdateGroup(w http.ResponseWriter, r *http.Request) {
 	existing.Slug = req.Slug
 	existing.Description = req.Description
 	existing.Order = req.Order
+	if req.ParentGroupID != nil {
+		existing.ParentGroupID = req.ParentGroupID
+		if *req.ParentGroupID == "" {
+			existing.ParentGroupID = nil
+		}
+	}
 
 	if err := h.service.UpdateGroup(r.Context(), existing); err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
@@ -251,7 +363,7 @@ func (h *Handler) UpdateGroup(w http.ResponseWriter, r *http.Request) {
 
 	// Update service memberships if provided
 	if req.ServiceIDs != nil {
-		if err := h.service.UpdateGroupServices(r.Context(), existing.ID, *req.ServiceIDs); err != nil {
+		if err := h.service.UpdateGroupServices(r.Context(), existing.ID, *req.ServiceIDs, httputil.GetUserID(r.Context())); err != nil {
 			httputil.HandleError(r.Context(), w, err, errorMappings)
 			return
 		}
@@ -318,7 +430,7 @@ func (h *Handler) CreateService(w http.ResponseWriter, r *http.Request) {
 	}
 
 	service := req.ToDomain()
-	if err := h.service.CreateService(r.Context(), service); err != nil {
+	if err := h.service.CreateService(r.Context(), service, req.Order == nil, httputil.GetUserID(r.Context())); err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
 	}
@@ -337,7 +449,10 @@ func (h *Handler) CreateService(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httputil.Success(w, http.StatusCreated, result)
+	httputil.Success(w, http.StatusCreated, AdminServiceResponse{
+		ServiceWithEffectiveStatus: result,
+		HealthCheckURL:             result.HealthCheckURL,
+	})
 }
 
 // GetService handles GET /services/{slug} request.
@@ -345,11 +460,24 @@ func (h *Handler) GetService(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 
 	service, err := h.service.GetServiceBySlugWithEffectiveStatus(r.Context(), slug)
+	if errors.Is(err, ErrServiceNotFound) {
+		// The service may have been renamed
+		newSlug, redirectErr := h.service.FindServiceSlugRedirect(r.Context(), slug)
+		switch {
+		case redirectErr == nil:
+			w.Header().Set("Location", "/api/"+httputil.CurrentAPIVersion(r.Context())+"/services/"+url.PathEscape(newSlug))
+			w.WriteHeader(http.StatusMovedPermanently)
+			return
+		case !errors.Is(redirectErr, ErrServiceNotFound):
+			err = redirectErr
+		}
+	}
 	if err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
 	}
 
+	httputil.SetLastModified(w, service.UpdatedAt)
 	httputil.Success(w, http.StatusOK, service)
 }
 
@@ -370,6 +498,20 @@ func (h *Handler) ListServices(w http.ResponseWriter, r *http.Request) {
 		filter.IncludeArchived = true
 	}
 
+	tags, err := parseTagFilter(r.URL.Query())
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.Tags = tags
+
+	customFields, err := parseCustomFieldFilter(r.URL.Query())
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.CustomFields = customFields
+
 	services, err := h.service.ListServicesWithEffectiveStatus(r.Context(), filter)
 	if err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
@@ -379,7 +521,68 @@ func (h *Handler) ListServices(w http.ResponseWriter, r *http.Request) {
 	httputil.Success(w, http.StatusOK, services)
 }
 
+// parseTagFilter extracts tag filters from query parameters in tag[key]=value form.
+// Returns nil if no tag filters are present.
+func parseTagFilter(query url.Values) (map[string]string, error) {
+	var tags map[string]string
+	for param, values := range query {
+		if !strings.HasPrefix(param, "tag[") || !strings.HasSuffix(param, "]") {
+			continue
+		}
+		key := param[len("tag[") : len(param)-1]
+		if key == "" {
+			return nil, errors.New("tag filter key must not be empty")
+		}
+		if len(values) > 1 {
+			return nil, fmt.Errorf("tag filter %q must be specified once", key)
+		}
+		if tags == nil {
+			tags = make(map[string]string)
+		}
+		tags[key] = values[0]
+	}
+	return tags, nil
+}
+
+// parseCustomFieldFilter extracts custom field filters from query parameters in
+// custom_field[key]=value form. Returns nil if no custom field filters are present.
+func parseCustomFieldFilter(query url.Values) (map[string]string, error) {
+	var fields map[string]string
+	for param, values := range query {
+		if !strings.HasPrefix(param, "custom_field[") || !strings.HasSuffix(param, "]") {
+			continue
+		}
+		key := param[len("custom_field[") : len(param)-1]
+		if key == "" {
+			return nil, errors.New("custom field filter key must not be empty")
+		}
+		if len(values) > 1 {
+			return nil, fmt.Errorf("custom field filter %q must be specified once", key)
+		}
+		if fields == nil {
+			fields = make(map[string]string)
+		}
+		fields[key] = values[0]
+	}
+	return fields, nil
+}
+
+// AdminServiceResponse is a service returned to admins, with the health check URL
+// that public responses omit.
+type AdminServiceResponse struct {
+	*domain.ServiceWithEffectiveStatus
+	HealthCheckURL string `json:"health_check_url"`
+}
+
+// UpdateServiceResponse is the service returned by PATCH /services/{slug}
+// with its most recent status change.
+type UpdateServiceResponse struct {
+	AdminServiceResponse
+	LastStatusChange *domain.ServiceStatusLogEntry `json:"last_status_change,omitempty"`
+}
+
 // UpdateService handles PATCH /services/{slug} request.
+// ?force=true allows changing the slug of a service with active events.
 func (h *Handler) UpdateService(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 
@@ -409,27 +612,59 @@ func (h *Handler) UpdateService(w http.ResponseWriter, r *http.Request) {
 		existing.GroupIDs = make([]string, 0)
 	}
 	existing.Order = req.Order
+	if req.ExternalURL != nil {
+		existing.ExternalURL = *req.ExternalURL
+	}
+	if req.DocumentationURL != nil {
+		existing.DocumentationURL = *req.DocumentationURL
+	}
+	if req.CustomFields != nil {
+		existing.CustomFields = req.CustomFields
+	}
+	if req.IconURL != nil {
+		existing.IconURL = *req.IconURL
+	}
+	if req.HealthCheckURL != nil {
+		existing.HealthCheckURL = *req.HealthCheckURL
+	}
+	if req.HealthCheckIntervalSeconds != nil {
+		existing.HealthCheckIntervalSeconds = *req.HealthCheckIntervalSeconds
+	}
 
 	userID := httputil.GetUserID(r.Context())
 	input := UpdateServiceInput{
 		Service:   existing,
 		UpdatedBy: userID,
 		Reason:    req.Reason,
+		Force:     r.URL.Query().Get("force") == "true",
 	}
 
+	before := h.snapshot(r.Context())
 	if err := h.service.UpdateService(r.Context(), input); err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
 	}
+	h.publishStatusChanges(r.Context(), before)
 
-	// Return with effective status
+	// Return with effective status and the status change just logged, if any
 	result, err := h.service.GetServiceBySlugWithEffectiveStatus(r.Context(), existing.Slug)
 	if err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
 	}
+	lastChange, err := h.service.GetLatestStatusLogEntry(r.Context(), existing.ID)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
 
-	httputil.Success(w, http.StatusOK, result)
+	httputil.Success(w, http.StatusOK, UpdateServiceResponse{
+		AdminServiceResponse: AdminServiceResponse{
+			ServiceWithEffectiveStatus: result,
+			HealthCheckURL:             result.HealthCheckURL,
+		},
+		LastStatusChange: lastChange,
+	})
 }
 
 // DeleteService handles DELETE /services/{slug} request.
@@ -450,6 +685,65 @@ func (h *Handler) DeleteService(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// BulkArchiveServices handles POST /services/archive request.
+func (h *Handler) BulkArchiveServices(w http.ResponseWriter, r *http.Request) {
+	var req BulkArchiveServicesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		httputil.ValidationError(w, err)
+		return
+	}
+
+	archived, failed, err := h.service.BulkArchiveServices(r.Context(), req.IDs)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+	if h.audit != nil {
+		h.audit.Record(r.Context(), auditActionServicesBulkArchive, auditTargetServices, "", map[string]interface{}{
+			"requested_ids": req.IDs,
+			"archived_ids":  archived,
+			"failed":        failed,
+		})
+	}
+
+	httputil.Success(w, http.StatusOK, BulkArchiveServicesResponse{
+		Archived: archived,
+		Failed:   failed,
+	})
+}
+
+// ReorderServices handles PUT /services/order request.
+func (h *Handler) ReorderServices(w http.ResponseWriter, r *http.Request) {
+	var items []ServiceOrderItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	if err := h.validator.Var(items, "required,min=1,max=1000,dive"); err != nil {
+		httputil.ValidationError(w, err)
+		return
+	}
+
+	if err := h.service.ReorderServices(r.Context(), items); err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	services, err := h.service.ListServicesWithEffectiveStatus(r.Context(), ServiceFilter{})
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, services)
+}
+
 // RestoreService handles POST /services/{slug}/restore request.
 func (h *Handler) RestoreService(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
@@ -460,7 +754,8 @@ func (h *Handler) RestoreService(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.RestoreService(r.Context(), service.ID); err != nil {
+	userID := httputil.GetUserID(r.Context())
+	if err := h.service.RestoreService(r.Context(), service.ID, userID); err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
 	}
@@ -485,32 +780,45 @@ func (h *Handler) GetServiceStatusLog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse pagination parameters with validation
-	limit := DefaultStatusLogLimit
-	offset := 0
+	limit, offset, err := parseLogPagination(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	if l := r.URL.Query().Get("limit"); l != "" {
-		parsed, err := strconv.Atoi(l)
-		if err != nil || parsed < 1 {
-			httputil.Error(w, http.StatusBadRequest, "limit must be a positive integer")
-			return
-		}
-		if parsed > MaxStatusLogLimit {
-			parsed = MaxStatusLogLimit
-		}
-		limit = parsed
+	entries, total, err := h.service.ListStatusLog(r.Context(), service.ID, limit, offset)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
 	}
 
-	if o := r.URL.Query().Get("offset"); o != "" {
-		parsed, err := strconv.Atoi(o)
-		if err != nil || parsed < 0 {
-			httputil.Error(w, http.StatusBadRequest, "offset must be a non-negative integer")
-			return
-		}
-		offset = parsed
+	response := map[string]interface{}{
+		"entries": entries,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
 	}
 
-	entries, total, err := h.service.ListStatusLog(r.Context(), service.ID, limit, offset)
+	httputil.Success(w, http.StatusOK, response)
+}
+
+// GetServiceGroupHistory handles GET /services/{slug}/group-history request.
+func (h *Handler) GetServiceGroupHistory(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	service, err := h.service.GetServiceBySlug(r.Context(), slug)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	limit, offset, err := parseLogPagination(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entries, total, err := h.service.ListMembershipLog(r.Context(), service.ID, limit, offset)
 	if err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
@@ -526,6 +834,33 @@ func (h *Handler) GetServiceStatusLog(w http.ResponseWriter, r *http.Request) {
 	httputil.Success(w, http.StatusOK, response)
 }
 
+// parseLogPagination parses limit and offset of a service log request.
+// Limit defaults to DefaultStatusLogLimit and is capped at MaxStatusLogLimit.
+func parseLogPagination(r *http.Request) (limit, offset int, err error) {
+	limit = DefaultStatusLogLimit
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 {
+			return 0, 0, errors.New("limit must be a positive integer")
+		}
+		if parsed > MaxStatusLogLimit {
+			parsed = MaxStatusLogLimit
+		}
+		limit = parsed
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		parsed, err := strconv.Atoi(o)
+		if err != nil || parsed < 0 {
+			return 0, 0, errors.New("offset must be a non-negative integer")
+		}
+		offset = parsed
+	}
+
+	return limit, offset, nil
+}
+
 // GetServiceEvents handles GET /services/{slug}/events request.
 func (h *Handler) GetServiceEvents(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
@@ -591,6 +926,73 @@ func (h *Handler) GetServiceEvents(w http.ResponseWriter, r *http.Request) {
 	httputil.Success(w, http.StatusOK, response)
 }
 
+// GetServiceUptime handles GET /services/{slug}/uptime request.
+func (h *Handler) GetServiceUptime(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	windowParam := r.URL.Query().Get("window")
+	if windowParam == "" {
+		windowParam = uptime.DefaultWindow
+	}
+	window, err := uptime.ParseWindow(windowParam)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	service, err := h.service.GetServiceBySlug(r.Context(), slug)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	report, err := h.service.GetServiceUptime(r.Context(), service.ID, window)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	response := map[string]interface{}{
+		"window":           windowParam,
+		"from":             report.From,
+		"to":               report.To,
+		"uptime_percent":   report.UptimePercent,
+		"downtime_seconds": report.DowntimeSeconds,
+		"daily":            report.Daily,
+	}
+
+	httputil.Success(w, http.StatusOK, response)
+}
+
+// GetServiceTimeline handles GET /services/{slug}/timeline request.
+func (h *Handler) GetServiceTimeline(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	windowParam := r.URL.Query().Get("window")
+	if windowParam == "" {
+		windowParam = uptime.DefaultWindow
+	}
+	window, err := uptime.ParseWindow(windowParam)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	service, err := h.service.GetServiceBySlug(r.Context(), slug)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	timeline, err := h.service.GetServiceTimeline(r.Context(), service.ID, window)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, timeline)
+}
+
 // GetServiceTags handles GET /services/{slug}/tags request.
 func (h *Handler) GetServiceTags(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
@@ -644,3 +1046,182 @@ func (h *Handler) UpdateServiceTags(w http.ResponseWriter, r *http.Request) {
 	httputil.Success(w, http.StatusOK, map[string]interface{}{"tags": req.Tags})
 }
 
+// maxImportFileSize limits the size of an uploaded service CSV file.
+const maxImportFileSize = 2 << 20
+
+// ImportServices handles POST /admin/services/import request.
+// The CSV file is sent as the "file" field of a multipart/form-data body.
+func (h *Handler) ImportServices(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+	if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid multipart form: file must be at most 2MB")
+		return
+	}
+	defer func() {
+		_ = r.MultipartForm.RemoveAll()
+	}()
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	rows, err := ParseServiceCSV(file)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	result, err := h.service.ImportServices(r.Context(), rows, httputil.GetUserID(r.Context()))
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	if result.Created > 0 {
+		ctxlog.FromContext(r.Context()).Info("services imported", "created", result.Created, "skipped", result.Skipped)
+	}
+	httputil.Success(w, http.StatusOK, result)
+}
+
+// GetServiceSLA handles GET /services/{slug}/sla request.
+func (h *Handler) GetServiceSLA(w http.ResponseWriter, r *http.Request) {
+	service, err := h.service.GetServiceBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	status, err := h.service.GetServiceSLA(r.Context(), service)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, status)
+}
+
+// UpdateServiceSLARequest represents the request body for setting a service SLA target.
+type UpdateServiceSLARequest struct {
+	SLAUptimeTarget *float64 `json:"sla_uptime_target"` // null removes the target
+}
+
+// UpdateServiceSLA handles PUT /services/{slug}/sla request.
+func (h *Handler) UpdateServiceSLA(w http.ResponseWriter, r *http.Request) {
+	service, err := h.service.GetServiceBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	var req UpdateServiceSLARequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	if err := h.service.SetServiceSLATarget(r.Context(), service.ID, req.SLAUptimeTarget); err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+	service.SLAUptimeTarget = req.SLAUptimeTarget
+
+	status, err := h.service.GetServiceSLA(r.Context(), service)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, status)
+}
+
+// GetServiceDependencies handles GET /services/{slug}/dependencies request.
+func (h *Handler) GetServiceDependencies(w http.ResponseWriter, r *http.Request) {
+	service, err := h.service.GetServiceBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	deps, err := h.dependencies.ListDependencies(r.Context(), service.ID)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, deps)
+}
+
+// GetServiceDependents handles GET /services/{slug}/dependents request.
+func (h *Handler) GetServiceDependents(w http.ResponseWriter, r *http.Request) {
+	service, err := h.service.GetServiceBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	deps, err := h.dependencies.ListDependents(r.Context(), service.ID)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, deps)
+}
+
+// UpdateServiceDependencies handles PUT /services/{slug}/dependencies request.
+// The body is the complete list of upstream dependencies; an empty list removes all.
+func (h *Handler) UpdateServiceDependencies(w http.ResponseWriter, r *http.Request) {
+	service, err := h.service.GetServiceBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	var req []ServiceDependencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	deps := make([]domain.ServiceDependency, 0, len(req))
+	for _, item := range req {
+		if err := h.validator.Struct(item); err != nil {
+			httputil.ValidationError(w, err)
+			return
+		}
+		deps = append(deps, domain.ServiceDependency{ServiceID: item.ServiceID, Type: item.Type})
+	}
+
+	if err := h.dependencies.SetDependencies(r.Context(), service.ID, deps); err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	result, err := h.dependencies.ListDependencies(r.Context(), service.ID)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, result)
+}
+
+
+// snapshot captures service statuses before a change (nil without publisher or subscribers).
+func (h *Handler) snapshot(ctx context.Context) sse.StatusSnapshot {
+	if h.publisher == nil {
+		return nil
+	}
+	return h.publisher.Snapshot(ctx)
+}
+
+// publishStatusChanges pushes effective status changes of services since the before snapshot.
+func (h *Handler) publishStatusChanges(ctx context.Context, before sse.StatusSnapshot) {
+	if h.publisher == nil || before == nil {
+		return
+	}
+	h.publisher.PublishStatusChanges(before, h.publisher.Snapshot(ctx))
+}
@@ -0,0 +1,73 @@
+package catalog
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseTagFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"no tags", "status=operational", nil, false},
+		{"single tag", "tag[team]=payments", map[string]string{"team": "payments"}, false},
+		{"multiple tags", "tag[team]=payments&tag[tier]=1", map[string]string{"team": "payments", "tier": "1"}, false},
+		{"empty value", "tag[team]=", map[string]string{"team": ""}, false},
+		{"ignores other params", "tags=x&tag=y&group_id=abc", nil, false},
+		{"empty key", "tag[]=payments", nil, true},
+		{"duplicate key", "tag[team]=a&tag[team]=b", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("parse query: %v", err)
+			}
+
+			got, err := parseTagFilter(query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTagFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTagFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCustomFieldFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"no custom fields", "tag[team]=payments", nil, false},
+		{"single field", "custom_field[team]=platform", map[string]string{"team": "platform"}, false},
+		{"multiple fields", "custom_field[team]=platform&custom_field[tier]=1", map[string]string{"team": "platform", "tier": "1"}, false},
+		{"empty key", "custom_field[]=platform", nil, true},
+		{"duplicate key", "custom_field[team]=a&custom_field[team]=b", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("parse query: %v", err)
+			}
+
+			got, err := parseCustomFieldFilter(query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCustomFieldFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCustomFieldFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
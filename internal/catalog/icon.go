@@ -0,0 +1,107 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+)
+
+// DefaultIconCheckTimeout limits the HEAD request checking a service icon URL.
+const DefaultIconCheckTimeout = 3 * time.Second
+
+// IconValidator checks that a service icon URL serves an image.
+// Returns an error wrapping ErrInvalidIcon if it does not.
+type IconValidator interface {
+	ValidateIcon(ctx context.Context, iconURL string) error
+}
+
+// HTTPIconValidator validates icon URLs with a HEAD request: the response must be
+// 2xx with an image/* Content-Type. Loopback, private, shared (100.64.0.0/10), link-local
+// and unspecified addresses are refused, also as redirect targets, so icon URLs cannot
+// probe the internal network.
+type HTTPIconValidator struct {
+	client *http.Client
+}
+
+// DialControl is a net.Dialer Control func deciding which addresses icons may be fetched from.
+type DialControl func(network, address string, c syscall.RawConn) error
+
+// errForbiddenAddress is returned by the dialer for addresses icons may not be fetched from.
+var errForbiddenAddress = errors.New("forbidden address")
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), not covered by net.IP.IsPrivate.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// NewHTTPIconValidator creates an icon validator whose requests time out after timeout.
+func NewHTTPIconValidator(timeout time.Duration) *HTTPIconValidator {
+	return NewHTTPIconValidatorWithControl(timeout, RefuseInternalAddress)
+}
+
+// NewHTTPIconValidatorWithControl creates an icon validator dialing through control.
+// Production uses RefuseInternalAddress; tests pass one that also allows their loopback servers.
+func NewHTTPIconValidatorWithControl(timeout time.Duration, control DialControl) *HTTPIconValidator {
+	dialer := &net.Dialer{Timeout: timeout, Control: control}
+	// No proxy: the dialer must see the address of the icon host.
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: timeout,
+	}
+	return &HTTPIconValidator{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: httputil.RequestIDTransport(transport),
+		},
+	}
+}
+
+// RefuseInternalAddress is a DialControl; it runs for every connection,
+// after DNS resolution, so redirects and DNS rebinding are covered.
+func RefuseInternalAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || sharedAddressSpace.Contains(ip) ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return errForbiddenAddress
+	}
+	return nil
+}
+
+// ValidateIcon implements IconValidator.
+func (v *HTTPIconValidator) ValidateIcon(ctx context.Context, iconURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, iconURL, nil)
+	if err != nil {
+		return ErrInvalidIcon
+	}
+
+	// Only the generic error is returned: the upstream status or Content-Type would let
+	// callers fingerprint hosts, so details are logged.
+	resp, err := v.client.Do(req)
+	if err != nil {
+		slog.DebugContext(ctx, "icon check failed", "icon_url", iconURL, "error", err)
+		return ErrInvalidIcon
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		slog.DebugContext(ctx, "icon check failed", "icon_url", iconURL, "status", resp.StatusCode)
+		return ErrInvalidIcon
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if !strings.HasPrefix(mediaType, "image/") {
+		slog.DebugContext(ctx, "icon check failed", "icon_url", iconURL, "content_type", resp.Header.Get("Content-Type"))
+		return ErrInvalidIcon
+	}
+	return nil
+}
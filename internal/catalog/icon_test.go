@@ -0,0 +1,113 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// allowLoopback lets the validator reach httptest servers and refuses
+// everything else RefuseInternalAddress refuses.
+func allowLoopback(network, address string, c syscall.RawConn) error {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+			return nil
+		}
+	}
+	return RefuseInternalAddress(network, address, c)
+}
+
+func newTestIconValidator(timeout time.Duration) *HTTPIconValidator {
+	return NewHTTPIconValidatorWithControl(timeout, allowLoopback)
+}
+
+func TestHTTPIconValidator_ValidateIcon(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		wantErr     bool
+	}{
+		{"png", http.StatusOK, "image/png", false},
+		{"svg with params", http.StatusOK, "image/svg+xml; charset=utf-8", false},
+		{"html", http.StatusOK, "text/html; charset=utf-8", true},
+		{"no content type", http.StatusOK, "", true},
+		{"not found", http.StatusNotFound, "image/png", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodHead {
+					t.Errorf("method = %s, want HEAD", r.Method)
+				}
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := newTestIconValidator(time.Second).ValidateIcon(context.Background(), server.URL+"/logo")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateIcon() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err.Error() != ErrInvalidIcon.Error() {
+				t.Errorf("ValidateIcon() error = %q, want the generic %q", err, ErrInvalidIcon)
+			}
+		})
+	}
+}
+
+func TestHTTPIconValidator_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	err := newTestIconValidator(50*time.Millisecond).ValidateIcon(context.Background(), server.URL)
+	if !errors.Is(err, ErrInvalidIcon) {
+		t.Errorf("ValidateIcon() error = %v, want ErrInvalidIcon", err)
+	}
+}
+
+func TestHTTPIconValidator_RefusesInternalAddresses(t *testing.T) {
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	for _, iconURL := range []string{
+		"http://127.0.0.1/logo.png",
+		"http://[::1]/logo.png",
+		"http://100.64.0.1/logo.png",
+		"http://10.0.0.1/logo.png",
+		"http://192.168.1.1/logo.png",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://[fd00::1]/logo.png",
+		"http://0.0.0.0/logo.png",
+	} {
+		t.Run(iconURL, func(t *testing.T) {
+			err := NewHTTPIconValidator(time.Second).ValidateIcon(context.Background(), iconURL)
+			if !errors.Is(err, ErrInvalidIcon) {
+				t.Fatalf("ValidateIcon() error = %v, want ErrInvalidIcon", err)
+			}
+			if err.Error() != ErrInvalidIcon.Error() {
+				t.Errorf("ValidateIcon() error = %q, want the generic %q", err, ErrInvalidIcon)
+			}
+		})
+	}
+
+	// The redirect target is refused even when the first host is allowed
+	t.Run("redirect", func(t *testing.T) {
+		err := newTestIconValidator(time.Second).ValidateIcon(context.Background(), redirect.URL+"/logo.png")
+		if !errors.Is(err, ErrInvalidIcon) {
+			t.Fatalf("ValidateIcon() error = %v, want ErrInvalidIcon", err)
+		}
+	})
+}
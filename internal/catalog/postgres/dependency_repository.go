@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DependencyRepository implements the catalog.DependencyRepository interface using PostgreSQL.
+type DependencyRepository struct {
+	db *pgxpool.Pool
+}
+
+var _ catalog.DependencyRepository = (*DependencyRepository)(nil)
+
+// NewDependencyRepository creates a new PostgreSQL dependency repository.
+func NewDependencyRepository(db *pgxpool.Pool) *DependencyRepository {
+	return &DependencyRepository{db: db}
+}
+
+// ReplaceDependencies replaces all dependencies of a service with the provided ones.
+func (r *DependencyRepository) ReplaceDependencies(ctx context.Context, serviceID string, deps []domain.ServiceDependency) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	deleteQuery := `DELETE FROM service_dependencies WHERE dependent_service_id = $1`
+	if _, err := tx.Exec(ctx, deleteQuery, serviceID); err != nil {
+		return fmt.Errorf("delete old dependencies: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO service_dependencies (dependent_service_id, dependency_service_id, dependency_type)
+		VALUES ($1, $2, $3::dependency_type)
+	`
+	for _, dep := range deps {
+		if _, err := tx.Exec(ctx, insertQuery, serviceID, dep.ServiceID, dep.Type); err != nil {
+			return fmt.Errorf("insert dependency: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListDependencies returns the non-archived services a service depends on, ordered by name.
+func (r *DependencyRepository) ListDependencies(ctx context.Context, serviceID string) ([]domain.ServiceDependency, error) {
+	query := `
+		SELECT s.id, s.name, s.slug, d.dependency_type::text
+		FROM service_dependencies d
+		JOIN services s ON s.id = d.dependency_service_id
+		WHERE d.dependent_service_id = $1 AND s.archived_at IS NULL
+		ORDER BY s.name
+	`
+	return r.list(ctx, query, serviceID)
+}
+
+// ListDependents returns the non-archived services depending on a service, ordered by name.
+func (r *DependencyRepository) ListDependents(ctx context.Context, serviceID string) ([]domain.ServiceDependency, error) {
+	query := `
+		SELECT s.id, s.name, s.slug, d.dependency_type::text
+		FROM service_dependencies d
+		JOIN services s ON s.id = d.dependent_service_id
+		WHERE d.dependency_service_id = $1 AND s.archived_at IS NULL
+		ORDER BY s.name
+	`
+	return r.list(ctx, query, serviceID)
+}
+
+func (r *DependencyRepository) list(ctx context.Context, query, serviceID string) ([]domain.ServiceDependency, error) {
+	rows, err := r.db.Query(ctx, query, serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("list dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	deps := make([]domain.ServiceDependency, 0)
+	for rows.Next() {
+		var dep domain.ServiceDependency
+		if err := rows.Scan(&dep.ServiceID, &dep.Name, &dep.Slug, &dep.Type); err != nil {
+			return nil, fmt.Errorf("scan dependency: %w", err)
+		}
+		deps = append(deps, dep)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dependencies: %w", err)
+	}
+
+	return deps, nil
+}
+
+// HasDependencyPath reports whether fromID depends on toID, directly or through other services.
+func (r *DependencyRepository) HasDependencyPath(ctx context.Context, fromID, toID string) (bool, error) {
+	// UNION (not UNION ALL) stops the walk at services already visited
+	query := `
+		WITH RECURSIVE upstream(service_id) AS (
+			SELECT dependency_service_id FROM service_dependencies WHERE dependent_service_id = $1
+			UNION
+			SELECT d.dependency_service_id
+			FROM service_dependencies d
+			JOIN upstream u ON d.dependent_service_id = u.service_id
+		)
+		SELECT EXISTS (SELECT 1 FROM upstream WHERE service_id = $2)
+	`
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, fromID, toID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("check dependency path: %w", err)
+	}
+	return exists, nil
+}
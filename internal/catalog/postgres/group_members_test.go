@@ -0,0 +1,144 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/catalog/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	benchGroups          = 100
+	benchGroupServices   = 10
+	benchGroupSlugPrefix = "bench-group-"
+)
+
+// queryCounter is a pgx tracer counting queries sent to the database.
+type queryCounter struct {
+	queries atomic.Int64
+}
+
+func (c *queryCounter) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	c.queries.Add(1)
+	return ctx
+}
+
+func (c *queryCounter) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+// newCountingPool starts a migrated PostgreSQL container and connects to it
+// with every query counted by the returned counter.
+func newCountingPool(ctx context.Context, tb testing.TB) (*pgxpool.Pool, *queryCounter) {
+	tb.Helper()
+
+	config, err := pgxpool.ParseConfig(startMigratedPostgres(ctx, tb))
+	if err != nil {
+		tb.Fatalf("parse config: %v", err)
+	}
+	counter := &queryCounter{}
+	config.ConnConfig.Tracer = counter
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		tb.Fatalf("connect: %v", err)
+	}
+	tb.Cleanup(pool.Close)
+	return pool, counter
+}
+
+// seedGroupMembers creates benchGroups groups and benchGroupServices services.
+// Group n contains the services with order 1..n%5, so every fifth group is empty.
+func seedGroupMembers(ctx context.Context, tb testing.TB, pool *pgxpool.Pool) {
+	tb.Helper()
+
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		{`INSERT INTO services (name, slug, "order")
+		 SELECT 'Member Service ' || g, 'member-svc-' || g, g
+		 FROM generate_series(1, $1::int) g`, []interface{}{benchGroupServices}},
+		{`INSERT INTO service_groups (name, slug, "order")
+		 SELECT 'Bench Group ' || g, 'bench-group-' || g, g
+		 FROM generate_series(1, $1::int) g`, []interface{}{benchGroups}},
+		{`INSERT INTO service_group_members (service_id, group_id)
+		 SELECT s.id, g.id
+		 FROM services s JOIN service_groups g ON s."order" <= g."order" % 5
+		 WHERE s.slug LIKE 'member-svc-%' AND g.slug LIKE 'bench-group-%'`, nil},
+	}
+	for i, stmt := range statements {
+		if _, err := pool.Exec(ctx, stmt.query, stmt.args...); err != nil {
+			tb.Fatalf("seed statement %d: %v", i, err)
+		}
+	}
+}
+
+func TestListGroups_LoadsMembersInOneQuery(t *testing.T) {
+	ctx := context.Background()
+	pool, counter := newCountingPool(ctx, t)
+	seedGroupMembers(ctx, t, pool)
+	repo := postgres.NewRepository(pool)
+
+	counter.queries.Store(0)
+	groups, err := repo.ListGroups(ctx, catalog.GroupFilter{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, counter.queries.Load(), "one query for groups, one for memberships")
+
+	var seeded int
+	for _, group := range groups {
+		if !strings.HasPrefix(group.Slug, benchGroupSlugPrefix) {
+			continue
+		}
+		seeded++
+		require.NotNil(t, group.ServiceIDs, group.Slug)
+		assert.Len(t, group.ServiceIDs, group.Order%5, group.Slug)
+	}
+	assert.Equal(t, benchGroups, seeded)
+
+	counter.queries.Store(0)
+	services, err := repo.ListServices(ctx, catalog.ServiceFilter{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, counter.queries.Load(), "one query for services, one for memberships")
+
+	for _, service := range services {
+		if !strings.HasPrefix(service.Slug, "member-svc-") {
+			continue
+		}
+		// Service n is in groups whose order % 5 >= n
+		var want int
+		for g := 1; g <= benchGroups; g++ {
+			if g%5 >= service.Order {
+				want++
+			}
+		}
+		require.NotNil(t, service.GroupIDs, service.Slug)
+		assert.Len(t, service.GroupIDs, want, service.Slug)
+	}
+}
+
+// BenchmarkListGroups measures the group list with benchGroups groups and their memberships.
+// Run with:
+//
+//	go test -tags integration -run '^$' -bench ListGroups ./internal/catalog/postgres/
+func BenchmarkListGroups(b *testing.B) {
+	ctx := context.Background()
+
+	pool := newBenchPool(ctx, b)
+	seedGroupMembers(ctx, b, pool)
+	repo := postgres.NewRepository(pool)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.ListGroups(ctx, catalog.GroupFilter{}); err != nil {
+			b.Fatalf("list groups: %v", err)
+		}
+	}
+}
@@ -6,10 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
+	"time"
 
 	"github.com/bissquit/incident-garden/internal/catalog"
 	"github.com/bissquit/incident-garden/internal/domain"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -23,18 +26,19 @@ func NewRepository(db *pgxpool.Pool) *Repository {
 	return &Repository{db: db}
 }
 
-// CreateGroup creates a new service group in the database.
-func (r *Repository) CreateGroup(ctx context.Context, group *domain.ServiceGroup) error {
+// CreateGroupTx creates a new service group within a transaction.
+func (r *Repository) CreateGroupTx(ctx context.Context, tx pgx.Tx, group *domain.ServiceGroup) error {
 	query := `
-		INSERT INTO service_groups (name, slug, description, "order")
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO service_groups (name, slug, description, "order", parent_group_id)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id, created_at, updated_at
 	`
-	err := r.db.QueryRow(ctx, query,
+	err := tx.QueryRow(ctx, query,
 		group.Name,
 		group.Slug,
 		group.Description,
 		group.Order,
+		group.ParentGroupID,
 	).Scan(&group.ID, &group.CreatedAt, &group.UpdatedAt)
 
 	if err != nil {
@@ -46,7 +50,7 @@ func (r *Repository) CreateGroup(ctx context.Context, group *domain.ServiceGroup
 // GetGroupBySlug retrieves a service group by its slug.
 func (r *Repository) GetGroupBySlug(ctx context.Context, slug string) (*domain.ServiceGroup, error) {
 	query := `
-		SELECT id, name, slug, description, "order", created_at, updated_at, archived_at
+		SELECT id, name, slug, description, "order", created_at, updated_at, archived_at, parent_group_id
 		FROM service_groups
 		WHERE slug = $1
 	`
@@ -60,6 +64,7 @@ func (r *Repository) GetGroupBySlug(ctx context.Context, slug string) (*domain.S
 		&group.CreatedAt,
 		&group.UpdatedAt,
 		&group.ArchivedAt,
+		&group.ParentGroupID,
 	)
 
 	if err != nil {
@@ -76,13 +81,19 @@ func (r *Repository) GetGroupBySlug(ctx context.Context, slug string) (*domain.S
 	}
 	group.ServiceIDs = serviceIDs
 
+	children, err := r.GetChildGroups(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+	group.Children = children
+
 	return &group, nil
 }
 
 // GetGroupByID retrieves a service group by its ID.
 func (r *Repository) GetGroupByID(ctx context.Context, id string) (*domain.ServiceGroup, error) {
 	query := `
-		SELECT id, name, slug, description, "order", created_at, updated_at, archived_at
+		SELECT id, name, slug, description, "order", created_at, updated_at, archived_at, parent_group_id
 		FROM service_groups
 		WHERE id = $1
 	`
@@ -96,6 +107,7 @@ func (r *Repository) GetGroupByID(ctx context.Context, id string) (*domain.Servi
 		&group.CreatedAt,
 		&group.UpdatedAt,
 		&group.ArchivedAt,
+		&group.ParentGroupID,
 	)
 
 	if err != nil {
@@ -118,7 +130,7 @@ func (r *Repository) GetGroupByID(ctx context.Context, id string) (*domain.Servi
 // ListGroups retrieves all service groups ordered by order and name.
 func (r *Repository) ListGroups(ctx context.Context, filter catalog.GroupFilter) ([]domain.ServiceGroup, error) {
 	query := `
-		SELECT id, name, slug, description, "order", created_at, updated_at, archived_at
+		SELECT id, name, slug, description, "order", created_at, updated_at, archived_at, parent_group_id
 		FROM service_groups
 	`
 
@@ -146,6 +158,7 @@ func (r *Repository) ListGroups(ctx context.Context, filter catalog.GroupFilter)
 			&group.CreatedAt,
 			&group.UpdatedAt,
 			&group.ArchivedAt,
+			&group.ParentGroupID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan service group: %w", err)
@@ -157,23 +170,110 @@ func (r *Repository) ListGroups(ctx context.Context, filter catalog.GroupFilter)
 		return nil, fmt.Errorf("iterate service groups: %w", err)
 	}
 
-	// Load services for each group
+	// Load services of all groups in one query
+	serviceIDs, err := r.getServicesByGroup(ctx, len(groups), func(i int) string { return groups[i].ID })
+	if err != nil {
+		return nil, err
+	}
 	for i := range groups {
-		serviceIDs, err := r.GetGroupServices(ctx, groups[i].ID)
-		if err != nil {
-			return nil, fmt.Errorf("get group services: %w", err)
-		}
-		groups[i].ServiceIDs = serviceIDs
+		groups[i].ServiceIDs = serviceIDs[groups[i].ID]
 	}
 
+	attachChildGroups(len(groups), func(i int) *domain.ServiceGroup { return &groups[i] })
+
 	return groups, nil
 }
 
+// GetChildGroups returns the non-archived sub-groups of a group ordered by order and name,
+// with their service IDs.
+func (r *Repository) GetChildGroups(ctx context.Context, parentGroupID string) ([]domain.ServiceGroup, error) {
+	query := `
+		SELECT id, name, slug, description, "order", created_at, updated_at, archived_at, parent_group_id
+		FROM service_groups
+		WHERE parent_group_id = $1 AND archived_at IS NULL
+		ORDER BY "order", name
+	`
+	rows, err := r.db.Query(ctx, query, parentGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("get child groups: %w", err)
+	}
+	children, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.ServiceGroup, error) {
+		var group domain.ServiceGroup
+		err := row.Scan(
+			&group.ID, &group.Name, &group.Slug, &group.Description, &group.Order,
+			&group.CreatedAt, &group.UpdatedAt, &group.ArchivedAt, &group.ParentGroupID,
+		)
+		group.Children = make([]domain.ServiceGroup, 0)
+		return group, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan child group: %w", err)
+	}
+
+	serviceIDs, err := r.getServicesByGroup(ctx, len(children), func(i int) string { return children[i].ID })
+	if err != nil {
+		return nil, err
+	}
+	for i := range children {
+		children[i].ServiceIDs = serviceIDs[children[i].ID]
+	}
+	return children, nil
+}
+
+// HasAncestor reports whether candidateAncestorID is the parent of groupID,
+// or the parent of its parent and so on. A group is not its own ancestor.
+func (r *Repository) HasAncestor(ctx context.Context, groupID, candidateAncestorID string) (bool, error) {
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_group_id AS id FROM service_groups WHERE id = $1
+			UNION
+			SELECT g.parent_group_id FROM service_groups g JOIN ancestors a ON g.id = a.id
+		)
+		SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2)
+	`
+	var found bool
+	if err := r.db.QueryRow(ctx, query, groupID, candidateAncestorID).Scan(&found); err != nil {
+		return false, fmt.Errorf("check group ancestor: %w", err)
+	}
+	return found, nil
+}
+
+// HasChildGroups reports whether any group, archived or not, has groupID as its parent.
+func (r *Repository) HasChildGroups(ctx context.Context, groupID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM service_groups WHERE parent_group_id = $1)`
+	var found bool
+	if err := r.db.QueryRow(ctx, query, groupID).Scan(&found); err != nil {
+		return false, fmt.Errorf("check child groups: %w", err)
+	}
+	return found, nil
+}
+
+// attachChildGroups fills Children of each listed group with the listed groups
+// whose parent it is, keeping the list order. group(i) returns the i-th of n groups.
+func attachChildGroups(n int, group func(i int) *domain.ServiceGroup) {
+	index := make(map[string]int, n)
+	for i := 0; i < n; i++ {
+		g := group(i)
+		g.Children = make([]domain.ServiceGroup, 0)
+		index[g.ID] = i
+	}
+	for i := 0; i < n; i++ {
+		g := group(i)
+		if g.ParentGroupID == nil {
+			continue
+		}
+		if parent, ok := index[*g.ParentGroupID]; ok {
+			p := group(parent)
+			p.Children = append(p.Children, *g)
+		}
+	}
+}
+
 // UpdateGroup updates an existing service group.
 func (r *Repository) UpdateGroup(ctx context.Context, group *domain.ServiceGroup) error {
 	query := `
 		UPDATE service_groups
-		SET name = $2, slug = $3, description = $4, "order" = $5, updated_at = NOW()
+		SET name = $2, slug = $3, description = $4, "order" = $5, parent_group_id = $6, updated_at = NOW()
 		WHERE id = $1
 		RETURNING updated_at
 	`
@@ -183,6 +283,7 @@ func (r *Repository) UpdateGroup(ctx context.Context, group *domain.ServiceGroup
 		group.Slug,
 		group.Description,
 		group.Order,
+		group.ParentGroupID,
 	).Scan(&group.UpdatedAt)
 
 	if err != nil {
@@ -208,22 +309,37 @@ func (r *Repository) DeleteGroup(ctx context.Context, id string) error {
 	return nil
 }
 
-// CreateService creates a new service in the database.
-func (r *Repository) CreateService(ctx context.Context, service *domain.Service) error {
+// serviceSlugConstraint is the unique constraint on services.slug.
+const serviceSlugConstraint = "services_slug_key"
+
+// CreateServiceTx creates a new service within a transaction.
+// A slug taken concurrently returns catalog.ErrSlugExists.
+func (r *Repository) CreateServiceTx(ctx context.Context, tx pgx.Tx, service *domain.Service) error {
 	query := `
-		INSERT INTO services (name, slug, description, status, "order")
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO services (name, slug, description, status, "order", external_url, documentation_url, custom_fields,
+			health_check_url, health_check_interval_seconds, icon_url)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), $8, NULLIF($9, ''), $10, NULLIF($11, ''))
 		RETURNING id, created_at, updated_at
 	`
-	err := r.db.QueryRow(ctx, query,
+	err := tx.QueryRow(ctx, query,
 		service.Name,
 		service.Slug,
 		service.Description,
 		service.Status,
 		service.Order,
+		service.ExternalURL,
+		service.DocumentationURL,
+		customFieldsParam(service.CustomFields),
+		service.HealthCheckURL,
+		service.HealthCheckIntervalSeconds,
+		service.IconURL,
 	).Scan(&service.ID, &service.CreatedAt, &service.UpdatedAt)
 
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == serviceSlugConstraint {
+			return catalog.ErrSlugExists
+		}
 		return fmt.Errorf("create service: %w", err)
 	}
 	return nil
@@ -232,7 +348,9 @@ func (r *Repository) CreateService(ctx context.Context, service *domain.Service)
 // GetServiceBySlug retrieves a service by its slug.
 func (r *Repository) GetServiceBySlug(ctx context.Context, slug string) (*domain.Service, error) {
 	query := `
-		SELECT id, name, slug, description, status, "order", created_at, updated_at, archived_at
+		SELECT id, name, slug, description, status, "order", sla_uptime_target,
+			COALESCE(external_url, ''), COALESCE(documentation_url, ''), custom_fields,
+			COALESCE(health_check_url, ''), health_check_interval_seconds, COALESCE(icon_url, ''), created_at, updated_at, archived_at
 		FROM services
 		WHERE slug = $1
 	`
@@ -244,6 +362,13 @@ func (r *Repository) GetServiceBySlug(ctx context.Context, slug string) (*domain
 		&service.Description,
 		&service.Status,
 		&service.Order,
+		&service.SLAUptimeTarget,
+		&service.ExternalURL,
+		&service.DocumentationURL,
+		&service.CustomFields,
+		&service.HealthCheckURL,
+		&service.HealthCheckIntervalSeconds,
+		&service.IconURL,
 		&service.CreatedAt,
 		&service.UpdatedAt,
 		&service.ArchivedAt,
@@ -269,7 +394,9 @@ func (r *Repository) GetServiceBySlug(ctx context.Context, slug string) (*domain
 // GetServiceByID retrieves a service by its ID.
 func (r *Repository) GetServiceByID(ctx context.Context, id string) (*domain.Service, error) {
 	query := `
-		SELECT id, name, slug, description, status, "order", created_at, updated_at, archived_at
+		SELECT id, name, slug, description, status, "order", sla_uptime_target,
+			COALESCE(external_url, ''), COALESCE(documentation_url, ''), custom_fields,
+			COALESCE(health_check_url, ''), health_check_interval_seconds, COALESCE(icon_url, ''), created_at, updated_at, archived_at
 		FROM services
 		WHERE id = $1
 	`
@@ -281,6 +408,13 @@ func (r *Repository) GetServiceByID(ctx context.Context, id string) (*domain.Ser
 		&service.Description,
 		&service.Status,
 		&service.Order,
+		&service.SLAUptimeTarget,
+		&service.ExternalURL,
+		&service.DocumentationURL,
+		&service.CustomFields,
+		&service.HealthCheckURL,
+		&service.HealthCheckIntervalSeconds,
+		&service.IconURL,
 		&service.CreatedAt,
 		&service.UpdatedAt,
 		&service.ArchivedAt,
@@ -312,7 +446,9 @@ func (r *Repository) ListServices(ctx context.Context, filter catalog.ServiceFil
 	if filter.GroupID != nil {
 		// Filter by group using JOIN on service_group_members
 		query = `
-			SELECT DISTINCT s.id, s.name, s.slug, s.description, s.status, s."order", s.created_at, s.updated_at, s.archived_at
+			SELECT DISTINCT s.id, s.name, s.slug, s.description, s.status, s."order", s.sla_uptime_target,
+				COALESCE(s.external_url, ''), COALESCE(s.documentation_url, ''), s.custom_fields,
+				COALESCE(s.health_check_url, ''), s.health_check_interval_seconds, COALESCE(s.icon_url, ''), s.created_at, s.updated_at, s.archived_at
 			FROM services s
 			JOIN service_group_members sgm ON s.id = sgm.service_id
 			WHERE sgm.group_id = $1
@@ -327,11 +463,17 @@ func (r *Repository) ListServices(ctx context.Context, filter catalog.ServiceFil
 		if filter.Status != nil {
 			query += fmt.Sprintf(" AND s.status = $%d", argNum)
 			args = append(args, *filter.Status)
+			argNum++
 		}
+
+		query, args, argNum = appendCustomFieldFilters(query, args, argNum, "s.custom_fields", filter.CustomFields)
+		query, args = appendTagFilters(query, args, argNum, "s.id", filter.Tags)
 	} else {
 		// No group filter
 		query = `
-			SELECT id, name, slug, description, status, "order", created_at, updated_at, archived_at
+			SELECT id, name, slug, description, status, "order", sla_uptime_target,
+				COALESCE(external_url, ''), COALESCE(documentation_url, ''), custom_fields,
+			COALESCE(health_check_url, ''), health_check_interval_seconds, COALESCE(icon_url, ''), created_at, updated_at, archived_at
 			FROM services
 			WHERE 1=1
 		`
@@ -343,7 +485,11 @@ func (r *Repository) ListServices(ctx context.Context, filter catalog.ServiceFil
 		if filter.Status != nil {
 			query += fmt.Sprintf(" AND status = $%d", argNum)
 			args = append(args, *filter.Status)
+			argNum++
 		}
+
+		query, args, argNum = appendCustomFieldFilters(query, args, argNum, "custom_fields", filter.CustomFields)
+		query, args = appendTagFilters(query, args, argNum, "services.id", filter.Tags)
 	}
 
 	query += ` ORDER BY "order", name`
@@ -364,6 +510,13 @@ func (r *Repository) ListServices(ctx context.Context, filter catalog.ServiceFil
 			&service.Description,
 			&service.Status,
 			&service.Order,
+			&service.SLAUptimeTarget,
+			&service.ExternalURL,
+			&service.DocumentationURL,
+			&service.CustomFields,
+			&service.HealthCheckURL,
+			&service.HealthCheckIntervalSeconds,
+			&service.IconURL,
 			&service.CreatedAt,
 			&service.UpdatedAt,
 			&service.ArchivedAt,
@@ -378,23 +531,31 @@ func (r *Repository) ListServices(ctx context.Context, filter catalog.ServiceFil
 		return nil, fmt.Errorf("iterate services: %w", err)
 	}
 
-	// Load groups for each service
+	// Load groups of all services in one query
+	groupIDs, err := r.getGroupsByService(ctx, len(services), func(i int) string { return services[i].ID })
+	if err != nil {
+		return nil, err
+	}
 	for i := range services {
-		groupIDs, err := r.GetServiceGroups(ctx, services[i].ID)
-		if err != nil {
-			return nil, fmt.Errorf("get service groups: %w", err)
-		}
-		services[i].GroupIDs = groupIDs
+		services[i].GroupIDs = groupIDs[services[i].ID]
 	}
 
 	return services, nil
 }
 
+// GetServicesByCustomField returns non-archived services whose custom field key equals value.
+func (r *Repository) GetServicesByCustomField(ctx context.Context, key, value string) ([]domain.Service, error) {
+	return r.ListServices(ctx, catalog.ServiceFilter{CustomFields: map[string]string{key: value}})
+}
+
 // UpdateService updates an existing service.
 func (r *Repository) UpdateService(ctx context.Context, service *domain.Service) error {
 	query := `
 		UPDATE services
-		SET name = $2, slug = $3, description = $4, status = $5, "order" = $6, updated_at = NOW()
+		SET name = $2, slug = $3, description = $4, status = $5, "order" = $6,
+			external_url = NULLIF($7, ''), documentation_url = NULLIF($8, ''), custom_fields = $9,
+			health_check_url = NULLIF($10, ''), health_check_interval_seconds = $11, icon_url = NULLIF($12, ''),
+			updated_at = NOW()
 		WHERE id = $1
 		RETURNING updated_at
 	`
@@ -405,6 +566,12 @@ func (r *Repository) UpdateService(ctx context.Context, service *domain.Service)
 		service.Description,
 		service.Status,
 		service.Order,
+		service.ExternalURL,
+		service.DocumentationURL,
+		customFieldsParam(service.CustomFields),
+		service.HealthCheckURL,
+		service.HealthCheckIntervalSeconds,
+		service.IconURL,
 	).Scan(&service.UpdatedAt)
 
 	if err != nil {
@@ -438,10 +605,23 @@ func (r *Repository) SetServiceTags(ctx context.Context, serviceID string, tags
 	}
 	defer func() {
 		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
-			slog.Error("failed to rollback transaction", "error", err)
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
 		}
 	}()
 
+	if err := r.SetServiceTagsTx(ctx, tx, serviceID, tags); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SetServiceTagsTx replaces all tags for a service within a transaction.
+func (r *Repository) SetServiceTagsTx(ctx context.Context, tx pgx.Tx, serviceID string, tags []domain.ServiceTag) error {
 	deleteQuery := `DELETE FROM service_tags WHERE service_id = $1`
 	if _, err := tx.Exec(ctx, deleteQuery, serviceID); err != nil {
 		return fmt.Errorf("delete old tags: %w", err)
@@ -462,10 +642,6 @@ func (r *Repository) SetServiceTags(ctx context.Context, serviceID string, tags
 		}
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("commit transaction: %w", err)
-	}
-
 	return nil
 }
 
@@ -500,32 +676,21 @@ func (r *Repository) GetServiceTags(ctx context.Context, serviceID string) ([]do
 	return tags, nil
 }
 
-// SetServiceGroups replaces all group memberships for a service.
-func (r *Repository) SetServiceGroups(ctx context.Context, serviceID string, groupIDs []string) error {
+// SetServiceGroups replaces all group memberships for a service and logs the changes
+// made by actorUserID ("" if unknown).
+func (r *Repository) SetServiceGroups(ctx context.Context, serviceID string, groupIDs []string, actorUserID string) error {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
 	}
 	defer func() {
 		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
-			slog.Error("failed to rollback transaction", "error", err)
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
 		}
 	}()
 
-	// Delete old group memberships
-	_, err = tx.Exec(ctx, `DELETE FROM service_group_members WHERE service_id = $1`, serviceID)
-	if err != nil {
-		return fmt.Errorf("delete old group memberships: %w", err)
-	}
-
-	// Insert new group memberships
-	for _, groupID := range groupIDs {
-		_, err = tx.Exec(ctx,
-			`INSERT INTO service_group_members (service_id, group_id) VALUES ($1, $2)`,
-			serviceID, groupID)
-		if err != nil {
-			return fmt.Errorf("insert group membership: %w", err)
-		}
+	if err := r.SetServiceGroupsTx(ctx, tx, serviceID, groupIDs, actorUserID); err != nil {
+		return err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -586,18 +751,75 @@ func (r *Repository) GetGroupServices(ctx context.Context, groupID string) ([]st
 	return serviceIDs, nil
 }
 
-// SetGroupServices replaces all service memberships for a group.
-func (r *Repository) SetGroupServices(ctx context.Context, groupID string, serviceIDs []string) error {
+// getGroupsByService returns group IDs of n services (id(i) is the i-th service ID)
+// keyed by service ID, in one query. Every service has an entry, empty if it is in no group.
+func (r *Repository) getGroupsByService(ctx context.Context, n int, id func(i int) string) (map[string][]string, error) {
+	return r.getMembers(ctx,
+		`SELECT service_id, group_id FROM service_group_members WHERE service_id = ANY($1) ORDER BY service_id, group_id`,
+		n, id)
+}
+
+// getServicesByGroup returns service IDs of n groups (id(i) is the i-th group ID)
+// keyed by group ID, in one query. Every group has an entry, empty if it has no services.
+func (r *Repository) getServicesByGroup(ctx context.Context, n int, id func(i int) string) (map[string][]string, error) {
+	return r.getMembers(ctx,
+		`SELECT group_id, service_id FROM service_group_members WHERE group_id = ANY($1) ORDER BY group_id, service_id`,
+		n, id)
+}
+
+// getMembers runs a (key, member) membership query for n keys and groups members by key.
+// No query is issued for zero keys.
+func (r *Repository) getMembers(ctx context.Context, query string, n int, id func(i int) string) (map[string][]string, error) {
+	members := make(map[string][]string, n)
+	if n == 0 {
+		return members, nil
+	}
+
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = id(i)
+		members[keys[i]] = make([]string, 0)
+	}
+
+	rows, err := r.db.Query(ctx, query, keys)
+	if err != nil {
+		return nil, fmt.Errorf("get group members: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, member string
+		if err := rows.Scan(&key, &member); err != nil {
+			return nil, fmt.Errorf("scan group member: %w", err)
+		}
+		members[key] = append(members[key], member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate group members: %w", err)
+	}
+
+	return members, nil
+}
+
+// SetGroupServices replaces all service memberships for a group and logs the changes
+// made by actorUserID ("" if unknown).
+func (r *Repository) SetGroupServices(ctx context.Context, groupID string, serviceIDs []string, actorUserID string) error {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
 	}
 	defer func() {
 		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
-			slog.Error("failed to rollback transaction", "error", err)
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
 		}
 	}()
 
+	current, err := queryIDsTx(ctx, tx, `SELECT service_id FROM service_group_members WHERE group_id = $1`, groupID)
+	if err != nil {
+		return fmt.Errorf("get current service memberships: %w", err)
+	}
+
 	// Delete old service memberships
 	_, err = tx.Exec(ctx, `DELETE FROM service_group_members WHERE group_id = $1`, groupID)
 	if err != nil {
@@ -614,6 +836,27 @@ func (r *Repository) SetGroupServices(ctx context.Context, groupID string, servi
 		}
 	}
 
+	added, removed := membershipChanges(current, serviceIDs)
+	for _, change := range []struct {
+		action     domain.MembershipAction
+		serviceIDs []string
+	}{
+		{domain.MembershipActionAdded, added},
+		{domain.MembershipActionRemoved, removed},
+	} {
+		for _, serviceID := range change.serviceIDs {
+			entry := &domain.MembershipLogEntry{
+				ServiceID:   serviceID,
+				GroupID:     groupID,
+				Action:      change.action,
+				ActorUserID: optionalUserID(actorUserID),
+			}
+			if err := r.CreateMembershipLogEntryTx(ctx, tx, entry); err != nil {
+				return err
+			}
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("commit transaction: %w", err)
 	}
@@ -645,17 +888,87 @@ func (r *Repository) ArchiveService(ctx context.Context, id string) error {
 	return nil
 }
 
-// RestoreService restores an archived service by clearing archived_at.
-func (r *Repository) RestoreService(ctx context.Context, id string) error {
-	query := `UPDATE services SET archived_at = NULL, updated_at = NOW() WHERE id = $1 AND archived_at IS NOT NULL`
-	result, err := r.db.Exec(ctx, query, id)
+// BulkArchiveServices archives multiple services in a single transaction.
+// Per-ID failures (not found, active events) are collected instead of aborting the batch.
+// Services that are already archived are reported as archived.
+func (r *Repository) BulkArchiveServices(ctx context.Context, ids []string) ([]string, []catalog.BulkError, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	archived := make([]string, 0, len(ids))
+	failed := make([]catalog.BulkError, 0)
+	seen := make(map[string]bool, len(ids))
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		var archivedAt *string
+		err := tx.QueryRow(ctx, `SELECT archived_at::text FROM services WHERE id = $1 FOR UPDATE`, id).Scan(&archivedAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				failed = append(failed, catalog.BulkError{ID: id, Reason: "not found"})
+				continue
+			}
+			return nil, nil, fmt.Errorf("lock service %s: %w", id, err)
+		}
+		if archivedAt != nil {
+			archived = append(archived, id)
+			continue
+		}
+
+		var activeCount int
+		err = tx.QueryRow(ctx, `
+			SELECT COUNT(DISTINCT e.id)
+			FROM events e
+			JOIN event_services es ON e.id = es.event_id
+			WHERE es.service_id = $1
+			  AND e.status NOT IN ('resolved', 'completed', 'scheduled')
+		`, id).Scan(&activeCount)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get active event count for service %s: %w", id, err)
+		}
+		if activeCount > 0 {
+			failed = append(failed, catalog.BulkError{ID: id, Reason: "has active events"})
+			continue
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE services SET archived_at = NOW(), updated_at = NOW() WHERE id = $1`, id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("archive service %s: %w", id, err)
+		}
+		archived = append(archived, id)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return archived, failed, nil
+}
+
+// RestoreServiceTx restores an archived service by clearing archived_at and resetting status to operational.
+func (r *Repository) RestoreServiceTx(ctx context.Context, tx pgx.Tx, id string) error {
+	query := `
+		UPDATE services SET archived_at = NULL, status = 'operational', updated_at = NOW()
+		WHERE id = $1 AND archived_at IS NOT NULL
+	`
+	result, err := tx.Exec(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("restore service: %w", err)
 	}
 	if result.RowsAffected() == 0 {
 		// Check if not found or not archived
 		var archivedAt *string
-		err := r.db.QueryRow(ctx, `SELECT archived_at::text FROM services WHERE id = $1`, id).Scan(&archivedAt)
+		err := tx.QueryRow(ctx, `SELECT archived_at::text FROM services WHERE id = $1`, id).Scan(&archivedAt)
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				return catalog.ErrServiceNotFound
@@ -663,7 +976,7 @@ func (r *Repository) RestoreService(ctx context.Context, id string) error {
 			return fmt.Errorf("check service exists: %w", err)
 		}
 		if archivedAt == nil {
-			return catalog.ErrNotArchived
+			return catalog.ErrServiceNotArchived
 		}
 		return catalog.ErrServiceNotFound
 	}
@@ -713,7 +1026,7 @@ func (r *Repository) RestoreGroup(ctx context.Context, id string) error {
 			return fmt.Errorf("check group exists: %w", err)
 		}
 		if archivedAt == nil {
-			return catalog.ErrNotArchived
+			return catalog.ErrGroupNotArchived
 		}
 		return catalog.ErrGroupNotFound
 	}
@@ -739,6 +1052,26 @@ func (r *Repository) GetActiveEventCountForService(ctx context.Context, serviceI
 	return count, nil
 }
 
+// HasActiveEventsBySlug reports whether the service with the given slug has active events.
+// Active events are the same as in GetActiveEventCountForService.
+func (r *Repository) HasActiveEventsBySlug(ctx context.Context, slug string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM services s
+			JOIN event_services es ON es.service_id = s.id
+			JOIN events e ON e.id = es.event_id
+			WHERE s.slug = $1
+			  AND e.status NOT IN ('resolved', 'completed', 'scheduled')
+		)
+	`
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, slug).Scan(&exists); err != nil {
+		return false, fmt.Errorf("check active events by slug: %w", err)
+	}
+	return exists, nil
+}
+
 // GetActiveEventCountForGroup returns count of active events for any service in the group.
 // Active events are those that affect service effective_status: NOT resolved, completed, or scheduled.
 // Scheduled maintenance is not considered active until it transitions to in_progress.
@@ -837,7 +1170,9 @@ func (r *Repository) GetServiceByIDWithEffectiveStatus(ctx context.Context, id s
 func (r *Repository) ListServicesWithEffectiveStatus(ctx context.Context, filter catalog.ServiceFilter) ([]domain.ServiceWithEffectiveStatus, error) {
 	query := `
 		SELECT
-			s.id, s.name, s.slug, s.description, s.status, s."order",
+			s.id, s.name, s.slug, s.description, s.status, s."order", s.sla_uptime_target,
+			COALESCE(s.external_url, ''), COALESCE(s.documentation_url, ''), s.custom_fields,
+			COALESCE(s.health_check_url, ''), s.health_check_interval_seconds, COALESCE(s.icon_url, ''),
 			s.created_at, s.updated_at, s.archived_at,
 			v.effective_status, v.has_active_events
 		FROM services s
@@ -857,12 +1192,16 @@ func (r *Repository) ListServicesWithEffectiveStatus(ctx context.Context, filter
 		// Filter by effective_status, not stored status
 		query += fmt.Sprintf(" AND v.effective_status = $%d", argNum)
 		args = append(args, *filter.Status)
+		argNum++
 	}
 
 	if !filter.IncludeArchived {
 		query += " AND s.archived_at IS NULL"
 	}
 
+	query, args, argNum = appendCustomFieldFilters(query, args, argNum, "s.custom_fields", filter.CustomFields)
+	query, args = appendTagFilters(query, args, argNum, "s.id", filter.Tags)
+
 	query += ` ORDER BY s."order", s.name`
 
 	rows, err := r.db.Query(ctx, query, args...)
@@ -875,7 +1214,9 @@ func (r *Repository) ListServicesWithEffectiveStatus(ctx context.Context, filter
 	for rows.Next() {
 		var svc domain.ServiceWithEffectiveStatus
 		err := rows.Scan(
-			&svc.ID, &svc.Name, &svc.Slug, &svc.Description, &svc.Status, &svc.Order,
+			&svc.ID, &svc.Name, &svc.Slug, &svc.Description, &svc.Status, &svc.Order, &svc.SLAUptimeTarget,
+			&svc.ExternalURL, &svc.DocumentationURL, &svc.CustomFields,
+			&svc.HealthCheckURL, &svc.HealthCheckIntervalSeconds, &svc.IconURL,
 			&svc.CreatedAt, &svc.UpdatedAt, &svc.ArchivedAt,
 			&svc.EffectiveStatus, &svc.HasActiveEvents,
 		)
@@ -889,18 +1230,169 @@ func (r *Repository) ListServicesWithEffectiveStatus(ctx context.Context, filter
 		return nil, fmt.Errorf("iterate services: %w", err)
 	}
 
-	// Load group_ids for each service
+	// Load group_ids of all services in one query
+	groupIDs, err := r.getGroupsByService(ctx, len(result), func(i int) string { return result[i].ID })
+	if err != nil {
+		return nil, err
+	}
 	for i := range result {
-		groupIDs, err := r.GetServiceGroups(ctx, result[i].ID)
+		result[i].GroupIDs = groupIDs[result[i].ID]
+	}
+
+	return result, nil
+}
+
+// groupEffectiveStatusJoin aggregates the worst-case effective status of the
+// non-archived member services of group g. Empty groups yield NULLs.
+const groupEffectiveStatusJoin = `
+	LEFT JOIN LATERAL (
+		SELECT
+			(ARRAY_AGG(v.effective_status ORDER BY service_status_priority(v.effective_status) DESC))[1] AS effective_status,
+			BOOL_OR(v.has_active_events) AS has_active_events
+		FROM service_group_members sgm
+		JOIN services s ON s.id = sgm.service_id AND s.archived_at IS NULL
+		JOIN v_service_effective_status v ON v.id = s.id
+		WHERE sgm.group_id = g.id
+	) gs ON true
+`
+
+// GetGroupEffectiveStatus returns the worst-case effective status among the non-archived
+// services of a group and whether any of them has active events.
+// A group without services is operational.
+func (r *Repository) GetGroupEffectiveStatus(ctx context.Context, groupID string) (domain.ServiceStatus, bool, error) {
+	query := `
+		SELECT COALESCE(gs.effective_status, 'operational'), COALESCE(gs.has_active_events, false)
+		FROM service_groups g
+	` + groupEffectiveStatusJoin + `
+		WHERE g.id = $1
+	`
+	var effectiveStatus domain.ServiceStatus
+	var hasActiveEvents bool
+	err := r.db.QueryRow(ctx, query, groupID).Scan(&effectiveStatus, &hasActiveEvents)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, catalog.ErrGroupNotFound
+		}
+		return "", false, fmt.Errorf("get group effective status: %w", err)
+	}
+	return effectiveStatus, hasActiveEvents, nil
+}
+
+// GetGroupBySlugWithEffectiveStatus returns a service group with its effective status.
+func (r *Repository) GetGroupBySlugWithEffectiveStatus(ctx context.Context, slug string) (*domain.GroupWithEffectiveStatus, error) {
+	group, err := r.GetGroupBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveStatus, hasActiveEvents, err := r.GetGroupEffectiveStatus(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.GroupWithEffectiveStatus{
+		ServiceGroup:    *group,
+		EffectiveStatus: effectiveStatus,
+		HasActiveEvents: hasActiveEvents,
+	}, nil
+}
+
+// ListGroupsWithEffectiveStatus returns service groups with their effective statuses
+// computed in the same query.
+func (r *Repository) ListGroupsWithEffectiveStatus(ctx context.Context, filter catalog.GroupFilter) ([]domain.GroupWithEffectiveStatus, error) {
+	query := `
+		SELECT
+			g.id, g.name, g.slug, g.description, g."order",
+			g.created_at, g.updated_at, g.archived_at, g.parent_group_id,
+			COALESCE(gs.effective_status, 'operational'), COALESCE(gs.has_active_events, false)
+		FROM service_groups g
+	` + groupEffectiveStatusJoin
+
+	if !filter.IncludeArchived {
+		query += " WHERE g.archived_at IS NULL"
+	}
+
+	query += ` ORDER BY g."order", g.name`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list service groups with effective status: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.GroupWithEffectiveStatus, 0)
+	for rows.Next() {
+		var group domain.GroupWithEffectiveStatus
+		err := rows.Scan(
+			&group.ID, &group.Name, &group.Slug, &group.Description, &group.Order,
+			&group.CreatedAt, &group.UpdatedAt, &group.ArchivedAt, &group.ParentGroupID,
+			&group.EffectiveStatus, &group.HasActiveEvents,
+		)
 		if err != nil {
-			return nil, fmt.Errorf("get service groups: %w", err)
+			return nil, fmt.Errorf("scan service group: %w", err)
 		}
-		result[i].GroupIDs = groupIDs
+		result = append(result, group)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate service groups: %w", err)
+	}
+
+	// Load service_ids of all groups in one query
+	serviceIDs, err := r.getServicesByGroup(ctx, len(result), func(i int) string { return result[i].ID })
+	if err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].ServiceIDs = serviceIDs[result[i].ID]
+	}
+
+	attachChildGroups(len(result), func(i int) *domain.ServiceGroup { return &result[i].ServiceGroup })
+
 	return result, nil
 }
 
+// appendTagFilters adds one EXISTS clause per tag so that only services
+// having ALL of the given key=value pairs match.
+// Keys are sorted to keep the generated SQL deterministic.
+func appendTagFilters(query string, args []interface{}, argNum int, serviceIDColumn string, tags map[string]string) (string, []interface{}) {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		query += fmt.Sprintf(
+			" AND EXISTS (SELECT 1 FROM service_tags st WHERE st.service_id = %s AND st.key = $%d AND st.value = $%d)",
+			serviceIDColumn, argNum, argNum+1,
+		)
+		args = append(args, key, tags[key])
+		argNum += 2
+	}
+
+	return query, args
+}
+
+// appendCustomFieldFilters adds a single containment clause so that only services
+// having ALL of the given custom fields match; it can use the GIN index on custom_fields.
+func appendCustomFieldFilters(query string, args []interface{}, argNum int, column string, fields map[string]string) (string, []interface{}, int) {
+	if len(fields) == 0 {
+		return query, args, argNum
+	}
+	query += fmt.Sprintf(" AND %s @> $%d::jsonb", column, argNum)
+	args = append(args, fields)
+	return query, args, argNum + 1
+}
+
+// customFieldsParam keeps a service without custom fields stored as {} rather than NULL.
+func customFieldsParam(fields map[string]string) map[string]string {
+	if fields == nil {
+		return map[string]string{}
+	}
+	return fields
+}
+
 // BeginTx starts a new transaction.
 func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
 	return r.db.Begin(ctx)
@@ -910,7 +1402,10 @@ func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
 func (r *Repository) UpdateServiceTx(ctx context.Context, tx pgx.Tx, service *domain.Service) error {
 	query := `
 		UPDATE services
-		SET name = $2, slug = $3, description = $4, status = $5, "order" = $6, updated_at = NOW()
+		SET name = $2, slug = $3, description = $4, status = $5, "order" = $6,
+			external_url = NULLIF($7, ''), documentation_url = NULLIF($8, ''), custom_fields = $9,
+			health_check_url = NULLIF($10, ''), health_check_interval_seconds = $11, icon_url = NULLIF($12, ''),
+			updated_at = NOW()
 		WHERE id = $1
 		RETURNING updated_at
 	`
@@ -921,6 +1416,12 @@ func (r *Repository) UpdateServiceTx(ctx context.Context, tx pgx.Tx, service *do
 		service.Description,
 		service.Status,
 		service.Order,
+		service.ExternalURL,
+		service.DocumentationURL,
+		customFieldsParam(service.CustomFields),
+		service.HealthCheckURL,
+		service.HealthCheckIntervalSeconds,
+		service.IconURL,
 	).Scan(&service.UpdatedAt)
 
 	if err != nil {
@@ -932,10 +1433,16 @@ func (r *Repository) UpdateServiceTx(ctx context.Context, tx pgx.Tx, service *do
 	return nil
 }
 
-// SetServiceGroupsTx replaces all group memberships for a service within a transaction.
-func (r *Repository) SetServiceGroupsTx(ctx context.Context, tx pgx.Tx, serviceID string, groupIDs []string) error {
+// SetServiceGroupsTx replaces all group memberships for a service within a transaction
+// and logs the changes made by actorUserID ("" if unknown).
+func (r *Repository) SetServiceGroupsTx(ctx context.Context, tx pgx.Tx, serviceID string, groupIDs []string, actorUserID string) error {
+	current, err := queryIDsTx(ctx, tx, `SELECT group_id FROM service_group_members WHERE service_id = $1`, serviceID)
+	if err != nil {
+		return fmt.Errorf("get current group memberships: %w", err)
+	}
+
 	// Delete old group memberships
-	_, err := tx.Exec(ctx, `DELETE FROM service_group_members WHERE service_id = $1`, serviceID)
+	_, err = tx.Exec(ctx, `DELETE FROM service_group_members WHERE service_id = $1`, serviceID)
 	if err != nil {
 		return fmt.Errorf("delete old group memberships: %w", err)
 	}
@@ -949,6 +1456,254 @@ func (r *Repository) SetServiceGroupsTx(ctx context.Context, tx pgx.Tx, serviceI
 			return fmt.Errorf("insert group membership: %w", err)
 		}
 	}
+
+	added, removed := membershipChanges(current, groupIDs)
+	for _, change := range []struct {
+		action   domain.MembershipAction
+		groupIDs []string
+	}{
+		{domain.MembershipActionAdded, added},
+		{domain.MembershipActionRemoved, removed},
+	} {
+		for _, groupID := range change.groupIDs {
+			entry := &domain.MembershipLogEntry{
+				ServiceID:   serviceID,
+				GroupID:     groupID,
+				Action:      change.action,
+				ActorUserID: optionalUserID(actorUserID),
+			}
+			if err := r.CreateMembershipLogEntryTx(ctx, tx, entry); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// membershipChanges returns IDs in next but not in current (added) and
+// IDs in current but not in next (removed).
+func membershipChanges(current, next []string) (added, removed []string) {
+	inCurrent := make(map[string]bool, len(current))
+	for _, id := range current {
+		inCurrent[id] = true
+	}
+	inNext := make(map[string]bool, len(next))
+	for _, id := range next {
+		if !inCurrent[id] && !inNext[id] {
+			added = append(added, id)
+		}
+		inNext[id] = true
+	}
+	for _, id := range current {
+		if !inNext[id] {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
+
+// optionalUserID maps an unknown ("") user to NULL.
+func optionalUserID(userID string) *string {
+	if userID == "" {
+		return nil
+	}
+	return &userID
+}
+
+// queryIDsTx returns the single ID column of query rows within a transaction.
+func queryIDsTx(ctx context.Context, tx pgx.Tx, query string, args ...interface{}) ([]string, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// CreateMembershipLogEntryTx records a group membership change within a transaction.
+func (r *Repository) CreateMembershipLogEntryTx(ctx context.Context, tx pgx.Tx, entry *domain.MembershipLogEntry) error {
+	query := `
+		INSERT INTO service_group_membership_log (service_id, group_id, action, actor_user_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	err := tx.QueryRow(ctx, query, entry.ServiceID, entry.GroupID, entry.Action, entry.ActorUserID).
+		Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create membership log entry: %w", err)
+	}
+	return nil
+}
+
+// ListMembershipLog returns group membership changes of a service, newest first,
+// with the slug and name of each group.
+func (r *Repository) ListMembershipLog(ctx context.Context, serviceID string, limit, offset int) ([]domain.MembershipLogEntry, error) {
+	query := `
+		SELECT l.id, l.service_id, l.group_id, g.slug, g.name, l.action, l.actor_user_id, l.created_at
+		FROM service_group_membership_log l
+		JOIN service_groups g ON g.id = l.group_id
+		WHERE l.service_id = $1
+		ORDER BY l.created_at DESC, l.id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, serviceID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list membership log: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.MembershipLogEntry, 0)
+	for rows.Next() {
+		var entry domain.MembershipLogEntry
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.ServiceID,
+			&entry.GroupID,
+			&entry.GroupSlug,
+			&entry.GroupName,
+			&entry.Action,
+			&entry.ActorUserID,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan membership log entry: %w", err)
+		}
+		result = append(result, entry)
+	}
+	return result, rows.Err()
+}
+
+// CountMembershipLog returns the number of group membership changes of a service.
+func (r *Repository) CountMembershipLog(ctx context.Context, serviceID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx,
+		`SELECT COUNT(*) FROM service_group_membership_log WHERE service_id = $1`, serviceID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count membership log: %w", err)
+	}
+	return count, nil
+}
+
+// Advisory lock keys serializing order assignment of services and groups.
+const (
+	serviceOrderLockKey = "services.order"
+	groupOrderLockKey   = "service_groups.order"
+)
+
+// lockOrderTx takes a transaction-scoped advisory lock on order assignment,
+// so concurrent creations don't pick the same order.
+func lockOrderTx(ctx context.Context, tx pgx.Tx, key string) error {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, key); err != nil {
+		return fmt.Errorf("lock order: %w", err)
+	}
+	return nil
+}
+
+// GetMaxServiceOrderTx returns the highest service order, or -1 if there are no services.
+// Locks service order assignment until the transaction ends.
+func (r *Repository) GetMaxServiceOrderTx(ctx context.Context, tx pgx.Tx) (int, error) {
+	if err := lockOrderTx(ctx, tx, serviceOrderLockKey); err != nil {
+		return 0, err
+	}
+
+	var maxOrder int
+	if err := tx.QueryRow(ctx, `SELECT COALESCE(MAX("order"), -1) FROM services`).Scan(&maxOrder); err != nil {
+		return 0, fmt.Errorf("get max service order: %w", err)
+	}
+	return maxOrder, nil
+}
+
+// ShiftServiceOrdersFromTx increments the order of services at or after fromOrder
+// if fromOrder is taken. Locks service order assignment until the transaction ends.
+func (r *Repository) ShiftServiceOrdersFromTx(ctx context.Context, tx pgx.Tx, fromOrder int) error {
+	if err := lockOrderTx(ctx, tx, serviceOrderLockKey); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE services
+		SET "order" = "order" + 1, updated_at = NOW()
+		WHERE "order" >= $1
+		  AND EXISTS (SELECT 1 FROM services WHERE "order" = $1)
+	`
+	if _, err := tx.Exec(ctx, query, fromOrder); err != nil {
+		return fmt.Errorf("shift service orders: %w", err)
+	}
+	return nil
+}
+
+// GetMaxGroupOrderTx returns the highest group order, or -1 if there are no groups.
+// Locks group order assignment until the transaction ends.
+func (r *Repository) GetMaxGroupOrderTx(ctx context.Context, tx pgx.Tx) (int, error) {
+	if err := lockOrderTx(ctx, tx, groupOrderLockKey); err != nil {
+		return 0, err
+	}
+
+	var maxOrder int
+	if err := tx.QueryRow(ctx, `SELECT COALESCE(MAX("order"), -1) FROM service_groups`).Scan(&maxOrder); err != nil {
+		return 0, fmt.Errorf("get max group order: %w", err)
+	}
+	return maxOrder, nil
+}
+
+// ShiftGroupOrdersFromTx increments the order of groups at or after fromOrder
+// if fromOrder is taken. Locks group order assignment until the transaction ends.
+func (r *Repository) ShiftGroupOrdersFromTx(ctx context.Context, tx pgx.Tx, fromOrder int) error {
+	if err := lockOrderTx(ctx, tx, groupOrderLockKey); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE service_groups
+		SET "order" = "order" + 1, updated_at = NOW()
+		WHERE "order" >= $1
+		  AND EXISTS (SELECT 1 FROM service_groups WHERE "order" = $1)
+	`
+	if _, err := tx.Exec(ctx, query, fromOrder); err != nil {
+		return fmt.Errorf("shift group orders: %w", err)
+	}
+	return nil
+}
+
+// BatchUpdateServiceOrder sets the order of non-archived services in one transaction.
+// Returns ErrServiceNotFound (nothing updated) if any service is missing or archived.
+func (r *Repository) BatchUpdateServiceOrder(ctx context.Context, items []catalog.ServiceOrderItem) error {
+	ids := make([]string, len(items))
+	orders := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+		orders[i] = item.Order
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	if err := lockOrderTx(ctx, tx, serviceOrderLockKey); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE services s
+		SET "order" = v.ord, updated_at = NOW()
+		FROM unnest($1::uuid[], $2::int[]) AS v(id, ord)
+		WHERE s.id = v.id AND s.archived_at IS NULL
+	`
+	result, err := tx.Exec(ctx, query, ids, orders)
+	if err != nil {
+		return fmt.Errorf("update service order: %w", err)
+	}
+	if result.RowsAffected() != int64(len(items)) {
+		return catalog.ErrServiceNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
 	return nil
 }
 
@@ -965,6 +1720,83 @@ func (r *Repository) UpdateServiceStatusTx(ctx context.Context, tx pgx.Tx, servi
 	return nil
 }
 
+// ResetServicesToOperationalTx sets the given services to operational unless another
+// active event (not resolved, completed or scheduled) besides eventID still affects them,
+// and logs each change for eventID with source. One statement for all services; returns the reset IDs.
+func (r *Repository) ResetServicesToOperationalTx(ctx context.Context, tx pgx.Tx, serviceIDs []string, eventID string, source domain.StatusLogSourceType, reason, createdBy string) ([]string, error) {
+	if len(serviceIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		WITH reset AS (
+			UPDATE services s
+			SET status = 'operational', updated_at = NOW()
+			FROM services old
+			WHERE old.id = s.id
+			  AND s.id = ANY($1::uuid[])
+			  AND NOT EXISTS (
+				SELECT 1
+				FROM event_services es
+				JOIN events e ON e.id = es.event_id
+				WHERE es.service_id = s.id
+				  AND e.id != $2
+				  AND e.status NOT IN ('resolved', 'completed', 'scheduled')
+			  )
+			RETURNING s.id, old.status AS old_status
+		)
+		INSERT INTO service_status_log (service_id, old_status, new_status, source_type, event_id, reason, created_by)
+		SELECT id, old_status, 'operational', $3, $2, $4, $5
+		FROM reset
+		RETURNING service_id
+	`
+	rows, err := tx.Query(ctx, query, serviceIDs, eventID, source, reason, createdBy)
+	if err != nil {
+		return nil, fmt.Errorf("reset services to operational: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan reset service: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// GetEffectiveStatusesTx returns the effective statuses of the given services as seen by tx,
+// in one query. Unknown IDs are absent from the result.
+func (r *Repository) GetEffectiveStatusesTx(ctx context.Context, tx pgx.Tx, serviceIDs []string) (map[string]domain.ServiceStatus, error) {
+	statuses := make(map[string]domain.ServiceStatus, len(serviceIDs))
+	if len(serviceIDs) == 0 {
+		return statuses, nil
+	}
+
+	query := `
+		SELECT id, effective_status
+		FROM v_service_effective_status
+		WHERE id = ANY($1::uuid[])
+	`
+	rows, err := tx.Query(ctx, query, serviceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get effective statuses: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var status domain.ServiceStatus
+		if err := rows.Scan(&id, &status); err != nil {
+			return nil, fmt.Errorf("scan effective status: %w", err)
+		}
+		statuses[id] = status
+	}
+	return statuses, rows.Err()
+}
+
 // CreateStatusLogEntry creates a new entry in the service status log.
 func (r *Repository) CreateStatusLogEntry(ctx context.Context, entry *domain.ServiceStatusLogEntry) error {
 	query := `
@@ -1037,6 +1869,155 @@ func (r *Repository) ListStatusLog(ctx context.Context, serviceID string, limit,
 	return result, rows.Err()
 }
 
+// GetLatestStatusLogEntry returns the most recent status log entry of a service,
+// nil if the service has none.
+func (r *Repository) GetLatestStatusLogEntry(ctx context.Context, serviceID string) (*domain.ServiceStatusLogEntry, error) {
+	query := `
+		SELECT id, service_id, old_status, new_status, source_type, event_id, reason, created_by, created_at
+		FROM service_status_log
+		WHERE service_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+	var entry domain.ServiceStatusLogEntry
+	err := r.db.QueryRow(ctx, query, serviceID).Scan(
+		&entry.ID,
+		&entry.ServiceID,
+		&entry.OldStatus,
+		&entry.NewStatus,
+		&entry.SourceType,
+		&entry.EventID,
+		&entry.Reason,
+		&entry.CreatedBy,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get latest status log entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// ListStatusLogRange returns status log entries created in [from, to) in chronological order,
+// preceded by the latest entry before from (if any), which defines the status at the start of the range.
+func (r *Repository) ListStatusLogRange(ctx context.Context, serviceID string, from, to time.Time) ([]domain.ServiceStatusLogEntry, error) {
+	query := `
+		(
+			SELECT id, service_id, old_status, new_status, source_type, event_id, reason, created_by, created_at
+			FROM service_status_log
+			WHERE service_id = $1 AND created_at < $2
+			ORDER BY created_at DESC
+			LIMIT 1
+		)
+		UNION ALL
+		(
+			SELECT id, service_id, old_status, new_status, source_type, event_id, reason, created_by, created_at
+			FROM service_status_log
+			WHERE service_id = $1 AND created_at >= $2 AND created_at < $3
+		)
+		ORDER BY created_at ASC
+	`
+	rows, err := r.db.Query(ctx, query, serviceID, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("list status log range: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.ServiceStatusLogEntry, 0)
+	for rows.Next() {
+		var entry domain.ServiceStatusLogEntry
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.ServiceID,
+			&entry.OldStatus,
+			&entry.NewStatus,
+			&entry.SourceType,
+			&entry.EventID,
+			&entry.Reason,
+			&entry.CreatedBy,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan status log entry: %w", err)
+		}
+		result = append(result, entry)
+	}
+	return result, rows.Err()
+}
+
+// ListStatusTimeline returns status log entries created in [from, to) in chronological order,
+// joined with the event that caused each change and the latest update of that event made
+// at or before the change. Events are keyed by ID.
+func (r *Repository) ListStatusTimeline(ctx context.Context, serviceID string, from, to time.Time) ([]catalog.ServiceStatusLogEntry, map[string]*domain.Event, error) {
+	query := `
+		SELECT l.id, l.service_id, l.old_status, l.new_status, l.source_type, l.event_id, l.reason, l.created_by, l.created_at,
+		       e.title, e.type, e.status, u.message
+		FROM service_status_log l
+		LEFT JOIN events e ON e.id = l.event_id
+		LEFT JOIN LATERAL (
+			SELECT eu.message
+			FROM event_updates eu
+			WHERE eu.event_id = l.event_id AND eu.created_at <= l.created_at
+			ORDER BY eu.created_at DESC
+			LIMIT 1
+		) u ON true
+		WHERE l.service_id = $1 AND l.created_at >= $2 AND l.created_at < $3
+		ORDER BY l.created_at ASC
+	`
+	rows, err := r.db.Query(ctx, query, serviceID, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, nil, fmt.Errorf("list status timeline: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]catalog.ServiceStatusLogEntry, 0)
+	events := make(map[string]*domain.Event)
+	for rows.Next() {
+		var entry catalog.ServiceStatusLogEntry
+		var (
+			reason, title, message *string
+			eventType              *domain.EventType
+			eventStatus            *domain.EventStatus
+		)
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.ServiceID,
+			&entry.OldStatus,
+			&entry.NewStatus,
+			&entry.SourceType,
+			&entry.EventID,
+			&reason,
+			&entry.CreatedBy,
+			&entry.CreatedAt,
+			&title,
+			&eventType,
+			&eventStatus,
+			&message,
+		); err != nil {
+			return nil, nil, fmt.Errorf("scan status timeline entry: %w", err)
+		}
+		if reason != nil {
+			entry.Reason = *reason
+		}
+		if message != nil {
+			entry.UpdateMessage = *message
+		}
+		if entry.EventID != nil && title != nil {
+			if _, ok := events[*entry.EventID]; !ok {
+				events[*entry.EventID] = &domain.Event{
+					ID:     *entry.EventID,
+					Title:  *title,
+					Type:   *eventType,
+					Status: *eventStatus,
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, events, rows.Err()
+}
+
 // CountStatusLog returns the total number of log entries for a service.
 func (r *Repository) CountStatusLog(ctx context.Context, serviceID string) (int, error) {
 	query := `SELECT COUNT(*) FROM service_status_log WHERE service_id = $1`
@@ -1052,6 +2033,83 @@ func (r *Repository) DeleteStatusLogByEventIDTx(ctx context.Context, tx pgx.Tx,
 	return err
 }
 
+// SetServiceSLATarget sets the monthly uptime target of a service (nil removes it)
+// and clears the breach notification mark, so a new target can alert again this month.
+func (r *Repository) SetServiceSLATarget(ctx context.Context, serviceID string, target *float64) error {
+	query := `
+		UPDATE services
+		SET sla_uptime_target = $2, sla_breach_notified_month = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+	result, err := r.db.Exec(ctx, query, serviceID, target)
+	if err != nil {
+		return fmt.Errorf("set service sla target: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return catalog.ErrServiceNotFound
+	}
+	return nil
+}
+
+// ListServicesWithSLATarget returns non-archived services that have an SLA target, without group IDs.
+func (r *Repository) ListServicesWithSLATarget(ctx context.Context) ([]domain.Service, error) {
+	query := `
+		SELECT id, name, slug, description, status, "order", sla_uptime_target,
+			COALESCE(external_url, ''), COALESCE(documentation_url, ''), custom_fields,
+			COALESCE(health_check_url, ''), health_check_interval_seconds, COALESCE(icon_url, ''), created_at, updated_at, archived_at
+		FROM services
+		WHERE sla_uptime_target IS NOT NULL AND archived_at IS NULL
+		ORDER BY "order", name
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list services with sla target: %w", err)
+	}
+	defer rows.Close()
+
+	services := make([]domain.Service, 0)
+	for rows.Next() {
+		var service domain.Service
+		if err := rows.Scan(
+			&service.ID,
+			&service.Name,
+			&service.Slug,
+			&service.Description,
+			&service.Status,
+			&service.Order,
+			&service.SLAUptimeTarget,
+			&service.ExternalURL,
+			&service.DocumentationURL,
+			&service.CustomFields,
+			&service.HealthCheckURL,
+			&service.HealthCheckIntervalSeconds,
+			&service.IconURL,
+			&service.CreatedAt,
+			&service.UpdatedAt,
+			&service.ArchivedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan service: %w", err)
+		}
+		services = append(services, service)
+	}
+	return services, rows.Err()
+}
+
+// ClaimSLABreach marks the SLA breach of a service in the month starting at month as notified.
+// Returns false if it was already claimed for that month.
+func (r *Repository) ClaimSLABreach(ctx context.Context, serviceID string, month time.Time) (bool, error) {
+	query := `
+		UPDATE services
+		SET sla_breach_notified_month = $2
+		WHERE id = $1 AND sla_breach_notified_month IS DISTINCT FROM $2
+	`
+	result, err := r.db.Exec(ctx, query, serviceID, month.UTC())
+	if err != nil {
+		return false, fmt.Errorf("claim sla breach: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
 // FindMissingServiceIDs returns IDs that don't exist or are archived.
 func (r *Repository) FindMissingServiceIDs(ctx context.Context, ids []string) ([]string, error) {
 	if len(ids) == 0 {
@@ -1108,3 +2166,38 @@ func (r *Repository) FindMissingGroupIDs(ctx context.Context, ids []string) ([]s
 	return missing, rows.Err()
 }
 
+// CreateSlugRedirectTx records that oldSlug moved to newSlug within a transaction.
+// Redirects to oldSlug are pointed at newSlug, so every old slug resolves in one hop,
+// and a redirect from newSlug (renaming back) is dropped.
+func (r *Repository) CreateSlugRedirectTx(ctx context.Context, tx pgx.Tx, oldSlug, newSlug string) error {
+	if _, err := tx.Exec(ctx, `UPDATE service_slug_redirects SET new_slug = $2 WHERE new_slug = $1`, oldSlug, newSlug); err != nil {
+		return fmt.Errorf("update slug redirects: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM service_slug_redirects WHERE old_slug = $1`, newSlug); err != nil {
+		return fmt.Errorf("delete slug redirect: %w", err)
+	}
+
+	query := `
+		INSERT INTO service_slug_redirects (old_slug, new_slug)
+		VALUES ($1, $2)
+		ON CONFLICT (old_slug) DO UPDATE SET new_slug = EXCLUDED.new_slug, created_at = NOW()
+	`
+	if _, err := tx.Exec(ctx, query, oldSlug, newSlug); err != nil {
+		return fmt.Errorf("create slug redirect: %w", err)
+	}
+	return nil
+}
+
+// FindSlugRedirect returns the current slug of a service renamed from oldSlug.
+// Returns catalog.ErrServiceNotFound if there is no redirect.
+func (r *Repository) FindSlugRedirect(ctx context.Context, oldSlug string) (string, error) {
+	var newSlug string
+	err := r.db.QueryRow(ctx, `SELECT new_slug FROM service_slug_redirects WHERE old_slug = $1`, oldSlug).Scan(&newSlug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", catalog.ErrServiceNotFound
+		}
+		return "", fmt.Errorf("find slug redirect: %w", err)
+	}
+	return newSlug, nil
+}
@@ -0,0 +1,133 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/catalog/postgres"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	benchServices         = 1000
+	benchEventsPerService = 10
+)
+
+// newBenchPool starts a migrated PostgreSQL container for a benchmark.
+func newBenchPool(ctx context.Context, b *testing.B) *pgxpool.Pool {
+	b.Helper()
+
+	pool, err := pgxpool.New(ctx, startMigratedPostgres(ctx, b))
+	if err != nil {
+		b.Fatalf("connect: %v", err)
+	}
+	b.Cleanup(pool.Close)
+	return pool
+}
+
+// startMigratedPostgres starts a PostgreSQL container with all migrations applied
+// and returns its connection string. The container is terminated on cleanup.
+func startMigratedPostgres(ctx context.Context, tb testing.TB) string {
+	tb.Helper()
+
+	pgContainer, err := testutil.NewPostgresContainer(ctx)
+	if err != nil {
+		tb.Fatalf("start postgres: %v", err)
+	}
+	tb.Cleanup(func() {
+		if err := pgContainer.Terminate(ctx); err != nil {
+			tb.Logf("terminate postgres: %v", err)
+		}
+	})
+
+	m, err := migrate.New("file://../../../migrations", pgContainer.ConnectionString)
+	if err != nil {
+		tb.Fatalf("create migrator: %v", err)
+	}
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		tb.Fatalf("run migrations: %v", err)
+	}
+	return pgContainer.ConnectionString
+}
+
+// seedEffectiveStatusLoad creates benchServices services, each affected by
+// benchEventsPerService active incidents.
+func seedEffectiveStatusLoad(ctx context.Context, b *testing.B, pool *pgxpool.Pool) {
+	b.Helper()
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		b.Fatalf("begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		{`INSERT INTO services (name, slug, "order")
+		 SELECT 'Bench Service ' || g, 'bench-svc-' || g, g
+		 FROM generate_series(1, $1::int) g`, []interface{}{benchServices}},
+		{`CREATE TEMP TABLE bench_events (event_id UUID, service_id UUID, n INT, status VARCHAR(50)) ON COMMIT DROP`, nil},
+		{`INSERT INTO bench_events
+		 SELECT gen_random_uuid() AS event_id, s.id AS service_id, n,
+		        (ARRAY['degraded', 'partial_outage', 'major_outage'])[1 + n % 3] AS status
+		 FROM services s, generate_series(1, $1::int) n
+		 WHERE s.slug LIKE 'bench-svc-%'`, []interface{}{benchEventsPerService}},
+		{`INSERT INTO events (id, title, type, status, severity, started_at, created_by)
+		 SELECT be.event_id, 'Bench incident ' || be.n, 'incident', 'investigating', 'minor', NOW(),
+		        (SELECT id FROM users WHERE email = 'admin@example.com')
+		 FROM bench_events be`, nil},
+		{`INSERT INTO event_services (event_id, service_id, status)
+		 SELECT event_id, service_id, status FROM bench_events`, nil},
+	}
+	for i, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt.query, stmt.args...); err != nil {
+			b.Fatalf("seed statement %d: %v", i, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		b.Fatalf("commit seed: %v", err)
+	}
+	if _, err := pool.Exec(ctx, "ANALYZE"); err != nil {
+		b.Fatalf("analyze: %v", err)
+	}
+}
+
+// BenchmarkListServicesWithEffectiveStatus measures the public service list
+// under benchServices services with benchEventsPerService active events each.
+// Run with:
+//
+//	go test -tags integration -run '^$' -bench ListServicesWithEffectiveStatus ./internal/catalog/postgres/
+func BenchmarkListServicesWithEffectiveStatus(b *testing.B) {
+	ctx := context.Background()
+
+	pool := newBenchPool(ctx, b)
+	seedEffectiveStatusLoad(ctx, b, pool)
+	repo := postgres.NewRepository(pool)
+
+	var listed int
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		services, err := repo.ListServicesWithEffectiveStatus(ctx, catalog.ServiceFilter{})
+		if err != nil {
+			b.Fatalf("list services: %v", err)
+		}
+		listed = len(services)
+	}
+	b.StopTimer()
+
+	if listed < benchServices {
+		b.Fatalf("listed %d services, want at least %d", listed, benchServices)
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/float64(listed), "ns/service")
+}
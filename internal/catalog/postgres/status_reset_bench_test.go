@@ -0,0 +1,149 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/catalog/postgres"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const benchResetServices = 50
+
+const benchResetReason = "Event resolved, no other active events"
+
+// seedResolvedEvent creates a resolved incident affecting benchResetServices services
+// in major outage. Returns the event ID, the service IDs and the creator user ID.
+func seedResolvedEvent(ctx context.Context, b *testing.B, pool *pgxpool.Pool) (string, []string, string) {
+	b.Helper()
+
+	var userID string
+	if err := pool.QueryRow(ctx, `SELECT id FROM users WHERE email = 'admin@example.com'`).Scan(&userID); err != nil {
+		b.Fatalf("get admin: %v", err)
+	}
+
+	var eventID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO events (title, type, status, severity, started_at, resolved_at, created_by)
+		VALUES ('Bench resolved incident', 'incident', 'resolved', 'major', NOW(), NOW(), $1)
+		RETURNING id`, userID).Scan(&eventID); err != nil {
+		b.Fatalf("seed event: %v", err)
+	}
+
+	rows, err := pool.Query(ctx, `
+		WITH svc AS (
+			INSERT INTO services (name, slug, status, "order")
+			SELECT 'Reset Service ' || g, 'reset-svc-' || g, 'major_outage', g
+			FROM generate_series(1, $1::int) g
+			RETURNING id
+		)
+		INSERT INTO event_services (event_id, service_id, status)
+		SELECT $2, id, 'major_outage' FROM svc
+		RETURNING service_id`, benchResetServices, eventID)
+	if err != nil {
+		b.Fatalf("seed services: %v", err)
+	}
+	serviceIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		b.Fatalf("collect services: %v", err)
+	}
+
+	if _, err := pool.Exec(ctx, "ANALYZE"); err != nil {
+		b.Fatalf("analyze: %v", err)
+	}
+	return eventID, serviceIDs, userID
+}
+
+// resetPerService is the per-service approach replaced by ResetServicesToOperationalTx:
+// an active event check, a status read, an update and a log insert for every service.
+func resetPerService(ctx context.Context, tx pgx.Tx, repo *postgres.Repository, serviceIDs []string, eventID, createdBy string) (int, error) {
+	reset := 0
+	for _, serviceID := range serviceIDs {
+		var hasOther bool
+		if err := tx.QueryRow(ctx, `
+			SELECT EXISTS(
+				SELECT 1
+				FROM event_services es
+				JOIN events e ON es.event_id = e.id
+				WHERE es.service_id = $1
+				  AND e.id != $2
+				  AND e.status NOT IN ('resolved', 'completed', 'scheduled')
+			)`, serviceID, eventID).Scan(&hasOther); err != nil {
+			return 0, err
+		}
+		if hasOther {
+			continue
+		}
+
+		service, err := repo.GetServiceByID(ctx, serviceID)
+		if err != nil {
+			return 0, err
+		}
+		if err := repo.UpdateServiceStatusTx(ctx, tx, serviceID, domain.ServiceStatusOperational); err != nil {
+			return 0, err
+		}
+		entry := &domain.ServiceStatusLogEntry{
+			ServiceID:  serviceID,
+			OldStatus:  &service.Status,
+			NewStatus:  domain.ServiceStatusOperational,
+			SourceType: domain.StatusLogSourceEvent,
+			EventID:    &eventID,
+			Reason:     benchResetReason,
+			CreatedBy:  createdBy,
+		}
+		if err := repo.CreateStatusLogEntryTx(ctx, tx, entry); err != nil {
+			return 0, err
+		}
+		reset++
+	}
+	return reset, nil
+}
+
+// BenchmarkResetServicesToOperational compares resetting the services of a resolved
+// event one by one with the single-statement reset, for benchResetServices services.
+// Every iteration runs in a rolled back transaction. Run with:
+//
+//	go test -tags integration -run '^$' -bench ResetServicesToOperational ./internal/catalog/postgres/
+func BenchmarkResetServicesToOperational(b *testing.B) {
+	ctx := context.Background()
+
+	pool := newBenchPool(ctx, b)
+	eventID, serviceIDs, userID := seedResolvedEvent(ctx, b, pool)
+	repo := postgres.NewRepository(pool)
+
+	approaches := []struct {
+		name  string
+		reset func(tx pgx.Tx) (int, error)
+	}{
+		{"per-service", func(tx pgx.Tx) (int, error) {
+			return resetPerService(ctx, tx, repo, serviceIDs, eventID, userID)
+		}},
+		{"batched", func(tx pgx.Tx) (int, error) {
+			ids, err := repo.ResetServicesToOperationalTx(ctx, tx, serviceIDs, eventID, domain.StatusLogSourceEvent, benchResetReason, userID)
+			return len(ids), err
+		}},
+	}
+
+	for _, approach := range approaches {
+		b.Run(approach.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tx, err := pool.Begin(ctx)
+				if err != nil {
+					b.Fatalf("begin: %v", err)
+				}
+				reset, err := approach.reset(tx)
+				_ = tx.Rollback(ctx)
+				if err != nil {
+					b.Fatalf("reset: %v", err)
+				}
+				if reset != benchResetServices {
+					b.Fatalf("reset %d services, want %d", reset, benchResetServices)
+				}
+			}
+		})
+	}
+}
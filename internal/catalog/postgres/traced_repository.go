@@ -0,0 +1,573 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/pkg/tracing"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/bissquit/incident-garden/internal/catalog/postgres"
+
+// TracedRepository wraps a catalog.Repository and records a span for every call,
+// with db.operation and db.table attributes and service.slug where the slug is known.
+type TracedRepository struct {
+	repo   catalog.Repository
+	tracer trace.Tracer
+}
+
+var _ catalog.Repository = (*TracedRepository)(nil)
+
+// NewTracedRepository wraps repo with tracing. Spans go to the global tracer provider.
+func NewTracedRepository(repo catalog.Repository) *TracedRepository {
+	return &TracedRepository{repo: repo, tracer: otel.Tracer(tracerName)}
+}
+
+// GetGroupBySlug wraps Repository.GetGroupBySlug in a span.
+func (r *TracedRepository) GetGroupBySlug(ctx context.Context, slug string) (*domain.ServiceGroup, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetGroupBySlug", tracing.OpSelect, "service_groups")
+	result, err := r.repo.GetGroupBySlug(ctx, slug)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetGroupByID wraps Repository.GetGroupByID in a span.
+func (r *TracedRepository) GetGroupByID(ctx context.Context, id string) (*domain.ServiceGroup, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetGroupByID", tracing.OpSelect, "service_groups")
+	result, err := r.repo.GetGroupByID(ctx, id)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ListGroups wraps Repository.ListGroups in a span.
+func (r *TracedRepository) ListGroups(ctx context.Context, filter catalog.GroupFilter) ([]domain.ServiceGroup, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ListGroups", tracing.OpSelect, "service_groups")
+	result, err := r.repo.ListGroups(ctx, filter)
+	tracing.End(span, err)
+	return result, err
+}
+
+// UpdateGroup wraps Repository.UpdateGroup in a span.
+func (r *TracedRepository) UpdateGroup(ctx context.Context, group *domain.ServiceGroup) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.UpdateGroup", tracing.OpUpdate, "service_groups")
+	err := r.repo.UpdateGroup(ctx, group)
+	tracing.End(span, err)
+	return err
+}
+
+// DeleteGroup wraps Repository.DeleteGroup in a span.
+func (r *TracedRepository) DeleteGroup(ctx context.Context, id string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.DeleteGroup", tracing.OpDelete, "service_groups")
+	err := r.repo.DeleteGroup(ctx, id)
+	tracing.End(span, err)
+	return err
+}
+
+// GetChildGroups wraps Repository.GetChildGroups in a span.
+func (r *TracedRepository) GetChildGroups(ctx context.Context, parentGroupID string) ([]domain.ServiceGroup, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetChildGroups", tracing.OpSelect, "service_groups")
+	result, err := r.repo.GetChildGroups(ctx, parentGroupID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// HasChildGroups wraps Repository.HasChildGroups in a span.
+func (r *TracedRepository) HasChildGroups(ctx context.Context, groupID string) (bool, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.HasChildGroups", tracing.OpSelect, "service_groups")
+	result, err := r.repo.HasChildGroups(ctx, groupID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// HasAncestor wraps Repository.HasAncestor in a span.
+func (r *TracedRepository) HasAncestor(ctx context.Context, groupID, candidateAncestorID string) (bool, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.HasAncestor", tracing.OpSelect, "service_groups")
+	result, err := r.repo.HasAncestor(ctx, groupID, candidateAncestorID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetServiceBySlug wraps Repository.GetServiceBySlug in a span.
+func (r *TracedRepository) GetServiceBySlug(ctx context.Context, slug string) (*domain.Service, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetServiceBySlug", tracing.OpSelect, "services", tracing.ServiceSlugKey.String(slug))
+	result, err := r.repo.GetServiceBySlug(ctx, slug)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetServiceByID wraps Repository.GetServiceByID in a span.
+func (r *TracedRepository) GetServiceByID(ctx context.Context, id string) (*domain.Service, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetServiceByID", tracing.OpSelect, "services")
+	result, err := r.repo.GetServiceByID(ctx, id)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ListServices wraps Repository.ListServices in a span.
+func (r *TracedRepository) ListServices(ctx context.Context, filter catalog.ServiceFilter) ([]domain.Service, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ListServices", tracing.OpSelect, "services")
+	result, err := r.repo.ListServices(ctx, filter)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetServicesByCustomField wraps Repository.GetServicesByCustomField in a span.
+func (r *TracedRepository) GetServicesByCustomField(ctx context.Context, key, value string) ([]domain.Service, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetServicesByCustomField", tracing.OpSelect, "services")
+	result, err := r.repo.GetServicesByCustomField(ctx, key, value)
+	tracing.End(span, err)
+	return result, err
+}
+
+// UpdateService wraps Repository.UpdateService in a span.
+func (r *TracedRepository) UpdateService(ctx context.Context, service *domain.Service) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.UpdateService", tracing.OpUpdate, "services")
+	err := r.repo.UpdateService(ctx, service)
+	tracing.End(span, err)
+	return err
+}
+
+// DeleteService wraps Repository.DeleteService in a span.
+func (r *TracedRepository) DeleteService(ctx context.Context, id string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.DeleteService", tracing.OpDelete, "services")
+	err := r.repo.DeleteService(ctx, id)
+	tracing.End(span, err)
+	return err
+}
+
+// SetServiceTags wraps Repository.SetServiceTags in a span.
+func (r *TracedRepository) SetServiceTags(ctx context.Context, serviceID string, tags []domain.ServiceTag) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.SetServiceTags", tracing.OpInsert, "service_tags")
+	err := r.repo.SetServiceTags(ctx, serviceID, tags)
+	tracing.End(span, err)
+	return err
+}
+
+// GetServiceTags wraps Repository.GetServiceTags in a span.
+func (r *TracedRepository) GetServiceTags(ctx context.Context, serviceID string) ([]domain.ServiceTag, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetServiceTags", tracing.OpSelect, "service_tags")
+	result, err := r.repo.GetServiceTags(ctx, serviceID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// SetServiceGroups wraps Repository.SetServiceGroups in a span.
+func (r *TracedRepository) SetServiceGroups(ctx context.Context, serviceID string, groupIDs []string, actorUserID string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.SetServiceGroups", tracing.OpInsert, "service_group_members")
+	err := r.repo.SetServiceGroups(ctx, serviceID, groupIDs, actorUserID)
+	tracing.End(span, err)
+	return err
+}
+
+// GetServiceGroups wraps Repository.GetServiceGroups in a span.
+func (r *TracedRepository) GetServiceGroups(ctx context.Context, serviceID string) ([]string, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetServiceGroups", tracing.OpSelect, "service_group_members")
+	result, err := r.repo.GetServiceGroups(ctx, serviceID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetGroupServices wraps Repository.GetGroupServices in a span.
+func (r *TracedRepository) GetGroupServices(ctx context.Context, groupID string) ([]string, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetGroupServices", tracing.OpSelect, "service_group_members")
+	result, err := r.repo.GetGroupServices(ctx, groupID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// SetGroupServices wraps Repository.SetGroupServices in a span.
+func (r *TracedRepository) SetGroupServices(ctx context.Context, groupID string, serviceIDs []string, actorUserID string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.SetGroupServices", tracing.OpInsert, "service_group_members")
+	err := r.repo.SetGroupServices(ctx, groupID, serviceIDs, actorUserID)
+	tracing.End(span, err)
+	return err
+}
+
+// ArchiveService wraps Repository.ArchiveService in a span.
+func (r *TracedRepository) ArchiveService(ctx context.Context, id string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ArchiveService", tracing.OpUpdate, "services")
+	err := r.repo.ArchiveService(ctx, id)
+	tracing.End(span, err)
+	return err
+}
+
+// RestoreServiceTx wraps Repository.RestoreServiceTx in a span.
+func (r *TracedRepository) RestoreServiceTx(ctx context.Context, tx pgx.Tx, id string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.RestoreServiceTx", tracing.OpUpdate, "services")
+	err := r.repo.RestoreServiceTx(ctx, tx, id)
+	tracing.End(span, err)
+	return err
+}
+
+// ArchiveGroup wraps Repository.ArchiveGroup in a span.
+func (r *TracedRepository) ArchiveGroup(ctx context.Context, id string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ArchiveGroup", tracing.OpUpdate, "service_groups")
+	err := r.repo.ArchiveGroup(ctx, id)
+	tracing.End(span, err)
+	return err
+}
+
+// RestoreGroup wraps Repository.RestoreGroup in a span.
+func (r *TracedRepository) RestoreGroup(ctx context.Context, id string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.RestoreGroup", tracing.OpUpdate, "service_groups")
+	err := r.repo.RestoreGroup(ctx, id)
+	tracing.End(span, err)
+	return err
+}
+
+// BulkArchiveServices wraps Repository.BulkArchiveServices in a span.
+func (r *TracedRepository) BulkArchiveServices(ctx context.Context, ids []string) ([]string, []catalog.BulkError, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.BulkArchiveServices", tracing.OpUpdate, "services")
+	archived, failed, err := r.repo.BulkArchiveServices(ctx, ids)
+	tracing.End(span, err)
+	return archived, failed, err
+}
+
+// GetActiveEventCountForService wraps Repository.GetActiveEventCountForService in a span.
+func (r *TracedRepository) GetActiveEventCountForService(ctx context.Context, serviceID string) (int, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetActiveEventCountForService", tracing.OpSelect, "event_services")
+	result, err := r.repo.GetActiveEventCountForService(ctx, serviceID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// HasActiveEventsBySlug wraps Repository.HasActiveEventsBySlug in a span.
+func (r *TracedRepository) HasActiveEventsBySlug(ctx context.Context, slug string) (bool, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.HasActiveEventsBySlug", tracing.OpSelect, "event_services")
+	result, err := r.repo.HasActiveEventsBySlug(ctx, slug)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetActiveEventCountForGroup wraps Repository.GetActiveEventCountForGroup in a span.
+func (r *TracedRepository) GetActiveEventCountForGroup(ctx context.Context, groupID string) (int, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetActiveEventCountForGroup", tracing.OpSelect, "event_groups")
+	result, err := r.repo.GetActiveEventCountForGroup(ctx, groupID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetNonArchivedServiceCountForGroup wraps Repository.GetNonArchivedServiceCountForGroup in a span.
+func (r *TracedRepository) GetNonArchivedServiceCountForGroup(ctx context.Context, groupID string) (int, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetNonArchivedServiceCountForGroup", tracing.OpSelect, "service_group_members")
+	result, err := r.repo.GetNonArchivedServiceCountForGroup(ctx, groupID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetEffectiveStatus wraps Repository.GetEffectiveStatus in a span.
+func (r *TracedRepository) GetEffectiveStatus(ctx context.Context, serviceID string) (domain.ServiceStatus, bool, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetEffectiveStatus", tracing.OpSelect, "services")
+	status, hasActive, err := r.repo.GetEffectiveStatus(ctx, serviceID)
+	tracing.End(span, err)
+	return status, hasActive, err
+}
+
+// GetServiceBySlugWithEffectiveStatus wraps Repository.GetServiceBySlugWithEffectiveStatus in a span.
+func (r *TracedRepository) GetServiceBySlugWithEffectiveStatus(ctx context.Context, slug string) (*domain.ServiceWithEffectiveStatus, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetServiceBySlugWithEffectiveStatus", tracing.OpSelect, "services", tracing.ServiceSlugKey.String(slug))
+	result, err := r.repo.GetServiceBySlugWithEffectiveStatus(ctx, slug)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetServiceByIDWithEffectiveStatus wraps Repository.GetServiceByIDWithEffectiveStatus in a span.
+func (r *TracedRepository) GetServiceByIDWithEffectiveStatus(ctx context.Context, id string) (*domain.ServiceWithEffectiveStatus, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetServiceByIDWithEffectiveStatus", tracing.OpSelect, "services")
+	result, err := r.repo.GetServiceByIDWithEffectiveStatus(ctx, id)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ListServicesWithEffectiveStatus wraps Repository.ListServicesWithEffectiveStatus in a span.
+func (r *TracedRepository) ListServicesWithEffectiveStatus(ctx context.Context, filter catalog.ServiceFilter) ([]domain.ServiceWithEffectiveStatus, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ListServicesWithEffectiveStatus", tracing.OpSelect, "services")
+	result, err := r.repo.ListServicesWithEffectiveStatus(ctx, filter)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetGroupEffectiveStatus wraps Repository.GetGroupEffectiveStatus in a span.
+func (r *TracedRepository) GetGroupEffectiveStatus(ctx context.Context, groupID string) (domain.ServiceStatus, bool, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetGroupEffectiveStatus", tracing.OpSelect, "service_groups")
+	status, hasActive, err := r.repo.GetGroupEffectiveStatus(ctx, groupID)
+	tracing.End(span, err)
+	return status, hasActive, err
+}
+
+// GetGroupBySlugWithEffectiveStatus wraps Repository.GetGroupBySlugWithEffectiveStatus in a span.
+func (r *TracedRepository) GetGroupBySlugWithEffectiveStatus(ctx context.Context, slug string) (*domain.GroupWithEffectiveStatus, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetGroupBySlugWithEffectiveStatus", tracing.OpSelect, "service_groups")
+	result, err := r.repo.GetGroupBySlugWithEffectiveStatus(ctx, slug)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ListGroupsWithEffectiveStatus wraps Repository.ListGroupsWithEffectiveStatus in a span.
+func (r *TracedRepository) ListGroupsWithEffectiveStatus(ctx context.Context, filter catalog.GroupFilter) ([]domain.GroupWithEffectiveStatus, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ListGroupsWithEffectiveStatus", tracing.OpSelect, "service_groups")
+	result, err := r.repo.ListGroupsWithEffectiveStatus(ctx, filter)
+	tracing.End(span, err)
+	return result, err
+}
+
+// BeginTx wraps Repository.BeginTx in a span.
+func (r *TracedRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.BeginTx", tracing.OpBegin, "")
+	result, err := r.repo.BeginTx(ctx)
+	tracing.End(span, err)
+	return result, err
+}
+
+// CreateGroupTx wraps Repository.CreateGroupTx in a span.
+func (r *TracedRepository) CreateGroupTx(ctx context.Context, tx pgx.Tx, group *domain.ServiceGroup) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.CreateGroupTx", tracing.OpInsert, "service_groups")
+	err := r.repo.CreateGroupTx(ctx, tx, group)
+	tracing.End(span, err)
+	return err
+}
+
+// CreateServiceTx wraps Repository.CreateServiceTx in a span.
+func (r *TracedRepository) CreateServiceTx(ctx context.Context, tx pgx.Tx, service *domain.Service) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.CreateServiceTx", tracing.OpInsert, "services")
+	err := r.repo.CreateServiceTx(ctx, tx, service)
+	tracing.End(span, err)
+	return err
+}
+
+// UpdateServiceTx wraps Repository.UpdateServiceTx in a span.
+func (r *TracedRepository) UpdateServiceTx(ctx context.Context, tx pgx.Tx, service *domain.Service) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.UpdateServiceTx", tracing.OpUpdate, "services")
+	err := r.repo.UpdateServiceTx(ctx, tx, service)
+	tracing.End(span, err)
+	return err
+}
+
+// SetServiceGroupsTx wraps Repository.SetServiceGroupsTx in a span.
+func (r *TracedRepository) SetServiceGroupsTx(ctx context.Context, tx pgx.Tx, serviceID string, groupIDs []string, actorUserID string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.SetServiceGroupsTx", tracing.OpInsert, "service_group_members")
+	err := r.repo.SetServiceGroupsTx(ctx, tx, serviceID, groupIDs, actorUserID)
+	tracing.End(span, err)
+	return err
+}
+
+// SetServiceTagsTx wraps Repository.SetServiceTagsTx in a span.
+func (r *TracedRepository) SetServiceTagsTx(ctx context.Context, tx pgx.Tx, serviceID string, tags []domain.ServiceTag) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.SetServiceTagsTx", tracing.OpInsert, "service_tags")
+	err := r.repo.SetServiceTagsTx(ctx, tx, serviceID, tags)
+	tracing.End(span, err)
+	return err
+}
+
+// UpdateServiceStatusTx wraps Repository.UpdateServiceStatusTx in a span.
+func (r *TracedRepository) UpdateServiceStatusTx(ctx context.Context, tx pgx.Tx, serviceID string, status domain.ServiceStatus) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.UpdateServiceStatusTx", tracing.OpUpdate, "services")
+	err := r.repo.UpdateServiceStatusTx(ctx, tx, serviceID, status)
+	tracing.End(span, err)
+	return err
+}
+
+// ResetServicesToOperationalTx wraps Repository.ResetServicesToOperationalTx in a span.
+func (r *TracedRepository) ResetServicesToOperationalTx(ctx context.Context, tx pgx.Tx, serviceIDs []string, eventID string, source domain.StatusLogSourceType, reason, createdBy string) ([]string, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ResetServicesToOperationalTx", tracing.OpUpdate, "services")
+	result, err := r.repo.ResetServicesToOperationalTx(ctx, tx, serviceIDs, eventID, source, reason, createdBy)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetEffectiveStatusesTx wraps Repository.GetEffectiveStatusesTx in a span.
+func (r *TracedRepository) GetEffectiveStatusesTx(ctx context.Context, tx pgx.Tx, serviceIDs []string) (map[string]domain.ServiceStatus, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetEffectiveStatusesTx", tracing.OpSelect, "services")
+	result, err := r.repo.GetEffectiveStatusesTx(ctx, tx, serviceIDs)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetMaxServiceOrderTx wraps Repository.GetMaxServiceOrderTx in a span.
+func (r *TracedRepository) GetMaxServiceOrderTx(ctx context.Context, tx pgx.Tx) (int, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetMaxServiceOrderTx", tracing.OpSelect, "services")
+	result, err := r.repo.GetMaxServiceOrderTx(ctx, tx)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ShiftServiceOrdersFromTx wraps Repository.ShiftServiceOrdersFromTx in a span.
+func (r *TracedRepository) ShiftServiceOrdersFromTx(ctx context.Context, tx pgx.Tx, fromOrder int) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ShiftServiceOrdersFromTx", tracing.OpUpdate, "services")
+	err := r.repo.ShiftServiceOrdersFromTx(ctx, tx, fromOrder)
+	tracing.End(span, err)
+	return err
+}
+
+// GetMaxGroupOrderTx wraps Repository.GetMaxGroupOrderTx in a span.
+func (r *TracedRepository) GetMaxGroupOrderTx(ctx context.Context, tx pgx.Tx) (int, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetMaxGroupOrderTx", tracing.OpSelect, "service_groups")
+	result, err := r.repo.GetMaxGroupOrderTx(ctx, tx)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ShiftGroupOrdersFromTx wraps Repository.ShiftGroupOrdersFromTx in a span.
+func (r *TracedRepository) ShiftGroupOrdersFromTx(ctx context.Context, tx pgx.Tx, fromOrder int) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ShiftGroupOrdersFromTx", tracing.OpUpdate, "service_groups")
+	err := r.repo.ShiftGroupOrdersFromTx(ctx, tx, fromOrder)
+	tracing.End(span, err)
+	return err
+}
+
+// BatchUpdateServiceOrder wraps Repository.BatchUpdateServiceOrder in a span.
+func (r *TracedRepository) BatchUpdateServiceOrder(ctx context.Context, items []catalog.ServiceOrderItem) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.BatchUpdateServiceOrder", tracing.OpUpdate, "services")
+	err := r.repo.BatchUpdateServiceOrder(ctx, items)
+	tracing.End(span, err)
+	return err
+}
+
+// CreateStatusLogEntry wraps Repository.CreateStatusLogEntry in a span.
+func (r *TracedRepository) CreateStatusLogEntry(ctx context.Context, entry *domain.ServiceStatusLogEntry) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.CreateStatusLogEntry", tracing.OpInsert, "service_status_log")
+	err := r.repo.CreateStatusLogEntry(ctx, entry)
+	tracing.End(span, err)
+	return err
+}
+
+// CreateStatusLogEntryTx wraps Repository.CreateStatusLogEntryTx in a span.
+func (r *TracedRepository) CreateStatusLogEntryTx(ctx context.Context, tx pgx.Tx, entry *domain.ServiceStatusLogEntry) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.CreateStatusLogEntryTx", tracing.OpInsert, "service_status_log")
+	err := r.repo.CreateStatusLogEntryTx(ctx, tx, entry)
+	tracing.End(span, err)
+	return err
+}
+
+// ListStatusLog wraps Repository.ListStatusLog in a span.
+func (r *TracedRepository) ListStatusLog(ctx context.Context, serviceID string, limit, offset int) ([]domain.ServiceStatusLogEntry, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ListStatusLog", tracing.OpSelect, "service_status_log")
+	result, err := r.repo.ListStatusLog(ctx, serviceID, limit, offset)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetLatestStatusLogEntry wraps Repository.GetLatestStatusLogEntry in a span.
+func (r *TracedRepository) GetLatestStatusLogEntry(ctx context.Context, serviceID string) (*domain.ServiceStatusLogEntry, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.GetLatestStatusLogEntry", tracing.OpSelect, "service_status_log")
+	result, err := r.repo.GetLatestStatusLogEntry(ctx, serviceID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ListStatusLogRange wraps Repository.ListStatusLogRange in a span.
+func (r *TracedRepository) ListStatusLogRange(ctx context.Context, serviceID string, from, to time.Time) ([]domain.ServiceStatusLogEntry, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ListStatusLogRange", tracing.OpSelect, "service_status_log")
+	result, err := r.repo.ListStatusLogRange(ctx, serviceID, from, to)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ListStatusTimeline wraps Repository.ListStatusTimeline in a span.
+func (r *TracedRepository) ListStatusTimeline(ctx context.Context, serviceID string, from, to time.Time) ([]catalog.ServiceStatusLogEntry, map[string]*domain.Event, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ListStatusTimeline", tracing.OpSelect, "service_status_log")
+	entries, eventsByID, err := r.repo.ListStatusTimeline(ctx, serviceID, from, to)
+	tracing.End(span, err)
+	return entries, eventsByID, err
+}
+
+// CountStatusLog wraps Repository.CountStatusLog in a span.
+func (r *TracedRepository) CountStatusLog(ctx context.Context, serviceID string) (int, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.CountStatusLog", tracing.OpSelect, "service_status_log")
+	result, err := r.repo.CountStatusLog(ctx, serviceID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// DeleteStatusLogByEventIDTx wraps Repository.DeleteStatusLogByEventIDTx in a span.
+func (r *TracedRepository) DeleteStatusLogByEventIDTx(ctx context.Context, tx pgx.Tx, eventID string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.DeleteStatusLogByEventIDTx", tracing.OpDelete, "service_status_log", tracing.EventIDKey.String(eventID))
+	err := r.repo.DeleteStatusLogByEventIDTx(ctx, tx, eventID)
+	tracing.End(span, err)
+	return err
+}
+
+// SetServiceSLATarget wraps Repository.SetServiceSLATarget in a span.
+func (r *TracedRepository) SetServiceSLATarget(ctx context.Context, serviceID string, target *float64) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.SetServiceSLATarget", tracing.OpUpdate, "services")
+	err := r.repo.SetServiceSLATarget(ctx, serviceID, target)
+	tracing.End(span, err)
+	return err
+}
+
+// ListServicesWithSLATarget wraps Repository.ListServicesWithSLATarget in a span.
+func (r *TracedRepository) ListServicesWithSLATarget(ctx context.Context) ([]domain.Service, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ListServicesWithSLATarget", tracing.OpSelect, "services")
+	result, err := r.repo.ListServicesWithSLATarget(ctx)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ClaimSLABreach wraps Repository.ClaimSLABreach in a span.
+func (r *TracedRepository) ClaimSLABreach(ctx context.Context, serviceID string, month time.Time) (bool, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ClaimSLABreach", tracing.OpUpdate, "services")
+	claimed, err := r.repo.ClaimSLABreach(ctx, serviceID, month)
+	tracing.End(span, err)
+	return claimed, err
+}
+
+// FindMissingServiceIDs wraps Repository.FindMissingServiceIDs in a span.
+func (r *TracedRepository) FindMissingServiceIDs(ctx context.Context, ids []string) ([]string, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.FindMissingServiceIDs", tracing.OpSelect, "services")
+	result, err := r.repo.FindMissingServiceIDs(ctx, ids)
+	tracing.End(span, err)
+	return result, err
+}
+
+// FindMissingGroupIDs wraps Repository.FindMissingGroupIDs in a span.
+func (r *TracedRepository) FindMissingGroupIDs(ctx context.Context, ids []string) ([]string, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.FindMissingGroupIDs", tracing.OpSelect, "service_groups")
+	result, err := r.repo.FindMissingGroupIDs(ctx, ids)
+	tracing.End(span, err)
+	return result, err
+}
+
+// CreateSlugRedirectTx wraps Repository.CreateSlugRedirectTx in a span.
+func (r *TracedRepository) CreateSlugRedirectTx(ctx context.Context, tx pgx.Tx, oldSlug, newSlug string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.CreateSlugRedirectTx", tracing.OpInsert, "service_slug_redirects")
+	err := r.repo.CreateSlugRedirectTx(ctx, tx, oldSlug, newSlug)
+	tracing.End(span, err)
+	return err
+}
+
+// FindSlugRedirect wraps Repository.FindSlugRedirect in a span.
+func (r *TracedRepository) FindSlugRedirect(ctx context.Context, oldSlug string) (string, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.FindSlugRedirect", tracing.OpSelect, "service_slug_redirects", tracing.ServiceSlugKey.String(oldSlug))
+	result, err := r.repo.FindSlugRedirect(ctx, oldSlug)
+	tracing.End(span, err)
+	return result, err
+}
+
+// CreateMembershipLogEntryTx wraps Repository.CreateMembershipLogEntryTx in a span.
+func (r *TracedRepository) CreateMembershipLogEntryTx(ctx context.Context, tx pgx.Tx, entry *domain.MembershipLogEntry) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.CreateMembershipLogEntryTx", tracing.OpInsert, "service_group_membership_log")
+	err := r.repo.CreateMembershipLogEntryTx(ctx, tx, entry)
+	tracing.End(span, err)
+	return err
+}
+
+// ListMembershipLog wraps Repository.ListMembershipLog in a span.
+func (r *TracedRepository) ListMembershipLog(ctx context.Context, serviceID string, limit, offset int) ([]domain.MembershipLogEntry, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.ListMembershipLog", tracing.OpSelect, "service_group_membership_log")
+	result, err := r.repo.ListMembershipLog(ctx, serviceID, limit, offset)
+	tracing.End(span, err)
+	return result, err
+}
+
+// CountMembershipLog wraps Repository.CountMembershipLog in a span.
+func (r *TracedRepository) CountMembershipLog(ctx context.Context, serviceID string) (int, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "catalog.CountMembershipLog", tracing.OpSelect, "service_group_membership_log")
+	result, err := r.repo.CountMembershipLog(ctx, serviceID)
+	tracing.End(span, err)
+	return result, err
+}
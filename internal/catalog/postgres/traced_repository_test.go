@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/pkg/tracing"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// stubRepository implements only the methods used by the tests.
+type stubRepository struct {
+	catalog.Repository
+}
+
+func (s *stubRepository) GetServiceBySlug(_ context.Context, slug string) (*domain.Service, error) {
+	return &domain.Service{Slug: slug}, nil
+}
+
+func (s *stubRepository) DeleteGroup(_ context.Context, _ string) error {
+	return nil
+}
+
+func (s *stubRepository) DeleteStatusLogByEventIDTx(_ context.Context, _ pgx.Tx, _ string) error {
+	return nil
+}
+
+func TestTracedRepository_SpanAttributes(t *testing.T) {
+	tests := []struct {
+		name      string
+		call      func(ctx context.Context, repo *TracedRepository) error
+		wantSpan  string
+		wantAttrs map[attribute.Key]string
+		absent    []attribute.Key
+	}{
+		{
+			name: "service by slug",
+			call: func(ctx context.Context, repo *TracedRepository) error {
+				_, err := repo.GetServiceBySlug(ctx, "api")
+				return err
+			},
+			wantSpan: "catalog.GetServiceBySlug",
+			wantAttrs: map[attribute.Key]string{
+				tracing.DBSystemKey:    "postgresql",
+				tracing.DBOperationKey: "SELECT",
+				tracing.DBTableKey:     "services",
+				tracing.ServiceSlugKey: "api",
+			},
+		},
+		{
+			name: "delete group",
+			call: func(ctx context.Context, repo *TracedRepository) error {
+				return repo.DeleteGroup(ctx, "grp-1")
+			},
+			wantSpan: "catalog.DeleteGroup",
+			wantAttrs: map[attribute.Key]string{
+				tracing.DBOperationKey: "DELETE",
+				tracing.DBTableKey:     "service_groups",
+			},
+			absent: []attribute.Key{tracing.ServiceSlugKey, tracing.EventIDKey},
+		},
+		{
+			name: "status log of an event",
+			call: func(ctx context.Context, repo *TracedRepository) error {
+				return repo.DeleteStatusLogByEventIDTx(ctx, nil, "evt-1")
+			},
+			wantSpan: "catalog.DeleteStatusLogByEventIDTx",
+			wantAttrs: map[attribute.Key]string{
+				tracing.DBOperationKey: "DELETE",
+				tracing.DBTableKey:     "service_status_log",
+				tracing.EventIDKey:     "evt-1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			previous := otel.GetTracerProvider()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+			t.Cleanup(func() { otel.SetTracerProvider(previous) })
+			repo := NewTracedRepository(&stubRepository{})
+
+			require.NoError(t, tt.call(context.Background(), repo))
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.wantSpan, spans[0].Name())
+			assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+
+			attrs := make(map[attribute.Key]string)
+			for _, kv := range spans[0].Attributes() {
+				attrs[kv.Key] = kv.Value.Emit()
+			}
+			for key, want := range tt.wantAttrs {
+				assert.Equal(t, want, attrs[key], key)
+			}
+			for _, key := range tt.absent {
+				assert.NotContains(t, attrs, key)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package catalog
 
 import (
 	"context"
+	"time"
 
 	"github.com/bissquit/incident-garden/internal/domain"
 	"github.com/jackc/pgx/v5"
@@ -9,37 +10,43 @@ import (
 
 // Repository defines the interface for catalog data operations.
 type Repository interface {
-	CreateGroup(ctx context.Context, group *domain.ServiceGroup) error
 	GetGroupBySlug(ctx context.Context, slug string) (*domain.ServiceGroup, error)
 	GetGroupByID(ctx context.Context, id string) (*domain.ServiceGroup, error)
 	ListGroups(ctx context.Context, filter GroupFilter) ([]domain.ServiceGroup, error)
 	UpdateGroup(ctx context.Context, group *domain.ServiceGroup) error
 	DeleteGroup(ctx context.Context, id string) error
 
-	CreateService(ctx context.Context, service *domain.Service) error
+	// Group nesting
+	GetChildGroups(ctx context.Context, parentGroupID string) ([]domain.ServiceGroup, error)
+	HasAncestor(ctx context.Context, groupID, candidateAncestorID string) (bool, error)
+	HasChildGroups(ctx context.Context, groupID string) (bool, error)
+
 	GetServiceBySlug(ctx context.Context, slug string) (*domain.Service, error)
 	GetServiceByID(ctx context.Context, id string) (*domain.Service, error)
 	ListServices(ctx context.Context, filter ServiceFilter) ([]domain.Service, error)
+	GetServicesByCustomField(ctx context.Context, key, value string) ([]domain.Service, error)
 	UpdateService(ctx context.Context, service *domain.Service) error
 	DeleteService(ctx context.Context, id string) error
 
 	SetServiceTags(ctx context.Context, serviceID string, tags []domain.ServiceTag) error
 	GetServiceTags(ctx context.Context, serviceID string) ([]domain.ServiceTag, error)
 
-	SetServiceGroups(ctx context.Context, serviceID string, groupIDs []string) error
+	SetServiceGroups(ctx context.Context, serviceID string, groupIDs []string, actorUserID string) error
 	GetServiceGroups(ctx context.Context, serviceID string) ([]string, error)
 	GetGroupServices(ctx context.Context, groupID string) ([]string, error)
-	SetGroupServices(ctx context.Context, groupID string, serviceIDs []string) error
+	SetGroupServices(ctx context.Context, groupID string, serviceIDs []string, actorUserID string) error
 
 	// Soft delete operations
 	ArchiveService(ctx context.Context, id string) error
-	RestoreService(ctx context.Context, id string) error
+	RestoreServiceTx(ctx context.Context, tx pgx.Tx, id string) error
 	ArchiveGroup(ctx context.Context, id string) error
 	RestoreGroup(ctx context.Context, id string) error
+	BulkArchiveServices(ctx context.Context, ids []string) ([]string, []BulkError, error)
 
 	// Active events check
 	GetActiveEventCountForService(ctx context.Context, serviceID string) (int, error)
 	GetActiveEventCountForGroup(ctx context.Context, groupID string) (int, error)
+	HasActiveEventsBySlug(ctx context.Context, slug string) (bool, error)
 
 	// Group membership check
 	GetNonArchivedServiceCountForGroup(ctx context.Context, groupID string) (int, error)
@@ -49,20 +56,53 @@ type Repository interface {
 	GetServiceBySlugWithEffectiveStatus(ctx context.Context, slug string) (*domain.ServiceWithEffectiveStatus, error)
 	GetServiceByIDWithEffectiveStatus(ctx context.Context, id string) (*domain.ServiceWithEffectiveStatus, error)
 	ListServicesWithEffectiveStatus(ctx context.Context, filter ServiceFilter) ([]domain.ServiceWithEffectiveStatus, error)
+	GetGroupEffectiveStatus(ctx context.Context, groupID string) (domain.ServiceStatus, bool, error)
+	GetGroupBySlugWithEffectiveStatus(ctx context.Context, slug string) (*domain.GroupWithEffectiveStatus, error)
+	ListGroupsWithEffectiveStatus(ctx context.Context, filter GroupFilter) ([]domain.GroupWithEffectiveStatus, error)
 
 	// Transaction methods
 	BeginTx(ctx context.Context) (pgx.Tx, error)
+	CreateGroupTx(ctx context.Context, tx pgx.Tx, group *domain.ServiceGroup) error
+	CreateServiceTx(ctx context.Context, tx pgx.Tx, service *domain.Service) error
 	UpdateServiceTx(ctx context.Context, tx pgx.Tx, service *domain.Service) error
-	SetServiceGroupsTx(ctx context.Context, tx pgx.Tx, serviceID string, groupIDs []string) error
+	SetServiceGroupsTx(ctx context.Context, tx pgx.Tx, serviceID string, groupIDs []string, actorUserID string) error
+	SetServiceTagsTx(ctx context.Context, tx pgx.Tx, serviceID string, tags []domain.ServiceTag) error
 	UpdateServiceStatusTx(ctx context.Context, tx pgx.Tx, serviceID string, status domain.ServiceStatus) error
+	ResetServicesToOperationalTx(ctx context.Context, tx pgx.Tx, serviceIDs []string, eventID string, source domain.StatusLogSourceType, reason, createdBy string) ([]string, error)
+	GetEffectiveStatusesTx(ctx context.Context, tx pgx.Tx, serviceIDs []string) (map[string]domain.ServiceStatus, error)
+
+	// Display order methods. Each locks order assignment of its entity set
+	// until the transaction ends.
+	GetMaxServiceOrderTx(ctx context.Context, tx pgx.Tx) (int, error)
+	ShiftServiceOrdersFromTx(ctx context.Context, tx pgx.Tx, fromOrder int) error
+	GetMaxGroupOrderTx(ctx context.Context, tx pgx.Tx) (int, error)
+	ShiftGroupOrdersFromTx(ctx context.Context, tx pgx.Tx, fromOrder int) error
+	BatchUpdateServiceOrder(ctx context.Context, items []ServiceOrderItem) error
 
 	// Status log methods
 	CreateStatusLogEntry(ctx context.Context, entry *domain.ServiceStatusLogEntry) error
 	CreateStatusLogEntryTx(ctx context.Context, tx pgx.Tx, entry *domain.ServiceStatusLogEntry) error
 	ListStatusLog(ctx context.Context, serviceID string, limit, offset int) ([]domain.ServiceStatusLogEntry, error)
+	ListStatusLogRange(ctx context.Context, serviceID string, from, to time.Time) ([]domain.ServiceStatusLogEntry, error)
+	ListStatusTimeline(ctx context.Context, serviceID string, from, to time.Time) ([]ServiceStatusLogEntry, map[string]*domain.Event, error)
 	CountStatusLog(ctx context.Context, serviceID string) (int, error)
+	GetLatestStatusLogEntry(ctx context.Context, serviceID string) (*domain.ServiceStatusLogEntry, error)
 	DeleteStatusLogByEventIDTx(ctx context.Context, tx pgx.Tx, eventID string) error
 
+	// Group membership log methods
+	CreateMembershipLogEntryTx(ctx context.Context, tx pgx.Tx, entry *domain.MembershipLogEntry) error
+	ListMembershipLog(ctx context.Context, serviceID string, limit, offset int) ([]domain.MembershipLogEntry, error)
+	CountMembershipLog(ctx context.Context, serviceID string) (int, error)
+
+	// SLA methods
+	SetServiceSLATarget(ctx context.Context, serviceID string, target *float64) error
+	ListServicesWithSLATarget(ctx context.Context) ([]domain.Service, error)
+	ClaimSLABreach(ctx context.Context, serviceID string, month time.Time) (bool, error)
+
+	// Slug redirect methods
+	CreateSlugRedirectTx(ctx context.Context, tx pgx.Tx, oldSlug, newSlug string) error
+	FindSlugRedirect(ctx context.Context, oldSlug string) (string, error)
+
 	// Validation methods
 	FindMissingServiceIDs(ctx context.Context, ids []string) ([]string, error)
 	FindMissingGroupIDs(ctx context.Context, ids []string) ([]string, error)
@@ -73,6 +113,20 @@ type ServiceFilter struct {
 	GroupID         *string
 	Status          *domain.ServiceStatus
 	IncludeArchived bool
+	Tags            map[string]string // service must have ALL tags (key=value)
+	CustomFields    map[string]string // service must have ALL custom fields (key=value)
+}
+
+// BulkError describes why a single item of a bulk operation failed.
+type BulkError struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// ServiceOrderItem sets the display order of a single service.
+type ServiceOrderItem struct {
+	ID    string `json:"id" validate:"required,uuid"`
+	Order int    `json:"order"`
 }
 
 // GroupFilter represents filter criteria for listing groups.
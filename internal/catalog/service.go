@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/bissquit/incident-garden/internal/catalog/uptime"
 	"github.com/bissquit/incident-garden/internal/domain"
 	"github.com/jackc/pgx/v5"
 )
@@ -22,22 +26,55 @@ var (
 	ErrGroupHasServices       = errors.New("cannot archive group: has services")
 	ErrAlreadyArchived        = errors.New("already archived")
 	ErrNotArchived            = errors.New("not archived")
+	ErrServiceNotArchived     = fmt.Errorf("service is %w", ErrNotArchived)
+	ErrGroupNotArchived       = fmt.Errorf("group is %w", ErrNotArchived)
+	ErrDuplicateOrder         = errors.New("duplicate order value")
+	ErrDuplicateServiceID     = errors.New("duplicate service id")
+	ErrInvalidServiceURL      = errors.New("invalid service url")
+	ErrInvalidCustomField     = errors.New("invalid custom field")
+	ErrInvalidHealthCheck     = errors.New("invalid health check")
+	ErrInvalidIcon            = errors.New("icon_url is not an image")
+	ErrSlugChangeActiveEvents = errors.New("cannot change slug: service has active events")
+	ErrParentGroupNotFound    = errors.New("parent group not found")
+	ErrGroupCycle             = errors.New("group cannot be its own ancestor")
+	ErrGroupNestingDepth      = errors.New("groups can be nested only one level deep")
+	ErrGroupHasChildren       = errors.New("cannot archive group: has sub-groups")
+	ErrParentGroupArchived    = errors.New("cannot restore group: parent group is archived")
 )
 
 var slugRegex = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
 
+var customFieldKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// MaxCustomFieldValueLength is the maximum length of a service custom field value, in characters.
+const MaxCustomFieldValueLength = 500
+
+// Bounds of a service health check interval in seconds (0 disables the check).
+const (
+	MinHealthCheckIntervalSeconds = 10
+	MaxHealthCheckIntervalSeconds = 86400
+)
+
 // Service provides business logic for managing service groups and services.
 type Service struct {
-	repo Repository
+	repo  Repository
+	icons IconValidator
 }
 
 // NewService creates a new catalog service.
-func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+// icons may be nil, in which case icon URLs are only checked to be http(s).
+func NewService(repo Repository, icons IconValidator) *Service {
+	return &Service{repo: repo, icons: icons}
 }
 
 // CreateGroup creates a new service group.
-func (s *Service) CreateGroup(ctx context.Context, group *domain.ServiceGroup) error {
+// With autoOrder the group is placed last (MAX(order)+1). Otherwise, if group.Order
+// is taken, groups at or after it are shifted by one in the same transaction.
+// An empty slug is generated from the name.
+func (s *Service) CreateGroup(ctx context.Context, group *domain.ServiceGroup, autoOrder bool) error {
+	if strings.TrimSpace(group.Slug) == "" {
+		group.Slug = domain.GenerateSlug(group.Name)
+	}
 	if err := validateSlug(group.Slug); err != nil {
 		return err
 	}
@@ -50,7 +87,37 @@ func (s *Service) CreateGroup(ctx context.Context, group *domain.ServiceGroup) e
 		return ErrSlugExists
 	}
 
-	return s.repo.CreateGroup(ctx, group)
+	if err := s.validateParentGroup(ctx, "", group.ParentGroupID); err != nil {
+		return err
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if autoOrder {
+		maxOrder, err := s.repo.GetMaxGroupOrderTx(ctx, tx)
+		if err != nil {
+			return err
+		}
+		group.Order = maxOrder + 1
+	} else if err := s.repo.ShiftGroupOrdersFromTx(ctx, tx, group.Order); err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateGroupTx(ctx, tx, group); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
 }
 
 // GetGroupBySlug returns a service group by its slug.
@@ -68,6 +135,16 @@ func (s *Service) ListGroups(ctx context.Context, filter GroupFilter) ([]domain.
 	return s.repo.ListGroups(ctx, filter)
 }
 
+// GetGroupBySlugWithEffectiveStatus returns a service group with its effective status.
+func (s *Service) GetGroupBySlugWithEffectiveStatus(ctx context.Context, slug string) (*domain.GroupWithEffectiveStatus, error) {
+	return s.repo.GetGroupBySlugWithEffectiveStatus(ctx, slug)
+}
+
+// ListGroupsWithEffectiveStatus returns service groups matching the filter with their effective statuses.
+func (s *Service) ListGroupsWithEffectiveStatus(ctx context.Context, filter GroupFilter) ([]domain.GroupWithEffectiveStatus, error) {
+	return s.repo.ListGroupsWithEffectiveStatus(ctx, filter)
+}
+
 // UpdateGroup updates an existing service group.
 func (s *Service) UpdateGroup(ctx context.Context, group *domain.ServiceGroup) error {
 	if err := validateSlug(group.Slug); err != nil {
@@ -89,9 +166,66 @@ func (s *Service) UpdateGroup(ctx context.Context, group *domain.ServiceGroup) e
 		}
 	}
 
+	if err := s.validateParentGroup(ctx, group.ID, group.ParentGroupID); err != nil {
+		return err
+	}
+
 	return s.repo.UpdateGroup(ctx, group)
 }
 
+// validateParentGroup checks that parentID, if set, can be the parent of the group
+// groupID ("" for a new group): an existing, non-archived top-level group that is
+// neither the group itself nor one of its descendants, while the group has no sub-groups.
+func (s *Service) validateParentGroup(ctx context.Context, groupID string, parentID *string) error {
+	if parentID == nil {
+		return nil
+	}
+	if *parentID == groupID {
+		return ErrGroupCycle
+	}
+
+	parent, err := s.repo.GetGroupByID(ctx, *parentID)
+	if errors.Is(err, ErrGroupNotFound) {
+		return ErrParentGroupNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("get parent group: %w", err)
+	}
+	if parent.IsArchived() {
+		return ErrParentGroupNotFound
+	}
+
+	if groupID != "" {
+		cycle, err := s.repo.HasAncestor(ctx, *parentID, groupID)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return ErrGroupCycle
+		}
+	}
+
+	if parent.ParentGroupID != nil {
+		return ErrGroupNestingDepth
+	}
+	// Archived sub-groups count too: restoring them must not create a second level
+	if groupID != "" {
+		hasChildren, err := s.repo.HasChildGroups(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		if hasChildren {
+			return ErrGroupNestingDepth
+		}
+	}
+	return nil
+}
+
+// GetChildGroups returns the non-archived sub-groups of a group.
+func (s *Service) GetChildGroups(ctx context.Context, parentGroupID string) ([]domain.ServiceGroup, error) {
+	return s.repo.GetChildGroups(ctx, parentGroupID)
+}
+
 // DeleteGroup archives a service group (soft delete).
 func (s *Service) DeleteGroup(ctx context.Context, id string) error {
 	// Check for active events in services belonging to this group
@@ -112,19 +246,63 @@ func (s *Service) DeleteGroup(ctx context.Context, id string) error {
 		return ErrGroupHasServices
 	}
 
+	children, err := s.repo.GetChildGroups(ctx, id)
+	if err != nil {
+		return fmt.Errorf("check sub-groups: %w", err)
+	}
+	if len(children) > 0 {
+		return ErrGroupHasChildren
+	}
+
 	return s.repo.ArchiveGroup(ctx, id)
 }
 
 // RestoreGroup restores an archived group.
+// A sub-group can't be restored while its parent is archived, nor when its parent
+// has become a sub-group itself.
 func (s *Service) RestoreGroup(ctx context.Context, id string) error {
+	group, err := s.repo.GetGroupByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if group.ParentGroupID != nil {
+		parent, err := s.repo.GetGroupByID(ctx, *group.ParentGroupID)
+		if err != nil {
+			return fmt.Errorf("get parent group: %w", err)
+		}
+		if parent.IsArchived() {
+			return ErrParentGroupArchived
+		}
+		if parent.ParentGroupID != nil {
+			return ErrGroupNestingDepth
+		}
+	}
 	return s.repo.RestoreGroup(ctx, id)
 }
 
 // CreateService creates a new service.
-func (s *Service) CreateService(ctx context.Context, service *domain.Service) error {
+// With autoOrder the service is placed last (MAX(order)+1). Otherwise, if service.Order
+// is taken, services at or after it are shifted by one in the same transaction.
+// An empty slug is generated from the name.
+func (s *Service) CreateService(ctx context.Context, service *domain.Service, autoOrder bool, createdBy string) error {
+	if strings.TrimSpace(service.Slug) == "" {
+		service.Slug = domain.GenerateSlug(service.Name)
+	}
 	if err := validateSlug(service.Slug); err != nil {
 		return err
 	}
+	if err := validateServiceURLs(service); err != nil {
+		return err
+	}
+	if err := validateCustomFields(service.CustomFields); err != nil {
+		return err
+	}
+	if err := validateHealthCheck(service); err != nil {
+		return err
+	}
+	if err := s.validateIcon(ctx, service.IconURL); err != nil {
+		return err
+	}
 
 	existing, err := s.repo.GetServiceBySlug(ctx, service.Slug)
 	if err != nil && !errors.Is(err, ErrServiceNotFound) {
@@ -138,17 +316,39 @@ func (s *Service) CreateService(ctx context.Context, service *domain.Service) er
 		service.Status = domain.ServiceStatusOperational
 	}
 
-	if err := s.repo.CreateService(ctx, service); err != nil {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if autoOrder {
+		maxOrder, err := s.repo.GetMaxServiceOrderTx(ctx, tx)
+		if err != nil {
+			return err
+		}
+		service.Order = maxOrder + 1
+	} else if err := s.repo.ShiftServiceOrdersFromTx(ctx, tx, service.Order); err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateServiceTx(ctx, tx, service); err != nil {
 		return err
 	}
 
 	// Set service groups if provided
 	if len(service.GroupIDs) > 0 {
-		if err := s.repo.SetServiceGroups(ctx, service.ID, service.GroupIDs); err != nil {
+		if err := s.repo.SetServiceGroupsTx(ctx, tx, service.ID, service.GroupIDs, createdBy); err != nil {
 			return fmt.Errorf("set service groups: %w", err)
 		}
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
 	return nil
 }
 
@@ -157,6 +357,12 @@ func (s *Service) GetServiceBySlug(ctx context.Context, slug string) (*domain.Se
 	return s.repo.GetServiceBySlug(ctx, slug)
 }
 
+// FindServiceSlugRedirect returns the current slug of a service renamed from oldSlug.
+// Returns ErrServiceNotFound if no service had that slug.
+func (s *Service) FindServiceSlugRedirect(ctx context.Context, oldSlug string) (string, error) {
+	return s.repo.FindSlugRedirect(ctx, oldSlug)
+}
+
 // GetServiceByID returns a service by its ID.
 func (s *Service) GetServiceByID(ctx context.Context, id string) (*domain.Service, error) {
 	return s.repo.GetServiceByID(ctx, id)
@@ -167,25 +373,49 @@ func (s *Service) ListServices(ctx context.Context, filter ServiceFilter) ([]dom
 	return s.repo.ListServices(ctx, filter)
 }
 
+// GetServicesByCustomField returns non-archived services whose custom field key equals value.
+func (s *Service) GetServicesByCustomField(ctx context.Context, key, value string) ([]domain.Service, error) {
+	return s.repo.GetServicesByCustomField(ctx, key, value)
+}
+
 // UpdateServiceInput contains data for updating a service with audit info.
 type UpdateServiceInput struct {
 	Service   *domain.Service
 	UpdatedBy string
 	Reason    string
+	Force     bool // allow a slug change while the service has active events
 }
 
 // UpdateService updates an existing service.
+// A slug change is rejected with ErrSlugChangeActiveEvents while the service has
+// active events, unless input.Force is set: integrations refer to services by slug.
 func (s *Service) UpdateService(ctx context.Context, input UpdateServiceInput) error {
 	service := input.Service
 	if err := validateSlug(service.Slug); err != nil {
 		return err
 	}
+	if err := validateServiceURLs(service); err != nil {
+		return err
+	}
+	if err := validateCustomFields(service.CustomFields); err != nil {
+		return err
+	}
+	if err := validateHealthCheck(service); err != nil {
+		return err
+	}
 
 	existing, err := s.repo.GetServiceByID(ctx, service.ID)
 	if err != nil {
 		return err
 	}
 
+	// An unchanged icon is not requested again
+	if service.IconURL != existing.IconURL {
+		if err := s.validateIcon(ctx, service.IconURL); err != nil {
+			return err
+		}
+	}
+
 	if existing.Slug != service.Slug {
 		existingBySlug, err := s.repo.GetServiceBySlug(ctx, service.Slug)
 		if err != nil && !errors.Is(err, ErrServiceNotFound) {
@@ -194,6 +424,16 @@ func (s *Service) UpdateService(ctx context.Context, input UpdateServiceInput) e
 		if existingBySlug != nil {
 			return ErrSlugExists
 		}
+
+		if !input.Force {
+			hasActive, err := s.repo.HasActiveEventsBySlug(ctx, existing.Slug)
+			if err != nil {
+				return fmt.Errorf("check active events: %w", err)
+			}
+			if hasActive {
+				return ErrSlugChangeActiveEvents
+			}
+		}
 	}
 
 	// Start transaction for atomic update
@@ -224,8 +464,15 @@ func (s *Service) UpdateService(ctx context.Context, input UpdateServiceInput) e
 		return err
 	}
 
+	// Keep links to the old slug working
+	if existing.Slug != service.Slug {
+		if err := s.repo.CreateSlugRedirectTx(ctx, tx, existing.Slug, service.Slug); err != nil {
+			return err
+		}
+	}
+
 	// Update service groups
-	if err := s.repo.SetServiceGroupsTx(ctx, tx, service.ID, service.GroupIDs); err != nil {
+	if err := s.repo.SetServiceGroupsTx(ctx, tx, service.ID, service.GroupIDs, input.UpdatedBy); err != nil {
 		return fmt.Errorf("set service groups: %w", err)
 	}
 
@@ -250,9 +497,81 @@ func (s *Service) DeleteService(ctx context.Context, id string) error {
 	return s.repo.ArchiveService(ctx, id)
 }
 
-// RestoreService restores an archived service.
-func (s *Service) RestoreService(ctx context.Context, id string) error {
-	return s.repo.RestoreService(ctx, id)
+// ReorderServices sets the order of the given services atomically.
+// All services must exist and be non-archived; IDs and orders must be unique within items.
+func (s *Service) ReorderServices(ctx context.Context, items []ServiceOrderItem) error {
+	ids := make([]string, 0, len(items))
+	seenIDs := make(map[string]bool, len(items))
+	seenOrders := make(map[int]bool, len(items))
+	for _, item := range items {
+		if seenIDs[item.ID] {
+			return fmt.Errorf("%w: %s", ErrDuplicateServiceID, item.ID)
+		}
+		if seenOrders[item.Order] {
+			return fmt.Errorf("%w: %d", ErrDuplicateOrder, item.Order)
+		}
+		seenIDs[item.ID] = true
+		seenOrders[item.Order] = true
+		ids = append(ids, item.ID)
+	}
+
+	missing, err := s.repo.FindMissingServiceIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("validate services: %w", err)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrServiceNotFound, strings.Join(missing, ", "))
+	}
+
+	return s.repo.BatchUpdateServiceOrder(ctx, items)
+}
+
+// BulkArchiveServices archives multiple services in one transaction.
+// Returns IDs that ended up archived and per-ID failures.
+func (s *Service) BulkArchiveServices(ctx context.Context, ids []string) ([]string, []BulkError, error) {
+	return s.repo.BulkArchiveServices(ctx, ids)
+}
+
+// RestoreService restores an archived service and resets its status to operational.
+// The reset is recorded in the status log as a manual change.
+func (s *Service) RestoreService(ctx context.Context, id, restoredBy string) error {
+	existing, err := s.repo.GetServiceByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !existing.IsArchived() {
+		return ErrServiceNotArchived
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := s.repo.RestoreServiceTx(ctx, tx, id); err != nil {
+		return err
+	}
+
+	entry := &domain.ServiceStatusLogEntry{
+		ServiceID:  id,
+		OldStatus:  &existing.Status,
+		NewStatus:  domain.ServiceStatusOperational,
+		SourceType: domain.StatusLogSourceManual,
+		Reason:     "Service restored from archive",
+		CreatedBy:  restoredBy,
+	}
+	if err := s.repo.CreateStatusLogEntryTx(ctx, tx, entry); err != nil {
+		return fmt.Errorf("create status log entry: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
 }
 
 // UpdateServiceTags replaces all tags for a service.
@@ -285,8 +604,8 @@ func (s *Service) GetGroupServices(ctx context.Context, groupID string) ([]strin
 }
 
 // UpdateGroupServices replaces all service memberships for a group.
-func (s *Service) UpdateGroupServices(ctx context.Context, groupID string, serviceIDs []string) error {
-	return s.repo.SetGroupServices(ctx, groupID, serviceIDs)
+func (s *Service) UpdateGroupServices(ctx context.Context, groupID string, serviceIDs []string, updatedBy string) error {
+	return s.repo.SetGroupServices(ctx, groupID, serviceIDs, updatedBy)
 }
 
 // GetServiceBySlugWithEffectiveStatus returns a service with its effective status.
@@ -304,11 +623,78 @@ func (s *Service) ListServicesWithEffectiveStatus(ctx context.Context, filter Se
 	return s.repo.ListServicesWithEffectiveStatus(ctx, filter)
 }
 
+// GetEffectiveStatuses returns effective statuses of all non-archived services keyed by service ID.
+func (s *Service) GetEffectiveStatuses(ctx context.Context) (map[string]domain.ServiceStatus, error) {
+	services, err := s.repo.ListServicesWithEffectiveStatus(ctx, ServiceFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]domain.ServiceStatus, len(services))
+	for _, svc := range services {
+		statuses[svc.ID] = svc.EffectiveStatus
+	}
+	return statuses, nil
+}
+
 // UpdateServiceStatusTx updates the stored status of a service within a transaction.
 func (s *Service) UpdateServiceStatusTx(ctx context.Context, tx pgx.Tx, serviceID string, status domain.ServiceStatus) error {
 	return s.repo.UpdateServiceStatusTx(ctx, tx, serviceID, status)
 }
 
+// ResetServicesToOperationalTx sets services no longer affected by any active event
+// other than eventID to operational and logs the changes with source. Returns the reset service IDs.
+func (s *Service) ResetServicesToOperationalTx(ctx context.Context, tx pgx.Tx, serviceIDs []string, eventID string, source domain.StatusLogSourceType, reason, createdBy string) ([]string, error) {
+	return s.repo.ResetServicesToOperationalTx(ctx, tx, serviceIDs, eventID, source, reason, createdBy)
+}
+
+// GetEffectiveStatusesTx returns the effective statuses of the given services as seen by tx.
+func (s *Service) GetEffectiveStatusesTx(ctx context.Context, tx pgx.Tx, serviceIDs []string) (map[string]domain.ServiceStatus, error) {
+	return s.repo.GetEffectiveStatusesTx(ctx, tx, serviceIDs)
+}
+
+// SetServiceStatus sets the stored status of a service and records the change in the status log.
+// Returns false without changes if the service already has this status.
+func (s *Service) SetServiceStatus(ctx context.Context, serviceID string, status domain.ServiceStatus, source domain.StatusLogSourceType, reason, changedBy string) (bool, error) {
+	existing, err := s.repo.GetServiceByID(ctx, serviceID)
+	if err != nil {
+		return false, err
+	}
+	if existing.Status == status {
+		return false, nil
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := s.repo.UpdateServiceStatusTx(ctx, tx, serviceID, status); err != nil {
+		return false, fmt.Errorf("update service status: %w", err)
+	}
+
+	entry := &domain.ServiceStatusLogEntry{
+		ServiceID:  serviceID,
+		OldStatus:  &existing.Status,
+		NewStatus:  status,
+		SourceType: source,
+		Reason:     reason,
+		CreatedBy:  changedBy,
+	}
+	if err := s.repo.CreateStatusLogEntryTx(ctx, tx, entry); err != nil {
+		return false, fmt.Errorf("create status log entry: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
 // GetServiceStatus returns the stored status of a service.
 func (s *Service) GetServiceStatus(ctx context.Context, serviceID string) (domain.ServiceStatus, error) {
 	service, err := s.repo.GetServiceByID(ctx, serviceID)
@@ -328,6 +714,11 @@ func (s *Service) CreateStatusLogEntryTx(ctx context.Context, tx pgx.Tx, entry *
 	return s.repo.CreateStatusLogEntryTx(ctx, tx, entry)
 }
 
+// GetLatestStatusLogEntry returns the most recent status change of a service, nil if it has none.
+func (s *Service) GetLatestStatusLogEntry(ctx context.Context, serviceID string) (*domain.ServiceStatusLogEntry, error) {
+	return s.repo.GetLatestStatusLogEntry(ctx, serviceID)
+}
+
 // ListStatusLog returns the status change history for a service.
 func (s *Service) ListStatusLog(ctx context.Context, serviceID string, limit, offset int) ([]domain.ServiceStatusLogEntry, int, error) {
 	entries, err := s.repo.ListStatusLog(ctx, serviceID, limit, offset)
@@ -343,6 +734,41 @@ func (s *Service) ListStatusLog(ctx context.Context, serviceID string, limit, of
 	return entries, total, nil
 }
 
+// ListMembershipLog returns paginated group membership changes of a service with total count.
+func (s *Service) ListMembershipLog(ctx context.Context, serviceID string, limit, offset int) ([]domain.MembershipLogEntry, int, error) {
+	entries, err := s.repo.ListMembershipLog(ctx, serviceID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountMembershipLog(ctx, serviceID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+// GetServiceUptime computes availability of a service over the window ending now.
+func (s *Service) GetServiceUptime(ctx context.Context, serviceID string, window time.Duration) (uptime.UptimeReport, error) {
+	now := time.Now()
+	entries, err := s.repo.ListStatusLogRange(ctx, serviceID, uptime.WindowStart(window, now), now)
+	if err != nil {
+		return uptime.UptimeReport{}, fmt.Errorf("list status log range: %w", err)
+	}
+	return uptime.ComputeUptimeFromLog(entries, window), nil
+}
+
+// GetServiceTimeline returns status transitions of a service over the window ending now.
+func (s *Service) GetServiceTimeline(ctx context.Context, serviceID string, window time.Duration) ([]TimelineEntry, error) {
+	now := time.Now()
+	entries, events, err := s.repo.ListStatusTimeline(ctx, serviceID, uptime.WindowStart(window, now), now)
+	if err != nil {
+		return nil, fmt.Errorf("list status timeline: %w", err)
+	}
+	return BuildTimeline(entries, events), nil
+}
+
 // DeleteStatusLogByEventIDTx deletes all status log entries for a given event within a transaction.
 func (s *Service) DeleteStatusLogByEventIDTx(ctx context.Context, tx pgx.Tx, eventID string) error {
 	return s.repo.DeleteStatusLogByEventIDTx(ctx, tx, eventID)
@@ -377,3 +803,63 @@ func validateSlug(slug string) error {
 	}
 	return nil
 }
+
+// validateIcon checks that a non-empty icon URL serves an image.
+func (s *Service) validateIcon(ctx context.Context, iconURL string) error {
+	if iconURL == "" || s.icons == nil {
+		return nil
+	}
+	return s.icons.ValidateIcon(ctx, iconURL)
+}
+
+// validateServiceURLs checks that the external, documentation, icon and health check URLs
+// of a service are empty or absolute http(s): they are rendered as links or requested.
+func validateServiceURLs(service *domain.Service) error {
+	for name, value := range map[string]string{
+		"external_url":      service.ExternalURL,
+		"documentation_url": service.DocumentationURL,
+		"icon_url":          service.IconURL,
+		"health_check_url":  service.HealthCheckURL,
+	} {
+		if value == "" {
+			continue
+		}
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %s must be an absolute http(s) URL", ErrInvalidServiceURL, name)
+		}
+	}
+	return nil
+}
+
+// validateCustomFields checks custom field keys against customFieldKeyRegex and
+// limits values to MaxCustomFieldValueLength characters.
+func validateCustomFields(fields map[string]string) error {
+	for key, value := range fields {
+		if !customFieldKeyRegex.MatchString(key) {
+			return fmt.Errorf("%w: key %q must match %s", ErrInvalidCustomField, key, customFieldKeyRegex)
+		}
+		if utf8.RuneCountInString(value) > MaxCustomFieldValueLength {
+			return fmt.Errorf("%w: value of %q exceeds %d characters", ErrInvalidCustomField, key, MaxCustomFieldValueLength)
+		}
+	}
+	return nil
+}
+
+// validateHealthCheck checks that an enabled health check has a URL and an interval
+// within [MinHealthCheckIntervalSeconds, MaxHealthCheckIntervalSeconds].
+// The URL itself is checked by validateServiceURLs.
+func validateHealthCheck(service *domain.Service) error {
+	interval := service.HealthCheckIntervalSeconds
+	if interval == 0 {
+		return nil
+	}
+	if interval < MinHealthCheckIntervalSeconds || interval > MaxHealthCheckIntervalSeconds {
+		return fmt.Errorf("%w: health_check_interval_seconds must be 0 or between %d and %d",
+			ErrInvalidHealthCheck, MinHealthCheckIntervalSeconds, MaxHealthCheckIntervalSeconds)
+	}
+	if service.HealthCheckURL == "" {
+		return fmt.Errorf("%w: health_check_url is required when the check is enabled", ErrInvalidHealthCheck)
+	}
+	return nil
+}
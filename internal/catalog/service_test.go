@@ -1,7 +1,12 @@
 package catalog
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+
+	"github.com/bissquit/incident-garden/internal/domain"
 )
 
 func TestValidateSlug(t *testing.T) {
@@ -33,3 +38,114 @@ func TestValidateSlug(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCustomFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  map[string]string
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"valid", map[string]string{"team": "platform", "cost_center_2": ""}, false},
+		{"max key length", map[string]string{"a" + strings.Repeat("b", 49): "x"}, false},
+		{"max value length", map[string]string{"team": strings.Repeat("é", MaxCustomFieldValueLength)}, false},
+		{"key too long", map[string]string{"a" + strings.Repeat("b", 50): "x"}, true},
+		{"leading digit", map[string]string{"1team": "x"}, true},
+		{"leading underscore", map[string]string{"_team": "x"}, true},
+		{"uppercase", map[string]string{"Team": "x"}, true},
+		{"hyphen", map[string]string{"cost-center": "x"}, true},
+		{"empty key", map[string]string{"": "x"}, true},
+		{"value too long", map[string]string{"team": strings.Repeat("x", MaxCustomFieldValueLength+1)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCustomFields(tt.fields)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCustomFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidCustomField) {
+				t.Errorf("validateCustomFields() error = %v, want ErrInvalidCustomField", err)
+			}
+		})
+	}
+}
+
+func TestValidateHealthCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		interval int
+		wantErr  bool
+	}{
+		{"disabled", "", 0, false},
+		{"disabled with url", "https://api.example.com/health", 0, false},
+		{"enabled", "https://api.example.com/health", 30, false},
+		{"min interval", "https://api.example.com/health", MinHealthCheckIntervalSeconds, false},
+		{"max interval", "https://api.example.com/health", MaxHealthCheckIntervalSeconds, false},
+		{"interval too short", "https://api.example.com/health", MinHealthCheckIntervalSeconds - 1, true},
+		{"interval too long", "https://api.example.com/health", MaxHealthCheckIntervalSeconds + 1, true},
+		{"negative interval", "https://api.example.com/health", -1, true},
+		{"enabled without url", "", 30, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHealthCheck(&domain.Service{HealthCheckURL: tt.url, HealthCheckIntervalSeconds: tt.interval})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateHealthCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidHealthCheck) {
+				t.Errorf("validateHealthCheck() error = %v, want ErrInvalidHealthCheck", err)
+			}
+		})
+	}
+}
+
+func TestDependencyService_SetDependencies_Rejects(t *testing.T) {
+	tests := []struct {
+		name string
+		deps []domain.ServiceDependency
+		want error
+	}{
+		{"self", []domain.ServiceDependency{{ServiceID: "s1", Type: domain.DependencyTypeHard}}, ErrSelfDependency},
+		{"invalid type", []domain.ServiceDependency{{ServiceID: "s2", Type: "optional"}}, ErrInvalidDependencyType},
+		{"duplicate", []domain.ServiceDependency{
+			{ServiceID: "s2", Type: domain.DependencyTypeHard},
+			{ServiceID: "s2", Type: domain.DependencyTypeSoft},
+		}, ErrDuplicateServiceID},
+	}
+
+	// Rejected before any lookup: nil repositories would panic otherwise
+	s := NewDependencyService(nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.SetDependencies(context.Background(), "s1", tt.deps)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("SetDependencies() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_SetServiceSLATarget_Rejects(t *testing.T) {
+	for _, target := range []float64{0, -1, 100.001} {
+		target := target
+		// Rejected before the repository is touched
+		err := NewService(nil, nil).SetServiceSLATarget(context.Background(), "s1", &target)
+		if !errors.Is(err, ErrInvalidSLATarget) {
+			t.Errorf("SetServiceSLATarget(%v) error = %v, want %v", target, err, ErrInvalidSLATarget)
+		}
+	}
+}
+
+func TestService_ValidateParentGroup_Self(t *testing.T) {
+	self := "g1"
+	// Rejected before the repository is touched
+	if err := NewService(nil, nil).validateParentGroup(context.Background(), "g1", &self); !errors.Is(err, ErrGroupCycle) {
+		t.Errorf("validateParentGroup() error = %v, want %v", err, ErrGroupCycle)
+	}
+	if err := NewService(nil, nil).validateParentGroup(context.Background(), "g1", nil); err != nil {
+		t.Errorf("validateParentGroup() without parent error = %v", err)
+	}
+}
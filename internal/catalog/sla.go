@@ -0,0 +1,166 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog/uptime"
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// ErrInvalidSLATarget is returned for an SLA uptime target outside (0, 100].
+var ErrInvalidSLATarget = errors.New("sla uptime target must be greater than 0 and at most 100")
+
+// SLAStatus compares the SLA target of a service with its uptime in the current UTC month.
+type SLAStatus struct {
+	Target        *float64  `json:"sla_uptime_target"`
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+	UptimePercent float64   `json:"uptime_percent"`
+	Breached      bool      `json:"breached"`
+}
+
+// SetServiceSLATarget sets the monthly uptime target of a service in percent; nil removes it.
+// Changing the target allows a new breach notification in the current month.
+func (s *Service) SetServiceSLATarget(ctx context.Context, serviceID string, target *float64) error {
+	if target != nil && (*target <= 0 || *target > 100) {
+		return ErrInvalidSLATarget
+	}
+	return s.repo.SetServiceSLATarget(ctx, serviceID, target)
+}
+
+// GetServiceSLA returns the SLA target of a service with its uptime since the start of the month.
+// Without a target the service is never breached.
+func (s *Service) GetServiceSLA(ctx context.Context, service *domain.Service) (*SLAStatus, error) {
+	report, err := s.GetServiceUptime(ctx, service.ID, uptime.MonthWindow(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	return &SLAStatus{
+		Target:        service.SLAUptimeTarget,
+		From:          report.From,
+		To:            report.To,
+		UptimePercent: report.UptimePercent,
+		Breached:      service.SLAUptimeTarget != nil && report.UptimePercent < *service.SLAUptimeTarget,
+	}, nil
+}
+
+// SLAConfig contains SLA breach check settings.
+type SLAConfig struct {
+	PollInterval time.Duration
+}
+
+// DefaultSLAConfig returns default SLA check configuration.
+func DefaultSLAConfig() SLAConfig {
+	return SLAConfig{
+		PollInterval: 15 * time.Minute,
+	}
+}
+
+// SLABreachNotifier sends alerts about services below their SLA target.
+type SLABreachNotifier interface {
+	OnSLABreach(ctx context.Context, service *domain.Service, actualUptime float64) error
+}
+
+// SLAChecker periodically compares the current-month uptime of services with
+// their SLA target and notifies about a breach once per service and month.
+type SLAChecker struct {
+	config   SLAConfig
+	service  *Service
+	notifier SLABreachNotifier
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewSLAChecker creates a new SLA breach checker.
+func NewSLAChecker(config SLAConfig, service *Service, notifier SLABreachNotifier) *SLAChecker {
+	return &SLAChecker{
+		config:   config,
+		service:  service,
+		notifier: notifier,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start launches the checker goroutine.
+func (c *SLAChecker) Start(ctx context.Context) {
+	slog.InfoContext(ctx, "starting sla checker", "poll_interval", c.config.PollInterval)
+
+	c.wg.Add(1)
+	go c.run(ctx)
+}
+
+// Stop gracefully stops the checker.
+func (c *SLAChecker) Stop() {
+	close(c.stopCh)
+	c.wg.Wait()
+	slog.Info("sla checker stopped")
+}
+
+func (c *SLAChecker) run(ctx context.Context) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.check(ctx)
+		}
+	}
+}
+
+// check notifies about every service below its target that was not notified this month.
+func (c *SLAChecker) check(ctx context.Context) {
+	services, err := c.service.repo.ListServicesWithSLATarget(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list services with sla target", "error", err)
+		return
+	}
+
+	for i := range services {
+		if err := c.checkService(ctx, &services[i]); err != nil {
+			slog.ErrorContext(ctx, "failed to check sla", "service_id", services[i].ID, "error", err)
+		}
+	}
+}
+
+// checkService claims the breach before notifying, so concurrent instances alert once.
+// A failed notification is not retried: the breach is already claimed for the month.
+func (c *SLAChecker) checkService(ctx context.Context, service *domain.Service) error {
+	status, err := c.service.GetServiceSLA(ctx, service)
+	if err != nil {
+		return err
+	}
+	if !status.Breached {
+		return nil
+	}
+
+	claimed, err := c.service.repo.ClaimSLABreach(ctx, service.ID, status.From)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	if err := c.notifier.OnSLABreach(ctx, service, status.UptimePercent); err != nil {
+		return fmt.Errorf("notify sla breach: %w", err)
+	}
+	slog.InfoContext(ctx, "sla breach notified",
+		"service_id", service.ID,
+		"target", *service.SLAUptimeTarget,
+		"uptime_percent", status.UptimePercent,
+	)
+	return nil
+}
@@ -0,0 +1,57 @@
+package catalog
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// ServiceStatusLogEntry is a status log entry together with the message of
+// the event update that produced it, if the change came from an event.
+type ServiceStatusLogEntry struct {
+	domain.ServiceStatusLogEntry
+	UpdateMessage string
+}
+
+// TimelineEntry describes one status transition of a service.
+type TimelineEntry struct {
+	At         time.Time             `json:"at"`
+	From       *domain.ServiceStatus `json:"from"`
+	To         domain.ServiceStatus  `json:"to"`
+	EventID    *string               `json:"event_id,omitempty"`
+	EventTitle string                `json:"event_title,omitempty"`
+	Message    string                `json:"message,omitempty"`
+}
+
+// BuildTimeline converts status log entries into chronological transitions.
+// Event titles are taken from events by the entry's event ID. The message is
+// the event update message when there is one, otherwise the log entry reason.
+func BuildTimeline(entries []ServiceStatusLogEntry, events map[string]*domain.Event) []TimelineEntry {
+	sorted := make([]ServiceStatusLogEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	timeline := make([]TimelineEntry, 0, len(sorted))
+	for _, e := range sorted {
+		item := TimelineEntry{
+			At:      e.CreatedAt,
+			From:    e.OldStatus,
+			To:      e.NewStatus,
+			EventID: e.EventID,
+			Message: e.UpdateMessage,
+		}
+		if item.Message == "" {
+			item.Message = e.Reason
+		}
+		if e.EventID != nil {
+			if event, ok := events[*e.EventID]; ok {
+				item.EventTitle = event.Title
+			}
+		}
+		timeline = append(timeline, item)
+	}
+	return timeline
+}
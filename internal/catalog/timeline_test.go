@@ -0,0 +1,73 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+func logEntry(at time.Time, from *domain.ServiceStatus, to domain.ServiceStatus, eventID *string, reason, message string) ServiceStatusLogEntry {
+	return ServiceStatusLogEntry{
+		ServiceStatusLogEntry: domain.ServiceStatusLogEntry{
+			OldStatus: from,
+			NewStatus: to,
+			EventID:   eventID,
+			Reason:    reason,
+			CreatedAt: at,
+		},
+		UpdateMessage: message,
+	}
+}
+
+func TestBuildTimeline(t *testing.T) {
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	operational := domain.ServiceStatusOperational
+	degraded := domain.ServiceStatusDegraded
+	eventID := "11111111-1111-1111-1111-111111111111"
+	missingID := "22222222-2222-2222-2222-222222222222"
+
+	entries := []ServiceStatusLogEntry{
+		logEntry(base.Add(time.Hour), &degraded, operational, &eventID, "Event resolved, no other active events", "fixed"),
+		logEntry(base, &operational, degraded, &eventID, "Event created: DB outage", "investigating"),
+		logEntry(base.Add(2*time.Hour), nil, degraded, nil, "manual check", ""),
+		logEntry(base.Add(3*time.Hour), &degraded, operational, &missingID, "deleted event", ""),
+	}
+	events := map[string]*domain.Event{eventID: {ID: eventID, Title: "DB outage"}}
+
+	timeline := BuildTimeline(entries, events)
+	if len(timeline) != 4 {
+		t.Fatalf("len(timeline) = %d, want 4", len(timeline))
+	}
+
+	first := timeline[0]
+	if !first.At.Equal(base) || *first.From != operational || first.To != degraded {
+		t.Errorf("first transition = %+v, want operational -> degraded at %v", first, base)
+	}
+	if first.EventTitle != "DB outage" || first.Message != "investigating" {
+		t.Errorf("first transition title/message = %q/%q", first.EventTitle, first.Message)
+	}
+
+	if timeline[1].Message != "fixed" {
+		t.Errorf("second transition message = %q, want update message", timeline[1].Message)
+	}
+
+	manual := timeline[2]
+	if manual.From != nil || manual.EventID != nil || manual.EventTitle != "" {
+		t.Errorf("manual transition = %+v, want no previous status and no event", manual)
+	}
+	if manual.Message != "manual check" {
+		t.Errorf("manual transition message = %q, want reason", manual.Message)
+	}
+
+	if timeline[3].EventTitle != "" {
+		t.Errorf("unknown event title = %q, want empty", timeline[3].EventTitle)
+	}
+}
+
+func TestBuildTimeline_Empty(t *testing.T) {
+	timeline := BuildTimeline(nil, nil)
+	if timeline == nil || len(timeline) != 0 {
+		t.Errorf("BuildTimeline(nil) = %#v, want empty non-nil slice", timeline)
+	}
+}
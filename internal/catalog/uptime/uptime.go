@@ -0,0 +1,185 @@
+// Package uptime computes service availability from the service status log.
+package uptime
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// ErrInvalidWindow is returned for unsupported window values.
+var ErrInvalidWindow = errors.New("invalid window: must be one of 7d, 30d, 90d")
+
+// DefaultWindow is used when no window is requested.
+const DefaultWindow = "30d"
+
+const day = 24 * time.Hour
+
+// windows lists supported report windows.
+var windows = map[string]time.Duration{
+	"7d":  7 * day,
+	"30d": 30 * day,
+	"90d": 90 * day,
+}
+
+// UptimeReport describes availability of a service over a window.
+type UptimeReport struct {
+	From            time.Time     `json:"from"`
+	To              time.Time     `json:"to"`
+	UptimePercent   float64       `json:"uptime_percent"`
+	DowntimeSeconds int64         `json:"downtime_seconds"`
+	Daily           []DailyUptime `json:"daily"`
+}
+
+// DailyUptime describes availability of a service during one UTC day.
+type DailyUptime struct {
+	Date            string  `json:"date"` // YYYY-MM-DD (UTC)
+	UptimePercent   float64 `json:"uptime_percent"`
+	DowntimeSeconds int64   `json:"downtime_seconds"`
+}
+
+// ParseWindow converts a window string ("7d", "30d", "90d") to a duration.
+// Empty string means DefaultWindow.
+func ParseWindow(s string) (time.Duration, error) {
+	if s == "" {
+		s = DefaultWindow
+	}
+	window, ok := windows[s]
+	if !ok {
+		return 0, ErrInvalidWindow
+	}
+	return window, nil
+}
+
+// WindowStart returns the beginning of the report window ending at now:
+// UTC midnight of the first day, so the window covers whole days with today being the last one.
+func WindowStart(window time.Duration, now time.Time) time.Time {
+	days := int(window / day)
+	if days < 1 {
+		days = 1
+	}
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return today.AddDate(0, 0, -(days - 1))
+}
+
+// MonthWindow returns the window covering the current UTC month up to now:
+// WindowStart(MonthWindow(now), now) is midnight of the first day of the month.
+func MonthWindow(now time.Time) time.Duration {
+	return time.Duration(now.UTC().Day()) * day
+}
+
+// ComputeUptimeFromLog computes availability for the window ending now.
+//
+// Any non-operational status (including maintenance) counts as downtime.
+// The status at the start of the window is taken from the latest entry before it, if present,
+// otherwise from old_status of the first entry inside the window; operational by default.
+func ComputeUptimeFromLog(entries []domain.ServiceStatusLogEntry, window time.Duration) UptimeReport {
+	return computeUptime(entries, window, time.Now())
+}
+
+func computeUptime(entries []domain.ServiceStatusLogEntry, window time.Duration, now time.Time) UptimeReport {
+	start := WindowStart(window, now)
+	end := now.UTC()
+
+	sorted := make([]domain.ServiceStatusLogEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	numDays := int(end.Sub(start)/day) + 1
+	downtime := make([]time.Duration, numDays)
+
+	// addDowntime spreads interval [from, to) over daily buckets.
+	addDowntime := func(from, to time.Time) {
+		for i := range downtime {
+			dayStart := start.Add(time.Duration(i) * day)
+			dayEnd := minTime(dayStart.Add(day), end)
+			overlap := minTime(to, dayEnd).Sub(maxTime(from, dayStart))
+			if overlap > 0 {
+				downtime[i] += overlap
+			}
+		}
+	}
+
+	status := domain.ServiceStatusOperational
+	haveBaseline := false
+	inWindow := false
+	cursor := start
+
+	for _, e := range sorted {
+		at := e.CreatedAt.UTC()
+		if at.Before(start) {
+			status = e.NewStatus
+			haveBaseline = true
+			continue
+		}
+		if !at.Before(end) {
+			break
+		}
+		if !inWindow {
+			inWindow = true
+			if !haveBaseline && e.OldStatus != nil {
+				status = *e.OldStatus
+			}
+		}
+		if status != domain.ServiceStatusOperational {
+			addDowntime(cursor, at)
+		}
+		status = e.NewStatus
+		cursor = at
+	}
+	if status != domain.ServiceStatusOperational {
+		addDowntime(cursor, end)
+	}
+
+	report := UptimeReport{
+		From:  start,
+		To:    end,
+		Daily: make([]DailyUptime, 0, numDays),
+	}
+
+	var totalDowntime time.Duration
+	for i, down := range downtime {
+		dayStart := start.Add(time.Duration(i) * day)
+		dayLen := minTime(dayStart.Add(day), end).Sub(dayStart)
+		totalDowntime += down
+		report.Daily = append(report.Daily, DailyUptime{
+			Date:            dayStart.Format("2006-01-02"),
+			UptimePercent:   percent(down, dayLen),
+			DowntimeSeconds: int64(down / time.Second),
+		})
+	}
+
+	report.DowntimeSeconds = int64(totalDowntime / time.Second)
+	report.UptimePercent = percent(totalDowntime, end.Sub(start))
+
+	return report
+}
+
+// percent returns uptime percentage rounded to two decimals.
+func percent(down, total time.Duration) float64 {
+	if total <= 0 {
+		return 100
+	}
+	up := float64(total-down) / float64(total) * 100
+	return math.Round(up*100) / 100
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
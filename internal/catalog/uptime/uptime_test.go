@@ -0,0 +1,145 @@
+package uptime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// now is 12:00 UTC, so today's bucket is half a day long.
+var now = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func entry(at time.Time, oldStatus, newStatus domain.ServiceStatus) domain.ServiceStatusLogEntry {
+	e := domain.ServiceStatusLogEntry{NewStatus: newStatus, CreatedAt: at}
+	if oldStatus != "" {
+		e.OldStatus = &oldStatus
+	}
+	return e
+}
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 30 * day, false},
+		{"7d", 7 * day, false},
+		{"30d", 30 * day, false},
+		{"90d", 90 * day, false},
+		{"1d", 0, true},
+		{"30", 0, true},
+		{"720h", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseWindow(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidWindow)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWindowStart(t *testing.T) {
+	assert.Equal(t, time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), WindowStart(7*day, now))
+
+	local := now.In(time.FixedZone("UTC+5", 5*3600))
+	assert.Equal(t, time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), WindowStart(7*day, local))
+}
+
+func TestMonthWindow(t *testing.T) {
+	assert.Equal(t, 10*day, MonthWindow(now))
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), WindowStart(MonthWindow(now), now))
+
+	firstDay := time.Date(2026, 4, 1, 0, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), WindowStart(MonthWindow(firstDay), firstDay))
+}
+
+func TestComputeUptime_NoEntries(t *testing.T) {
+	report := computeUptime(nil, 7*day, now)
+
+	assert.Equal(t, 100.0, report.UptimePercent)
+	assert.Equal(t, int64(0), report.DowntimeSeconds)
+	require.Len(t, report.Daily, 7)
+	assert.Equal(t, "2026-03-04", report.Daily[0].Date)
+	assert.Equal(t, "2026-03-10", report.Daily[6].Date)
+	for _, d := range report.Daily {
+		assert.Equal(t, 100.0, d.UptimePercent)
+	}
+}
+
+func TestComputeUptime_OutageWithinDay(t *testing.T) {
+	entries := []domain.ServiceStatusLogEntry{
+		entry(time.Date(2026, 3, 5, 6, 0, 0, 0, time.UTC), domain.ServiceStatusOperational, domain.ServiceStatusMajorOutage),
+		entry(time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC), domain.ServiceStatusMajorOutage, domain.ServiceStatusOperational),
+	}
+
+	report := computeUptime(entries, 7*day, now)
+
+	assert.Equal(t, int64(6*3600), report.DowntimeSeconds)
+	assert.Equal(t, 75.0, report.Daily[1].UptimePercent)
+	assert.Equal(t, int64(6*3600), report.Daily[1].DowntimeSeconds)
+	assert.Equal(t, 100.0, report.Daily[0].UptimePercent)
+	// 6h down out of 6.5 days
+	assert.Equal(t, 96.15, report.UptimePercent)
+}
+
+func TestComputeUptime_OutageSpansMidnight(t *testing.T) {
+	entries := []domain.ServiceStatusLogEntry{
+		entry(time.Date(2026, 3, 6, 18, 0, 0, 0, time.UTC), domain.ServiceStatusOperational, domain.ServiceStatusDegraded),
+		entry(time.Date(2026, 3, 7, 6, 0, 0, 0, time.UTC), domain.ServiceStatusDegraded, domain.ServiceStatusOperational),
+	}
+
+	report := computeUptime(entries, 7*day, now)
+
+	assert.Equal(t, int64(6*3600), report.Daily[2].DowntimeSeconds)
+	assert.Equal(t, int64(6*3600), report.Daily[3].DowntimeSeconds)
+	assert.Equal(t, int64(12*3600), report.DowntimeSeconds)
+}
+
+func TestComputeUptime_BaselineBeforeWindow(t *testing.T) {
+	// Service went down before the window and is still down.
+	entries := []domain.ServiceStatusLogEntry{
+		entry(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), domain.ServiceStatusOperational, domain.ServiceStatusPartialOutage),
+	}
+
+	report := computeUptime(entries, 7*day, now)
+
+	assert.Equal(t, 0.0, report.UptimePercent)
+	assert.Equal(t, int64(6*86400+12*3600), report.DowntimeSeconds)
+	assert.Equal(t, 0.0, report.Daily[6].UptimePercent)
+	assert.Equal(t, int64(12*3600), report.Daily[6].DowntimeSeconds)
+}
+
+func TestComputeUptime_InitialStatusFromOldStatus(t *testing.T) {
+	// No baseline: status before the first entry comes from its old_status.
+	entries := []domain.ServiceStatusLogEntry{
+		entry(time.Date(2026, 3, 4, 6, 0, 0, 0, time.UTC), domain.ServiceStatusMaintenance, domain.ServiceStatusOperational),
+	}
+
+	report := computeUptime(entries, 7*day, now)
+
+	assert.Equal(t, int64(6*3600), report.DowntimeSeconds)
+	assert.Equal(t, 75.0, report.Daily[0].UptimePercent)
+}
+
+func TestComputeUptime_UnsortedAndFutureEntries(t *testing.T) {
+	entries := []domain.ServiceStatusLogEntry{
+		entry(time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), domain.ServiceStatusOperational, domain.ServiceStatusMajorOutage),
+		entry(time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC), domain.ServiceStatusMajorOutage, domain.ServiceStatusOperational),
+		entry(time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC), domain.ServiceStatusOperational, domain.ServiceStatusMajorOutage),
+	}
+
+	report := computeUptime(entries, 7*day, now)
+
+	assert.Equal(t, int64(12*3600), report.DowntimeSeconds)
+	assert.Equal(t, 50.0, report.Daily[4].UptimePercent)
+}
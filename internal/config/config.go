@@ -19,10 +19,19 @@ type Config struct {
 	Database      DatabaseConfig
 	Log           LogConfig
 	JWT           JWTConfig
+	OIDC          OIDCConfig
 	CORS          CORSConfig
 	Cookie        CookieConfig
 	App           AppConfig
 	Notifications NotificationsConfig
+	Webhooks      WebhooksConfig
+	Escalation    EscalationConfig
+	Recurrence    RecurrenceConfig
+	HealthCheck   HealthCheckConfig
+	Idempotency   IdempotencyConfig
+	RateLimit     RateLimitConfig
+	Tracing       TracingConfig
+	Admin         AdminConfig
 }
 
 // AppConfig contains general application settings.
@@ -75,14 +84,26 @@ type JWTConfig struct {
 	RefreshTokenDuration time.Duration
 }
 
+// OIDCConfig contains OpenID Connect login settings.
+type OIDCConfig struct {
+	Issuer       string // provider issuer URL; empty disables /auth/oidc
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string // frontend page receiving code and state, registered at the provider
+}
+
 // NotificationsConfig contains notification system settings.
 type NotificationsConfig struct {
-	Enabled  bool
-	BaseURL  string // Base URL for event links (e.g., https://status.example.com)
-	Email    EmailConfig
-	Telegram TelegramConfig
-	Retry    RetryConfig
-	Worker   WorkerConfig
+	Enabled              bool
+	BaseURL              string // Base URL for event links (e.g., https://status.example.com)
+	SlackAdminWebhookURL string // Slack incoming webhook for every new event, regardless of subscriptions (empty = disabled)
+	Email                EmailConfig
+	Telegram             TelegramConfig
+	Webhook              WebhookConfig
+	Retry                RetryConfig
+	Worker               WorkerConfig
+	Reminder             ReminderConfig
+	SLA                  SLAConfig
 }
 
 // EmailConfig contains email sender settings.
@@ -101,8 +122,103 @@ type TelegramConfig struct {
 	Enabled     bool
 	BotToken    string
 	RateLimit   float64
-	APIUrl      string // Custom API URL template (default: https://api.telegram.org/bot%s/sendMessage)
-	BotUsername string // Bot username for deep links (e.g., YourStatusBot)
+	APIUrl      string        // Custom API URL template (default: https://api.telegram.org/bot%s/sendMessage)
+	BotUsername string        // Bot username for deep links (e.g., YourStatusBot)
+	PollTimeout time.Duration // Long polling timeout of bot commands (getUpdates)
+	// BotEnabled starts the bot command poller. Telegram allows one getUpdates
+	// consumer per bot, so enable it on a single replica only.
+	BotEnabled bool
+}
+
+// WebhookConfig contains outgoing webhook sender settings.
+type WebhookConfig struct {
+	Timeout        time.Duration // per-request timeout
+	MaxAttempts    int           // in-sender attempts for 5xx responses
+	InitialBackoff time.Duration // delay before the first in-sender retry
+}
+
+// WebhooksConfig contains incoming webhook (alert ingest) settings.
+type WebhooksConfig struct {
+	PagerDuty  PagerDutyConfig
+	OpsGenie   OpsGenieConfig
+	Prometheus PrometheusConfig
+	Slack      SlackConfig
+}
+
+// PagerDutyConfig contains PagerDuty webhook settings.
+type PagerDutyConfig struct {
+	Secret string // signing secret; empty disables POST /webhooks/pagerduty
+	// SecretPrevious is the previous signing secret, still accepted until the rotation is completed.
+	// WEBHOOK_SECRET_PREVIOUS is read when WEBHOOKS_PAGERDUTY_SECRET_PREVIOUS is not set: PagerDuty
+	// is the only source verified by webhooks.HMACMiddleware, so the generic name refers to its secret.
+	SecretPrevious string
+}
+
+// OpsGenieConfig contains OpsGenie webhook settings.
+type OpsGenieConfig struct {
+	Token     string // expected X-OG-Delivery-Token header; empty disables POST /webhooks/opsgenie
+	TagPrefix string // alert tags with this prefix name affected service slugs
+}
+
+// SlackConfig contains Slack slash command settings.
+type SlackConfig struct {
+	SigningSecret string // Slack app signing secret; empty disables POST /webhooks/slack/command
+}
+
+// PrometheusConfig contains Prometheus Alertmanager webhook settings.
+type PrometheusConfig struct {
+	Token         string            // static Bearer token; empty disables POST /webhooks/prometheus
+	ServiceLabel  string            // alert label with the service slug
+	SeverityLabel string            // alert label with the alert severity
+	SeverityMap   map[string]string // severity label value -> service status
+	DefaultStatus string            // service status for severities missing from SeverityMap
+}
+
+// EscalationConfig contains automatic incident severity escalation settings.
+type EscalationConfig struct {
+	Enabled      bool
+	MinorAfter   time.Duration // minor → major after this long investigating/identified
+	MajorAfter   time.Duration // major → critical after this long since the last severity change
+	PollInterval time.Duration
+}
+
+// RecurrenceConfig contains recurring maintenance settings.
+type RecurrenceConfig struct {
+	LeadTime     time.Duration // an occurrence is created this long before it starts
+	PollInterval time.Duration
+}
+
+// HealthCheckConfig contains service health check settings.
+// Each service sets its URL and interval; the checker runs only due checks.
+type HealthCheckConfig struct {
+	Timeout      time.Duration // per request to a health check URL
+	PollInterval time.Duration // how often to look for due checks
+}
+
+// IdempotencyConfig contains Idempotency-Key settings.
+type IdempotencyConfig struct {
+	KeyTTL        time.Duration // how long the response to a key is replayed
+	EvictInterval time.Duration // how often expired keys are deleted
+}
+
+// RateLimitConfig contains per-user write request limits.
+type RateLimitConfig struct {
+	Enabled           bool
+	AdminPerMinute    int // POST/PUT/PATCH/DELETE requests per minute for admins
+	OperatorPerMinute int // ... for operators
+	UserPerMinute     int // ... for users
+}
+
+// TracingConfig contains OpenTelemetry tracing settings.
+type TracingConfig struct {
+	Endpoint    string // OTLP/HTTP collector URL (empty = tracing disabled)
+	ServiceName string // service.name resource attribute
+}
+
+// AdminConfig contains access restrictions for admin endpoints.
+type AdminConfig struct {
+	IPAllowlist    []string // CIDR ranges or addresses allowed to call admin endpoints (empty = any)
+	TrustedProxies int      // reverse proxies in front of the app appending to X-Forwarded-For
 }
 
 // RetryConfig contains notification retry settings.
@@ -115,11 +231,23 @@ type RetryConfig struct {
 
 // WorkerConfig contains notification worker settings.
 type WorkerConfig struct {
-	NumWorkers   int
-	BatchSize    int
+	NumWorkers      int
+	BatchSize       int
+	PollInterval    time.Duration
+	ShutdownTimeout time.Duration // how long shutdown waits for in-flight sends
+}
+
+// ReminderConfig contains maintenance reminder settings.
+type ReminderConfig struct {
+	Window       time.Duration // remind about scheduled maintenance starting within this window
 	PollInterval time.Duration
 }
 
+// SLAConfig contains SLA breach check settings.
+type SLAConfig struct {
+	PollInterval time.Duration // how often current-month uptime is compared with SLA targets
+}
+
 // Load loads configuration from config.yaml and environment variables.
 func Load() (*Config, error) {
 	k := koanf.New(".")
@@ -161,8 +289,14 @@ func Load() (*Config, error) {
 			AccessTokenDuration:  k.Duration("JWT_ACCESS_TOKEN_DURATION"),
 			RefreshTokenDuration: k.Duration("JWT_REFRESH_TOKEN_DURATION"),
 		},
+		OIDC: OIDCConfig{
+			Issuer:       k.String("OIDC_ISSUER"),
+			ClientID:     k.String("OIDC_CLIENT_ID"),
+			ClientSecret: k.String("OIDC_CLIENT_SECRET"),
+			RedirectURL:  k.String("OIDC_REDIRECT_URL"),
+		},
 		CORS: CORSConfig{
-			AllowedOrigins: parseOrigins(k.String("CORS_ALLOWED_ORIGINS")),
+			AllowedOrigins: parseList(k.String("CORS_ALLOWED_ORIGINS")),
 		},
 		Cookie: CookieConfig{
 			Secure: k.Bool("COOKIE_SECURE"),
@@ -172,8 +306,9 @@ func Load() (*Config, error) {
 			FrontendURL: k.String("APP_FRONTEND_URL"),
 		},
 		Notifications: NotificationsConfig{
-			Enabled: !k.Exists("NOTIFICATIONS_ENABLED") || k.Bool("NOTIFICATIONS_ENABLED"),
-			BaseURL: k.String("NOTIFICATIONS_BASE_URL"),
+			Enabled:              !k.Exists("NOTIFICATIONS_ENABLED") || k.Bool("NOTIFICATIONS_ENABLED"),
+			BaseURL:              k.String("NOTIFICATIONS_BASE_URL"),
+			SlackAdminWebhookURL: k.String("SLACK_ADMIN_WEBHOOK_URL"),
 			Email: EmailConfig{
 				Enabled:      k.Bool("NOTIFICATIONS_EMAIL_ENABLED"),
 				SMTPHost:     k.String("NOTIFICATIONS_EMAIL_SMTP_HOST"),
@@ -189,6 +324,13 @@ func Load() (*Config, error) {
 				RateLimit:   k.Float64("NOTIFICATIONS_TELEGRAM_RATE_LIMIT"),
 				APIUrl:      k.String("NOTIFICATIONS_TELEGRAM_API_URL"),
 				BotUsername: k.String("NOTIFICATIONS_TELEGRAM_BOT_USERNAME"),
+				PollTimeout: k.Duration("NOTIFICATIONS_TELEGRAM_POLL_TIMEOUT"),
+				BotEnabled:  k.Bool("NOTIFICATIONS_TELEGRAM_BOT_ENABLED"),
+			},
+			Webhook: WebhookConfig{
+				Timeout:        k.Duration("NOTIFICATIONS_WEBHOOK_TIMEOUT"),
+				MaxAttempts:    k.Int("NOTIFICATIONS_WEBHOOK_MAX_ATTEMPTS"),
+				InitialBackoff: k.Duration("NOTIFICATIONS_WEBHOOK_INITIAL_BACKOFF"),
 			},
 			Retry: RetryConfig{
 				MaxAttempts:       k.Int("NOTIFICATIONS_RETRY_MAX_ATTEMPTS"),
@@ -197,11 +339,71 @@ func Load() (*Config, error) {
 				BackoffMultiplier: k.Float64("NOTIFICATIONS_RETRY_BACKOFF_MULTIPLIER"),
 			},
 			Worker: WorkerConfig{
-				NumWorkers:   k.Int("NOTIFICATIONS_WORKER_NUM_WORKERS"),
-				BatchSize:    k.Int("NOTIFICATIONS_WORKER_BATCH_SIZE"),
-				PollInterval: k.Duration("NOTIFICATIONS_WORKER_POLL_INTERVAL"),
+				NumWorkers:      k.Int("NOTIFICATIONS_WORKER_NUM_WORKERS"),
+				BatchSize:       k.Int("NOTIFICATIONS_WORKER_BATCH_SIZE"),
+				PollInterval:    k.Duration("NOTIFICATIONS_WORKER_POLL_INTERVAL"),
+				ShutdownTimeout: k.Duration("NOTIFICATIONS_WORKER_SHUTDOWN_TIMEOUT"),
+			},
+			Reminder: ReminderConfig{
+				Window:       k.Duration("NOTIFICATIONS_REMINDER_WINDOW"),
+				PollInterval: k.Duration("NOTIFICATIONS_REMINDER_POLL_INTERVAL"),
+			},
+			SLA: SLAConfig{
+				PollInterval: k.Duration("NOTIFICATIONS_SLA_POLL_INTERVAL"),
 			},
 		},
+		Webhooks: WebhooksConfig{
+			PagerDuty: PagerDutyConfig{
+				Secret:         k.String("WEBHOOKS_PAGERDUTY_SECRET"),
+				SecretPrevious: firstNonEmpty(k.String("WEBHOOKS_PAGERDUTY_SECRET_PREVIOUS"), k.String("WEBHOOK_SECRET_PREVIOUS")),
+			},
+			OpsGenie: OpsGenieConfig{
+				Token:     k.String("WEBHOOKS_OPSGENIE_TOKEN"),
+				TagPrefix: k.String("WEBHOOKS_OPSGENIE_TAG_PREFIX"),
+			},
+			Prometheus: PrometheusConfig{
+				Token:         k.String("WEBHOOKS_PROMETHEUS_TOKEN"),
+				ServiceLabel:  k.String("WEBHOOKS_PROMETHEUS_SERVICE_LABEL"),
+				SeverityLabel: k.String("WEBHOOKS_PROMETHEUS_SEVERITY_LABEL"),
+				SeverityMap:   parseKeyValues(k.String("WEBHOOKS_PROMETHEUS_SEVERITY_MAP")),
+				DefaultStatus: k.String("WEBHOOKS_PROMETHEUS_DEFAULT_STATUS"),
+			},
+			Slack: SlackConfig{
+				SigningSecret: k.String("WEBHOOKS_SLACK_SIGNING_SECRET"),
+			},
+		},
+		Escalation: EscalationConfig{
+			Enabled:      k.Bool("ESCALATION_ENABLED"),
+			MinorAfter:   k.Duration("ESCALATION_MINOR_AFTER"),
+			MajorAfter:   k.Duration("ESCALATION_MAJOR_AFTER"),
+			PollInterval: k.Duration("ESCALATION_POLL_INTERVAL"),
+		},
+		Recurrence: RecurrenceConfig{
+			LeadTime:     k.Duration("RECURRENCE_LEAD_TIME"),
+			PollInterval: k.Duration("RECURRENCE_POLL_INTERVAL"),
+		},
+		HealthCheck: HealthCheckConfig{
+			Timeout:      k.Duration("HEALTH_CHECK_TIMEOUT"),
+			PollInterval: k.Duration("HEALTH_CHECK_POLL_INTERVAL"),
+		},
+		Idempotency: IdempotencyConfig{
+			KeyTTL:        k.Duration("IDEMPOTENCY_KEY_TTL"),
+			EvictInterval: k.Duration("IDEMPOTENCY_EVICT_INTERVAL"),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:           !k.Exists("RATE_LIMIT_ENABLED") || k.Bool("RATE_LIMIT_ENABLED"),
+			AdminPerMinute:    k.Int("RATE_LIMIT_ADMIN_PER_MINUTE"),
+			OperatorPerMinute: k.Int("RATE_LIMIT_OPERATOR_PER_MINUTE"),
+			UserPerMinute:     k.Int("RATE_LIMIT_USER_PER_MINUTE"),
+		},
+		Tracing: TracingConfig{
+			Endpoint:    k.String("OTEL_EXPORTER_OTLP_ENDPOINT"),
+			ServiceName: k.String("OTEL_SERVICE_NAME"),
+		},
+		Admin: AdminConfig{
+			IPAllowlist:    parseList(k.String("ADMIN_IP_ALLOWLIST")),
+			TrustedProxies: k.Int("ADMIN_TRUSTED_PROXIES"),
+		},
 	}
 
 	setDefaults(cfg)
@@ -269,7 +471,7 @@ func setDefaults(cfg *Config) {
 		cfg.JWT.AccessTokenDuration = 15 * time.Minute
 	}
 	if cfg.JWT.RefreshTokenDuration == 0 {
-		cfg.JWT.RefreshTokenDuration = 168 * time.Hour
+		cfg.JWT.RefreshTokenDuration = 30 * 24 * time.Hour
 	}
 
 	if len(cfg.CORS.AllowedOrigins) == 0 {
@@ -289,6 +491,18 @@ func setDefaults(cfg *Config) {
 	if cfg.Notifications.Telegram.APIUrl == "" {
 		cfg.Notifications.Telegram.APIUrl = "https://api.telegram.org/bot%s/sendMessage"
 	}
+	if cfg.Notifications.Telegram.PollTimeout == 0 {
+		cfg.Notifications.Telegram.PollTimeout = 30 * time.Second
+	}
+	if cfg.Notifications.Webhook.Timeout == 0 {
+		cfg.Notifications.Webhook.Timeout = 10 * time.Second
+	}
+	if cfg.Notifications.Webhook.MaxAttempts == 0 {
+		cfg.Notifications.Webhook.MaxAttempts = 3
+	}
+	if cfg.Notifications.Webhook.InitialBackoff == 0 {
+		cfg.Notifications.Webhook.InitialBackoff = 500 * time.Millisecond
+	}
 	if cfg.Notifications.Retry.MaxAttempts == 0 {
 		cfg.Notifications.Retry.MaxAttempts = 3
 	}
@@ -311,6 +525,88 @@ func setDefaults(cfg *Config) {
 	if cfg.Notifications.Worker.PollInterval == 0 {
 		cfg.Notifications.Worker.PollInterval = 5 * time.Second
 	}
+	if cfg.Notifications.Worker.ShutdownTimeout == 0 {
+		cfg.Notifications.Worker.ShutdownTimeout = 5 * time.Second
+	}
+	if cfg.Notifications.Reminder.Window == 0 {
+		cfg.Notifications.Reminder.Window = time.Hour
+	}
+	if cfg.Notifications.Reminder.PollInterval == 0 {
+		cfg.Notifications.Reminder.PollInterval = 5 * time.Minute
+	}
+	if cfg.Notifications.SLA.PollInterval == 0 {
+		cfg.Notifications.SLA.PollInterval = 15 * time.Minute
+	}
+
+	// Incoming webhooks defaults
+	if cfg.Webhooks.OpsGenie.TagPrefix == "" {
+		cfg.Webhooks.OpsGenie.TagPrefix = "ig_service:"
+	}
+	if cfg.Webhooks.Prometheus.ServiceLabel == "" {
+		cfg.Webhooks.Prometheus.ServiceLabel = "service_slug"
+	}
+	if cfg.Webhooks.Prometheus.SeverityLabel == "" {
+		cfg.Webhooks.Prometheus.SeverityLabel = "severity"
+	}
+	if len(cfg.Webhooks.Prometheus.SeverityMap) == 0 {
+		cfg.Webhooks.Prometheus.SeverityMap = map[string]string{
+			"critical": "major_outage",
+			"warning":  "degraded",
+		}
+	}
+	if cfg.Webhooks.Prometheus.DefaultStatus == "" {
+		cfg.Webhooks.Prometheus.DefaultStatus = "degraded"
+	}
+
+	// Severity escalation defaults
+	if cfg.Escalation.MinorAfter == 0 {
+		cfg.Escalation.MinorAfter = 30 * time.Minute
+	}
+	if cfg.Escalation.MajorAfter == 0 {
+		cfg.Escalation.MajorAfter = 15 * time.Minute
+	}
+	if cfg.Escalation.PollInterval == 0 {
+		cfg.Escalation.PollInterval = 5 * time.Minute
+	}
+
+	// Recurring maintenance defaults
+	if cfg.Recurrence.LeadTime == 0 {
+		cfg.Recurrence.LeadTime = 24 * time.Hour
+	}
+	if cfg.Recurrence.PollInterval == 0 {
+		cfg.Recurrence.PollInterval = 5 * time.Minute
+	}
+
+	// Service health check defaults
+	if cfg.HealthCheck.Timeout == 0 {
+		cfg.HealthCheck.Timeout = 5 * time.Second
+	}
+	if cfg.HealthCheck.PollInterval == 0 {
+		cfg.HealthCheck.PollInterval = 5 * time.Second
+	}
+
+	// Idempotency-Key defaults
+	if cfg.Idempotency.KeyTTL == 0 {
+		cfg.Idempotency.KeyTTL = 24 * time.Hour
+	}
+	if cfg.Idempotency.EvictInterval == 0 {
+		cfg.Idempotency.EvictInterval = time.Hour
+	}
+
+	// Rate limit defaults
+	if cfg.RateLimit.AdminPerMinute == 0 {
+		cfg.RateLimit.AdminPerMinute = 600
+	}
+	if cfg.RateLimit.OperatorPerMinute == 0 {
+		cfg.RateLimit.OperatorPerMinute = 120
+	}
+	if cfg.RateLimit.UserPerMinute == 0 {
+		cfg.RateLimit.UserPerMinute = 60
+	}
+
+	if cfg.Tracing.ServiceName == "" {
+		cfg.Tracing.ServiceName = "incident-garden"
+	}
 }
 
 func validate(cfg *Config) error {
@@ -333,14 +629,46 @@ func validate(cfg *Config) error {
 		}
 	}
 
+	if cfg.OIDC.Issuer != "" {
+		if cfg.OIDC.ClientID == "" {
+			return fmt.Errorf("OIDC_CLIENT_ID is required when OIDC_ISSUER is set")
+		}
+		if cfg.OIDC.RedirectURL == "" {
+			return fmt.Errorf("OIDC_REDIRECT_URL is required when OIDC_ISSUER is set")
+		}
+	}
+
+	for severity, status := range cfg.Webhooks.Prometheus.SeverityMap {
+		if !isAlertStatus(status) {
+			return fmt.Errorf("WEBHOOKS_PROMETHEUS_SEVERITY_MAP: invalid status %q for severity %q", status, severity)
+		}
+	}
+	if !isAlertStatus(cfg.Webhooks.Prometheus.DefaultStatus) {
+		return fmt.Errorf("WEBHOOKS_PROMETHEUS_DEFAULT_STATUS: invalid status %q", cfg.Webhooks.Prometheus.DefaultStatus)
+	}
+
+	if cfg.Admin.TrustedProxies < 0 {
+		return fmt.Errorf("ADMIN_TRUSTED_PROXIES must not be negative")
+	}
+
 	return nil
 }
 
-func parseOrigins(origins string) []string {
-	if origins == "" {
+// isAlertStatus reports whether a firing alert may set this service status.
+func isAlertStatus(status string) bool {
+	switch status {
+	case "degraded", "partial_outage", "major_outage", "maintenance":
+		return true
+	}
+	return false
+}
+
+// parseList parses a comma-separated list, skipping empty items.
+func parseList(raw string) []string {
+	if raw == "" {
 		return nil
 	}
-	parts := strings.Split(origins, ",")
+	parts := strings.Split(raw, ",")
 	result := make([]string, 0, len(parts))
 	for _, p := range parts {
 		trimmed := strings.TrimSpace(p)
@@ -350,3 +678,30 @@ func parseOrigins(origins string) []string {
 	}
 	return result
 }
+
+// parseKeyValues parses "key:value,key:value" pairs. Pairs without a colon are skipped.
+func parseKeyValues(raw string) map[string]string {
+	if raw == "" {
+		return nil
+	}
+	result := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(pair, ":")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" || value == "" {
+			continue
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// firstNonEmpty returns the first non-empty value, e.g. of an env var and its alias.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
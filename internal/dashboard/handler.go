@@ -0,0 +1,141 @@
+// Package dashboard provides the operator dashboard summary.
+package dashboard
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/sync/errgroup"
+)
+
+// RecentEventsLimit is the number of most recent events in the summary.
+const RecentEventsLimit = 5
+
+// QueryTimeout bounds each sub-query of the summary.
+const QueryTimeout = 5 * time.Second
+
+// EventReader counts and lists events (implemented by events.Service).
+type EventReader interface {
+	CountEvents(ctx context.Context, filters events.EventFilters) (int, error)
+	ListEvents(ctx context.Context, filters events.EventFilters) ([]*domain.Event, error)
+}
+
+// ServiceLister lists services with their effective statuses (implemented by catalog.Service).
+type ServiceLister interface {
+	ListServicesWithEffectiveStatus(ctx context.Context, filter catalog.ServiceFilter) ([]domain.ServiceWithEffectiveStatus, error)
+}
+
+// ChannelLister lists the notification channels of a user (implemented by notifications.Service).
+type ChannelLister interface {
+	ListUserChannels(ctx context.Context, userID string) ([]domain.NotificationChannel, error)
+}
+
+// Summary is the response of GET /dashboard.
+type Summary struct {
+	ActiveIncidentsCount        int             `json:"active_incidents_count"`
+	ActiveMaintenancesCount     int             `json:"active_maintenances_count"`
+	ServicesNotOperationalCount int             `json:"services_not_operational_count"`
+	RecentEvents                []*domain.Event `json:"recent_events"`
+	UnverifiedChannelsCount     int             `json:"unverified_channels_count"` // of the calling user
+}
+
+// Handler serves the operator dashboard.
+type Handler struct {
+	events   EventReader
+	services ServiceLister
+	channels ChannelLister
+}
+
+// NewHandler creates a new dashboard handler.
+func NewHandler(eventReader EventReader, services ServiceLister, channels ChannelLister) *Handler {
+	return &Handler{
+		events:   eventReader,
+		services: services,
+		channels: channels,
+	}
+}
+
+// RegisterOperatorRoutes registers dashboard routes (operator+).
+func (h *Handler) RegisterOperatorRoutes(r chi.Router) {
+	r.Get("/dashboard", h.GetDashboard)
+}
+
+// GetDashboard handles GET /dashboard.
+func (h *Handler) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	summary, err := h.summary(r.Context(), httputil.GetUserID(r.Context()))
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, nil)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, summary)
+}
+
+// summary runs the sub-queries concurrently, each with its own QueryTimeout;
+// the first error cancels the others.
+func (h *Handler) summary(ctx context.Context, userID string) (*Summary, error) {
+	var summary Summary
+	g, ctx := errgroup.WithContext(ctx)
+
+	query := func(fn func(ctx context.Context) error) {
+		g.Go(func() error {
+			ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+			defer cancel()
+			return fn(ctx)
+		})
+	}
+
+	incident := domain.EventTypeIncident
+	query(func(ctx context.Context) (err error) {
+		summary.ActiveIncidentsCount, err = h.events.CountEvents(ctx, events.EventFilters{Type: &incident, Active: true})
+		return err
+	})
+
+	maintenance := domain.EventTypeMaintenance
+	query(func(ctx context.Context) (err error) {
+		summary.ActiveMaintenancesCount, err = h.events.CountEvents(ctx, events.EventFilters{Type: &maintenance, Active: true})
+		return err
+	})
+
+	query(func(ctx context.Context) error {
+		services, err := h.services.ListServicesWithEffectiveStatus(ctx, catalog.ServiceFilter{})
+		if err != nil {
+			return err
+		}
+		for _, s := range services {
+			if s.EffectiveStatus != domain.ServiceStatusOperational {
+				summary.ServicesNotOperationalCount++
+			}
+		}
+		return nil
+	})
+
+	query(func(ctx context.Context) (err error) {
+		summary.RecentEvents, err = h.events.ListEvents(ctx, events.EventFilters{Limit: RecentEventsLimit})
+		return err
+	})
+
+	query(func(ctx context.Context) error {
+		channels, err := h.channels.ListUserChannels(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, c := range channels {
+			if !c.IsVerified {
+				summary.UnverifiedChannelsCount++
+			}
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
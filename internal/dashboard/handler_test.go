@@ -0,0 +1,126 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubEvents struct {
+	counts map[domain.EventType]int
+	recent []*domain.Event
+	err    error
+}
+
+func (s *stubEvents) CountEvents(_ context.Context, filters events.EventFilters) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	if !filters.Active {
+		return 0, errors.New("counts must be limited to active events")
+	}
+	return s.counts[*filters.Type], nil
+}
+
+func (s *stubEvents) ListEvents(_ context.Context, filters events.EventFilters) ([]*domain.Event, error) {
+	return s.recent[:min(filters.Limit, len(s.recent))], nil
+}
+
+type stubServices struct {
+	services []domain.ServiceWithEffectiveStatus
+}
+
+func (s *stubServices) ListServicesWithEffectiveStatus(ctx context.Context, _ catalog.ServiceFilter) ([]domain.ServiceWithEffectiveStatus, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		return nil, errors.New("sub-query without deadline")
+	}
+	return s.services, nil
+}
+
+type stubChannels struct {
+	userID   string
+	channels []domain.NotificationChannel
+}
+
+func (s *stubChannels) ListUserChannels(_ context.Context, userID string) ([]domain.NotificationChannel, error) {
+	s.userID = userID
+	return s.channels, nil
+}
+
+func serviceWithStatus(status domain.ServiceStatus) domain.ServiceWithEffectiveStatus {
+	return domain.ServiceWithEffectiveStatus{EffectiveStatus: status}
+}
+
+func getDashboard(h *Handler, userID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/dashboard", nil)
+	req = req.WithContext(context.WithValue(req.Context(), httputil.UserIDKey, userID))
+	rec := httptest.NewRecorder()
+	h.GetDashboard(rec, req)
+	return rec
+}
+
+func TestHandler_Summary(t *testing.T) {
+	recent := make([]*domain.Event, 7)
+	for i := range recent {
+		recent[i] = &domain.Event{ID: string(rune('a' + i))}
+	}
+	eventReader := &stubEvents{
+		counts: map[domain.EventType]int{domain.EventTypeIncident: 3, domain.EventTypeMaintenance: 1},
+		recent: recent,
+	}
+	services := &stubServices{services: []domain.ServiceWithEffectiveStatus{
+		serviceWithStatus(domain.ServiceStatusOperational),
+		serviceWithStatus(domain.ServiceStatusDegraded),
+		serviceWithStatus(domain.ServiceStatusMaintenance),
+	}}
+	channels := &stubChannels{channels: []domain.NotificationChannel{
+		{ID: "c1", IsVerified: true},
+		{ID: "c2"},
+	}}
+	h := NewHandler(eventReader, services, channels)
+
+	summary, err := h.summary(context.Background(), "user-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, summary.ActiveIncidentsCount)
+	assert.Equal(t, 1, summary.ActiveMaintenancesCount)
+	assert.Equal(t, 2, summary.ServicesNotOperationalCount)
+	assert.Equal(t, recent[:RecentEventsLimit], summary.RecentEvents)
+	assert.Equal(t, 1, summary.UnverifiedChannelsCount)
+	assert.Equal(t, "user-1", channels.userID)
+}
+
+func TestHandler_GetDashboard_Error(t *testing.T) {
+	h := NewHandler(&stubEvents{err: errors.New("db down")}, &stubServices{}, &stubChannels{})
+
+	rec := getDashboard(h, "user-1")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+// timingOutServices times out after checking that its sub-query has the QueryTimeout deadline.
+type timingOutServices struct{}
+
+func (timingOutServices) ListServicesWithEffectiveStatus(ctx context.Context, _ catalog.ServiceFilter) ([]domain.ServiceWithEffectiveStatus, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > QueryTimeout {
+		return nil, errors.New("sub-query without QueryTimeout deadline")
+	}
+	return nil, context.DeadlineExceeded
+}
+
+func TestHandler_Summary_SubQueryDeadline(t *testing.T) {
+	h := NewHandler(&stubEvents{}, timingOutServices{}, &stubChannels{})
+
+	_, err := h.summary(context.Background(), "user-1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
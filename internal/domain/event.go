@@ -43,28 +43,61 @@ type Event struct {
 	Status            EventStatus  `json:"status"`
 	Severity          *Severity    `json:"severity"`
 	Description       string       `json:"description"`
+	Impact            string       `json:"impact"`      // customer-facing impact summary, max 500 characters
+	OnCallTeam        string       `json:"oncall_team"` // on-call team the event is escalated to, max 100 characters
 	StartedAt         *time.Time   `json:"started_at"`
 	ResolvedAt        *time.Time   `json:"resolved_at"`
 	ScheduledStartAt  *time.Time   `json:"scheduled_start_at"`
 	ScheduledEndAt    *time.Time   `json:"scheduled_end_at"`
 	NotifySubscribers bool         `json:"notify_subscribers"`
 	TemplateID        *string      `json:"template_id"`
+	RecurrenceRule    string       `json:"recurrence_rule,omitempty"`     // maintenance only, see events.ParseRecurrenceRule
+	RecurrenceEndDate *time.Time   `json:"recurrence_end_date,omitempty"` // no occurrences start after it
+	ParentEventID     *string      `json:"parent_event_id,omitempty"`     // recurring maintenance this occurrence belongs to
 	CreatedBy         string       `json:"created_by"`
+	CreatedByName     string       `json:"created_by_name,omitempty"` // resolved on reads, not stored
 	CreatedAt         time.Time    `json:"created_at"`
 	UpdatedAt         time.Time    `json:"updated_at"`
 	ServiceIDs        []string     `json:"service_ids"`
 	GroupIDs          []string     `json:"group_ids"`
+	DurationSeconds   *int64       `json:"duration_seconds"` // computed, not stored
+	HasPostMortem     *bool        `json:"has_post_mortem,omitempty"` // published post-mortem exists, set by event lists
 }
 
 // EventUpdate represents a status update for an event.
 type EventUpdate struct {
-	ID                string      `json:"id"`
-	EventID           string      `json:"event_id"`
-	Status            EventStatus `json:"status"`
-	Message           string      `json:"message"`
-	NotifySubscribers bool        `json:"notify_subscribers"`
-	CreatedBy         string      `json:"created_by"`
-	CreatedAt         time.Time   `json:"created_at"`
+	ID                string                 `json:"id"`
+	EventID           string                 `json:"event_id"`
+	Status            EventStatus            `json:"status"`
+	Message           string                 `json:"message"`
+	NotifySubscribers bool                   `json:"notify_subscribers"`
+	Changes           map[string]FieldChange `json:"changes,omitempty"` // event fields changed by the update
+	CreatedBy         string                 `json:"created_by"`
+	CreatedByName     string                 `json:"created_by_name,omitempty"` // resolved on reads, not stored
+	CreatedAt         time.Time              `json:"created_at"`
+}
+
+// EventPostmortem is the post-mortem document of a resolved event.
+// It is public once PublishedAt is set and not in the future.
+type EventPostmortem struct {
+	EventID     string     `json:"event_id"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	PublishedAt *time.Time `json:"published_at"`
+	CreatedBy   string     `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// IsPublished reports whether the post-mortem is published at the given time.
+func (p *EventPostmortem) IsPublished(now time.Time) bool {
+	return p.PublishedAt != nil && !p.PublishedAt.After(now)
+}
+
+// FieldChange holds the previous and new value of an event field.
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
 }
 
 // IsValidForType checks if the status is valid for the given event type.
@@ -93,6 +126,28 @@ func (s Severity) IsValid() bool {
 	return s == SeverityMinor || s == SeverityMajor || s == SeverityCritical
 }
 
+// Next returns the severity one level higher. Critical has no next level.
+func (s Severity) Next() (Severity, bool) {
+	switch s {
+	case SeverityMinor:
+		return SeverityMajor, true
+	case SeverityMajor:
+		return SeverityCritical, true
+	}
+	return "", false
+}
+
+// AtLeast reports whether s is as severe as threshold or more.
+func (s Severity) AtLeast(threshold Severity) bool {
+	return severityRank[s] >= severityRank[threshold]
+}
+
+var severityRank = map[Severity]int{
+	SeverityMinor:    1,
+	SeverityMajor:    2,
+	SeverityCritical: 3,
+}
+
 // IsResolved checks if the status represents a resolved/completed state.
 // Note: 'scheduled' is NOT considered resolved, but it's also NOT active
 // for the purpose of affecting service effective_status.
@@ -10,6 +10,8 @@ const (
 	ChannelTypeEmail      ChannelType = "email"
 	ChannelTypeTelegram   ChannelType = "telegram"
 	ChannelTypeMattermost ChannelType = "mattermost"
+	ChannelTypeSlack      ChannelType = "slack"
+	ChannelTypeWebhook    ChannelType = "webhook"
 )
 
 // NotificationChannel represents a user's notification channel.
@@ -22,6 +24,8 @@ type NotificationChannel struct {
 	IsVerified             bool        `json:"is_verified"`
 	IsDefault              bool        `json:"is_default"`
 	SubscribeToAllServices bool        `json:"subscribe_to_all_services"`
+	MinSeverity            *Severity   `json:"min_severity"` // incidents below it are not notified, nil = all
+	Secret                 string      `json:"-"`            // HMAC signing secret (webhook only), never exposed
 	CreatedAt              time.Time   `json:"created_at"`
 	UpdatedAt              time.Time   `json:"updated_at"`
 }
@@ -27,16 +27,26 @@ func (s ServiceStatus) IsValid() bool {
 
 // Service represents a monitored service.
 type Service struct {
-	ID          string        `json:"id"`
-	Name        string        `json:"name"`
-	Slug        string        `json:"slug"`
-	Description string        `json:"description"`
-	Status      ServiceStatus `json:"status"`
-	GroupIDs    []string      `json:"group_ids"`
-	Order       int           `json:"order"`
-	CreatedAt   time.Time     `json:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at"`
-	ArchivedAt  *time.Time    `json:"archived_at,omitempty"`
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Slug             string            `json:"slug"`
+	Description      string            `json:"description"`
+	Status           ServiceStatus     `json:"status"`
+	GroupIDs         []string          `json:"group_ids"`
+	Order            int               `json:"order"`
+	SLAUptimeTarget  *float64          `json:"sla_uptime_target,omitempty"` // monthly uptime target in percent; nil = no SLA
+	ExternalURL      string            `json:"external_url"`                // e.g. dashboard; "" = none (NULL in DB)
+	DocumentationURL string            `json:"documentation_url"`           // e.g. runbook; "" = none (NULL in DB)
+	CustomFields     map[string]string `json:"custom_fields"`               // free-form metadata, e.g. team
+	IconURL          string            `json:"icon_url"`                    // logo shown on status pages; "" = none (NULL in DB)
+	// HealthCheckURL is polled by the health checker every HealthCheckIntervalSeconds;
+	// "" = none (NULL in DB), interval 0 = disabled. It may be internal, so it is
+	// not serialized; admin responses add it explicitly.
+	HealthCheckURL             string     `json:"-"`
+	HealthCheckIntervalSeconds int        `json:"health_check_interval_seconds"`
+	CreatedAt                  time.Time  `json:"created_at"`
+	UpdatedAt                  time.Time  `json:"updated_at"`
+	ArchivedAt                 *time.Time `json:"archived_at,omitempty"`
 }
 
 // IsArchived returns true if the service is archived.
@@ -55,6 +65,11 @@ type ServiceGroup struct {
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+
+	// ParentGroupID is set for sub-groups; groups nest one level deep.
+	ParentGroupID *string `json:"parent_group_id"`
+	// Children are the sub-groups of a top-level group, filled by group reads.
+	Children []ServiceGroup `json:"children"`
 }
 
 // IsArchived returns true if the group is archived.
@@ -69,6 +84,14 @@ type ServiceWithEffectiveStatus struct {
 	HasActiveEvents bool          `json:"has_active_events"`
 }
 
+// GroupWithEffectiveStatus extends ServiceGroup with the worst-case effective status
+// of its non-archived services (operational for an empty group).
+type GroupWithEffectiveStatus struct {
+	ServiceGroup
+	EffectiveStatus ServiceStatus `json:"effective_status"`
+	HasActiveEvents bool          `json:"has_active_events"`
+}
+
 // ServiceTag represents a key-value tag attached to a service.
 type ServiceTag struct {
 	ID        string `json:"id"`
@@ -77,14 +100,39 @@ type ServiceTag struct {
 	Value     string `json:"value"`
 }
 
+// DependencyType represents how strongly a service depends on another one.
+type DependencyType string
+
+// Dependency types.
+const (
+	DependencyTypeHard DependencyType = "hard" // the service can't work without the dependency
+	DependencyTypeSoft DependencyType = "soft" // the service works with reduced functionality
+)
+
+// IsValid checks if the dependency type is valid.
+func (t DependencyType) IsValid() bool {
+	return t == DependencyTypeHard || t == DependencyTypeSoft
+}
+
+// ServiceDependency is a dependency link seen from one of its services:
+// the other service of the link and the dependency type.
+type ServiceDependency struct {
+	ServiceID string         `json:"service_id"`
+	Name      string         `json:"name"`
+	Slug      string         `json:"slug"`
+	Type      DependencyType `json:"type"`
+}
+
 // StatusLogSourceType represents the source of a status change.
 type StatusLogSourceType string
 
 // Status log source types.
 const (
-	StatusLogSourceManual  StatusLogSourceType = "manual"
-	StatusLogSourceEvent   StatusLogSourceType = "event"
-	StatusLogSourceWebhook StatusLogSourceType = "webhook"
+	StatusLogSourceManual      StatusLogSourceType = "manual"
+	StatusLogSourceEvent       StatusLogSourceType = "event"
+	StatusLogSourceWebhook     StatusLogSourceType = "webhook"
+	StatusLogSourceDependency  StatusLogSourceType = "dependency"   // incident opened by an outage of a dependency
+	StatusLogSourceHealthCheck StatusLogSourceType = "health_check" // incident opened by failed health checks
 )
 
 // ServiceStatusLogEntry represents a single status change in the audit log.
@@ -99,3 +147,24 @@ type ServiceStatusLogEntry struct {
 	CreatedBy  string              `json:"created_by"`
 	CreatedAt  time.Time           `json:"created_at"`
 }
+
+// MembershipAction is a change of the groups of a service.
+type MembershipAction string
+
+// Membership actions.
+const (
+	MembershipActionAdded   MembershipAction = "added"
+	MembershipActionRemoved MembershipAction = "removed"
+)
+
+// MembershipLogEntry records a service added to or removed from a group.
+type MembershipLogEntry struct {
+	ID          string           `json:"id"`
+	ServiceID   string           `json:"service_id"`
+	GroupID     string           `json:"group_id"`
+	GroupSlug   string           `json:"group_slug"` // set when listed
+	GroupName   string           `json:"group_name"` // set when listed
+	Action      MembershipAction `json:"action"`
+	ActorUserID *string          `json:"actor_user_id,omitempty"` // nil if unknown or the user was deleted
+	CreatedAt   time.Time        `json:"created_at"`
+}
@@ -0,0 +1,10 @@
+package domain
+
+// StatusPageSettings holds the branding of the public status page.
+// Empty fields are not configured; the frontend uses its own defaults.
+type StatusPageSettings struct {
+	StatusPageTitle string `json:"status_page_title"`
+	LogoURL         string `json:"logo_url"`
+	FaviconURL      string `json:"favicon_url"`
+	CustomCSS       string `json:"custom_css"`
+}
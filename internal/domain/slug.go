@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// MaxSlugLength is the maximum length of a service or group slug.
+const MaxSlugLength = 255
+
+// slugSuffixBytes is the number of random bytes in a generated slug suffix (4 hex chars).
+const slugSuffixBytes = 2
+
+// GenerateSlug builds a slug from name with a random 4-hex suffix, so that
+// services and groups with the same name get distinct slugs.
+func GenerateSlug(name string) string {
+	b := make([]byte, slugSuffixBytes)
+	if _, err := rand.Read(b); err != nil {
+		// Fallback to the clock, collisions are still rejected on insert
+		nanos := time.Now().UnixNano()
+		b = []byte{byte(nanos >> 8), byte(nanos)}
+	}
+	return SlugWithSuffix(name, hex.EncodeToString(b))
+}
+
+// SlugWithSuffix lowercases name, replaces every run of characters other than
+// ASCII letters and digits with a hyphen and appends suffix. The name part is
+// truncated to keep the slug within MaxSlugLength. A name without ASCII letters
+// or digits gives just the suffix.
+func SlugWithSuffix(name, suffix string) string {
+	maxBase := MaxSlugLength - len(suffix) - 1
+
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(name) {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			pendingHyphen = b.Len() > 0
+			continue
+		}
+		if pendingHyphen {
+			if b.Len()+2 > maxBase {
+				break
+			}
+			b.WriteByte('-')
+			pendingHyphen = false
+		}
+		if b.Len()+1 > maxBase {
+			break
+		}
+		b.WriteRune(r)
+	}
+
+	if b.Len() == 0 {
+		return suffix
+	}
+	return b.String() + "-" + suffix
+}
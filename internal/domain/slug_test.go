@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+func TestSlugWithSuffix(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"API Gateway", "api-gateway-a1b2"},
+		{"  Payments  ", "payments-a1b2"},
+		{"Auth / SSO (beta)", "auth-sso-beta-a1b2"},
+		{"web_frontend.v2", "web-frontend-v2-a1b2"},
+		{"Café Münster", "caf-m-nster-a1b2"},
+		{"Платежи", "a1b2"},
+		{"日本 API", "api-a1b2"},
+		{"", "a1b2"},
+		{"--", "a1b2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SlugWithSuffix(tt.name, "a1b2")
+			if got != tt.want {
+				t.Errorf("SlugWithSuffix(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+			if again := SlugWithSuffix(tt.name, "a1b2"); again != got {
+				t.Errorf("SlugWithSuffix(%q) not stable: %q then %q", tt.name, got, again)
+			}
+		})
+	}
+}
+
+func TestSlugWithSuffix_LongName(t *testing.T) {
+	for _, name := range []string{
+		strings.Repeat("a", 1000),
+		strings.Repeat("ab ", 400),
+		strings.Repeat("é", 300) + strings.Repeat("x", 300),
+	} {
+		got := SlugWithSuffix(name, "a1b2")
+		if len(got) > MaxSlugLength {
+			t.Errorf("slug length %d exceeds %d", len(got), MaxSlugLength)
+		}
+		if !slugPattern.MatchString(got) {
+			t.Errorf("slug %q does not match the slug format", got)
+		}
+		if !strings.HasSuffix(got, "-a1b2") {
+			t.Errorf("slug %q lost its suffix", got)
+		}
+	}
+}
+
+func TestGenerateSlug(t *testing.T) {
+	first := GenerateSlug("API Gateway")
+	if !regexp.MustCompile(`^api-gateway-[0-9a-f]{4}$`).MatchString(first) {
+		t.Errorf("GenerateSlug() = %q, want api-gateway-<4 hex>", first)
+	}
+	if !slugPattern.MatchString(GenerateSlug("Платежи")) {
+		t.Error("slug of a non-ASCII name does not match the slug format")
+	}
+}
@@ -21,4 +21,5 @@ type TemplateData struct {
 	ResolvedAt       *time.Time
 	ScheduledStart   *time.Time
 	ScheduledEnd     *time.Time
+	Variables        map[string]string // free-form values referenced as {{.Variables.key}}
 }
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidTransition is returned when an event cannot move between two statuses.
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// StatusMachine defines allowed status transitions per event type.
+// Keeping the current status is allowed for every active status.
+type StatusMachine map[EventType]map[EventStatus][]EventStatus
+
+// EventStatusMachine is the transition table enforced for event updates.
+// Resolved and completed are terminal. Scheduled maintenance may be completed
+// directly (cancelled), but in-progress maintenance cannot be rescheduled.
+var EventStatusMachine = StatusMachine{
+	EventTypeIncident: {
+		EventStatusInvestigating: {EventStatusInvestigating, EventStatusIdentified, EventStatusMonitoring, EventStatusResolved},
+		EventStatusIdentified:    {EventStatusInvestigating, EventStatusIdentified, EventStatusMonitoring, EventStatusResolved},
+		EventStatusMonitoring:    {EventStatusInvestigating, EventStatusIdentified, EventStatusMonitoring, EventStatusResolved},
+		EventStatusResolved:      {},
+	},
+	EventTypeMaintenance: {
+		EventStatusScheduled:  {EventStatusScheduled, EventStatusInProgress, EventStatusCompleted},
+		EventStatusInProgress: {EventStatusInProgress, EventStatusCompleted},
+		EventStatusCompleted:  {},
+	},
+}
+
+// ValidateTransition checks the transition against the machine.
+func (m StatusMachine) ValidateTransition(eventType EventType, from, to EventStatus) error {
+	for _, allowed := range m[eventType][from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s cannot move from %s to %s", ErrInvalidTransition, eventType, from, to)
+}
+
+// ValidateTransition checks the transition against EventStatusMachine.
+func ValidateTransition(eventType EventType, from, to EventStatus) error {
+	return EventStatusMachine.ValidateTransition(eventType, from, to)
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+var allEventStatuses = []EventStatus{
+	EventStatusInvestigating, EventStatusIdentified, EventStatusMonitoring, EventStatusResolved,
+	EventStatusScheduled, EventStatusInProgress, EventStatusCompleted,
+}
+
+// TestValidateTransition checks every from→to pair of every event type:
+// the listed transitions are allowed, all others are rejected.
+func TestValidateTransition(t *testing.T) {
+	type transition struct {
+		eventType EventType
+		from      EventStatus
+		to        EventStatus
+	}
+	allowed := map[transition]bool{
+		// Incidents
+		{EventTypeIncident, EventStatusInvestigating, EventStatusInvestigating}: true,
+		{EventTypeIncident, EventStatusInvestigating, EventStatusIdentified}:    true,
+		{EventTypeIncident, EventStatusInvestigating, EventStatusMonitoring}:    true,
+		{EventTypeIncident, EventStatusInvestigating, EventStatusResolved}:      true,
+		{EventTypeIncident, EventStatusIdentified, EventStatusInvestigating}:    true,
+		{EventTypeIncident, EventStatusIdentified, EventStatusIdentified}:       true,
+		{EventTypeIncident, EventStatusIdentified, EventStatusMonitoring}:       true,
+		{EventTypeIncident, EventStatusIdentified, EventStatusResolved}:         true,
+		{EventTypeIncident, EventStatusMonitoring, EventStatusInvestigating}:    true,
+		{EventTypeIncident, EventStatusMonitoring, EventStatusIdentified}:       true,
+		{EventTypeIncident, EventStatusMonitoring, EventStatusMonitoring}:       true,
+		{EventTypeIncident, EventStatusMonitoring, EventStatusResolved}:         true,
+
+		// Maintenance
+		{EventTypeMaintenance, EventStatusScheduled, EventStatusScheduled}:   true,
+		{EventTypeMaintenance, EventStatusScheduled, EventStatusInProgress}:  true,
+		{EventTypeMaintenance, EventStatusScheduled, EventStatusCompleted}:   true,
+		{EventTypeMaintenance, EventStatusInProgress, EventStatusInProgress}: true,
+		{EventTypeMaintenance, EventStatusInProgress, EventStatusCompleted}:  true,
+	}
+
+	// Resolved, completed, statuses of the other type and unknown types are rejected
+	for _, eventType := range []EventType{EventTypeIncident, EventTypeMaintenance, EventType("unknown")} {
+		for _, from := range allEventStatuses {
+			for _, to := range allEventStatuses {
+				tt := transition{eventType, from, to}
+				t.Run(string(eventType)+"/"+string(from)+"->"+string(to), func(t *testing.T) {
+					err := ValidateTransition(tt.eventType, tt.from, tt.to)
+					if allowed[tt] {
+						if err != nil {
+							t.Errorf("ValidateTransition() error = %v, want nil", err)
+						}
+						return
+					}
+					if !errors.Is(err, ErrInvalidTransition) {
+						t.Errorf("ValidateTransition() error = %v, want ErrInvalidTransition", err)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestEventStatusMachine_CoversAllStatuses(t *testing.T) {
+	for _, eventType := range []EventType{EventTypeIncident, EventTypeMaintenance} {
+		for _, status := range allEventStatuses {
+			_, defined := EventStatusMachine[eventType][status]
+			if defined != status.IsValidForType(eventType) {
+				t.Errorf("%s/%s: defined in machine = %v, valid for type = %v",
+					eventType, status, defined, status.IsValidForType(eventType))
+			}
+			for _, to := range EventStatusMachine[eventType][status] {
+				if !to.IsValidForType(eventType) {
+					t.Errorf("%s/%s: target %s is not valid for type", eventType, status, to)
+				}
+			}
+		}
+	}
+}
@@ -34,16 +34,19 @@ func (r Role) HasPermission(required Role) bool {
 
 // User represents a user account in the system.
 type User struct {
-	ID                 string    `json:"id"`
-	Email              string    `json:"email"`
-	PasswordHash       string    `json:"-"`
-	FirstName          string    `json:"first_name,omitempty"`
-	LastName           string    `json:"last_name,omitempty"`
-	Role               Role      `json:"role"`
-	IsActive           bool      `json:"is_active"`
-	MustChangePassword bool      `json:"must_change_password"`
-	CreatedAt          time.Time `json:"created_at"`
-	UpdatedAt          time.Time `json:"updated_at"`
+	ID                 string     `json:"id"`
+	Email              string     `json:"email"`
+	PasswordHash       string     `json:"-"`
+	Name               string     `json:"name"`
+	FirstName          string     `json:"first_name,omitempty"`
+	LastName           string     `json:"last_name,omitempty"`
+	Role               Role       `json:"role"`
+	IsActive           bool       `json:"is_active"`
+	MustChangePassword bool       `json:"must_change_password"`
+	LastLoginAt        *time.Time `json:"last_login_at"`
+	DeactivatedAt      *time.Time `json:"deactivated_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
 }
 
 // RefreshToken represents a refresh token stored in the database.
@@ -0,0 +1,82 @@
+// Package embed serves the embeddable status badge and stores its settings.
+package embed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// Badge positions on the embedding page.
+const (
+	PositionBottomRight = "bottom-right"
+	PositionBottomLeft  = "bottom-left"
+	PositionTopRight    = "top-right"
+	PositionTopLeft     = "top-left"
+)
+
+// ErrInvalidConfig is returned for widget settings that can't be stored.
+var ErrInvalidConfig = errors.New("invalid embed config")
+
+var colorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// EmbedConfig holds the settings of the status badge.
+type EmbedConfig struct {
+	Position         string     `json:"position"`
+	OperationalColor string     `json:"operational_color"`
+	IncidentColor    string     `json:"incident_color"`
+	MaintenanceColor string     `json:"maintenance_color"`
+	StatusPageURL    string     `json:"status_page_url"` // badge links here; empty: no link
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+// DefaultConfig returns the settings used until an admin changes them
+// (the defaults of migration 000039).
+func DefaultConfig() EmbedConfig {
+	return EmbedConfig{
+		Position:         PositionBottomRight,
+		OperationalColor: "#2e7d32",
+		IncidentColor:    "#c62828",
+		MaintenanceColor: "#1565c0",
+	}
+}
+
+// Validate checks the position, #RRGGBB colors and the status page URL.
+// The URL must be absolute http(s): it ends up as a link on foreign pages.
+func (c *EmbedConfig) Validate() error {
+	switch c.Position {
+	case PositionBottomRight, PositionBottomLeft, PositionTopRight, PositionTopLeft:
+	default:
+		return fmt.Errorf("%w: position must be bottom-right, bottom-left, top-right or top-left", ErrInvalidConfig)
+	}
+
+	for name, color := range map[string]string{
+		"operational_color": c.OperationalColor,
+		"incident_color":    c.IncidentColor,
+		"maintenance_color": c.MaintenanceColor,
+	} {
+		if !colorRegex.MatchString(color) {
+			return fmt.Errorf("%w: %s must be a #RRGGBB color", ErrInvalidConfig, name)
+		}
+	}
+
+	if c.StatusPageURL != "" {
+		u, err := url.Parse(c.StatusPageURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: status_page_url must be an absolute http(s) URL", ErrInvalidConfig)
+		}
+	}
+
+	return nil
+}
+
+// Store persists the widget settings.
+// This interface is implemented by postgres.Store.
+type Store interface {
+	GetConfig(ctx context.Context) (*EmbedConfig, error)
+	// SaveConfig replaces the settings and sets cfg.UpdatedAt.
+	SaveConfig(ctx context.Context, cfg *EmbedConfig, updatedBy string) error
+}
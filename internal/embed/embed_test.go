@@ -0,0 +1,55 @@
+package embed
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderWidget(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Position = PositionTopLeft
+	cfg.StatusPageURL = "https://status.example.com/?a=1&b=</script>"
+
+	body, err := RenderWidget(cfg)
+	require.NoError(t, err)
+
+	js := string(body)
+	assert.Less(t, len(body), MaxWidgetSize)
+	assert.Contains(t, js, `"position":"top-left"`)
+	assert.Contains(t, js, `"operational_color":"#2e7d32"`)
+	assert.Contains(t, js, "/api/v1/status")
+	assert.NotContains(t, js, "updated_at")
+	assert.Equal(t, 1, strings.Count(js, "</script>"), "only the usage comment; the URL is escaped")
+}
+
+func TestEmbedConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *EmbedConfig)
+		wantErr bool
+	}{
+		{name: "defaults", modify: func(*EmbedConfig) {}},
+		{name: "status page url", modify: func(c *EmbedConfig) { c.StatusPageURL = "https://status.example.com" }},
+		{name: "unknown position", modify: func(c *EmbedConfig) { c.Position = "center" }, wantErr: true},
+		{name: "short color", modify: func(c *EmbedConfig) { c.IncidentColor = "#fff" }, wantErr: true},
+		{name: "color name", modify: func(c *EmbedConfig) { c.MaintenanceColor = "blue" }, wantErr: true},
+		{name: "relative url", modify: func(c *EmbedConfig) { c.StatusPageURL = "/status" }, wantErr: true},
+		{name: "javascript url", modify: func(c *EmbedConfig) { c.StatusPageURL = "javascript:alert(1)" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidConfig)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
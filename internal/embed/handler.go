@@ -0,0 +1,92 @@
+package embed
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/bissquit/incident-garden/internal/pkg/ctxlog"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/go-chi/chi/v5"
+)
+
+// WidgetContentType is the Content-Type of the widget script.
+const WidgetContentType = "application/javascript; charset=utf-8"
+
+var errorMappings = []httputil.ErrorMapping{
+	{Error: ErrInvalidConfig, Status: http.StatusBadRequest, Message: ""},
+}
+
+// Handler serves the status badge widget and its settings.
+type Handler struct {
+	store Store
+}
+
+// NewHandler creates a new embed handler.
+func NewHandler(store Store) *Handler {
+	return &Handler{store: store}
+}
+
+// RegisterPublicRoutes registers the widget script route (no auth required, any origin).
+func (h *Handler) RegisterPublicRoutes(r chi.Router) {
+	r.With(httputil.PublicCORSMiddleware).Get("/embed/widget.js", h.GetWidget)
+}
+
+// RegisterAdminRoutes registers routes for widget settings (admin only).
+func (h *Handler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/embed/config", h.GetConfig)
+	r.Put("/embed/config", h.UpdateConfig)
+}
+
+// GetWidget handles GET /embed/widget.js.
+func (h *Handler) GetWidget(w http.ResponseWriter, r *http.Request) {
+	cfg, err := h.store.GetConfig(r.Context())
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	body, err := RenderWidget(*cfg)
+	if err != nil {
+		ctxlog.FromContext(r.Context()).Error("failed to render widget", "error", err)
+		httputil.Error(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.Header().Set("Content-Type", WidgetContentType)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// GetConfig handles GET /embed/config.
+func (h *Handler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	cfg, err := h.store.GetConfig(r.Context())
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, cfg)
+}
+
+// UpdateConfig handles PUT /embed/config.
+func (h *Handler) UpdateConfig(w http.ResponseWriter, r *http.Request) {
+	var cfg EmbedConfig
+	if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	cfg.UpdatedAt = nil
+
+	if err := cfg.Validate(); err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	if err := h.store.SaveConfig(r.Context(), &cfg, httputil.GetUserID(r.Context())); err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, cfg)
+}
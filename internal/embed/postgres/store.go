@@ -0,0 +1,58 @@
+// Package postgres provides PostgreSQL storage for the embed module.
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bissquit/incident-garden/internal/embed"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Store implements the embed.Store interface using PostgreSQL.
+type Store struct {
+	db *pgxpool.Pool
+}
+
+var _ embed.Store = (*Store)(nil)
+
+// NewStore creates a new PostgreSQL embed config store.
+func NewStore(db *pgxpool.Pool) *Store {
+	return &Store{db: db}
+}
+
+// GetConfig returns the widget settings.
+func (s *Store) GetConfig(ctx context.Context) (*embed.EmbedConfig, error) {
+	query := `
+		SELECT position, operational_color, incident_color, maintenance_color,
+		       status_page_url, updated_at
+		FROM embed_config
+	`
+	var cfg embed.EmbedConfig
+	err := s.db.QueryRow(ctx, query).Scan(
+		&cfg.Position, &cfg.OperationalColor, &cfg.IncidentColor, &cfg.MaintenanceColor,
+		&cfg.StatusPageURL, &cfg.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get embed config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// SaveConfig replaces the widget settings.
+func (s *Store) SaveConfig(ctx context.Context, cfg *embed.EmbedConfig, updatedBy string) error {
+	query := `
+		UPDATE embed_config
+		SET position = $1, operational_color = $2, incident_color = $3, maintenance_color = $4,
+		    status_page_url = $5, updated_by = $6, updated_at = NOW()
+		RETURNING updated_at
+	`
+	err := s.db.QueryRow(ctx, query,
+		cfg.Position, cfg.OperationalColor, cfg.IncidentColor, cfg.MaintenanceColor,
+		cfg.StatusPageURL, updatedBy,
+	).Scan(&cfg.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("save embed config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package embed
+
+import (
+	"bytes"
+	_ "embed" // widget.js.tmpl
+	"encoding/json"
+	"fmt"
+	"text/template"
+)
+
+// MaxWidgetSize is the size budget of the rendered widget script.
+const MaxWidgetSize = 200 * 1024
+
+//go:embed widget.js.tmpl
+var widgetSource string
+
+var widgetTemplate = template.Must(template.New("widget.js").Parse(widgetSource))
+
+// RenderWidget renders the badge script with cfg baked in.
+// cfg is inlined as JSON, which escapes <, > and & so it can't end the script early.
+func RenderWidget(cfg EmbedConfig) ([]byte, error) {
+	cfg.UpdatedAt = nil
+	configJSON, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := widgetTemplate.Execute(&buf, struct{ Config string }{string(configJSON)}); err != nil {
+		return nil, fmt.Errorf("render widget: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,95 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// MaxBulkEvents is the maximum number of events in a single bulk import.
+const MaxBulkEvents = 100
+
+// BulkResult is the outcome of one item of a bulk import.
+// Err is the validation error of the item; Event is set once the batch is stored.
+type BulkResult struct {
+	Index int
+	Event *domain.Event
+	Err   error
+}
+
+// CreateEventBulk imports events, typically historical ones from another status page.
+// Every item needs an explicit started_at, and resolved_at exactly when it is resolved
+// or completed. All items are validated first: if any is invalid, nothing is stored and
+// ErrBulkRejected is returned with the per-item errors. Otherwise all events are created
+// in one transaction.
+func (s *Service) CreateEventBulk(ctx context.Context, inputs []CreateEventInput, createdBy string) ([]BulkResult, error) {
+	if len(inputs) == 0 || len(inputs) > MaxBulkEvents {
+		return nil, ErrBulkSize
+	}
+
+	results := make([]BulkResult, len(inputs))
+	prepared := make([]*preparedEvent, len(inputs))
+	rejected := false
+	for i, input := range inputs {
+		results[i].Index = i
+		if err := validateImportTimes(input); err != nil {
+			results[i].Err = err
+			rejected = true
+			continue
+		}
+		p, err := s.prepareEvent(ctx, input, createdBy)
+		if err != nil {
+			results[i].Err = err
+			rejected = true
+			continue
+		}
+		prepared[i] = p
+	}
+	if rejected {
+		return results, ErrBulkRejected
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	for i, p := range prepared {
+		if err := s.createEventTx(ctx, tx, p, createdBy); err != nil {
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	for i, p := range prepared {
+		s.afterEventCreated(ctx, p, createdBy)
+		results[i].Event = p.event
+	}
+	return results, nil
+}
+
+// validateImportTimes checks the explicit timestamps an imported event must carry.
+func validateImportTimes(input CreateEventInput) error {
+	if input.StartedAt == nil {
+		return ErrStartedAtRequired
+	}
+	if (input.ResolvedAt != nil) != input.Status.IsResolved() {
+		return ErrResolvedAtMismatch
+	}
+	if input.ResolvedAt != nil && input.ResolvedAt.Before(*input.StartedAt) {
+		return ErrResolvedBeforeStarted
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// dependentOutage is a dependent service to open an incident for, with the
+// names of its dependencies in major outage.
+type dependentOutage struct {
+	service  domain.ServiceDependency
+	upstream []string
+}
+
+// majorOutages returns the services in major_outage, sorted.
+func majorOutages(statuses map[string]domain.ServiceStatus) []string {
+	ids := make([]string, 0)
+	for serviceID, status := range statuses {
+		if status == domain.ServiceStatusMajorOutage {
+			ids = append(ids, serviceID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// updatedMajorOutages returns the services an update put into major_outage:
+// those it changed or added that are now in major_outage within the event.
+func (s *Service) updatedMajorOutages(ctx context.Context, input CreateEventUpdateInput) []string {
+	if s.dependents == nil || !s.hasServiceChanges(input) {
+		return nil
+	}
+
+	touched := make(map[string]bool)
+	for _, su := range input.ServiceUpdates {
+		touched[su.ServiceID] = true
+	}
+	for _, as := range input.AddServices {
+		touched[as.ServiceID] = true
+	}
+	for _, ag := range input.AddGroups {
+		serviceIDs, err := s.resolver.GetGroupServices(ctx, ag.GroupID)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to resolve group for dependency cascade", "group_id", ag.GroupID, "error", err)
+			continue
+		}
+		for _, serviceID := range serviceIDs {
+			touched[serviceID] = true
+		}
+	}
+
+	services, err := s.repo.GetEventServices(ctx, input.EventID)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load event services for dependency cascade", "event_id", input.EventID, "error", err)
+		return nil
+	}
+	statuses := make(map[string]domain.ServiceStatus)
+	for _, es := range services {
+		if touched[es.ServiceID] {
+			statuses[es.ServiceID] = es.Status
+		}
+	}
+	return majorOutages(statuses)
+}
+
+// cascadeMajorOutage opens a minor incident for every service depending on one of
+// serviceIDs, unless an active event already covers it. Hard dependents are put in
+// partial_outage, soft ones in degraded; a dependent of several failing services gets
+// a single incident. The triggering change is already committed, so failures are logged.
+func (s *Service) cascadeMajorOutage(ctx context.Context, source *domain.Event, serviceIDs []string, createdBy string) {
+	if s.dependents == nil || len(serviceIDs) == 0 {
+		return
+	}
+
+	outages := make(map[string]*dependentOutage)
+	for _, serviceID := range serviceIDs {
+		dependents, err := s.dependents.ListDependents(ctx, serviceID)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to list dependent services", "service_id", serviceID, "error", err)
+			continue
+		}
+		if len(dependents) == 0 {
+			continue
+		}
+
+		name, err := s.catalogService.GetServiceName(ctx, serviceID)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to get service name for dependency cascade", "service_id", serviceID, "error", err)
+			name = serviceID
+		}
+
+		for _, dep := range dependents {
+			outage, ok := outages[dep.ServiceID]
+			if !ok {
+				outage = &dependentOutage{service: dep}
+				outages[dep.ServiceID] = outage
+			}
+			if dep.Type == domain.DependencyTypeHard {
+				outage.service.Type = domain.DependencyTypeHard
+			}
+			outage.upstream = append(outage.upstream, name)
+		}
+	}
+
+	ids := make([]string, 0, len(outages))
+	for id := range outages {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if err := s.openDependencyIncident(ctx, source, outages[id], createdBy); err != nil {
+			slog.ErrorContext(ctx, "failed to open dependency incident", "service_id", id, "source_event_id", source.ID, "error", err)
+		}
+	}
+}
+
+// openDependencyIncident creates the incident of a dependent service unless it has an active event.
+func (s *Service) openDependencyIncident(ctx context.Context, source *domain.Event, outage *dependentOutage, createdBy string) error {
+	active, err := s.repo.CountEventsByServiceID(ctx, outage.service.ServiceID, ServiceEventFilter{Status: "active"})
+	if err != nil {
+		return fmt.Errorf("count active events: %w", err)
+	}
+	if active > 0 {
+		return nil
+	}
+
+	status := domain.ServiceStatusDegraded
+	if outage.service.Type == domain.DependencyTypeHard {
+		status = domain.ServiceStatusPartialOutage
+	}
+	severity := domain.SeverityMinor
+	upstream := strings.Join(outage.upstream, ", ")
+
+	event, err := s.CreateEvent(ctx, CreateEventInput{
+		Title:             fmt.Sprintf("%s affected by %s outage", outage.service.Name, upstream),
+		Type:              domain.EventTypeIncident,
+		Status:            domain.EventStatusInvestigating,
+		Severity:          &severity,
+		Description:       fmt.Sprintf("Opened automatically: %s depends on %s, which is in major outage (%s).", outage.service.Name, upstream, source.Title),
+		NotifySubscribers: source.NotifySubscribers,
+		AffectedServices:  []domain.AffectedService{{ServiceID: outage.service.ServiceID, Status: status}},
+		StatusChange:      StatusChangeContext{SourceType: domain.StatusLogSourceDependency},
+	}, createdBy)
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "opened dependency incident",
+		"event_id", event.ID,
+		"service_id", outage.service.ServiceID,
+		"source_event_id", source.ID,
+	)
+	return nil
+}
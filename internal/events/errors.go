@@ -1,17 +1,51 @@
 // Package events provides event and template management.
 package events
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
 
 // Event errors.
 var (
 	ErrEventNotFound           = errors.New("event not found")
 	ErrTemplateNotFound        = errors.New("template not found")
+	ErrTemplateRender          = errors.New("template rendering failed")
+	ErrTemplateSlugExists      = errors.New("template slug already exists")
 	ErrInvalidStatus           = errors.New("invalid status for event type")
 	ErrInvalidSeverity         = errors.New("severity is required for incidents")
+	ErrSeverityNotSupported    = errors.New("severity is only supported for incidents")
 	ErrServiceNotInEvent       = errors.New("service is not associated with this event")
-	ErrEventAlreadyResolved    = errors.New("cannot update resolved event")
 	ErrEventNotResolved        = errors.New("cannot delete active event: resolve it first")
 	ErrAffectedServiceNotFound = errors.New("affected service not found")
 	ErrAffectedGroupNotFound   = errors.New("affected group not found")
+	ErrStartedAtInFuture       = errors.New("started_at cannot be in the future")
+	ErrPostmortemNotFound      = errors.New("post-mortem not found")
+	ErrPostmortemEventActive   = errors.New("post-mortem requires a resolved event")
+	ErrMaintenanceOverlap      = errors.New("overlapping maintenance window")
+	ErrStartedAtRequired       = errors.New("started_at is required for imported events")
+	ErrResolvedAtMismatch      = errors.New("resolved_at is required for resolved or completed events and not allowed otherwise")
+	ErrResolvedBeforeStarted   = errors.New("resolved_at cannot be before started_at")
+	ErrBulkSize                = errors.New("bulk import must contain between 1 and 100 events")
+	ErrBulkRejected            = errors.New("bulk import rejected: some events are invalid")
+	ErrInvalidRecurrenceRule   = errors.New("invalid recurrence rule")
+	ErrRecurrenceNotSupported  = errors.New("recurrence requires a maintenance with scheduled_start_at and scheduled_end_at")
+	ErrOccurrenceExists        = errors.New("occurrence of recurring maintenance already exists")
+	ErrEventNotReopenable      = errors.New("only resolved incidents can be reopened")
+	ErrReopenNotSupported      = errors.New("maintenance cannot be reopened")
 )
+
+// MaintenanceOverlapError lists the scheduled or in-progress maintenances whose
+// window overlaps a new one on the same services. It matches ErrMaintenanceOverlap.
+type MaintenanceOverlapError struct {
+	Conflicts []*domain.Event
+}
+
+func (e *MaintenanceOverlapError) Error() string {
+	return ErrMaintenanceOverlap.Error()
+}
+
+func (e *MaintenanceOverlapError) Unwrap() error {
+	return ErrMaintenanceOverlap
+}
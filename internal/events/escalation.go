@@ -0,0 +1,219 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// EscalationConfig contains automatic severity escalation settings.
+type EscalationConfig struct {
+	// Thresholds is how long an incident may stay investigating or identified
+	// at a severity before it is raised to the next one. Missing = never.
+	Thresholds   map[domain.Severity]time.Duration
+	PollInterval time.Duration
+}
+
+// DefaultEscalationConfig returns default escalation configuration.
+func DefaultEscalationConfig() EscalationConfig {
+	return EscalationConfig{
+		Thresholds: map[domain.Severity]time.Duration{
+			domain.SeverityMinor: 30 * time.Minute,
+			domain.SeverityMajor: 15 * time.Minute,
+		},
+		PollInterval: 5 * time.Minute,
+	}
+}
+
+// EscalationChecker periodically raises the severity of incidents that stay
+// investigating or identified longer than the threshold of their severity.
+// The time is counted from the last severity change, so minor → major → critical
+// takes both thresholds.
+type EscalationChecker struct {
+	config  EscalationConfig
+	repo    Repository
+	service *Service
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewEscalationChecker creates a new escalation checker.
+func NewEscalationChecker(config EscalationConfig, repo Repository, service *Service) *EscalationChecker {
+	return &EscalationChecker{
+		config:  config,
+		repo:    repo,
+		service: service,
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Start launches the checker goroutine.
+func (c *EscalationChecker) Start(ctx context.Context) {
+	slog.InfoContext(ctx, "starting severity escalation checker",
+		"thresholds", c.config.Thresholds,
+		"poll_interval", c.config.PollInterval,
+	)
+
+	c.wg.Add(1)
+	go c.run(ctx)
+}
+
+// Stop gracefully stops the checker.
+func (c *EscalationChecker) Stop() {
+	close(c.stopCh)
+	c.wg.Wait()
+	slog.Info("severity escalation checker stopped")
+}
+
+func (c *EscalationChecker) run(ctx context.Context) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.check(ctx)
+		}
+	}
+}
+
+// check escalates every candidate whose severity is older than its threshold.
+func (c *EscalationChecker) check(ctx context.Context) {
+	candidates, err := c.repo.ListEscalationCandidates(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list escalation candidates", "error", err)
+		return
+	}
+
+	now := time.Now()
+	for _, candidate := range candidates {
+		threshold, ok := c.config.Thresholds[candidate.Severity]
+		if !ok || threshold <= 0 || now.Sub(candidate.Since) < threshold {
+			continue
+		}
+		if err := c.escalate(ctx, candidate, threshold); err != nil {
+			slog.ErrorContext(ctx, "failed to escalate severity", "event_id", candidate.EventID, "error", err)
+		}
+	}
+}
+
+func (c *EscalationChecker) escalate(ctx context.Context, candidate EscalationCandidate, threshold time.Duration) error {
+	next, ok := candidate.Severity.Next()
+	if !ok {
+		return nil
+	}
+
+	message := fmt.Sprintf("Severity automatically escalated from %s to %s: unresolved for %s at %s severity",
+		candidate.Severity, next, threshold, candidate.Severity)
+	escalated, err := c.service.EscalateSeverity(ctx, candidate.EventID, candidate.Severity, message)
+	if err != nil {
+		return err
+	}
+	if escalated {
+		slog.InfoContext(ctx, "severity escalated", "event_id", candidate.EventID, "from", candidate.Severity, "to", next)
+	}
+	return nil
+}
+
+// EscalateSeverity raises the severity of an investigating or identified incident from
+// one level to the next and records a system update with the message, notifying
+// subscribers if the event does. Returns false without changes if the incident moved
+// on meanwhile (status or severity changed, or another instance escalated it first).
+func (s *Service) EscalateSeverity(ctx context.Context, eventID string, from domain.Severity, message string) (bool, error) {
+	to, ok := from.Next()
+	if !ok {
+		return false, nil
+	}
+
+	event, err := s.repo.GetEvent(ctx, eventID)
+	if err != nil {
+		return false, fmt.Errorf("get event: %w", err)
+	}
+
+	userID, err := s.repo.GetEscalationUserID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	escalated, err := s.repo.EscalateSeverityTx(ctx, tx, eventID, from, to)
+	if err != nil || !escalated {
+		return false, err
+	}
+
+	input := CreateEventUpdateInput{
+		EventID:           eventID,
+		Status:            event.Status,
+		Severity:          &to,
+		Message:           message,
+		NotifySubscribers: event.NotifySubscribers,
+	}
+	event.Severity = &from // matched by EscalateSeverityTx, recorded as the change origin
+	update := &domain.EventUpdate{
+		EventID:           eventID,
+		Status:            event.Status,
+		Message:           message,
+		NotifySubscribers: input.NotifySubscribers,
+		Changes:           eventChanges(event, input),
+		CreatedBy:         userID,
+	}
+	if err := s.repo.CreateEventUpdateTx(ctx, tx, update); err != nil {
+		return false, fmt.Errorf("create update: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit: %w", err)
+	}
+
+	event.Severity = &to
+	if s.notifier != nil && input.NotifySubscribers {
+		go func() {
+			notifyErr := s.notifyOnUpdate(context.WithoutCancel(ctx), event, update, event.Status, input, nil)
+			if notifyErr != nil {
+				slog.ErrorContext(ctx, "failed to notify on severity escalation", "event_id", event.ID, "error", notifyErr)
+			}
+		}()
+	}
+
+	return true, nil
+}
+
+// EscalateToTeam sets the on-call team of an event and records an update "Escalated to <team>"
+// by createdBy. The status stays as it is; subscribers are notified if the event notifies them.
+// Resolved and completed events can't be escalated.
+func (s *Service) EscalateToTeam(ctx context.Context, eventID, team, createdBy string) (*domain.EventUpdate, error) {
+	event, err := s.repo.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("get event: %w", err)
+	}
+
+	return s.AddUpdate(ctx, CreateEventUpdateInput{
+		EventID:           eventID,
+		Status:            event.Status,
+		OnCallTeam:        &team,
+		Message:           "Escalated to " + team,
+		NotifySubscribers: event.NotifySubscribers,
+	}, createdBy)
+}
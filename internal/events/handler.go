@@ -1,75 +1,159 @@
 package events
 
 import (
+	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/pkg/ctxlog"
 	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/bissquit/incident-garden/internal/sse"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 )
 
 var errorMappings = []httputil.ErrorMapping{
 	{Error: ErrEventNotFound, Status: http.StatusNotFound, Message: "event not found"},
 	{Error: ErrTemplateNotFound, Status: http.StatusNotFound, Message: "template not found"},
+	{Error: ErrTemplateRender, Status: http.StatusBadRequest},
+	{Error: ErrTemplateSlugExists, Status: http.StatusConflict},
 	{Error: ErrInvalidStatus, Status: http.StatusBadRequest, Message: "invalid status for event type"},
 	{Error: ErrInvalidSeverity, Status: http.StatusBadRequest, Message: "severity is required for incidents"},
-	{Error: ErrEventAlreadyResolved, Status: http.StatusConflict, Message: "cannot update resolved event"},
+	{Error: ErrStartedAtInFuture, Status: http.StatusBadRequest, Message: "started_at cannot be in the future; use scheduled_start_at for planned work"},
+	{Error: ErrSeverityNotSupported, Status: http.StatusBadRequest, Message: "severity is only supported for incidents"},
+	{Error: domain.ErrInvalidTransition, Status: http.StatusUnprocessableEntity},
 	{Error: ErrEventNotResolved, Status: http.StatusConflict, Message: "cannot delete active event: resolve it first"},
 	{Error: ErrServiceNotInEvent, Status: http.StatusBadRequest, Message: "service is not in this event"},
 	{Error: ErrAffectedServiceNotFound, Status: http.StatusBadRequest},
 	{Error: ErrAffectedGroupNotFound, Status: http.StatusBadRequest},
+	{Error: ErrPostmortemNotFound, Status: http.StatusNotFound, Message: "post-mortem not found"},
+	{Error: ErrPostmortemEventActive, Status: http.StatusConflict, Message: "post-mortem requires a resolved event"},
+	{Error: ErrMaintenanceOverlap, Status: http.StatusConflict},
+	{Error: ErrStartedAtRequired, Status: http.StatusBadRequest},
+	{Error: ErrResolvedAtMismatch, Status: http.StatusBadRequest},
+	{Error: ErrResolvedBeforeStarted, Status: http.StatusBadRequest},
+	{Error: ErrBulkSize, Status: http.StatusBadRequest},
+	{Error: ErrInvalidRecurrenceRule, Status: http.StatusBadRequest},
+	{Error: ErrRecurrenceNotSupported, Status: http.StatusBadRequest},
+	{Error: ErrEventNotReopenable, Status: http.StatusConflict},
+	{Error: ErrReopenNotSupported, Status: http.StatusBadRequest},
 }
 
+// Pagination and search constants for GET /events.
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+	MaxSearchLength  = 200
+	// MaxStartedRangeYears bounds ?from=&to= on GET /events.
+	MaxStartedRangeYears = 1
+)
+
+// Pagination constants for GET /events/{id}/updates.
+const (
+	DefaultUpdatesLimit = 20
+	MaxUpdatesLimit     = 100
+)
+
+// Constants for GET /status.
+const (
+	StatusPageEventsLimit  = 10
+	MaxRecentResolvedHours = 168
+)
+
 // Handler handles HTTP requests for events and templates.
 type Handler struct {
 	service   *Service
+	publisher sse.Publisher
+	alerter   AdminAlerter
+	settings  StatusPageSettingsReader
+	audit     AuditRecorder
 	validator *validator.Validate
 }
 
+// Audit log action and target type of event purges.
+const (
+	auditActionEventsPurge = "events.purge"
+	auditTargetEvents      = "events"
+)
+
 // NewHandler creates a new events handler.
-func NewHandler(service *Service) *Handler {
+// publisher may be nil, in which case no live updates are pushed.
+// alerter may be nil, in which case new events are not posted to the admin channel.
+// settings may be nil, in which case GET /status has no status page settings.
+// audit may be nil, in which case purges are not audited.
+func NewHandler(service *Service, publisher sse.Publisher, alerter AdminAlerter, settings StatusPageSettingsReader, audit AuditRecorder) *Handler {
 	return &Handler{
 		service:   service,
+		publisher: publisher,
+		alerter:   alerter,
+		settings:  settings,
+		audit:     audit,
 		validator: validator.New(),
 	}
 }
 
 // RegisterPublicRoutes registers public routes (no auth required).
+// Status data is readable from any origin for the embeddable widget.
 func (h *Handler) RegisterPublicRoutes(r chi.Router) {
-	r.Get("/status", h.GetPublicStatus)
-	r.Get("/status/history", h.GetStatusHistory)
+	r.With(httputil.PublicCORSMiddleware).Get("/status", h.GetPublicStatus)
+	r.With(httputil.PublicCORSMiddleware).Get("/status/history", h.GetStatusHistory)
 }
 
 // RegisterPublicEventRoutes registers public read-only event routes (no auth required).
 func (h *Handler) RegisterPublicEventRoutes(r chi.Router) {
 	r.Get("/events", h.ListEvents)
-	r.Get("/events/{id}", h.GetEvent)
+	r.With(httputil.ETagMiddleware).Get("/events/{id}", h.GetEvent)
 	r.Get("/events/{id}/updates", h.GetEventUpdates)
 	r.Get("/events/{id}/changes", h.GetServiceChanges)
+	r.Get("/events/{id}/postmortem", h.GetPostmortem)
+	r.Get("/events/{id}/timeline", h.GetEventTimeline)
 }
 
 // RegisterOperatorRoutes registers operator-level routes (write operations only).
-func (h *Handler) RegisterOperatorRoutes(r chi.Router) {
+// updateMiddlewares wrap POST /events/{id}/updates only, e.g. idempotent retries.
+func (h *Handler) RegisterOperatorRoutes(r chi.Router, updateMiddlewares ...func(http.Handler) http.Handler) {
 	r.Post("/events", h.CreateEvent)
-	r.Post("/events/{id}/updates", h.AddUpdate)
+	r.With(updateMiddlewares...).Post("/events/{id}/updates", h.AddUpdate)
+	r.Post("/events/{id}/escalate", h.EscalateEvent)
+	r.Post("/templates/{slug}/preview", h.PreviewTemplate)
+	r.Post("/templates/{slug}/clone", h.CloneTemplate)
 }
 
 // RegisterAdminRoutes registers admin-level routes.
 func (h *Handler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/events/export", h.ExportEvents)
+	r.Post("/events/bulk", h.CreateEventBulk)
 	r.Delete("/events/{id}", h.DeleteEvent)
+	r.Post("/events/{id}/reopen", h.ReopenEvent)
+	r.Put("/events/{id}/postmortem", h.SavePostmortem)
+	r.Delete("/admin/cleanup", h.PurgeEvents)
+	r.Get("/stats/events", h.GetEventStats)
 
 	r.Route("/templates", func(r chi.Router) {
 		r.Post("/", h.CreateTemplate)
 		r.Get("/", h.ListTemplates)
 		r.Get("/{slug}", h.GetTemplate)
-		r.Post("/{slug}/preview", h.PreviewTemplate)
 		r.Delete("/{id}", h.DeleteTemplate)
 	})
 }
 
+// DefaultNotifyPolicy reports whether subscribers are notified when a request
+// omits notify_subscribers: incidents notify by default, maintenance does not,
+// since operators usually announce planned work in a targeted way.
+func DefaultNotifyPolicy(eventType domain.EventType) bool {
+	return eventType == domain.EventTypeIncident
+}
+
 // CreateEventRequest represents the request body for creating an event.
 type CreateEventRequest struct {
 	Title             string                   `json:"title" validate:"required"`
@@ -77,17 +161,49 @@ type CreateEventRequest struct {
 	Status            domain.EventStatus       `json:"status" validate:"required"`
 	Severity          *domain.Severity         `json:"severity"`
 	Description       string                   `json:"description" validate:"required"`
+	Impact            string                   `json:"impact" validate:"max=500"`
+	OnCallTeam        string                   `json:"oncall_team" validate:"max=100"`
 	StartedAt         *time.Time               `json:"started_at"`
 	ResolvedAt        *time.Time               `json:"resolved_at"`
 	ScheduledStartAt  *time.Time               `json:"scheduled_start_at"`
 	ScheduledEndAt    *time.Time               `json:"scheduled_end_at"`
-	NotifySubscribers bool                     `json:"notify_subscribers"`
+	NotifySubscribers *bool                    `json:"notify_subscribers"` // nil: DefaultNotifyPolicy
 	TemplateID        *string                  `json:"template_id"`
+	RecurrenceRule    string                   `json:"recurrence_rule"`
+	RecurrenceEndDate *time.Time               `json:"recurrence_end_date"`
 	AffectedServices  []domain.AffectedService `json:"affected_services" validate:"dive"`
 	AffectedGroups    []domain.AffectedGroup   `json:"affected_groups" validate:"dive"`
 }
 
-// CreateEvent handles POST /events.
+// toInput converts the request; notifyDefault applies when notify_subscribers is omitted.
+func (req *CreateEventRequest) toInput(notifyDefault bool) CreateEventInput {
+	notify := notifyDefault
+	if req.NotifySubscribers != nil {
+		notify = *req.NotifySubscribers
+	}
+
+	return CreateEventInput{
+		Title:             req.Title,
+		Type:              req.Type,
+		Status:            req.Status,
+		Severity:          req.Severity,
+		Description:       req.Description,
+		Impact:            req.Impact,
+		OnCallTeam:        req.OnCallTeam,
+		StartedAt:         req.StartedAt,
+		ResolvedAt:        req.ResolvedAt,
+		ScheduledStartAt:  req.ScheduledStartAt,
+		ScheduledEndAt:    req.ScheduledEndAt,
+		NotifySubscribers: notify,
+		TemplateID:        req.TemplateID,
+		RecurrenceRule:    req.RecurrenceRule,
+		RecurrenceEndDate: req.RecurrenceEndDate,
+		AffectedServices:  req.AffectedServices,
+		AffectedGroups:    req.AffectedGroups,
+	}
+}
+
+// CreateEvent handles POST /events[?dry_run=true].
 func (h *Handler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 	var req CreateEventRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -100,17 +216,154 @@ func (h *Handler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	dryRun := false
+	if raw := r.URL.Query().Get("dry_run"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			httputil.Error(w, http.StatusBadRequest, "dry_run must be true or false")
+			return
+		}
+		dryRun = parsed
+	}
+
 	userID := httputil.GetUserID(r.Context())
-	event, err := h.service.CreateEvent(r.Context(), CreateEventInput(req), userID)
+	input := req.toInput(DefaultNotifyPolicy(req.Type))
+	if dryRun {
+		event, wouldNotify, err := h.service.CreateEventDryRun(r.Context(), input, userID)
+		if err != nil {
+			h.handleWriteError(r.Context(), w, err)
+			return
+		}
+		httputil.Success(w, http.StatusOK, DryRunEventResponse{Event: event, DryRun: true, WouldNotify: wouldNotify})
+		return
+	}
+
+	before := h.snapshot(r.Context())
+	event, err := h.service.CreateEvent(r.Context(), input, userID)
 
 	if err != nil {
-		httputil.HandleError(r.Context(), w, err, errorMappings)
+		h.handleWriteError(r.Context(), w, err)
 		return
 	}
 
+	h.publish(r.Context(), before, sse.TypeEventCreated, event)
+	h.alertAdmins(r.Context(), event)
+
 	httputil.Success(w, http.StatusCreated, event)
 }
 
+// DryRunEventResponse is the result of POST /events?dry_run=true: the event as it
+// would be created and the number of channels that would be notified.
+type DryRunEventResponse struct {
+	*domain.Event
+	DryRun      bool `json:"dry_run"`
+	WouldNotify int  `json:"would_notify"`
+}
+
+// bulkItemResult is one entry of the POST /events/bulk response.
+type bulkItemResult struct {
+	Index  int           `json:"index"`
+	Status int           `json:"status"`
+	Event  *domain.Event `json:"event,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// CreateEventBulk handles POST /events/bulk.
+// Responds 207 with a result per item: 201 and the event when the batch is stored,
+// otherwise the error status of each invalid item and 424 for the valid ones, since
+// nothing is stored. notify_subscribers defaults to false, imports are usually history;
+// imported events are not posted to the admin channel.
+func (h *Handler) CreateEventBulk(w http.ResponseWriter, r *http.Request) {
+	var reqs []CreateEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	if len(reqs) == 0 || len(reqs) > MaxBulkEvents {
+		httputil.HandleError(r.Context(), w, ErrBulkSize, errorMappings)
+		return
+	}
+
+	results := make([]bulkItemResult, len(reqs))
+	inputs := make([]CreateEventInput, len(reqs))
+	rejected := false
+	for i := range reqs {
+		results[i] = bulkItemResult{Index: i, Status: http.StatusFailedDependency}
+		if err := h.validator.Struct(reqs[i]); err != nil {
+			results[i].Status = http.StatusBadRequest
+			results[i].Error = validationMessage(err)
+			rejected = true
+			continue
+		}
+		inputs[i] = reqs[i].toInput(false)
+	}
+	if rejected {
+		httputil.Success(w, http.StatusMultiStatus, results)
+		return
+	}
+
+	before := h.snapshot(r.Context())
+	bulk, err := h.service.CreateEventBulk(r.Context(), inputs, httputil.GetUserID(r.Context()))
+	if err != nil && !errors.Is(err, ErrBulkRejected) {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	for _, res := range bulk {
+		switch {
+		case res.Err != nil:
+			status, msg, ok := httputil.MapError(res.Err, errorMappings)
+			if !ok {
+				ctxlog.FromContext(r.Context()).Error("bulk event rejected", "index", res.Index, "error", res.Err)
+			}
+			results[res.Index].Status = status
+			results[res.Index].Error = msg
+		case res.Event != nil:
+			results[res.Index].Status = http.StatusCreated
+			results[res.Index].Event = res.Event
+		}
+	}
+
+	if err == nil && h.publisher != nil {
+		for _, res := range bulk {
+			h.publisher.Publish(sse.TypeEventCreated, res.Event)
+		}
+		if before != nil {
+			h.publisher.PublishStatusChanges(before, h.publisher.Snapshot(r.Context()))
+		}
+	}
+
+	httputil.Success(w, http.StatusMultiStatus, results)
+}
+
+// validationMessage describes validator errors of a bulk item in one line.
+func validationMessage(err error) string {
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return err.Error()
+	}
+	fields := make([]string, 0, len(fieldErrors))
+	for _, e := range fieldErrors {
+		fields = append(fields, fmt.Sprintf("%s (%s)", e.Field(), e.Tag()))
+	}
+	return "validation failed: " + strings.Join(fields, ", ")
+}
+
+// alertAdmins posts a new event to the admin channel asynchronously.
+// The alert outlives the request but keeps its values, such as the request ID.
+func (h *Handler) alertAdmins(ctx context.Context, event *domain.Event) {
+	if h.alerter == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := h.alerter.Alert(ctx, event); err != nil {
+			slog.ErrorContext(ctx, "failed to send admin alert", "event_id", event.ID, "error", err)
+		}
+	}()
+}
+
 // GetEvent handles GET /events/{id}.
 func (h *Handler) GetEvent(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
@@ -120,6 +373,7 @@ func (h *Handler) GetEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	httputil.SetLastModified(w, event.UpdatedAt)
 	httputil.Success(w, http.StatusOK, event)
 }
 
@@ -137,20 +391,213 @@ func (h *Handler) ListEvents(w http.ResponseWriter, r *http.Request) {
 		filters.Status = &status
 	}
 
+	if severityParam := r.URL.Query().Get("severity"); severityParam != "" {
+		severity := domain.Severity(severityParam)
+		if !severity.IsValid() {
+			httputil.Error(w, http.StatusBadRequest, "invalid severity filter, must be 'minor', 'major' or 'critical'")
+			return
+		}
+		filters.Severity = &severity
+	}
+
+	if groupID := r.URL.Query().Get("group_id"); groupID != "" {
+		if _, err := uuid.Parse(groupID); err != nil {
+			httputil.Error(w, http.StatusBadRequest, "invalid group_id, must be a UUID")
+			return
+		}
+		filters.GroupID = &groupID
+	}
+
+	if hasPostMortem := r.URL.Query().Get("has_post_mortem"); hasPostMortem != "" {
+		if hasPostMortem != "true" && hasPostMortem != "false" {
+			httputil.Error(w, http.StatusBadRequest, "invalid has_post_mortem filter, must be 'true' or 'false'")
+			return
+		}
+		value := hasPostMortem == "true"
+		filters.HasPostMortem = &value
+	}
+
+	if q := strings.TrimSpace(r.URL.Query().Get("q")); q != "" {
+		if utf8.RuneCountInString(q) > MaxSearchLength {
+			httputil.Error(w, http.StatusBadRequest, fmt.Sprintf("search query must be at most %d characters", MaxSearchLength))
+			return
+		}
+		filters.Search = &q
+	}
+
+	from, to, err := parseStartedRange(r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filters.StartedFrom, filters.StartedTo = from, to
+
+	// Parse pagination with validation
+	filters.Limit = DefaultListLimit
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 {
+			httputil.Error(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		if parsed > MaxListLimit {
+			parsed = MaxListLimit
+		}
+		filters.Limit = parsed
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		parsed, err := strconv.Atoi(o)
+		if err != nil || parsed < 0 {
+			httputil.Error(w, http.StatusBadRequest, "offset must be a non-negative integer")
+			return
+		}
+		filters.Offset = parsed
+	}
+
 	events, err := h.service.ListEvents(r.Context(), filters)
 	if err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
 	}
 
-	httputil.Success(w, http.StatusOK, events)
+	total, err := h.service.CountEvents(r.Context(), filters)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	response := map[string]interface{}{
+		"events": events,
+		"total":  total,
+		"limit":  filters.Limit,
+		"offset": filters.Offset,
+	}
+
+	httputil.Success(w, http.StatusOK, response)
+}
+
+// exportDateLayout is the format of the from/to export parameters.
+const exportDateLayout = "2006-01-02"
+
+// exportColumns is the CSV header of the events export.
+var exportColumns = []string{
+	"id", "title", "type", "status", "severity",
+	"started_at", "resolved_at", "duration_seconds", "affected_services",
+}
+
+// ExportEvents handles GET /events/export.
+// Rows are written to the response as they are read from the database.
+func (h *Handler) ExportEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if format := query.Get("format"); format != "" && format != "csv" {
+		httputil.Error(w, http.StatusBadRequest, "unsupported export format, must be 'csv'")
+		return
+	}
+
+	var filters EventFilters
+	if from := query.Get("from"); from != "" {
+		parsed, err := time.Parse(exportDateLayout, from)
+		if err != nil {
+			httputil.Error(w, http.StatusBadRequest, "from must be a date in YYYY-MM-DD format")
+			return
+		}
+		filters.From = parsed
+	}
+	if to := query.Get("to"); to != "" {
+		parsed, err := time.Parse(exportDateLayout, to)
+		if err != nil {
+			httputil.Error(w, http.StatusBadRequest, "to must be a date in YYYY-MM-DD format")
+			return
+		}
+		// The to date is inclusive
+		filters.To = parsed.AddDate(0, 0, 1)
+	}
+	if !filters.From.IsZero() && !filters.To.IsZero() && !filters.From.Before(filters.To) {
+		httputil.Error(w, http.StatusBadRequest, "from must not be after to")
+		return
+	}
+
+	// Headers are sent with the first row, so a failing query still gets a JSON error
+	cw := csv.NewWriter(w)
+	started := false
+	start := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="events.csv"`)
+		w.WriteHeader(http.StatusOK)
+		return cw.Write(exportColumns)
+	}
+
+	err := h.service.ExportEvents(r.Context(), filters, func(row *ExportRow) error {
+		if err := start(); err != nil {
+			return err
+		}
+		return cw.Write(exportRecord(row))
+	})
+	if err == nil {
+		err = start()
+	}
+	if err != nil {
+		if !started {
+			httputil.HandleError(r.Context(), w, err, errorMappings)
+			return
+		}
+		ctxlog.FromContext(r.Context()).Error("events export interrupted", "error", err)
+		return
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		ctxlog.FromContext(r.Context()).Error("failed to write events export", "error", err)
+	}
+}
+
+// exportRecord converts an event to a CSV record matching exportColumns.
+func exportRecord(row *ExportRow) []string {
+	event := &row.Event
+
+	var severity, duration string
+	if event.Severity != nil {
+		severity = string(*event.Severity)
+	}
+	if event.DurationSeconds != nil {
+		duration = strconv.FormatInt(*event.DurationSeconds, 10)
+	}
+
+	return []string{
+		event.ID,
+		event.Title,
+		string(event.Type),
+		string(event.Status),
+		severity,
+		formatExportTime(event.StartedAt),
+		formatExportTime(event.ResolvedAt),
+		duration,
+		strings.Join(row.ServiceSlugs, ";"),
+	}
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
 }
 
 // AddUpdateRequest represents the request body for adding an event update.
 type AddUpdateRequest struct {
 	Status            domain.EventStatus       `json:"status" validate:"required"`
+	Severity          *domain.Severity         `json:"severity" validate:"omitempty,oneof=minor major critical"`
+	Impact            *string                  `json:"impact" validate:"omitempty,max=500"`      // nil keeps, "" clears
+	OnCallTeam        *string                  `json:"oncall_team" validate:"omitempty,max=100"` // nil keeps, "" clears
 	Message           string                   `json:"message" validate:"required"`
-	NotifySubscribers bool                     `json:"notify_subscribers"`
+	NotifySubscribers *bool                    `json:"notify_subscribers"` // nil: DefaultNotifyPolicy of the event type
 	ServiceUpdates    []domain.AffectedService `json:"service_updates" validate:"dive"`
 	AddServices       []domain.AffectedService `json:"add_services" validate:"dive"`
 	AddGroups         []domain.AffectedGroup   `json:"add_groups" validate:"dive"`
@@ -173,12 +620,28 @@ func (h *Handler) AddUpdate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var notify bool
+	if req.NotifySubscribers != nil {
+		notify = *req.NotifySubscribers
+	} else {
+		event, err := h.service.GetEvent(r.Context(), eventID)
+		if err != nil {
+			httputil.HandleError(r.Context(), w, err, errorMappings)
+			return
+		}
+		notify = DefaultNotifyPolicy(event.Type)
+	}
+
 	userID := httputil.GetUserID(r.Context())
+	before := h.snapshot(r.Context())
 	update, err := h.service.AddUpdate(r.Context(), CreateEventUpdateInput{
 		EventID:           eventID,
 		Status:            req.Status,
+		Severity:          req.Severity,
+		Impact:            req.Impact,
+		OnCallTeam:        req.OnCallTeam,
 		Message:           req.Message,
-		NotifySubscribers: req.NotifySubscribers,
+		NotifySubscribers: notify,
 		ServiceUpdates:    req.ServiceUpdates,
 		AddServices:       req.AddServices,
 		AddGroups:         req.AddGroups,
@@ -186,24 +649,170 @@ func (h *Handler) AddUpdate(w http.ResponseWriter, r *http.Request) {
 		Reason:            req.Reason,
 	}, userID)
 
+	if err != nil {
+		h.handleWriteError(r.Context(), w, err)
+		return
+	}
+
+	h.publish(r.Context(), before, sse.TypeEventUpdated, update)
+
+	httputil.Success(w, http.StatusCreated, update)
+}
+
+// EscalateEventRequest represents request body for escalating an event to an on-call team.
+type EscalateEventRequest struct {
+	OnCallTeam string `json:"oncall_team" validate:"required,max=100"`
+}
+
+// EscalateEvent handles POST /events/{id}/escalate.
+func (h *Handler) EscalateEvent(w http.ResponseWriter, r *http.Request) {
+	eventID := chi.URLParam(r, "id")
+
+	var req EscalateEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	req.OnCallTeam = strings.TrimSpace(req.OnCallTeam)
+	if err := h.validator.Struct(req); err != nil {
+		httputil.ValidationError(w, err)
+		return
+	}
+
+	before := h.snapshot(r.Context())
+	update, err := h.service.EscalateToTeam(r.Context(), eventID, req.OnCallTeam, httputil.GetUserID(r.Context()))
 	if err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
 	}
 
+	h.publish(r.Context(), before, sse.TypeEventUpdated, update)
+
 	httputil.Success(w, http.StatusCreated, update)
 }
 
+// ReopenEvent handles POST /events/{id}/reopen.
+func (h *Handler) ReopenEvent(w http.ResponseWriter, r *http.Request) {
+	eventID := chi.URLParam(r, "id")
+
+	before := h.snapshot(r.Context())
+	if err := h.service.Reopen(r.Context(), eventID, httputil.GetUserID(r.Context())); err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	event, err := h.service.GetEvent(r.Context(), eventID)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	h.publish(r.Context(), before, sse.TypeEventUpdated, event)
+
+	httputil.Success(w, http.StatusOK, event)
+}
+
+// maintenanceConflict is an item of the conflicts list of a 409 overlapping maintenance response.
+type maintenanceConflict struct {
+	EventID string `json:"event_id"`
+	Title   string `json:"title"`
+}
+
+// handleWriteError is HandleError for event writes: an overlapping maintenance
+// window is answered with 409 and the list of conflicting events.
+func (h *Handler) handleWriteError(ctx context.Context, w http.ResponseWriter, err error) {
+	var overlap *MaintenanceOverlapError
+	if !errors.As(err, &overlap) {
+		httputil.HandleError(ctx, w, err, errorMappings)
+		return
+	}
+
+	conflicts := make([]maintenanceConflict, 0, len(overlap.Conflicts))
+	for _, event := range overlap.Conflicts {
+		conflicts = append(conflicts, maintenanceConflict{EventID: event.ID, Title: event.Title})
+	}
+	httputil.JSON(w, http.StatusConflict, map[string]interface{}{
+		"error": map[string]interface{}{
+			"message":   overlap.Error(),
+			"conflicts": conflicts,
+		},
+	})
+}
+
+// snapshot captures service statuses before a change (nil without publisher or subscribers).
+func (h *Handler) snapshot(ctx context.Context) sse.StatusSnapshot {
+	if h.publisher == nil {
+		return nil
+	}
+	return h.publisher.Snapshot(ctx)
+}
+
+// publish pushes a live update for a committed change followed by
+// effective status changes of services since the before snapshot.
+func (h *Handler) publish(ctx context.Context, before sse.StatusSnapshot, msgType string, data interface{}) {
+	if h.publisher == nil {
+		return
+	}
+	h.publisher.Publish(msgType, data)
+	if before != nil {
+		h.publisher.PublishStatusChanges(before, h.publisher.Snapshot(ctx))
+	}
+}
+
 // GetEventUpdates handles GET /events/{id}/updates.
 func (h *Handler) GetEventUpdates(w http.ResponseWriter, r *http.Request) {
 	eventID := chi.URLParam(r, "id")
-	updates, err := h.service.GetEventUpdates(r.Context(), eventID)
+
+	limit := DefaultUpdatesLimit
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 {
+			httputil.Error(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		if parsed > MaxUpdatesLimit {
+			parsed = MaxUpdatesLimit
+		}
+		limit = parsed
+	}
+
+	if o := r.URL.Query().Get("offset"); o != "" {
+		parsed, err := strconv.Atoi(o)
+		if err != nil || parsed < 0 {
+			httputil.Error(w, http.StatusBadRequest, "offset must be a non-negative integer")
+			return
+		}
+		offset = parsed
+	}
+
+	updates, total, err := h.service.ListEventUpdates(r.Context(), eventID, limit, offset)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	response := map[string]interface{}{
+		"updates": updates,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
+	}
+
+	httputil.Success(w, http.StatusOK, response)
+}
+
+// GetEventTimeline handles GET /events/{id}/timeline.
+func (h *Handler) GetEventTimeline(w http.ResponseWriter, r *http.Request) {
+	timeline, err := h.service.GetEventTimeline(r.Context(), chi.URLParam(r, "id"))
 	if err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
 	}
 
-	httputil.Success(w, http.StatusOK, updates)
+	httputil.Success(w, http.StatusOK, timeline)
 }
 
 // DeleteEvent handles DELETE /events/{id}.
@@ -218,6 +827,204 @@ func (h *Handler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// PurgeResponse is the result of DELETE /admin/cleanup.
+type PurgeResponse struct {
+	PurgeResult
+	DryRun bool `json:"dry_run"`
+}
+
+// PurgeEvents handles DELETE /admin/cleanup?older_than=90d[&dry_run=true].
+func (h *Handler) PurgeEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	olderThan, err := parseRetention(query.Get("older_than"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dryRun := false
+	if raw := query.Get("dry_run"); raw != "" {
+		dryRun, err = strconv.ParseBool(raw)
+		if err != nil {
+			httputil.Error(w, http.StatusBadRequest, "dry_run must be true or false")
+			return
+		}
+	}
+
+	result, err := h.service.PurgeOldEvents(r.Context(), olderThan, dryRun)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+	if h.audit != nil && !dryRun {
+		h.audit.Record(r.Context(), auditActionEventsPurge, auditTargetEvents, "", map[string]interface{}{
+			"older_than":      query.Get("older_than"),
+			"deleted_events":  result.DeletedEvents,
+			"deleted_updates": result.DeletedUpdates,
+		})
+	}
+
+	httputil.Success(w, http.StatusOK, PurgeResponse{PurgeResult: result, DryRun: dryRun})
+}
+
+// parseRetention parses a positive age: days as "90d", or a Go duration such as "36h".
+func parseRetention(raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, errors.New("older_than is required, e.g. 90d")
+	}
+	return parseAge("older_than", raw)
+}
+
+// parseAge parses a positive duration given as days ("90d") or a Go duration ("36h");
+// param names the query parameter in error messages.
+func parseAge(param, raw string) (time.Duration, error) {
+	var d time.Duration
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("%s must be a number of days like 90d or a duration like 36h", param)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return 0, fmt.Errorf("%s must be a number of days like 90d or a duration like 36h", param)
+		}
+		d = parsed
+	}
+
+	if d <= 0 {
+		return 0, fmt.Errorf("%s must be positive", param)
+	}
+	return d, nil
+}
+
+// parseStartedRange parses the RFC 3339 from/to bounds of GET /events; without both bounds
+// the range is unbounded. A single bound is completed to a MaxStartedRangeYears range
+// (from+1y or to-1y), so from must be before to and the range is capped either way.
+func parseStartedRange(rawFrom, rawTo string) (from, to *time.Time, err error) {
+	if rawFrom != "" {
+		parsed, err := time.Parse(time.RFC3339, rawFrom)
+		if err != nil {
+			return nil, nil, errors.New("from must be an RFC 3339 timestamp")
+		}
+		from = &parsed
+	}
+	if rawTo != "" {
+		parsed, err := time.Parse(time.RFC3339, rawTo)
+		if err != nil {
+			return nil, nil, errors.New("to must be an RFC 3339 timestamp")
+		}
+		to = &parsed
+	}
+
+	switch {
+	case from == nil && to == nil:
+		return nil, nil, nil
+	case to == nil:
+		end := from.AddDate(MaxStartedRangeYears, 0, 0)
+		to = &end
+	case from == nil:
+		start := to.AddDate(-MaxStartedRangeYears, 0, 0)
+		from = &start
+	}
+
+	if !from.Before(*to) {
+		return nil, nil, errors.New("from must be before to")
+	}
+	if to.After(from.AddDate(MaxStartedRangeYears, 0, 0)) {
+		return nil, nil, fmt.Errorf("from and to must be at most %d year apart", MaxStartedRangeYears)
+	}
+	return from, to, nil
+}
+
+// DefaultStatsWindow is the statistics window used when none is requested.
+const DefaultStatsWindow = "30d"
+
+// EventStatsResponse is the result of GET /stats/events.
+type EventStatsResponse struct {
+	Window string    `json:"window"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	EventStats
+}
+
+// GetEventStats handles GET /stats/events?window=30d.
+func (h *Handler) GetEventStats(w http.ResponseWriter, r *http.Request) {
+	windowParam := r.URL.Query().Get("window")
+	if windowParam == "" {
+		windowParam = DefaultStatsWindow
+	}
+	window, err := parseAge("window", windowParam)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	to := time.Now().UTC()
+	from := to.Add(-window)
+	stats, err := h.service.ComputeEventStats(r.Context(), from, to)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, EventStatsResponse{
+		Window:     windowParam,
+		From:       from,
+		To:         to,
+		EventStats: stats,
+	})
+}
+
+// PostmortemRequest represents the request body for saving a post-mortem.
+type PostmortemRequest struct {
+	Title       string     `json:"title" validate:"required,max=500"`
+	Body        string     `json:"body" validate:"required"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// SavePostmortem handles PUT /events/{id}/postmortem.
+func (h *Handler) SavePostmortem(w http.ResponseWriter, r *http.Request) {
+	var req PostmortemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		httputil.ValidationError(w, err)
+		return
+	}
+
+	userID := httputil.GetUserID(r.Context())
+	postmortem, err := h.service.SavePostmortem(r.Context(), PostmortemInput{
+		EventID:     chi.URLParam(r, "id"),
+		Title:       req.Title,
+		Body:        req.Body,
+		PublishedAt: req.PublishedAt,
+	}, userID)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, postmortem)
+}
+
+// GetPostmortem handles GET /events/{id}/postmortem.
+// Only published post-mortems are returned.
+func (h *Handler) GetPostmortem(w http.ResponseWriter, r *http.Request) {
+	postmortem, err := h.service.GetPublishedPostmortem(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, postmortem)
+}
+
 // CreateTemplateRequest represents the request body for creating a template.
 type CreateTemplateRequest struct {
 	Slug          string           `json:"slug" validate:"required"`
@@ -274,12 +1081,13 @@ func (h *Handler) ListTemplates(w http.ResponseWriter, r *http.Request) {
 
 // PreviewTemplateRequest represents the request body for previewing a template.
 type PreviewTemplateRequest struct {
-	ServiceName      string     `json:"service_name"`
-	ServiceGroupName string     `json:"service_group_name"`
-	StartedAt        *time.Time `json:"started_at"`
-	ResolvedAt       *time.Time `json:"resolved_at"`
-	ScheduledStart   *time.Time `json:"scheduled_start"`
-	ScheduledEnd     *time.Time `json:"scheduled_end"`
+	ServiceName      string            `json:"service_name"`
+	ServiceGroupName string            `json:"service_group_name"`
+	StartedAt        *time.Time        `json:"started_at"`
+	ResolvedAt       *time.Time        `json:"resolved_at"`
+	ScheduledStart   *time.Time        `json:"scheduled_start"`
+	ScheduledEnd     *time.Time        `json:"scheduled_end"`
+	Variables        map[string]string `json:"variables"`
 }
 
 // PreviewTemplateResponse represents the response for template preview.
@@ -305,6 +1113,7 @@ func (h *Handler) PreviewTemplate(w http.ResponseWriter, r *http.Request) {
 		ResolvedAt:       req.ResolvedAt,
 		ScheduledStart:   req.ScheduledStart,
 		ScheduledEnd:     req.ScheduledEnd,
+		Variables:        req.Variables,
 	})
 
 	if err != nil {
@@ -318,6 +1127,35 @@ func (h *Handler) PreviewTemplate(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// CloneTemplateRequest represents the request body for cloning a template.
+type CloneTemplateRequest struct {
+	NewSlug string `json:"new_slug" validate:"required"`
+}
+
+// CloneTemplate handles POST /templates/{slug}/clone.
+func (h *Handler) CloneTemplate(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	var req CloneTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		httputil.ValidationError(w, err)
+		return
+	}
+
+	template, err := h.service.CloneTemplate(r.Context(), slug, req.NewSlug)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusCreated, template)
+}
+
 // DeleteTemplate handles DELETE /templates/{id}.
 func (h *Handler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
@@ -330,16 +1168,38 @@ func (h *Handler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
 }
 
 // GetPublicStatus handles GET /status.
+// Resolved events are listed only with include_recent_resolved_hours.
+// The status page settings are omitted if they can't be read: events matter more than branding.
 func (h *Handler) GetPublicStatus(w http.ResponseWriter, r *http.Request) {
-	events, err := h.service.ListEvents(r.Context(), EventFilters{Limit: 10})
+	var hours int
+	if raw := r.URL.Query().Get("include_recent_resolved_hours"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			httputil.Error(w, http.StatusBadRequest, "include_recent_resolved_hours must be a non-negative integer")
+			return
+		}
+		hours = min(parsed, MaxRecentResolvedHours)
+	}
+
+	events, err := h.service.ListStatusEvents(r.Context(), time.Duration(hours)*time.Hour, StatusPageEventsLimit)
 	if err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
 	}
 
-	httputil.Success(w, http.StatusOK, map[string]interface{}{
+	response := map[string]interface{}{
 		"events": events,
-	})
+	}
+	if h.settings != nil {
+		settings, err := h.settings.GetStatusPageSettings(r.Context())
+		if err != nil {
+			ctxlog.FromContext(r.Context()).Warn("failed to get status page settings", "error", err)
+		} else {
+			response["settings"] = settings
+		}
+	}
+
+	httputil.Success(w, http.StatusOK, response)
 }
 
 // GetStatusHistory handles GET /status/history.
@@ -367,4 +1227,3 @@ func (h *Handler) GetServiceChanges(w http.ResponseWriter, r *http.Request) {
 
 	httputil.Success(w, http.StatusOK, changes)
 }
-
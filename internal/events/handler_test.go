@@ -0,0 +1,145 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+func TestParseRetention(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{raw: "90d", want: 90 * 24 * time.Hour},
+		{raw: "1d", want: 24 * time.Hour},
+		{raw: "36h", want: 36 * time.Hour},
+		{raw: "", wantErr: true},
+		{raw: "0d", wantErr: true},
+		{raw: "-5d", wantErr: true},
+		{raw: "-1h", wantErr: true},
+		{raw: "d", wantErr: true},
+		{raw: "1.5d", wantErr: true},
+		{raw: "ninety", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := parseRetention(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseRetention(%q) = %v, want error", tt.raw, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRetention(%q) error = %v", tt.raw, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseRetention(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAge_ErrorNamesParam(t *testing.T) {
+	_, err := parseAge("window", "month")
+	if err == nil || !strings.HasPrefix(err.Error(), "window ") {
+		t.Errorf("parseAge(window, month) error = %v, want one naming window", err)
+	}
+}
+
+func TestParseStartedRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		wantFrom string
+		wantTo   string
+		wantErr  string
+	}{
+		{name: "unbounded"},
+		{name: "from only", from: "2026-03-10T12:00:00Z", wantFrom: "2026-03-10T12:00:00Z", wantTo: "2027-03-10T12:00:00Z"},
+		{name: "to only", to: "2026-03-10T12:00:00+03:00", wantFrom: "2025-03-10T12:00:00+03:00", wantTo: "2026-03-10T12:00:00+03:00"},
+		{name: "range", from: "2026-03-10T12:00:00Z", to: "2026-03-11T12:00:00Z", wantFrom: "2026-03-10T12:00:00Z", wantTo: "2026-03-11T12:00:00Z"},
+		{name: "exactly a year", from: "2026-03-10T12:00:00Z", to: "2027-03-10T12:00:00Z", wantFrom: "2026-03-10T12:00:00Z", wantTo: "2027-03-10T12:00:00Z"},
+		{name: "longer than a year", from: "2026-03-10T12:00:00Z", to: "2027-03-10T12:00:01Z", wantErr: "at most 1 year"},
+		{name: "equal bounds", from: "2026-03-10T12:00:00Z", to: "2026-03-10T12:00:00Z", wantErr: "from must be before to"},
+		{name: "reversed", from: "2026-03-11T12:00:00Z", to: "2026-03-10T12:00:00Z", wantErr: "from must be before to"},
+		{name: "date only", from: "2026-03-10", wantErr: "from must be an RFC 3339"},
+		{name: "invalid to", to: "yesterday", wantErr: "to must be an RFC 3339"},
+	}
+
+	format := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := parseStartedRange(tt.from, tt.to)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("parseStartedRange() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseStartedRange() error = %v", err)
+			}
+			if format(from) != tt.wantFrom || format(to) != tt.wantTo {
+				t.Errorf("parseStartedRange() = %s, %s, want %s, %s", format(from), format(to), tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestHandleWriteError_MaintenanceOverlap(t *testing.T) {
+	h := &Handler{}
+	overlap := &MaintenanceOverlapError{Conflicts: []*domain.Event{{ID: "e1", Title: "DB upgrade"}}}
+
+	rec := httptest.NewRecorder()
+	h.handleWriteError(context.Background(), rec, fmt.Errorf("create event: %w", overlap))
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	var body struct {
+		Error struct {
+			Message   string                `json:"message"`
+			Conflicts []maintenanceConflict `json:"conflicts"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Error.Message != "overlapping maintenance window" {
+		t.Errorf("message = %q", body.Error.Message)
+	}
+	want := []maintenanceConflict{{EventID: "e1", Title: "DB upgrade"}}
+	if len(body.Error.Conflicts) != 1 || body.Error.Conflicts[0] != want[0] {
+		t.Errorf("conflicts = %+v, want %+v", body.Error.Conflicts, want)
+	}
+}
+
+func TestHandleWriteError_OtherErrors(t *testing.T) {
+	rec := httptest.NewRecorder()
+	(&Handler{}).handleWriteError(context.Background(), rec, ErrEventNotResolved)
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	if got := rec.Body.String(); !strings.Contains(got, "cannot delete active event") || strings.Contains(got, "conflicts") {
+		t.Errorf("body = %q, want the mapped error without conflicts", got)
+	}
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// eventsChangedChannel is notified by the events_changed_notify trigger (migration 000034).
+const eventsChangedChannel = "events_changed"
+
+// Listener implements events.ChangeListener using PostgreSQL LISTEN/NOTIFY.
+type Listener struct {
+	db *pgxpool.Pool
+}
+
+// NewListener creates a new PostgreSQL change listener.
+func NewListener(db *pgxpool.Pool) *Listener {
+	return &Listener{db: db}
+}
+
+// ListenEventChanges calls fn for every committed change of the events table until
+// ctx is cancelled or the connection fails. The connection is taken out of the pool
+// for the whole time, so the LISTEN never leaks into other queries.
+func (l *Listener) ListenEventChanges(ctx context.Context, fn func(events.EventChange)) error {
+	pooled, err := l.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+eventsChangedChannel); err != nil {
+		return fmt.Errorf("listen %s: %w", eventsChangedChannel, err)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("wait for notification: %w", err)
+		}
+
+		var change events.EventChange
+		if err := json.Unmarshal([]byte(notification.Payload), &change); err != nil {
+			slog.ErrorContext(ctx, "invalid events_changed payload", "payload", notification.Payload, "error", err)
+			continue
+		}
+		fn(change)
+	}
+}
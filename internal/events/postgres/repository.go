@@ -3,8 +3,12 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/bissquit/incident-garden/internal/domain"
 	"github.com/bissquit/incident-garden/internal/events"
@@ -13,6 +17,9 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// escalationUserEmail identifies the user created by migration 000031.
+const escalationUserEmail = "escalation@incident-garden.local"
+
 // querier is an interface for database operations that both *pgxpool.Pool and pgx.Tx implement.
 type querier interface {
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
@@ -35,9 +42,16 @@ func (r *Repository) CreateEvent(ctx context.Context, event *domain.Event) error
 		INSERT INTO events (
 			title, type, status, severity, description,
 			started_at, resolved_at, scheduled_start_at, scheduled_end_at,
-			notify_subscribers, template_id, created_by
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-		RETURNING id, created_at, updated_at
+			notify_subscribers, template_id, created_by,
+			recurrence_rule, recurrence_end_date, parent_event_id, impact, oncall_team
+		) VALUES (
+			$1, $2, $3, $4, $5,
+			-- Without an explicit start an active event starts now; scheduled maintenance has not started yet
+			COALESCE($6::timestamp, CASE WHEN $3 <> 'scheduled' THEN NOW()::timestamp END),
+			$7, $8, $9, $10, $11, $12,
+			NULLIF($13, ''), $14, $15, $16, $17
+		)
+		RETURNING id, created_at, updated_at, started_at
 	`
 	err := r.db.QueryRow(ctx, query,
 		event.Title,
@@ -52,7 +66,12 @@ func (r *Repository) CreateEvent(ctx context.Context, event *domain.Event) error
 		event.NotifySubscribers,
 		event.TemplateID,
 		event.CreatedBy,
-	).Scan(&event.ID, &event.CreatedAt, &event.UpdatedAt)
+		event.RecurrenceRule,
+		event.RecurrenceEndDate,
+		event.ParentEventID,
+		event.Impact,
+		event.OnCallTeam,
+	).Scan(&event.ID, &event.CreatedAt, &event.UpdatedAt, &event.StartedAt)
 
 	if err != nil {
 		return fmt.Errorf("create event: %w", err)
@@ -64,9 +83,10 @@ func (r *Repository) CreateEvent(ctx context.Context, event *domain.Event) error
 func (r *Repository) GetEvent(ctx context.Context, id string) (*domain.Event, error) {
 	query := `
 		SELECT
-			id, title, type, status, severity, description,
+			id, title, type, status, severity, description, impact, oncall_team,
 			started_at, resolved_at, scheduled_start_at, scheduled_end_at,
-			notify_subscribers, template_id, created_by, created_at, updated_at
+			notify_subscribers, template_id, created_by, created_at, updated_at,
+			COALESCE(recurrence_rule, ''), recurrence_end_date, parent_event_id
 		FROM events
 		WHERE id = $1
 	`
@@ -78,6 +98,8 @@ func (r *Repository) GetEvent(ctx context.Context, id string) (*domain.Event, er
 		&event.Status,
 		&event.Severity,
 		&event.Description,
+		&event.Impact,
+		&event.OnCallTeam,
 		&event.StartedAt,
 		&event.ResolvedAt,
 		&event.ScheduledStartAt,
@@ -87,6 +109,9 @@ func (r *Repository) GetEvent(ctx context.Context, id string) (*domain.Event, er
 		&event.CreatedBy,
 		&event.CreatedAt,
 		&event.UpdatedAt,
+		&event.RecurrenceRule,
+		&event.RecurrenceEndDate,
+		&event.ParentEventID,
 	)
 
 	if err != nil {
@@ -111,30 +136,73 @@ func (r *Repository) GetEvent(ctx context.Context, id string) (*domain.Event, er
 	return &event, nil
 }
 
-// ListEvents retrieves events with optional filters.
-func (r *Repository) ListEvents(ctx context.Context, filters events.EventFilters) ([]*domain.Event, error) {
+// GetEventForUpdateTx retrieves an event by ID within a transaction and locks its row
+// until the transaction ends, so concurrent status updates are serialized.
+func (r *Repository) GetEventForUpdateTx(ctx context.Context, tx pgx.Tx, id string) (*domain.Event, error) {
 	query := `
-		SELECT 
-			id, title, type, status, severity, description,
+		SELECT
+			id, title, type, status, severity, description, impact, oncall_team,
 			started_at, resolved_at, scheduled_start_at, scheduled_end_at,
-			notify_subscribers, template_id, created_by, created_at, updated_at
+			notify_subscribers, template_id, created_by, created_at, updated_at,
+			COALESCE(recurrence_rule, ''), recurrence_end_date, parent_event_id
 		FROM events
-		WHERE 1=1
+		WHERE id = $1
+		FOR UPDATE
 	`
-	args := []interface{}{}
-	argNum := 1
+	var event domain.Event
+	err := tx.QueryRow(ctx, query, id).Scan(
+		&event.ID,
+		&event.Title,
+		&event.Type,
+		&event.Status,
+		&event.Severity,
+		&event.Description,
+		&event.Impact,
+		&event.OnCallTeam,
+		&event.StartedAt,
+		&event.ResolvedAt,
+		&event.ScheduledStartAt,
+		&event.ScheduledEndAt,
+		&event.NotifySubscribers,
+		&event.TemplateID,
+		&event.CreatedBy,
+		&event.CreatedAt,
+		&event.UpdatedAt,
+		&event.RecurrenceRule,
+		&event.RecurrenceEndDate,
+		&event.ParentEventID,
+	)
 
-	if filters.Type != nil {
-		query += fmt.Sprintf(" AND type = $%d", argNum)
-		args = append(args, *filters.Type)
-		argNum++
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, events.ErrEventNotFound
+		}
+		return nil, fmt.Errorf("get event for update: %w", err)
 	}
 
-	if filters.Status != nil {
-		query += fmt.Sprintf(" AND status = $%d", argNum)
-		args = append(args, *filters.Status)
-		argNum++
+	serviceIDs, err := r.GetEventServiceIDsTx(ctx, tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get event services: %w", err)
 	}
+	event.ServiceIDs = serviceIDs
+
+	return &event, nil
+}
+
+// ListEvents retrieves events with optional filters.
+func (r *Repository) ListEvents(ctx context.Context, filters events.EventFilters) ([]*domain.Event, error) {
+	where, args := eventFiltersClause(filters)
+	query := `
+		SELECT 
+			id, title, type, status, severity, description, impact, oncall_team,
+			started_at, resolved_at, scheduled_start_at, scheduled_end_at,
+			notify_subscribers, template_id, created_by, created_at, updated_at,
+			COALESCE(recurrence_rule, ''), recurrence_end_date, parent_event_id,
+			EXISTS (` + publishedPostmortemQuery + `)
+		FROM events
+		WHERE 1=1
+	` + where
+	argNum := len(args) + 1
 
 	query += " ORDER BY created_at DESC"
 
@@ -165,6 +233,8 @@ func (r *Repository) ListEvents(ctx context.Context, filters events.EventFilters
 			&event.Status,
 			&event.Severity,
 			&event.Description,
+			&event.Impact,
+			&event.OnCallTeam,
 			&event.StartedAt,
 			&event.ResolvedAt,
 			&event.ScheduledStartAt,
@@ -174,6 +244,10 @@ func (r *Repository) ListEvents(ctx context.Context, filters events.EventFilters
 			&event.CreatedBy,
 			&event.CreatedAt,
 			&event.UpdatedAt,
+			&event.RecurrenceRule,
+			&event.RecurrenceEndDate,
+			&event.ParentEventID,
+			&event.HasPostMortem,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan event: %w", err)
@@ -197,13 +271,173 @@ func (r *Repository) ListEvents(ctx context.Context, filters events.EventFilters
 	return eventsList, nil
 }
 
+// CountEvents returns the number of events matching filters (Limit and Offset are ignored).
+func (r *Repository) CountEvents(ctx context.Context, filters events.EventFilters) (int, error) {
+	where, args := eventFiltersClause(filters)
+	query := `SELECT COUNT(*) FROM events WHERE 1=1` + where
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count events: %w", err)
+	}
+	return count, nil
+}
+
+// publishedPostmortemQuery selects the published post-mortem of the event in the outer query,
+// matching domain.EventPostmortem.IsPublished.
+const publishedPostmortemQuery = `SELECT 1 FROM event_postmortems p WHERE p.event_id = events.id AND p.published_at <= NOW()`
+
+// eventFiltersClause builds the AND conditions for type, status, severity and search filters.
+func eventFiltersClause(filters events.EventFilters) (string, []interface{}) {
+	var clause string
+	args := []interface{}{}
+
+	if filters.Type != nil {
+		args = append(args, *filters.Type)
+		clause += fmt.Sprintf(" AND type = $%d", len(args))
+	}
+
+	if filters.Status != nil {
+		args = append(args, *filters.Status)
+		clause += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+
+	if filters.Severity != nil {
+		args = append(args, *filters.Severity)
+		clause += fmt.Sprintf(" AND severity = $%d", len(args))
+	}
+
+	if filters.Search != nil {
+		args = append(args, "%"+escapeLike(*filters.Search)+"%")
+		clause += fmt.Sprintf(" AND (title ILIKE $%d OR description ILIKE $%d)", len(args), len(args))
+	}
+
+	if filters.ServiceID != nil {
+		args = append(args, *filters.ServiceID)
+		clause += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM event_services es WHERE es.event_id = events.id AND es.service_id = $%d)", len(args))
+	}
+
+	if filters.GroupID != nil {
+		args = append(args, *filters.GroupID)
+		clause += fmt.Sprintf(` AND EXISTS (
+			SELECT 1 FROM event_services es
+			JOIN service_group_members sgm ON es.service_id = sgm.service_id
+			WHERE es.event_id = events.id AND sgm.group_id = $%d)`, len(args))
+	}
+
+	if filters.HasPostMortem != nil {
+		if *filters.HasPostMortem {
+			clause += " AND EXISTS (" + publishedPostmortemQuery + ")"
+		} else {
+			clause += " AND NOT EXISTS (" + publishedPostmortemQuery + ")"
+		}
+	}
+
+	if !filters.From.IsZero() {
+		args = append(args, filters.From)
+		clause += fmt.Sprintf(" AND created_at >= $%d", len(args))
+	}
+
+	if !filters.To.IsZero() {
+		args = append(args, filters.To)
+		clause += fmt.Sprintf(" AND created_at < $%d", len(args))
+	}
+
+	if filters.StartedFrom != nil {
+		args = append(args, *filters.StartedFrom)
+		clause += fmt.Sprintf(" AND started_at >= $%d", len(args))
+	}
+
+	if filters.StartedTo != nil {
+		args = append(args, *filters.StartedTo)
+		clause += fmt.Sprintf(" AND started_at <= $%d", len(args))
+	}
+
+	if filters.OpenOrResolvedSince != nil {
+		args = append(args, *filters.OpenOrResolvedSince)
+		clause += fmt.Sprintf(" AND (status NOT IN ('resolved', 'completed') OR resolved_at >= $%d)", len(args))
+	}
+
+	if filters.Active {
+		clause += " AND status NOT IN ('resolved', 'completed', 'scheduled')"
+	}
+
+	return clause, args
+}
+
+// ExportEvents streams events matching filters, oldest first, with affected service slugs.
+func (r *Repository) ExportEvents(ctx context.Context, filters events.EventFilters, fn func(*events.ExportRow) error) error {
+	where, args := eventFiltersClause(filters)
+	query := `
+		SELECT
+			id, title, type, status, severity, description, impact, oncall_team,
+			started_at, resolved_at, scheduled_start_at, scheduled_end_at,
+			notify_subscribers, template_id, created_by, created_at, updated_at,
+			ARRAY(
+				SELECT s.slug FROM event_services es
+				JOIN services s ON s.id = es.service_id
+				WHERE es.event_id = events.id
+				ORDER BY s.slug
+			)
+		FROM events
+		WHERE 1=1
+	` + where + " ORDER BY created_at ASC"
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("export events: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row events.ExportRow
+		event := &row.Event
+		if err := rows.Scan(
+			&event.ID,
+			&event.Title,
+			&event.Type,
+			&event.Status,
+			&event.Severity,
+			&event.Description,
+			&event.Impact,
+			&event.OnCallTeam,
+			&event.StartedAt,
+			&event.ResolvedAt,
+			&event.ScheduledStartAt,
+			&event.ScheduledEndAt,
+			&event.NotifySubscribers,
+			&event.TemplateID,
+			&event.CreatedBy,
+			&event.CreatedAt,
+			&event.UpdatedAt,
+			&row.ServiceSlugs,
+		); err != nil {
+			return fmt.Errorf("scan event: %w", err)
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate events: %w", err)
+	}
+	return nil
+}
+
+// likeEscaper escapes LIKE wildcards so search terms match literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
 // UpdateEvent updates an existing event.
 func (r *Repository) UpdateEvent(ctx context.Context, event *domain.Event) error {
 	query := `
 		UPDATE events
 		SET title = $2, status = $3, severity = $4, description = $5,
 		    resolved_at = $6, scheduled_start_at = $7, scheduled_end_at = $8,
-		    notify_subscribers = $9, updated_at = NOW()
+		    notify_subscribers = $9, impact = $10, oncall_team = $11, updated_at = NOW()
 		WHERE id = $1
 		RETURNING updated_at
 	`
@@ -217,6 +451,8 @@ func (r *Repository) UpdateEvent(ctx context.Context, event *domain.Event) error
 		event.ScheduledStartAt,
 		event.ScheduledEndAt,
 		event.NotifySubscribers,
+		event.Impact,
+		event.OnCallTeam,
 	).Scan(&event.UpdatedAt)
 
 	if err != nil {
@@ -244,16 +480,30 @@ func (r *Repository) DeleteEvent(ctx context.Context, id string) error {
 
 // CreateEventUpdate creates a new event update.
 func (r *Repository) CreateEventUpdate(ctx context.Context, update *domain.EventUpdate) error {
+	return r.createEventUpdate(ctx, r.db, update)
+}
+
+// createEventUpdate is a helper that works with both pool and transaction.
+func (r *Repository) createEventUpdate(ctx context.Context, q querier, update *domain.EventUpdate) error {
+	var changes []byte
+	if len(update.Changes) > 0 {
+		var err error
+		if changes, err = json.Marshal(update.Changes); err != nil {
+			return fmt.Errorf("marshal update changes: %w", err)
+		}
+	}
+
 	query := `
-		INSERT INTO event_updates (event_id, status, message, notify_subscribers, created_by)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO event_updates (event_id, status, message, notify_subscribers, changes, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id, created_at
 	`
-	err := r.db.QueryRow(ctx, query,
+	err := q.QueryRow(ctx, query,
 		update.EventID,
 		update.Status,
 		update.Message,
 		update.NotifySubscribers,
+		changes,
 		update.CreatedBy,
 	).Scan(&update.ID, &update.CreatedAt)
 
@@ -263,15 +513,17 @@ func (r *Repository) CreateEventUpdate(ctx context.Context, update *domain.Event
 	return nil
 }
 
-// ListEventUpdates retrieves all updates for an event.
-func (r *Repository) ListEventUpdates(ctx context.Context, eventID string) ([]*domain.EventUpdate, error) {
+// ListEventUpdates retrieves updates for an event, newest first.
+// limit 0 returns all updates (LIMIT NULL).
+func (r *Repository) ListEventUpdates(ctx context.Context, eventID string, limit, offset int) ([]*domain.EventUpdate, error) {
 	query := `
-		SELECT id, event_id, status, message, notify_subscribers, created_by, created_at
+		SELECT id, event_id, status, message, notify_subscribers, changes, created_by, created_at
 		FROM event_updates
 		WHERE event_id = $1
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, id DESC
+		LIMIT NULLIF($2, 0) OFFSET $3
 	`
-	rows, err := r.db.Query(ctx, query, eventID)
+	rows, err := r.db.Query(ctx, query, eventID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("list event updates: %w", err)
 	}
@@ -280,24 +532,94 @@ func (r *Repository) ListEventUpdates(ctx context.Context, eventID string) ([]*d
 	updates := make([]*domain.EventUpdate, 0)
 	for rows.Next() {
 		var update domain.EventUpdate
+		var changes []byte
 		err := rows.Scan(
 			&update.ID,
 			&update.EventID,
 			&update.Status,
 			&update.Message,
 			&update.NotifySubscribers,
+			&changes,
 			&update.CreatedBy,
 			&update.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan event update: %w", err)
 		}
+		if changes != nil {
+			if err := json.Unmarshal(changes, &update.Changes); err != nil {
+				return nil, fmt.Errorf("unmarshal update changes: %w", err)
+			}
+		}
 		updates = append(updates, &update)
 	}
 
 	return updates, nil
 }
 
+// CountEventUpdates returns the number of updates of an event.
+func (r *Repository) CountEventUpdates(ctx context.Context, eventID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM event_updates WHERE event_id = $1`, eventID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count event updates: %w", err)
+	}
+	return count, nil
+}
+
+// GetPostmortem retrieves the post-mortem of an event.
+func (r *Repository) GetPostmortem(ctx context.Context, eventID string) (*domain.EventPostmortem, error) {
+	query := `
+		SELECT event_id, title, body, published_at, created_by, created_at, updated_at
+		FROM event_postmortems
+		WHERE event_id = $1
+	`
+	var postmortem domain.EventPostmortem
+	err := r.db.QueryRow(ctx, query, eventID).Scan(
+		&postmortem.EventID,
+		&postmortem.Title,
+		&postmortem.Body,
+		&postmortem.PublishedAt,
+		&postmortem.CreatedBy,
+		&postmortem.CreatedAt,
+		&postmortem.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, events.ErrPostmortemNotFound
+		}
+		return nil, fmt.Errorf("get postmortem: %w", err)
+	}
+	return &postmortem, nil
+}
+
+// UpsertPostmortem creates or replaces the post-mortem of an event.
+func (r *Repository) UpsertPostmortem(ctx context.Context, postmortem *domain.EventPostmortem) error {
+	query := `
+		INSERT INTO event_postmortems (event_id, title, body, published_at, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (event_id) DO UPDATE SET
+			title = EXCLUDED.title,
+			body = EXCLUDED.body,
+			published_at = EXCLUDED.published_at,
+			updated_at = NOW()
+		RETURNING created_by, created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query,
+		postmortem.EventID,
+		postmortem.Title,
+		postmortem.Body,
+		postmortem.PublishedAt,
+		postmortem.CreatedBy,
+	).Scan(&postmortem.CreatedBy, &postmortem.CreatedAt, &postmortem.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("upsert postmortem: %w", err)
+	}
+	return nil
+}
+
 // CreateTemplate creates a new event template.
 func (r *Repository) CreateTemplate(ctx context.Context, template *domain.EventTemplate) error {
 	query := `
@@ -719,9 +1041,16 @@ func (r *Repository) CreateEventTx(ctx context.Context, tx pgx.Tx, event *domain
 		INSERT INTO events (
 			title, type, status, severity, description,
 			started_at, resolved_at, scheduled_start_at, scheduled_end_at,
-			notify_subscribers, template_id, created_by
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-		RETURNING id, created_at, updated_at
+			notify_subscribers, template_id, created_by,
+			recurrence_rule, recurrence_end_date, parent_event_id, impact, oncall_team
+		) VALUES (
+			$1, $2, $3, $4, $5,
+			-- Without an explicit start an active event starts now; scheduled maintenance has not started yet
+			COALESCE($6::timestamp, CASE WHEN $3 <> 'scheduled' THEN NOW()::timestamp END),
+			$7, $8, $9, $10, $11, $12,
+			NULLIF($13, ''), $14, $15, $16, $17
+		)
+		RETURNING id, created_at, updated_at, started_at
 	`
 	err := tx.QueryRow(ctx, query,
 		event.Title,
@@ -736,9 +1065,18 @@ func (r *Repository) CreateEventTx(ctx context.Context, tx pgx.Tx, event *domain
 		event.NotifySubscribers,
 		event.TemplateID,
 		event.CreatedBy,
-	).Scan(&event.ID, &event.CreatedAt, &event.UpdatedAt)
+		event.RecurrenceRule,
+		event.RecurrenceEndDate,
+		event.ParentEventID,
+		event.Impact,
+		event.OnCallTeam,
+	).Scan(&event.ID, &event.CreatedAt, &event.UpdatedAt, &event.StartedAt)
 
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "idx_events_parent_occurrence" {
+			return events.ErrOccurrenceExists
+		}
 		return fmt.Errorf("create event: %w", err)
 	}
 	return nil
@@ -768,24 +1106,9 @@ func (r *Repository) AssociateGroupsTx(ctx context.Context, tx pgx.Tx, eventID s
 }
 
 // CreateEventUpdateTx creates a new event update within a transaction.
+// update.Changes is stored as JSON (NULL when empty).
 func (r *Repository) CreateEventUpdateTx(ctx context.Context, tx pgx.Tx, update *domain.EventUpdate) error {
-	query := `
-		INSERT INTO event_updates (event_id, status, message, notify_subscribers, created_by)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, created_at
-	`
-	err := tx.QueryRow(ctx, query,
-		update.EventID,
-		update.Status,
-		update.Message,
-		update.NotifySubscribers,
-		update.CreatedBy,
-	).Scan(&update.ID, &update.CreatedAt)
-
-	if err != nil {
-		return fmt.Errorf("create event update: %w", err)
-	}
-	return nil
+	return r.createEventUpdate(ctx, tx, update)
 }
 
 // UpdateEventTx updates an existing event within a transaction.
@@ -794,7 +1117,7 @@ func (r *Repository) UpdateEventTx(ctx context.Context, tx pgx.Tx, event *domain
 		UPDATE events
 		SET title = $2, status = $3, severity = $4, description = $5,
 		    resolved_at = $6, scheduled_start_at = $7, scheduled_end_at = $8,
-		    notify_subscribers = $9, updated_at = NOW()
+		    notify_subscribers = $9, impact = $10, oncall_team = $11, updated_at = NOW()
 		WHERE id = $1
 		RETURNING updated_at
 	`
@@ -808,6 +1131,8 @@ func (r *Repository) UpdateEventTx(ctx context.Context, tx pgx.Tx, event *domain
 		event.ScheduledStartAt,
 		event.ScheduledEndAt,
 		event.NotifySubscribers,
+		event.Impact,
+		event.OnCallTeam,
 	).Scan(&event.UpdatedAt)
 
 	if err != nil {
@@ -871,25 +1196,6 @@ func (r *Repository) GetEventServiceIDsTx(ctx context.Context, tx pgx.Tx, eventI
 	return ids, rows.Err()
 }
 
-// HasOtherActiveEventsTx checks if a service has other active events (excluding the specified event).
-// Active events are those that affect service effective_status: NOT resolved, completed, or scheduled.
-// Scheduled maintenance is not considered active until it transitions to in_progress.
-func (r *Repository) HasOtherActiveEventsTx(ctx context.Context, tx pgx.Tx, serviceID, excludeEventID string) (bool, error) {
-	query := `
-		SELECT EXISTS(
-			SELECT 1
-			FROM event_services es
-			JOIN events e ON es.event_id = e.id
-			WHERE es.service_id = $1
-			  AND e.id != $2
-			  AND e.status NOT IN ('resolved', 'completed', 'scheduled')
-		)
-	`
-	var exists bool
-	err := tx.QueryRow(ctx, query, serviceID, excludeEventID).Scan(&exists)
-	return exists, err
-}
-
 // GetEventServiceStatusTx returns the status of a service in an event context.
 func (r *Repository) GetEventServiceStatusTx(ctx context.Context, tx pgx.Tx, eventID, serviceID string) (domain.ServiceStatus, error) {
 	query := `SELECT status FROM event_services WHERE event_id = $1 AND service_id = $2`
@@ -908,7 +1214,7 @@ func (r *Repository) GetEventServiceStatusTx(ctx context.Context, tx pgx.Tx, eve
 func (r *Repository) ListEventsByServiceID(ctx context.Context, serviceID string, filter events.ServiceEventFilter) ([]*domain.Event, error) {
 	query := `
 		SELECT
-			e.id, e.title, e.type, e.status, e.severity, e.description,
+			e.id, e.title, e.type, e.status, e.severity, e.description, e.impact, e.oncall_team,
 			e.started_at, e.resolved_at, e.scheduled_start_at, e.scheduled_end_at,
 			e.notify_subscribers, e.template_id, e.created_by,
 			e.created_at, e.updated_at
@@ -958,6 +1264,8 @@ func (r *Repository) ListEventsByServiceID(ctx context.Context, serviceID string
 			&event.Status,
 			&event.Severity,
 			&event.Description,
+			&event.Impact,
+			&event.OnCallTeam,
 			&event.StartedAt,
 			&event.ResolvedAt,
 			&event.ScheduledStartAt,
@@ -1019,6 +1327,160 @@ func (r *Repository) CountEventsByServiceID(ctx context.Context, serviceID strin
 	return count, nil
 }
 
+// ListEventsByServiceIDs returns events of several services in one query, keyed by service ID.
+// Events are ordered as in ListEventsByServiceID; Limit and Offset apply per service.
+func (r *Repository) ListEventsByServiceIDs(ctx context.Context, serviceIDs []string, filter events.ServiceEventFilter) (map[string][]*domain.Event, error) {
+	result := make(map[string][]*domain.Event, len(serviceIDs))
+	if len(serviceIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT
+			service_id, id, title, type, status, severity, description, impact, oncall_team,
+			started_at, resolved_at, scheduled_start_at, scheduled_end_at,
+			notify_subscribers, template_id, created_by,
+			created_at, updated_at, service_ids, group_ids
+		FROM (
+			SELECT
+				es.service_id::text AS service_id,
+				e.id, e.title, e.type, e.status, e.severity, e.description, e.impact, e.oncall_team,
+				e.started_at, e.resolved_at, e.scheduled_start_at, e.scheduled_end_at,
+				e.notify_subscribers, e.template_id, e.created_by,
+				e.created_at, e.updated_at,
+				ARRAY(SELECT s.service_id::text FROM event_services s WHERE s.event_id = e.id) AS service_ids,
+				ARRAY(SELECT g.group_id::text FROM event_groups g WHERE g.event_id = e.id) AS group_ids,
+				ROW_NUMBER() OVER (
+					PARTITION BY es.service_id
+					ORDER BY
+						CASE WHEN e.status NOT IN ('resolved', 'completed', 'scheduled') THEN 0 ELSE 1 END,
+						e.created_at DESC
+				) AS rn
+			FROM events e
+			JOIN event_services es ON es.event_id = e.id
+			WHERE es.service_id = ANY($1)
+	`
+
+	switch filter.Status {
+	case "active":
+		query += " AND e.status NOT IN ('resolved', 'completed', 'scheduled')"
+	case "resolved":
+		query += " AND e.status IN ('resolved', 'completed')"
+	}
+
+	query += `
+		) ranked
+		WHERE rn > $2 AND ($3 = 0 OR rn <= $2 + $3)
+		ORDER BY service_id, rn
+	`
+
+	rows, err := r.db.Query(ctx, query, serviceIDs, filter.Offset, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("list events by services: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var serviceID string
+		var event domain.Event
+		if err := rows.Scan(
+			&serviceID,
+			&event.ID,
+			&event.Title,
+			&event.Type,
+			&event.Status,
+			&event.Severity,
+			&event.Description,
+			&event.Impact,
+			&event.OnCallTeam,
+			&event.StartedAt,
+			&event.ResolvedAt,
+			&event.ScheduledStartAt,
+			&event.ScheduledEndAt,
+			&event.NotifySubscribers,
+			&event.TemplateID,
+			&event.CreatedBy,
+			&event.CreatedAt,
+			&event.UpdatedAt,
+			&event.ServiceIDs,
+			&event.GroupIDs,
+		); err != nil {
+			return nil, fmt.Errorf("scan event: %w", err)
+		}
+		result[serviceID] = append(result[serviceID], &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindOverlappingMaintenances returns scheduled or in-progress maintenances affecting any of
+// serviceIDs whose window overlaps [start, end), oldest window first.
+// Maintenances without a window are never reported.
+func (r *Repository) FindOverlappingMaintenances(ctx context.Context, serviceIDs []string, start, end time.Time, excludeEventID string) ([]*domain.Event, error) {
+	if len(serviceIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT
+			e.id, e.title, e.type, e.status, e.severity, e.description, e.impact, e.oncall_team,
+			e.started_at, e.resolved_at, e.scheduled_start_at, e.scheduled_end_at,
+			e.notify_subscribers, e.template_id, e.created_by, e.created_at, e.updated_at,
+			ARRAY(SELECT s.service_id::text FROM event_services s WHERE s.event_id = e.id) AS service_ids,
+			ARRAY(SELECT g.group_id::text FROM event_groups g WHERE g.event_id = e.id) AS group_ids
+		FROM events e
+		WHERE e.type = 'maintenance'
+		  AND e.status IN ('scheduled', 'in_progress')
+		  AND e.scheduled_start_at < $3
+		  AND e.scheduled_end_at > $2
+		  AND ($4 = '' OR e.id::text <> $4)
+		  AND EXISTS (
+			SELECT 1 FROM event_services es
+			WHERE es.event_id = e.id AND es.service_id = ANY($1)
+		  )
+		ORDER BY e.scheduled_start_at, e.created_at
+	`
+	rows, err := r.db.Query(ctx, query, serviceIDs, start, end, excludeEventID)
+	if err != nil {
+		return nil, fmt.Errorf("find overlapping maintenances: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*domain.Event
+	for rows.Next() {
+		var event domain.Event
+		if err := rows.Scan(
+			&event.ID,
+			&event.Title,
+			&event.Type,
+			&event.Status,
+			&event.Severity,
+			&event.Description,
+			&event.Impact,
+			&event.OnCallTeam,
+			&event.StartedAt,
+			&event.ResolvedAt,
+			&event.ScheduledStartAt,
+			&event.ScheduledEndAt,
+			&event.NotifySubscribers,
+			&event.TemplateID,
+			&event.CreatedBy,
+			&event.CreatedAt,
+			&event.UpdatedAt,
+			&event.ServiceIDs,
+			&event.GroupIDs,
+		); err != nil {
+			return nil, fmt.Errorf("scan event: %w", err)
+		}
+		result = append(result, &event)
+	}
+	return result, rows.Err()
+}
+
 // DeleteEventTx deletes an event within a transaction.
 // CASCADE will automatically delete: event_services, event_groups, event_updates, event_service_changes.
 func (r *Repository) DeleteEventTx(ctx context.Context, tx pgx.Tx, id string) error {
@@ -1032,3 +1494,210 @@ func (r *Repository) DeleteEventTx(ctx context.Context, tx pgx.Tx, id string) er
 	}
 	return nil
 }
+
+// ListEscalationCandidates returns investigating or identified incidents below critical
+// severity with the time their severity was last changed (or the event was created).
+func (r *Repository) ListEscalationCandidates(ctx context.Context) ([]events.EscalationCandidate, error) {
+	query := `
+		SELECT e.id, e.severity,
+		       COALESCE((
+		           SELECT MAX(u.created_at) FROM event_updates u
+		           WHERE u.event_id = e.id AND u.changes ? 'severity'
+		       ), e.created_at)
+		FROM events e
+		WHERE e.type = 'incident'
+		  AND e.status IN ('investigating', 'identified')
+		  AND e.severity IN ('minor', 'major')
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list escalation candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := make([]events.EscalationCandidate, 0)
+	for rows.Next() {
+		var c events.EscalationCandidate
+		if err := rows.Scan(&c.EventID, &c.Severity, &c.Since); err != nil {
+			return nil, fmt.Errorf("scan escalation candidate: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate escalation candidates: %w", err)
+	}
+	return candidates, nil
+}
+
+// ListRecurringEvents returns recurring maintenances whose series has not ended,
+// each with the start of its latest occurrence.
+func (r *Repository) ListRecurringEvents(ctx context.Context) ([]events.RecurringEvent, error) {
+	query := `
+		SELECT e.id, e.recurrence_rule, e.scheduled_start_at, e.recurrence_end_date,
+		       COALESCE(MAX(o.scheduled_start_at), e.scheduled_start_at)
+		FROM events e
+		LEFT JOIN events o ON o.parent_event_id = e.id
+		WHERE e.type = 'maintenance'
+		  AND e.recurrence_rule IS NOT NULL
+		  AND e.scheduled_start_at IS NOT NULL
+		  AND (e.recurrence_end_date IS NULL OR e.recurrence_end_date > NOW())
+		GROUP BY e.id
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list recurring events: %w", err)
+	}
+	defer rows.Close()
+
+	recurring := make([]events.RecurringEvent, 0)
+	for rows.Next() {
+		var e events.RecurringEvent
+		if err := rows.Scan(&e.EventID, &e.Rule, &e.FirstStartAt, &e.EndDate, &e.LastStartAt); err != nil {
+			return nil, fmt.Errorf("scan recurring event: %w", err)
+		}
+		recurring = append(recurring, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate recurring events: %w", err)
+	}
+	return recurring, nil
+}
+
+// EscalateSeverityTx raises severity within a transaction if the incident is still
+// investigating or identified at the expected severity.
+func (r *Repository) EscalateSeverityTx(ctx context.Context, tx pgx.Tx, eventID string, from, to domain.Severity) (bool, error) {
+	query := `
+		UPDATE events
+		SET severity = $3, updated_at = NOW()
+		WHERE id = $1 AND severity = $2 AND status IN ('investigating', 'identified')
+	`
+	result, err := tx.Exec(ctx, query, eventID, from, to)
+	if err != nil {
+		return false, fmt.Errorf("escalate severity: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// ReopenEventTx moves a resolved event back to investigating and clears resolved_at
+// within a transaction. Returns false if the event is not resolved.
+func (r *Repository) ReopenEventTx(ctx context.Context, tx pgx.Tx, eventID string) (bool, error) {
+	query := `
+		UPDATE events
+		SET status = 'investigating', resolved_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND status = 'resolved'
+	`
+	result, err := tx.Exec(ctx, query, eventID)
+	if err != nil {
+		return false, fmt.Errorf("reopen event: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// GetEscalationUserID returns the ID of the user that authors automatic escalation updates.
+func (r *Repository) GetEscalationUserID(ctx context.Context) (string, error) {
+	var id string
+	if err := r.db.QueryRow(ctx, `SELECT id FROM users WHERE email = $1`, escalationUserEmail).Scan(&id); err != nil {
+		return "", fmt.Errorf("get escalation user: %w", err)
+	}
+	return id, nil
+}
+
+// purgeableEventsCondition selects events of alias e resolved more than $1 seconds ago.
+const purgeableEventsCondition = `e.status IN ('resolved', 'completed') AND e.resolved_at < NOW()::timestamp - $1 * INTERVAL '1 second'`
+
+// CountPurgeableEvents counts resolved/completed events older than olderThan and their updates.
+func (r *Repository) CountPurgeableEvents(ctx context.Context, olderThan time.Duration) (events.PurgeResult, error) {
+	query := `
+		SELECT COUNT(DISTINCT e.id), COUNT(u.id)
+		FROM events e
+		LEFT JOIN event_updates u ON u.event_id = e.id
+		WHERE ` + purgeableEventsCondition
+
+	var result events.PurgeResult
+	if err := r.db.QueryRow(ctx, query, int64(olderThan.Seconds())).Scan(&result.DeletedEvents, &result.DeletedUpdates); err != nil {
+		return events.PurgeResult{}, fmt.Errorf("count purgeable events: %w", err)
+	}
+	return result, nil
+}
+
+// PurgeOldEvents deletes resolved/completed events older than olderThan.
+// Status log entries referencing them are deleted explicitly (their FK is SET NULL);
+// event_updates, event_services, event_groups and event_service_changes are deleted by CASCADE.
+func (r *Repository) PurgeOldEvents(ctx context.Context, olderThan time.Duration) (events.PurgeResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return events.PurgeResult{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	rows, err := tx.Query(ctx, `SELECT e.id FROM events e WHERE `+purgeableEventsCondition+` FOR UPDATE`, int64(olderThan.Seconds()))
+	if err != nil {
+		return events.PurgeResult{}, fmt.Errorf("select purgeable events: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return events.PurgeResult{}, fmt.Errorf("collect purgeable events: %w", err)
+	}
+	if len(ids) == 0 {
+		return events.PurgeResult{}, nil
+	}
+
+	var result events.PurgeResult
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM event_updates WHERE event_id = ANY($1)`, ids).Scan(&result.DeletedUpdates); err != nil {
+		return events.PurgeResult{}, fmt.Errorf("count event updates: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM service_status_log WHERE event_id = ANY($1)`, ids); err != nil {
+		return events.PurgeResult{}, fmt.Errorf("delete status log entries: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM events WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return events.PurgeResult{}, fmt.Errorf("delete events: %w", err)
+	}
+	result.DeletedEvents = int(tag.RowsAffected())
+
+	if err := tx.Commit(ctx); err != nil {
+		return events.PurgeResult{}, fmt.Errorf("commit: %w", err)
+	}
+	return result, nil
+}
+
+// ComputeEventStats aggregates events created in [from, to) with a single query.
+// Resolution time of an incident counts from started_at, or created_at if it has none.
+func (r *Repository) ComputeEventStats(ctx context.Context, from, to time.Time) (events.EventStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE type = 'incident'),
+			COUNT(*) FILTER (WHERE type = 'maintenance'),
+			COUNT(*) FILTER (WHERE severity = 'critical'),
+			COUNT(*) FILTER (WHERE severity = 'major'),
+			COUNT(*) FILTER (WHERE severity = 'minor'),
+			COUNT(*) FILTER (WHERE type = 'incident' AND status = 'resolved' AND resolved_at IS NOT NULL),
+			COALESCE(AVG(EXTRACT(EPOCH FROM resolved_at - COALESCE(started_at, created_at)))
+				FILTER (WHERE type = 'incident' AND status = 'resolved' AND resolved_at IS NOT NULL), 0)::float8
+		FROM events
+		WHERE created_at >= $1 AND created_at < $2
+	`
+
+	var stats events.EventStats
+	err := r.db.QueryRow(ctx, query, from.UTC(), to.UTC()).Scan(
+		&stats.Total,
+		&stats.ByType.Incident,
+		&stats.ByType.Maintenance,
+		&stats.BySeverity.Critical,
+		&stats.BySeverity.Major,
+		&stats.BySeverity.Minor,
+		&stats.ResolvedIncidents,
+		&stats.MeanTimeToResolveSeconds,
+	)
+	if err != nil {
+		return events.EventStats{}, fmt.Errorf("compute event stats: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,469 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/bissquit/incident-garden/internal/pkg/tracing"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/bissquit/incident-garden/internal/events/postgres"
+
+// TracedRepository wraps an events.Repository and records a span for every call,
+// with db.operation and db.table attributes and event.id where the event is known.
+type TracedRepository struct {
+	repo   events.Repository
+	tracer trace.Tracer
+}
+
+var _ events.Repository = (*TracedRepository)(nil)
+
+// NewTracedRepository wraps repo with tracing. Spans go to the global tracer provider.
+func NewTracedRepository(repo events.Repository) *TracedRepository {
+	return &TracedRepository{repo: repo, tracer: otel.Tracer(tracerName)}
+}
+
+// CreateEvent wraps Repository.CreateEvent in a span.
+func (r *TracedRepository) CreateEvent(ctx context.Context, event *domain.Event) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.CreateEvent", tracing.OpInsert, "events")
+	err := r.repo.CreateEvent(ctx, event)
+	tracing.End(span, err)
+	return err
+}
+
+// GetEvent wraps Repository.GetEvent in a span.
+func (r *TracedRepository) GetEvent(ctx context.Context, id string) (*domain.Event, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.GetEvent", tracing.OpSelect, "events", tracing.EventIDKey.String(id))
+	result, err := r.repo.GetEvent(ctx, id)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ListEvents wraps Repository.ListEvents in a span.
+func (r *TracedRepository) ListEvents(ctx context.Context, filters events.EventFilters) ([]*domain.Event, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.ListEvents", tracing.OpSelect, "events")
+	result, err := r.repo.ListEvents(ctx, filters)
+	tracing.End(span, err)
+	return result, err
+}
+
+// CountEvents wraps Repository.CountEvents in a span.
+func (r *TracedRepository) CountEvents(ctx context.Context, filters events.EventFilters) (int, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.CountEvents", tracing.OpSelect, "events")
+	result, err := r.repo.CountEvents(ctx, filters)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ExportEvents wraps Repository.ExportEvents in a span.
+func (r *TracedRepository) ExportEvents(ctx context.Context, filters events.EventFilters, fn func(*events.ExportRow) error) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.ExportEvents", tracing.OpSelect, "events")
+	err := r.repo.ExportEvents(ctx, filters, fn)
+	tracing.End(span, err)
+	return err
+}
+
+// UpdateEvent wraps Repository.UpdateEvent in a span.
+func (r *TracedRepository) UpdateEvent(ctx context.Context, event *domain.Event) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.UpdateEvent", tracing.OpUpdate, "events", tracing.EventIDKey.String(event.ID))
+	err := r.repo.UpdateEvent(ctx, event)
+	tracing.End(span, err)
+	return err
+}
+
+// DeleteEvent wraps Repository.DeleteEvent in a span.
+func (r *TracedRepository) DeleteEvent(ctx context.Context, id string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.DeleteEvent", tracing.OpDelete, "events", tracing.EventIDKey.String(id))
+	err := r.repo.DeleteEvent(ctx, id)
+	tracing.End(span, err)
+	return err
+}
+
+// CreateEventUpdate wraps Repository.CreateEventUpdate in a span.
+func (r *TracedRepository) CreateEventUpdate(ctx context.Context, update *domain.EventUpdate) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.CreateEventUpdate", tracing.OpInsert, "event_updates", tracing.EventIDKey.String(update.EventID))
+	err := r.repo.CreateEventUpdate(ctx, update)
+	tracing.End(span, err)
+	return err
+}
+
+// ListEventUpdates wraps Repository.ListEventUpdates in a span.
+func (r *TracedRepository) ListEventUpdates(ctx context.Context, eventID string, limit, offset int) ([]*domain.EventUpdate, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.ListEventUpdates", tracing.OpSelect, "event_updates", tracing.EventIDKey.String(eventID))
+	result, err := r.repo.ListEventUpdates(ctx, eventID, limit, offset)
+	tracing.End(span, err)
+	return result, err
+}
+
+// CountEventUpdates wraps Repository.CountEventUpdates in a span.
+func (r *TracedRepository) CountEventUpdates(ctx context.Context, eventID string) (int, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.CountEventUpdates", tracing.OpSelect, "event_updates", tracing.EventIDKey.String(eventID))
+	result, err := r.repo.CountEventUpdates(ctx, eventID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetPostmortem wraps Repository.GetPostmortem in a span.
+func (r *TracedRepository) GetPostmortem(ctx context.Context, eventID string) (*domain.EventPostmortem, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.GetPostmortem", tracing.OpSelect, "event_postmortems", tracing.EventIDKey.String(eventID))
+	result, err := r.repo.GetPostmortem(ctx, eventID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// UpsertPostmortem wraps Repository.UpsertPostmortem in a span.
+func (r *TracedRepository) UpsertPostmortem(ctx context.Context, postmortem *domain.EventPostmortem) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.UpsertPostmortem", tracing.OpInsert, "event_postmortems", tracing.EventIDKey.String(postmortem.EventID))
+	err := r.repo.UpsertPostmortem(ctx, postmortem)
+	tracing.End(span, err)
+	return err
+}
+
+// CreateTemplate wraps Repository.CreateTemplate in a span.
+func (r *TracedRepository) CreateTemplate(ctx context.Context, template *domain.EventTemplate) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.CreateTemplate", tracing.OpInsert, "event_templates")
+	err := r.repo.CreateTemplate(ctx, template)
+	tracing.End(span, err)
+	return err
+}
+
+// GetTemplate wraps Repository.GetTemplate in a span.
+func (r *TracedRepository) GetTemplate(ctx context.Context, id string) (*domain.EventTemplate, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.GetTemplate", tracing.OpSelect, "event_templates")
+	result, err := r.repo.GetTemplate(ctx, id)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetTemplateBySlug wraps Repository.GetTemplateBySlug in a span.
+func (r *TracedRepository) GetTemplateBySlug(ctx context.Context, slug string) (*domain.EventTemplate, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.GetTemplateBySlug", tracing.OpSelect, "event_templates")
+	result, err := r.repo.GetTemplateBySlug(ctx, slug)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ListTemplates wraps Repository.ListTemplates in a span.
+func (r *TracedRepository) ListTemplates(ctx context.Context) ([]*domain.EventTemplate, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.ListTemplates", tracing.OpSelect, "event_templates")
+	result, err := r.repo.ListTemplates(ctx)
+	tracing.End(span, err)
+	return result, err
+}
+
+// UpdateTemplate wraps Repository.UpdateTemplate in a span.
+func (r *TracedRepository) UpdateTemplate(ctx context.Context, template *domain.EventTemplate) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.UpdateTemplate", tracing.OpUpdate, "event_templates")
+	err := r.repo.UpdateTemplate(ctx, template)
+	tracing.End(span, err)
+	return err
+}
+
+// DeleteTemplate wraps Repository.DeleteTemplate in a span.
+func (r *TracedRepository) DeleteTemplate(ctx context.Context, id string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.DeleteTemplate", tracing.OpDelete, "event_templates")
+	err := r.repo.DeleteTemplate(ctx, id)
+	tracing.End(span, err)
+	return err
+}
+
+// AssociateServices wraps Repository.AssociateServices in a span.
+func (r *TracedRepository) AssociateServices(ctx context.Context, eventID string, serviceIDs []string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.AssociateServices", tracing.OpInsert, "event_services", tracing.EventIDKey.String(eventID))
+	err := r.repo.AssociateServices(ctx, eventID, serviceIDs)
+	tracing.End(span, err)
+	return err
+}
+
+// GetEventServiceIDs wraps Repository.GetEventServiceIDs in a span.
+func (r *TracedRepository) GetEventServiceIDs(ctx context.Context, eventID string) ([]string, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.GetEventServiceIDs", tracing.OpSelect, "event_services", tracing.EventIDKey.String(eventID))
+	result, err := r.repo.GetEventServiceIDs(ctx, eventID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetEventServices wraps Repository.GetEventServices in a span.
+func (r *TracedRepository) GetEventServices(ctx context.Context, eventID string) ([]domain.EventService, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.GetEventServices", tracing.OpSelect, "event_services", tracing.EventIDKey.String(eventID))
+	result, err := r.repo.GetEventServices(ctx, eventID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// AssociateGroups wraps Repository.AssociateGroups in a span.
+func (r *TracedRepository) AssociateGroups(ctx context.Context, eventID string, groupIDs []string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.AssociateGroups", tracing.OpInsert, "event_groups", tracing.EventIDKey.String(eventID))
+	err := r.repo.AssociateGroups(ctx, eventID, groupIDs)
+	tracing.End(span, err)
+	return err
+}
+
+// AddGroups wraps Repository.AddGroups in a span.
+func (r *TracedRepository) AddGroups(ctx context.Context, eventID string, groupIDs []string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.AddGroups", tracing.OpInsert, "event_groups", tracing.EventIDKey.String(eventID))
+	err := r.repo.AddGroups(ctx, eventID, groupIDs)
+	tracing.End(span, err)
+	return err
+}
+
+// GetEventGroups wraps Repository.GetEventGroups in a span.
+func (r *TracedRepository) GetEventGroups(ctx context.Context, eventID string) ([]string, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.GetEventGroups", tracing.OpSelect, "event_groups", tracing.EventIDKey.String(eventID))
+	result, err := r.repo.GetEventGroups(ctx, eventID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// CreateServiceChange wraps Repository.CreateServiceChange in a span.
+func (r *TracedRepository) CreateServiceChange(ctx context.Context, change *domain.EventServiceChange) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.CreateServiceChange", tracing.OpInsert, "event_service_changes", tracing.EventIDKey.String(change.EventID))
+	err := r.repo.CreateServiceChange(ctx, change)
+	tracing.End(span, err)
+	return err
+}
+
+// ListServiceChanges wraps Repository.ListServiceChanges in a span.
+func (r *TracedRepository) ListServiceChanges(ctx context.Context, eventID string) ([]*domain.EventServiceChange, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.ListServiceChanges", tracing.OpSelect, "event_service_changes", tracing.EventIDKey.String(eventID))
+	result, err := r.repo.ListServiceChanges(ctx, eventID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// BeginTx wraps Repository.BeginTx in a span.
+func (r *TracedRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.BeginTx", tracing.OpBegin, "")
+	result, err := r.repo.BeginTx(ctx)
+	tracing.End(span, err)
+	return result, err
+}
+
+// CreateEventTx wraps Repository.CreateEventTx in a span.
+func (r *TracedRepository) CreateEventTx(ctx context.Context, tx pgx.Tx, event *domain.Event) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.CreateEventTx", tracing.OpInsert, "events")
+	err := r.repo.CreateEventTx(ctx, tx, event)
+	tracing.End(span, err)
+	return err
+}
+
+// CreateEventUpdateTx wraps Repository.CreateEventUpdateTx in a span.
+func (r *TracedRepository) CreateEventUpdateTx(ctx context.Context, tx pgx.Tx, update *domain.EventUpdate) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.CreateEventUpdateTx", tracing.OpInsert, "event_updates", tracing.EventIDKey.String(update.EventID))
+	err := r.repo.CreateEventUpdateTx(ctx, tx, update)
+	tracing.End(span, err)
+	return err
+}
+
+// GetEventForUpdateTx wraps Repository.GetEventForUpdateTx in a span.
+func (r *TracedRepository) GetEventForUpdateTx(ctx context.Context, tx pgx.Tx, id string) (*domain.Event, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.GetEventForUpdateTx", tracing.OpSelect, "events", tracing.EventIDKey.String(id))
+	event, err := r.repo.GetEventForUpdateTx(ctx, tx, id)
+	tracing.End(span, err)
+	return event, err
+}
+
+// UpdateEventTx wraps Repository.UpdateEventTx in a span.
+func (r *TracedRepository) UpdateEventTx(ctx context.Context, tx pgx.Tx, event *domain.Event) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.UpdateEventTx", tracing.OpUpdate, "events", tracing.EventIDKey.String(event.ID))
+	err := r.repo.UpdateEventTx(ctx, tx, event)
+	tracing.End(span, err)
+	return err
+}
+
+// AssociateServicesTx wraps Repository.AssociateServicesTx in a span.
+func (r *TracedRepository) AssociateServicesTx(ctx context.Context, tx pgx.Tx, eventID string, serviceIDs []string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.AssociateServicesTx", tracing.OpInsert, "event_services", tracing.EventIDKey.String(eventID))
+	err := r.repo.AssociateServicesTx(ctx, tx, eventID, serviceIDs)
+	tracing.End(span, err)
+	return err
+}
+
+// AssociateServiceWithStatusTx wraps Repository.AssociateServiceWithStatusTx in a span.
+func (r *TracedRepository) AssociateServiceWithStatusTx(ctx context.Context, tx pgx.Tx, eventID, serviceID string, status domain.ServiceStatus) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.AssociateServiceWithStatusTx", tracing.OpInsert, "event_services", tracing.EventIDKey.String(eventID))
+	err := r.repo.AssociateServiceWithStatusTx(ctx, tx, eventID, serviceID, status)
+	tracing.End(span, err)
+	return err
+}
+
+// UpdateEventServiceStatusTx wraps Repository.UpdateEventServiceStatusTx in a span.
+func (r *TracedRepository) UpdateEventServiceStatusTx(ctx context.Context, tx pgx.Tx, eventID, serviceID string, status domain.ServiceStatus) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.UpdateEventServiceStatusTx", tracing.OpUpdate, "event_services", tracing.EventIDKey.String(eventID))
+	err := r.repo.UpdateEventServiceStatusTx(ctx, tx, eventID, serviceID, status)
+	tracing.End(span, err)
+	return err
+}
+
+// AssociateGroupsTx wraps Repository.AssociateGroupsTx in a span.
+func (r *TracedRepository) AssociateGroupsTx(ctx context.Context, tx pgx.Tx, eventID string, groupIDs []string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.AssociateGroupsTx", tracing.OpInsert, "event_groups", tracing.EventIDKey.String(eventID))
+	err := r.repo.AssociateGroupsTx(ctx, tx, eventID, groupIDs)
+	tracing.End(span, err)
+	return err
+}
+
+// AddGroupsTx wraps Repository.AddGroupsTx in a span.
+func (r *TracedRepository) AddGroupsTx(ctx context.Context, tx pgx.Tx, eventID string, groupIDs []string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.AddGroupsTx", tracing.OpInsert, "event_groups", tracing.EventIDKey.String(eventID))
+	err := r.repo.AddGroupsTx(ctx, tx, eventID, groupIDs)
+	tracing.End(span, err)
+	return err
+}
+
+// CreateServiceChangeTx wraps Repository.CreateServiceChangeTx in a span.
+func (r *TracedRepository) CreateServiceChangeTx(ctx context.Context, tx pgx.Tx, change *domain.EventServiceChange) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.CreateServiceChangeTx", tracing.OpInsert, "event_service_changes", tracing.EventIDKey.String(change.EventID))
+	err := r.repo.CreateServiceChangeTx(ctx, tx, change)
+	tracing.End(span, err)
+	return err
+}
+
+// IsServiceInEventTx wraps Repository.IsServiceInEventTx in a span.
+func (r *TracedRepository) IsServiceInEventTx(ctx context.Context, tx pgx.Tx, eventID, serviceID string) (bool, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.IsServiceInEventTx", tracing.OpSelect, "event_services", tracing.EventIDKey.String(eventID))
+	result, err := r.repo.IsServiceInEventTx(ctx, tx, eventID, serviceID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// RemoveServiceFromEventTx wraps Repository.RemoveServiceFromEventTx in a span.
+func (r *TracedRepository) RemoveServiceFromEventTx(ctx context.Context, tx pgx.Tx, eventID, serviceID string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.RemoveServiceFromEventTx", tracing.OpDelete, "event_services", tracing.EventIDKey.String(eventID))
+	err := r.repo.RemoveServiceFromEventTx(ctx, tx, eventID, serviceID)
+	tracing.End(span, err)
+	return err
+}
+
+// AddGroupToEventTx wraps Repository.AddGroupToEventTx in a span.
+func (r *TracedRepository) AddGroupToEventTx(ctx context.Context, tx pgx.Tx, eventID, groupID string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.AddGroupToEventTx", tracing.OpInsert, "event_groups", tracing.EventIDKey.String(eventID))
+	err := r.repo.AddGroupToEventTx(ctx, tx, eventID, groupID)
+	tracing.End(span, err)
+	return err
+}
+
+// GetEventServiceIDsTx wraps Repository.GetEventServiceIDsTx in a span.
+func (r *TracedRepository) GetEventServiceIDsTx(ctx context.Context, tx pgx.Tx, eventID string) ([]string, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.GetEventServiceIDsTx", tracing.OpSelect, "event_services", tracing.EventIDKey.String(eventID))
+	result, err := r.repo.GetEventServiceIDsTx(ctx, tx, eventID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetEventServiceStatusTx wraps Repository.GetEventServiceStatusTx in a span.
+func (r *TracedRepository) GetEventServiceStatusTx(ctx context.Context, tx pgx.Tx, eventID, serviceID string) (domain.ServiceStatus, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.GetEventServiceStatusTx", tracing.OpSelect, "event_services", tracing.EventIDKey.String(eventID))
+	result, err := r.repo.GetEventServiceStatusTx(ctx, tx, eventID, serviceID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ListEventsByServiceID wraps Repository.ListEventsByServiceID in a span.
+func (r *TracedRepository) ListEventsByServiceID(ctx context.Context, serviceID string, filter events.ServiceEventFilter) ([]*domain.Event, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.ListEventsByServiceID", tracing.OpSelect, "events")
+	result, err := r.repo.ListEventsByServiceID(ctx, serviceID, filter)
+	tracing.End(span, err)
+	return result, err
+}
+
+// CountEventsByServiceID wraps Repository.CountEventsByServiceID in a span.
+func (r *TracedRepository) CountEventsByServiceID(ctx context.Context, serviceID string, filter events.ServiceEventFilter) (int, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.CountEventsByServiceID", tracing.OpSelect, "events")
+	result, err := r.repo.CountEventsByServiceID(ctx, serviceID, filter)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ListEventsByServiceIDs wraps Repository.ListEventsByServiceIDs in a span.
+func (r *TracedRepository) ListEventsByServiceIDs(ctx context.Context, serviceIDs []string, filter events.ServiceEventFilter) (map[string][]*domain.Event, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.ListEventsByServiceIDs", tracing.OpSelect, "events")
+	result, err := r.repo.ListEventsByServiceIDs(ctx, serviceIDs, filter)
+	tracing.End(span, err)
+	return result, err
+}
+
+// FindOverlappingMaintenances wraps Repository.FindOverlappingMaintenances in a span.
+func (r *TracedRepository) FindOverlappingMaintenances(ctx context.Context, serviceIDs []string, start, end time.Time, excludeEventID string) ([]*domain.Event, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.FindOverlappingMaintenances", tracing.OpSelect, "events")
+	result, err := r.repo.FindOverlappingMaintenances(ctx, serviceIDs, start, end, excludeEventID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ListEscalationCandidates wraps Repository.ListEscalationCandidates in a span.
+func (r *TracedRepository) ListEscalationCandidates(ctx context.Context) ([]events.EscalationCandidate, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.ListEscalationCandidates", tracing.OpSelect, "events")
+	result, err := r.repo.ListEscalationCandidates(ctx)
+	tracing.End(span, err)
+	return result, err
+}
+
+// EscalateSeverityTx wraps Repository.EscalateSeverityTx in a span.
+func (r *TracedRepository) EscalateSeverityTx(ctx context.Context, tx pgx.Tx, eventID string, from, to domain.Severity) (bool, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.EscalateSeverityTx", tracing.OpUpdate, "events", tracing.EventIDKey.String(eventID))
+	result, err := r.repo.EscalateSeverityTx(ctx, tx, eventID, from, to)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ReopenEventTx wraps Repository.ReopenEventTx in a span.
+func (r *TracedRepository) ReopenEventTx(ctx context.Context, tx pgx.Tx, eventID string) (bool, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.ReopenEventTx", tracing.OpUpdate, "events", tracing.EventIDKey.String(eventID))
+	result, err := r.repo.ReopenEventTx(ctx, tx, eventID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// GetEscalationUserID wraps Repository.GetEscalationUserID in a span.
+func (r *TracedRepository) GetEscalationUserID(ctx context.Context) (string, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.GetEscalationUserID", tracing.OpSelect, "users")
+	result, err := r.repo.GetEscalationUserID(ctx)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ListRecurringEvents wraps Repository.ListRecurringEvents in a span.
+func (r *TracedRepository) ListRecurringEvents(ctx context.Context) ([]events.RecurringEvent, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.ListRecurringEvents", tracing.OpSelect, "events")
+	result, err := r.repo.ListRecurringEvents(ctx)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ComputeEventStats wraps Repository.ComputeEventStats in a span.
+func (r *TracedRepository) ComputeEventStats(ctx context.Context, from, to time.Time) (events.EventStats, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.ComputeEventStats", tracing.OpSelect, "events")
+	stats, err := r.repo.ComputeEventStats(ctx, from, to)
+	tracing.End(span, err)
+	return stats, err
+}
+
+// DeleteEventTx wraps Repository.DeleteEventTx in a span.
+func (r *TracedRepository) DeleteEventTx(ctx context.Context, tx pgx.Tx, id string) error {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.DeleteEventTx", tracing.OpDelete, "events", tracing.EventIDKey.String(id))
+	err := r.repo.DeleteEventTx(ctx, tx, id)
+	tracing.End(span, err)
+	return err
+}
+
+// CountPurgeableEvents wraps Repository.CountPurgeableEvents in a span.
+func (r *TracedRepository) CountPurgeableEvents(ctx context.Context, olderThan time.Duration) (events.PurgeResult, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.CountPurgeableEvents", tracing.OpSelect, "events")
+	result, err := r.repo.CountPurgeableEvents(ctx, olderThan)
+	tracing.End(span, err)
+	return result, err
+}
+
+// PurgeOldEvents wraps Repository.PurgeOldEvents in a span.
+func (r *TracedRepository) PurgeOldEvents(ctx context.Context, olderThan time.Duration) (events.PurgeResult, error) {
+	ctx, span := tracing.StartDBSpan(ctx, r.tracer, "events.PurgeOldEvents", tracing.OpDelete, "events")
+	result, err := r.repo.PurgeOldEvents(ctx, olderThan)
+	tracing.End(span, err)
+	return result, err
+}
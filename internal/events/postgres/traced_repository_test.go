@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/bissquit/incident-garden/internal/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// stubRepository implements only the methods used by the tests.
+type stubRepository struct {
+	events.Repository
+	getErr error
+}
+
+func (s *stubRepository) GetEvent(_ context.Context, id string) (*domain.Event, error) {
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+	return &domain.Event{ID: id}, nil
+}
+
+func (s *stubRepository) CreateEventUpdate(_ context.Context, _ *domain.EventUpdate) error {
+	return nil
+}
+
+func (s *stubRepository) ListEvents(_ context.Context, _ events.EventFilters) ([]*domain.Event, error) {
+	return []*domain.Event{{ID: "evt-1"}}, nil
+}
+
+func newTracedStub(t *testing.T, stub *stubRepository) (*TracedRepository, *tracetest.SpanRecorder) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return NewTracedRepository(stub), recorder
+}
+
+func spanAttrs(span sdktrace.ReadOnlySpan) map[attribute.Key]string {
+	result := make(map[attribute.Key]string)
+	for _, kv := range span.Attributes() {
+		result[kv.Key] = kv.Value.Emit()
+	}
+	return result
+}
+
+func TestTracedRepository_SpanAttributes(t *testing.T) {
+	tests := []struct {
+		name      string
+		call      func(ctx context.Context, repo *TracedRepository) error
+		wantSpan  string
+		wantAttrs map[attribute.Key]string
+	}{
+		{
+			name: "get event",
+			call: func(ctx context.Context, repo *TracedRepository) error {
+				_, err := repo.GetEvent(ctx, "evt-1")
+				return err
+			},
+			wantSpan: "events.GetEvent",
+			wantAttrs: map[attribute.Key]string{
+				tracing.DBSystemKey:    "postgresql",
+				tracing.DBOperationKey: "SELECT",
+				tracing.DBTableKey:     "events",
+				tracing.EventIDKey:     "evt-1",
+			},
+		},
+		{
+			name: "create event update",
+			call: func(ctx context.Context, repo *TracedRepository) error {
+				return repo.CreateEventUpdate(ctx, &domain.EventUpdate{EventID: "evt-2"})
+			},
+			wantSpan: "events.CreateEventUpdate",
+			wantAttrs: map[attribute.Key]string{
+				tracing.DBOperationKey: "INSERT",
+				tracing.DBTableKey:     "event_updates",
+				tracing.EventIDKey:     "evt-2",
+			},
+		},
+		{
+			name: "list events",
+			call: func(ctx context.Context, repo *TracedRepository) error {
+				_, err := repo.ListEvents(ctx, events.EventFilters{})
+				return err
+			},
+			wantSpan: "events.ListEvents",
+			wantAttrs: map[attribute.Key]string{
+				tracing.DBOperationKey: "SELECT",
+				tracing.DBTableKey:     "events",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, recorder := newTracedStub(t, &stubRepository{})
+
+			require.NoError(t, tt.call(context.Background(), repo))
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.wantSpan, spans[0].Name())
+			assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+			assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+			attrs := spanAttrs(spans[0])
+			for key, want := range tt.wantAttrs {
+				assert.Equal(t, want, attrs[key], key)
+			}
+		})
+	}
+}
+
+func TestTracedRepository_RecordsError(t *testing.T) {
+	repo, recorder := newTracedStub(t, &stubRepository{getErr: events.ErrEventNotFound})
+
+	_, err := repo.GetEvent(context.Background(), "evt-missing")
+	assert.True(t, errors.Is(err, events.ErrEventNotFound), "errors are passed through unchanged")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+}
+
+func TestTracedRepository_ChildOfRequestSpan(t *testing.T) {
+	repo, recorder := newTracedStub(t, &stubRepository{})
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "GET /api/v1/events/{id}")
+	_, err := repo.GetEvent(ctx, "evt-1")
+	require.NoError(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, parent.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+}
@@ -0,0 +1,192 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// RecurrenceConfig contains recurring maintenance settings.
+type RecurrenceConfig struct {
+	// LeadTime is how long before its start an occurrence is created.
+	LeadTime     time.Duration
+	PollInterval time.Duration
+}
+
+// DefaultRecurrenceConfig returns default recurrence configuration.
+func DefaultRecurrenceConfig() RecurrenceConfig {
+	return RecurrenceConfig{
+		LeadTime:     24 * time.Hour,
+		PollInterval: 5 * time.Minute,
+	}
+}
+
+// RecurrenceScheduler periodically creates the next occurrence of recurring
+// maintenances. An occurrence is a scheduled maintenance created LeadTime
+// before it starts, with the title, description, window length and services
+// of the recurring event, linked to it by parent_event_id.
+type RecurrenceScheduler struct {
+	config  RecurrenceConfig
+	repo    Repository
+	service *Service
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewRecurrenceScheduler creates a new recurrence scheduler.
+func NewRecurrenceScheduler(config RecurrenceConfig, repo Repository, service *Service) *RecurrenceScheduler {
+	return &RecurrenceScheduler{
+		config:  config,
+		repo:    repo,
+		service: service,
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Start launches the scheduler goroutine.
+func (s *RecurrenceScheduler) Start(ctx context.Context) {
+	slog.InfoContext(ctx, "starting recurrence scheduler",
+		"lead_time", s.config.LeadTime,
+		"poll_interval", s.config.PollInterval,
+	)
+
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+// Stop gracefully stops the scheduler.
+func (s *RecurrenceScheduler) Stop() {
+	close(s.stopCh)
+	s.wg.Wait()
+	slog.Info("recurrence scheduler stopped")
+}
+
+func (s *RecurrenceScheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	// Check immediately on start, an occurrence may be due already
+	s.check(ctx)
+
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.check(ctx)
+		}
+	}
+}
+
+// check creates the next occurrence of every series whose next start is within LeadTime.
+func (s *RecurrenceScheduler) check(ctx context.Context) {
+	recurring, err := s.repo.ListRecurringEvents(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list recurring events", "error", err)
+		return
+	}
+
+	now := time.Now()
+	for _, r := range recurring {
+		next, ok := nextOccurrence(r, now)
+		if !ok || next.Sub(now) > s.config.LeadTime {
+			continue
+		}
+		event, err := s.service.CreateOccurrence(ctx, r.EventID, next)
+		if errors.Is(err, ErrOccurrenceExists) {
+			continue
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to create maintenance occurrence", "event_id", r.EventID, "start", next, "error", err)
+			continue
+		}
+		slog.InfoContext(ctx, "maintenance occurrence created", "event_id", r.EventID, "occurrence_id", event.ID, "start", next)
+	}
+}
+
+// nextOccurrence returns the start of the occurrence after the latest one.
+// Occurrences missed while nothing was running are skipped rather than created
+// in the past. Returns false if the series is over or its rule is invalid.
+func nextOccurrence(r RecurringEvent, now time.Time) (time.Time, bool) {
+	rule, err := ParseRecurrenceRule(r.Rule)
+	if err != nil {
+		slog.Error("invalid stored recurrence rule", "event_id", r.EventID, "rule", r.Rule, "error", err)
+		return time.Time{}, false
+	}
+
+	after := r.LastStartAt
+	if after.Before(now) {
+		after = now
+	}
+	next := rule.Next(r.FirstStartAt, after)
+	if next.IsZero() || (r.EndDate != nil && next.After(*r.EndDate)) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// CreateOccurrence creates the occurrence of a recurring maintenance starting at start.
+// Returns ErrOccurrenceExists if it was created already, e.g. by another instance.
+func (s *Service) CreateOccurrence(ctx context.Context, parentID string, start time.Time) (*domain.Event, error) {
+	parent, err := s.repo.GetEvent(ctx, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("get recurring event: %w", err)
+	}
+	if parent.ScheduledStartAt == nil || parent.ScheduledEndAt == nil {
+		return nil, ErrRecurrenceNotSupported
+	}
+
+	services, err := s.repo.GetEventServices(ctx, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("get recurring event services: %w", err)
+	}
+	affected := make([]domain.AffectedService, 0, len(services))
+	for _, es := range services {
+		affected = append(affected, domain.AffectedService{ServiceID: es.ServiceID, Status: es.Status})
+	}
+
+	end := start.Add(parent.ScheduledEndAt.Sub(*parent.ScheduledStartAt))
+	return s.CreateEvent(ctx, CreateEventInput{
+		Title:             parent.Title,
+		Type:              domain.EventTypeMaintenance,
+		Status:            domain.EventStatusScheduled,
+		Description:       parent.Description,
+		Impact:            parent.Impact,
+		OnCallTeam:        parent.OnCallTeam,
+		ScheduledStartAt:  &start,
+		ScheduledEndAt:    &end,
+		NotifySubscribers: parent.NotifySubscribers,
+		ParentEventID:     &parent.ID,
+		AffectedServices:  affected,
+	}, parent.CreatedBy)
+}
+
+// validateRecurrence checks the recurrence fields of a new event.
+func validateRecurrence(input CreateEventInput) error {
+	if input.RecurrenceRule == "" {
+		if input.RecurrenceEndDate != nil {
+			return fmt.Errorf("%w: recurrence_end_date requires recurrence_rule", ErrInvalidRecurrenceRule)
+		}
+		return nil
+	}
+	if input.Type != domain.EventTypeMaintenance || input.ScheduledStartAt == nil || input.ScheduledEndAt == nil {
+		return ErrRecurrenceNotSupported
+	}
+	if _, err := ParseRecurrenceRule(input.RecurrenceRule); err != nil {
+		return err
+	}
+	if input.RecurrenceEndDate != nil && input.RecurrenceEndDate.Before(*input.ScheduledStartAt) {
+		return fmt.Errorf("%w: recurrence_end_date cannot be before scheduled_start_at", ErrInvalidRecurrenceRule)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package events
 
 import (
 	"context"
+	"time"
 
 	"github.com/bissquit/incident-garden/internal/domain"
 	"github.com/jackc/pgx/v5"
@@ -12,11 +13,23 @@ type Repository interface {
 	CreateEvent(ctx context.Context, event *domain.Event) error
 	GetEvent(ctx context.Context, id string) (*domain.Event, error)
 	ListEvents(ctx context.Context, filters EventFilters) ([]*domain.Event, error)
+	CountEvents(ctx context.Context, filters EventFilters) (int, error)
+	// ExportEvents calls fn for each event matching filters, oldest first,
+	// reading rows one by one. Limit and Offset are ignored.
+	ExportEvents(ctx context.Context, filters EventFilters, fn func(*ExportRow) error) error
 	UpdateEvent(ctx context.Context, event *domain.Event) error
 	DeleteEvent(ctx context.Context, id string) error
 
 	CreateEventUpdate(ctx context.Context, update *domain.EventUpdate) error
-	ListEventUpdates(ctx context.Context, eventID string) ([]*domain.EventUpdate, error)
+	// ListEventUpdates returns updates newest first; limit 0 returns all of them.
+	ListEventUpdates(ctx context.Context, eventID string, limit, offset int) ([]*domain.EventUpdate, error)
+	CountEventUpdates(ctx context.Context, eventID string) (int, error)
+
+	// GetPostmortem returns ErrPostmortemNotFound if the event has none.
+	GetPostmortem(ctx context.Context, eventID string) (*domain.EventPostmortem, error)
+	// UpsertPostmortem creates or replaces the post-mortem of an event and fills its timestamps.
+	// created_by and created_at of an existing post-mortem are kept.
+	UpsertPostmortem(ctx context.Context, postmortem *domain.EventPostmortem) error
 
 	CreateTemplate(ctx context.Context, template *domain.EventTemplate) error
 	GetTemplate(ctx context.Context, id string) (*domain.EventTemplate, error)
@@ -40,6 +53,7 @@ type Repository interface {
 	BeginTx(ctx context.Context) (pgx.Tx, error)
 	CreateEventTx(ctx context.Context, tx pgx.Tx, event *domain.Event) error
 	CreateEventUpdateTx(ctx context.Context, tx pgx.Tx, update *domain.EventUpdate) error
+	GetEventForUpdateTx(ctx context.Context, tx pgx.Tx, id string) (*domain.Event, error)
 	UpdateEventTx(ctx context.Context, tx pgx.Tx, event *domain.Event) error
 	AssociateServicesTx(ctx context.Context, tx pgx.Tx, eventID string, serviceIDs []string) error
 	AssociateServiceWithStatusTx(ctx context.Context, tx pgx.Tx, eventID, serviceID string, status domain.ServiceStatus) error
@@ -51,12 +65,42 @@ type Repository interface {
 	RemoveServiceFromEventTx(ctx context.Context, tx pgx.Tx, eventID, serviceID string) error
 	AddGroupToEventTx(ctx context.Context, tx pgx.Tx, eventID, groupID string) error
 	GetEventServiceIDsTx(ctx context.Context, tx pgx.Tx, eventID string) ([]string, error)
-	HasOtherActiveEventsTx(ctx context.Context, tx pgx.Tx, serviceID, excludeEventID string) (bool, error)
 	GetEventServiceStatusTx(ctx context.Context, tx pgx.Tx, eventID, serviceID string) (domain.ServiceStatus, error)
 
 	// Service events methods
 	ListEventsByServiceID(ctx context.Context, serviceID string, filter ServiceEventFilter) ([]*domain.Event, error)
 	CountEventsByServiceID(ctx context.Context, serviceID string, filter ServiceEventFilter) (int, error)
+	// ListEventsByServiceIDs returns events of several services in one query, keyed by service ID.
+	// Limit and Offset apply per service.
+	ListEventsByServiceIDs(ctx context.Context, serviceIDs []string, filter ServiceEventFilter) (map[string][]*domain.Event, error)
+
+	// FindOverlappingMaintenances returns scheduled or in-progress maintenances affecting any of
+	// serviceIDs whose window overlaps [start, end). Windows that only touch do not overlap.
+	// excludeEventID may be empty.
+	FindOverlappingMaintenances(ctx context.Context, serviceIDs []string, start, end time.Time, excludeEventID string) ([]*domain.Event, error)
+
+	// Automatic severity escalation
+	ListEscalationCandidates(ctx context.Context) ([]EscalationCandidate, error)
+	// EscalateSeverityTx raises severity from one level to another if the incident is still
+	// investigating or identified at that severity. Returns false if nothing was updated.
+	EscalateSeverityTx(ctx context.Context, tx pgx.Tx, eventID string, from, to domain.Severity) (bool, error)
+	GetEscalationUserID(ctx context.Context) (string, error)
+
+	// ReopenEventTx moves a resolved event back to investigating and clears resolved_at.
+	// Returns false if the event is not resolved (anymore).
+	ReopenEventTx(ctx context.Context, tx pgx.Tx, eventID string) (bool, error)
+
+	// Recurring maintenance
+	ListRecurringEvents(ctx context.Context) ([]RecurringEvent, error)
+
+	// Retention. Both select resolved/completed events resolved more than olderThan ago.
+	CountPurgeableEvents(ctx context.Context, olderThan time.Duration) (PurgeResult, error)
+	// PurgeOldEvents deletes the events and their status log entries in one transaction;
+	// updates, services, groups and service changes go by CASCADE.
+	PurgeOldEvents(ctx context.Context, olderThan time.Duration) (PurgeResult, error)
+
+	// Statistics
+	ComputeEventStats(ctx context.Context, from, to time.Time) (EventStats, error)
 
 	// DeleteEventTx deletes an event within a transaction.
 	// CASCADE will automatically delete: event_services, event_groups, event_updates, event_service_changes.
@@ -65,10 +109,74 @@ type Repository interface {
 
 // EventFilters holds filter options for listing events.
 type EventFilters struct {
-	Type   *domain.EventType
-	Status *domain.EventStatus
-	Limit  int
-	Offset int
+	Type          *domain.EventType
+	Status        *domain.EventStatus
+	Severity      *domain.Severity
+	Search        *string    // case-insensitive substring of title or description
+	ServiceID     *string    // events affecting the service
+	GroupID       *string    // events affecting any current member service of the group
+	HasPostMortem *bool      // events with (or without) a published post-mortem
+	From          time.Time  // created at or after, zero = unbounded
+	To            time.Time  // created before, zero = unbounded
+	StartedFrom   *time.Time // started at or after, nil = unbounded
+	StartedTo     *time.Time // started at or before, nil = unbounded
+	// OpenOrResolvedSince keeps events that are not resolved or completed, plus those
+	// resolved at or after it; nil = no restriction.
+	OpenOrResolvedSince *time.Time
+	Active              bool // events that are not resolved, completed or scheduled
+	Limit               int
+	Offset              int
+}
+
+// ExportRow is an event with the slugs of its affected services.
+type ExportRow struct {
+	Event        domain.Event
+	ServiceSlugs []string
+}
+
+// PurgeResult counts events and event updates deleted (or to be deleted, in a dry run) by a purge.
+type PurgeResult struct {
+	DeletedEvents  int `json:"deleted_events"`
+	DeletedUpdates int `json:"deleted_updates"`
+}
+
+// EventStats aggregates events created within a time range.
+// The mean time to resolve covers resolved incidents among them only.
+type EventStats struct {
+	Total                    int             `json:"total"`
+	ByType                   EventTypeCounts `json:"by_type"`
+	BySeverity               SeverityCounts  `json:"by_severity"`
+	ResolvedIncidents        int             `json:"resolved_incidents"`
+	MeanTimeToResolveSeconds float64         `json:"mean_time_to_resolve_seconds"` // 0 without resolved incidents
+}
+
+// EventTypeCounts counts events by type.
+type EventTypeCounts struct {
+	Incident    int `json:"incident"`
+	Maintenance int `json:"maintenance"`
+}
+
+// SeverityCounts counts events by severity; events without severity are not counted.
+type SeverityCounts struct {
+	Critical int `json:"critical"`
+	Major    int `json:"major"`
+	Minor    int `json:"minor"`
+}
+
+// EscalationCandidate is an active incident that may be escalated.
+type EscalationCandidate struct {
+	EventID  string
+	Severity domain.Severity
+	Since    time.Time // last severity change, else creation
+}
+
+// RecurringEvent is a recurring maintenance whose series has not ended.
+type RecurringEvent struct {
+	EventID      string
+	Rule         string
+	FirstStartAt time.Time  // scheduled start of the event itself
+	EndDate      *time.Time // recurrence_end_date
+	LastStartAt  time.Time  // latest occurrence, FirstStartAt if none yet
 }
 
 // ServiceEventFilter holds filters for listing events by service.
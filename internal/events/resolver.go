@@ -16,19 +16,56 @@ type GroupServiceResolver interface {
 // CatalogServiceUpdater updates service status within a transaction.
 type CatalogServiceUpdater interface {
 	UpdateServiceStatusTx(ctx context.Context, tx pgx.Tx, serviceID string, status domain.ServiceStatus) error
+	ResetServicesToOperationalTx(ctx context.Context, tx pgx.Tx, serviceIDs []string, eventID string, source domain.StatusLogSourceType, reason, createdBy string) ([]string, error)
+	GetEffectiveStatusesTx(ctx context.Context, tx pgx.Tx, serviceIDs []string) (map[string]domain.ServiceStatus, error)
 	CreateStatusLogEntryTx(ctx context.Context, tx pgx.Tx, entry *domain.ServiceStatusLogEntry) error
 	DeleteStatusLogByEventIDTx(ctx context.Context, tx pgx.Tx, eventID string) error
 	GetServiceStatus(ctx context.Context, serviceID string) (domain.ServiceStatus, error)
 	ValidateServicesExist(ctx context.Context, ids []string) (missingIDs []string, err error)
 	GetServiceName(ctx context.Context, serviceID string) (string, error)
+	GetServiceByIDWithEffectiveStatus(ctx context.Context, id string) (*domain.ServiceWithEffectiveStatus, error)
+}
+
+// UserNameResolver resolves user IDs to display names.
+// This interface is implemented by identity/postgres.Repository.
+type UserNameResolver interface {
+	ResolveUserNames(ctx context.Context, ids []string) (map[string]string, error)
+}
+
+// DependentsLister lists the services depending on a service.
+// This interface is implemented by catalog.DependencyService.
+type DependentsLister interface {
+	ListDependents(ctx context.Context, serviceID string) ([]domain.ServiceDependency, error)
+}
+
+// StatusPageSettingsReader returns the branding of the public status page.
+// This interface is implemented by admin.Service.
+type StatusPageSettingsReader interface {
+	GetStatusPageSettings(ctx context.Context) (*domain.StatusPageSettings, error)
 }
 
 // EventNotifier sends notifications about events.
 // This interface is implemented by notifications.Notifier.
 type EventNotifier interface {
 	OnEventCreated(ctx context.Context, event *domain.Event, serviceIDs []string) error
+	// PreviewEventCreated returns how many channels OnEventCreated would notify, without notifying.
+	PreviewEventCreated(ctx context.Context, event *domain.Event, serviceIDs []string) (int, error)
 	OnEventUpdated(ctx context.Context, event *domain.Event, update *domain.EventUpdate, changes interface{}) error
 	OnEventResolved(ctx context.Context, event *domain.Event, resolution interface{}) error
 	OnEventCompleted(ctx context.Context, event *domain.Event, resolution interface{}) error
 	OnEventCancelled(ctx context.Context, event *domain.Event) error
+	OnPostmortemPublished(ctx context.Context, event *domain.Event, postmortem *domain.EventPostmortem) error
+	OnServiceRecovered(ctx context.Context, service *domain.Service, fromStatus domain.ServiceStatus, event *domain.Event) error
+}
+
+// AuditRecorder records admin actions in the audit log.
+// This interface is implemented by admin.AuditLogger.
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, details map[string]interface{})
+}
+
+// AdminAlerter posts every new event to a global admin channel.
+// This interface is implemented by notifications.AdminAlerter.
+type AdminAlerter interface {
+	Alert(ctx context.Context, event *domain.Event) error
 }
@@ -0,0 +1,144 @@
+package events
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceRule is the supported subset of an iCalendar RRULE (RFC 5545):
+// FREQ=WEEKLY with optional INTERVAL, BYDAY, BYHOUR and BYMINUTE, for example
+// "FREQ=WEEKLY;BYDAY=SU;BYHOUR=2". COUNT and UNTIL are not supported, the end
+// of a series is the event's recurrence_end_date.
+type RecurrenceRule struct {
+	Interval int            // weeks between occurrences, 1 if omitted
+	Weekdays []time.Weekday // BYDAY; empty = the weekday of the first occurrence
+	Hour     *int           // BYHOUR; nil = the hour of the first occurrence
+	Minute   *int           // BYMINUTE; nil = the minute of the first occurrence
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// maxRecurrenceInterval keeps the search for the next occurrence bounded.
+const maxRecurrenceInterval = 52
+
+// ParseRecurrenceRule parses an RRULE. Errors wrap ErrInvalidRecurrenceRule.
+func ParseRecurrenceRule(s string) (*RecurrenceRule, error) {
+	rule := &RecurrenceRule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimSpace(s), "RRULE:"), ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRecurrenceRule, part)
+		}
+		key = strings.ToUpper(key)
+		if seen[key] {
+			return nil, fmt.Errorf("%w: duplicate %s", ErrInvalidRecurrenceRule, key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			if strings.ToUpper(value) != "WEEKLY" {
+				return nil, fmt.Errorf("%w: only FREQ=WEEKLY is supported", ErrInvalidRecurrenceRule)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > maxRecurrenceInterval {
+				return nil, fmt.Errorf("%w: INTERVAL must be between 1 and %d", ErrInvalidRecurrenceRule, maxRecurrenceInterval)
+			}
+			rule.Interval = n
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleWeekdays[strings.ToUpper(day)]
+				if !ok {
+					return nil, fmt.Errorf("%w: unknown BYDAY value %q", ErrInvalidRecurrenceRule, day)
+				}
+				rule.Weekdays = append(rule.Weekdays, weekday)
+			}
+		case "BYHOUR":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 || n > 23 {
+				return nil, fmt.Errorf("%w: BYHOUR must be a single hour between 0 and 23", ErrInvalidRecurrenceRule)
+			}
+			rule.Hour = &n
+		case "BYMINUTE":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 || n > 59 {
+				return nil, fmt.Errorf("%w: BYMINUTE must be a single minute between 0 and 59", ErrInvalidRecurrenceRule)
+			}
+			rule.Minute = &n
+		default:
+			return nil, fmt.Errorf("%w: %s is not supported", ErrInvalidRecurrenceRule, key)
+		}
+	}
+	if !seen["FREQ"] {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRecurrenceRule)
+	}
+	return rule, nil
+}
+
+// Next returns the first occurrence strictly after the given time. first is the
+// start of the first occurrence: it fills the parts the rule omits, and weeks
+// for INTERVAL are counted from its week (weeks start on Monday, in UTC).
+// Occurrences before first are never returned.
+func (r *RecurrenceRule) Next(first, after time.Time) time.Time {
+	first = first.UTC()
+	after = after.UTC()
+	if after.Before(first) {
+		after = first.Add(-time.Nanosecond)
+	}
+
+	hour, minute := first.Hour(), first.Minute()
+	if r.Hour != nil {
+		hour = *r.Hour
+	}
+	if r.Minute != nil {
+		minute = *r.Minute
+	}
+	weekdays := r.Weekdays
+	if len(weekdays) == 0 {
+		weekdays = []time.Weekday{first.Weekday()}
+	}
+
+	firstWeek := weekStart(first)
+	day := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, time.UTC)
+	// Within Interval+1 weeks there is always a matching day of a matching week
+	for i := 0; i <= 7*(r.Interval+1); i++ {
+		d := day.AddDate(0, 0, i)
+		weeks := int(weekStart(d).Sub(firstWeek).Hours()) / (7 * 24)
+		if weeks%r.Interval != 0 || !containsWeekday(weekdays, d.Weekday()) {
+			continue
+		}
+		occurrence := time.Date(d.Year(), d.Month(), d.Day(), hour, minute, 0, 0, time.UTC)
+		if occurrence.After(after) && !occurrence.Before(first) {
+			return occurrence
+		}
+	}
+	// Unreachable for a parsed rule
+	return time.Time{}
+}
+
+// weekStart returns midnight of the Monday of t's week.
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+func containsWeekday(weekdays []time.Weekday, day time.Weekday) bool {
+	for _, w := range weekdays {
+		if w == day {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	rule, err := ParseRecurrenceRule("FREQ=WEEKLY;INTERVAL=2;BYDAY=SA,SU;BYHOUR=2;BYMINUTE=30")
+	require.NoError(t, err)
+	assert.Equal(t, 2, rule.Interval)
+	assert.Equal(t, []time.Weekday{time.Saturday, time.Sunday}, rule.Weekdays)
+	require.NotNil(t, rule.Hour)
+	assert.Equal(t, 2, *rule.Hour)
+	require.NotNil(t, rule.Minute)
+	assert.Equal(t, 30, *rule.Minute)
+
+	rule, err = ParseRecurrenceRule("RRULE:FREQ=WEEKLY")
+	require.NoError(t, err)
+	assert.Equal(t, 1, rule.Interval)
+	assert.Empty(t, rule.Weekdays)
+	assert.Nil(t, rule.Hour)
+}
+
+func TestParseRecurrenceRule_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+	}{
+		{"empty", ""},
+		{"no freq", "BYDAY=SU"},
+		{"daily", "FREQ=DAILY"},
+		{"unsupported part", "FREQ=WEEKLY;COUNT=5"},
+		{"zero interval", "FREQ=WEEKLY;INTERVAL=0"},
+		{"unknown day", "FREQ=WEEKLY;BYDAY=XX"},
+		{"hour out of range", "FREQ=WEEKLY;BYHOUR=24"},
+		{"several hours", "FREQ=WEEKLY;BYHOUR=1,2"},
+		{"minute out of range", "FREQ=WEEKLY;BYMINUTE=60"},
+		{"duplicate part", "FREQ=WEEKLY;FREQ=WEEKLY"},
+		{"malformed", "FREQ=WEEKLY;BYDAY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRecurrenceRule(tt.rule)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidRecurrenceRule))
+		})
+	}
+}
+
+func TestRecurrenceRule_Next(t *testing.T) {
+	// Sunday 2026-03-01 02:00 UTC
+	first := time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		rule  string
+		after time.Time
+		want  time.Time
+	}{
+		{
+			name:  "weekly after first",
+			rule:  "FREQ=WEEKLY;BYDAY=SU;BYHOUR=2",
+			after: first,
+			want:  time.Date(2026, 3, 8, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "weekly mid-week",
+			rule:  "FREQ=WEEKLY;BYDAY=SU;BYHOUR=2",
+			after: time.Date(2026, 3, 11, 15, 0, 0, 0, time.UTC),
+			want:  time.Date(2026, 3, 15, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "same day before hour",
+			rule:  "FREQ=WEEKLY;BYDAY=SU;BYHOUR=2",
+			after: time.Date(2026, 3, 8, 1, 0, 0, 0, time.UTC),
+			want:  time.Date(2026, 3, 8, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "biweekly skips odd weeks",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU;BYHOUR=2",
+			after: first,
+			want:  time.Date(2026, 3, 15, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "biweekly from odd week",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU;BYHOUR=2",
+			after: time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2026, 3, 29, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "several days",
+			rule:  "FREQ=WEEKLY;BYDAY=WE,SU;BYHOUR=2;BYMINUTE=15",
+			after: first.Add(time.Hour),
+			want:  time.Date(2026, 3, 4, 2, 15, 0, 0, time.UTC),
+		},
+		{
+			name:  "omitted parts from first",
+			rule:  "FREQ=WEEKLY",
+			after: first,
+			want:  time.Date(2026, 3, 8, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "never before first",
+			rule:  "FREQ=WEEKLY;BYDAY=SU;BYHOUR=1",
+			after: first.Add(-48 * time.Hour),
+			want:  time.Date(2026, 3, 8, 1, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rule.Next(first, tt.after))
+		})
+	}
+}
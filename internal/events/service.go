@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
 	"time"
 
 	"github.com/bissquit/incident-garden/internal/domain"
@@ -12,6 +13,9 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
+// startedAtClockSkew tolerates clocks of integrations that report started_at slightly ahead of ours.
+const startedAtClockSkew = time.Minute
+
 // Service implements event business logic.
 type Service struct {
 	repo           Repository
@@ -19,16 +23,22 @@ type Service struct {
 	catalogService CatalogServiceUpdater
 	renderer       *TemplateRenderer
 	notifier       EventNotifier
+	users          UserNameResolver
+	dependents     DependentsLister
 }
 
 // NewService creates a new event service.
-func NewService(repo Repository, resolver GroupServiceResolver, catalogService CatalogServiceUpdater, notifier EventNotifier) *Service {
+// users may be nil, then created_by_name is left empty.
+// dependents may be nil, then outages don't cascade to dependent services.
+func NewService(repo Repository, resolver GroupServiceResolver, catalogService CatalogServiceUpdater, notifier EventNotifier, users UserNameResolver, dependents DependentsLister) *Service {
 	return &Service{
 		repo:           repo,
 		resolver:       resolver,
 		catalogService: catalogService,
 		renderer:       NewTemplateRenderer(),
 		notifier:       notifier,
+		users:          users,
+		dependents:     dependents,
 	}
 }
 
@@ -39,20 +49,29 @@ type CreateEventInput struct {
 	Status            domain.EventStatus
 	Severity          *domain.Severity
 	Description       string
+	Impact            string
+	OnCallTeam        string
 	StartedAt         *time.Time
 	ResolvedAt        *time.Time // For creating past events
 	ScheduledStartAt  *time.Time
 	ScheduledEndAt    *time.Time
 	NotifySubscribers bool
 	TemplateID        *string
+	RecurrenceRule    string
+	RecurrenceEndDate *time.Time
+	ParentEventID     *string // set for occurrences created by the RecurrenceScheduler
 	AffectedServices  []domain.AffectedService
 	AffectedGroups    []domain.AffectedGroup
+	StatusChange      StatusChangeContext // source of the initial status log entries
 }
 
 // CreateEventUpdateInput holds data for creating an event update.
 type CreateEventUpdateInput struct {
 	EventID           string
 	Status            domain.EventStatus
+	Severity          *domain.Severity // optional, incidents only
+	Impact            *string          // optional, "" clears
+	OnCallTeam        *string          // optional, "" clears
 	Message           string
 	NotifySubscribers bool
 	ServiceUpdates    []domain.AffectedService // Update statuses of existing services
@@ -60,6 +79,53 @@ type CreateEventUpdateInput struct {
 	AddGroups         []domain.AffectedGroup   // Add groups (expand to services)
 	RemoveServiceIDs  []string                 // Remove services from event
 	Reason            string                   // Reason for changes (audit)
+	StatusChange      StatusChangeContext      // source of the status log entries
+}
+
+// StatusChangeContext describes what triggered the service status changes of an event
+// and is recorded with every status log entry they produce. Callers set the source;
+// EventID and Reason are filled in by the service for each change.
+type StatusChangeContext struct {
+	SourceType    domain.StatusLogSourceType // event if empty
+	EventID       string
+	WebhookSource string // webhook that triggered the change, e.g. "pagerduty"
+	Reason        string
+}
+
+// withReason returns the context of a change of eventID for reason.
+func (c StatusChangeContext) withReason(eventID, reason string) StatusChangeContext {
+	c.EventID = eventID
+	c.Reason = reason
+	return c
+}
+
+func (c StatusChangeContext) sourceType() domain.StatusLogSourceType {
+	if c.SourceType == "" {
+		return domain.StatusLogSourceEvent
+	}
+	return c.SourceType
+}
+
+// reason returns the status log reason, naming the webhook for webhook-triggered changes.
+func (c StatusChangeContext) reason() string {
+	if c.WebhookSource == "" || c.Reason == "" {
+		return c.Reason
+	}
+	return fmt.Sprintf("%s (%s webhook)", c.Reason, c.WebhookSource)
+}
+
+// logEntry builds the status log entry of a service status change.
+func (c StatusChangeContext) logEntry(serviceID string, oldStatus *domain.ServiceStatus, newStatus domain.ServiceStatus, createdBy string) *domain.ServiceStatusLogEntry {
+	eventID := c.EventID
+	return &domain.ServiceStatusLogEntry{
+		ServiceID:  serviceID,
+		OldStatus:  oldStatus,
+		NewStatus:  newStatus,
+		SourceType: c.sourceType(),
+		EventID:    &eventID,
+		Reason:     c.reason(),
+		CreatedBy:  createdBy,
+	}
 }
 
 // CreateTemplateInput holds data for creating a template.
@@ -72,6 +138,81 @@ type CreateTemplateInput struct {
 
 // CreateEvent creates a new event with validation.
 func (s *Service) CreateEvent(ctx context.Context, input CreateEventInput, createdBy string) (*domain.Event, error) {
+	prepared, err := s.prepareEvent(ctx, input, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Begin transaction for atomicity
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	if err := s.createEventTx(ctx, tx, prepared, createdBy); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.afterEventCreated(ctx, prepared, createdBy)
+	return prepared.event, nil
+}
+
+// CreateEventDryRun runs CreateEvent in a transaction that is always rolled back:
+// the event is validated, its groups are expanded and it is stored, but nothing is
+// committed and no side effects run. Returns the would-be event and the number of
+// channels that would be notified, whose messages are rendered to check them.
+func (s *Service) CreateEventDryRun(ctx context.Context, input CreateEventInput, createdBy string) (*domain.Event, int, error) {
+	prepared, err := s.prepareEvent(ctx, input, createdBy)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	if err := s.createEventTx(ctx, tx, prepared, createdBy); err != nil {
+		return nil, 0, err
+	}
+
+	event := prepared.event
+	setDurations(time.Now(), event)
+
+	wouldNotify := 0
+	if s.notifier != nil && event.NotifySubscribers {
+		wouldNotify, err = s.notifier.PreviewEventCreated(ctx, event, event.ServiceIDs)
+		if err != nil {
+			return nil, 0, fmt.Errorf("preview notifications: %w", err)
+		}
+	}
+	return event, wouldNotify, nil
+}
+
+// preparedEvent is a validated event ready to be stored.
+type preparedEvent struct {
+	input           CreateEventInput
+	event           *domain.Event
+	serviceStatuses map[string]domain.ServiceStatus // groups expanded, explicit services win
+}
+
+// prepareEvent validates input and resolves the statuses of all affected services.
+// It reads but doesn't write, so a whole batch can be checked before storing any of it.
+func (s *Service) prepareEvent(ctx context.Context, input CreateEventInput, createdBy string) (*preparedEvent, error) {
 	if !input.Type.IsValid() {
 		return nil, fmt.Errorf("invalid event type: %s", input.Type)
 	}
@@ -90,6 +231,15 @@ func (s *Service) CreateEvent(ctx context.Context, input CreateEventInput, creat
 		}
 	}
 
+	if err := validateRecurrence(input); err != nil {
+		return nil, err
+	}
+
+	// Imported post-mortems carry their real start; only the future is rejected
+	if input.StartedAt != nil && input.StartedAt.After(time.Now().Add(startedAtClockSkew)) {
+		return nil, ErrStartedAtInFuture
+	}
+
 	// Validate affected entities exist before starting transaction
 	if err := s.validateAffectedEntities(ctx, input.AffectedServices, input.AffectedGroups); err != nil {
 		return nil, err
@@ -120,62 +270,69 @@ func (s *Service) CreateEvent(ctx context.Context, input CreateEventInput, creat
 		serviceStatuses[as.ServiceID] = as.Status
 	}
 
+	if input.Type == domain.EventTypeMaintenance && !input.Status.IsResolved() {
+		ids := make([]string, 0, len(serviceStatuses))
+		for serviceID := range serviceStatuses {
+			ids = append(ids, serviceID)
+		}
+		if err := s.checkMaintenanceOverlap(ctx, ids, input.ScheduledStartAt, input.ScheduledEndAt, ""); err != nil {
+			return nil, err
+		}
+	}
+
 	event := &domain.Event{
 		Title:             input.Title,
 		Type:              input.Type,
 		Status:            input.Status,
 		Severity:          input.Severity,
 		Description:       input.Description,
+		Impact:            input.Impact,
+		OnCallTeam:        input.OnCallTeam,
 		StartedAt:         input.StartedAt,
 		ResolvedAt:        input.ResolvedAt,
 		ScheduledStartAt:  input.ScheduledStartAt,
 		ScheduledEndAt:    input.ScheduledEndAt,
 		NotifySubscribers: input.NotifySubscribers,
 		TemplateID:        input.TemplateID,
+		RecurrenceRule:    input.RecurrenceRule,
+		RecurrenceEndDate: input.RecurrenceEndDate,
+		ParentEventID:     input.ParentEventID,
 		CreatedBy:         createdBy,
 		GroupIDs:          groupIDs,
 	}
 
-	// Begin transaction for atomicity
-	tx, err := s.repo.BeginTx(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("begin transaction: %w", err)
-	}
-	defer func() {
-		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
-			slog.Error("failed to rollback transaction", "error", err)
-		}
-	}()
+	return &preparedEvent{input: input, event: event, serviceStatuses: serviceStatuses}, nil
+}
+
+// createEventTx stores a prepared event with its services, status log and initial changes.
+// Events created resolved or completed are history: they don't write status log entries,
+// which would be dated now and count as downtime from now on.
+func (s *Service) createEventTx(ctx context.Context, tx pgx.Tx, prepared *preparedEvent, createdBy string) error {
+	event, input := prepared.event, prepared.input
 
 	if err := s.repo.CreateEventTx(ctx, tx, event); err != nil {
-		return nil, fmt.Errorf("create event: %w", err)
+		return fmt.Errorf("create event: %w", err)
 	}
 
-	// Associate services with their statuses and log status changes
-	serviceIDs := make([]string, 0, len(serviceStatuses))
-	for serviceID, status := range serviceStatuses {
-		// Get current service status for audit log
-		currentStatus, err := s.catalogService.GetServiceStatus(ctx, serviceID)
-		if err != nil {
-			return nil, fmt.Errorf("get current status for %s: %w", serviceID, err)
-		}
+	change := input.StatusChange.withReason(event.ID, fmt.Sprintf("Event created: %s", input.Title))
 
+	// Associate services with their statuses and log status changes
+	serviceIDs := make([]string, 0, len(prepared.serviceStatuses))
+	for serviceID, status := range prepared.serviceStatuses {
 		if err := s.repo.AssociateServiceWithStatusTx(ctx, tx, event.ID, serviceID, status); err != nil {
-			return nil, fmt.Errorf("associate service %s: %w", serviceID, err)
+			return fmt.Errorf("associate service %s: %w", serviceID, err)
 		}
 
-		// Log status change
-		logEntry := &domain.ServiceStatusLogEntry{
-			ServiceID:  serviceID,
-			OldStatus:  &currentStatus,
-			NewStatus:  status,
-			SourceType: domain.StatusLogSourceEvent,
-			EventID:    &event.ID,
-			Reason:     fmt.Sprintf("Event created: %s", input.Title),
-			CreatedBy:  createdBy,
-		}
-		if err := s.catalogService.CreateStatusLogEntryTx(ctx, tx, logEntry); err != nil {
-			return nil, fmt.Errorf("create status log: %w", err)
+		// Log status change from the current service status
+		if !event.Status.IsResolved() {
+			currentStatus, err := s.catalogService.GetServiceStatus(ctx, serviceID)
+			if err != nil {
+				return fmt.Errorf("get current status for %s: %w", serviceID, err)
+			}
+			logEntry := change.logEntry(serviceID, &currentStatus, status, createdBy)
+			if err := s.catalogService.CreateStatusLogEntryTx(ctx, tx, logEntry); err != nil {
+				return fmt.Errorf("create status log: %w", err)
+			}
 		}
 
 		serviceIDs = append(serviceIDs, serviceID)
@@ -183,41 +340,121 @@ func (s *Service) CreateEvent(ctx context.Context, input CreateEventInput, creat
 	event.ServiceIDs = serviceIDs
 
 	// Save group associations
-	if len(groupIDs) > 0 {
-		if err := s.repo.AssociateGroupsTx(ctx, tx, event.ID, groupIDs); err != nil {
-			return nil, fmt.Errorf("associate groups: %w", err)
+	if len(event.GroupIDs) > 0 {
+		if err := s.repo.AssociateGroupsTx(ctx, tx, event.ID, event.GroupIDs); err != nil {
+			return fmt.Errorf("associate groups: %w", err)
 		}
 	}
 
 	// Record initial state in change history
 	if err := s.recordInitialChangesTx(ctx, tx, event.ID, input.AffectedServices, input.AffectedGroups, createdBy); err != nil {
-		return nil, fmt.Errorf("record initial changes: %w", err)
+		return fmt.Errorf("record initial changes: %w", err)
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("commit transaction: %w", err)
+	return nil
+}
+
+// afterEventCreated runs the side effects of a committed event: the dependency
+// cascade and, asynchronously, subscriber notifications.
+func (s *Service) afterEventCreated(ctx context.Context, prepared *preparedEvent, createdBy string) {
+	event := prepared.event
+	setDurations(time.Now(), event)
+
+	if event.Status.IsActive() {
+		s.cascadeMajorOutage(ctx, event, majorOutages(prepared.serviceStatuses), createdBy)
 	}
 
 	// Send notifications asynchronously
 	if s.notifier != nil && event.NotifySubscribers {
+		serviceIDs := event.ServiceIDs
 		go func() {
-			if err := s.notifier.OnEventCreated(context.Background(), event, serviceIDs); err != nil {
-				slog.Error("failed to notify on event created", "event_id", event.ID, "error", err)
+			if err := s.notifier.OnEventCreated(context.WithoutCancel(ctx), event, serviceIDs); err != nil {
+				slog.ErrorContext(ctx, "failed to notify on event created", "event_id", event.ID, "error", err)
 			}
 		}()
 	}
-
-	return event, nil
 }
 
 // GetEvent retrieves an event by ID.
 func (s *Service) GetEvent(ctx context.Context, id string) (*domain.Event, error) {
-	return s.repo.GetEvent(ctx, id)
+	event, err := s.repo.GetEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	setDurations(time.Now(), event)
+	event.CreatedByName = s.userNames(ctx, []string{event.CreatedBy})[event.CreatedBy]
+	return event, nil
 }
 
 // ListEvents retrieves events with optional filters.
 func (s *Service) ListEvents(ctx context.Context, filters EventFilters) ([]*domain.Event, error) {
-	return s.repo.ListEvents(ctx, filters)
+	eventsList, err := s.repo.ListEvents(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	setDurations(time.Now(), eventsList...)
+
+	ids := make([]string, 0, len(eventsList))
+	for _, event := range eventsList {
+		ids = append(ids, event.CreatedBy)
+	}
+	names := s.userNames(ctx, ids)
+	for _, event := range eventsList {
+		event.CreatedByName = names[event.CreatedBy]
+	}
+	return eventsList, nil
+}
+
+// ListStatusEvents returns the latest events for the public status page: open events,
+// including scheduled maintenance, and events resolved or completed within recentResolved.
+// A zero recentResolved omits resolved events.
+func (s *Service) ListStatusEvents(ctx context.Context, recentResolved time.Duration, limit int) ([]*domain.Event, error) {
+	since := time.Now().Add(-recentResolved)
+	return s.ListEvents(ctx, EventFilters{OpenOrResolvedSince: &since, Limit: limit})
+}
+
+// userNames resolves author IDs to display names with a single lookup.
+// Names are decoration: a failed lookup is logged and yields no names
+// rather than failing the read.
+func (s *Service) userNames(ctx context.Context, ids []string) map[string]string {
+	if s.users == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+
+	names, err := s.users.ResolveUserNames(ctx, unique)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to resolve user names", "count", len(unique), "error", err)
+		return nil
+	}
+	return names
+}
+
+// CountEvents returns the number of events matching filters, ignoring pagination.
+func (s *Service) CountEvents(ctx context.Context, filters EventFilters) (int, error) {
+	return s.repo.CountEvents(ctx, filters)
+}
+
+// ExportEvents calls fn for each event matching filters, oldest first,
+// without loading the whole result set. Durations are computed as in ListEvents.
+func (s *Service) ExportEvents(ctx context.Context, filters EventFilters, fn func(*ExportRow) error) error {
+	now := time.Now()
+	return s.repo.ExportEvents(ctx, filters, func(row *ExportRow) error {
+		setDurations(now, &row.Event)
+		return fn(row)
+	})
 }
 
 // AddUpdate adds an update to an event and optionally modifies service associations.
@@ -231,16 +468,40 @@ func (s *Service) AddUpdate(ctx context.Context, input CreateEventUpdateInput, c
 		return nil, ErrInvalidStatus
 	}
 
-	if event.Status.IsResolved() {
-		return nil, ErrEventAlreadyResolved
+	if err := domain.ValidateTransition(event.Type, event.Status, input.Status); err != nil {
+		return nil, err
+	}
+
+	if input.Severity != nil {
+		if event.Type != domain.EventTypeIncident {
+			return nil, ErrSeverityNotSupported
+		}
+		if !input.Severity.IsValid() {
+			return nil, fmt.Errorf("invalid severity: %s", *input.Severity)
+		}
 	}
 
 	if err := s.validateAffectedEntities(ctx, input.AddServices, input.AddGroups); err != nil {
 		return nil, err
 	}
 
-	// Save old status for notification
-	oldStatus := event.Status
+	// Services added to a maintenance must be free in its window; the event itself doesn't count
+	if event.Type == domain.EventTypeMaintenance && !input.Status.IsResolved() {
+		ids := make([]string, 0, len(input.AddServices))
+		for _, as := range input.AddServices {
+			ids = append(ids, as.ServiceID)
+		}
+		for _, ag := range input.AddGroups {
+			serviceIDs, err := s.resolver.GetGroupServices(ctx, ag.GroupID)
+			if err != nil {
+				return nil, fmt.Errorf("resolve group %s: %w", ag.GroupID, err)
+			}
+			ids = append(ids, serviceIDs...)
+		}
+		if err := s.checkMaintenanceOverlap(ctx, ids, event.ScheduledStartAt, event.ScheduledEndAt, event.ID); err != nil {
+			return nil, err
+		}
+	}
 
 	tx, err := s.repo.BeginTx(ctx)
 	if err != nil {
@@ -248,15 +509,43 @@ func (s *Service) AddUpdate(ctx context.Context, input CreateEventUpdateInput, c
 	}
 	defer func() {
 		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
-			slog.Error("failed to rollback transaction", "error", err)
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
 		}
 	}()
 
+	// Lock the event row and validate the transition again: a concurrent update, reopen or
+	// severity escalation may have changed the event since it was read above.
+	locked, err := s.repo.GetEventForUpdateTx(ctx, tx, input.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("lock event: %w", err)
+	}
+	if err := domain.ValidateTransition(locked.Type, locked.Status, input.Status); err != nil {
+		return nil, err
+	}
+	locked.GroupIDs = event.GroupIDs
+	event = locked
+
+	// Save old status for notification
+	oldStatus := event.Status
+
+	// Effective statuses before an incident resolves, to notify about services that recover.
+	// Recoveries are service notifications and don't depend on NotifySubscribers of the event.
+	trackRecoveries := s.notifier != nil &&
+		event.Type == domain.EventTypeIncident && input.Status == domain.EventStatusResolved
+	var statusesBefore map[string]domain.ServiceStatus
+	if trackRecoveries {
+		statusesBefore, err = s.catalogService.GetEffectiveStatusesTx(ctx, tx, event.ServiceIDs)
+		if err != nil {
+			return nil, fmt.Errorf("get service statuses: %w", err)
+		}
+	}
+
 	update := &domain.EventUpdate{
 		EventID:           input.EventID,
 		Status:            input.Status,
 		Message:           input.Message,
 		NotifySubscribers: input.NotifySubscribers,
+		Changes:           eventChanges(event, input),
 		CreatedBy:         createdBy,
 	}
 	if err := s.repo.CreateEventUpdateTx(ctx, tx, update); err != nil {
@@ -264,6 +553,15 @@ func (s *Service) AddUpdate(ctx context.Context, input CreateEventUpdateInput, c
 	}
 
 	event.Status = input.Status
+	if input.Severity != nil {
+		event.Severity = input.Severity
+	}
+	if input.Impact != nil {
+		event.Impact = *input.Impact
+	}
+	if input.OnCallTeam != nil {
+		event.OnCallTeam = *input.OnCallTeam
+	}
 	if input.Status.IsResolved() && event.ResolvedAt == nil {
 		now := time.Now()
 		event.ResolvedAt = &now
@@ -284,27 +582,174 @@ func (s *Service) AddUpdate(ctx context.Context, input CreateEventUpdateInput, c
 		return nil, err
 	}
 
-	if err := s.handleResolution(ctx, tx, input, createdBy); err != nil {
+	resetServiceIDs, err := s.handleResolution(ctx, tx, input, createdBy)
+	if err != nil {
 		return nil, err
 	}
 
+	var recoveries []serviceRecovery
+	if trackRecoveries {
+		recoveries, err = s.recoveredServices(ctx, tx, resetServiceIDs, statusesBefore)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("commit: %w", err)
 	}
 
+	if event.Status.IsActive() {
+		s.cascadeMajorOutage(ctx, event, s.updatedMajorOutages(ctx, input), createdBy)
+	}
+
 	// Send notifications asynchronously
-	if s.notifier != nil && input.NotifySubscribers {
+	notifyUpdate := s.notifier != nil && input.NotifySubscribers
+	if notifyUpdate || len(recoveries) > 0 {
+		notifyCtx := context.WithoutCancel(ctx)
 		go func() {
-			notifyErr := s.notifyOnUpdate(context.Background(), event, update, oldStatus, input, oldServiceStatuses)
-			if notifyErr != nil {
-				slog.Error("failed to notify on event update", "event_id", event.ID, "error", notifyErr)
+			if notifyUpdate {
+				notifyErr := s.notifyOnUpdate(notifyCtx, event, update, oldStatus, input, oldServiceStatuses)
+				if notifyErr != nil {
+					slog.ErrorContext(ctx, "failed to notify on event update", "event_id", event.ID, "error", notifyErr)
+				}
 			}
+			s.notifyServiceRecoveries(notifyCtx, event, recoveries)
 		}()
 	}
 
 	return update, nil
 }
 
+// Reopen moves a resolved incident back to investigating, clears resolved_at and
+// records an "Event reopened" update by actorID. Its services count towards effective
+// status again: the change is logged per service and major outages cascade to dependents.
+// Subscribers are not notified.
+func (s *Service) Reopen(ctx context.Context, eventID, actorID string) error {
+	event, err := s.repo.GetEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("get event: %w", err)
+	}
+
+	if event.Type != domain.EventTypeIncident {
+		return ErrReopenNotSupported
+	}
+	if event.Status != domain.EventStatusResolved {
+		return ErrEventNotReopenable
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	// Reopened concurrently, or the event changed since it was read
+	reopened, err := s.repo.ReopenEventTx(ctx, tx, eventID)
+	if err != nil {
+		return err
+	}
+	if !reopened {
+		return ErrEventNotReopenable
+	}
+
+	update := &domain.EventUpdate{
+		EventID:   eventID,
+		Status:    domain.EventStatusInvestigating,
+		Message:   "Event reopened",
+		CreatedBy: actorID,
+	}
+	if err := s.repo.CreateEventUpdateTx(ctx, tx, update); err != nil {
+		return fmt.Errorf("create update: %w", err)
+	}
+
+	serviceIDs, err := s.repo.GetEventServiceIDsTx(ctx, tx, eventID)
+	if err != nil {
+		return fmt.Errorf("get event services: %w", err)
+	}
+	change := StatusChangeContext{}.withReason(event.ID, fmt.Sprintf("Event reopened: %s", event.Title))
+	serviceStatuses := make(map[string]domain.ServiceStatus, len(serviceIDs))
+	for _, serviceID := range serviceIDs {
+		status, err := s.repo.GetEventServiceStatusTx(ctx, tx, eventID, serviceID)
+		if err != nil {
+			return fmt.Errorf("get event service status: %w", err)
+		}
+		serviceStatuses[serviceID] = status
+
+		currentStatus, err := s.catalogService.GetServiceStatus(ctx, serviceID)
+		if err != nil {
+			return fmt.Errorf("get current status for %s: %w", serviceID, err)
+		}
+		logEntry := change.logEntry(serviceID, &currentStatus, status, actorID)
+		if err := s.catalogService.CreateStatusLogEntryTx(ctx, tx, logEntry); err != nil {
+			return fmt.Errorf("create status log: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+
+	event.Status = domain.EventStatusInvestigating
+	event.ResolvedAt = nil
+	s.cascadeMajorOutage(ctx, event, majorOutages(serviceStatuses), actorID)
+
+	slog.InfoContext(ctx, "event reopened", "event_id", eventID, "actor_id", actorID)
+	return nil
+}
+
+// checkMaintenanceOverlap returns a *MaintenanceOverlapError if another scheduled or
+// in-progress maintenance of any of serviceIDs overlaps the window [start, end).
+// A maintenance without a window is not checked.
+func (s *Service) checkMaintenanceOverlap(ctx context.Context, serviceIDs []string, start, end *time.Time, excludeEventID string) error {
+	if start == nil || end == nil || len(serviceIDs) == 0 {
+		return nil
+	}
+
+	conflicts, err := s.repo.FindOverlappingMaintenances(ctx, serviceIDs, *start, *end, excludeEventID)
+	if err != nil {
+		return fmt.Errorf("find overlapping maintenances: %w", err)
+	}
+	if len(conflicts) > 0 {
+		return &MaintenanceOverlapError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// eventChanges returns the event fields an update changes, keyed by JSON field name.
+// Returns nil if nothing changes.
+func eventChanges(event *domain.Event, input CreateEventUpdateInput) map[string]domain.FieldChange {
+	var changes map[string]domain.FieldChange
+	add := func(field string, from, to interface{}) {
+		if changes == nil {
+			changes = make(map[string]domain.FieldChange)
+		}
+		changes[field] = domain.FieldChange{From: from, To: to}
+	}
+
+	if input.Status != event.Status {
+		add("status", event.Status, input.Status)
+	}
+	if input.Severity != nil && (event.Severity == nil || *event.Severity != *input.Severity) {
+		var from interface{} // null when the event had no severity
+		if event.Severity != nil {
+			from = *event.Severity
+		}
+		add("severity", from, *input.Severity)
+	}
+	if input.Impact != nil && *input.Impact != event.Impact {
+		add("impact", event.Impact, *input.Impact)
+	}
+	if input.OnCallTeam != nil && *input.OnCallTeam != event.OnCallTeam {
+		add("oncall_team", event.OnCallTeam, *input.OnCallTeam)
+	}
+	return changes
+}
+
 // processServiceChanges handles all service modifications in a single batch.
 func (s *Service) processServiceChanges(ctx context.Context, tx pgx.Tx, input CreateEventUpdateInput, createdBy string) error {
 	if !s.hasServiceChanges(input) {
@@ -312,29 +757,32 @@ func (s *Service) processServiceChanges(ctx context.Context, tx pgx.Tx, input Cr
 	}
 
 	batchID := uuid.New().String()
-	if err := s.updateExistingServiceStatuses(ctx, tx, input.EventID, input.ServiceUpdates, input.Reason, createdBy); err != nil {
+	change := input.StatusChange.withReason(input.EventID, input.Reason)
+	if err := s.updateExistingServiceStatuses(ctx, tx, change, input.ServiceUpdates, createdBy); err != nil {
 		return err
 	}
-	if err := s.addServicesToEvent(ctx, tx, input.EventID, batchID, input.AddServices, input.Reason, createdBy); err != nil {
+	if err := s.addServicesToEvent(ctx, tx, change, batchID, input.AddServices, createdBy); err != nil {
 		return err
 	}
-	if err := s.addGroupsToEvent(ctx, tx, input.EventID, batchID, input.AddGroups, input.Reason, createdBy); err != nil {
+	if err := s.addGroupsToEvent(ctx, tx, change, batchID, input.AddGroups, createdBy); err != nil {
 		return err
 	}
 	return s.removeServicesFromEvent(ctx, tx, input.EventID, batchID, input.RemoveServiceIDs, input.Reason, createdBy)
 }
 
 // handleResolution recalculates service statuses when event is resolved.
-func (s *Service) handleResolution(ctx context.Context, tx pgx.Tx, input CreateEventUpdateInput, createdBy string) error {
+// Returns the services reset to operational.
+func (s *Service) handleResolution(ctx context.Context, tx pgx.Tx, input CreateEventUpdateInput, createdBy string) ([]string, error) {
 	if !input.Status.IsResolved() {
-		return nil
+		return nil, nil
 	}
 
 	affectedServiceIDs, err := s.repo.GetEventServiceIDsTx(ctx, tx, input.EventID)
 	if err != nil {
-		return fmt.Errorf("get event services: %w", err)
+		return nil, fmt.Errorf("get event services: %w", err)
 	}
-	return s.recalculateServicesStoredStatus(ctx, tx, affectedServiceIDs, input.EventID, createdBy)
+	change := input.StatusChange.withReason(input.EventID, "Event resolved, no other active events")
+	return s.recalculateServicesStoredStatus(ctx, tx, affectedServiceIDs, change, createdBy)
 }
 
 // hasServiceChanges returns true if input contains any service modifications.
@@ -343,8 +791,9 @@ func (s *Service) hasServiceChanges(input CreateEventUpdateInput) bool {
 		len(input.AddGroups) > 0 || len(input.RemoveServiceIDs) > 0
 }
 
-// updateExistingServiceStatuses updates statuses of services already in event.
-func (s *Service) updateExistingServiceStatuses(ctx context.Context, tx pgx.Tx, eventID string, updates []domain.AffectedService, reason, createdBy string) error {
+// updateExistingServiceStatuses updates statuses of services already in the event of change.
+func (s *Service) updateExistingServiceStatuses(ctx context.Context, tx pgx.Tx, change StatusChangeContext, updates []domain.AffectedService, createdBy string) error {
+	eventID := change.EventID
 	for _, su := range updates {
 		currentEventStatus, err := s.repo.GetEventServiceStatusTx(ctx, tx, eventID, su.ServiceID)
 		if err != nil {
@@ -359,15 +808,7 @@ func (s *Service) updateExistingServiceStatuses(ctx context.Context, tx pgx.Tx,
 			return fmt.Errorf("update service %s status: %w", su.ServiceID, err)
 		}
 
-		logEntry := &domain.ServiceStatusLogEntry{
-			ServiceID:  su.ServiceID,
-			OldStatus:  &currentEventStatus,
-			NewStatus:  su.Status,
-			SourceType: domain.StatusLogSourceEvent,
-			EventID:    &eventID,
-			Reason:     reason,
-			CreatedBy:  createdBy,
-		}
+		logEntry := change.logEntry(su.ServiceID, &currentEventStatus, su.Status, createdBy)
 		if err := s.catalogService.CreateStatusLogEntryTx(ctx, tx, logEntry); err != nil {
 			return fmt.Errorf("create status log: %w", err)
 		}
@@ -375,10 +816,11 @@ func (s *Service) updateExistingServiceStatuses(ctx context.Context, tx pgx.Tx,
 	return nil
 }
 
-// addServicesToEvent adds new services to event with audit trail.
-func (s *Service) addServicesToEvent(ctx context.Context, tx pgx.Tx, eventID, batchID string, services []domain.AffectedService, reason, createdBy string) error {
+// addServicesToEvent adds new services to the event of change with audit trail.
+func (s *Service) addServicesToEvent(ctx context.Context, tx pgx.Tx, change StatusChangeContext, batchID string, services []domain.AffectedService, createdBy string) error {
+	eventID, reason := change.EventID, change.Reason
 	for _, as := range services {
-		added, err := s.associateServiceIfNotExists(ctx, tx, eventID, as.ServiceID, as.Status, reason, createdBy)
+		added, err := s.associateServiceIfNotExists(ctx, tx, change, as.ServiceID, as.Status, createdBy)
 		if err != nil {
 			return err
 		}
@@ -402,8 +844,9 @@ func (s *Service) addServicesToEvent(ctx context.Context, tx pgx.Tx, eventID, ba
 	return nil
 }
 
-// addGroupsToEvent adds groups (expanding to services) with audit trail.
-func (s *Service) addGroupsToEvent(ctx context.Context, tx pgx.Tx, eventID, batchID string, groups []domain.AffectedGroup, reason, createdBy string) error {
+// addGroupsToEvent adds groups (expanding to services) to the event of change with audit trail.
+func (s *Service) addGroupsToEvent(ctx context.Context, tx pgx.Tx, change StatusChangeContext, batchID string, groups []domain.AffectedGroup, createdBy string) error {
+	eventID, reason := change.EventID, change.Reason
 	for _, ag := range groups {
 		groupServiceIDs, err := s.resolver.GetGroupServices(ctx, ag.GroupID)
 		if err != nil {
@@ -411,7 +854,7 @@ func (s *Service) addGroupsToEvent(ctx context.Context, tx pgx.Tx, eventID, batc
 		}
 
 		for _, sid := range groupServiceIDs {
-			if _, err := s.associateServiceIfNotExists(ctx, tx, eventID, sid, ag.Status, reason, createdBy); err != nil {
+			if _, err := s.associateServiceIfNotExists(ctx, tx, change, sid, ag.Status, createdBy); err != nil {
 				return err
 			}
 		}
@@ -436,9 +879,10 @@ func (s *Service) addGroupsToEvent(ctx context.Context, tx pgx.Tx, eventID, batc
 	return nil
 }
 
-// associateServiceIfNotExists adds service to event if not already present.
+// associateServiceIfNotExists adds service to the event of change if not already present.
 // Returns true if service was added, false if it already existed.
-func (s *Service) associateServiceIfNotExists(ctx context.Context, tx pgx.Tx, eventID, serviceID string, status domain.ServiceStatus, reason, createdBy string) (bool, error) {
+func (s *Service) associateServiceIfNotExists(ctx context.Context, tx pgx.Tx, change StatusChangeContext, serviceID string, status domain.ServiceStatus, createdBy string) (bool, error) {
+	eventID := change.EventID
 	exists, err := s.repo.IsServiceInEventTx(ctx, tx, eventID, serviceID)
 	if err != nil {
 		return false, fmt.Errorf("check service in event: %w", err)
@@ -453,15 +897,7 @@ func (s *Service) associateServiceIfNotExists(ctx context.Context, tx pgx.Tx, ev
 		return false, fmt.Errorf("add service: %w", err)
 	}
 
-	logEntry := &domain.ServiceStatusLogEntry{
-		ServiceID:  serviceID,
-		OldStatus:  &currentStatus,
-		NewStatus:  status,
-		SourceType: domain.StatusLogSourceEvent,
-		EventID:    &eventID,
-		Reason:     reason,
-		CreatedBy:  createdBy,
-	}
+	logEntry := change.logEntry(serviceID, &currentStatus, status, createdBy)
 	if err := s.catalogService.CreateStatusLogEntryTx(ctx, tx, logEntry); err != nil {
 		return false, fmt.Errorf("create status log: %w", err)
 	}
@@ -495,50 +931,60 @@ func (s *Service) removeServicesFromEvent(ctx context.Context, tx pgx.Tx, eventI
 	return nil
 }
 
-// recalculateServicesStoredStatus recalculates stored status for services after event resolution.
-func (s *Service) recalculateServicesStoredStatus(ctx context.Context, tx pgx.Tx, serviceIDs []string, excludeEventID, updatedBy string) error {
-	for _, serviceID := range serviceIDs {
-		hasOther, err := s.repo.HasOtherActiveEventsTx(ctx, tx, serviceID, excludeEventID)
-		if err != nil {
-			return fmt.Errorf("check other events for %s: %w", serviceID, err)
-		}
-
-		if !hasOther {
-			// Get current status for audit log
-			var oldStatus *domain.ServiceStatus
-			if currentStatus, err := s.catalogService.GetServiceStatus(ctx, serviceID); err != nil {
-				slog.Warn("failed to get current service status for audit log",
-					"service_id", serviceID,
-					"error", err)
-				// Continue with nil old_status
-			} else {
-				oldStatus = &currentStatus
-			}
-			newStatus := domain.ServiceStatusOperational
+// recalculateServicesStoredStatus resets services without other active events to operational
+// after event resolution, in a single statement for all services of the event.
+// Services still affected by other active events keep their stored status;
+// effective_status is computed via worst-case from the remaining events.
+// Returns the services reset to operational.
+func (s *Service) recalculateServicesStoredStatus(ctx context.Context, tx pgx.Tx, serviceIDs []string, change StatusChangeContext, updatedBy string) ([]string, error) {
+	resetIDs, err := s.catalogService.ResetServicesToOperationalTx(ctx, tx, serviceIDs, change.EventID,
+		change.sourceType(), change.reason(), updatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("reset service statuses: %w", err)
+	}
+	return resetIDs, nil
+}
 
-			// No other active events → set service to operational
-			if err := s.catalogService.UpdateServiceStatusTx(ctx, tx, serviceID, newStatus); err != nil {
-				return fmt.Errorf("update service %s status: %w", serviceID, err)
-			}
+// serviceRecovery is a service whose effective status went from fromStatus to operational.
+type serviceRecovery struct {
+	serviceID  string
+	fromStatus domain.ServiceStatus
+}
 
-			// Log status change
-			logEntry := &domain.ServiceStatusLogEntry{
-				ServiceID:  serviceID,
-				OldStatus:  oldStatus,
-				NewStatus:  newStatus,
-				SourceType: domain.StatusLogSourceEvent,
-				EventID:    &excludeEventID,
-				Reason:     "Event resolved, no other active events",
-				CreatedBy:  updatedBy,
-			}
-			if err := s.catalogService.CreateStatusLogEntryTx(ctx, tx, logEntry); err != nil {
-				return fmt.Errorf("create status log: %w", err)
-			}
+// recoveredServices returns the services reset by a resolution whose effective status went
+// from non-operational (before) to operational, reading the new statuses in one query within tx.
+func (s *Service) recoveredServices(ctx context.Context, tx pgx.Tx, resetServiceIDs []string, before map[string]domain.ServiceStatus) ([]serviceRecovery, error) {
+	if len(resetServiceIDs) == 0 {
+		return nil, nil
+	}
+	after, err := s.catalogService.GetEffectiveStatusesTx(ctx, tx, resetServiceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get recovered service statuses: %w", err)
+	}
+
+	var recoveries []serviceRecovery
+	for _, id := range resetServiceIDs {
+		fromStatus, ok := before[id]
+		if !ok || fromStatus == domain.ServiceStatusOperational || after[id] != domain.ServiceStatusOperational {
+			continue
+		}
+		recoveries = append(recoveries, serviceRecovery{serviceID: id, fromStatus: fromStatus})
+	}
+	return recoveries, nil
+}
+
+// notifyServiceRecoveries sends OnServiceRecovered for the services recovered by the resolution of event.
+func (s *Service) notifyServiceRecoveries(ctx context.Context, event *domain.Event, recoveries []serviceRecovery) {
+	for _, r := range recoveries {
+		service, err := s.catalogService.GetServiceByIDWithEffectiveStatus(ctx, r.serviceID)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to get recovered service", "service_id", r.serviceID, "error", err)
+			continue
+		}
+		if err := s.notifier.OnServiceRecovered(ctx, &service.Service, r.fromStatus, event); err != nil {
+			slog.ErrorContext(ctx, "failed to notify on service recovery", "service_id", r.serviceID, "event_id", event.ID, "error", err)
 		}
-		// If there are other active events, stored status stays as-is
-		// and effective_status will be computed via worst-case from remaining events
 	}
-	return nil
 }
 
 // notifyOnUpdate sends appropriate notification based on new event status.
@@ -566,7 +1012,7 @@ func (s *Service) notifyOnUpdate(ctx context.Context, event *domain.Event, updat
 	for _, ag := range input.AddGroups {
 		serviceIDs, err := s.resolver.GetGroupServices(ctx, ag.GroupID)
 		if err != nil {
-			slog.Error("failed to resolve group services for notification", "group_id", ag.GroupID, "error", err)
+			slog.ErrorContext(ctx, "failed to resolve group services for notification", "group_id", ag.GroupID, "error", err)
 			continue
 		}
 		for _, sid := range serviceIDs {
@@ -644,7 +1090,168 @@ type eventResolution struct {
 
 // GetEventUpdates retrieves all updates for an event.
 func (s *Service) GetEventUpdates(ctx context.Context, eventID string) ([]*domain.EventUpdate, error) {
-	return s.repo.ListEventUpdates(ctx, eventID)
+	updates, err := s.repo.ListEventUpdates(ctx, eventID, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	s.setUpdateAuthorNames(ctx, updates)
+	return updates, nil
+}
+
+// ListEventUpdates returns a page of updates for an event, newest first, and the total count.
+func (s *Service) ListEventUpdates(ctx context.Context, eventID string, limit, offset int) ([]*domain.EventUpdate, int, error) {
+	updates, err := s.repo.ListEventUpdates(ctx, eventID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountEventUpdates(ctx, eventID)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.setUpdateAuthorNames(ctx, updates)
+
+	return updates, total, nil
+}
+
+// setUpdateAuthorNames fills CreatedByName of updates.
+func (s *Service) setUpdateAuthorNames(ctx context.Context, updates []*domain.EventUpdate) {
+	ids := make([]string, 0, len(updates))
+	for _, update := range updates {
+		ids = append(ids, update.CreatedBy)
+	}
+	names := s.userNames(ctx, ids)
+	for _, update := range updates {
+		update.CreatedByName = names[update.CreatedBy]
+	}
+}
+
+// PostmortemInput holds data for saving a post-mortem.
+type PostmortemInput struct {
+	EventID     string
+	Title       string
+	Body        string
+	PublishedAt *time.Time
+}
+
+// SavePostmortem creates or replaces the post-mortem of a resolved or completed event.
+// Subscribers are notified once, when the post-mortem first becomes published;
+// a post-mortem published in the future is not announced.
+func (s *Service) SavePostmortem(ctx context.Context, input PostmortemInput, createdBy string) (*domain.EventPostmortem, error) {
+	event, err := s.repo.GetEvent(ctx, input.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("get event: %w", err)
+	}
+	if !event.Status.IsResolved() {
+		return nil, ErrPostmortemEventActive
+	}
+
+	wasPublished := false
+	existing, err := s.repo.GetPostmortem(ctx, input.EventID)
+	switch {
+	case err == nil:
+		wasPublished = existing.IsPublished(time.Now())
+	case !errors.Is(err, ErrPostmortemNotFound):
+		return nil, fmt.Errorf("get postmortem: %w", err)
+	}
+
+	postmortem := &domain.EventPostmortem{
+		EventID:     input.EventID,
+		Title:       input.Title,
+		Body:        input.Body,
+		PublishedAt: input.PublishedAt,
+		CreatedBy:   createdBy,
+	}
+	if err := s.repo.UpsertPostmortem(ctx, postmortem); err != nil {
+		return nil, fmt.Errorf("save postmortem: %w", err)
+	}
+
+	if s.notifier != nil && event.NotifySubscribers && !wasPublished && postmortem.IsPublished(time.Now()) {
+		go func() {
+			if err := s.notifier.OnPostmortemPublished(context.WithoutCancel(ctx), event, postmortem); err != nil {
+				slog.ErrorContext(ctx, "failed to notify on postmortem published", "event_id", event.ID, "error", err)
+			}
+		}()
+	}
+
+	return postmortem, nil
+}
+
+// GetPublishedPostmortem returns the post-mortem of an event if it is published.
+// Drafts and post-mortems scheduled for later are reported as not found.
+func (s *Service) GetPublishedPostmortem(ctx context.Context, eventID string) (*domain.EventPostmortem, error) {
+	postmortem, err := s.repo.GetPostmortem(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !postmortem.IsPublished(time.Now()) {
+		return nil, ErrPostmortemNotFound
+	}
+	return postmortem, nil
+}
+
+// TimelineEntryType discriminates entries of an event timeline.
+type TimelineEntryType string
+
+// Timeline entry types.
+const (
+	TimelineEntryUpdate        TimelineEntryType = "update"
+	TimelineEntryServiceChange TimelineEntryType = "service_change"
+	TimelineEntryPostmortem    TimelineEntryType = "postmortem"
+)
+
+// TimelineEntry is one item of an event timeline. The field matching Type is set.
+// For a post-mortem, CreatedAt is its publication time.
+type TimelineEntry struct {
+	Type          TimelineEntryType          `json:"type"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	Update        *domain.EventUpdate        `json:"update,omitempty"`
+	ServiceChange *domain.EventServiceChange `json:"service_change,omitempty"`
+	Postmortem    *domain.EventPostmortem    `json:"postmortem,omitempty"`
+}
+
+// BuildEventTimeline merges updates, service changes and a published post-mortem (may be nil)
+// into one list sorted by created_at ascending. Entries with equal time keep the order
+// updates, service changes, post-mortem.
+func BuildEventTimeline(updates []*domain.EventUpdate, changes []*domain.EventServiceChange, postmortem *domain.EventPostmortem) []TimelineEntry {
+	timeline := make([]TimelineEntry, 0, len(updates)+len(changes)+1)
+	for _, update := range updates {
+		timeline = append(timeline, TimelineEntry{Type: TimelineEntryUpdate, CreatedAt: update.CreatedAt, Update: update})
+	}
+	for _, change := range changes {
+		timeline = append(timeline, TimelineEntry{Type: TimelineEntryServiceChange, CreatedAt: change.CreatedAt, ServiceChange: change})
+	}
+	if postmortem != nil && postmortem.PublishedAt != nil {
+		timeline = append(timeline, TimelineEntry{Type: TimelineEntryPostmortem, CreatedAt: *postmortem.PublishedAt, Postmortem: postmortem})
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].CreatedAt.Before(timeline[j].CreatedAt)
+	})
+	return timeline
+}
+
+// GetEventTimeline returns the updates, service changes and published post-mortem of an event
+// as one chronological list.
+func (s *Service) GetEventTimeline(ctx context.Context, eventID string) ([]TimelineEntry, error) {
+	if _, err := s.repo.GetEvent(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	updates, err := s.repo.ListEventUpdates(ctx, eventID, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("list updates: %w", err)
+	}
+	changes, err := s.repo.ListServiceChanges(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("list service changes: %w", err)
+	}
+	postmortem, err := s.GetPublishedPostmortem(ctx, eventID)
+	if err != nil && !errors.Is(err, ErrPostmortemNotFound) {
+		return nil, fmt.Errorf("get postmortem: %w", err)
+	}
+
+	return BuildEventTimeline(updates, changes, postmortem), nil
 }
 
 // DeleteEvent deletes an event and all associated data.
@@ -683,7 +1290,7 @@ func (s *Service) DeleteEvent(ctx context.Context, id string) error {
 	// Must be done synchronously because event_subscribers will be deleted
 	if isScheduled && s.notifier != nil && event.NotifySubscribers {
 		if err := s.notifier.OnEventCancelled(ctx, event); err != nil {
-			slog.Error("failed to notify on event cancelled", "event_id", id, "error", err)
+			slog.ErrorContext(ctx, "failed to notify on event cancelled", "event_id", id, "error", err)
 		}
 	}
 
@@ -693,7 +1300,7 @@ func (s *Service) DeleteEvent(ctx context.Context, id string) error {
 	}
 	defer func() {
 		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
-			slog.Error("failed to rollback transaction", "error", err)
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
 		}
 	}()
 
@@ -714,6 +1321,39 @@ func (s *Service) DeleteEvent(ctx context.Context, id string) error {
 	return nil
 }
 
+// PurgeOldEvents deletes resolved/completed events resolved more than olderThan ago,
+// with their updates and status log entries. No notifications are sent.
+// With dryRun nothing is deleted and the result counts what would be.
+func (s *Service) PurgeOldEvents(ctx context.Context, olderThan time.Duration, dryRun bool) (PurgeResult, error) {
+	if dryRun {
+		result, err := s.repo.CountPurgeableEvents(ctx, olderThan)
+		if err != nil {
+			return PurgeResult{}, fmt.Errorf("count purgeable events: %w", err)
+		}
+		return result, nil
+	}
+
+	result, err := s.repo.PurgeOldEvents(ctx, olderThan)
+	if err != nil {
+		return PurgeResult{}, fmt.Errorf("purge old events: %w", err)
+	}
+	slog.InfoContext(ctx, "purged old events",
+		"older_than", olderThan,
+		"deleted_events", result.DeletedEvents,
+		"deleted_updates", result.DeletedUpdates,
+	)
+	return result, nil
+}
+
+// ComputeEventStats aggregates events created in [from, to).
+func (s *Service) ComputeEventStats(ctx context.Context, from, to time.Time) (EventStats, error) {
+	stats, err := s.repo.ComputeEventStats(ctx, from, to)
+	if err != nil {
+		return EventStats{}, fmt.Errorf("compute event stats: %w", err)
+	}
+	return stats, nil
+}
+
 // CreateTemplate creates a new event template with validation.
 func (s *Service) CreateTemplate(ctx context.Context, input CreateTemplateInput) (*domain.EventTemplate, error) {
 	if !input.Type.IsValid() {
@@ -742,6 +1382,35 @@ func (s *Service) CreateTemplate(ctx context.Context, input CreateTemplateInput)
 	return template, nil
 }
 
+// CloneTemplate creates a copy of a template under a new slug.
+func (s *Service) CloneTemplate(ctx context.Context, slug, newSlug string) (*domain.EventTemplate, error) {
+	source, err := s.repo.GetTemplateBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetTemplateBySlug(ctx, newSlug)
+	if err != nil && !errors.Is(err, ErrTemplateNotFound) {
+		return nil, fmt.Errorf("check slug uniqueness: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrTemplateSlugExists
+	}
+
+	template := &domain.EventTemplate{
+		Slug:          newSlug,
+		Type:          source.Type,
+		TitleTemplate: source.TitleTemplate,
+		BodyTemplate:  source.BodyTemplate,
+	}
+
+	if err := s.repo.CreateTemplate(ctx, template); err != nil {
+		return nil, fmt.Errorf("create template: %w", err)
+	}
+
+	return template, nil
+}
+
 // GetTemplate retrieves a template by ID.
 func (s *Service) GetTemplate(ctx context.Context, id string) (*domain.EventTemplate, error) {
 	return s.repo.GetTemplate(ctx, id)
@@ -758,6 +1427,7 @@ func (s *Service) ListTemplates(ctx context.Context) ([]*domain.EventTemplate, e
 }
 
 // PreviewTemplate renders a template with provided data.
+// Returns ErrTemplateRender if the title or body cannot be rendered.
 func (s *Service) PreviewTemplate(ctx context.Context, templateSlug string, data domain.TemplateData) (string, string, error) {
 	template, err := s.repo.GetTemplateBySlug(ctx, templateSlug)
 	if err != nil {
@@ -766,12 +1436,12 @@ func (s *Service) PreviewTemplate(ctx context.Context, templateSlug string, data
 
 	title, err := s.renderer.Render(template.TitleTemplate, data)
 	if err != nil {
-		return "", "", fmt.Errorf("render title: %w", err)
+		return "", "", fmt.Errorf("%w: title: %v", ErrTemplateRender, err)
 	}
 
 	body, err := s.renderer.Render(template.BodyTemplate, data)
 	if err != nil {
-		return "", "", fmt.Errorf("render body: %w", err)
+		return "", "", fmt.Errorf("%w: body: %v", ErrTemplateRender, err)
 	}
 
 	return title, body, nil
@@ -880,6 +1550,56 @@ func (s *Service) ListEventsByServiceID(ctx context.Context, serviceID string, f
 	if err != nil {
 		return nil, 0, fmt.Errorf("count events: %w", err)
 	}
+	setDurations(time.Now(), eventsList...)
 
 	return eventsList, total, nil
 }
+
+// ListEventsByServiceIDs returns events of several services keyed by service ID, in one query.
+// Services without events are missing from the map. Limit and Offset apply per service.
+func (s *Service) ListEventsByServiceIDs(ctx context.Context, serviceIDs []string, filter ServiceEventFilter) (map[string][]*domain.Event, error) {
+	eventsByService, err := s.repo.ListEventsByServiceIDs(ctx, serviceIDs, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+
+	now := time.Now()
+	for _, eventsList := range eventsByService {
+		setDurations(now, eventsList...)
+	}
+	return eventsByService, nil
+}
+
+// setDurations fills DurationSeconds: from started_at (or creation) until resolution, or until now
+// for active events. Scheduled maintenance has no duration yet.
+// Computed here with one clock rather than in SQL.
+func setDurations(now time.Time, eventsList ...*domain.Event) {
+	for _, event := range eventsList {
+		event.DurationSeconds = eventDuration(event, now)
+	}
+}
+
+func eventDuration(event *domain.Event, now time.Time) *int64 {
+	if event.Status == domain.EventStatusScheduled {
+		return nil
+	}
+
+	start := event.CreatedAt
+	if event.StartedAt != nil {
+		start = *event.StartedAt
+	}
+
+	end := now
+	if event.Status.IsResolved() {
+		end = event.UpdatedAt
+		if event.ResolvedAt != nil {
+			end = *event.ResolvedAt
+		}
+	}
+
+	seconds := int64(end.Sub(start).Seconds())
+	if seconds < 0 {
+		seconds = 0
+	}
+	return &seconds
+}
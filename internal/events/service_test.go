@@ -1,7 +1,11 @@
 package events
 
 import (
+	"context"
+	"errors"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/bissquit/incident-garden/internal/domain"
 )
@@ -172,3 +176,393 @@ func TestSeverity_IsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestSeverity_Next(t *testing.T) {
+	tests := []struct {
+		severity domain.Severity
+		want     domain.Severity
+		wantOK   bool
+	}{
+		{domain.SeverityMinor, domain.SeverityMajor, true},
+		{domain.SeverityMajor, domain.SeverityCritical, true},
+		{domain.SeverityCritical, "", false},
+		{domain.Severity("unknown"), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.severity), func(t *testing.T) {
+			got, ok := tt.severity.Next()
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Severity.Next() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDefaultNotifyPolicy(t *testing.T) {
+	tests := []struct {
+		eventType domain.EventType
+		want      bool
+	}{
+		{domain.EventTypeIncident, true},
+		{domain.EventTypeMaintenance, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.eventType), func(t *testing.T) {
+			if got := DefaultNotifyPolicy(tt.eventType); got != tt.want {
+				t.Errorf("DefaultNotifyPolicy(%q) = %v, want %v", tt.eventType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventDuration(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	started := now.Add(-2 * time.Hour)
+	resolved := now.Add(-30 * time.Minute)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		event domain.Event
+		want  *int64
+	}{
+		{
+			name:  "resolved incident",
+			event: domain.Event{Status: domain.EventStatusResolved, StartedAt: &started, ResolvedAt: &resolved},
+			want:  int64Ptr(5400),
+		},
+		{
+			name:  "active incident runs until now",
+			event: domain.Event{Status: domain.EventStatusInvestigating, StartedAt: &started},
+			want:  int64Ptr(7200),
+		},
+		{
+			name:  "falls back to created_at",
+			event: domain.Event{Status: domain.EventStatusMonitoring, CreatedAt: now.Add(-time.Minute)},
+			want:  int64Ptr(60),
+		},
+		{
+			name:  "completed maintenance",
+			event: domain.Event{Status: domain.EventStatusCompleted, StartedAt: &started, ResolvedAt: &resolved},
+			want:  int64Ptr(5400),
+		},
+		{
+			name:  "scheduled maintenance",
+			event: domain.Event{Status: domain.EventStatusScheduled, StartedAt: &started},
+			want:  nil,
+		},
+		{
+			name:  "start in the future",
+			event: domain.Event{Status: domain.EventStatusInProgress, StartedAt: &future},
+			want:  int64Ptr(0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := eventDuration(&tt.event, now)
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("eventDuration() = %d, want nil", *got)
+				}
+				return
+			}
+			if got == nil || *got != *tt.want {
+				t.Errorf("eventDuration() = %v, want %d", got, *tt.want)
+			}
+		})
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestEventChanges(t *testing.T) {
+	minor := domain.SeverityMinor
+	major := domain.SeverityMajor
+	emptyImpact, sameImpact := "", "Logins fail"
+	team := "Payments SRE"
+
+	tests := []struct {
+		name  string
+		event domain.Event
+		input CreateEventUpdateInput
+		want  map[string]domain.FieldChange
+	}{
+		{
+			name:  "nothing changes",
+			event: domain.Event{Status: domain.EventStatusInvestigating, Severity: &minor},
+			input: CreateEventUpdateInput{Status: domain.EventStatusInvestigating, Severity: &minor},
+			want:  nil,
+		},
+		{
+			name:  "status",
+			event: domain.Event{Status: domain.EventStatusInvestigating, Severity: &minor},
+			input: CreateEventUpdateInput{Status: domain.EventStatusIdentified},
+			want: map[string]domain.FieldChange{
+				"status": {From: domain.EventStatusInvestigating, To: domain.EventStatusIdentified},
+			},
+		},
+		{
+			name:  "severity escalation",
+			event: domain.Event{Status: domain.EventStatusInvestigating, Severity: &minor},
+			input: CreateEventUpdateInput{Status: domain.EventStatusInvestigating, Severity: &major},
+			want: map[string]domain.FieldChange{
+				"severity": {From: minor, To: major},
+			},
+		},
+		{
+			name:  "impact",
+			event: domain.Event{Status: domain.EventStatusInvestigating, Impact: "Logins fail"},
+			input: CreateEventUpdateInput{Status: domain.EventStatusInvestigating, Impact: &emptyImpact},
+			want: map[string]domain.FieldChange{
+				"impact": {From: "Logins fail", To: ""},
+			},
+		},
+		{
+			name:  "same impact",
+			event: domain.Event{Status: domain.EventStatusInvestigating, Impact: "Logins fail"},
+			input: CreateEventUpdateInput{Status: domain.EventStatusInvestigating, Impact: &sameImpact},
+			want:  nil,
+		},
+		{
+			name:  "on-call team",
+			event: domain.Event{Status: domain.EventStatusIdentified},
+			input: CreateEventUpdateInput{Status: domain.EventStatusIdentified, OnCallTeam: &team},
+			want: map[string]domain.FieldChange{
+				"oncall_team": {From: "", To: "Payments SRE"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := eventChanges(&tt.event, tt.input)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("eventChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildEventTimeline(t *testing.T) {
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+	published := at(90)
+
+	updates := []*domain.EventUpdate{
+		{ID: "u1", CreatedAt: at(0)},
+		{ID: "u2", CreatedAt: at(30)},
+		{ID: "u3", CreatedAt: at(60)},
+	}
+	changes := []*domain.EventServiceChange{
+		{ID: "c1", CreatedAt: at(0)},
+		{ID: "c2", CreatedAt: at(45)},
+	}
+	postmortem := &domain.EventPostmortem{EventID: "e1", PublishedAt: &published, CreatedAt: at(70)}
+
+	timeline := BuildEventTimeline(updates, changes, postmortem)
+
+	type entry struct {
+		Type TimelineEntryType
+		ID   string
+	}
+	got := make([]entry, len(timeline))
+	for i, e := range timeline {
+		switch e.Type {
+		case TimelineEntryUpdate:
+			got[i] = entry{e.Type, e.Update.ID}
+		case TimelineEntryServiceChange:
+			got[i] = entry{e.Type, e.ServiceChange.ID}
+		case TimelineEntryPostmortem:
+			got[i] = entry{e.Type, e.Postmortem.EventID}
+		}
+	}
+	want := []entry{
+		{TimelineEntryUpdate, "u1"},
+		{TimelineEntryServiceChange, "c1"},
+		{TimelineEntryUpdate, "u2"},
+		{TimelineEntryServiceChange, "c2"},
+		{TimelineEntryUpdate, "u3"},
+		{TimelineEntryPostmortem, "e1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BuildEventTimeline() order = %v, want %v", got, want)
+	}
+	if !timeline[5].CreatedAt.Equal(published) {
+		t.Errorf("postmortem entry created_at = %v, want publication time %v", timeline[5].CreatedAt, published)
+	}
+}
+
+func TestBuildEventTimeline_UnpublishedPostmortem(t *testing.T) {
+	postmortem := &domain.EventPostmortem{EventID: "e1"}
+
+	timeline := BuildEventTimeline(nil, nil, postmortem)
+	if len(timeline) != 0 {
+		t.Errorf("BuildEventTimeline() = %v, want empty", timeline)
+	}
+	if BuildEventTimeline(nil, nil, nil) == nil {
+		t.Error("BuildEventTimeline() = nil, want empty slice")
+	}
+}
+
+// namesResolver serves names from a map and records its lookups.
+type namesResolver struct {
+	names   map[string]string
+	err     error
+	lookups [][]string
+}
+
+func (r *namesResolver) ResolveUserNames(_ context.Context, ids []string) (map[string]string, error) {
+	r.lookups = append(r.lookups, ids)
+	return r.names, r.err
+}
+
+func TestService_UserNames(t *testing.T) {
+	users := &namesResolver{names: map[string]string{"u1": "Dana Scully", "u2": "Fox Mulder"}}
+	s := &Service{users: users}
+
+	names := s.userNames(context.Background(), []string{"u1", "u2", "u1", ""})
+	if names["u1"] != "Dana Scully" || names["u2"] != "Fox Mulder" {
+		t.Errorf("userNames() = %v", names)
+	}
+	if want := [][]string{{"u1", "u2"}}; !reflect.DeepEqual(users.lookups, want) {
+		t.Errorf("lookups = %v, want a single deduplicated lookup %v", users.lookups, want)
+	}
+
+	if names := s.userNames(context.Background(), []string{""}); names != nil {
+		t.Errorf("userNames() without IDs = %v, want nil", names)
+	}
+	if len(users.lookups) != 1 {
+		t.Errorf("lookups = %d, want no lookup without IDs", len(users.lookups))
+	}
+}
+
+func TestService_UserNames_LookupFails(t *testing.T) {
+	s := &Service{users: &namesResolver{err: errors.New("connection refused")}}
+	if names := s.userNames(context.Background(), []string{"u1"}); names != nil {
+		t.Errorf("userNames() = %v, want nil on failure", names)
+	}
+
+	s = &Service{}
+	if names := s.userNames(context.Background(), []string{"u1"}); names != nil {
+		t.Errorf("userNames() without resolver = %v, want nil", names)
+	}
+}
+
+func TestMajorOutages(t *testing.T) {
+	got := majorOutages(map[string]domain.ServiceStatus{
+		"s3": domain.ServiceStatusMajorOutage,
+		"s1": domain.ServiceStatusMajorOutage,
+		"s2": domain.ServiceStatusPartialOutage,
+		"s4": domain.ServiceStatusMaintenance,
+	})
+	if want := []string{"s1", "s3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("majorOutages() = %v, want %v", got, want)
+	}
+}
+
+func TestService_CascadeMajorOutage_WithoutDependents(t *testing.T) {
+	// Without a lister nothing is looked up; a nil repo would panic otherwise
+	s := &Service{}
+	s.cascadeMajorOutage(context.Background(), &domain.Event{ID: "e1"}, []string{"s1"}, "u1")
+	if ids := s.updatedMajorOutages(context.Background(), CreateEventUpdateInput{
+		EventID:        "e1",
+		ServiceUpdates: []domain.AffectedService{{ServiceID: "s1", Status: domain.ServiceStatusMajorOutage}},
+	}); ids != nil {
+		t.Errorf("updatedMajorOutages() = %v, want nil without lister", ids)
+	}
+}
+
+func TestValidateImportTimes(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	before := start.Add(-time.Minute)
+
+	tests := []struct {
+		name  string
+		input CreateEventInput
+		want  error
+	}{
+		{"resolved with both", CreateEventInput{Status: domain.EventStatusResolved, StartedAt: &start, ResolvedAt: &end}, nil},
+		{"active without resolved_at", CreateEventInput{Status: domain.EventStatusMonitoring, StartedAt: &start}, nil},
+		{"missing started_at", CreateEventInput{Status: domain.EventStatusResolved, ResolvedAt: &end}, ErrStartedAtRequired},
+		{"resolved without resolved_at", CreateEventInput{Status: domain.EventStatusCompleted, StartedAt: &start}, ErrResolvedAtMismatch},
+		{"active with resolved_at", CreateEventInput{Status: domain.EventStatusInvestigating, StartedAt: &start, ResolvedAt: &end}, ErrResolvedAtMismatch},
+		{"resolved before start", CreateEventInput{Status: domain.EventStatusResolved, StartedAt: &start, ResolvedAt: &before}, ErrResolvedBeforeStarted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateImportTimes(tt.input); !errors.Is(err, tt.want) {
+				t.Errorf("validateImportTimes() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_CreateEventBulk_Size(t *testing.T) {
+	s := &Service{}
+	for _, n := range []int{0, MaxBulkEvents + 1} {
+		if _, err := s.CreateEventBulk(context.Background(), make([]CreateEventInput, n), "u1"); !errors.Is(err, ErrBulkSize) {
+			t.Errorf("CreateEventBulk(%d items) error = %v, want ErrBulkSize", n, err)
+		}
+	}
+}
+
+func TestStatusChangeContext_LogEntry(t *testing.T) {
+	old := domain.ServiceStatusOperational
+	tests := []struct {
+		name       string
+		change     StatusChangeContext
+		wantSource domain.StatusLogSourceType
+		wantReason string
+	}{
+		{
+			name:       "event by default",
+			change:     StatusChangeContext{},
+			wantSource: domain.StatusLogSourceEvent,
+			wantReason: "Event created: API down",
+		},
+		{
+			name:       "dependency",
+			change:     StatusChangeContext{SourceType: domain.StatusLogSourceDependency},
+			wantSource: domain.StatusLogSourceDependency,
+			wantReason: "Event created: API down",
+		},
+		{
+			name:       "webhook",
+			change:     StatusChangeContext{SourceType: domain.StatusLogSourceWebhook, WebhookSource: "pagerduty"},
+			wantSource: domain.StatusLogSourceWebhook,
+			wantReason: "Event created: API down (pagerduty webhook)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := tt.change.withReason("evt-1", "Event created: API down")
+			entry := change.logEntry("svc-1", &old, domain.ServiceStatusMajorOutage, "user-1")
+
+			if entry.SourceType != tt.wantSource {
+				t.Errorf("SourceType = %q, want %q", entry.SourceType, tt.wantSource)
+			}
+			if entry.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", entry.Reason, tt.wantReason)
+			}
+			if entry.EventID == nil || *entry.EventID != "evt-1" {
+				t.Errorf("EventID = %v, want evt-1", entry.EventID)
+			}
+			if entry.ServiceID != "svc-1" || entry.NewStatus != domain.ServiceStatusMajorOutage || entry.CreatedBy != "user-1" {
+				t.Errorf("unexpected entry %+v", entry)
+			}
+		})
+	}
+
+	// A webhook change without a reason gets none
+	if got := (StatusChangeContext{WebhookSource: "pagerduty"}).reason(); got != "" {
+		t.Errorf("reason() = %q, want empty", got)
+	}
+}
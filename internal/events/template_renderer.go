@@ -18,6 +18,7 @@ func NewTemplateRenderer() *TemplateRenderer {
 }
 
 // Render renders a template string with the given data.
+// Referencing a variable missing from data.Variables is an error.
 func (tr *TemplateRenderer) Render(tmplStr string, data domain.TemplateData) (string, error) {
 	funcMap := template.FuncMap{
 		"formatTime": func(t *time.Time) string {
@@ -28,7 +29,7 @@ func (tr *TemplateRenderer) Render(tmplStr string, data domain.TemplateData) (st
 		},
 	}
 
-	tmpl, err := template.New("event").Funcs(funcMap).Parse(tmplStr)
+	tmpl, err := template.New("event").Funcs(funcMap).Option("missingkey=error").Parse(tmplStr)
 	if err != nil {
 		return "", fmt.Errorf("parse template: %w", err)
 	}
@@ -0,0 +1,54 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateRenderer_Render(t *testing.T) {
+	renderer := NewTemplateRenderer()
+	started := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	got, err := renderer.Render(
+		"{{.ServiceName}} degraded in {{.Variables.region}} since {{formatTime .StartedAt}}",
+		domain.TemplateData{
+			ServiceName: "API",
+			StartedAt:   &started,
+			Variables:   map[string]string{"region": "eu-west-1"},
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "API degraded in eu-west-1 since 2026-03-10 12:00:00 UTC", got)
+}
+
+func TestTemplateRenderer_Render_MissingVariable(t *testing.T) {
+	renderer := NewTemplateRenderer()
+
+	tests := []struct {
+		name      string
+		variables map[string]string
+	}{
+		{"no variables", nil},
+		{"other variables", map[string]string{"zone": "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := renderer.Render("Outage in {{.Variables.region}}", domain.TemplateData{Variables: tt.variables})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "region")
+		})
+	}
+}
+
+func TestTemplateRenderer_Render_MalformedSyntax(t *testing.T) {
+	renderer := NewTemplateRenderer()
+
+	_, err := renderer.Render("{{.ServiceName", domain.TemplateData{ServiceName: "API"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parse template")
+}
@@ -0,0 +1,272 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// Watch event types, as in Kubernetes watch streams.
+const (
+	WatchAdded    = "ADDED"
+	WatchModified = "MODIFIED"
+	WatchDeleted  = "DELETED"
+)
+
+const (
+	defaultWatchBufferSize    = 16
+	defaultWatchRetryInterval = 5 * time.Second
+)
+
+// EventChange is a committed change of an events row, as reported by the database.
+type EventChange struct {
+	Op string `json:"op"` // INSERT, UPDATE or DELETE
+	ID string `json:"id"`
+}
+
+// WatchEvent is a single line of the watch stream.
+// Object is the full event for ADDED and MODIFIED; a deleted event can't be
+// loaded anymore, so DELETED carries only its ID.
+type WatchEvent struct {
+	Type   string      `json:"type"`
+	Object interface{} `json:"object"`
+}
+
+// deletedObject is the object of a DELETED watch event.
+type deletedObject struct {
+	ID string `json:"id"`
+}
+
+// ChangeListener reports changes of the events table until ctx is cancelled or it fails.
+type ChangeListener interface {
+	ListenEventChanges(ctx context.Context, fn func(EventChange)) error
+}
+
+// EventGetter loads an event by ID. Implemented by Service.
+type EventGetter interface {
+	GetEvent(ctx context.Context, id string) (*domain.Event, error)
+}
+
+// WatcherConfig holds watcher configuration.
+type WatcherConfig struct {
+	BufferSize    int           // per-client line buffer
+	RetryInterval time.Duration // delay before listening again after a failure
+}
+
+// Watcher streams event changes to clients as newline-delimited JSON.
+type Watcher struct {
+	config   WatcherConfig
+	listener ChangeListener
+	events   EventGetter
+
+	clients   sync.Map // *watchClient -> struct{}
+	count     atomic.Int64
+	stopCh    chan struct{} // stops the listener
+	done      chan struct{} // ends the streams
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// watchClient is a single connected client.
+type watchClient struct {
+	lines    chan []byte
+	dropped  chan struct{}
+	dropOnce sync.Once
+}
+
+// NewWatcher creates a new event watcher.
+func NewWatcher(config WatcherConfig, listener ChangeListener, events EventGetter) *Watcher {
+	if config.BufferSize <= 0 {
+		config.BufferSize = defaultWatchBufferSize
+	}
+	if config.RetryInterval <= 0 {
+		config.RetryInterval = defaultWatchRetryInterval
+	}
+
+	return &Watcher{
+		config:   config,
+		listener: listener,
+		events:   events,
+		stopCh:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start launches the listener goroutine.
+func (w *Watcher) Start(ctx context.Context) {
+	w.wg.Add(1)
+	go w.run(ctx)
+}
+
+// Close stops the listener and all streams. Lines already buffered are flushed
+// to clients before their connections are closed. Safe to call more than once.
+func (w *Watcher) Close() {
+	w.closeOnce.Do(func() {
+		close(w.stopCh)
+		w.wg.Wait() // changes being handled are published before the streams end
+		close(w.done)
+	})
+}
+
+// ClientCount returns the number of connected clients.
+func (w *Watcher) ClientCount() int {
+	return int(w.count.Load())
+}
+
+func (w *Watcher) run(ctx context.Context) {
+	defer w.wg.Done()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-w.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		err := w.listener.ListenEventChanges(ctx, func(change EventChange) {
+			w.handle(ctx, change)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		slog.ErrorContext(ctx, "event watch listener failed, retrying", "error", err, "retry_in", w.config.RetryInterval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.config.RetryInterval):
+		}
+	}
+}
+
+// handle turns a database change into a watch event and publishes it.
+func (w *Watcher) handle(ctx context.Context, change EventChange) {
+	if w.ClientCount() == 0 {
+		return
+	}
+
+	var watchEvent WatchEvent
+	switch change.Op {
+	case "INSERT", "UPDATE":
+		event, err := w.events.GetEvent(ctx, change.ID)
+		if errors.Is(err, ErrEventNotFound) {
+			return // deleted meanwhile, DELETED follows
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to load watched event", "event_id", change.ID, "error", err)
+			return
+		}
+		watchEvent = WatchEvent{Type: WatchModified, Object: event}
+		if change.Op == "INSERT" {
+			watchEvent.Type = WatchAdded
+		}
+	case "DELETE":
+		watchEvent = WatchEvent{Type: WatchDeleted, Object: deletedObject{ID: change.ID}}
+	default:
+		slog.WarnContext(ctx, "unknown event change operation", "op", change.Op, "event_id", change.ID)
+		return
+	}
+
+	w.publish(watchEvent)
+}
+
+// publish sends a watch event to all clients without blocking.
+// A client whose buffer is full is disconnected: it re-lists events and
+// watches again instead of silently missing changes.
+func (w *Watcher) publish(watchEvent WatchEvent) {
+	payload, err := json.Marshal(watchEvent)
+	if err != nil {
+		slog.Error("failed to marshal watch event", "type", watchEvent.Type, "error", err)
+		return
+	}
+	line := append(payload, '\n')
+
+	w.clients.Range(func(key, _ interface{}) bool {
+		client := key.(*watchClient)
+		select {
+		case client.lines <- line:
+		default:
+			slog.Warn("watch client too slow, disconnecting", "type", watchEvent.Type)
+			w.unsubscribe(client)
+			client.dropOnce.Do(func() { close(client.dropped) })
+		}
+		return true
+	})
+}
+
+func (w *Watcher) subscribe() *watchClient {
+	client := &watchClient{
+		lines:   make(chan []byte, w.config.BufferSize),
+		dropped: make(chan struct{}),
+	}
+	w.clients.Store(client, struct{}{})
+	w.count.Add(1)
+	return client
+}
+
+// unsubscribe removes a client. Safe to call more than once.
+func (w *Watcher) unsubscribe(client *watchClient) {
+	if _, loaded := w.clients.LoadAndDelete(client); loaded {
+		w.count.Add(-1)
+	}
+}
+
+// ServeHTTP handles GET /watch/events.
+func (w *Watcher) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		http.Error(rw, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// Stream outlives the server write timeout
+	if err := http.NewResponseController(rw).SetWriteDeadline(time.Time{}); err != nil {
+		slog.Debug("failed to clear write deadline for watch stream", "error", err)
+	}
+
+	client := w.subscribe()
+	defer w.unsubscribe(client)
+
+	rw.Header().Set("Content-Type", "application/x-ndjson")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("X-Accel-Buffering", "no")
+	rw.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case line := <-client.lines:
+			if _, err := rw.Write(line); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-client.dropped:
+			return
+		case <-r.Context().Done():
+			return
+		case <-w.done:
+			for {
+				select {
+				case line := <-client.lines:
+					if _, err := rw.Write(line); err != nil {
+						return
+					}
+				default:
+					flusher.Flush()
+					return
+				}
+			}
+		}
+	}
+}
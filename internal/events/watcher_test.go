@@ -0,0 +1,108 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// chanListener reports changes sent to its channel.
+type chanListener chan EventChange
+
+func (l chanListener) ListenEventChanges(ctx context.Context, fn func(EventChange)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case change := <-l:
+			fn(change)
+		}
+	}
+}
+
+// mapGetter serves events from a map.
+type mapGetter map[string]*domain.Event
+
+func (g mapGetter) GetEvent(_ context.Context, id string) (*domain.Event, error) {
+	event, ok := g[id]
+	if !ok {
+		return nil, ErrEventNotFound
+	}
+	return event, nil
+}
+
+func TestWatcher_StreamsChanges(t *testing.T) {
+	listener := make(chanListener)
+	watcher := NewWatcher(WatcherConfig{}, listener, mapGetter{
+		"e1": {ID: "e1", Title: "Outage", Status: domain.EventStatusInvestigating},
+	})
+	watcher.Start(context.Background())
+
+	server := httptest.NewServer(watcher)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("get stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q, want application/x-ndjson", got)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for watcher.ClientCount() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	listener <- EventChange{Op: "INSERT", ID: "e1"}
+	listener <- EventChange{Op: "UPDATE", ID: "missing"} // deleted meanwhile, skipped
+	listener <- EventChange{Op: "UPDATE", ID: "e1"}
+	listener <- EventChange{Op: "DELETE", ID: "e1"}
+	watcher.Close()
+
+	type line struct {
+		Type   string `json:"type"`
+		Object struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"object"`
+	}
+	var got []line
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var l line
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			t.Fatalf("decode line %q: %v", scanner.Text(), err)
+		}
+		got = append(got, l)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("got %d lines, want 3: %+v", len(got), got)
+	}
+	wantTypes := []string{WatchAdded, WatchModified, WatchDeleted}
+	for i, l := range got {
+		if l.Type != wantTypes[i] || l.Object.ID != "e1" {
+			t.Errorf("line %d = %+v, want type %s for e1", i, l, wantTypes[i])
+		}
+	}
+	if got[0].Object.Title != "Outage" {
+		t.Errorf("ADDED title = %q, want full event", got[0].Object.Title)
+	}
+	if got[2].Object.Title != "" {
+		t.Errorf("DELETED carries only the ID, got title %q", got[2].Object.Title)
+	}
+}
+
+func TestWatcher_SkipsLookupWithoutClients(t *testing.T) {
+	watcher := NewWatcher(WatcherConfig{}, make(chanListener), nil)
+	// A nil getter would panic if the change were loaded
+	watcher.handle(context.Background(), EventChange{Op: "INSERT", ID: "e1"})
+}
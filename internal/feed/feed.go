@@ -0,0 +1,149 @@
+// Package feed provides Atom feeds of status page events.
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// ContentType is the Atom feed media type.
+const ContentType = "application/atom+xml; charset=utf-8"
+
+// Feed is an Atom 1.0 feed document.
+type Feed struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string   `xml:"id"`
+	Title   string   `xml:"title"`
+	Updated string   `xml:"updated"`
+	Logo    string   `xml:"logo,omitempty"` // service icon of a service feed
+	Author  Person   `xml:"author"`
+	Links   []Link   `xml:"link"`
+	Entries []Entry  `xml:"entry"`
+}
+
+// Entry is a single event in a feed.
+type Entry struct {
+	ID         string     `xml:"id"`
+	Title      string     `xml:"title"`
+	Published  string     `xml:"published"`
+	Updated    string     `xml:"updated"`
+	Links      []Link     `xml:"link"`
+	Categories []Category `xml:"category"`
+	Summary    *Text      `xml:"summary,omitempty"` // event impact, omitted when empty
+	Content    Text       `xml:"content"`
+}
+
+// Link is an Atom link element.
+type Link struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// Category labels an entry with the event type, severity or status.
+type Category struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr,omitempty"`
+}
+
+// Person is an Atom person construct.
+type Person struct {
+	Name string `xml:"name"`
+}
+
+// Text is an Atom text construct.
+type Text struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// Options describe the feed being built.
+type Options struct {
+	ID       string // feed IRI
+	Title    string
+	SelfURL  string // URL the feed is served from
+	Logo     string // feed logo URL, omitted when empty
+	EventURL func(eventID string) string
+}
+
+// Build creates a feed of events, newest first.
+// Events must already be ordered by creation time, newest first.
+func Build(opts Options, events []*domain.Event) *Feed {
+	feed := &Feed{
+		ID:      opts.ID,
+		Title:   opts.Title,
+		Updated: formatTime(feedUpdated(events)),
+		Logo:    opts.Logo,
+		Author:  Person{Name: "IncidentGarden"},
+		Links:   []Link{{Href: opts.SelfURL, Rel: "self", Type: ContentType}},
+		Entries: make([]Entry, 0, len(events)),
+	}
+
+	for _, event := range events {
+		feed.Entries = append(feed.Entries, buildEntry(opts, event))
+	}
+	return feed
+}
+
+func buildEntry(opts Options, event *domain.Event) Entry {
+	categories := []Category{
+		{Term: string(event.Type), Label: "type"},
+		{Term: string(event.Status), Label: "status"},
+	}
+	lines := []string{
+		"Type: " + string(event.Type),
+		"Status: " + string(event.Status),
+	}
+	if event.Severity != nil {
+		categories = append(categories, Category{Term: string(*event.Severity), Label: "severity"})
+		lines = append(lines, "Severity: "+string(*event.Severity))
+	}
+	if event.Description != "" {
+		lines = append(lines, "", event.Description)
+	}
+
+	entry := Entry{
+		ID:         "urn:uuid:" + event.ID,
+		Title:      event.Title,
+		Published:  formatTime(event.CreatedAt),
+		Updated:    formatTime(event.UpdatedAt),
+		Links:      []Link{{Href: opts.EventURL(event.ID), Rel: "alternate"}},
+		Categories: categories,
+		Content:    Text{Type: "text", Body: strings.Join(lines, "\n")},
+	}
+	if event.Impact != "" {
+		entry.Summary = &Text{Type: "text", Body: event.Impact}
+	}
+	return entry
+}
+
+// feedUpdated returns the latest event change, or now for an empty feed.
+func feedUpdated(events []*domain.Event) time.Time {
+	var latest time.Time
+	for _, event := range events {
+		if event.UpdatedAt.After(latest) {
+			latest = event.UpdatedAt
+		}
+	}
+	if latest.IsZero() {
+		return time.Now()
+	}
+	return latest
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Marshal encodes the feed with an XML declaration.
+func Marshal(feed *Feed) ([]byte, error) {
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal feed: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,96 @@
+package feed
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOptions() Options {
+	return Options{
+		ID:       "urn:incident-garden:feed",
+		Title:    "Status updates",
+		SelfURL:  "https://status.example.com/api/v1/feed",
+		EventURL: func(id string) string { return "https://status.example.com/events/" + id },
+	}
+}
+
+func TestBuild_Entries(t *testing.T) {
+	created := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	severity := domain.SeverityMajor
+	incident := &domain.Event{
+		ID:          "11111111-1111-1111-1111-111111111111",
+		Title:       "API outage",
+		Type:        domain.EventTypeIncident,
+		Status:      domain.EventStatusInvestigating,
+		Severity:    &severity,
+		Description: "Requests fail",
+		Impact:      "Checkout is unavailable",
+		CreatedAt:   created,
+		UpdatedAt:   created.Add(time.Hour),
+	}
+	maintenance := &domain.Event{
+		ID:        "22222222-2222-2222-2222-222222222222",
+		Title:     "DB upgrade",
+		Type:      domain.EventTypeMaintenance,
+		Status:    domain.EventStatusScheduled,
+		CreatedAt: created.Add(-time.Hour),
+		UpdatedAt: created.Add(-time.Hour),
+	}
+
+	feed := Build(testOptions(), []*domain.Event{incident, maintenance})
+
+	assert.Equal(t, "2026-01-02T11:00:00Z", feed.Updated)
+	require.Len(t, feed.Entries, 2)
+
+	entry := feed.Entries[0]
+	assert.Equal(t, "urn:uuid:"+incident.ID, entry.ID)
+	assert.Equal(t, "API outage", entry.Title)
+	assert.Equal(t, "2026-01-02T10:00:00Z", entry.Published)
+	assert.Equal(t, "https://status.example.com/events/"+incident.ID, entry.Links[0].Href)
+	assert.Equal(t, []Category{
+		{Term: "incident", Label: "type"},
+		{Term: "investigating", Label: "status"},
+		{Term: "major", Label: "severity"},
+	}, entry.Categories)
+	assert.Equal(t, "Type: incident\nStatus: investigating\nSeverity: major\n\nRequests fail", entry.Content.Body)
+
+	assert.Equal(t, &Text{Type: "text", Body: "Checkout is unavailable"}, entry.Summary)
+
+	assert.Len(t, feed.Entries[1].Categories, 2, "maintenance has no severity")
+	assert.Nil(t, feed.Entries[1].Summary, "no summary without impact")
+}
+
+func TestMarshal_ValidAtom(t *testing.T) {
+	body, err := Marshal(Build(testOptions(), nil))
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `<?xml version="1.0" encoding="UTF-8"?>`)
+
+	var decoded struct {
+		XMLName xml.Name
+		ID      string `xml:"id"`
+	}
+	require.NoError(t, xml.Unmarshal(body, &decoded))
+	assert.Equal(t, "http://www.w3.org/2005/Atom", decoded.XMLName.Space)
+	assert.Equal(t, "feed", decoded.XMLName.Local)
+	assert.Equal(t, "urn:incident-garden:feed", decoded.ID)
+	assert.NotContains(t, string(body), "<logo>", "no logo without icon")
+}
+
+func TestMarshal_Logo(t *testing.T) {
+	opts := testOptions()
+	opts.Logo = "https://cdn.example.com/api.png"
+
+	body, err := Marshal(Build(opts, nil))
+	require.NoError(t, err)
+
+	var decoded struct {
+		Logo string `xml:"logo"`
+	}
+	require.NoError(t, xml.Unmarshal(body, &decoded))
+	assert.Equal(t, "https://cdn.example.com/api.png", decoded.Logo)
+}
@@ -0,0 +1,120 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/bissquit/incident-garden/internal/pkg/ctxlog"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/go-chi/chi/v5"
+)
+
+// Size is the number of most recent events in a feed.
+const Size = 50
+
+var errorMappings = []httputil.ErrorMapping{
+	{Error: catalog.ErrServiceNotFound, Status: http.StatusNotFound, Message: "service not found"},
+}
+
+// EventLister lists events (implemented by events.Service).
+type EventLister interface {
+	ListEvents(ctx context.Context, filters events.EventFilters) ([]*domain.Event, error)
+}
+
+// ServiceResolver finds services by slug (implemented by catalog.Service).
+type ServiceResolver interface {
+	GetServiceBySlug(ctx context.Context, slug string) (*domain.Service, error)
+}
+
+// Handler serves Atom feeds of events.
+type Handler struct {
+	events   EventLister
+	services ServiceResolver
+	baseURL  string
+}
+
+// NewHandler creates a new feed handler.
+// baseURL is the status page URL used for event permalinks; when empty,
+// permalinks point to the API event resource of the requested host.
+func NewHandler(eventLister EventLister, services ServiceResolver, baseURL string) *Handler {
+	return &Handler{
+		events:   eventLister,
+		services: services,
+		baseURL:  baseURL,
+	}
+}
+
+// RegisterRoutes registers public feed routes (no auth required).
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/feed", h.GetFeed)
+	r.Get("/services/{slug}/feed", h.GetServiceFeed)
+}
+
+// GetFeed handles GET /feed.
+func (h *Handler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, Options{ID: "urn:incident-garden:feed", Title: "Status updates"}, events.EventFilters{Limit: Size})
+}
+
+// GetServiceFeed handles GET /services/{slug}/feed.
+func (h *Handler) GetServiceFeed(w http.ResponseWriter, r *http.Request) {
+	service, err := h.services.GetServiceBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	h.serve(w, r,
+		Options{
+			ID:    "urn:incident-garden:feed:service:" + service.ID,
+			Title: fmt.Sprintf("%s status updates", service.Name),
+			Logo:  service.IconURL,
+		},
+		events.EventFilters{ServiceID: &service.ID, Limit: Size},
+	)
+}
+
+// serve renders the feed of filtered events; opts.SelfURL and opts.EventURL are set from the request.
+func (h *Handler) serve(w http.ResponseWriter, r *http.Request, opts Options, filters events.EventFilters) {
+	eventsList, err := h.events.ListEvents(r.Context(), filters)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	origin := requestOrigin(r)
+	opts.SelfURL = origin + r.URL.Path
+	opts.EventURL = h.eventURL(origin)
+	body, err := Marshal(Build(opts, eventsList))
+	if err != nil {
+		ctxlog.FromContext(r.Context()).Error("failed to render feed", "error", err)
+		httputil.Error(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+func (h *Handler) eventURL(origin string) func(string) string {
+	if h.baseURL != "" {
+		return func(id string) string { return fmt.Sprintf("%s/events/%s", h.baseURL, id) }
+	}
+	return func(id string) string { return fmt.Sprintf("%s/api/v1/events/%s", origin, id) }
+}
+
+// requestOrigin returns the scheme and host the request was made to.
+func requestOrigin(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host
+}
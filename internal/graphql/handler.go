@@ -0,0 +1,111 @@
+// Package graphql serves catalog and event data over GraphQL.
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/bissquit/incident-garden/internal/sse"
+	"github.com/go-chi/chi/v5"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+const (
+	// maxDepth limits query nesting, e.g. services → events → services → events.
+	maxDepth = 8
+	// maxBodySize limits GraphQL request bodies.
+	maxBodySize = 1 << 20
+)
+
+// CatalogReader reads services and groups with their effective status.
+type CatalogReader interface {
+	ListServicesWithEffectiveStatus(ctx context.Context, filter catalog.ServiceFilter) ([]domain.ServiceWithEffectiveStatus, error)
+	ListGroupsWithEffectiveStatus(ctx context.Context, filter catalog.GroupFilter) ([]domain.GroupWithEffectiveStatus, error)
+}
+
+// EventsService reads events and adds event updates.
+type EventsService interface {
+	GetEvent(ctx context.Context, id string) (*domain.Event, error)
+	ListEvents(ctx context.Context, filters events.EventFilters) ([]*domain.Event, error)
+	ListEventsByServiceIDs(ctx context.Context, serviceIDs []string, filter events.ServiceEventFilter) (map[string][]*domain.Event, error)
+	GetEventUpdates(ctx context.Context, eventID string) ([]*domain.EventUpdate, error)
+	AddUpdate(ctx context.Context, input events.CreateEventUpdateInput, createdBy string) (*domain.EventUpdate, error)
+}
+
+// Request is a GraphQL request body.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler executes GraphQL requests.
+type Handler struct {
+	schema  *graphqlgo.Schema
+	catalog CatalogReader
+	events  EventsService
+}
+
+// NewHandler parses the schema and creates a GraphQL handler.
+// publisher may be nil; mutations then do not push live updates.
+func NewHandler(catalogReader CatalogReader, eventsService EventsService, publisher sse.Publisher) (*Handler, error) {
+	root := &resolver{catalog: catalogReader, events: eventsService, publisher: publisher}
+	schema, err := graphqlgo.ParseSchema(schemaSDL, root, graphqlgo.MaxDepth(maxDepth))
+	if err != nil {
+		return nil, fmt.Errorf("parse graphql schema: %w", err)
+	}
+
+	return &Handler{
+		schema:  schema,
+		catalog: catalogReader,
+		events:  eventsService,
+	}, nil
+}
+
+// RegisterRoutes registers the GraphQL route. Queries are public; mutations check
+// the role from the context, so the route needs httputil.OptionalAuthMiddleware.
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Post("/graphql", h.ServeGraphQL)
+}
+
+// ServeGraphQL handles POST /graphql.
+// The response is a standard GraphQL {data, errors} document, not the REST envelope.
+func (h *Handler) ServeGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if req.Query == "" {
+		httputil.Error(w, http.StatusBadRequest, "query is required")
+		return
+	}
+
+	ctx := withLoaders(r.Context(), newLoaders(h.catalog, h.events))
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+// loadersFrom returns the request loaders set by ServeGraphQL.
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,293 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCatalog struct {
+	services []domain.ServiceWithEffectiveStatus
+	groups   []domain.GroupWithEffectiveStatus
+
+	mu          sync.Mutex
+	serviceHits int
+}
+
+func (f *fakeCatalog) ListServicesWithEffectiveStatus(_ context.Context, _ catalog.ServiceFilter) ([]domain.ServiceWithEffectiveStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.serviceHits++
+	return f.services, nil
+}
+
+func (f *fakeCatalog) ListGroupsWithEffectiveStatus(_ context.Context, _ catalog.GroupFilter) ([]domain.GroupWithEffectiveStatus, error) {
+	return f.groups, nil
+}
+
+type fakeEvents struct {
+	events  map[string]*domain.Event
+	updates map[string][]*domain.EventUpdate
+	added   []events.CreateEventUpdateInput
+	listErr error
+
+	mu         sync.Mutex
+	batchCalls [][]string
+}
+
+func (f *fakeEvents) GetEvent(_ context.Context, id string) (*domain.Event, error) {
+	event, ok := f.events[id]
+	if !ok {
+		return nil, events.ErrEventNotFound
+	}
+	return event, nil
+}
+
+func (f *fakeEvents) ListEvents(_ context.Context, _ events.EventFilters) ([]*domain.Event, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	result := make([]*domain.Event, 0, len(f.events))
+	for _, event := range f.events {
+		result = append(result, event)
+	}
+	return result, nil
+}
+
+func (f *fakeEvents) ListEventsByServiceIDs(_ context.Context, serviceIDs []string, _ events.ServiceEventFilter) (map[string][]*domain.Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.batchCalls = append(f.batchCalls, serviceIDs)
+
+	result := make(map[string][]*domain.Event)
+	for _, id := range serviceIDs {
+		for _, event := range f.events {
+			for _, serviceID := range event.ServiceIDs {
+				if serviceID == id {
+					result[id] = append(result[id], event)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeEvents) GetEventUpdates(_ context.Context, eventID string) ([]*domain.EventUpdate, error) {
+	return f.updates[eventID], nil
+}
+
+func (f *fakeEvents) AddUpdate(_ context.Context, input events.CreateEventUpdateInput, createdBy string) (*domain.EventUpdate, error) {
+	f.added = append(f.added, input)
+	return &domain.EventUpdate{
+		ID:                "upd-new",
+		EventID:           input.EventID,
+		Status:            input.Status,
+		Message:           input.Message,
+		NotifySubscribers: input.NotifySubscribers,
+		CreatedBy:         createdBy,
+		CreatedAt:         time.Now(),
+	}, nil
+}
+
+func newFixture() (*fakeCatalog, *fakeEvents) {
+	started := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	cat := &fakeCatalog{
+		services: []domain.ServiceWithEffectiveStatus{
+			{Service: domain.Service{ID: "svc-api", Name: "API", Slug: "api", Status: domain.ServiceStatusOperational, GroupIDs: []string{"grp-core"}}, EffectiveStatus: domain.ServiceStatusMajorOutage, HasActiveEvents: true},
+			{Service: domain.Service{ID: "svc-db", Name: "Database", Slug: "db", Status: domain.ServiceStatusOperational, GroupIDs: []string{"grp-core"}}, EffectiveStatus: domain.ServiceStatusMajorOutage, HasActiveEvents: true},
+			{Service: domain.Service{ID: "svc-web", Name: "Web", Slug: "web", Status: domain.ServiceStatusOperational}, EffectiveStatus: domain.ServiceStatusOperational},
+			{Service: domain.Service{ID: "svc-old", Name: "Old", Slug: "old", Status: domain.ServiceStatusOperational, ArchivedAt: &started}, EffectiveStatus: domain.ServiceStatusOperational},
+		},
+		groups: []domain.GroupWithEffectiveStatus{
+			{ServiceGroup: domain.ServiceGroup{ID: "grp-core", Name: "Core", Slug: "core", ServiceIDs: []string{"svc-api", "svc-db"}}, EffectiveStatus: domain.ServiceStatusMajorOutage},
+		},
+	}
+	ev := &fakeEvents{
+		events: map[string]*domain.Event{
+			"evt-1": {ID: "evt-1", Title: "Database outage", Type: domain.EventTypeIncident, Status: domain.EventStatusInvestigating, StartedAt: &started, CreatedAt: started, ServiceIDs: []string{"svc-api", "svc-db"}},
+		},
+		updates: map[string][]*domain.EventUpdate{
+			"evt-1": {{ID: "upd-1", EventID: "evt-1", Status: domain.EventStatusInvestigating, Message: "Looking into it", CreatedAt: started}},
+		},
+	}
+	return cat, ev
+}
+
+// execute runs a GraphQL request as the given role ("" = anonymous) and decodes the response.
+func execute(t *testing.T, handler *Handler, role domain.Role, query string, variables map[string]interface{}) (map[string]interface{}, []map[string]interface{}) {
+	t.Helper()
+	body, err := json.Marshal(Request{Query: query, Variables: variables})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	if role != "" {
+		ctx := context.WithValue(req.Context(), httputil.UserIDKey, "user-1")
+		ctx = context.WithValue(ctx, httputil.RoleKey, role)
+		req = req.WithContext(ctx)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeGraphQL(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Data   map[string]interface{}   `json:"data"`
+		Errors []map[string]interface{} `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp.Data, resp.Errors
+}
+
+func newTestHandler(t *testing.T, cat *fakeCatalog, ev *fakeEvents) *Handler {
+	t.Helper()
+	handler, err := NewHandler(cat, ev, nil)
+	require.NoError(t, err)
+	return handler
+}
+
+func TestGraphQL_NestedQuery(t *testing.T) {
+	cat, ev := newFixture()
+	handler := newTestHandler(t, cat, ev)
+
+	data, errs := execute(t, handler, "", `{
+		services {
+			slug
+			effectiveStatus
+			groups { slug }
+			events(status: "active") {
+				title
+				updates { message }
+				services { slug }
+			}
+		}
+	}`, nil)
+	require.Empty(t, errs)
+
+	services := data["services"].([]interface{})
+	require.Len(t, services, 3, "archived services are hidden by default")
+
+	api := services[0].(map[string]interface{})
+	assert.Equal(t, "api", api["slug"])
+	assert.Equal(t, "major_outage", api["effectiveStatus"])
+	assert.Equal(t, "core", api["groups"].([]interface{})[0].(map[string]interface{})["slug"])
+
+	apiEvents := api["events"].([]interface{})
+	require.Len(t, apiEvents, 1)
+	event := apiEvents[0].(map[string]interface{})
+	assert.Equal(t, "Database outage", event["title"])
+	assert.Equal(t, "Looking into it", event["updates"].([]interface{})[0].(map[string]interface{})["message"])
+	assert.Len(t, event["services"], 2)
+
+	web := services[2].(map[string]interface{})
+	assert.Empty(t, web["events"])
+}
+
+func TestGraphQL_ServiceEventsBatched(t *testing.T) {
+	cat, ev := newFixture()
+	handler := newTestHandler(t, cat, ev)
+
+	_, errs := execute(t, handler, "", `{ services { slug events { title } } }`, nil)
+	require.Empty(t, errs)
+
+	require.Len(t, ev.batchCalls, 1, "events of all services are loaded in one batch")
+	assert.ElementsMatch(t, []string{"svc-api", "svc-db", "svc-web"}, ev.batchCalls[0])
+	assert.Equal(t, 1, cat.serviceHits, "catalog is loaded once per request")
+}
+
+func TestGraphQL_GroupAndEventLookups(t *testing.T) {
+	cat, ev := newFixture()
+	handler := newTestHandler(t, cat, ev)
+
+	data, errs := execute(t, handler, "", `query($id: ID!) {
+		group(slug: "core") { name services { slug } }
+		event(id: $id) { title severity services { name } }
+		missing: event(id: "evt-unknown") { title }
+		unknown: service(slug: "nope") { slug }
+	}`, map[string]interface{}{"id": "evt-1"})
+	require.Empty(t, errs)
+
+	group := data["group"].(map[string]interface{})
+	assert.Equal(t, "Core", group["name"])
+	assert.Len(t, group["services"], 2)
+
+	event := data["event"].(map[string]interface{})
+	assert.Equal(t, "Database outage", event["title"])
+	assert.Nil(t, event["severity"])
+	assert.Nil(t, data["missing"])
+	assert.Nil(t, data["unknown"])
+}
+
+func TestGraphQL_AddEventUpdate_RequiresOperator(t *testing.T) {
+	const mutation = `mutation {
+		addEventUpdate(eventId: "evt-1", input: {status: "identified", message: "Found it"}) { status message notifySubscribers }
+	}`
+
+	tests := []struct {
+		name    string
+		role    domain.Role
+		wantErr string
+	}{
+		{name: "anonymous", role: "", wantErr: "unauthorized"},
+		{name: "user", role: domain.RoleUser, wantErr: "insufficient permissions"},
+		{name: "operator", role: domain.RoleOperator},
+		{name: "admin", role: domain.RoleAdmin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cat, ev := newFixture()
+			handler := newTestHandler(t, cat, ev)
+
+			data, errs := execute(t, handler, tt.role, mutation, nil)
+
+			if tt.wantErr != "" {
+				require.Len(t, errs, 1)
+				assert.Equal(t, tt.wantErr, errs[0]["message"])
+				assert.Empty(t, ev.added)
+				return
+			}
+			require.Empty(t, errs)
+			update := data["addEventUpdate"].(map[string]interface{})
+			assert.Equal(t, "identified", update["status"])
+			assert.Equal(t, true, update["notifySubscribers"], "incidents notify by default")
+			require.Len(t, ev.added, 1)
+			assert.Equal(t, "evt-1", ev.added[0].EventID)
+		})
+	}
+}
+
+func TestGraphQL_InternalErrorsHidden(t *testing.T) {
+	cat, ev := newFixture()
+	ev.listErr = errors.New("connection refused")
+	handler := newTestHandler(t, cat, ev)
+
+	_, errs := execute(t, handler, "", `{ events { title } }`, nil)
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, "internal error", errs[0]["message"])
+}
+
+func TestGraphQL_InvalidRequest(t *testing.T) {
+	cat, ev := newFixture()
+	handler := newTestHandler(t, cat, ev)
+
+	rec := httptest.NewRecorder()
+	handler.ServeGraphQL(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader("{")))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeGraphQL(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": ""}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,155 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+)
+
+// loaders caches data for one GraphQL request, so nested fields do not query per parent object.
+// The catalog is loaded once per request; service events are batched (see serviceEventsLoader).
+type loaders struct {
+	catalog CatalogReader
+	events  EventsService
+
+	servicesOnce sync.Once
+	services     []domain.ServiceWithEffectiveStatus
+	servicesErr  error
+
+	groupsOnce sync.Once
+	groups     []domain.GroupWithEffectiveStatus
+	groupsErr  error
+
+	serviceEvents *serviceEventsLoader
+}
+
+func newLoaders(catalogReader CatalogReader, eventsService EventsService) *loaders {
+	return &loaders{
+		catalog: catalogReader,
+		events:  eventsService,
+		serviceEvents: &serviceEventsLoader{
+			events: eventsService,
+			seen:   make(map[string]bool),
+			loaded: make(map[events.ServiceEventFilter]map[string][]*domain.Event),
+		},
+	}
+}
+
+// allServices returns all services including archived ones.
+func (l *loaders) allServices(ctx context.Context) ([]domain.ServiceWithEffectiveStatus, error) {
+	l.servicesOnce.Do(func() {
+		l.services, l.servicesErr = l.catalog.ListServicesWithEffectiveStatus(ctx, catalog.ServiceFilter{IncludeArchived: true})
+	})
+	return l.services, l.servicesErr
+}
+
+// allGroups returns all groups including archived ones.
+func (l *loaders) allGroups(ctx context.Context) ([]domain.GroupWithEffectiveStatus, error) {
+	l.groupsOnce.Do(func() {
+		l.groups, l.groupsErr = l.catalog.ListGroupsWithEffectiveStatus(ctx, catalog.GroupFilter{IncludeArchived: true})
+	})
+	return l.groups, l.groupsErr
+}
+
+// servicesByID returns resolvers for the services with the given IDs, skipping unknown IDs.
+func (l *loaders) servicesByID(ctx context.Context, ids []string) ([]*serviceResolver, error) {
+	all, err := l.allServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	result := make([]*serviceResolver, 0, len(ids))
+	for i := range all {
+		if wanted[all[i].ID] {
+			result = append(result, l.newServiceResolver(&all[i]))
+		}
+	}
+	return result, nil
+}
+
+// groupsByID returns resolvers for the groups with the given IDs, skipping unknown IDs.
+func (l *loaders) groupsByID(ctx context.Context, ids []string) ([]*groupResolver, error) {
+	all, err := l.allGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	result := make([]*groupResolver, 0, len(ids))
+	for i := range all {
+		if wanted[all[i].ID] {
+			result = append(result, &groupResolver{group: &all[i], loaders: l})
+		}
+	}
+	return result, nil
+}
+
+// serviceEventsLoader batches events of services, DataLoader style.
+// Every service resolver created in a request registers its ID; the first events
+// lookup for a filter fetches events of all registered services in one query,
+// and later lookups are served from the batch. Services registered later
+// (deeper in the query) are fetched together on their first lookup.
+type serviceEventsLoader struct {
+	events EventsService
+
+	mu     sync.Mutex
+	ids    []string // registered services, in registration order
+	seen   map[string]bool
+	loaded map[events.ServiceEventFilter]map[string][]*domain.Event
+}
+
+// register adds a service whose events may be requested in this request.
+func (l *serviceEventsLoader) register(serviceID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.registerLocked(serviceID)
+}
+
+func (l *serviceEventsLoader) registerLocked(serviceID string) {
+	if !l.seen[serviceID] {
+		l.seen[serviceID] = true
+		l.ids = append(l.ids, serviceID)
+	}
+}
+
+// load returns events of a service, fetching all registered services not loaded yet for the filter.
+func (l *serviceEventsLoader) load(ctx context.Context, serviceID string, filter events.ServiceEventFilter) ([]*domain.Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.registerLocked(serviceID)
+
+	batch, ok := l.loaded[filter]
+	if !ok {
+		batch = make(map[string][]*domain.Event)
+		l.loaded[filter] = batch
+	}
+	if eventsList, ok := batch[serviceID]; ok {
+		return eventsList, nil
+	}
+
+	pending := make([]string, 0, len(l.ids))
+	for _, id := range l.ids {
+		if _, ok := batch[id]; !ok {
+			pending = append(pending, id)
+		}
+	}
+
+	eventsByService, err := l.events.ListEventsByServiceIDs(ctx, pending, filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range pending {
+		batch[id] = eventsByService[id]
+	}
+	return batch[serviceID], nil
+}
@@ -0,0 +1,390 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/bissquit/incident-garden/internal/pkg/ctxlog"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/bissquit/incident-garden/internal/sse"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+// Errors returned to GraphQL clients.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("insufficient permissions")
+	errInternal     = errors.New("internal error")
+)
+
+// publicErrors are domain errors whose message is safe to return to clients.
+var publicErrors = []error{
+	events.ErrEventNotFound,
+	events.ErrInvalidStatus,
+	events.ErrInvalidSeverity,
+	events.ErrSeverityNotSupported,
+	domain.ErrInvalidTransition,
+}
+
+// resolver is the root resolver. Per-request state lives in loaders from the context.
+type resolver struct {
+	catalog   CatalogReader
+	events    EventsService
+	publisher sse.Publisher
+}
+
+// publicError hides unexpected errors from clients and logs them.
+func publicError(ctx context.Context, err error) error {
+	for _, public := range publicErrors {
+		if errors.Is(err, public) {
+			return err
+		}
+	}
+	ctxlog.FromContext(ctx).Error("graphql: internal error", "error", err)
+	return errInternal
+}
+
+// Services resolves Query.services.
+func (r *resolver) Services(ctx context.Context, args struct{ IncludeArchived bool }) ([]*serviceResolver, error) {
+	l := loadersFrom(ctx)
+	all, err := l.allServices(ctx)
+	if err != nil {
+		return nil, publicError(ctx, err)
+	}
+
+	result := make([]*serviceResolver, 0, len(all))
+	for i := range all {
+		if all[i].IsArchived() && !args.IncludeArchived {
+			continue
+		}
+		result = append(result, l.newServiceResolver(&all[i]))
+	}
+	return result, nil
+}
+
+// Service resolves Query.service. Unknown slugs resolve to null.
+func (r *resolver) Service(ctx context.Context, args struct{ Slug string }) (*serviceResolver, error) {
+	l := loadersFrom(ctx)
+	all, err := l.allServices(ctx)
+	if err != nil {
+		return nil, publicError(ctx, err)
+	}
+
+	for i := range all {
+		if all[i].Slug == args.Slug {
+			return l.newServiceResolver(&all[i]), nil
+		}
+	}
+	return nil, nil
+}
+
+// Groups resolves Query.groups.
+func (r *resolver) Groups(ctx context.Context, args struct{ IncludeArchived bool }) ([]*groupResolver, error) {
+	l := loadersFrom(ctx)
+	all, err := l.allGroups(ctx)
+	if err != nil {
+		return nil, publicError(ctx, err)
+	}
+
+	result := make([]*groupResolver, 0, len(all))
+	for i := range all {
+		if all[i].IsArchived() && !args.IncludeArchived {
+			continue
+		}
+		result = append(result, &groupResolver{group: &all[i], loaders: l})
+	}
+	return result, nil
+}
+
+// Group resolves Query.group. Unknown slugs resolve to null.
+func (r *resolver) Group(ctx context.Context, args struct{ Slug string }) (*groupResolver, error) {
+	l := loadersFrom(ctx)
+	all, err := l.allGroups(ctx)
+	if err != nil {
+		return nil, publicError(ctx, err)
+	}
+
+	for i := range all {
+		if all[i].Slug == args.Slug {
+			return &groupResolver{group: &all[i], loaders: l}, nil
+		}
+	}
+	return nil, nil
+}
+
+// Events resolves Query.events.
+func (r *resolver) Events(ctx context.Context, args struct {
+	Type   *string
+	Status *string
+	Limit  int32
+	Offset int32
+}) ([]*eventResolver, error) {
+	filters := events.EventFilters{
+		Limit:  clampLimit(args.Limit, events.MaxListLimit),
+		Offset: max(int(args.Offset), 0),
+	}
+	if args.Type != nil {
+		eventType := domain.EventType(*args.Type)
+		filters.Type = &eventType
+	}
+	if args.Status != nil {
+		status := domain.EventStatus(*args.Status)
+		filters.Status = &status
+	}
+
+	eventsList, err := r.events.ListEvents(ctx, filters)
+	if err != nil {
+		return nil, publicError(ctx, err)
+	}
+	return newEventResolvers(loadersFrom(ctx), eventsList), nil
+}
+
+// Event resolves Query.event. Unknown IDs resolve to null.
+func (r *resolver) Event(ctx context.Context, args struct{ ID graphqlgo.ID }) (*eventResolver, error) {
+	event, err := r.events.GetEvent(ctx, string(args.ID))
+	if errors.Is(err, events.ErrEventNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, publicError(ctx, err)
+	}
+	return &eventResolver{event: event, loaders: loadersFrom(ctx)}, nil
+}
+
+// EventUpdateInput is the input of Mutation.addEventUpdate.
+type EventUpdateInput struct {
+	Status            string
+	Message           string
+	Severity          *string
+	NotifySubscribers *bool
+}
+
+// AddEventUpdate resolves Mutation.addEventUpdate. Like POST /events/{id}/updates,
+// it requires the operator role and publishes the change to the live status stream.
+func (r *resolver) AddEventUpdate(ctx context.Context, args struct {
+	EventID graphqlgo.ID
+	Input   EventUpdateInput
+}) (*eventUpdateResolver, error) {
+	role := httputil.GetRole(ctx)
+	if role == "" {
+		return nil, ErrUnauthorized
+	}
+	if !role.HasPermission(domain.RoleOperator) {
+		return nil, ErrForbidden
+	}
+
+	eventID := string(args.EventID)
+	var notify bool
+	if args.Input.NotifySubscribers != nil {
+		notify = *args.Input.NotifySubscribers
+	} else {
+		event, err := r.events.GetEvent(ctx, eventID)
+		if err != nil {
+			return nil, publicError(ctx, err)
+		}
+		notify = events.DefaultNotifyPolicy(event.Type)
+	}
+
+	var severity *domain.Severity
+	if args.Input.Severity != nil {
+		s := domain.Severity(*args.Input.Severity)
+		severity = &s
+	}
+
+	var before sse.StatusSnapshot
+	if r.publisher != nil {
+		before = r.publisher.Snapshot(ctx)
+	}
+
+	update, err := r.events.AddUpdate(ctx, events.CreateEventUpdateInput{
+		EventID:           eventID,
+		Status:            domain.EventStatus(args.Input.Status),
+		Severity:          severity,
+		Message:           args.Input.Message,
+		NotifySubscribers: notify,
+	}, httputil.GetUserID(ctx))
+	if err != nil {
+		return nil, publicError(ctx, err)
+	}
+
+	if r.publisher != nil {
+		r.publisher.Publish(sse.TypeEventUpdated, update)
+		if before != nil {
+			r.publisher.PublishStatusChanges(before, r.publisher.Snapshot(ctx))
+		}
+	}
+
+	return &eventUpdateResolver{update: update}, nil
+}
+
+// clampLimit caps a requested page size at maxLimit; non-positive sizes also get maxLimit.
+func clampLimit(limit int32, maxLimit int) int {
+	if limit <= 0 || int(limit) > maxLimit {
+		return maxLimit
+	}
+	return int(limit)
+}
+
+// newServiceResolver wraps a service and registers it for batched event loading.
+func (l *loaders) newServiceResolver(service *domain.ServiceWithEffectiveStatus) *serviceResolver {
+	l.serviceEvents.register(service.ID)
+	return &serviceResolver{service: service, loaders: l}
+}
+
+func newEventResolvers(l *loaders, eventsList []*domain.Event) []*eventResolver {
+	result := make([]*eventResolver, 0, len(eventsList))
+	for _, event := range eventsList {
+		result = append(result, &eventResolver{event: event, loaders: l})
+	}
+	return result
+}
+
+type serviceResolver struct {
+	service *domain.ServiceWithEffectiveStatus
+	loaders *loaders
+}
+
+func (r *serviceResolver) ID() graphqlgo.ID            { return graphqlgo.ID(r.service.ID) }
+func (r *serviceResolver) Name() string                { return r.service.Name }
+func (r *serviceResolver) Slug() string                { return r.service.Slug }
+func (r *serviceResolver) Description() string         { return r.service.Description }
+func (r *serviceResolver) Status() string              { return string(r.service.Status) }
+func (r *serviceResolver) EffectiveStatus() string     { return string(r.service.EffectiveStatus) }
+func (r *serviceResolver) HasActiveEvents() bool       { return r.service.HasActiveEvents }
+func (r *serviceResolver) ArchivedAt() *graphqlgo.Time { return toTime(r.service.ArchivedAt) }
+
+func (r *serviceResolver) Groups(ctx context.Context) ([]*groupResolver, error) {
+	groups, err := r.loaders.groupsByID(ctx, r.service.GroupIDs)
+	if err != nil {
+		return nil, publicError(ctx, err)
+	}
+	return groups, nil
+}
+
+// Events resolves Service.events through the batched loader.
+func (r *serviceResolver) Events(ctx context.Context, args struct {
+	Status *string
+	Limit  int32
+	Offset int32
+}) ([]*eventResolver, error) {
+	filter := events.ServiceEventFilter{
+		Limit:  clampLimit(args.Limit, events.MaxListLimit),
+		Offset: max(int(args.Offset), 0),
+	}
+	if args.Status != nil {
+		filter.Status = *args.Status
+	}
+
+	eventsList, err := r.loaders.serviceEvents.load(ctx, r.service.ID, filter)
+	if err != nil {
+		return nil, publicError(ctx, err)
+	}
+	return newEventResolvers(r.loaders, eventsList), nil
+}
+
+type groupResolver struct {
+	group   *domain.GroupWithEffectiveStatus
+	loaders *loaders
+}
+
+func (r *groupResolver) ID() graphqlgo.ID            { return graphqlgo.ID(r.group.ID) }
+func (r *groupResolver) Name() string                { return r.group.Name }
+func (r *groupResolver) Slug() string                { return r.group.Slug }
+func (r *groupResolver) Description() string         { return r.group.Description }
+func (r *groupResolver) EffectiveStatus() string     { return string(r.group.EffectiveStatus) }
+func (r *groupResolver) HasActiveEvents() bool       { return r.group.HasActiveEvents }
+func (r *groupResolver) ArchivedAt() *graphqlgo.Time { return toTime(r.group.ArchivedAt) }
+
+func (r *groupResolver) Services(ctx context.Context) ([]*serviceResolver, error) {
+	services, err := r.loaders.servicesByID(ctx, r.group.ServiceIDs)
+	if err != nil {
+		return nil, publicError(ctx, err)
+	}
+	return services, nil
+}
+
+type eventResolver struct {
+	event   *domain.Event
+	loaders *loaders
+}
+
+func (r *eventResolver) ID() graphqlgo.ID                  { return graphqlgo.ID(r.event.ID) }
+func (r *eventResolver) Title() string                     { return r.event.Title }
+func (r *eventResolver) Type() string                      { return string(r.event.Type) }
+func (r *eventResolver) Status() string                    { return string(r.event.Status) }
+func (r *eventResolver) Description() string               { return r.event.Description }
+func (r *eventResolver) StartedAt() *graphqlgo.Time        { return toTime(r.event.StartedAt) }
+func (r *eventResolver) ResolvedAt() *graphqlgo.Time       { return toTime(r.event.ResolvedAt) }
+func (r *eventResolver) ScheduledStartAt() *graphqlgo.Time { return toTime(r.event.ScheduledStartAt) }
+func (r *eventResolver) ScheduledEndAt() *graphqlgo.Time   { return toTime(r.event.ScheduledEndAt) }
+func (r *eventResolver) NotifySubscribers() bool           { return r.event.NotifySubscribers }
+func (r *eventResolver) CreatedAt() graphqlgo.Time         { return graphqlgo.Time{Time: r.event.CreatedAt} }
+func (r *eventResolver) UpdatedAt() graphqlgo.Time         { return graphqlgo.Time{Time: r.event.UpdatedAt} }
+
+func (r *eventResolver) Severity() *string {
+	if r.event.Severity == nil {
+		return nil
+	}
+	severity := string(*r.event.Severity)
+	return &severity
+}
+
+// DurationSeconds is a Float: GraphQL Int is 32-bit.
+func (r *eventResolver) DurationSeconds() *float64 {
+	if r.event.DurationSeconds == nil {
+		return nil
+	}
+	seconds := float64(*r.event.DurationSeconds)
+	return &seconds
+}
+
+func (r *eventResolver) Services(ctx context.Context) ([]*serviceResolver, error) {
+	services, err := r.loaders.servicesByID(ctx, r.event.ServiceIDs)
+	if err != nil {
+		return nil, publicError(ctx, err)
+	}
+	return services, nil
+}
+
+func (r *eventResolver) Groups(ctx context.Context) ([]*groupResolver, error) {
+	groups, err := r.loaders.groupsByID(ctx, r.event.GroupIDs)
+	if err != nil {
+		return nil, publicError(ctx, err)
+	}
+	return groups, nil
+}
+
+func (r *eventResolver) Updates(ctx context.Context) ([]*eventUpdateResolver, error) {
+	updates, err := r.loaders.events.GetEventUpdates(ctx, r.event.ID)
+	if err != nil {
+		return nil, publicError(ctx, err)
+	}
+	result := make([]*eventUpdateResolver, 0, len(updates))
+	for _, update := range updates {
+		result = append(result, &eventUpdateResolver{update: update})
+	}
+	return result, nil
+}
+
+type eventUpdateResolver struct {
+	update *domain.EventUpdate
+}
+
+func (r *eventUpdateResolver) ID() graphqlgo.ID        { return graphqlgo.ID(r.update.ID) }
+func (r *eventUpdateResolver) EventID() graphqlgo.ID   { return graphqlgo.ID(r.update.EventID) }
+func (r *eventUpdateResolver) Status() string          { return string(r.update.Status) }
+func (r *eventUpdateResolver) Message() string         { return r.update.Message }
+func (r *eventUpdateResolver) NotifySubscribers() bool { return r.update.NotifySubscribers }
+func (r *eventUpdateResolver) CreatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.update.CreatedAt}
+}
+
+func toTime(t *time.Time) *graphqlgo.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphqlgo.Time{Time: *t}
+}
@@ -0,0 +1,244 @@
+// Package healthcheck polls health check URLs of services and opens an incident
+// for a service that keeps failing them, resolving it once the service recovers.
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+)
+
+// maxBodyRead limits how much of a response body is drained before closing it.
+const maxBodyRead = 64 << 10
+
+// Config contains health checker settings.
+type Config struct {
+	Timeout          time.Duration // per request to a health check URL
+	PollInterval     time.Duration // how often to look for due checks
+	FailureThreshold int           // consecutive failures that open an incident
+}
+
+// DefaultConfig returns default health checker configuration.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:          5 * time.Second,
+		PollInterval:     5 * time.Second,
+		FailureThreshold: 2,
+	}
+}
+
+// EventsService is the subset of events.Service used by the health checker.
+type EventsService interface {
+	CreateEvent(ctx context.Context, input events.CreateEventInput, createdBy string) (*domain.Event, error)
+	GetEvent(ctx context.Context, id string) (*domain.Event, error)
+	AddUpdate(ctx context.Context, input events.CreateEventUpdateInput, createdBy string) (*domain.EventUpdate, error)
+}
+
+// HealthChecker periodically polls the health check URL of every service with
+// an enabled check. After FailureThreshold consecutive failures it opens a minor
+// incident that degrades the service; the first successful check resolves it.
+// An incident resolved by hand is left alone, and no new one is opened until
+// the service recovers.
+type HealthChecker struct {
+	config Config
+	repo   HealthCheckRepository
+	events EventsService
+	client *http.Client
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewHealthChecker creates a new health checker.
+func NewHealthChecker(config Config, repo HealthCheckRepository, eventsService EventsService) *HealthChecker {
+	return &HealthChecker{
+		config: config,
+		repo:   repo,
+		events: eventsService,
+		client: &http.Client{Timeout: config.Timeout},
+		stopCh: make(chan struct{}),
+	}
+}
+
+// Start launches the checker goroutine.
+func (c *HealthChecker) Start(ctx context.Context) {
+	slog.InfoContext(ctx, "starting health checker",
+		"timeout", c.config.Timeout,
+		"poll_interval", c.config.PollInterval,
+		"failure_threshold", c.config.FailureThreshold,
+	)
+
+	c.wg.Add(1)
+	go c.run(ctx)
+}
+
+// Stop gracefully stops the checker.
+func (c *HealthChecker) Stop() {
+	close(c.stopCh)
+	c.wg.Wait()
+	slog.Info("health checker stopped")
+}
+
+func (c *HealthChecker) run(ctx context.Context) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.check(ctx)
+		}
+	}
+}
+
+// check polls all due checks concurrently, so a slow URL doesn't delay the others.
+func (c *HealthChecker) check(ctx context.Context) {
+	checks, err := c.repo.ClaimDueChecks(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to claim due health checks", "error", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			if err := c.checkService(ctx, check); err != nil {
+				slog.ErrorContext(ctx, "failed to process health check", "service_id", check.ServiceID, "error", err)
+			}
+		}(check)
+	}
+	wg.Wait()
+}
+
+// checkService polls the URL of a check, opens or resolves its incident and stores the new state.
+// The state is stored even if the incident could not be changed, so the next run retries.
+func (c *HealthChecker) checkService(ctx context.Context, check Check) error {
+	result := Result{ServiceID: check.ServiceID, EventID: check.EventID}
+
+	var incidentErr error
+	if err := c.probe(ctx, check.URL); err != nil {
+		result.Error = err.Error()
+		result.ConsecutiveFailures = check.ConsecutiveFailures + 1
+		slog.WarnContext(ctx, "health check failed",
+			"service_id", check.ServiceID,
+			"consecutive_failures", result.ConsecutiveFailures,
+			"error", err,
+		)
+
+		if result.EventID == nil && result.ConsecutiveFailures >= c.config.FailureThreshold {
+			event, err := c.openIncident(ctx, check, result)
+			if err != nil {
+				incidentErr = fmt.Errorf("open incident: %w", err)
+			} else {
+				result.EventID = &event.ID
+				slog.InfoContext(ctx, "health check incident opened", "service_id", check.ServiceID, "event_id", event.ID)
+			}
+		}
+	} else if result.EventID != nil {
+		if err := c.resolveIncident(ctx, *result.EventID); err != nil {
+			incidentErr = fmt.Errorf("resolve incident: %w", err)
+		} else {
+			slog.InfoContext(ctx, "health check incident resolved", "service_id", check.ServiceID, "event_id", *result.EventID)
+			result.EventID = nil
+		}
+	}
+
+	if err := c.repo.SaveResult(ctx, result); err != nil {
+		return err
+	}
+	return incidentErr
+}
+
+// probe requests url and returns an error unless it answers 2xx within the timeout.
+func (c *HealthChecker) probe(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodyRead))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// openIncident opens a public incident for a failing service. The description names
+// neither the URL nor the error, which may reveal internal addresses; the error is
+// kept in service_health_checks.last_error.
+func (c *HealthChecker) openIncident(ctx context.Context, check Check, result Result) (*domain.Event, error) {
+	userID, err := c.repo.GetSystemUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	severity := domain.SeverityMinor
+	return c.events.CreateEvent(ctx, events.CreateEventInput{
+		Title:  fmt.Sprintf("%s health check failing", check.ServiceName),
+		Type:   domain.EventTypeIncident,
+		Status: domain.EventStatusInvestigating,
+		Description: fmt.Sprintf("%d consecutive automated health checks of %s failed. We are investigating.",
+			result.ConsecutiveFailures, check.ServiceName),
+		Severity:          &severity,
+		NotifySubscribers: true,
+		AffectedServices: []domain.AffectedService{
+			{ServiceID: check.ServiceID, Status: domain.SeverityToServiceStatus(domain.EventTypeIncident, &severity)},
+		},
+		StatusChange: statusChange(),
+	}, userID)
+}
+
+// resolveIncident resolves the incident of a recovered service unless it is already resolved or deleted.
+func (c *HealthChecker) resolveIncident(ctx context.Context, eventID string) error {
+	event, err := c.events.GetEvent(ctx, eventID)
+	if errors.Is(err, events.ErrEventNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get event: %w", err)
+	}
+	if event.Status.IsResolved() {
+		return nil
+	}
+
+	userID, err := c.repo.GetSystemUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.events.AddUpdate(ctx, events.CreateEventUpdateInput{
+		EventID:           eventID,
+		Status:            domain.EventStatusResolved,
+		Message:           "Health check is passing again",
+		NotifySubscribers: event.NotifySubscribers,
+		StatusChange:      statusChange(),
+	}, userID)
+	return err
+}
+
+// statusChange returns the status log source of the changes made by health checks.
+func statusChange() events.StatusChangeContext {
+	return events.StatusChangeContext{SourceType: domain.StatusLogSourceHealthCheck}
+}
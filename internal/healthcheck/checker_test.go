@@ -0,0 +1,201 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRepo keeps the state of a single check that is always due.
+type stubRepo struct {
+	check   Check
+	results []Result
+}
+
+func (r *stubRepo) ClaimDueChecks(_ context.Context) ([]Check, error) {
+	return []Check{r.check}, nil
+}
+
+func (r *stubRepo) SaveResult(_ context.Context, result Result) error {
+	r.results = append(r.results, result)
+	r.check.ConsecutiveFailures = result.ConsecutiveFailures
+	r.check.EventID = result.EventID
+	return nil
+}
+
+func (r *stubRepo) GetSystemUserID(_ context.Context) (string, error) {
+	return "system-user", nil
+}
+
+type stubEvents struct {
+	events    map[string]*domain.Event
+	created   []events.CreateEventInput
+	updates   []events.CreateEventUpdateInput
+	createErr error
+}
+
+func (e *stubEvents) CreateEvent(_ context.Context, input events.CreateEventInput, createdBy string) (*domain.Event, error) {
+	if e.createErr != nil {
+		return nil, e.createErr
+	}
+	e.created = append(e.created, input)
+	event := &domain.Event{ID: "evt-1", Status: input.Status, CreatedBy: createdBy, NotifySubscribers: input.NotifySubscribers}
+	e.events[event.ID] = event
+	return event, nil
+}
+
+func (e *stubEvents) GetEvent(_ context.Context, id string) (*domain.Event, error) {
+	event, ok := e.events[id]
+	if !ok {
+		return nil, events.ErrEventNotFound
+	}
+	return event, nil
+}
+
+func (e *stubEvents) AddUpdate(_ context.Context, input events.CreateEventUpdateInput, _ string) (*domain.EventUpdate, error) {
+	e.updates = append(e.updates, input)
+	e.events[input.EventID].Status = input.Status
+	return &domain.EventUpdate{EventID: input.EventID, Status: input.Status}, nil
+}
+
+// newTarget starts a server answering 200 while healthy is set and 503 otherwise.
+func newTarget(t *testing.T, healthy *atomic.Bool) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestChecker(url string) (*HealthChecker, *stubRepo, *stubEvents) {
+	repo := &stubRepo{check: Check{ServiceID: "svc-1", ServiceName: "API", URL: url, Interval: time.Minute}}
+	eventsService := &stubEvents{events: make(map[string]*domain.Event)}
+	return NewHealthChecker(DefaultConfig(), repo, eventsService), repo, eventsService
+}
+
+func TestHealthChecker_FailureAndRecovery(t *testing.T) {
+	var healthy atomic.Bool
+	srv := newTarget(t, &healthy)
+	checker, repo, eventsService := newTestChecker(srv.URL)
+	ctx := context.Background()
+
+	// First failure is tolerated
+	checker.check(ctx)
+	assert.Equal(t, 1, repo.check.ConsecutiveFailures)
+	assert.Empty(t, eventsService.created)
+	assert.Contains(t, repo.results[0].Error, "503")
+
+	// Second consecutive failure opens a minor incident degrading the service
+	checker.check(ctx)
+	require.Len(t, eventsService.created, 1)
+	created := eventsService.created[0]
+	assert.Equal(t, "API health check failing", created.Title)
+	assert.Equal(t, domain.EventStatusInvestigating, created.Status)
+	assert.Equal(t, domain.SeverityMinor, *created.Severity)
+	assert.Equal(t, []domain.AffectedService{{ServiceID: "svc-1", Status: domain.ServiceStatusDegraded}}, created.AffectedServices)
+	assert.Equal(t, domain.StatusLogSourceHealthCheck, created.StatusChange.SourceType)
+	require.NotNil(t, repo.check.EventID)
+	assert.Equal(t, "evt-1", *repo.check.EventID)
+
+	// Further failures keep the incident without opening another
+	checker.check(ctx)
+	assert.Len(t, eventsService.created, 1)
+	assert.Equal(t, 3, repo.check.ConsecutiveFailures)
+
+	// Recovery resolves the incident and resets the state
+	healthy.Store(true)
+	checker.check(ctx)
+	require.Len(t, eventsService.updates, 1)
+	update := eventsService.updates[0]
+	assert.Equal(t, "evt-1", update.EventID)
+	assert.Equal(t, domain.EventStatusResolved, update.Status)
+	assert.Equal(t, domain.StatusLogSourceHealthCheck, update.StatusChange.SourceType)
+	assert.Equal(t, 0, repo.check.ConsecutiveFailures)
+	assert.Nil(t, repo.check.EventID)
+	assert.Empty(t, repo.results[len(repo.results)-1].Error)
+}
+
+func TestHealthChecker_SuccessResetsFailures(t *testing.T) {
+	var healthy atomic.Bool
+	srv := newTarget(t, &healthy)
+	checker, repo, eventsService := newTestChecker(srv.URL)
+	ctx := context.Background()
+
+	checker.check(ctx)
+	healthy.Store(true)
+	checker.check(ctx)
+	healthy.Store(false)
+	checker.check(ctx)
+
+	assert.Equal(t, 1, repo.check.ConsecutiveFailures, "failures must be consecutive")
+	assert.Empty(t, eventsService.created)
+}
+
+func TestHealthChecker_ManuallyResolvedIncident(t *testing.T) {
+	var healthy atomic.Bool
+	healthy.Store(true)
+	srv := newTarget(t, &healthy)
+	checker, repo, eventsService := newTestChecker(srv.URL)
+
+	eventID := "evt-manual"
+	eventsService.events[eventID] = &domain.Event{ID: eventID, Status: domain.EventStatusResolved}
+	repo.check.EventID = &eventID
+	repo.check.ConsecutiveFailures = 4
+
+	checker.check(context.Background())
+
+	assert.Empty(t, eventsService.updates, "an incident resolved by hand is not updated")
+	assert.Nil(t, repo.check.EventID)
+	assert.Equal(t, 0, repo.check.ConsecutiveFailures)
+}
+
+func TestHealthChecker_OpenIncidentRetried(t *testing.T) {
+	var healthy atomic.Bool
+	srv := newTarget(t, &healthy)
+	checker, repo, eventsService := newTestChecker(srv.URL)
+	ctx := context.Background()
+
+	eventsService.createErr = errors.New("database is down")
+	checker.check(ctx)
+	checker.check(ctx)
+	assert.Equal(t, 2, repo.check.ConsecutiveFailures, "the state is saved even if the incident was not opened")
+	assert.Nil(t, repo.check.EventID)
+
+	eventsService.createErr = nil
+	checker.check(ctx)
+	assert.Len(t, eventsService.created, 1)
+	assert.NotNil(t, repo.check.EventID)
+}
+
+func TestHealthChecker_Timeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	config := DefaultConfig()
+	config.Timeout = 50 * time.Millisecond
+	checker := NewHealthChecker(config, &stubRepo{}, &stubEvents{})
+
+	err := checker.probe(context.Background(), srv.URL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Client.Timeout")
+}
@@ -0,0 +1,106 @@
+// Package postgres provides PostgreSQL implementation of health check repository.
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/healthcheck"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// systemUserEmail identifies the user created by migration 000056.
+const systemUserEmail = "health-checks@incident-garden.local"
+
+// Repository implements healthcheck.HealthCheckRepository using PostgreSQL.
+type Repository struct {
+	db *pgxpool.Pool
+}
+
+// NewRepository creates a new PostgreSQL repository.
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{db: db}
+}
+
+// ClaimDueChecks returns due checks and schedules their next run.
+func (r *Repository) ClaimDueChecks(ctx context.Context) ([]healthcheck.Check, error) {
+	// Services with a newly enabled check get their state row, due immediately
+	insertQuery := `
+		INSERT INTO service_health_checks (service_id, next_check_at)
+		SELECT id, NOW() FROM services
+		WHERE health_check_interval_seconds > 0 AND health_check_url IS NOT NULL AND archived_at IS NULL
+		ON CONFLICT (service_id) DO NOTHING
+	`
+	if _, err := r.db.Exec(ctx, insertQuery); err != nil {
+		return nil, fmt.Errorf("init health checks: %w", err)
+	}
+
+	claimQuery := `
+		UPDATE service_health_checks hc
+		SET next_check_at = NOW() + make_interval(secs => s.health_check_interval_seconds)
+		FROM services s
+		WHERE s.id = hc.service_id
+			AND s.health_check_interval_seconds > 0 AND s.health_check_url IS NOT NULL AND s.archived_at IS NULL
+			AND hc.next_check_at <= NOW()
+		RETURNING hc.service_id, s.name, s.health_check_url, s.health_check_interval_seconds,
+			hc.consecutive_failures, hc.event_id
+	`
+	rows, err := r.db.Query(ctx, claimQuery)
+	if err != nil {
+		return nil, fmt.Errorf("claim due health checks: %w", err)
+	}
+	defer rows.Close()
+
+	checks := make([]healthcheck.Check, 0)
+	for rows.Next() {
+		var check healthcheck.Check
+		var intervalSeconds int
+		if err := rows.Scan(
+			&check.ServiceID,
+			&check.ServiceName,
+			&check.URL,
+			&intervalSeconds,
+			&check.ConsecutiveFailures,
+			&check.EventID,
+		); err != nil {
+			return nil, fmt.Errorf("scan health check: %w", err)
+		}
+		check.Interval = time.Duration(intervalSeconds) * time.Second
+		checks = append(checks, check)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate health checks: %w", err)
+	}
+	return checks, nil
+}
+
+// SaveResult stores the state of a check after polling it.
+func (r *Repository) SaveResult(ctx context.Context, result healthcheck.Result) error {
+	query := `
+		UPDATE service_health_checks
+		SET consecutive_failures = $2, last_checked_at = NOW(), last_error = NULLIF($3, ''), event_id = $4
+		WHERE service_id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query,
+		result.ServiceID,
+		result.ConsecutiveFailures,
+		result.Error,
+		result.EventID,
+	); err != nil {
+		return fmt.Errorf("save health check result: %w", err)
+	}
+	return nil
+}
+
+// GetSystemUserID returns the ID of the user that owns incidents opened by health checks.
+func (r *Repository) GetSystemUserID(ctx context.Context) (string, error) {
+	query := `SELECT id FROM users WHERE email = $1`
+
+	var id string
+	if err := r.db.QueryRow(ctx, query, systemUserEmail).Scan(&id); err != nil {
+		return "", fmt.Errorf("get health check system user: %w", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,36 @@
+package healthcheck
+
+import (
+	"context"
+	"time"
+)
+
+// Check is a due health check of a service together with its state.
+type Check struct {
+	ServiceID           string
+	ServiceName         string
+	URL                 string
+	Interval            time.Duration
+	ConsecutiveFailures int
+	EventID             *string // incident opened for the failures; nil while healthy
+}
+
+// Result is the state of a health check after polling it.
+type Result struct {
+	ServiceID           string
+	ConsecutiveFailures int
+	Error               string  // "" if the check succeeded
+	EventID             *string // incident opened for the failures; nil while healthy
+}
+
+// HealthCheckRepository stores pending health checks and their state.
+type HealthCheckRepository interface {
+	// ClaimDueChecks returns enabled checks of non-archived services that are due and
+	// schedules their next run one interval later, so concurrent instances poll each
+	// check once. Services checked for the first time are due immediately.
+	ClaimDueChecks(ctx context.Context) ([]Check, error)
+	// SaveResult stores the state of a check after polling it.
+	SaveResult(ctx context.Context, result Result) error
+	// GetSystemUserID returns the ID of the user that owns incidents opened by health checks.
+	GetSystemUserID(ctx context.Context) (string, error)
+}
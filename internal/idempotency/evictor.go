@@ -0,0 +1,70 @@
+package idempotency
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Evictor periodically deletes expired idempotency keys.
+type Evictor struct {
+	store    IdempotencyStore
+	interval time.Duration
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewEvictor creates a new evictor of expired keys.
+func NewEvictor(store IdempotencyStore, interval time.Duration) *Evictor {
+	return &Evictor{
+		store:    store,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start launches the evictor goroutine.
+func (e *Evictor) Start(ctx context.Context) {
+	slog.InfoContext(ctx, "starting idempotency key evictor", "interval", e.interval)
+
+	e.wg.Add(1)
+	go e.run(ctx)
+}
+
+// Stop gracefully stops the evictor.
+func (e *Evictor) Stop() {
+	close(e.stopCh)
+	e.wg.Wait()
+	slog.Info("idempotency key evictor stopped")
+}
+
+func (e *Evictor) run(ctx context.Context) {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.stopCh:
+			return
+		case <-ticker.C:
+			e.evict(ctx)
+		}
+	}
+}
+
+func (e *Evictor) evict(ctx context.Context) {
+	deleted, err := e.store.DeleteExpired(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to delete expired idempotency keys", "error", err)
+		return
+	}
+	if deleted > 0 {
+		slog.InfoContext(ctx, "expired idempotency keys deleted", "count", deleted)
+	}
+}
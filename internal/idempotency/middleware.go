@@ -0,0 +1,134 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/google/uuid"
+)
+
+// Header names.
+const (
+	// KeyHeader carries the client-supplied key of a request, a UUID.
+	KeyHeader = "Idempotency-Key"
+	// ReplayedHeader is set to "true" on responses replayed from the store.
+	ReplayedHeader = "Idempotent-Replayed"
+)
+
+// DefaultTTL is how long the response to a key is replayed.
+const DefaultTTL = 24 * time.Hour
+
+// reservationLease bounds how long a key stays reserved by a request that never
+// completes (e.g. the instance crashed), after which the key can be used again.
+const reservationLease = time.Minute
+
+// Middleware replays stored responses for authenticated requests with an Idempotency-Key.
+// The first request with a key is processed and its 2xx response is stored for ttl; retries
+// with the same key get that body with 200 instead of being processed again. A key whose
+// request failed is released, so the retry is processed. Requests without the header pass through.
+//
+// A key reused for another path → 422; a retry while the first request is still
+// in progress → 409; a key that is not a UUID → 400.
+func Middleware(store IdempotencyStore, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(KeyHeader)
+			userID := httputil.GetUserID(r.Context())
+			if key == "" || userID == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			parsed, err := uuid.Parse(key)
+			if err != nil {
+				httputil.Error(w, http.StatusBadRequest, KeyHeader+" must be a UUID")
+				return
+			}
+			key = parsed.String()
+
+			reserved, err := store.Reserve(r.Context(), userID, key, r.URL.Path, reservationLease)
+			if err != nil {
+				httputil.HandleError(r.Context(), w, err, nil)
+				return
+			}
+			if !reserved {
+				replay(w, r, store, userID, key)
+				return
+			}
+
+			rec := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+
+			// The response is already sent: store it even if the client went away
+			ctx := context.WithoutCancel(r.Context())
+			if rec.statusCode() >= http.StatusOK && rec.statusCode() < http.StatusMultipleChoices {
+				if err := store.Complete(ctx, userID, key, rec.statusCode(), rec.body.Bytes(), ttl); err != nil {
+					slog.ErrorContext(ctx, "failed to store idempotent response", "key", key, "error", err)
+				}
+				return
+			}
+			if err := store.Release(ctx, userID, key); err != nil {
+				slog.ErrorContext(ctx, "failed to release idempotency key", "key", key, "error", err)
+			}
+		})
+	}
+}
+
+// replay answers a request whose key is already taken.
+func replay(w http.ResponseWriter, r *http.Request, store IdempotencyStore, userID, key string) {
+	record, err := store.Get(r.Context(), userID, key)
+	if errors.Is(err, ErrKeyNotFound) {
+		// Released or evicted between Reserve and Get: the first request has finished
+		httputil.Error(w, http.StatusConflict, "request with this "+KeyHeader+" is in progress, retry later")
+		return
+	}
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, nil)
+		return
+	}
+
+	switch {
+	case record.RequestPath != r.URL.Path:
+		httputil.Error(w, http.StatusUnprocessableEntity, KeyHeader+" was already used for another request")
+	case !record.Completed():
+		httputil.Error(w, http.StatusConflict, "request with this "+KeyHeader+" is in progress, retry later")
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(ReplayedHeader, "true")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(record.Body)
+	}
+}
+
+// responseRecorder passes a response through and keeps a copy of its status and body.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+func (rec *responseRecorder) statusCode() int {
+	if rec.status == 0 {
+		return http.StatusOK
+	}
+	return rec.status
+}
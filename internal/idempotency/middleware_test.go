@@ -0,0 +1,185 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore is an in-memory IdempotencyStore.
+type memoryStore struct {
+	mu      sync.Mutex
+	now     time.Time
+	records map[string]*Record
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{now: time.Now(), records: make(map[string]*Record)}
+}
+
+func (s *memoryStore) Reserve(_ context.Context, userID, key, requestPath string, lease time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := s.records[userID+"/"+key]; ok && r.ExpiresAt.After(s.now) {
+		return false, nil
+	}
+	s.records[userID+"/"+key] = &Record{RequestPath: requestPath, ExpiresAt: s.now.Add(lease)}
+	return true, nil
+}
+
+func (s *memoryStore) Get(_ context.Context, userID, key string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.records[userID+"/"+key]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	copied := *r
+	return &copied, nil
+}
+
+func (s *memoryStore) Complete(_ context.Context, userID, key string, statusCode int, body []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := s.records[userID+"/"+key]
+	r.StatusCode, r.Body, r.ExpiresAt = statusCode, body, s.now.Add(ttl)
+	return nil
+}
+
+func (s *memoryStore) Release(_ context.Context, userID, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, userID+"/"+key)
+	return nil
+}
+
+func (s *memoryStore) DeleteExpired(_ context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deleted int64
+	for k, r := range s.records {
+		if !r.ExpiresAt.After(s.now) {
+			delete(s.records, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+const testKey = "0b7c3a5e-7f64-4a2e-9c1d-2f6e8b9a0c41"
+
+// countingHandler answers status with a body numbering its calls.
+type countingHandler struct {
+	status int
+	calls  int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	h.calls++
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(h.status)
+	_, _ = w.Write([]byte(`{"data":{"call":` + strconv.Itoa(h.calls) + `}}`))
+}
+
+func send(handler http.Handler, userID, path, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{}`))
+	if key != "" {
+		req.Header.Set(KeyHeader, key)
+	}
+	if userID != "" {
+		req = req.WithContext(context.WithValue(req.Context(), httputil.UserIDKey, userID))
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddleware_Replay(t *testing.T) {
+	store := newMemoryStore()
+	next := &countingHandler{status: http.StatusCreated}
+	handler := Middleware(store, DefaultTTL)(next)
+
+	first := send(handler, "u1", "/events/e1/updates", testKey)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, `{"data":{"call":1}}`, first.Body.String())
+	assert.Empty(t, first.Header().Get(ReplayedHeader))
+
+	retry := send(handler, "u1", "/events/e1/updates", testKey)
+	assert.Equal(t, http.StatusOK, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get(ReplayedHeader))
+	assert.Equal(t, 1, next.calls, "the retry is not processed")
+
+	// Keys are scoped by user
+	other := send(handler, "u2", "/events/e1/updates", testKey)
+	assert.Equal(t, http.StatusCreated, other.Code)
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestMiddleware_Expired(t *testing.T) {
+	store := newMemoryStore()
+	next := &countingHandler{status: http.StatusCreated}
+	handler := Middleware(store, DefaultTTL)(next)
+
+	send(handler, "u1", "/events/e1/updates", testKey)
+	store.now = store.now.Add(DefaultTTL + time.Second)
+
+	rec := send(handler, "u1", "/events/e1/updates", testKey)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, `{"data":{"call":2}}`, rec.Body.String())
+
+	deleted, err := store.DeleteExpired(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, deleted, "the key was taken over, not left expired")
+}
+
+func TestMiddleware_FailedRequestReleasesKey(t *testing.T) {
+	store := newMemoryStore()
+	next := &countingHandler{status: http.StatusConflict}
+	handler := Middleware(store, DefaultTTL)(next)
+
+	assert.Equal(t, http.StatusConflict, send(handler, "u1", "/events/e1/updates", testKey).Code)
+
+	next.status = http.StatusCreated
+	assert.Equal(t, http.StatusCreated, send(handler, "u1", "/events/e1/updates", testKey).Code)
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestMiddleware_Rejects(t *testing.T) {
+	store := newMemoryStore()
+	next := &countingHandler{status: http.StatusCreated}
+	handler := Middleware(store, DefaultTTL)(next)
+
+	assert.Equal(t, http.StatusBadRequest, send(handler, "u1", "/events/e1/updates", "not-a-uuid").Code)
+
+	send(handler, "u1", "/events/e1/updates", testKey)
+	assert.Equal(t, http.StatusUnprocessableEntity, send(handler, "u1", "/events/e2/updates", testKey).Code)
+
+	// A reserved key whose request is still running
+	_, err := store.Reserve(context.Background(), "u1", "6f1c2d3e-4b5a-4c6d-8e7f-9a0b1c2d3e4f", "/events/e1/updates", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, send(handler, "u1", "/events/e1/updates", "6f1c2d3e-4b5a-4c6d-8e7f-9a0b1c2d3e4f").Code)
+
+	assert.Equal(t, 1, next.calls)
+}
+
+func TestMiddleware_PassesThrough(t *testing.T) {
+	store := newMemoryStore()
+	next := &countingHandler{status: http.StatusCreated}
+	handler := Middleware(store, DefaultTTL)(next)
+
+	// Without a key every request is processed
+	send(handler, "u1", "/events/e1/updates", "")
+	send(handler, "u1", "/events/e1/updates", "")
+	assert.Equal(t, 2, next.calls)
+	assert.Empty(t, store.records)
+}
@@ -0,0 +1,94 @@
+// Package postgres provides PostgreSQL implementation of the idempotency key store.
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/idempotency"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Store implements idempotency.IdempotencyStore using PostgreSQL.
+type Store struct {
+	db *pgxpool.Pool
+}
+
+// NewStore creates a new PostgreSQL idempotency key store.
+func NewStore(db *pgxpool.Pool) *Store {
+	return &Store{db: db}
+}
+
+// Reserve records a key as in progress, taking over an expired record of the same key.
+func (s *Store) Reserve(ctx context.Context, userID, key, requestPath string, lease time.Duration) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (user_id, idempotency_key, request_path, expires_at)
+		VALUES ($1, $2, $3, NOW() + make_interval(secs => $4))
+		ON CONFLICT (user_id, idempotency_key) DO UPDATE
+		SET request_path = EXCLUDED.request_path, status_code = NULL, response_body = NULL,
+			created_at = NOW(), expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= NOW()
+	`
+
+	result, err := s.db.Exec(ctx, query, userID, key, requestPath, lease.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("reserve idempotency key: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// Get returns the record of a key.
+func (s *Store) Get(ctx context.Context, userID, key string) (*idempotency.Record, error) {
+	query := `
+		SELECT request_path, COALESCE(status_code, 0), response_body, expires_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND idempotency_key = $2
+	`
+
+	var record idempotency.Record
+	err := s.db.QueryRow(ctx, query, userID, key).
+		Scan(&record.RequestPath, &record.StatusCode, &record.Body, &record.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, idempotency.ErrKeyNotFound
+		}
+		return nil, fmt.Errorf("get idempotency key: %w", err)
+	}
+	return &record, nil
+}
+
+// Complete stores the response to the request of a reserved key.
+func (s *Store) Complete(ctx context.Context, userID, key string, statusCode int, body []byte, ttl time.Duration) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $3, response_body = $4, expires_at = NOW() + make_interval(secs => $5)
+		WHERE user_id = $1 AND idempotency_key = $2
+	`
+
+	if _, err := s.db.Exec(ctx, query, userID, key, statusCode, body, ttl.Seconds()); err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release deletes a reserved key whose request failed.
+func (s *Store) Release(ctx context.Context, userID, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2 AND status_code IS NULL`
+
+	if _, err := s.db.Exec(ctx, query, userID, key); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired deletes expired records.
+func (s *Store) DeleteExpired(ctx context.Context) (int64, error) {
+	result, err := s.db.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,41 @@
+// Package idempotency makes retried write requests safe: the response to a request
+// sent with an Idempotency-Key header is stored and replayed for retries with the same key.
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrKeyNotFound is returned for a key without a stored record.
+var ErrKeyNotFound = errors.New("idempotency key not found")
+
+// Record is a stored idempotency key of a user.
+type Record struct {
+	RequestPath string
+	StatusCode  int // 0 while the first request with the key is in progress
+	Body        []byte
+	ExpiresAt   time.Time
+}
+
+// Completed reports whether the response of the first request is stored.
+func (r *Record) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// IdempotencyStore stores idempotency keys and the responses to their requests.
+// Keys are scoped by user.
+type IdempotencyStore interface {
+	// Reserve records a key as in progress for requestPath until lease passes, taking
+	// over an expired record of the same key. Returns false if an unexpired record exists.
+	Reserve(ctx context.Context, userID, key, requestPath string, lease time.Duration) (bool, error)
+	// Get returns the record of a key, or ErrKeyNotFound.
+	Get(ctx context.Context, userID, key string) (*Record, error)
+	// Complete stores the response to the request of a reserved key, kept for ttl.
+	Complete(ctx context.Context, userID, key string, statusCode int, body []byte, ttl time.Duration) error
+	// Release deletes a reserved key whose request failed, so it can be retried.
+	Release(ctx context.Context, userID, key string) error
+	// DeleteExpired deletes expired records and returns how many were deleted.
+	DeleteExpired(ctx context.Context) (int64, error)
+}
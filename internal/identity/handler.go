@@ -1,12 +1,15 @@
 package identity
 
 import (
+	"context"
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bissquit/incident-garden/internal/domain"
@@ -21,12 +24,18 @@ var errorMappings = []httputil.ErrorMapping{
 	{Error: ErrEmailExists, Status: http.StatusConflict},
 	{Error: ErrInvalidCredentials, Status: http.StatusUnauthorized},
 	{Error: ErrInvalidToken, Status: http.StatusUnauthorized},
-	{Error: ErrAccountDeactivated, Status: http.StatusForbidden},
+	{Error: ErrAccountDeactivated, Status: http.StatusUnauthorized},
 	{Error: ErrWrongPassword, Status: http.StatusBadRequest, Message: "wrong current password"},
 	{Error: ErrEmailNotConfigured, Status: http.StatusBadRequest, Message: "email is not configured, contact your administrator"},
 	{Error: ErrInvalidResetToken, Status: http.StatusBadRequest, Message: "invalid or expired reset token"},
 	{Error: ErrCannotModifySelf, Status: http.StatusConflict, Message: "cannot modify your own account"},
 	{Error: ErrInvalidRole, Status: http.StatusBadRequest, Message: "invalid role"},
+	{Error: ErrInvalidName, Status: http.StatusBadRequest, Message: "name must be between 1 and 100 characters"},
+	{Error: ErrOIDCStateMismatch, Status: http.StatusBadRequest},
+	{Error: ErrOIDCLoginFailed, Status: http.StatusUnauthorized, Message: "oidc login failed"},
+	{Error: ErrOIDCEmailNotVerified, Status: http.StatusUnauthorized, Message: "email not verified by identity provider"},
+	{Error: ErrOIDCAccountLinked, Status: http.StatusConflict, Message: "account is linked to another identity"},
+	{Error: ErrOIDCProviderUnavailable, Status: http.StatusBadGateway, Message: "identity provider unavailable"},
 }
 
 // Pagination defaults for user listing.
@@ -48,14 +57,32 @@ type Handler struct {
 	service        *Service
 	validator      *validator.Validate
 	cookieSettings CookieSettings
+	oidc           OIDCProvider  // nil if OIDC login is disabled
+	audit          AuditRecorder // nil if admin actions are not audited
 }
 
+// AuditRecorder records admin actions in the audit log.
+// This interface is implemented by admin.AuditLogger.
+type AuditRecorder interface {
+	RecordChange(ctx context.Context, action, targetType, targetID string, before, after interface{})
+}
+
+// Audit log action and target type of admin user updates.
+const (
+	auditActionUserUpdate = "user.update"
+	auditTargetUser       = "user"
+)
+
 // NewHandler creates a new identity handler.
-func NewHandler(service *Service, cookieSettings CookieSettings) *Handler {
+// oidc is optional: without it the /auth/oidc routes are not registered.
+// audit may be nil, in which case admin user updates are not audited.
+func NewHandler(service *Service, cookieSettings CookieSettings, oidc OIDCProvider, audit AuditRecorder) *Handler {
 	return &Handler{
 		service:        service,
 		validator:      validator.New(),
 		cookieSettings: cookieSettings,
+		oidc:           oidc,
+		audit:          audit,
 	}
 }
 
@@ -68,6 +95,11 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 		r.Post("/logout", h.Logout)
 		r.Post("/forgot-password", h.ForgotPassword)
 		r.Post("/reset-password", h.ResetPassword)
+
+		if h.oidc != nil {
+			r.Get("/oidc/login", h.OIDCLogin)
+			r.Post("/oidc/callback", h.OIDCCallback)
+		}
 	})
 }
 
@@ -146,9 +178,10 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 }
 
 // Refresh handles POST /auth/refresh.
-// Reads refresh_token from cookie, issues new tokens.
+// Reads refresh_token from cookie or body, issues new tokens. The refresh token
+// is rotated: API clients that sent it in the body get the new pair in the response.
 func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
-	refreshToken := h.getRefreshTokenFromRequest(r)
+	refreshToken, fromBody := h.getRefreshTokenFromRequest(r)
 	if refreshToken == "" {
 		httputil.Error(w, http.StatusBadRequest, "missing refresh token")
 		return
@@ -162,13 +195,17 @@ func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
 
 	h.setAuthCookies(w, tokens)
 
+	if fromBody {
+		httputil.Success(w, http.StatusOK, tokens)
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
 // Logout handles POST /auth/logout.
 // Reads refresh_token from cookie, invalidates it, clears all auth cookies.
 func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
-	refreshToken := h.getRefreshTokenFromRequest(r)
+	refreshToken, _ := h.getRefreshTokenFromRequest(r)
 	if refreshToken != "" {
 		if err := h.service.Logout(r.Context(), refreshToken); err != nil {
 			ctxlog.FromContext(r.Context()).Warn("logout error", "error", err)
@@ -234,11 +271,15 @@ func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 
 // UpdateProfileRequest represents profile update request body.
 type UpdateProfileRequest struct {
-	FirstName *string `json:"first_name" validate:"omitempty,max=100"`
-	LastName  *string `json:"last_name" validate:"omitempty,max=100"`
+	Name            *string `json:"name"`
+	FirstName       *string `json:"first_name" validate:"omitempty,max=100"`
+	LastName        *string `json:"last_name" validate:"omitempty,max=100"`
+	Password        *string `json:"password" validate:"omitempty,min=8"`
+	CurrentPassword string  `json:"current_password" validate:"required_with=Password"`
 }
 
 // UpdateProfile handles PATCH /me.
+// Changing the password ends all sessions like PUT /me/password does.
 func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	userID := httputil.GetUserID(r.Context())
 
@@ -253,16 +294,27 @@ func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.service.UpdateProfile(r.Context(), UpdateProfileInput{
-		UserID:    userID,
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
+	err := h.service.UpdateUserProfile(r.Context(), userID, UpdateUserProfileInput{
+		Name:            req.Name,
+		FirstName:       req.FirstName,
+		LastName:        req.LastName,
+		Password:        req.Password,
+		CurrentPassword: req.CurrentPassword,
 	})
 	if err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
 	}
 
+	user, err := h.service.GetUserByID(r.Context(), userID)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	if req.Password != nil {
+		h.clearAuthCookies(w)
+	}
 	httputil.Success(w, http.StatusOK, user)
 }
 
@@ -335,6 +387,7 @@ func (h *Handler) RegisterAdminRoutes(r chi.Router) {
 		r.Post("/", h.AdminCreateUser)
 		r.Get("/{id}", h.GetUser)
 		r.Patch("/{id}", h.AdminUpdateUser)
+		r.Delete("/{id}", h.AdminDeactivateUser)
 		r.Post("/{id}/reset-password", h.AdminResetPassword)
 	})
 }
@@ -457,6 +510,16 @@ func (h *Handler) AdminUpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var before map[string]interface{}
+	if h.audit != nil {
+		existing, err := h.service.GetUserByID(r.Context(), targetID)
+		if err != nil {
+			httputil.HandleError(r.Context(), w, err, errorMappings)
+			return
+		}
+		before = auditedUserFields(existing)
+	}
+
 	user, err := h.service.AdminUpdateUser(r.Context(), adminUserID, AdminUpdateUserInput{
 		UserID:    targetID,
 		Role:      req.Role,
@@ -468,10 +531,36 @@ func (h *Handler) AdminUpdateUser(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
 	}
+	if h.audit != nil {
+		h.audit.RecordChange(r.Context(), auditActionUserUpdate, auditTargetUser, user.ID, before, auditedUserFields(user))
+	}
 
 	httputil.Success(w, http.StatusOK, user)
 }
 
+// auditedUserFields returns the user fields an admin may change, as recorded in the audit log.
+func auditedUserFields(user *domain.User) map[string]interface{} {
+	return map[string]interface{}{
+		"role":       user.Role,
+		"is_active":  user.IsActive,
+		"first_name": user.FirstName,
+		"last_name":  user.LastName,
+	}
+}
+
+// AdminDeactivateUser handles DELETE /users/{id}.
+func (h *Handler) AdminDeactivateUser(w http.ResponseWriter, r *http.Request) {
+	targetID := chi.URLParam(r, "id")
+	adminUserID := httputil.GetUserID(r.Context())
+
+	if err := h.service.AdminDeactivateUser(r.Context(), adminUserID, targetID); err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // AdminResetPasswordRequest represents admin password reset request body.
 type AdminResetPasswordRequest struct {
 	NewPassword string `json:"new_password" validate:"required,min=8"`
@@ -505,6 +594,110 @@ func (h *Handler) AdminResetPassword(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// OIDC login state cookie: "<state>.<nonce>". Scoped to /api so the callback of
+// every API version reads it.
+const (
+	oidcStateCookie     = "oidc_state"
+	oidcStateCookiePath = "/api"
+	oidcStateTTL        = 10 * time.Minute
+)
+
+// Refresh token cookie paths. The cookie is scoped to /api so /api/v1/auth and
+// /api/v2/auth share it; cookies set before v2 used the v1 path and are expired.
+const (
+	refreshTokenCookiePath       = "/api"
+	legacyRefreshTokenCookiePath = "/api/v1/auth"
+)
+
+// OIDCLogin handles GET /auth/oidc/login.
+// Redirects to the provider with a fresh state and nonce, remembered in a short-lived cookie.
+func (h *Handler) OIDCLogin(w http.ResponseWriter, r *http.Request) {
+	state, nonce := generateCSRFToken(), generateCSRFToken()
+
+	authURL, err := h.oidc.AuthCodeURL(r.Context(), state, nonce)
+	if err != nil {
+		ctxlog.FromContext(r.Context()).Error("oidc login redirect failed", "error", err)
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state + "." + nonce,
+		Path:     oidcStateCookiePath,
+		Domain:   h.cookieSettings.Domain,
+		MaxAge:   int(oidcStateTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   h.cookieSettings.Secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// OIDCCallbackRequest represents the code and state the provider sent back to the frontend.
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+}
+
+// OIDCCallback handles POST /auth/oidc/callback.
+// Checks state against the login cookie, exchanges the code and logs the user in like /auth/login.
+func (h *Handler) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	var req OIDCCallbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		httputil.ValidationError(w, err)
+		return
+	}
+
+	var state, nonce string
+	if cookie, err := r.Cookie(oidcStateCookie); err == nil {
+		state, nonce, _ = strings.Cut(cookie.Value, ".")
+	}
+	// The state is single-use: clear it whatever the outcome
+	h.clearOIDCStateCookie(w)
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(req.State)) != 1 {
+		httputil.HandleError(r.Context(), w, ErrOIDCStateMismatch, errorMappings)
+		return
+	}
+
+	identity, err := h.oidc.Exchange(r.Context(), req.Code, nonce)
+	if err != nil {
+		ctxlog.FromContext(r.Context()).Warn("oidc code exchange failed", "error", err)
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	user, tokens, err := h.service.LoginOIDC(r.Context(), identity)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	h.setAuthCookies(w, tokens)
+
+	httputil.Success(w, http.StatusOK, LoginResponse{
+		User: user,
+	})
+}
+
+func (h *Handler) clearOIDCStateCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    "",
+		Path:     oidcStateCookiePath,
+		Domain:   h.cookieSettings.Domain,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   h.cookieSettings.Secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
 // setAuthCookies sets access_token, refresh_token, and csrf_token cookies.
 func (h *Handler) setAuthCookies(w http.ResponseWriter, tokens *TokenPair) {
 	// Access token cookie - available to all paths
@@ -519,11 +712,12 @@ func (h *Handler) setAuthCookies(w http.ResponseWriter, tokens *TokenPair) {
 		SameSite: http.SameSiteLaxMode,
 	})
 
-	// Refresh token cookie - only for /api/v1/auth paths
+	// Refresh token cookie - only for API paths
+	h.clearLegacyRefreshTokenCookie(w)
 	http.SetCookie(w, &http.Cookie{
 		Name:     httputil.RefreshTokenCookie,
 		Value:    tokens.RefreshToken,
-		Path:     "/api/v1/auth",
+		Path:     refreshTokenCookiePath,
 		Domain:   h.cookieSettings.Domain,
 		MaxAge:   int(h.cookieSettings.RefreshTokenDuration.Seconds()),
 		HttpOnly: true,
@@ -561,13 +755,14 @@ func (h *Handler) clearAuthCookies(w http.ResponseWriter) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     httputil.RefreshTokenCookie,
 		Value:    "",
-		Path:     "/api/v1/auth",
+		Path:     refreshTokenCookiePath,
 		Domain:   h.cookieSettings.Domain,
 		MaxAge:   -1,
 		HttpOnly: true,
 		Secure:   h.cookieSettings.Secure,
 		SameSite: http.SameSiteStrictMode,
 	})
+	h.clearLegacyRefreshTokenCookie(w)
 
 	http.SetCookie(w, &http.Cookie{
 		Name:     httputil.CSRFTokenCookie,
@@ -581,11 +776,26 @@ func (h *Handler) clearAuthCookies(w http.ResponseWriter) {
 	})
 }
 
+// clearLegacyRefreshTokenCookie expires a refresh token cookie set on the old v1-only path,
+// which browsers would otherwise send ahead of the current one.
+func (h *Handler) clearLegacyRefreshTokenCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     httputil.RefreshTokenCookie,
+		Value:    "",
+		Path:     legacyRefreshTokenCookiePath,
+		Domain:   h.cookieSettings.Domain,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   h.cookieSettings.Secure,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
 // getRefreshTokenFromRequest extracts refresh token from cookie or request body (for backward compatibility).
-func (h *Handler) getRefreshTokenFromRequest(r *http.Request) string {
+func (h *Handler) getRefreshTokenFromRequest(r *http.Request) (token string, fromBody bool) {
 	// Try cookie first
 	if cookie, err := r.Cookie(httputil.RefreshTokenCookie); err == nil && cookie.Value != "" {
-		return cookie.Value
+		return cookie.Value, false
 	}
 
 	// Fallback to request body for API clients
@@ -593,10 +803,10 @@ func (h *Handler) getRefreshTokenFromRequest(r *http.Request) string {
 		RefreshToken string `json:"refresh_token"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err == nil && body.RefreshToken != "" {
-		return body.RefreshToken
+		return body.RefreshToken, true
 	}
 
-	return ""
+	return "", false
 }
 
 // generateCSRFToken generates a random CSRF token.
@@ -54,7 +54,7 @@ func NewAuthenticator(config Config, tokenStore TokenStore) *Authenticator {
 		config.AccessTokenDuration = 15 * time.Minute
 	}
 	if config.RefreshTokenDuration == 0 {
-		config.RefreshTokenDuration = 7 * 24 * time.Hour
+		config.RefreshTokenDuration = 30 * 24 * time.Hour
 	}
 	return &Authenticator{
 		config:     config,
@@ -0,0 +1,161 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// OIDC login errors.
+var (
+	ErrOIDCStateMismatch       = errors.New("invalid oidc state")
+	ErrOIDCLoginFailed         = errors.New("oidc login failed")
+	ErrOIDCEmailNotVerified    = errors.New("email not verified by identity provider")
+	ErrOIDCAccountLinked       = errors.New("account is linked to another identity")
+	ErrOIDCProviderUnavailable = errors.New("identity provider unavailable")
+)
+
+// oidcPasswordHash is stored for users created by OIDC login.
+// It is not a bcrypt hash, so password login fails until an admin resets the password.
+const oidcPasswordHash = "!"
+
+// OIDCIdentity is the verified ID token of an OIDC login.
+type OIDCIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	GivenName     string
+	FamilyName    string
+}
+
+// OIDCProvider runs the authorization code flow against an OpenID Connect provider.
+// This interface is implemented by oidc.Provider.
+type OIDCProvider interface {
+	// AuthCodeURL returns the provider's authorization URL for state and nonce.
+	AuthCodeURL(ctx context.Context, state, nonce string) (string, error)
+	// Exchange redeems the code and returns the verified ID token with the expected nonce.
+	Exchange(ctx context.Context, code, nonce string) (*OIDCIdentity, error)
+}
+
+// LoginOIDC finds or creates the local user of a verified OIDC identity and returns tokens.
+// Users are matched by subject, then by verified email (the subject is linked on first
+// login). New users get the user role and no usable password.
+func (s *Service) LoginOIDC(ctx context.Context, claims *OIDCIdentity) (*domain.User, *TokenPair, error) {
+	user, err := s.repo.GetUserByOIDCSubject(ctx, claims.Subject)
+	switch {
+	case err == nil:
+		if err := s.syncOIDCProfile(ctx, user, claims); err != nil {
+			return nil, nil, err
+		}
+	case errors.Is(err, ErrUserNotFound):
+		if !claims.EmailVerified || claims.Email == "" {
+			return nil, nil, ErrOIDCEmailNotVerified
+		}
+		user, err = s.linkOIDCUser(ctx, claims)
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, fmt.Errorf("get user by oidc subject: %w", err)
+	}
+
+	if !user.IsActive {
+		return nil, nil, ErrAccountDeactivated
+	}
+
+	tokens, err := s.authenticator.GenerateTokens(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lastLoginAt, err := s.repo.UpdateLastLogin(ctx, user.ID)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to record last login", "user_id", user.ID, "error", err)
+	} else {
+		user.LastLoginAt = &lastLoginAt
+	}
+
+	return user, tokens, nil
+}
+
+// linkOIDCUser links the subject to the user with the identity's email, creating the user if needed.
+func (s *Service) linkOIDCUser(ctx context.Context, claims *OIDCIdentity) (*domain.User, error) {
+	user, err := s.repo.GetUserByEmail(ctx, claims.Email)
+	if err != nil && !errors.Is(err, ErrUserNotFound) {
+		return nil, fmt.Errorf("check email: %w", err)
+	}
+
+	if user != nil {
+		if err := s.repo.SetOIDCSubject(ctx, user.ID, claims.Subject); err != nil {
+			return nil, err
+		}
+		if err := s.syncOIDCProfile(ctx, user, claims); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	user = &domain.User{
+		Email:        claims.Email,
+		PasswordHash: oidcPasswordHash,
+		Name:         oidcDisplayName(claims),
+		FirstName:    claims.GivenName,
+		LastName:     claims.FamilyName,
+		Role:         domain.RoleUser,
+		IsActive:     true,
+	}
+	if err := s.repo.CreateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetOIDCSubject(ctx, user.ID, claims.Subject); err != nil {
+		return nil, err
+	}
+
+	if s.userCreatedHandler != nil {
+		if err := s.userCreatedHandler.OnUserCreated(ctx, user); err != nil {
+			slog.WarnContext(ctx, "failed to create default notification channel",
+				"user_id", user.ID,
+				"email", user.Email,
+				"error", err,
+			)
+		}
+	}
+
+	return user, nil
+}
+
+// syncOIDCProfile copies given and family names from the provider when they changed.
+// The display name is only filled in when empty: users may have edited it.
+func (s *Service) syncOIDCProfile(ctx context.Context, user *domain.User, claims *OIDCIdentity) error {
+	changed := false
+	if claims.GivenName != "" && claims.GivenName != user.FirstName {
+		user.FirstName = claims.GivenName
+		changed = true
+	}
+	if claims.FamilyName != "" && claims.FamilyName != user.LastName {
+		user.LastName = claims.FamilyName
+		changed = true
+	}
+	if user.Name == "" {
+		if name := oidcDisplayName(claims); name != "" {
+			user.Name = name
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return s.repo.UpdateUser(ctx, user)
+}
+
+// oidcDisplayName returns the name claim, else given and family names.
+func oidcDisplayName(claims *OIDCIdentity) string {
+	if name := defaultName(claims.Name, ""); name != "" {
+		return name
+	}
+	return defaultName(claims.GivenName, claims.FamilyName)
+}
@@ -0,0 +1,116 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/identity"
+)
+
+// JWKS cache timing.
+const (
+	keysTTL            = time.Hour   // keys are refetched after this long
+	minRefreshInterval = time.Minute // an unknown kid refetches at most this often
+)
+
+// keyCache caches the provider's RSA signing keys by kid.
+// An unknown kid triggers a refetch, so key rotation needs no restart.
+type keyCache struct {
+	client *http.Client
+	now    func() time.Time
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+func newKeyCache(client *http.Client) *keyCache {
+	return &keyCache{client: client, now: time.Now}
+}
+
+// key returns the signing key with kid. An empty kid matches the only key of a single-key set.
+func (c *keyCache) key(ctx context.Context, uri, kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	age := c.now().Sub(c.fetchedAt)
+	if key := c.lookup(kid); key != nil && age < keysTTL {
+		return key, nil
+	}
+	if c.keys == nil || age >= minRefreshInterval {
+		if err := c.refresh(ctx, uri); err != nil {
+			return nil, err
+		}
+	}
+
+	key := c.lookup(kid)
+	if key == nil {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+func (c *keyCache) lookup(kid string) *rsa.PublicKey {
+	if kid == "" && len(c.keys) == 1 {
+		for _, key := range c.keys {
+			return key
+		}
+	}
+	return c.keys[kid]
+}
+
+// jwk is an entry of a JSON Web Key Set.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+func (c *keyCache) refresh(ctx context.Context, uri string) error {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := getJSON(ctx, c.client, uri, &set); err != nil {
+		return fmt.Errorf("%w: jwks: %v", identity.ErrOIDCProviderUnavailable, err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		key, err := parseRSAKey(k)
+		if err != nil {
+			return fmt.Errorf("%w: jwks key %q: %v", identity.ErrOIDCProviderUnavailable, k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+
+	c.keys = keys
+	c.fetchedAt = c.now()
+	return nil
+}
+
+func parseRSAKey(k jwk) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("decode modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("decode exponent: %w", err)
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid key parameters")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
@@ -0,0 +1,204 @@
+// Package oidc provides OpenID Connect login against an external identity provider.
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/identity"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+// Config holds OIDC client settings.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string       // where the provider sends the browser back with the code
+	HTTPClient   *http.Client // optional; default has a 10s timeout
+}
+
+// Scopes requested from the provider.
+var Scopes = []string{"openid", "email", "profile"}
+
+// clockSkew is the tolerance for exp, iat and nbf of ID tokens.
+const clockSkew = time.Minute
+
+// Provider implements identity.OIDCProvider with the authorization code flow.
+// Endpoints are discovered from the issuer on first use and cached.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+	keys   *keyCache
+
+	mu       sync.Mutex
+	metadata *metadata
+}
+
+var _ identity.OIDCProvider = (*Provider)(nil)
+
+// metadata is the part of the provider's discovery document used here.
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// NewProvider creates a new OIDC provider client.
+func NewProvider(cfg Config) *Provider {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Provider{
+		cfg:    cfg,
+		client: client,
+		keys:   newKeyCache(client),
+	}
+}
+
+// AuthCodeURL returns the provider's authorization URL for state and nonce.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce string) (string, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return p.oauth2Config(md).AuthCodeURL(state, oauth2.SetAuthURLParam("nonce", nonce)), nil
+}
+
+// Exchange redeems the authorization code and verifies the returned ID token:
+// RS256 signature from the provider's JWKS, issuer, audience, expiry and nonce.
+func (p *Provider) Exchange(ctx context.Context, code, nonce string) (*identity.OIDCIdentity, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := p.oauth2Config(md).Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.client), code)
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) {
+			return nil, fmt.Errorf("%w: exchange code: %v", identity.ErrOIDCLoginFailed, err)
+		}
+		return nil, fmt.Errorf("%w: exchange code: %v", identity.ErrOIDCProviderUnavailable, err)
+	}
+
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return nil, fmt.Errorf("%w: no id_token in token response", identity.ErrOIDCLoginFailed)
+	}
+	return p.verify(ctx, md, rawIDToken, nonce)
+}
+
+// idTokenClaims are the ID token claims used for login.
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+}
+
+func (p *Provider) verify(ctx context.Context, md *metadata, rawIDToken, nonce string) (*identity.OIDCIdentity, error) {
+	var claims idTokenClaims
+	_, err := jwt.ParseWithClaims(rawIDToken, &claims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.keys.key(ctx, md.JWKSURI, kid)
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(md.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		if errors.Is(err, identity.ErrOIDCProviderUnavailable) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: id token: %v", identity.ErrOIDCLoginFailed, err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: id token nonce mismatch", identity.ErrOIDCLoginFailed)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: id token without subject", identity.ErrOIDCLoginFailed)
+	}
+
+	return &identity.OIDCIdentity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+		GivenName:     claims.GivenName,
+		FamilyName:    claims.FamilyName,
+	}, nil
+}
+
+func (p *Provider) oauth2Config(md *metadata) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Scopes:       Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  md.AuthorizationEndpoint,
+			TokenURL: md.TokenEndpoint,
+		},
+	}
+}
+
+// discover fetches the discovery document once; failures are retried on the next call.
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	url := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	var md metadata
+	if err := getJSON(ctx, p.client, url, &md); err != nil {
+		return nil, fmt.Errorf("%w: discovery: %v", identity.ErrOIDCProviderUnavailable, err)
+	}
+	if strings.TrimSuffix(md.Issuer, "/") != strings.TrimSuffix(p.cfg.Issuer, "/") {
+		return nil, fmt.Errorf("%w: discovery issuer %q does not match %q", identity.ErrOIDCProviderUnavailable, md.Issuer, p.cfg.Issuer)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: discovery document misses endpoints", identity.ErrOIDCProviderUnavailable)
+	}
+
+	p.metadata = &md
+	return p.metadata, nil
+}
+
+// getJSON fetches url and decodes the JSON body into v.
+func getJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,117 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/identity"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProvider(t *testing.T) (*Provider, *testutil.OIDCServer) {
+	t.Helper()
+	server := testutil.NewOIDCServer("client-id", "client-secret")
+	t.Cleanup(server.Close)
+
+	return NewProvider(Config{
+		Issuer:       server.Issuer(),
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RedirectURL:  "https://status.example.com/auth/callback",
+	}), server
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	provider, server := newTestProvider(t)
+
+	raw, err := provider.AuthCodeURL(context.Background(), "state-1", "nonce-1")
+	require.NoError(t, err)
+
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	q := u.Query()
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, "client-id", q.Get("client_id"))
+	assert.Equal(t, "https://status.example.com/auth/callback", q.Get("redirect_uri"))
+	assert.Equal(t, "openid email profile", q.Get("scope"))
+	assert.Equal(t, "state-1", q.Get("state"))
+	assert.Equal(t, "nonce-1", q.Get("nonce"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	provider, server := newTestProvider(t)
+
+	code := server.IssueCode(map[string]interface{}{
+		"sub":            "user-1",
+		"nonce":          "nonce-1",
+		"email":          "ada@example.com",
+		"email_verified": true,
+		"given_name":     "Ada",
+		"family_name":    "Lovelace",
+	})
+
+	got, err := provider.Exchange(context.Background(), code, "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, &identity.OIDCIdentity{
+		Subject:       "user-1",
+		Email:         "ada@example.com",
+		EmailVerified: true,
+		GivenName:     "Ada",
+		FamilyName:    "Lovelace",
+	}, got)
+
+	_, err = provider.Exchange(context.Background(), code, "nonce-1")
+	assert.ErrorIs(t, err, identity.ErrOIDCLoginFailed, "codes are single-use")
+}
+
+func TestProvider_Exchange_RejectsInvalidTokens(t *testing.T) {
+	provider, server := newTestProvider(t)
+
+	tests := []struct {
+		name   string
+		claims map[string]interface{}
+	}{
+		{name: "wrong nonce", claims: map[string]interface{}{"sub": "u", "nonce": "other"}},
+		{name: "wrong audience", claims: map[string]interface{}{"sub": "u", "nonce": "n", "aud": "other-client"}},
+		{name: "wrong issuer", claims: map[string]interface{}{"sub": "u", "nonce": "n", "iss": "https://evil.example.com"}},
+		{name: "expired", claims: map[string]interface{}{"sub": "u", "nonce": "n", "exp": time.Now().Add(-time.Hour).Unix()}},
+		{name: "no subject", claims: map[string]interface{}{"nonce": "n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := provider.Exchange(context.Background(), server.IssueCode(tt.claims), "n")
+			assert.ErrorIs(t, err, identity.ErrOIDCLoginFailed)
+		})
+	}
+}
+
+func TestProvider_Exchange_KeyRotation(t *testing.T) {
+	provider, server := newTestProvider(t)
+	now := time.Now()
+	provider.keys.now = func() time.Time { return now }
+
+	_, err := provider.Exchange(context.Background(), server.IssueCode(map[string]interface{}{"sub": "u", "nonce": "n"}), "n")
+	require.NoError(t, err)
+
+	server.RotateKey()
+	_, err = provider.Exchange(context.Background(), server.IssueCode(map[string]interface{}{"sub": "u", "nonce": "n"}), "n")
+	assert.ErrorIs(t, err, identity.ErrOIDCLoginFailed, "no refetch within the minimum refresh interval")
+
+	now = now.Add(minRefreshInterval)
+	_, err = provider.Exchange(context.Background(), server.IssueCode(map[string]interface{}{"sub": "u", "nonce": "n"}), "n")
+	assert.NoError(t, err, "unknown kid refetches the key set")
+}
+
+func TestProvider_ProviderUnavailable(t *testing.T) {
+	provider, server := newTestProvider(t)
+	server.Close()
+
+	_, err := provider.AuthCodeURL(context.Background(), "state", "nonce")
+	assert.ErrorIs(t, err, identity.ErrOIDCProviderUnavailable)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/bissquit/incident-garden/internal/domain"
 	"github.com/bissquit/incident-garden/internal/identity"
@@ -26,13 +27,14 @@ func NewRepository(db *pgxpool.Pool) *Repository {
 // CreateUser creates a new user.
 func (r *Repository) CreateUser(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (email, password_hash, first_name, last_name, role, is_active, must_change_password)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO users (email, password_hash, name, first_name, last_name, role, is_active, must_change_password)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, created_at, updated_at
 	`
 	err := r.db.QueryRow(ctx, query,
 		user.Email,
 		user.PasswordHash,
+		user.Name,
 		user.FirstName,
 		user.LastName,
 		user.Role,
@@ -49,7 +51,8 @@ func (r *Repository) CreateUser(ctx context.Context, user *domain.User) error {
 // GetUserByID retrieves a user by ID.
 func (r *Repository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, first_name, last_name, role, is_active, must_change_password, created_at, updated_at
+		SELECT id, email, password_hash, name, first_name, last_name, role, is_active, must_change_password,
+		       last_login_at, deactivated_at, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -58,11 +61,14 @@ func (r *Repository) GetUserByID(ctx context.Context, id string) (*domain.User,
 		&user.ID,
 		&user.Email,
 		&user.PasswordHash,
+		&user.Name,
 		&user.FirstName,
 		&user.LastName,
 		&user.Role,
 		&user.IsActive,
 		&user.MustChangePassword,
+		&user.LastLoginAt,
+		&user.DeactivatedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -79,7 +85,8 @@ func (r *Repository) GetUserByID(ctx context.Context, id string) (*domain.User,
 // GetUserByEmail retrieves a user by email.
 func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, first_name, last_name, role, is_active, must_change_password, created_at, updated_at
+		SELECT id, email, password_hash, name, first_name, last_name, role, is_active, must_change_password,
+		       last_login_at, deactivated_at, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
@@ -88,11 +95,14 @@ func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*domain.
 		&user.ID,
 		&user.Email,
 		&user.PasswordHash,
+		&user.Name,
 		&user.FirstName,
 		&user.LastName,
 		&user.Role,
 		&user.IsActive,
 		&user.MustChangePassword,
+		&user.LastLoginAt,
+		&user.DeactivatedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -106,23 +116,75 @@ func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*domain.
 	return &user, nil
 }
 
+// GetUserByOIDCSubject retrieves the user linked to an OIDC subject.
+func (r *Repository) GetUserByOIDCSubject(ctx context.Context, subject string) (*domain.User, error) {
+	query := `
+		SELECT id, email, password_hash, name, first_name, last_name, role, is_active, must_change_password,
+		       last_login_at, deactivated_at, created_at, updated_at
+		FROM users
+		WHERE oidc_subject = $1
+	`
+	var user domain.User
+	err := r.db.QueryRow(ctx, query, subject).Scan(
+		&user.ID,
+		&user.Email,
+		&user.PasswordHash,
+		&user.Name,
+		&user.FirstName,
+		&user.LastName,
+		&user.Role,
+		&user.IsActive,
+		&user.MustChangePassword,
+		&user.LastLoginAt,
+		&user.DeactivatedAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, identity.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user by oidc subject: %w", err)
+	}
+	return &user, nil
+}
+
+// SetOIDCSubject links an OIDC subject to a user that isn't linked to another one.
+func (r *Repository) SetOIDCSubject(ctx context.Context, userID, subject string) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE users SET oidc_subject = $2
+		WHERE id = $1 AND (oidc_subject IS NULL OR oidc_subject = $2)
+	`, userID, subject)
+	if err != nil {
+		return fmt.Errorf("set oidc subject: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return identity.ErrOIDCAccountLinked
+	}
+	return nil
+}
+
 // UpdateUser updates an existing user.
 func (r *Repository) UpdateUser(ctx context.Context, user *domain.User) error {
 	query := `
 		UPDATE users
-		SET email = $2, first_name = $3, last_name = $4, role = $5, is_active = $6, must_change_password = $7, updated_at = NOW()
+		SET email = $2, name = $3, first_name = $4, last_name = $5, role = $6, is_active = $7, must_change_password = $8,
+		    deactivated_at = CASE WHEN $7 THEN NULL ELSE COALESCE(deactivated_at, NOW()) END,
+		    updated_at = NOW()
 		WHERE id = $1
-		RETURNING updated_at
+		RETURNING deactivated_at, updated_at
 	`
 	err := r.db.QueryRow(ctx, query,
 		user.ID,
 		user.Email,
+		user.Name,
 		user.FirstName,
 		user.LastName,
 		user.Role,
 		user.IsActive,
 		user.MustChangePassword,
-	).Scan(&user.UpdatedAt)
+	).Scan(&user.DeactivatedAt, &user.UpdatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -133,6 +195,46 @@ func (r *Repository) UpdateUser(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
+// UpdateLastLogin records a successful login and returns its time.
+func (r *Repository) UpdateLastLogin(ctx context.Context, userID string) (time.Time, error) {
+	var lastLoginAt time.Time
+	err := r.db.QueryRow(ctx,
+		`UPDATE users SET last_login_at = NOW() WHERE id = $1 RETURNING last_login_at`,
+		userID,
+	).Scan(&lastLoginAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, identity.ErrUserNotFound
+		}
+		return time.Time{}, fmt.Errorf("update last login: %w", err)
+	}
+	return lastLoginAt, nil
+}
+
+// ResolveUserNames returns display names of the given users, keyed by ID.
+// Unknown users and users without a name are left out.
+func (r *Repository) ResolveUserNames(ctx context.Context, ids []string) (map[string]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT id, name FROM users WHERE id = ANY($1::uuid[]) AND name <> ''`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("resolve user names: %w", err)
+	}
+	defer rows.Close()
+
+	names := make(map[string]string, len(ids))
+	for rows.Next() {
+		var id, name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("scan user name: %w", err)
+		}
+		names[id] = name
+	}
+	return names, rows.Err()
+}
+
 // SaveRefreshToken saves a refresh token to the database.
 func (r *Repository) SaveRefreshToken(ctx context.Context, token *domain.RefreshToken) error {
 	query := `
@@ -201,8 +303,8 @@ func (r *Repository) DeleteUserRefreshTokens(ctx context.Context, userID string)
 // ListUsers returns a paginated list of users with optional role filter.
 func (r *Repository) ListUsers(ctx context.Context, filter identity.UserFilter) ([]*domain.User, int, error) {
 	query := `
-		SELECT id, email, first_name, last_name, role, is_active, must_change_password,
-		       created_at, updated_at, COUNT(*) OVER() AS total
+		SELECT id, email, name, first_name, last_name, role, is_active, must_change_password,
+		       last_login_at, deactivated_at, created_at, updated_at, COUNT(*) OVER() AS total
 		FROM users
 	`
 	args := make([]interface{}, 0)
@@ -235,9 +337,10 @@ func (r *Repository) ListUsers(ctx context.Context, filter identity.UserFilter)
 	for rows.Next() {
 		var user domain.User
 		if err := rows.Scan(
-			&user.ID, &user.Email,
+			&user.ID, &user.Email, &user.Name,
 			&user.FirstName, &user.LastName, &user.Role,
 			&user.IsActive, &user.MustChangePassword,
+			&user.LastLoginAt, &user.DeactivatedAt,
 			&user.CreatedAt, &user.UpdatedAt,
 			&total,
 		); err != nil {
@@ -2,6 +2,7 @@ package identity
 
 import (
 	"context"
+	"time"
 
 	"github.com/bissquit/incident-garden/internal/domain"
 )
@@ -19,6 +20,12 @@ type Repository interface {
 	GetUserByID(ctx context.Context, id string) (*domain.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
 	UpdateUser(ctx context.Context, user *domain.User) error
+	UpdateLastLogin(ctx context.Context, userID string) (time.Time, error)
+
+	// OIDC login
+	GetUserByOIDCSubject(ctx context.Context, subject string) (*domain.User, error)
+	// SetOIDCSubject links the subject to a user without one; ErrOIDCAccountLinked otherwise.
+	SetOIDCSubject(ctx context.Context, userID, subject string) error
 
 	// User management
 	ListUsers(ctx context.Context, filter UserFilter) ([]*domain.User, int, error)
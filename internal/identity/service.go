@@ -7,7 +7,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/bissquit/incident-garden/internal/domain"
 	"golang.org/x/crypto/bcrypt"
@@ -25,8 +27,12 @@ var (
 	ErrEmailNotConfigured = errors.New("email not configured")
 	ErrCannotModifySelf   = errors.New("cannot modify your own account")
 	ErrInvalidRole        = errors.New("invalid role")
+	ErrInvalidName        = errors.New("name must be between 1 and 100 characters")
 )
 
+// maxNameLength is the maximum length of a user's display name in characters.
+const maxNameLength = 100
+
 // UserCreatedHandler handles user creation events.
 // Used to create default notification channels, send welcome emails, etc.
 type UserCreatedHandler interface {
@@ -86,6 +92,7 @@ func (s *Service) Register(ctx context.Context, input RegisterInput) (*domain.Us
 	user := &domain.User{
 		Email:              input.Email,
 		PasswordHash:       string(hashedPassword),
+		Name:               defaultName(input.FirstName, input.LastName),
 		FirstName:          input.FirstName,
 		LastName:           input.LastName,
 		Role:               domain.RoleUser,
@@ -100,7 +107,7 @@ func (s *Service) Register(ctx context.Context, input RegisterInput) (*domain.Us
 	// Create default notification channel
 	if s.userCreatedHandler != nil {
 		if err := s.userCreatedHandler.OnUserCreated(ctx, user); err != nil {
-			slog.Warn("failed to create default notification channel",
+			slog.WarnContext(ctx, "failed to create default notification channel",
 				"user_id", user.ID,
 				"email", user.Email,
 				"error", err,
@@ -141,6 +148,13 @@ func (s *Service) Login(ctx context.Context, input LoginInput) (*domain.User, *T
 		return nil, nil, err
 	}
 
+	lastLoginAt, err := s.repo.UpdateLastLogin(ctx, user.ID)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to record last login", "user_id", user.ID, "error", err)
+	} else {
+		user.LastLoginAt = &lastLoginAt
+	}
+
 	return user, tokens, nil
 }
 
@@ -170,7 +184,7 @@ func (s *Service) RefreshTokens(ctx context.Context, refreshToken string) (*Toke
 
 // Logout invalidates the refresh token.
 func (s *Service) Logout(ctx context.Context, refreshToken string) error {
-	return s.repo.DeleteRefreshToken(ctx, refreshToken)
+	return s.authenticator.RevokeRefreshToken(ctx, refreshToken)
 }
 
 // GetUserByID returns user by ID.
@@ -196,7 +210,13 @@ func (s *Service) ChangePassword(ctx context.Context, input ChangePasswordInput)
 		return ErrWrongPassword
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.NewPassword), bcrypt.DefaultCost)
+	return s.setPassword(ctx, user, input.NewPassword)
+}
+
+// setPassword stores a new password, clears must_change_password and
+// invalidates all refresh tokens to force re-login.
+func (s *Service) setPassword(ctx context.Context, user *domain.User, password string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return fmt.Errorf("hash password: %w", err)
 	}
@@ -209,7 +229,7 @@ func (s *Service) ChangePassword(ctx context.Context, input ChangePasswordInput)
 	if user.MustChangePassword {
 		user.MustChangePassword = false
 		if err := s.repo.UpdateUser(ctx, user); err != nil {
-			slog.Warn("failed to clear must_change_password flag",
+			slog.WarnContext(ctx, "failed to clear must_change_password flag",
 				"user_id", user.ID,
 				"error", err,
 			)
@@ -218,7 +238,7 @@ func (s *Service) ChangePassword(ctx context.Context, input ChangePasswordInput)
 
 	// Invalidate all refresh tokens to force re-login
 	if err := s.repo.DeleteUserRefreshTokens(ctx, user.ID); err != nil {
-		slog.Warn("failed to invalidate refresh tokens after password change",
+		slog.WarnContext(ctx, "failed to invalidate refresh tokens after password change",
 			"user_id", user.ID,
 			"error", err,
 		)
@@ -227,36 +247,56 @@ func (s *Service) ChangePassword(ctx context.Context, input ChangePasswordInput)
 	return nil
 }
 
-// UpdateProfileInput contains data for profile update.
-type UpdateProfileInput struct {
-	UserID    string
-	FirstName *string
-	LastName  *string
+// UpdateUserProfileInput contains data for PATCH /me. Nil fields are left unchanged.
+type UpdateUserProfileInput struct {
+	Name            *string
+	FirstName       *string
+	LastName        *string
+	Password        *string
+	CurrentPassword string // required with Password
 }
 
-// UpdateProfile updates the authenticated user's profile.
-func (s *Service) UpdateProfile(ctx context.Context, input UpdateProfileInput) (*domain.User, error) {
-	user, err := s.repo.GetUserByID(ctx, input.UserID)
+// UpdateUserProfile updates the authenticated user's profile and, when Password is set,
+// their password. The current password is checked before anything is changed.
+func (s *Service) UpdateUserProfile(ctx context.Context, userID string, input UpdateUserProfileInput) error {
+	user, err := s.repo.GetUserByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("get user: %w", err)
+		return fmt.Errorf("get user: %w", err)
 	}
 
-	if input.FirstName == nil && input.LastName == nil {
-		return user, nil
+	var name string
+	if input.Name != nil {
+		name = strings.TrimSpace(*input.Name)
+		if length := utf8.RuneCountInString(name); length < 1 || length > maxNameLength {
+			return ErrInvalidName
+		}
 	}
 
-	if input.FirstName != nil {
-		user.FirstName = *input.FirstName
-	}
-	if input.LastName != nil {
-		user.LastName = *input.LastName
+	if input.Password != nil {
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(input.CurrentPassword)); err != nil {
+			return ErrWrongPassword
+		}
 	}
 
-	if err := s.repo.UpdateUser(ctx, user); err != nil {
-		return nil, fmt.Errorf("update profile: %w", err)
+	if input.Name != nil || input.FirstName != nil || input.LastName != nil {
+		if input.Name != nil {
+			user.Name = name
+		}
+		if input.FirstName != nil {
+			user.FirstName = *input.FirstName
+		}
+		if input.LastName != nil {
+			user.LastName = *input.LastName
+		}
+		if err := s.repo.UpdateUser(ctx, user); err != nil {
+			return fmt.Errorf("update profile: %w", err)
+		}
 	}
 
-	return user, nil
+	if input.Password != nil {
+		return s.setPassword(ctx, user, *input.Password)
+	}
+	return nil
 }
 
 // ValidateToken validates access token and returns user info.
@@ -312,7 +352,7 @@ func (s *Service) ForgotPassword(ctx context.Context, email string) error {
 	body := fmt.Sprintf("You requested a password reset.\n\nClick the link below to reset your password:\n%s\n\nThis link expires in 1 hour.\n\nIf you did not request this, please ignore this email.", resetLink)
 
 	if err := s.emailSender.SendEmail(ctx, user.Email, subject, body); err != nil {
-		slog.Warn("failed to send password reset email",
+		slog.WarnContext(ctx, "failed to send password reset email",
 			"user_id", user.ID,
 			"error", err,
 		)
@@ -349,7 +389,7 @@ func (s *Service) ResetPassword(ctx context.Context, input ResetPasswordInput) e
 	if err == nil && user.MustChangePassword {
 		user.MustChangePassword = false
 		if err := s.repo.UpdateUser(ctx, user); err != nil {
-			slog.Warn("failed to clear must_change_password after reset",
+			slog.WarnContext(ctx, "failed to clear must_change_password after reset",
 				"user_id", token.UserID,
 				"error", err,
 			)
@@ -358,7 +398,7 @@ func (s *Service) ResetPassword(ctx context.Context, input ResetPasswordInput) e
 
 	// Clean up: delete used token and all user's reset tokens
 	if err := s.repo.DeleteUserPasswordResetTokens(ctx, token.UserID); err != nil {
-		slog.Warn("failed to delete password reset tokens",
+		slog.WarnContext(ctx, "failed to delete password reset tokens",
 			"user_id", token.UserID,
 			"error", err,
 		)
@@ -366,7 +406,7 @@ func (s *Service) ResetPassword(ctx context.Context, input ResetPasswordInput) e
 
 	// Invalidate all refresh tokens
 	if err := s.repo.DeleteUserRefreshTokens(ctx, token.UserID); err != nil {
-		slog.Warn("failed to invalidate refresh tokens after password reset",
+		slog.WarnContext(ctx, "failed to invalidate refresh tokens after password reset",
 			"user_id", token.UserID,
 			"error", err,
 		)
@@ -412,6 +452,7 @@ func (s *Service) AdminCreateUser(ctx context.Context, input AdminCreateUserInpu
 	user := &domain.User{
 		Email:              input.Email,
 		PasswordHash:       string(hashedPassword),
+		Name:               defaultName(input.FirstName, input.LastName),
 		FirstName:          input.FirstName,
 		LastName:           input.LastName,
 		Role:               input.Role,
@@ -426,7 +467,7 @@ func (s *Service) AdminCreateUser(ctx context.Context, input AdminCreateUserInpu
 	// Create default notification channel
 	if s.userCreatedHandler != nil {
 		if err := s.userCreatedHandler.OnUserCreated(ctx, user); err != nil {
-			slog.Warn("failed to create default notification channel for admin-created user",
+			slog.WarnContext(ctx, "failed to create default notification channel for admin-created user",
 				"user_id", user.ID,
 				"email", user.Email,
 				"error", err,
@@ -487,7 +528,7 @@ func (s *Service) AdminUpdateUser(ctx context.Context, adminUserID string, input
 	// On deactivation: invalidate all refresh tokens
 	if wasActive && input.IsActive != nil && !*input.IsActive {
 		if err := s.repo.DeleteUserRefreshTokens(ctx, user.ID); err != nil {
-			slog.Warn("failed to invalidate refresh tokens after deactivation",
+			slog.WarnContext(ctx, "failed to invalidate refresh tokens after deactivation",
 				"user_id", user.ID,
 				"error", err,
 			)
@@ -497,6 +538,17 @@ func (s *Service) AdminUpdateUser(ctx context.Context, adminUserID string, input
 	return user, nil
 }
 
+// AdminDeactivateUser deactivates a user (admin only): they can no longer log in and
+// their sessions end. The account and its data are kept, PATCH with is_active=true restores it.
+func (s *Service) AdminDeactivateUser(ctx context.Context, adminUserID, userID string) error {
+	isActive := false
+	_, err := s.AdminUpdateUser(ctx, adminUserID, AdminUpdateUserInput{
+		UserID:   userID,
+		IsActive: &isActive,
+	})
+	return err
+}
+
 // AdminResetPasswordInput contains data for admin password reset.
 type AdminResetPasswordInput struct {
 	UserID      string
@@ -530,7 +582,7 @@ func (s *Service) AdminResetPassword(ctx context.Context, adminUserID string, in
 	}
 
 	if err := s.repo.DeleteUserRefreshTokens(ctx, user.ID); err != nil {
-		slog.Warn("failed to invalidate refresh tokens after admin password reset",
+		slog.WarnContext(ctx, "failed to invalidate refresh tokens after admin password reset",
 			"user_id", user.ID,
 			"error", err,
 		)
@@ -538,3 +590,12 @@ func (s *Service) AdminResetPassword(ctx context.Context, adminUserID string, in
 
 	return nil
 }
+
+// defaultName builds the display name of a new user from their first and last name.
+func defaultName(firstName, lastName string) string {
+	name := strings.TrimSpace(strings.TrimSpace(firstName) + " " + strings.TrimSpace(lastName))
+	if utf8.RuneCountInString(name) > maxNameLength {
+		name = string([]rune(name)[:maxNameLength])
+	}
+	return name
+}
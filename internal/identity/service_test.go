@@ -3,6 +3,7 @@ package identity
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,11 +32,14 @@ type mockRepository struct {
 	listUsersFilter UserFilter
 	listUsersResult []*domain.User
 	listUsersTotal  int
+
+	oidcSubjects map[string]string // user ID -> OIDC subject
 }
 
 func newMockRepository() *mockRepository {
 	return &mockRepository{
-		users: make(map[string]*domain.User),
+		users:        make(map[string]*domain.User),
+		oidcSubjects: make(map[string]string),
 	}
 }
 
@@ -75,6 +79,34 @@ func (m *mockRepository) UpdateUser(_ context.Context, user *domain.User) error
 	return nil
 }
 
+func (m *mockRepository) UpdateLastLogin(_ context.Context, userID string) (time.Time, error) {
+	now := time.Now()
+	for _, u := range m.users {
+		if u.ID == userID {
+			u.LastLoginAt = &now
+			return now, nil
+		}
+	}
+	return time.Time{}, ErrUserNotFound
+}
+
+func (m *mockRepository) GetUserByOIDCSubject(_ context.Context, subject string) (*domain.User, error) {
+	for _, u := range m.users {
+		if m.oidcSubjects[u.ID] == subject {
+			return u, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (m *mockRepository) SetOIDCSubject(_ context.Context, userID, subject string) error {
+	if existing, ok := m.oidcSubjects[userID]; ok && existing != subject {
+		return ErrOIDCAccountLinked
+	}
+	m.oidcSubjects[userID] = subject
+	return nil
+}
+
 func (m *mockRepository) SaveRefreshToken(_ context.Context, _ *domain.RefreshToken) error {
 	return nil
 }
@@ -138,7 +170,9 @@ func (m *mockRepository) DeleteUserPasswordResetTokens(_ context.Context, _ stri
 }
 
 // mockAuthenticator implements Authenticator for testing.
-type mockAuthenticator struct{}
+type mockAuthenticator struct {
+	revokedTokens []string
+}
 
 func (m *mockAuthenticator) GenerateTokens(_ context.Context, _ *domain.User) (*TokenPair, error) {
 	return &TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil
@@ -152,7 +186,8 @@ func (m *mockAuthenticator) RefreshTokens(_ context.Context, _ string) (*TokenPa
 	return &TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil
 }
 
-func (m *mockAuthenticator) RevokeRefreshToken(_ context.Context, _ string) error {
+func (m *mockAuthenticator) RevokeRefreshToken(_ context.Context, refreshToken string) error {
+	m.revokedTokens = append(m.revokedTokens, refreshToken)
 	return nil
 }
 
@@ -316,6 +351,42 @@ func TestLogin_DeactivatedUser(t *testing.T) {
 	assert.ErrorIs(t, err, ErrAccountDeactivated)
 }
 
+func TestLogin_RecordsLastLogin(t *testing.T) {
+	repo := newMockRepository()
+	auth := &mockAuthenticator{}
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	repo.users["test@example.com"] = &domain.User{
+		ID:           "user-1",
+		Email:        "test@example.com",
+		PasswordHash: string(hashedPassword),
+		IsActive:     true,
+	}
+
+	service := NewService(repo, auth, nil, nil, "")
+
+	user, _, err := service.Login(context.Background(), LoginInput{
+		Email:    "test@example.com",
+		Password: "password123",
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, user.LastLoginAt)
+	assert.WithinDuration(t, time.Now(), *user.LastLoginAt, time.Second)
+}
+
+func TestLogout_RevokesRefreshToken(t *testing.T) {
+	repo := newMockRepository()
+	auth := &mockAuthenticator{}
+
+	service := NewService(repo, auth, nil, nil, "")
+
+	err := service.Logout(context.Background(), "refresh-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"refresh-1"}, auth.revokedTokens)
+}
+
 func TestChangePassword_Success(t *testing.T) {
 	repo := newMockRepository()
 	auth := &mockAuthenticator{}
@@ -391,7 +462,7 @@ func TestChangePassword_ClearsMustChangePassword(t *testing.T) {
 	assert.False(t, repo.users["test@example.com"].MustChangePassword)
 }
 
-func TestUpdateProfile_Success(t *testing.T) {
+func TestUpdateUserProfile_Success(t *testing.T) {
 	repo := newMockRepository()
 	auth := &mockAuthenticator{}
 
@@ -405,18 +476,19 @@ func TestUpdateProfile_Success(t *testing.T) {
 
 	firstName := "John"
 	lastName := "Doe"
-	user, err := service.UpdateProfile(context.Background(), UpdateProfileInput{
-		UserID:    "user-1",
+	err := service.UpdateUserProfile(context.Background(), "user-1", UpdateUserProfileInput{
 		FirstName: &firstName,
 		LastName:  &lastName,
 	})
+	require.NoError(t, err)
 
+	user, err := service.GetUserByID(context.Background(), "user-1")
 	require.NoError(t, err)
 	assert.Equal(t, "John", user.FirstName)
 	assert.Equal(t, "Doe", user.LastName)
 }
 
-func TestUpdateProfile_PartialUpdate(t *testing.T) {
+func TestUpdateUserProfile_PartialUpdate(t *testing.T) {
 	repo := newMockRepository()
 	auth := &mockAuthenticator{}
 
@@ -431,16 +503,97 @@ func TestUpdateProfile_PartialUpdate(t *testing.T) {
 	service := NewService(repo, auth, nil, nil, "")
 
 	newFirst := "Updated"
-	user, err := service.UpdateProfile(context.Background(), UpdateProfileInput{
-		UserID:    "user-1",
+	err := service.UpdateUserProfile(context.Background(), "user-1", UpdateUserProfileInput{
 		FirstName: &newFirst,
 	})
+	require.NoError(t, err)
 
+	user, err := service.GetUserByID(context.Background(), "user-1")
 	require.NoError(t, err)
 	assert.Equal(t, "Updated", user.FirstName)
 	assert.Equal(t, "Name", user.LastName) // Unchanged
 }
 
+func TestUpdateUserProfile_TrimsName(t *testing.T) {
+	repo := newMockRepository()
+	repo.users["test@example.com"] = &domain.User{ID: "user-1", Email: "test@example.com", IsActive: true}
+
+	service := NewService(repo, &mockAuthenticator{}, nil, nil, "")
+
+	name := "  Jane Doe \t"
+	err := service.UpdateUserProfile(context.Background(), "user-1", UpdateUserProfileInput{Name: &name})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe", repo.users["test@example.com"].Name)
+	assert.False(t, repo.updateUserPasswordCalled)
+}
+
+func TestUpdateUserProfile_InvalidName(t *testing.T) {
+	for _, name := range []string{"", "   ", strings.Repeat("я", 101)} {
+		repo := newMockRepository()
+		repo.users["test@example.com"] = &domain.User{ID: "user-1", Email: "test@example.com", Name: "Original"}
+
+		service := NewService(repo, &mockAuthenticator{}, nil, nil, "")
+
+		err := service.UpdateUserProfile(context.Background(), "user-1", UpdateUserProfileInput{Name: &name})
+
+		assert.ErrorIs(t, err, ErrInvalidName)
+		assert.False(t, repo.updateUserCalled)
+	}
+}
+
+func TestUpdateUserProfile_ChangesPassword(t *testing.T) {
+	repo := newMockRepository()
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("oldpassword"), bcrypt.MinCost)
+	repo.users["test@example.com"] = &domain.User{
+		ID:           "user-1",
+		Email:        "test@example.com",
+		PasswordHash: string(hashedPassword),
+		IsActive:     true,
+	}
+
+	service := NewService(repo, &mockAuthenticator{}, nil, nil, "")
+
+	name := "Jane"
+	password := "newpassword"
+	err := service.UpdateUserProfile(context.Background(), "user-1", UpdateUserProfileInput{
+		Name:            &name,
+		Password:        &password,
+		CurrentPassword: "oldpassword",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Jane", repo.users["test@example.com"].Name)
+	assert.True(t, repo.updateUserPasswordCalled)
+	assert.True(t, repo.deleteUserRefreshTokensCalled)
+}
+
+func TestUpdateUserProfile_WrongCurrentPassword(t *testing.T) {
+	repo := newMockRepository()
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correctpassword"), bcrypt.MinCost)
+	repo.users["test@example.com"] = &domain.User{
+		ID:           "user-1",
+		Email:        "test@example.com",
+		PasswordHash: string(hashedPassword),
+		Name:         "Original",
+	}
+
+	service := NewService(repo, &mockAuthenticator{}, nil, nil, "")
+
+	name := "Changed"
+	password := "newpassword"
+	err := service.UpdateUserProfile(context.Background(), "user-1", UpdateUserProfileInput{
+		Name:            &name,
+		Password:        &password,
+		CurrentPassword: "wrongpassword",
+	})
+
+	assert.ErrorIs(t, err, ErrWrongPassword)
+	assert.False(t, repo.updateUserCalled, "profile is not changed when the password check fails")
+	assert.False(t, repo.updateUserPasswordCalled)
+	assert.Equal(t, "Original", repo.users["test@example.com"].Name)
+}
+
 func TestForgotPassword_NoEmailSender(t *testing.T) {
 	repo := newMockRepository()
 	auth := &mockAuthenticator{}
@@ -721,6 +874,38 @@ func TestAdminUpdateUser_Deactivation(t *testing.T) {
 	assert.True(t, repo.deleteUserRefreshTokensCalled)
 }
 
+func TestAdminDeactivateUser(t *testing.T) {
+	repo := newMockRepository()
+	auth := &mockAuthenticator{}
+
+	repo.users["target@example.com"] = &domain.User{
+		ID:       "target-1",
+		Email:    "target@example.com",
+		Role:     domain.RoleOperator,
+		IsActive: true,
+	}
+
+	service := NewService(repo, auth, nil, nil, "")
+
+	err := service.AdminDeactivateUser(context.Background(), "admin-1", "target-1")
+
+	require.NoError(t, err)
+	assert.False(t, repo.users["target@example.com"].IsActive)
+	assert.Equal(t, domain.RoleOperator, repo.users["target@example.com"].Role)
+	assert.True(t, repo.deleteUserRefreshTokensCalled)
+}
+
+func TestAdminDeactivateUser_Self(t *testing.T) {
+	repo := newMockRepository()
+	auth := &mockAuthenticator{}
+
+	service := NewService(repo, auth, nil, nil, "")
+
+	err := service.AdminDeactivateUser(context.Background(), "admin-1", "admin-1")
+
+	assert.ErrorIs(t, err, ErrCannotModifySelf)
+}
+
 func TestAdminUpdateUser_NotFound(t *testing.T) {
 	repo := newMockRepository()
 	auth := &mockAuthenticator{}
@@ -832,3 +1017,86 @@ func TestListUsers_Passthrough(t *testing.T) {
 	assert.True(t, repo.listUsersCalled)
 	assert.Equal(t, &role, repo.listUsersFilter.Role)
 }
+
+func TestLoginOIDC_CreatesUser(t *testing.T) {
+	repo := newMockRepository()
+	handler := &mockUserCreatedHandler{}
+	service := NewService(repo, &mockAuthenticator{}, handler, nil, "")
+
+	user, tokens, err := service.LoginOIDC(context.Background(), &OIDCIdentity{
+		Subject:       "sub-1",
+		Email:         "oidc@example.com",
+		EmailVerified: true,
+		GivenName:     "Ada",
+		FamilyName:    "Lovelace",
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, tokens)
+	assert.Equal(t, "oidc@example.com", user.Email)
+	assert.Equal(t, "Ada Lovelace", user.Name)
+	assert.Equal(t, domain.RoleUser, user.Role)
+	assert.Equal(t, "sub-1", repo.oidcSubjects[user.ID])
+	assert.NotNil(t, user.LastLoginAt)
+	assert.True(t, handler.called, "default channel hook runs for OIDC users too")
+
+	_, _, err = service.Login(context.Background(), LoginInput{Email: "oidc@example.com", Password: "!"})
+	assert.ErrorIs(t, err, ErrInvalidCredentials, "OIDC users have no usable password")
+}
+
+func TestLoginOIDC_LinksExistingUserByEmail(t *testing.T) {
+	repo := newMockRepository()
+	repo.users["existing@example.com"] = &domain.User{
+		ID: "existing-id", Email: "existing@example.com", Name: "Custom Name",
+		FirstName: "Old", Role: domain.RoleOperator, IsActive: true,
+	}
+	service := NewService(repo, &mockAuthenticator{}, nil, nil, "")
+
+	user, _, err := service.LoginOIDC(context.Background(), &OIDCIdentity{
+		Subject: "sub-2", Email: "existing@example.com", EmailVerified: true, GivenName: "New",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "existing-id", user.ID)
+	assert.Equal(t, domain.RoleOperator, user.Role, "role is kept")
+	assert.Equal(t, "New", user.FirstName, "given name synced from the provider")
+	assert.Equal(t, "Custom Name", user.Name, "display name is not overwritten")
+	assert.Equal(t, "sub-2", repo.oidcSubjects["existing-id"])
+
+	user, _, err = service.LoginOIDC(context.Background(), &OIDCIdentity{Subject: "sub-2"})
+	require.NoError(t, err, "linked subject logs in without an email claim")
+	assert.Equal(t, "existing-id", user.ID)
+}
+
+func TestLoginOIDC_Rejects(t *testing.T) {
+	t.Run("unverified email", func(t *testing.T) {
+		repo := newMockRepository()
+		service := NewService(repo, &mockAuthenticator{}, nil, nil, "")
+
+		_, _, err := service.LoginOIDC(context.Background(), &OIDCIdentity{Subject: "sub", Email: "x@example.com"})
+		assert.ErrorIs(t, err, ErrOIDCEmailNotVerified)
+		assert.Empty(t, repo.users)
+	})
+
+	t.Run("email linked to another subject", func(t *testing.T) {
+		repo := newMockRepository()
+		repo.users["taken@example.com"] = &domain.User{ID: "taken-id", Email: "taken@example.com", IsActive: true}
+		repo.oidcSubjects["taken-id"] = "other-sub"
+		service := NewService(repo, &mockAuthenticator{}, nil, nil, "")
+
+		_, _, err := service.LoginOIDC(context.Background(), &OIDCIdentity{
+			Subject: "sub", Email: "taken@example.com", EmailVerified: true,
+		})
+		assert.ErrorIs(t, err, ErrOIDCAccountLinked)
+	})
+
+	t.Run("deactivated user", func(t *testing.T) {
+		repo := newMockRepository()
+		repo.users["off@example.com"] = &domain.User{ID: "off-id", Email: "off@example.com", IsActive: false}
+		repo.oidcSubjects["off-id"] = "sub"
+		service := NewService(repo, &mockAuthenticator{}, nil, nil, "")
+
+		_, _, err := service.LoginOIDC(context.Background(), &OIDCIdentity{Subject: "sub"})
+		assert.ErrorIs(t, err, ErrAccountDeactivated)
+	})
+}
@@ -0,0 +1,75 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+)
+
+const adminAlertTimeout = 10 * time.Second
+
+// AdminAlerter posts a one-line message about every new event to a global
+// Slack incoming webhook, regardless of subscriber settings.
+type AdminAlerter struct {
+	webhookURL string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewAdminAlerter creates an alerter posting to webhookURL.
+// baseURL is used for event links and may be empty.
+func NewAdminAlerter(webhookURL, baseURL string) *AdminAlerter {
+	return &AdminAlerter{
+		webhookURL: webhookURL,
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: adminAlertTimeout, Transport: httputil.RequestIDTransport(nil)},
+	}
+}
+
+// Alert posts "[SEVERITY] <title> – <link>" for the event.
+// Maintenance has no severity and is labelled MAINTENANCE.
+func (a *AdminAlerter) Alert(ctx context.Context, event *domain.Event) error {
+	body, err := json.Marshal(map[string]string{"text": a.message(event)})
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+func (a *AdminAlerter) message(event *domain.Event) string {
+	label := "MAINTENANCE"
+	if event.Severity != nil {
+		label = strings.ToUpper(string(*event.Severity))
+	}
+
+	text := fmt.Sprintf("[%s] %s", label, event.Title)
+	if a.baseURL != "" {
+		text += fmt.Sprintf(" – %s/events/%s", a.baseURL, event.ID)
+	}
+	return text
+}
@@ -0,0 +1,78 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slackServer records the text of posted messages and replies with status.
+func slackServer(t *testing.T, status int, texts *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var payload struct {
+			Text string `json:"text"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		*texts = append(*texts, payload.Text)
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAdminAlerter_Alert_Incident(t *testing.T) {
+	var texts []string
+	server := slackServer(t, http.StatusOK, &texts)
+
+	severity := domain.SeverityCritical
+	alerter := NewAdminAlerter(server.URL, "https://status.example.com")
+	err := alerter.Alert(context.Background(), &domain.Event{
+		ID:       "evt-1",
+		Title:    "API down",
+		Type:     domain.EventTypeIncident,
+		Severity: &severity,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"[CRITICAL] API down – https://status.example.com/events/evt-1"}, texts)
+}
+
+func TestAdminAlerter_Alert_MaintenanceWithoutBaseURL(t *testing.T) {
+	var texts []string
+	server := slackServer(t, http.StatusOK, &texts)
+
+	alerter := NewAdminAlerter(server.URL, "")
+	err := alerter.Alert(context.Background(), &domain.Event{
+		ID:    "evt-2",
+		Title: "Database upgrade",
+		Type:  domain.EventTypeMaintenance,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"[MAINTENANCE] Database upgrade"}, texts)
+}
+
+func TestAdminAlerter_Alert_ErrorStatus(t *testing.T) {
+	var texts []string
+	server := slackServer(t, http.StatusForbidden, &texts)
+
+	severity := domain.SeverityMinor
+	alerter := NewAdminAlerter(server.URL, "")
+	err := alerter.Alert(context.Background(), &domain.Event{ID: "evt-3", Title: "Slow", Severity: &severity})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+	assert.Len(t, texts, 1)
+}
@@ -0,0 +1,39 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Pagination of GET /admin/notifications/dead-letters.
+const (
+	DefaultDeadLettersLimit = 20
+	MaxDeadLettersLimit     = 100
+)
+
+// DeadLetter is a notification the worker gave up on after MaxAttempts.
+// The queue item stays failed until the dead letter is requeued.
+type DeadLetter struct {
+	ID             string          `json:"id"`
+	NotificationID string          `json:"notification_id"`
+	EventID        string          `json:"event_id"`
+	ChannelID      string          `json:"channel_id"`
+	MessageType    MessageType     `json:"message_type"`
+	Payload        json.RawMessage `json:"payload"`
+	LastError      string          `json:"last_error"`
+	Attempts       int             `json:"attempts"`
+	AttemptedAt    []time.Time     `json:"attempted_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// ListDeadLetters returns dead letters, newest first, and their total count.
+func (s *Service) ListDeadLetters(ctx context.Context, limit, offset int) ([]DeadLetter, int, error) {
+	return s.repo.ListDeadLetters(ctx, limit, offset)
+}
+
+// RequeueDeadLetter puts a dead-lettered notification back into the queue with
+// a fresh attempt budget and removes the dead letter.
+func (s *Service) RequeueDeadLetter(ctx context.Context, id string) error {
+	return s.repo.RequeueDeadLetter(ctx, id)
+}
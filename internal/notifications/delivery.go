@@ -0,0 +1,34 @@
+package notifications
+
+import (
+	"context"
+	"time"
+)
+
+// DeliveryStatus represents the outcome of a delivery attempt.
+type DeliveryStatus string
+
+// Delivery statuses.
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// Delivery is a receipt of one attempt to send a queued notification.
+// A notification retried by the worker has one delivery per attempt.
+type Delivery struct {
+	ID             string         `json:"id"`
+	NotificationID string         `json:"notification_id"`
+	ChannelID      string         `json:"channel_id"`
+	Status         DeliveryStatus `json:"status"`
+	SentAt         *time.Time     `json:"sent_at"`
+	FailedAt       *time.Time     `json:"failed_at"`
+	FailureReason  *string        `json:"failure_reason"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+// ListDeliveries returns the delivery attempts of a queued notification.
+func (s *Service) ListDeliveries(ctx context.Context, notificationID string) ([]Delivery, error) {
+	return s.repo.ListDeliveries(ctx, notificationID)
+}
@@ -2,6 +2,7 @@ package notifications
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 
@@ -28,9 +29,10 @@ func NewDispatcher(repo Repository, senders ...Sender) *Dispatcher {
 
 // DispatchInput contains data for dispatching notifications.
 type DispatchInput struct {
-	ServiceIDs []string
-	Subject    string
-	Body       string
+	ServiceIDs       []string
+	Subject          string
+	Body             string
+	NotificationType string // notification_type for webhook channels; default event_updated
 }
 
 // Dispatch sends notifications to all subscribers of the given services.
@@ -40,7 +42,7 @@ func (d *Dispatcher) Dispatch(ctx context.Context, input DispatchInput) error {
 		return fmt.Errorf("find subscribers: %w", err)
 	}
 
-	slog.Info("dispatching notifications",
+	slog.InfoContext(ctx, "dispatching notifications",
 		"service_ids", input.ServiceIDs,
 		"channel_count", len(channels),
 	)
@@ -48,7 +50,7 @@ func (d *Dispatcher) Dispatch(ctx context.Context, input DispatchInput) error {
 	for _, ch := range channels {
 		sender, ok := d.senders[ch.Type]
 		if !ok {
-			slog.Warn("no sender for channel type", "type", ch.Type)
+			slog.WarnContext(ctx, "no sender for channel type", "type", ch.Type)
 			continue
 		}
 
@@ -56,10 +58,29 @@ func (d *Dispatcher) Dispatch(ctx context.Context, input DispatchInput) error {
 			To:      ch.Target,
 			Subject: input.Subject,
 			Body:    input.Body,
+			Secret:  ch.Secret,
+		}
+
+		// Webhook endpoints expect JSON, wrap the plain-text message
+		if ch.Type == domain.ChannelTypeWebhook {
+			notificationType := input.NotificationType
+			if notificationType == "" {
+				notificationType = WebhookNotificationEventUpdated
+			}
+			body, err := json.Marshal(WebhookMessage{
+				NotificationType: notificationType,
+				Subject:          input.Subject,
+				Message:          input.Body,
+			})
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to marshal webhook message", "channel_id", ch.ID, "error", err)
+				continue
+			}
+			notification.Body = string(body)
 		}
 
 		if err := sender.Send(ctx, notification); err != nil {
-			slog.Error("failed to send notification",
+			slog.ErrorContext(ctx, "failed to send notification",
 				"channel_type", ch.Type,
 				"target", ch.Target,
 				"error", err,
@@ -2,13 +2,20 @@
 package email
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"html"
+	"io"
 	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
 	"net"
 	"net/smtp"
+	"net/textproto"
 	"strings"
 	"time"
 
@@ -80,7 +87,7 @@ func (s *Sender) Type() domain.ChannelType {
 // Send sends an email notification to a single recipient.
 func (s *Sender) Send(ctx context.Context, notification notifications.Notification) error {
 	if !s.config.Enabled {
-		slog.Warn("email sender disabled, skipping send",
+		slog.WarnContext(ctx, "email sender disabled, skipping send",
 			"recipient_count", 1,
 		)
 		return nil
@@ -93,7 +100,7 @@ func (s *Sender) Send(ctx context.Context, notification notifications.Notificati
 // Recipients are split into batches to respect SMTP server limits.
 func (s *Sender) SendBatch(ctx context.Context, subject, body string, recipients []string) error {
 	if !s.config.Enabled {
-		slog.Warn("email sender disabled, skipping send",
+		slog.WarnContext(ctx, "email sender disabled, skipping send",
 			"recipient_count", len(recipients),
 		)
 		return nil
@@ -109,7 +116,7 @@ func (s *Sender) SendBatch(ctx context.Context, subject, body string, recipients
 		batch := recipients[i:end]
 
 		if err := s.sendEmail(ctx, subject, body, batch); err != nil {
-			slog.Error("failed to send email batch",
+			slog.ErrorContext(ctx, "failed to send email batch",
 				"batch_start", i,
 				"batch_size", len(batch),
 				"error", err,
@@ -118,7 +125,7 @@ func (s *Sender) SendBatch(ctx context.Context, subject, body string, recipients
 			continue
 		}
 
-		slog.Info("email batch sent",
+		slog.InfoContext(ctx, "email batch sent",
 			"batch_start", i,
 			"batch_size", len(batch),
 		)
@@ -128,6 +135,7 @@ func (s *Sender) SendBatch(ctx context.Context, subject, body string, recipients
 }
 
 // sendEmail sends an email to the specified recipients.
+// Failures are returned as PermanentError or RetryableError.
 func (s *Sender) sendEmail(ctx context.Context, subject, body string, recipients []string) error {
 	msg := s.buildMessage(subject, body)
 	addr := fmt.Sprintf("%s:%d", s.config.SMTPHost, s.config.SMTPPort)
@@ -137,25 +145,56 @@ func (s *Sender) sendEmail(ctx context.Context, subject, body string, recipients
 		MinVersion: tls.VersionTLS12,
 	}
 
-	return s.sendWithSTARTTLS(ctx, addr, tlsConfig, recipients, msg)
+	return classifyError(s.sendWithSTARTTLS(ctx, addr, tlsConfig, recipients, msg))
 }
 
 // buildMessage constructs the email message with headers.
+// The body is sent as multipart/alternative: the text as is and an HTML version of it.
 func (s *Sender) buildMessage(subject, body string) []byte {
+	// Writes to a bytes.Buffer never fail
+	var parts bytes.Buffer
+	mw := multipart.NewWriter(&parts)
+	writePart(mw, "text/plain", body)
+	writePart(mw, "text/html", renderHTML(body))
+	_ = mw.Close()
+
 	var msg strings.Builder
 
 	// Headers in deterministic order
 	msg.WriteString(fmt.Sprintf("From: %s\r\n", s.config.FromAddress))
 	msg.WriteString("To: undisclosed-recipients:;\r\n")
-	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
+	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject)))
 	msg.WriteString("MIME-Version: 1.0\r\n")
-	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	msg.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=%q\r\n", mw.Boundary()))
 	msg.WriteString("\r\n")
-	msg.WriteString(body)
+	msg.Write(parts.Bytes())
 
 	return []byte(msg.String())
 }
 
+// writePart adds a quoted-printable UTF-8 part of the given media type.
+func writePart(mw *multipart.Writer, mediaType, content string) {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mediaType+"; charset=\"utf-8\"")
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	pw, err := mw.CreatePart(header)
+	if err != nil {
+		return
+	}
+	qp := quotedprintable.NewWriter(pw)
+	_, _ = io.WriteString(qp, content)
+	_ = qp.Close()
+}
+
+// renderHTML wraps the plain text body into a minimal HTML document, preserving line breaks.
+func renderHTML(body string) string {
+	return "<!DOCTYPE html>\r\n<html><body>\r\n" +
+		"<div style=\"font-family: sans-serif; white-space: pre-wrap;\">" +
+		html.EscapeString(body) +
+		"</div>\r\n</body></html>\r\n"
+}
+
 // sendWithSTARTTLS sends an email using STARTTLS (port 587).
 func (s *Sender) sendWithSTARTTLS(ctx context.Context, addr string, tlsConfig *tls.Config, recipients []string, msg []byte) error {
 	// Dial with timeout
@@ -180,6 +219,11 @@ func (s *Sender) sendWithSTARTTLS(ctx context.Context, addr string, tlsConfig *t
 		}
 	}
 
+	return s.deliver(client, recipients, msg)
+}
+
+// deliver authenticates and sends the message over an established SMTP session.
+func (s *Sender) deliver(client *smtp.Client, recipients []string, msg []byte) error {
 	// Authenticate if credentials provided
 	if s.auth != nil {
 		if err := client.Auth(s.auth); err != nil {
@@ -195,17 +239,22 @@ func (s *Sender) sendWithSTARTTLS(ctx context.Context, addr string, tlsConfig *t
 
 	// Add recipients (BCC - recipients are in envelope, not headers)
 	var addedRecipients int
+	var rcptErr error
 	for _, rcpt := range recipients {
 		if err := client.Rcpt(rcpt); err != nil {
 			slog.Warn("failed to add recipient",
 				"error", err,
 			)
+			rcptErr = err
 			continue
 		}
 		addedRecipients++
 	}
 
 	if addedRecipients == 0 {
+		if rcptErr != nil {
+			return fmt.Errorf("no valid recipients: %w", rcptErr)
+		}
 		return errors.New("no valid recipients")
 	}
 
@@ -272,3 +321,48 @@ func IsRetryable(err error) bool {
 
 	return false
 }
+
+// classifyError maps a send failure to a sender error (nil stays nil).
+// SMTP 5xx replies are permanent, except 552 (mailbox full) which usually clears up;
+// 4xx replies, network and other failures are retryable.
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		if protoErr.Code >= 500 && protoErr.Code != 552 {
+			return &PermanentError{Code: protoErr.Code, Message: err.Error()}
+		}
+		return &RetryableError{Code: protoErr.Code, Message: err.Error()}
+	}
+
+	return &RetryableError{Message: err.Error()}
+}
+
+// PermanentError indicates a permanent error that should not be retried.
+type PermanentError struct {
+	Code    int
+	Message string
+}
+
+func (e *PermanentError) Error() string {
+	return fmt.Sprintf("email error: %s", e.Message)
+}
+
+// IsRetryable returns false as permanent errors should not be retried.
+func (e *PermanentError) IsRetryable() bool { return false }
+
+// RetryableError indicates a temporary error that can be retried.
+type RetryableError struct {
+	Code    int
+	Message string
+}
+
+func (e *RetryableError) Error() string {
+	return fmt.Sprintf("email error: %s", e.Message)
+}
+
+// IsRetryable returns true as these errors are temporary.
+func (e *RetryableError) IsRetryable() bool { return true }
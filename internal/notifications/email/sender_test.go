@@ -2,7 +2,14 @@ package email
 
 import (
 	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
 	"testing"
 
 	"github.com/bissquit/incident-garden/internal/domain"
@@ -178,6 +185,149 @@ func TestSender_BuildMessage(t *testing.T) {
 	assert.Contains(t, msgStr, "Test body content")
 }
 
+func TestSender_BuildMessage_Multipart(t *testing.T) {
+	sender := &Sender{config: Config{FromAddress: "noreply@example.com"}}
+
+	body := "Service <API> is down.\nStatus: major & rising — Платежи"
+	msg, err := mail.ReadMessage(strings.NewReader(string(sender.buildMessage("Инцидент", body))))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Инцидент", subject)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	// multipart.Reader decodes quoted-printable parts transparently
+	parts := make(map[string]string)
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		partType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		content, err := io.ReadAll(part)
+		require.NoError(t, err)
+		parts[partType] = string(content)
+	}
+
+	// Line breaks are sent as CRLF
+	require.Len(t, parts, 2)
+	assert.Equal(t, strings.ReplaceAll(body, "\n", "\r\n"), parts["text/plain"])
+	assert.Contains(t, parts["text/html"], "Service &lt;API&gt; is down.\r\nStatus: major &amp; rising — Платежи")
+	assert.Contains(t, parts["text/html"], "white-space: pre-wrap")
+}
+
+// fakeSMTPServer serves one SMTP session on conn. Commands listed in reject
+// (by verb: MAIL, RCPT, DATA) get the given reply instead of success.
+// The message received after DATA is sent to the returned channel.
+func fakeSMTPServer(conn net.Conn, reject map[string]string) <-chan string {
+	received := make(chan string, 1)
+	go func() {
+		defer func() { _ = conn.Close() }()
+		tp := textproto.NewConn(conn)
+		_ = tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			verb := strings.ToUpper(strings.Fields(line)[0])
+			if reply, ok := reject[verb]; ok {
+				_ = tp.PrintfLine("%s", reply)
+				continue
+			}
+			switch verb {
+			case "DATA":
+				_ = tp.PrintfLine("354 go ahead")
+				data, err := tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				received <- string(data)
+				_ = tp.PrintfLine("250 queued")
+			case "QUIT":
+				_ = tp.PrintfLine("221 bye")
+				return
+			default:
+				_ = tp.PrintfLine("250 ok")
+			}
+		}
+	}()
+	return received
+}
+
+// deliverTo runs a session against fakeSMTPServer and returns the classified result.
+func deliverTo(t *testing.T, reject map[string]string, recipients ...string) (<-chan string, error) {
+	t.Helper()
+	clientConn, serverConn := net.Pipe()
+	received := fakeSMTPServer(serverConn, reject)
+
+	client, err := smtp.NewClient(clientConn, "localhost")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	sender := &Sender{config: Config{FromAddress: "StatusPage <noreply@example.com>"}}
+	return received, classifyError(sender.deliver(client, recipients, sender.buildMessage("Alert", "API is down")))
+}
+
+func TestSender_Deliver_Success(t *testing.T) {
+	received, err := deliverTo(t, nil, "user@example.com")
+	require.NoError(t, err)
+
+	msg := <-received
+	assert.Contains(t, msg, "Subject: Alert")
+	assert.Contains(t, msg, "multipart/alternative")
+	assert.Contains(t, msg, "API is down")
+}
+
+func TestSender_Deliver_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		reject    map[string]string
+		code      int
+		retryable bool
+	}{
+		{"mailbox not found", map[string]string{"RCPT": "550 mailbox not found"}, 550, false},
+		{"sender rejected", map[string]string{"MAIL": "553 sender not allowed"}, 553, false},
+		{"greylisted", map[string]string{"RCPT": "450 try again later"}, 450, true},
+		{"data unavailable", map[string]string{"DATA": "421 service not available"}, 421, true},
+		{"mailbox full", map[string]string{"RCPT": "552 mailbox full"}, 552, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := deliverTo(t, tt.reject, "user@example.com")
+			require.Error(t, err)
+
+			if tt.retryable {
+				var retryable *RetryableError
+				require.ErrorAs(t, err, &retryable)
+				assert.Equal(t, tt.code, retryable.Code)
+			} else {
+				var permanent *PermanentError
+				require.ErrorAs(t, err, &permanent)
+				assert.Equal(t, tt.code, permanent.Code)
+			}
+			assert.Equal(t, tt.retryable, err.(interface{ IsRetryable() bool }).IsRetryable())
+		})
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	assert.NoError(t, classifyError(nil))
+
+	var retryable *RetryableError
+	err := classifyError(&net.OpError{Op: "dial", Err: errors.New("connection refused")})
+	require.ErrorAs(t, err, &retryable)
+	assert.Zero(t, retryable.Code)
+}
+
 func TestIsRetryable(t *testing.T) {
 	tests := []struct {
 		name      string
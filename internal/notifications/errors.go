@@ -25,6 +25,27 @@ var (
 	ErrServicesNotFound   = errors.New("one or more services not found")
 )
 
+// Telegram bot errors.
+var (
+	ErrTelegramChatNotLinked = errors.New("telegram chat is not linked to a verified channel")
+)
+
+// Public subscription errors.
+var (
+	ErrSubscriberTokenNotFound = errors.New("subscriber token not found")
+)
+
+// Event subscriber errors.
+var (
+	ErrEventNotFound = errors.New("event not found")
+)
+
+// Delivery errors.
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrDeadLetterNotFound   = errors.New("dead letter not found")
+)
+
 // Deletion errors.
 var (
 	ErrCannotDeleteDefaultChannel = errors.New("cannot delete default channel")
@@ -33,4 +54,10 @@ var (
 // Channel type errors.
 var (
 	ErrChannelTypeDisabled = errors.New("channel type is not available")
+	ErrSecretNotSupported  = errors.New("secret is only supported for webhook channels")
+)
+
+// Limit errors.
+var (
+	ErrChannelLimitReached = errors.New("channel limit reached")
 )
@@ -1,9 +1,12 @@
 package notifications
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/bissquit/incident-garden/internal/domain"
 	"github.com/bissquit/incident-garden/internal/pkg/httputil"
@@ -14,7 +17,7 @@ import (
 var errorMappings = []httputil.ErrorMapping{
 	{Error: ErrChannelNotFound, Status: http.StatusNotFound, Message: "notification channel not found"},
 	{Error: ErrChannelNotOwned, Status: http.StatusForbidden, Message: "channel does not belong to user"},
-	{Error: ErrChannelAlreadyExists, Status: http.StatusConflict, Message: "channel with this email already exists"},
+	{Error: ErrChannelAlreadyExists, Status: http.StatusConflict, Message: "channel already exists"},
 	{Error: ErrVerificationCodeNotFound, Status: http.StatusBadRequest, Message: "verification code expired, request a new one"},
 	{Error: ErrVerificationCodeInvalid, Status: http.StatusBadRequest, Message: "invalid verification code"},
 	{Error: ErrTooManyAttempts, Status: http.StatusTooManyRequests, Message: "too many attempts, request a new code"},
@@ -25,19 +28,28 @@ var errorMappings = []httputil.ErrorMapping{
 	{Error: ErrServicesNotFound, Status: http.StatusBadRequest, Message: "one or more services not found"},
 	{Error: ErrCannotDeleteDefaultChannel, Status: http.StatusConflict, Message: "cannot delete default channel"},
 	{Error: ErrChannelTypeDisabled, Status: http.StatusBadRequest, Message: "channel type is not available"},
+	{Error: ErrSecretNotSupported, Status: http.StatusBadRequest, Message: "secret is only supported for webhook channels"},
 	{Error: ErrVerificationFailed, Status: http.StatusUnprocessableEntity, Message: ""},
+	{Error: ErrSubscriberTokenNotFound, Status: http.StatusNotFound, Message: "subscription not found"},
+	{Error: ErrEventNotFound, Status: http.StatusNotFound, Message: "event not found"},
+	{Error: ErrNotificationNotFound, Status: http.StatusNotFound, Message: "notification not found"},
+	{Error: ErrDeadLetterNotFound, Status: http.StatusNotFound, Message: "dead letter not found"},
+	{Error: ErrChannelLimitReached, Status: http.StatusTooManyRequests, Message: "channel limit reached"},
 }
 
 // Handler handles HTTP requests for the notifications module.
 type Handler struct {
 	service   *Service
+	limits    ChannelLimitProvider
 	validator *validator.Validate
 }
 
 // NewHandler creates a new notifications handler.
-func NewHandler(service *Service) *Handler {
+// limits may be nil, in which case users may create any number of channels.
+func NewHandler(service *Service, limits ChannelLimitProvider) *Handler {
 	return &Handler{
 		service:   service,
+		limits:    limits,
 		validator: validator.New(),
 	}
 }
@@ -55,13 +67,34 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 
 	// Subscription endpoints
 	r.Get("/me/subscriptions", h.GetSubscriptions)
+	r.Delete("/me/subscriptions", h.UnsubscribeAll)
 	r.Put("/me/channels/{id}/subscriptions", h.SetChannelSubscriptions)
 }
 
+// RegisterOperatorRoutes registers operator-level routes.
+func (h *Handler) RegisterOperatorRoutes(r chi.Router) {
+	r.Get("/events/{id}/subscribers", h.ListEventSubscribers)
+}
+
+// RegisterAdminRoutes registers admin-level routes.
+func (h *Handler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/notifications/{id}/deliveries", h.ListDeliveries)
+	r.Get("/admin/notifications/dead-letters", h.ListDeadLetters)
+	r.Patch("/admin/notifications/dead-letters/{id}/retry", h.RequeueDeadLetter)
+}
+
+// RegisterPublicRoutes registers public subscription routes (no auth).
+func (h *Handler) RegisterPublicRoutes(r chi.Router) {
+	r.Post("/subscribe", h.Subscribe)
+	r.Post("/subscribe/verify", h.VerifySubscription)
+	r.Delete("/unsubscribe", h.Unsubscribe)
+}
+
 // CreateChannelRequest represents request body for creating a channel.
 type CreateChannelRequest struct {
-	Type   string `json:"type" validate:"required,oneof=email telegram mattermost"`
+	Type   string `json:"type" validate:"required,oneof=email telegram mattermost slack webhook"`
 	Target string `json:"target" validate:"required"`
+	Secret string `json:"secret" validate:"omitempty,max=255"` // HMAC signing secret, webhook only
 }
 
 // UpdateChannelRequest represents request body for updating a channel.
@@ -87,6 +120,77 @@ func (h *Handler) ListChannels(w http.ResponseWriter, r *http.Request) {
 	httputil.Success(w, http.StatusOK, channels)
 }
 
+// ListEventSubscribers handles GET /events/{id}/subscribers.
+func (h *Handler) ListEventSubscribers(w http.ResponseWriter, r *http.Request) {
+	subscribers, err := h.service.ListEventSubscribers(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, subscribers)
+}
+
+// ListDeliveries handles GET /notifications/{id}/deliveries.
+func (h *Handler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := h.service.ListDeliveries(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, deliveries)
+}
+
+// ListDeadLetters handles GET /admin/notifications/dead-letters.
+func (h *Handler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	limit := DefaultDeadLettersLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 {
+			httputil.Error(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		if parsed > MaxDeadLettersLimit {
+			parsed = MaxDeadLettersLimit
+		}
+		limit = parsed
+	}
+
+	offset := 0
+	if o := r.URL.Query().Get("offset"); o != "" {
+		parsed, err := strconv.Atoi(o)
+		if err != nil || parsed < 0 {
+			httputil.Error(w, http.StatusBadRequest, "offset must be a non-negative integer")
+			return
+		}
+		offset = parsed
+	}
+
+	letters, total, err := h.service.ListDeadLetters(r.Context(), limit, offset)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, map[string]interface{}{
+		"dead_letters": letters,
+		"total":        total,
+		"limit":        limit,
+		"offset":       offset,
+	})
+}
+
+// RequeueDeadLetter handles PATCH /admin/notifications/dead-letters/{id}/retry.
+func (h *Handler) RequeueDeadLetter(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.RequeueDeadLetter(r.Context(), chi.URLParam(r, "id")); err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // CreateChannel handles POST /me/channels.
 func (h *Handler) CreateChannel(w http.ResponseWriter, r *http.Request) {
 	userID := httputil.GetUserID(r.Context())
@@ -102,7 +206,12 @@ func (h *Handler) CreateChannel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	channel, err := h.service.CreateChannel(r.Context(), userID, domain.ChannelType(req.Type), req.Target)
+	if err := h.checkChannelLimit(r.Context(), userID); err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	channel, err := h.service.CreateChannel(r.Context(), userID, domain.ChannelType(req.Type), req.Target, req.Secret)
 	if err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
@@ -111,6 +220,27 @@ func (h *Handler) CreateChannel(w http.ResponseWriter, r *http.Request) {
 	httputil.Success(w, http.StatusCreated, channel)
 }
 
+// checkChannelLimit returns ErrChannelLimitReached if a non-admin user already has
+// the maximum number of channels. Concurrent requests may overshoot it slightly.
+func (h *Handler) checkChannelLimit(ctx context.Context, userID string) error {
+	if h.limits == nil || httputil.GetRole(ctx) == domain.RoleAdmin {
+		return nil
+	}
+
+	limit, err := h.limits.GetMaxChannelsPerUser(ctx)
+	if err != nil {
+		return fmt.Errorf("get channel limit: %w", err)
+	}
+	count, err := h.service.CountUserChannels(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if count >= limit {
+		return ErrChannelLimitReached
+	}
+	return nil
+}
+
 // UpdateChannel handles PATCH /me/channels/{id}.
 func (h *Handler) UpdateChannel(w http.ResponseWriter, r *http.Request) {
 	userID := httputil.GetUserID(r.Context())
@@ -201,10 +331,23 @@ func (h *Handler) GetSubscriptions(w http.ResponseWriter, r *http.Request) {
 	httputil.Success(w, http.StatusOK, matrix)
 }
 
+// UnsubscribeAll handles DELETE /me/subscriptions.
+func (h *Handler) UnsubscribeAll(w http.ResponseWriter, r *http.Request) {
+	userID := httputil.GetUserID(r.Context())
+
+	if err := h.service.UnsubscribeAll(r.Context(), userID); err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // SetSubscriptionsRequest represents request body for setting channel subscriptions.
 type SetSubscriptionsRequest struct {
-	SubscribeToAllServices bool     `json:"subscribe_to_all_services"`
-	ServiceIDs             []string `json:"service_ids" validate:"dive,uuid"`
+	SubscribeToAllServices bool             `json:"subscribe_to_all_services"`
+	ServiceIDs             []string         `json:"service_ids" validate:"dive,uuid"`
+	MinSeverity            *domain.Severity `json:"min_severity" validate:"omitempty,oneof=minor major critical"`
 }
 
 // SetChannelSubscriptions handles PUT /me/channels/{id}/subscriptions.
@@ -229,7 +372,7 @@ func (h *Handler) SetChannelSubscriptions(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err := h.service.SetChannelSubscriptions(r.Context(), userID, channelID, req.SubscribeToAllServices, req.ServiceIDs)
+	err := h.service.SetChannelSubscriptions(r.Context(), userID, channelID, req.SubscribeToAllServices, req.ServiceIDs, req.MinSeverity)
 	if err != nil {
 		httputil.HandleError(r.Context(), w, err, errorMappings)
 		return
@@ -246,6 +389,7 @@ func (h *Handler) SetChannelSubscriptions(w http.ResponseWriter, r *http.Request
 		"channel_id":                channelID,
 		"subscribe_to_all_services": subscribeAll,
 		"subscribed_service_ids":    serviceIDs,
+		"min_severity":              req.MinSeverity,
 	})
 }
 
@@ -254,3 +398,75 @@ func (h *Handler) GetNotificationsConfig(w http.ResponseWriter, _ *http.Request)
 	config := h.service.GetAvailableChannels()
 	httputil.Success(w, http.StatusOK, config)
 }
+
+// SubscribeRequest represents request body for a public subscription.
+type SubscribeRequest struct {
+	Email      string   `json:"email" validate:"required,email,max=255"`
+	ServiceIDs []string `json:"service_ids" validate:"required,min=1,dive,uuid"`
+}
+
+// VerifySubscriptionRequest represents request body for confirming a public subscription.
+type VerifySubscriptionRequest struct {
+	Token string `json:"token" validate:"required"`
+	Code  string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// Subscribe handles POST /subscribe.
+func (h *Handler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	var req SubscribeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		httputil.ValidationError(w, err)
+		return
+	}
+
+	subscription, err := h.service.Subscribe(r.Context(), req.Email, req.ServiceIDs)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusCreated, subscription)
+}
+
+// VerifySubscription handles POST /subscribe/verify.
+func (h *Handler) VerifySubscription(w http.ResponseWriter, r *http.Request) {
+	var req VerifySubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		httputil.ValidationError(w, err)
+		return
+	}
+
+	subscription, err := h.service.VerifySubscription(r.Context(), req.Token, req.Code)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, subscription)
+}
+
+// Unsubscribe handles DELETE /unsubscribe?token=....
+func (h *Handler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		httputil.Error(w, http.StatusBadRequest, "token is required")
+		return
+	}
+
+	if err := h.service.Unsubscribe(r.Context(), token); err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
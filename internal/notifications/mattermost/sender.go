@@ -5,21 +5,39 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/bissquit/incident-garden/internal/domain"
 	"github.com/bissquit/incident-garden/internal/notifications"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
 )
 
 const (
-	defaultTimeout  = 10 * time.Second
-	defaultUsername = "StatusPage"
+	defaultTimeout        = 10 * time.Second
+	defaultUsername       = "StatusPage"
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = 500 * time.Millisecond
+
+	// maxRetryAfter caps the wait requested by a 429 Retry-After header.
+	maxRetryAfter = 30 * time.Second
+
+	// maxErrorBodyLength limits how much of the response body is kept in error messages.
+	maxErrorBodyLength = 512
 )
 
+// severityColors are the attachment colors of incident severities.
+var severityColors = map[string]string{
+	"minor":    "#F5C518",
+	"major":    "#F08A24",
+	"critical": "#D0021B",
+}
+
 // Config holds Mattermost sender configuration.
 // Note: webhook URL is stored in notification_channel.target,
 // so global configuration is minimal.
@@ -28,7 +46,9 @@ const (
 type Config struct {
 	DefaultUsername string        // username for display, default "StatusPage"
 	DefaultIconURL  string        // icon URL (optional)
-	Timeout         time.Duration // request timeout
+	Timeout         time.Duration // per-request timeout
+	MaxAttempts     int           // total attempts per Send, including the first one
+	InitialBackoff  time.Duration // delay before the first retry, doubled on each next retry
 }
 
 // Sender implements Mattermost notification sender via Incoming Webhooks.
@@ -45,11 +65,18 @@ func NewSender(config Config) *Sender {
 	if config.Timeout == 0 {
 		config.Timeout = defaultTimeout
 	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+	if config.InitialBackoff == 0 {
+		config.InitialBackoff = defaultInitialBackoff
+	}
 
 	return &Sender{
 		config: config,
 		httpClient: &http.Client{
-			Timeout: config.Timeout,
+			Timeout:   config.Timeout,
+			Transport: httputil.RequestIDTransport(nil),
 		},
 	}
 }
@@ -60,33 +87,100 @@ func (s *Sender) Type() domain.ChannelType {
 }
 
 // Send sends a notification to Mattermost.
-// notification.To contains the webhook URL.
+// notification.To contains the webhook URL. 429 and 5xx responses are retried
+// up to MaxAttempts, waiting Retry-After for 429 and an exponential backoff otherwise.
 func (s *Sender) Send(ctx context.Context, notification notifications.Notification) error {
 	webhookURL := notification.To
 	if webhookURL == "" {
 		return &PermanentError{Message: "webhook URL is empty"}
 	}
 
+	body, err := json.Marshal(s.buildPayload(notification))
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	backoff := s.config.InitialBackoff
+	var lastErr error
+	for attempt := 1; attempt <= s.config.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			wait := backoff
+			var retryErr *RetryableError
+			if errors.As(lastErr, &retryErr) && retryErr.RetryAfter > 0 {
+				wait = retryErr.RetryAfter
+			}
+			select {
+			case <-ctx.Done():
+				return &RetryableError{Message: fmt.Sprintf("context done: %v", ctx.Err())}
+			case <-time.After(wait):
+			}
+			backoff *= 2
+		}
+
+		lastErr = s.post(ctx, webhookURL, body)
+		var retryErr *RetryableError
+		if lastErr == nil || !errors.As(lastErr, &retryErr) || retryErr.Code == 0 {
+			return lastErr
+		}
+
+		slog.DebugContext(ctx, "mattermost webhook failed, retrying",
+			"webhook", maskWebhookURL(webhookURL),
+			"attempt", attempt,
+			"max_attempts", s.config.MaxAttempts,
+			"status", retryErr.Code,
+		)
+	}
+
+	return lastErr
+}
+
+type webhookPayload struct {
+	Text        string       `json:"text"`
+	Username    string       `json:"username,omitempty"`
+	IconURL     string       `json:"icon_url,omitempty"`
+	Attachments []attachment `json:"attachments,omitempty"`
+}
+
+// attachment is a Mattermost message attachment; Color is the bar on its left.
+type attachment struct {
+	Fallback string `json:"fallback"`
+	Color    string `json:"color"`
+	Text     string `json:"text"`
+}
+
+// buildPayload puts the subject as a heading above the body. Incident messages
+// with a known severity carry the body in an attachment colored by it.
+func (s *Sender) buildPayload(notification notifications.Notification) webhookPayload {
 	payload := webhookPayload{
 		Username: s.config.DefaultUsername,
+		IconURL:  s.config.DefaultIconURL,
 	}
 
-	if s.config.DefaultIconURL != "" {
-		payload.IconURL = s.config.DefaultIconURL
+	color, colored := severityColors[notification.Severity]
+	if !colored {
+		payload.Text = notification.Body
+		if notification.Subject != "" {
+			payload.Text = fmt.Sprintf("### %s\n\n%s", notification.Subject, notification.Body)
+		}
+		return payload
 	}
 
-	// If subject is provided, add as markdown heading
+	fallback := notification.Body
 	if notification.Subject != "" {
-		payload.Text = fmt.Sprintf("### %s\n\n%s", notification.Subject, notification.Body)
-	} else {
-		payload.Text = notification.Body
+		payload.Text = "### " + notification.Subject
+		fallback = notification.Subject
 	}
+	payload.Attachments = []attachment{{
+		Fallback: fallback,
+		Color:    color,
+		Text:     notification.Body,
+	}}
 
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("marshal payload: %w", err)
-	}
+	return payload
+}
 
+// post performs a single request and classifies the response.
+func (s *Sender) post(ctx context.Context, webhookURL string, body []byte) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
@@ -102,17 +196,12 @@ func (s *Sender) Send(ctx context.Context, notification notifications.Notificati
 	return s.handleResponse(resp, webhookURL)
 }
 
-type webhookPayload struct {
-	Text     string `json:"text"`
-	Username string `json:"username,omitempty"`
-	IconURL  string `json:"icon_url,omitempty"`
-}
-
 func (s *Sender) handleResponse(resp *http.Response, webhookURL string) error {
-	body, err := io.ReadAll(resp.Body)
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
 	if err != nil {
 		return fmt.Errorf("read response: %w", err)
 	}
+	body := errorMessage(raw)
 
 	switch resp.StatusCode {
 	case http.StatusOK:
@@ -122,36 +211,74 @@ func (s *Sender) handleResponse(resp *http.Response, webhookURL string) error {
 	case http.StatusBadRequest:
 		return &PermanentError{
 			Code:    resp.StatusCode,
-			Message: fmt.Sprintf("bad request: %s", string(body)),
+			Message: fmt.Sprintf("bad request: %s", body),
 		}
 
 	case http.StatusUnauthorized, http.StatusForbidden:
 		return &PermanentError{
 			Code:    resp.StatusCode,
-			Message: "invalid or expired webhook",
+			Message: withDetail("invalid or expired webhook", body),
 		}
 
 	case http.StatusNotFound:
 		return &PermanentError{
 			Code:    resp.StatusCode,
-			Message: "webhook not found",
+			Message: withDetail("webhook not found", body),
 		}
 
 	case http.StatusTooManyRequests:
 		return &RetryableError{
-			Code:    resp.StatusCode,
-			Message: "rate limited",
+			Code:       resp.StatusCode,
+			Message:    "rate limited",
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
 		}
 
 	default:
 		if resp.StatusCode >= 500 {
 			return &RetryableError{
 				Code:    resp.StatusCode,
-				Message: fmt.Sprintf("server error: %s", string(body)),
+				Message: fmt.Sprintf("server error: %s", body),
 			}
 		}
-		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+}
+
+// apiError is the error body of the Mattermost API.
+type apiError struct {
+	ID            string `json:"id"`
+	Message       string `json:"message"`
+	DetailedError string `json:"detailed_error"`
+}
+
+// errorMessage returns the message of a Mattermost error body, or the body itself
+// if it is not one (e.g. a proxy error page).
+func errorMessage(body []byte) string {
+	var apiErr apiError
+	if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Message == "" {
+		return string(body)
 	}
+	if apiErr.DetailedError != "" {
+		return fmt.Sprintf("%s (%s)", apiErr.Message, apiErr.DetailedError)
+	}
+	return apiErr.Message
+}
+
+func withDetail(message, detail string) string {
+	if detail == "" {
+		return message
+	}
+	return message + ": " + detail
+}
+
+// parseRetryAfter parses the Retry-After header value in seconds, capped at maxRetryAfter.
+// Returns 0 (use the regular backoff) if the header is missing or invalid.
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return min(time.Duration(seconds)*time.Second, maxRetryAfter)
 }
 
 // maskWebhookURL hides part of the URL for logging.
@@ -180,8 +307,9 @@ func (e *PermanentError) IsRetryable() bool { return false }
 
 // RetryableError indicates a temporary error that can be retried.
 type RetryableError struct {
-	Code    int
-	Message string
+	Code       int // HTTP status, 0 for network errors
+	Message    string
+	RetryAfter time.Duration // requested by a 429 Retry-After header, 0 if none
 }
 
 func (e *RetryableError) Error() string {
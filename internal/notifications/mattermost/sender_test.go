@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -19,6 +20,8 @@ func TestNewSender_Defaults(t *testing.T) {
 
 	assert.Equal(t, defaultUsername, sender.config.DefaultUsername)
 	assert.Equal(t, defaultTimeout, sender.config.Timeout)
+	assert.Equal(t, defaultMaxAttempts, sender.config.MaxAttempts)
+	assert.Equal(t, defaultInitialBackoff, sender.config.InitialBackoff)
 	assert.NotNil(t, sender.httpClient)
 }
 
@@ -211,7 +214,7 @@ func TestSender_Send_RateLimit(t *testing.T) {
 	}))
 	defer server.Close()
 
-	sender := NewSender(Config{})
+	sender := NewSender(Config{InitialBackoff: time.Millisecond})
 	err := sender.Send(context.Background(), notifications.Notification{
 		To:   server.URL,
 		Body: "Test message",
@@ -232,7 +235,7 @@ func TestSender_Send_ServerError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	sender := NewSender(Config{})
+	sender := NewSender(Config{InitialBackoff: time.Millisecond})
 	err := sender.Send(context.Background(), notifications.Notification{
 		To:   server.URL,
 		Body: "Test message",
@@ -252,7 +255,7 @@ func TestSender_Send_ServiceUnavailable(t *testing.T) {
 	}))
 	defer server.Close()
 
-	sender := NewSender(Config{})
+	sender := NewSender(Config{InitialBackoff: time.Millisecond})
 	err := sender.Send(context.Background(), notifications.Notification{
 		To:   server.URL,
 		Body: "Test message",
@@ -300,6 +303,140 @@ func TestSender_Send_NetworkError(t *testing.T) {
 	assert.True(t, retryErr.IsRetryable())
 }
 
+func TestSender_Send_SeverityAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload webhookPayload
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		require.NoError(t, err)
+
+		assert.Equal(t, "### Incident: API down", payload.Text)
+		require.Len(t, payload.Attachments, 1)
+		assert.Equal(t, severityColors["critical"], payload.Attachments[0].Color)
+		assert.Equal(t, "All requests fail", payload.Attachments[0].Text)
+		assert.Equal(t, "Incident: API down", payload.Attachments[0].Fallback)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{})
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:       server.URL,
+		Subject:  "Incident: API down",
+		Body:     "All requests fail",
+		Severity: "critical",
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestSender_Send_RateLimitRetryAfter(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{InitialBackoff: time.Millisecond})
+	start := time.Now()
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   server.URL,
+		Body: "Test message",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), attempts.Load())
+	assert.GreaterOrEqual(t, time.Since(start), time.Second, "should wait Retry-After, not the backoff")
+}
+
+func TestSender_Send_ServerErrorRetried(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{InitialBackoff: time.Millisecond})
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   server.URL,
+		Body: "Test message",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestSender_Send_ServerErrorExhausted(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   server.URL,
+		Body: "Test message",
+	})
+
+	var retryErr *RetryableError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, http.StatusServiceUnavailable, retryErr.Code)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestSender_Send_ClientErrorNotRetried(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"id":"web.incoming_webhook.text.app_error","message":"No text specified","detailed_error":"","status_code":400}`))
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{InitialBackoff: time.Millisecond})
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   server.URL,
+		Body: "Test message",
+	})
+
+	var permErr *PermanentError
+	require.ErrorAs(t, err, &permErr)
+	assert.Equal(t, "bad request: No text specified", permErr.Message)
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestErrorMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"mattermost error", `{"id":"x","message":"Invalid webhook","detailed_error":""}`, "Invalid webhook"},
+		{"with detail", `{"id":"x","message":"Invalid webhook","detailed_error":"hook disabled"}`, "Invalid webhook (hook disabled)"},
+		{"plain text", "502 Bad Gateway", "502 Bad Gateway"},
+		{"other json", `{"error":"nope"}`, `{"error":"nope"}`},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errorMessage([]byte(tt.body)))
+		})
+	}
+}
+
 func TestMaskWebhookURL(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -9,6 +9,13 @@ import (
 
 const namespace = "incidentgarden"
 
+// apiNamespace matches the documented incident_garden_* metrics exposed by
+// internal/pkg/metrics.
+const apiNamespace = "incident_garden"
+
+// statusSuccess is the status label of a delivered notification.
+const statusSuccess = "success"
+
 var (
 	notificationQueueSize = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -30,6 +37,16 @@ var (
 		[]string{"channel_type", "status"},
 	)
 
+	notificationsDelivered = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: apiNamespace,
+			Subsystem: "notifications",
+			Name:      "sent_total",
+			Help:      "Total notifications delivered by channel type",
+		},
+		[]string{"channel_type"},
+	)
+
 	notificationSendDuration = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: namespace,
@@ -54,6 +71,9 @@ var (
 // recordNotificationSent records a sent notification metric.
 func recordNotificationSent(channelType, status string) {
 	notificationsSent.WithLabelValues(channelType, status).Inc()
+	if status == statusSuccess {
+		notificationsDelivered.WithLabelValues(channelType).Inc()
+	}
 }
 
 // recordNotificationDuration records notification send duration.
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"log/slog"
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
 	"github.com/google/uuid"
 )
 
@@ -99,9 +101,10 @@ func (n *Notifier) OnEventCreated(ctx context.Context, event *domain.Event, serv
 	if err != nil {
 		return fmt.Errorf("find subscribers: %w", err)
 	}
+	channels = filterBySeverity(channels, event.Severity)
 
 	if len(channels) == 0 {
-		slog.Debug("no subscribers for event", "event_id", event.ID)
+		slog.DebugContext(ctx, "no subscribers for event", "event_id", event.ID)
 		return nil
 	}
 
@@ -121,14 +124,62 @@ func (n *Notifier) OnEventCreated(ctx context.Context, event *domain.Event, serv
 
 	// Enqueue notifications
 	if err := n.enqueueForChannels(ctx, event.ID, channelIDs, payload); err != nil {
-		slog.Error("failed to enqueue notifications", "event_id", event.ID, "error", err)
+		slog.ErrorContext(ctx, "failed to enqueue notifications", "event_id", event.ID, "error", err)
 		// Don't return error - event is created, notifications can be retried
 	}
 
-	slog.Info("event notifications queued", "event_id", event.ID, "subscribers", len(channels))
+	slog.InfoContext(ctx, "event notifications queued", "event_id", event.ID, "subscribers", len(channels))
 	return nil
 }
 
+// PreviewEventCreated returns the number of channels OnEventCreated would notify
+// and renders the initial message for each of their channel types, so template
+// errors surface without anything being enqueued.
+func (n *Notifier) PreviewEventCreated(ctx context.Context, event *domain.Event, serviceIDs []string) (int, error) {
+	if !event.NotifySubscribers {
+		return 0, nil
+	}
+
+	channels, err := n.repo.FindSubscribersForServices(ctx, serviceIDs)
+	if err != nil {
+		return 0, fmt.Errorf("find subscribers: %w", err)
+	}
+	channels = filterBySeverity(channels, event.Severity)
+	if len(channels) == 0 || n.renderer == nil {
+		return len(channels), nil
+	}
+
+	payload := NewInitialPayload(n.buildEventData(ctx, event, serviceIDs), n.buildEventURL(event.ID))
+	rendered := make(map[domain.ChannelType]bool)
+	for _, ch := range channels {
+		if rendered[ch.Type] {
+			continue
+		}
+		if _, _, err := n.renderer.Render(ch.Type, payload); err != nil {
+			return 0, fmt.Errorf("render %s notification: %w", ch.Type, err)
+		}
+		rendered[ch.Type] = true
+	}
+
+	return len(channels), nil
+}
+
+// filterBySeverity drops channels whose min_severity is above the event severity.
+// Events without severity (maintenance) reach every channel.
+func filterBySeverity(channels []ChannelInfo, severity *domain.Severity) []ChannelInfo {
+	if severity == nil {
+		return channels
+	}
+	filtered := make([]ChannelInfo, 0, len(channels))
+	for _, ch := range channels {
+		if ch.MinSeverity != nil && !severity.AtLeast(*ch.MinSeverity) {
+			continue
+		}
+		filtered = append(filtered, ch)
+	}
+	return filtered
+}
+
 // OnEventUpdated handles notifications for an event update.
 // changes should be *EventUpdateChanges or compatible struct.
 func (n *Notifier) OnEventUpdated(ctx context.Context, event *domain.Event, update *domain.EventUpdate, changes interface{}) error {
@@ -160,14 +211,14 @@ func (n *Notifier) onEventUpdatedInternal(ctx context.Context, event *domain.Eve
 
 		newChannels, err := n.repo.FindSubscribersForServices(ctx, addedServiceIDs)
 		if err != nil {
-			slog.Error("failed to find new subscribers", "error", err)
-		} else if len(newChannels) > 0 {
+			slog.ErrorContext(ctx, "failed to find new subscribers", "error", err)
+		} else if newChannels = filterBySeverity(newChannels, event.Severity); len(newChannels) > 0 {
 			newChannelIDs := make([]string, len(newChannels))
 			for i, ch := range newChannels {
 				newChannelIDs[i] = ch.ID
 			}
 			if err := n.repo.AddEventSubscribers(ctx, event.ID, newChannelIDs); err != nil {
-				slog.Error("failed to add subscribers", "error", err)
+				slog.ErrorContext(ctx, "failed to add subscribers", "error", err)
 			}
 		}
 	}
@@ -291,6 +342,83 @@ func (n *Notifier) OnEventCancelled(ctx context.Context, event *domain.Event) er
 	return n.sendToEventSubscribers(ctx, event.ID, payload)
 }
 
+// OnPostmortemPublished notifies event subscribers that the post-mortem of a
+// resolved event was published. It is sent as an update with a post-mortem link.
+func (n *Notifier) OnPostmortemPublished(ctx context.Context, event *domain.Event, postmortem *domain.EventPostmortem) error {
+	if !event.NotifySubscribers {
+		return nil
+	}
+
+	eventData := n.buildEventData(ctx, event, event.ServiceIDs)
+	eventData.Message = "Post-mortem published: " + postmortem.Title
+	payload := NewUpdatePayload(eventData, EventChanges{}, n.buildEventURL(event.ID))
+	payload.PostmortemURL = n.buildPostmortemURL(event.ID)
+
+	return n.sendToEventSubscribers(ctx, event.ID, payload)
+}
+
+// OnMaintenanceReminder handles notifications for scheduled maintenance that starts soon.
+func (n *Notifier) OnMaintenanceReminder(ctx context.Context, event *domain.Event) error {
+	if !event.NotifySubscribers {
+		return nil
+	}
+
+	eventData := n.buildEventData(ctx, event, event.ServiceIDs)
+	payload := NewReminderPayload(eventData, n.buildEventURL(event.ID))
+
+	return n.sendToEventSubscribers(ctx, event.ID, payload)
+}
+
+// OnSLABreach notifies subscribers of a service that its uptime this month fell
+// below the SLA target. There is no event to queue against, so the messages are
+// sent directly and not retried.
+func (n *Notifier) OnSLABreach(ctx context.Context, service *domain.Service, actualUptime float64) error {
+	var target float64
+	if service.SLAUptimeTarget != nil {
+		target = *service.SLAUptimeTarget
+	}
+
+	return n.dispatcher.Dispatch(ctx, DispatchInput{
+		ServiceIDs: []string{service.ID},
+		Subject:    "[SLA breach] " + service.Name,
+		Body: fmt.Sprintf("%s uptime this month is %s%%, below the SLA target of %s%%.",
+			service.Name,
+			strconv.FormatFloat(actualUptime, 'f', -1, 64),
+			strconv.FormatFloat(target, 'f', -1, 64)),
+		NotificationType: WebhookNotificationSLABreach,
+	})
+}
+
+// OnServiceRecovered notifies subscribers of a service that it is operational again
+// after fromStatus. event is the resolved incident that affected the service last;
+// the notification is queued against it and skips channels whose min_severity is
+// above its severity, like the incident notifications themselves.
+func (n *Notifier) OnServiceRecovered(ctx context.Context, service *domain.Service, fromStatus domain.ServiceStatus, event *domain.Event) error {
+	channels, err := n.repo.FindSubscribersForServices(ctx, []string{service.ID})
+	if err != nil {
+		return fmt.Errorf("find subscribers: %w", err)
+	}
+	channels = filterBySeverity(channels, event.Severity)
+	if len(channels) == 0 {
+		return nil
+	}
+
+	channelIDs := make([]string, len(channels))
+	for i, ch := range channels {
+		channelIDs[i] = ch.ID
+	}
+
+	eventData := n.buildEventData(ctx, event, event.ServiceIDs)
+	payload := NewServiceRecoveredPayload(eventData, ServiceStatusChange{
+		ID:         service.ID,
+		Name:       service.Name,
+		StatusFrom: string(fromStatus),
+		StatusTo:   string(domain.ServiceStatusOperational),
+	}, n.buildEventURL(event.ID))
+
+	return n.enqueueForChannels(ctx, event.ID, channelIDs, payload)
+}
+
 // sendToEventSubscribers sends notifications to all subscribers of an event.
 func (n *Notifier) sendToEventSubscribers(ctx context.Context, eventID string, payload NotificationPayload) error {
 	channelIDs, err := n.repo.GetEventSubscribers(ctx, eventID)
@@ -320,6 +448,7 @@ func (n *Notifier) enqueueForChannels(ctx context.Context, eventID string, chann
 			MessageType: payload.MessageType,
 			Payload:     payload,
 			MaxAttempts: n.config.MaxAttempts,
+			RequestID:   httputil.RequestIDFromContext(ctx),
 		})
 	}
 
@@ -327,7 +456,7 @@ func (n *Notifier) enqueueForChannels(ctx context.Context, eventID string, chann
 		return fmt.Errorf("enqueue notifications: %w", err)
 	}
 
-	slog.Info("notifications queued", "event_id", eventID, "count", len(items))
+	slog.InfoContext(ctx, "notifications queued", "event_id", eventID, "count", len(items))
 	return nil
 }
 
@@ -349,14 +478,16 @@ func (n *Notifier) buildEventData(ctx context.Context, event *domain.Event, serv
 	}
 
 	data := EventData{
-		ID:        event.ID,
-		Title:     event.Title,
-		Type:      string(event.Type),
-		Status:    string(event.Status),
-		Message:   event.Description,
-		Services:  services,
-		CreatedAt: event.CreatedAt,
-		StartedAt: event.StartedAt,
+		ID:         event.ID,
+		Title:      event.Title,
+		Type:       string(event.Type),
+		Status:     string(event.Status),
+		Message:    event.Description,
+		Impact:     event.Impact,
+		OnCallTeam: event.OnCallTeam,
+		Services:   services,
+		CreatedAt:  event.CreatedAt,
+		StartedAt:  event.StartedAt,
 	}
 
 	if event.Severity != nil {
@@ -436,6 +567,14 @@ func (n *Notifier) buildEventURL(eventID string) string {
 	return fmt.Sprintf("%s/events/%s", n.baseURL, eventID)
 }
 
+// buildPostmortemURL constructs the URL for an event post-mortem.
+func (n *Notifier) buildPostmortemURL(eventID string) string {
+	if n.baseURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/events/%s/postmortem", n.baseURL, eventID)
+}
+
 // extractChanges extracts EventUpdateChanges from an interface{}.
 // This allows accepting structs from the events package without circular dependency.
 func (n *Notifier) extractChanges(v interface{}) *EventUpdateChanges {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -15,6 +16,8 @@ type mockRepository struct {
 	channels          []ChannelInfo
 	eventSubscribers  map[string][]string
 	findSubscribersErr error
+	reminders         []domain.Event
+	enqueued          []*QueueItem
 }
 
 func newMockRepository() *mockRepository {
@@ -35,18 +38,24 @@ func (m *mockRepository) GetChannelByUserAndTarget(_ context.Context, _ string,
 func (m *mockRepository) ListUserChannels(_ context.Context, _ string) ([]domain.NotificationChannel, error) {
 	return nil, nil
 }
+func (m *mockRepository) CountChannelsByUserID(_ context.Context, _ string) (int, error) {
+	return 0, nil
+}
 func (m *mockRepository) UpdateChannel(_ context.Context, _ *domain.NotificationChannel) error {
 	return nil
 }
 func (m *mockRepository) DeleteChannel(_ context.Context, _ string) error {
 	return nil
 }
-func (m *mockRepository) SetChannelSubscriptions(_ context.Context, _ string, _ bool, _ []string) error {
+func (m *mockRepository) SetChannelSubscriptions(_ context.Context, _ string, _ bool, _ []string, _ *domain.Severity) error {
 	return nil
 }
 func (m *mockRepository) GetChannelSubscriptions(_ context.Context, _ string) (bool, []string, error) {
 	return false, nil, nil
 }
+func (m *mockRepository) UnsubscribeAll(_ context.Context, _ string) error {
+	return nil
+}
 func (m *mockRepository) GetUserChannelsWithSubscriptions(_ context.Context, _ string) ([]ChannelWithSubscriptions, error) {
 	return nil, nil
 }
@@ -66,11 +75,43 @@ func (m *mockRepository) DeleteExpiredCodes(_ context.Context) (int64, error) {
 	return 0, nil
 }
 
+func (m *mockRepository) GetSubscriberUserID(_ context.Context) (string, error) {
+	return "", nil
+}
+
+func (m *mockRepository) CreateSubscriberToken(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockRepository) CreatePendingSubscriberToken(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockRepository) ConfirmSubscriberToken(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockRepository) GetSubscriberToken(_ context.Context, _ string) (*SubscriberToken, error) {
+	return nil, ErrSubscriberTokenNotFound
+}
+
 func (m *mockRepository) EnqueueNotification(_ context.Context, _ *QueueItem) error {
 	return nil
 }
 
-func (m *mockRepository) EnqueueBatch(_ context.Context, _ []*QueueItem) error {
+func (m *mockRepository) EnqueueBatch(_ context.Context, items []*QueueItem) error {
+	m.enqueued = append(m.enqueued, items...)
+	return nil
+}
+
+func (m *mockRepository) ClaimMaintenanceReminders(_ context.Context, _ time.Duration, send func(*domain.Event) error) error {
+	pending := m.reminders[:0:0]
+	for i := range m.reminders {
+		if err := send(&m.reminders[i]); err != nil {
+			pending = append(pending, m.reminders[i])
+		}
+	}
+	m.reminders = pending
 	return nil
 }
 
@@ -94,6 +135,18 @@ func (m *mockRepository) MarkForRetry(_ context.Context, _ string, _ error, _ ti
 	return nil
 }
 
+func (m *mockRepository) MoveToDeadLetter(_ context.Context, _ string, _ error) error {
+	return nil
+}
+
+func (m *mockRepository) ListDeadLetters(_ context.Context, _, _ int) ([]DeadLetter, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockRepository) RequeueDeadLetter(_ context.Context, _ string) error {
+	return nil
+}
+
 func (m *mockRepository) GetFailedItems(_ context.Context, _ int) ([]*QueueItem, error) {
 	return nil, nil
 }
@@ -106,6 +159,22 @@ func (m *mockRepository) RecoverStuckProcessing(_ context.Context, _ time.Durati
 	return 0, nil
 }
 
+func (m *mockRepository) ReleaseProcessing(_ context.Context, _ []string) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockRepository) GetTelegramUserID(_ context.Context, _ int64) (string, error) {
+	return "", nil
+}
+
+func (m *mockRepository) LinkTelegramUser(_ context.Context, _ int64, _ string) error {
+	return nil
+}
+
+func (m *mockRepository) GetVerifiedChannelByTarget(_ context.Context, _ domain.ChannelType, _ string) (*domain.NotificationChannel, error) {
+	return nil, nil
+}
+
 func (m *mockRepository) DeleteOldSentItems(_ context.Context, _ time.Duration) (int64, error) {
 	return 0, nil
 }
@@ -114,6 +183,18 @@ func (m *mockRepository) GetQueueStats(_ context.Context) (*QueueStats, error) {
 	return &QueueStats{}, nil
 }
 
+func (m *mockRepository) CreateDelivery(_ context.Context, _ *Delivery) error {
+	return nil
+}
+
+func (m *mockRepository) UpdateDelivery(_ context.Context, _ *Delivery) error {
+	return nil
+}
+
+func (m *mockRepository) ListDeliveries(_ context.Context, _ string) ([]Delivery, error) {
+	return nil, nil
+}
+
 func (m *mockRepository) CreateEventSubscribers(_ context.Context, eventID string, channelIDs []string) error {
 	m.eventSubscribers[eventID] = channelIDs
 	return nil
@@ -129,6 +210,10 @@ func (m *mockRepository) AddEventSubscribers(_ context.Context, eventID string,
 	return nil
 }
 
+func (m *mockRepository) ListEventSubscribers(_ context.Context, _ string) ([]domain.NotificationChannel, error) {
+	return nil, nil
+}
+
 func (m *mockRepository) FindSubscribersForServices(_ context.Context, _ []string) ([]ChannelInfo, error) {
 	if m.findSubscribersErr != nil {
 		return nil, m.findSubscribersErr
@@ -221,6 +306,80 @@ func TestNotifier_OnEventCreated_SavesSubscribers(t *testing.T) {
 	assert.Equal(t, []string{"ch-1", "ch-2"}, repo.eventSubscribers["event-1"])
 }
 
+func TestNotifier_OnEventCreated_SkipsChannelsBelowMinSeverity(t *testing.T) {
+	minor := domain.SeverityMinor
+	major := domain.SeverityMajor
+	critical := domain.SeverityCritical
+
+	tests := []struct {
+		name     string
+		severity *domain.Severity
+		want     []string
+	}{
+		{name: "minor incident", severity: &minor, want: []string{"ch-any"}},
+		{name: "major incident", severity: &major, want: []string{"ch-any", "ch-major"}},
+		{name: "critical incident", severity: &critical, want: []string{"ch-any", "ch-major", "ch-critical"}},
+		{name: "maintenance without severity", severity: nil, want: []string{"ch-any", "ch-major", "ch-critical"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			repo.channels = []ChannelInfo{
+				{ID: "ch-any", Type: domain.ChannelTypeEmail, Target: "any@example.com"},
+				{ID: "ch-major", Type: domain.ChannelTypeEmail, Target: "major@example.com", MinSeverity: &major},
+				{ID: "ch-critical", Type: domain.ChannelTypeEmail, Target: "critical@example.com", MinSeverity: &critical},
+			}
+			notifier := NewNotifier(repo, nil, nil, nil, "https://status.example.com")
+
+			event := &domain.Event{
+				ID:                "event-1",
+				Title:             "Test Incident",
+				Severity:          tt.severity,
+				NotifySubscribers: true,
+			}
+
+			err := notifier.OnEventCreated(context.Background(), event, []string{"svc-1"})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, repo.eventSubscribers["event-1"])
+		})
+	}
+}
+
+func TestNotifier_PreviewEventCreated(t *testing.T) {
+	repo := newMockRepository()
+	critical := domain.SeverityCritical
+	repo.channels = []ChannelInfo{
+		{ID: "ch-1", Type: domain.ChannelTypeEmail, Target: "user1@example.com"},
+		{ID: "ch-2", Type: domain.ChannelTypeTelegram, Target: "123456"},
+		{ID: "ch-3", Type: domain.ChannelTypeEmail, Target: "critical@example.com", MinSeverity: &critical},
+	}
+	renderer, err := NewRenderer()
+	require.NoError(t, err)
+	notifier := NewNotifier(repo, renderer, nil, nil, "https://status.example.com")
+
+	minor := domain.SeverityMinor
+	event := &domain.Event{
+		ID:                "event-1",
+		Title:             "Test Incident",
+		Type:              domain.EventTypeIncident,
+		Severity:          &minor,
+		NotifySubscribers: true,
+	}
+
+	n, err := notifier.PreviewEventCreated(context.Background(), event, []string{"svc-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Empty(t, repo.eventSubscribers, "a preview saves no subscribers")
+	assert.Empty(t, repo.enqueued, "a preview enqueues nothing")
+
+	event.NotifySubscribers = false
+	n, err = notifier.PreviewEventCreated(context.Background(), event, []string{"svc-1"})
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
 func TestNotifier_OnEventCancelled_NotifyDisabled(t *testing.T) {
 	repo := newMockRepository()
 	notifier := NewNotifier(repo, nil, nil, nil, "https://status.example.com")
@@ -235,6 +394,121 @@ func TestNotifier_OnEventCancelled_NotifyDisabled(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNotifier_OnMaintenanceReminder(t *testing.T) {
+	repo := newMockRepository()
+	repo.eventSubscribers["event-1"] = []string{"ch-1", "ch-2"}
+	notifier := NewNotifier(repo, nil, nil, nil, "https://status.example.com")
+
+	event := &domain.Event{
+		ID:                "event-1",
+		Title:             "Database upgrade",
+		Type:              domain.EventTypeMaintenance,
+		Status:            domain.EventStatusScheduled,
+		NotifySubscribers: true,
+	}
+
+	err := notifier.OnMaintenanceReminder(context.Background(), event)
+	require.NoError(t, err)
+
+	require.Len(t, repo.enqueued, 2)
+	for _, item := range repo.enqueued {
+		assert.Equal(t, MessageTypeReminder, item.MessageType)
+		assert.Equal(t, "https://status.example.com/events/event-1", item.Payload.EventURL)
+	}
+}
+
+func TestNotifier_EnqueueKeepsRequestID(t *testing.T) {
+	repo := newMockRepository()
+	repo.eventSubscribers["event-1"] = []string{"ch-1"}
+	notifier := NewNotifier(repo, nil, nil, nil, "https://status.example.com")
+	event := &domain.Event{ID: "event-1", Type: domain.EventTypeMaintenance, NotifySubscribers: true}
+
+	ctx := httputil.WithRequestID(context.Background(), "req-1")
+	require.NoError(t, notifier.OnMaintenanceReminder(ctx, event))
+	require.NoError(t, notifier.OnMaintenanceReminder(context.Background(), event))
+
+	require.Len(t, repo.enqueued, 2)
+	assert.Equal(t, "req-1", repo.enqueued[0].RequestID)
+	assert.Empty(t, repo.enqueued[1].RequestID, "no request ID outside requests")
+}
+
+func TestNotifier_OnPostmortemPublished(t *testing.T) {
+	repo := newMockRepository()
+	repo.eventSubscribers["event-1"] = []string{"ch-1"}
+	notifier := NewNotifier(repo, nil, nil, nil, "https://status.example.com")
+
+	event := &domain.Event{
+		ID:                "event-1",
+		Title:             "Database outage",
+		Type:              domain.EventTypeIncident,
+		Status:            domain.EventStatusResolved,
+		NotifySubscribers: true,
+	}
+	postmortem := &domain.EventPostmortem{EventID: "event-1", Title: "Why the database went down"}
+
+	err := notifier.OnPostmortemPublished(context.Background(), event, postmortem)
+	require.NoError(t, err)
+
+	require.Len(t, repo.enqueued, 1)
+	item := repo.enqueued[0]
+	assert.Equal(t, MessageTypeUpdate, item.MessageType)
+	assert.Equal(t, "Post-mortem published: Why the database went down", item.Payload.Event.Message)
+	assert.Equal(t, "https://status.example.com/events/event-1/postmortem", item.Payload.PostmortemURL)
+}
+
+func TestNotifier_OnServiceRecovered(t *testing.T) {
+	major := domain.SeverityMajor
+	critical := domain.SeverityCritical
+
+	repo := newMockRepository()
+	repo.channels = []ChannelInfo{
+		{ID: "ch-any", Type: domain.ChannelTypeEmail, Target: "any@example.com"},
+		{ID: "ch-critical", Type: domain.ChannelTypeEmail, Target: "critical@example.com", MinSeverity: &critical},
+	}
+	notifier := NewNotifier(repo, nil, nil, nil, "https://status.example.com")
+
+	event := &domain.Event{
+		ID:       "event-1",
+		Title:    "Payments outage",
+		Type:     domain.EventTypeIncident,
+		Status:   domain.EventStatusResolved,
+		Severity: &major,
+	}
+	service := &domain.Service{ID: "svc-1", Name: "Payments API", Status: domain.ServiceStatusOperational}
+
+	err := notifier.OnServiceRecovered(context.Background(), service, domain.ServiceStatusMajorOutage, event)
+	require.NoError(t, err)
+
+	require.Len(t, repo.enqueued, 1, "channel above the incident severity is skipped")
+	item := repo.enqueued[0]
+	assert.Equal(t, "ch-any", item.ChannelID)
+	assert.Equal(t, "event-1", item.EventID)
+	assert.Equal(t, MessageTypeServiceRecovered, item.MessageType)
+	assert.Equal(t, &ServiceStatusChange{
+		ID:         "svc-1",
+		Name:       "Payments API",
+		StatusFrom: "major_outage",
+		StatusTo:   "operational",
+	}, item.Payload.Recovery)
+	assert.Equal(t, "https://status.example.com/events/event-1", item.Payload.EventURL)
+}
+
+func TestNotifier_OnMaintenanceReminder_NotifyDisabled(t *testing.T) {
+	repo := newMockRepository()
+	repo.eventSubscribers["event-1"] = []string{"ch-1"}
+	notifier := NewNotifier(repo, nil, nil, nil, "https://status.example.com")
+
+	event := &domain.Event{
+		ID:                "event-1",
+		Title:             "Database upgrade",
+		NotifySubscribers: false,
+	}
+
+	err := notifier.OnMaintenanceReminder(context.Background(), event)
+	require.NoError(t, err)
+	assert.Empty(t, repo.enqueued)
+}
+
 func TestNotifier_BuildEventURL(t *testing.T) {
 	tests := []struct {
 		name     string
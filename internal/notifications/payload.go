@@ -12,16 +12,21 @@ const (
 	MessageTypeResolved  MessageType = "resolved"  // Incident resolved
 	MessageTypeCompleted MessageType = "completed" // Maintenance completed
 	MessageTypeCancelled MessageType = "cancelled" // Scheduled maintenance cancelled
+	MessageTypeReminder  MessageType = "reminder"  // Scheduled maintenance starts soon
+
+	MessageTypeServiceRecovered MessageType = "service_recovered" // Service operational after its last active event resolved
 )
 
 // NotificationPayload contains data for rendering a notification.
 type NotificationPayload struct {
-	MessageType MessageType      `json:"message_type"`
-	Event       EventData        `json:"event"`
-	Changes     *EventChanges    `json:"changes,omitempty"`
-	Resolution  *EventResolution `json:"resolution,omitempty"`
-	EventURL    string           `json:"event_url,omitempty"`
-	GeneratedAt time.Time        `json:"generated_at"`
+	MessageType   MessageType          `json:"message_type"`
+	Event         EventData            `json:"event"`
+	Changes       *EventChanges        `json:"changes,omitempty"`
+	Resolution    *EventResolution     `json:"resolution,omitempty"`
+	EventURL      string               `json:"event_url,omitempty"`
+	PostmortemURL string               `json:"postmortem_url,omitempty"` // published post-mortem (update notifications only)
+	Recovery      *ServiceStatusChange `json:"recovery,omitempty"`       // recovered service (service_recovered only)
+	GeneratedAt   time.Time            `json:"generated_at"`
 }
 
 // EventData contains event information for notification.
@@ -32,6 +37,8 @@ type EventData struct {
 	Status         string             `json:"status"`             // investigating, identified, etc.
 	Severity       string             `json:"severity,omitempty"` // minor, major, critical (empty for maintenance)
 	Message        string             `json:"message"`
+	Impact         string             `json:"impact,omitempty"`      // customer-facing impact summary
+	OnCallTeam     string             `json:"oncall_team,omitempty"` // on-call team the event is escalated to
 	Services       []ServiceInfo      `json:"services"`
 	Groups         []GroupInfo        `json:"groups,omitempty"`
 	CreatedAt      time.Time          `json:"created_at"`
@@ -133,3 +140,146 @@ func NewCancelledPayload(event EventData) NotificationPayload {
 		GeneratedAt: time.Now(),
 	}
 }
+
+// NewReminderPayload creates a payload for an upcoming maintenance reminder.
+func NewReminderPayload(event EventData, eventURL string) NotificationPayload {
+	return NotificationPayload{
+		MessageType: MessageTypeReminder,
+		Event:       event,
+		EventURL:    eventURL,
+		GeneratedAt: time.Now(),
+	}
+}
+
+// NewServiceRecoveredPayload creates a payload for a service that is operational again
+// after event, the last active event affecting it, was resolved.
+func NewServiceRecoveredPayload(event EventData, recovery ServiceStatusChange, eventURL string) NotificationPayload {
+	return NotificationPayload{
+		MessageType: MessageTypeServiceRecovered,
+		Event:       event,
+		Recovery:    &recovery,
+		EventURL:    eventURL,
+		GeneratedAt: time.Now(),
+	}
+}
+
+// Webhook notification types (notification_type field of WebhookPayload).
+const (
+	WebhookNotificationEventCreated  = "event_created"
+	WebhookNotificationEventUpdated  = "event_updated"
+	WebhookNotificationEventResolved = "event_resolved"
+	WebhookNotificationVerification  = "verification"
+	// WebhookNotificationMaintenanceReminder is sent before scheduled maintenance starts.
+	WebhookNotificationMaintenanceReminder = "maintenance_reminder"
+	// WebhookNotificationSLABreach is sent when a service falls below its monthly SLA target.
+	WebhookNotificationSLABreach = "sla_breach"
+	// WebhookNotificationServiceRecovered is sent when a service is operational again after an incident.
+	WebhookNotificationServiceRecovered = "service_recovered"
+)
+
+// WebhookMessage is the JSON document posted to webhook channels for
+// plain-text messages that are not tied to an event payload (e.g. verification).
+type WebhookMessage struct {
+	NotificationType string `json:"notification_type"`
+	Subject          string `json:"subject"`
+	Message          string `json:"message"`
+}
+
+// WebhookPayload is the JSON document posted to webhook channels.
+// Fields mirror domain.Event, plus notification_type and notification context.
+type WebhookPayload struct {
+	NotificationType string               `json:"notification_type"`
+	ID               string               `json:"id"`
+	Title            string               `json:"title"`
+	Type             string               `json:"type"`
+	Status           string               `json:"status"`
+	Severity         *string              `json:"severity"`
+	Description      string               `json:"description"` // event description or update message
+	Impact           string               `json:"impact,omitempty"`
+	OnCallTeam       string               `json:"oncall_team,omitempty"`
+	StartedAt        *time.Time           `json:"started_at"`
+	ResolvedAt       *time.Time           `json:"resolved_at"`
+	ScheduledStartAt *time.Time           `json:"scheduled_start_at"`
+	ScheduledEndAt   *time.Time           `json:"scheduled_end_at"`
+	CreatedAt        time.Time            `json:"created_at"`
+	ServiceIDs       []string             `json:"service_ids"`
+	GroupIDs         []string             `json:"group_ids"`
+	Services         []ServiceInfo        `json:"services"`
+	Changes          *EventChanges        `json:"changes,omitempty"`
+	Resolution       *EventResolution     `json:"resolution,omitempty"`
+	EventURL         string               `json:"event_url,omitempty"`
+	PostmortemURL    string               `json:"postmortem_url,omitempty"`
+	Recovery         *ServiceStatusChange `json:"recovery,omitempty"`
+	GeneratedAt      time.Time            `json:"generated_at"`
+}
+
+// NewWebhookPayload converts a notification payload to the webhook JSON format.
+func NewWebhookPayload(payload NotificationPayload) WebhookPayload {
+	event := payload.Event
+
+	serviceIDs := make([]string, 0, len(event.Services))
+	services := make([]ServiceInfo, 0, len(event.Services))
+	for _, svc := range event.Services {
+		serviceIDs = append(serviceIDs, svc.ID)
+		services = append(services, svc)
+	}
+
+	groupIDs := make([]string, 0, len(event.Groups))
+	for _, g := range event.Groups {
+		groupIDs = append(groupIDs, g.ID)
+	}
+
+	result := WebhookPayload{
+		NotificationType: webhookNotificationType(payload.MessageType),
+		ID:               event.ID,
+		Title:            event.Title,
+		Type:             event.Type,
+		Status:           event.Status,
+		Description:      event.Message,
+		Impact:           event.Impact,
+		OnCallTeam:       event.OnCallTeam,
+		StartedAt:        event.StartedAt,
+		ScheduledStartAt: event.ScheduledStart,
+		ScheduledEndAt:   event.ScheduledEnd,
+		CreatedAt:        event.CreatedAt,
+		ServiceIDs:       serviceIDs,
+		GroupIDs:         groupIDs,
+		Services:         services,
+		Changes:          payload.Changes,
+		Resolution:       payload.Resolution,
+		EventURL:         payload.EventURL,
+		PostmortemURL:    payload.PostmortemURL,
+		Recovery:         payload.Recovery,
+		GeneratedAt:      payload.GeneratedAt,
+	}
+
+	if event.Severity != "" {
+		severity := event.Severity
+		result.Severity = &severity
+	}
+
+	if payload.Resolution != nil {
+		resolvedAt := payload.Resolution.ResolvedAt
+		result.ResolvedAt = &resolvedAt
+	}
+
+	return result
+}
+
+// webhookNotificationType maps message type to webhook notification_type.
+// Completed and cancelled maintenance are reported as event_resolved:
+// in both cases the event no longer affects services.
+func webhookNotificationType(messageType MessageType) string {
+	switch messageType {
+	case MessageTypeInitial:
+		return WebhookNotificationEventCreated
+	case MessageTypeResolved, MessageTypeCompleted, MessageTypeCancelled:
+		return WebhookNotificationEventResolved
+	case MessageTypeReminder:
+		return WebhookNotificationMaintenanceReminder
+	case MessageTypeServiceRecovered:
+		return WebhookNotificationServiceRecovered
+	default:
+		return WebhookNotificationEventUpdated
+	}
+}
@@ -11,9 +11,13 @@ import (
 	"github.com/bissquit/incident-garden/internal/domain"
 	"github.com/bissquit/incident-garden/internal/notifications"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// subscriberUserEmail identifies the user created by migration 000027.
+const subscriberUserEmail = "subscribers@incident-garden.local"
+
 // Repository implements notifications.Repository using PostgreSQL.
 type Repository struct {
 	db *pgxpool.Pool
@@ -24,14 +28,18 @@ func NewRepository(db *pgxpool.Pool) *Repository {
 	return &Repository{db: db}
 }
 
+// channelTargetConstraint is the unique constraint on (user_id, type, target).
+const channelTargetConstraint = "uq_notification_channels_user_type_target"
+
 // CreateChannel creates a new notification channel.
+// Returns ErrChannelAlreadyExists if the user has a channel of the same type and target.
 func (r *Repository) CreateChannel(ctx context.Context, channel *domain.NotificationChannel) error {
 	query := `
-		INSERT INTO notification_channels (user_id, type, target, is_enabled, is_verified, is_default, subscribe_to_all_services)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO notification_channels (user_id, type, target, is_enabled, is_verified, is_default, subscribe_to_all_services, secret)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, created_at, updated_at
 	`
-	return r.db.QueryRow(ctx, query,
+	err := r.db.QueryRow(ctx, query,
 		channel.UserID,
 		channel.Type,
 		channel.Target,
@@ -39,13 +47,22 @@ func (r *Repository) CreateChannel(ctx context.Context, channel *domain.Notifica
 		channel.IsVerified,
 		channel.IsDefault,
 		channel.SubscribeToAllServices,
+		channel.Secret,
 	).Scan(&channel.ID, &channel.CreatedAt, &channel.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == channelTargetConstraint {
+			return notifications.ErrChannelAlreadyExists
+		}
+		return err
+	}
+	return nil
 }
 
 // GetChannelByID retrieves a notification channel by ID.
 func (r *Repository) GetChannelByID(ctx context.Context, id string) (*domain.NotificationChannel, error) {
 	query := `
-		SELECT id, user_id, type, target, is_enabled, is_verified, is_default, subscribe_to_all_services, created_at, updated_at
+		SELECT id, user_id, type, target, is_enabled, is_verified, is_default, subscribe_to_all_services, min_severity, secret, created_at, updated_at
 		FROM notification_channels
 		WHERE id = $1
 	`
@@ -59,6 +76,8 @@ func (r *Repository) GetChannelByID(ctx context.Context, id string) (*domain.Not
 		&channel.IsVerified,
 		&channel.IsDefault,
 		&channel.SubscribeToAllServices,
+		&channel.MinSeverity,
+		&channel.Secret,
 		&channel.CreatedAt,
 		&channel.UpdatedAt,
 	)
@@ -76,7 +95,7 @@ func (r *Repository) GetChannelByID(ctx context.Context, id string) (*domain.Not
 func (r *Repository) GetChannelByUserAndTarget(ctx context.Context, userID string, channelType domain.ChannelType, target string) (*domain.NotificationChannel, error) {
 	query := `
 		SELECT id, user_id, type, target, is_enabled, is_verified, is_default,
-		       subscribe_to_all_services, created_at, updated_at
+		       subscribe_to_all_services, min_severity, created_at, updated_at
 		FROM notification_channels
 		WHERE user_id = $1 AND type = $2 AND target = $3
 	`
@@ -84,7 +103,7 @@ func (r *Repository) GetChannelByUserAndTarget(ctx context.Context, userID strin
 	var ch domain.NotificationChannel
 	err := r.db.QueryRow(ctx, query, userID, channelType, target).Scan(
 		&ch.ID, &ch.UserID, &ch.Type, &ch.Target,
-		&ch.IsEnabled, &ch.IsVerified, &ch.IsDefault, &ch.SubscribeToAllServices,
+		&ch.IsEnabled, &ch.IsVerified, &ch.IsDefault, &ch.SubscribeToAllServices, &ch.MinSeverity,
 		&ch.CreatedAt, &ch.UpdatedAt,
 	)
 	if err != nil {
@@ -97,10 +116,20 @@ func (r *Repository) GetChannelByUserAndTarget(ctx context.Context, userID strin
 	return &ch, nil
 }
 
+// CountChannelsByUserID returns the number of notification channels of a user.
+func (r *Repository) CountChannelsByUserID(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM notification_channels WHERE user_id = $1`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count user channels: %w", err)
+	}
+	return count, nil
+}
+
 // ListUserChannels retrieves all notification channels for a user.
 func (r *Repository) ListUserChannels(ctx context.Context, userID string) ([]domain.NotificationChannel, error) {
 	query := `
-		SELECT id, user_id, type, target, is_enabled, is_verified, is_default, subscribe_to_all_services, created_at, updated_at
+		SELECT id, user_id, type, target, is_enabled, is_verified, is_default, subscribe_to_all_services, min_severity, created_at, updated_at
 		FROM notification_channels
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -123,6 +152,7 @@ func (r *Repository) ListUserChannels(ctx context.Context, userID string) ([]dom
 			&channel.IsVerified,
 			&channel.IsDefault,
 			&channel.SubscribeToAllServices,
+			&channel.MinSeverity,
 			&channel.CreatedAt,
 			&channel.UpdatedAt,
 		)
@@ -176,7 +206,8 @@ func (r *Repository) DeleteChannel(ctx context.Context, id string) error {
 // SetChannelSubscriptions sets subscriptions for a channel.
 // If subscribeAll is true, serviceIDs are ignored and channel subscribes to all services.
 // If subscribeAll is false, channel subscribes only to specified services.
-func (r *Repository) SetChannelSubscriptions(ctx context.Context, channelID string, subscribeAll bool, serviceIDs []string) error {
+// minSeverity replaces the channel's severity threshold, nil clears it.
+func (r *Repository) SetChannelSubscriptions(ctx context.Context, channelID string, subscribeAll bool, serviceIDs []string, minSeverity *domain.Severity) error {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
@@ -185,9 +216,9 @@ func (r *Repository) SetChannelSubscriptions(ctx context.Context, channelID stri
 		_ = tx.Rollback(ctx)
 	}()
 
-	// Update subscribe_to_all_services flag
-	updateQuery := `UPDATE notification_channels SET subscribe_to_all_services = $2, updated_at = NOW() WHERE id = $1`
-	result, err := tx.Exec(ctx, updateQuery, channelID, subscribeAll)
+	// Update subscribe_to_all_services flag and severity threshold
+	updateQuery := `UPDATE notification_channels SET subscribe_to_all_services = $2, min_severity = $3, updated_at = NOW() WHERE id = $1`
+	result, err := tx.Exec(ctx, updateQuery, channelID, subscribeAll, minSeverity)
 	if err != nil {
 		return fmt.Errorf("update subscribe_to_all_services: %w", err)
 	}
@@ -218,6 +249,52 @@ func (r *Repository) SetChannelSubscriptions(ctx context.Context, channelID stri
 	return nil
 }
 
+// UnsubscribeAll clears subscriptions of every channel owned by the user and
+// removes those channels from subscriber snapshots of ongoing events.
+func (r *Repository) UnsubscribeAll(ctx context.Context, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	updateQuery := `
+		UPDATE notification_channels
+		SET subscribe_to_all_services = false, updated_at = NOW()
+		WHERE user_id = $1 AND subscribe_to_all_services
+	`
+	if _, err := tx.Exec(ctx, updateQuery, userID); err != nil {
+		return fmt.Errorf("clear subscribe_to_all_services: %w", err)
+	}
+
+	deleteSubscriptionsQuery := `
+		DELETE FROM channel_subscriptions
+		WHERE channel_id IN (SELECT id FROM notification_channels WHERE user_id = $1)
+	`
+	if _, err := tx.Exec(ctx, deleteSubscriptionsQuery, userID); err != nil {
+		return fmt.Errorf("delete subscriptions: %w", err)
+	}
+
+	deleteSubscribersQuery := `
+		DELETE FROM event_subscribers es
+		USING notification_channels nc, events e
+		WHERE es.channel_id = nc.id AND es.event_id = e.id
+		  AND nc.user_id = $1
+		  AND e.status NOT IN ('resolved', 'completed')
+	`
+	if _, err := tx.Exec(ctx, deleteSubscribersQuery, userID); err != nil {
+		return fmt.Errorf("delete event subscribers: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 // GetChannelSubscriptions returns subscription settings for a channel.
 func (r *Repository) GetChannelSubscriptions(ctx context.Context, channelID string) (bool, []string, error) {
 	// Get subscribe_to_all_services flag
@@ -345,6 +422,49 @@ func (r *Repository) AddEventSubscribers(ctx context.Context, eventID string, ch
 	return nil
 }
 
+// ListEventSubscribers returns the channels snapshotted for an event, ordered by type and target.
+func (r *Repository) ListEventSubscribers(ctx context.Context, eventID string) ([]domain.NotificationChannel, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM events WHERE id = $1)`, eventID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("check event: %w", err)
+	}
+	if !exists {
+		return nil, notifications.ErrEventNotFound
+	}
+
+	query := `
+		SELECT nc.id, nc.user_id, nc.type, nc.target, nc.is_enabled, nc.is_verified, nc.is_default,
+		       nc.subscribe_to_all_services, nc.min_severity, nc.created_at, nc.updated_at
+		FROM event_subscribers es
+		JOIN notification_channels nc ON nc.id = es.channel_id
+		WHERE es.event_id = $1
+		ORDER BY nc.type, nc.target
+	`
+	rows, err := r.db.Query(ctx, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("list event subscribers: %w", err)
+	}
+	defer rows.Close()
+
+	channels := make([]domain.NotificationChannel, 0)
+	for rows.Next() {
+		var ch domain.NotificationChannel
+		if err := rows.Scan(
+			&ch.ID, &ch.UserID, &ch.Type, &ch.Target,
+			&ch.IsEnabled, &ch.IsVerified, &ch.IsDefault, &ch.SubscribeToAllServices, &ch.MinSeverity,
+			&ch.CreatedAt, &ch.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan event subscriber: %w", err)
+		}
+		channels = append(channels, ch)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate event subscribers: %w", err)
+	}
+
+	return channels, nil
+}
+
 // FindSubscribersForServices finds all enabled and verified channels subscribed to any of the given services.
 func (r *Repository) FindSubscribersForServices(ctx context.Context, serviceIDs []string) ([]notifications.ChannelInfo, error) {
 	if len(serviceIDs) == 0 {
@@ -352,7 +472,7 @@ func (r *Repository) FindSubscribersForServices(ctx context.Context, serviceIDs
 	}
 
 	query := `
-		SELECT DISTINCT nc.id, nc.user_id, nc.type, nc.target, u.email
+		SELECT DISTINCT nc.id, nc.user_id, nc.type, nc.target, nc.secret, u.email, nc.min_severity
 		FROM notification_channels nc
 		JOIN users u ON u.id = nc.user_id
 		LEFT JOIN channel_subscriptions cs ON cs.channel_id = nc.id
@@ -369,7 +489,7 @@ func (r *Repository) FindSubscribersForServices(ctx context.Context, serviceIDs
 	channels := make([]notifications.ChannelInfo, 0)
 	for rows.Next() {
 		var info notifications.ChannelInfo
-		if err := rows.Scan(&info.ID, &info.UserID, &info.Type, &info.Target, &info.Email); err != nil {
+		if err := rows.Scan(&info.ID, &info.UserID, &info.Type, &info.Target, &info.Secret, &info.Email, &info.MinSeverity); err != nil {
 			return nil, fmt.Errorf("scan channel info: %w", err)
 		}
 		channels = append(channels, info)
@@ -385,7 +505,7 @@ func (r *Repository) GetChannelsByIDs(ctx context.Context, ids []string) ([]noti
 	}
 
 	query := `
-		SELECT nc.id, nc.user_id, nc.type, nc.target, u.email
+		SELECT nc.id, nc.user_id, nc.type, nc.target, nc.secret, u.email
 		FROM notification_channels nc
 		JOIN users u ON u.id = nc.user_id
 		WHERE nc.id = ANY($1::uuid[])
@@ -402,7 +522,7 @@ func (r *Repository) GetChannelsByIDs(ctx context.Context, ids []string) ([]noti
 	channels := make([]notifications.ChannelInfo, 0, len(ids))
 	for rows.Next() {
 		var info notifications.ChannelInfo
-		if err := rows.Scan(&info.ID, &info.UserID, &info.Type, &info.Target, &info.Email); err != nil {
+		if err := rows.Scan(&info.ID, &info.UserID, &info.Type, &info.Target, &info.Secret, &info.Email); err != nil {
 			return nil, fmt.Errorf("scan channel info: %w", err)
 		}
 		channels = append(channels, info)
@@ -411,6 +531,101 @@ func (r *Repository) GetChannelsByIDs(ctx context.Context, ids []string) ([]noti
 	return channels, nil
 }
 
+// ClaimMaintenanceReminders calls send for scheduled maintenance starting within window
+// that has no reminder yet, and sets reminder_sent_at for the events send succeeded for.
+// The events stay locked (FOR UPDATE SKIP LOCKED) until then, so replicas don't remind
+// twice and a failed send is retried on the next call.
+func (r *Repository) ClaimMaintenanceReminders(ctx context.Context, window time.Duration, send func(*domain.Event) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	query := `
+		SELECT
+			id, title, type, status, severity, description,
+			started_at, resolved_at, scheduled_start_at, scheduled_end_at,
+			notify_subscribers, created_by, created_at, updated_at
+		FROM events
+		WHERE type = 'maintenance'
+		  AND status = 'scheduled'
+		  AND reminder_sent_at IS NULL
+		  AND scheduled_start_at BETWEEN NOW() AND NOW() + make_interval(secs => $1)
+		FOR UPDATE SKIP LOCKED
+	`
+	rows, err := tx.Query(ctx, query, window.Seconds())
+	if err != nil {
+		return fmt.Errorf("claim maintenance reminders: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]domain.Event, 0)
+	for rows.Next() {
+		var event domain.Event
+		err := rows.Scan(
+			&event.ID, &event.Title, &event.Type, &event.Status, &event.Severity, &event.Description,
+			&event.StartedAt, &event.ResolvedAt, &event.ScheduledStartAt, &event.ScheduledEndAt,
+			&event.NotifySubscribers, &event.CreatedBy, &event.CreatedAt, &event.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate events: %w", err)
+	}
+
+	sent := make([]string, 0, len(events))
+	for i := range events {
+		serviceIDs, err := r.getEventServiceIDs(ctx, events[i].ID)
+		if err != nil {
+			return err
+		}
+		events[i].ServiceIDs = serviceIDs
+
+		if err := send(&events[i]); err != nil {
+			continue
+		}
+		sent = append(sent, events[i].ID)
+	}
+
+	if len(sent) > 0 {
+		_, err = tx.Exec(ctx, `UPDATE events SET reminder_sent_at = NOW() WHERE id = ANY($1)`, sent)
+		if err != nil {
+			return fmt.Errorf("mark reminders sent: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// getEventServiceIDs returns IDs of services affected by an event.
+func (r *Repository) getEventServiceIDs(ctx context.Context, eventID string) ([]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT service_id FROM event_services WHERE event_id = $1`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("get event services: %w", err)
+	}
+	defer rows.Close()
+
+	serviceIDs := make([]string, 0)
+	for rows.Next() {
+		var serviceID string
+		if err := rows.Scan(&serviceID); err != nil {
+			return nil, fmt.Errorf("scan service id: %w", err)
+		}
+		serviceIDs = append(serviceIDs, serviceID)
+	}
+
+	return serviceIDs, rows.Err()
+}
+
 // CreateVerificationCode creates a new verification code, replacing any existing one.
 func (r *Repository) CreateVerificationCode(ctx context.Context, channelID, code string, expiresAt time.Time) error {
 	// Delete any existing code first
@@ -480,6 +695,129 @@ func (r *Repository) DeleteExpiredCodes(ctx context.Context) (int64, error) {
 	return result.RowsAffected(), nil
 }
 
+// GetSubscriberUserID returns the ID of the system user that owns public subscriber channels.
+func (r *Repository) GetSubscriberUserID(ctx context.Context) (string, error) {
+	var id string
+	if err := r.db.QueryRow(ctx, `SELECT id FROM users WHERE email = $1`, subscriberUserEmail).Scan(&id); err != nil {
+		return "", fmt.Errorf("get subscriber system user: %w", err)
+	}
+	return id, nil
+}
+
+// CreateSubscriberToken stores a token for a public subscriber channel.
+func (r *Repository) CreateSubscriberToken(ctx context.Context, channelID, token string) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO subscriber_tokens (token, channel_id)
+		VALUES ($1, $2)
+	`, token, channelID)
+	if err != nil {
+		return fmt.Errorf("create subscriber token: %w", err)
+	}
+	return nil
+}
+
+// CreatePendingSubscriberToken stores an unconfirmed token for a public subscriber channel.
+func (r *Repository) CreatePendingSubscriberToken(ctx context.Context, channelID, token string) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO subscriber_tokens (token, channel_id, confirmed)
+		VALUES ($1, $2, false)
+	`, token, channelID)
+	if err != nil {
+		return fmt.Errorf("create pending subscriber token: %w", err)
+	}
+	return nil
+}
+
+// ConfirmSubscriberToken confirms a subscriber token and deletes the other tokens of its channel.
+func (r *Repository) ConfirmSubscriberToken(ctx context.Context, token string) error {
+	_, err := r.db.Exec(ctx, `
+		WITH confirmed AS (
+			UPDATE subscriber_tokens SET confirmed = true
+			WHERE token = $1
+			RETURNING channel_id
+		)
+		DELETE FROM subscriber_tokens st
+		USING confirmed c
+		WHERE st.channel_id = c.channel_id AND st.token <> $1
+	`, token)
+	if err != nil {
+		return fmt.Errorf("confirm subscriber token: %w", err)
+	}
+	return nil
+}
+
+// GetSubscriberToken retrieves a public subscriber token.
+func (r *Repository) GetSubscriberToken(ctx context.Context, token string) (*notifications.SubscriberToken, error) {
+	var st notifications.SubscriberToken
+	err := r.db.QueryRow(ctx, `
+		SELECT token, channel_id, confirmed, created_at
+		FROM subscriber_tokens
+		WHERE token = $1
+	`, token).Scan(&st.Token, &st.ChannelID, &st.Confirmed, &st.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, notifications.ErrSubscriberTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get subscriber token: %w", err)
+	}
+	return &st, nil
+}
+
+// GetTelegramUserID returns the user linked to a Telegram chat, "" if the chat is not linked.
+func (r *Repository) GetTelegramUserID(ctx context.Context, chatID int64) (string, error) {
+	var userID string
+	err := r.db.QueryRow(ctx, `SELECT user_id FROM telegram_users WHERE chat_id = $1`, chatID).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get telegram user: %w", err)
+	}
+	return userID, nil
+}
+
+// LinkTelegramUser links a Telegram chat to a user, replacing a previous link.
+func (r *Repository) LinkTelegramUser(ctx context.Context, chatID int64, userID string) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO telegram_users (chat_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (chat_id) DO UPDATE SET user_id = EXCLUDED.user_id, created_at = NOW()
+	`, chatID, userID)
+	if err != nil {
+		return fmt.Errorf("link telegram user: %w", err)
+	}
+	return nil
+}
+
+// GetVerifiedChannelByTarget returns the oldest verified channel with the given type and target.
+// Returns nil, nil if not found.
+func (r *Repository) GetVerifiedChannelByTarget(ctx context.Context, channelType domain.ChannelType, target string) (*domain.NotificationChannel, error) {
+	query := `
+		SELECT id, user_id, type, target, is_enabled, is_verified, is_default,
+		       subscribe_to_all_services, min_severity, created_at, updated_at
+		FROM notification_channels
+		WHERE type = $1 AND target = $2 AND is_verified
+		ORDER BY created_at
+		LIMIT 1
+	`
+
+	var ch domain.NotificationChannel
+	err := r.db.QueryRow(ctx, query, channelType, target).Scan(
+		&ch.ID, &ch.UserID, &ch.Type, &ch.Target,
+		&ch.IsEnabled, &ch.IsVerified, &ch.IsDefault, &ch.SubscribeToAllServices, &ch.MinSeverity,
+		&ch.CreatedAt, &ch.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("query channel: %w", err)
+	}
+
+	return &ch, nil
+}
+
 // EnqueueNotification adds a notification to the queue.
 func (r *Repository) EnqueueNotification(ctx context.Context, item *notifications.QueueItem) error {
 	payloadJSON, err := json.Marshal(item.Payload)
@@ -489,10 +827,10 @@ func (r *Repository) EnqueueNotification(ctx context.Context, item *notification
 
 	_, err = r.db.Exec(ctx, `
 		INSERT INTO notification_queue
-			(id, event_id, channel_id, message_type, payload, status, max_attempts, next_attempt_at)
+			(id, event_id, channel_id, message_type, payload, status, max_attempts, next_attempt_at, request_id)
 		VALUES
-			($1, $2, $3, $4, $5, 'pending', $6, NOW())
-	`, item.ID, item.EventID, item.ChannelID, item.MessageType, payloadJSON, item.MaxAttempts)
+			($1, $2, $3, $4, $5, 'pending', $6, NOW(), $7)
+	`, item.ID, item.EventID, item.ChannelID, item.MessageType, payloadJSON, item.MaxAttempts, item.RequestID)
 
 	if err != nil {
 		return fmt.Errorf("enqueue notification: %w", err)
@@ -522,10 +860,10 @@ func (r *Repository) EnqueueBatch(ctx context.Context, items []*notifications.Qu
 
 		_, err = tx.Exec(ctx, `
 			INSERT INTO notification_queue
-				(id, event_id, channel_id, message_type, payload, status, max_attempts, next_attempt_at)
+				(id, event_id, channel_id, message_type, payload, status, max_attempts, next_attempt_at, request_id)
 			VALUES
-				($1, $2, $3, $4, $5, 'pending', $6, NOW())
-		`, item.ID, item.EventID, item.ChannelID, item.MessageType, payloadJSON, item.MaxAttempts)
+				($1, $2, $3, $4, $5, 'pending', $6, NOW(), $7)
+		`, item.ID, item.EventID, item.ChannelID, item.MessageType, payloadJSON, item.MaxAttempts, item.RequestID)
 
 		if err != nil {
 			return fmt.Errorf("enqueue notification %s: %w", item.ID, err)
@@ -554,7 +892,7 @@ func (r *Repository) FetchPendingNotifications(ctx context.Context, limit int) (
 	rows, err := tx.Query(ctx, `
 		SELECT id, event_id, channel_id, message_type, payload,
 			   status, attempts, max_attempts, next_attempt_at, last_error,
-			   created_at, updated_at, sent_at
+			   created_at, updated_at, sent_at, request_id
 		FROM notification_queue
 		WHERE status = 'pending'
 		  AND next_attempt_at <= NOW()
@@ -613,7 +951,7 @@ func scanQueueItem(rows pgx.Rows) (*notifications.QueueItem, error) {
 	err := rows.Scan(
 		&item.ID, &item.EventID, &item.ChannelID, &item.MessageType, &payloadJSON,
 		&item.Status, &item.Attempts, &item.MaxAttempts, &item.NextAttemptAt, &lastError,
-		&item.CreatedAt, &item.UpdatedAt, &sentAt,
+		&item.CreatedAt, &item.UpdatedAt, &sentAt, &item.RequestID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("scan: %w", err)
@@ -663,6 +1001,74 @@ func (r *Repository) MarkAsFailed(ctx context.Context, id string, failErr error)
 	return nil
 }
 
+// CreateDelivery records the start of a delivery attempt.
+func (r *Repository) CreateDelivery(ctx context.Context, delivery *notifications.Delivery) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO notification_deliveries (notification_id, channel_id, status)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`, delivery.NotificationID, delivery.ChannelID, delivery.Status).Scan(&delivery.ID, &delivery.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create delivery: %w", err)
+	}
+	return nil
+}
+
+// UpdateDelivery stores the outcome of a delivery attempt.
+func (r *Repository) UpdateDelivery(ctx context.Context, delivery *notifications.Delivery) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE notification_deliveries
+		SET status = $2,
+			sent_at = $3,
+			failed_at = $4,
+			failure_reason = $5
+		WHERE id = $1
+	`, delivery.ID, delivery.Status, delivery.SentAt, delivery.FailedAt, delivery.FailureReason)
+	if err != nil {
+		return fmt.Errorf("update delivery: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries returns the deliveries of a queued notification, oldest first.
+func (r *Repository) ListDeliveries(ctx context.Context, notificationID string) ([]notifications.Delivery, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM notification_queue WHERE id = $1)`, notificationID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("check notification: %w", err)
+	}
+	if !exists {
+		return nil, notifications.ErrNotificationNotFound
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, notification_id, channel_id, status, sent_at, failed_at, failure_reason, created_at
+		FROM notification_deliveries
+		WHERE notification_id = $1
+		ORDER BY created_at, id
+	`, notificationID)
+	if err != nil {
+		return nil, fmt.Errorf("list deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]notifications.Delivery, 0)
+	for rows.Next() {
+		var d notifications.Delivery
+		if err := rows.Scan(
+			&d.ID, &d.NotificationID, &d.ChannelID, &d.Status,
+			&d.SentAt, &d.FailedAt, &d.FailureReason, &d.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
 // MarkForRetry schedules a notification for retry.
 func (r *Repository) MarkForRetry(ctx context.Context, id string, retryErr error, nextAttempt time.Time) error {
 	_, err := r.db.Exec(ctx, `
@@ -694,12 +1100,135 @@ func (r *Repository) MarkAsProcessing(ctx context.Context, id string) error {
 	return nil
 }
 
+// MoveToDeadLetter marks a notification as failed and copies it to notification_dead_letters.
+// Attempt timestamps are taken from its delivery receipts.
+func (r *Repository) MoveToDeadLetter(ctx context.Context, id string, failErr error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	_, err = tx.Exec(ctx, `
+		UPDATE notification_queue
+		SET status = 'failed',
+			attempts = attempts + 1,
+			last_error = $2,
+			updated_at = NOW()
+		WHERE id = $1
+	`, id, failErr.Error())
+	if err != nil {
+		return fmt.Errorf("mark as failed: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO notification_dead_letters
+			(notification_id, event_id, channel_id, message_type, payload, last_error, attempts, attempted_at)
+		SELECT q.id, q.event_id, q.channel_id, q.message_type, q.payload, q.last_error, q.attempts,
+			   ARRAY(SELECT d.created_at FROM notification_deliveries d WHERE d.notification_id = q.id ORDER BY d.created_at)
+		FROM notification_queue q
+		WHERE q.id = $1
+		ON CONFLICT (notification_id) DO UPDATE
+		SET last_error = EXCLUDED.last_error,
+			attempts = EXCLUDED.attempts,
+			attempted_at = EXCLUDED.attempted_at,
+			created_at = NOW()
+	`, id)
+	if err != nil {
+		return fmt.Errorf("insert dead letter: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListDeadLetters returns dead letters, newest first, and their total count.
+func (r *Repository) ListDeadLetters(ctx context.Context, limit, offset int) ([]notifications.DeadLetter, int, error) {
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM notification_dead_letters`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count dead letters: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, notification_id, event_id, channel_id, message_type, payload,
+			   last_error, attempts, attempted_at, created_at
+		FROM notification_dead_letters
+		ORDER BY created_at DESC, id
+		LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	letters := make([]notifications.DeadLetter, 0)
+	for rows.Next() {
+		var dl notifications.DeadLetter
+		if err := rows.Scan(
+			&dl.ID, &dl.NotificationID, &dl.EventID, &dl.ChannelID, &dl.MessageType, &dl.Payload,
+			&dl.LastError, &dl.Attempts, &dl.AttemptedAt, &dl.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("scan dead letter: %w", err)
+		}
+		letters = append(letters, dl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate dead letters: %w", err)
+	}
+
+	return letters, total, nil
+}
+
+// RequeueDeadLetter resets the notification of a dead letter to pending and deletes the dead letter.
+func (r *Repository) RequeueDeadLetter(ctx context.Context, id string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var notificationID string
+	err = tx.QueryRow(ctx, `
+		DELETE FROM notification_dead_letters WHERE id = $1 RETURNING notification_id
+	`, id).Scan(&notificationID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return notifications.ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("delete dead letter: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE notification_queue
+		SET status = 'pending',
+			attempts = 0,
+			next_attempt_at = NOW(),
+			last_error = NULL,
+			updated_at = NOW()
+		WHERE id = $1
+	`, notificationID)
+	if err != nil {
+		return fmt.Errorf("requeue notification: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
 // GetFailedItems returns failed notifications for potential manual retry.
 func (r *Repository) GetFailedItems(ctx context.Context, limit int) ([]*notifications.QueueItem, error) {
 	rows, err := r.db.Query(ctx, `
 		SELECT id, event_id, channel_id, message_type, payload,
 			   status, attempts, max_attempts, next_attempt_at,
-			   last_error, created_at, updated_at, sent_at
+			   last_error, created_at, updated_at, sent_at, request_id
 		FROM notification_queue
 		WHERE status = 'failed'
 		ORDER BY updated_at DESC
@@ -759,6 +1288,21 @@ func (r *Repository) RecoverStuckProcessing(ctx context.Context, stuckFor time.D
 	return result.RowsAffected(), nil
 }
 
+// ReleaseProcessing resets the given items back to pending if they are still processing.
+func (r *Repository) ReleaseProcessing(ctx context.Context, ids []string) (int64, error) {
+	result, err := r.db.Exec(ctx, `
+		UPDATE notification_queue
+		SET status = 'pending',
+			next_attempt_at = NOW(),
+			updated_at = NOW()
+		WHERE id = ANY($1) AND status = 'processing'
+	`, ids)
+	if err != nil {
+		return 0, fmt.Errorf("release processing: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
 // DeleteOldSentItems removes sent notifications older than the specified duration.
 func (r *Repository) DeleteOldSentItems(ctx context.Context, olderThan time.Duration) (int64, error) {
 	cutoff := time.Now().Add(-olderThan)
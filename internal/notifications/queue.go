@@ -28,4 +28,5 @@ type QueueItem struct {
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 	SentAt        *time.Time
+	RequestID     string // request that queued the notification, sent as X-Request-ID on delivery
 }
@@ -0,0 +1,93 @@
+package notifications
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// MaintenanceReminderNotifier sends reminders for scheduled maintenance.
+type MaintenanceReminderNotifier interface {
+	OnMaintenanceReminder(ctx context.Context, event *domain.Event) error
+}
+
+// ReminderScheduler periodically sends a reminder to subscribers of scheduled
+// maintenance that starts within the configured window. Each maintenance is
+// reminded once.
+type ReminderScheduler struct {
+	window       time.Duration
+	pollInterval time.Duration
+	repo         Repository
+	notifier     MaintenanceReminderNotifier
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewReminderScheduler creates a scheduler that checks every pollInterval for
+// maintenance starting within window.
+func NewReminderScheduler(window, pollInterval time.Duration, repo Repository, notifier MaintenanceReminderNotifier) *ReminderScheduler {
+	return &ReminderScheduler{
+		window:       window,
+		pollInterval: pollInterval,
+		repo:         repo,
+		notifier:     notifier,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// Start launches the scheduler goroutine.
+func (s *ReminderScheduler) Start(ctx context.Context) {
+	slog.InfoContext(ctx, "starting maintenance reminder scheduler",
+		"window", s.window,
+		"poll_interval", s.pollInterval,
+	)
+
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+// Stop gracefully stops the scheduler.
+func (s *ReminderScheduler) Stop() {
+	close(s.stopCh)
+	s.wg.Wait()
+	slog.Info("maintenance reminder scheduler stopped")
+}
+
+func (s *ReminderScheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.sendReminders(ctx)
+		}
+	}
+}
+
+// sendReminders notifies subscribers of due maintenance. A maintenance is marked
+// as reminded only once its notification is queued; a failed one is retried on the
+// next poll.
+func (s *ReminderScheduler) sendReminders(ctx context.Context) {
+	err := s.repo.ClaimMaintenanceReminders(ctx, s.window, func(event *domain.Event) error {
+		if err := s.notifier.OnMaintenanceReminder(ctx, event); err != nil {
+			slog.ErrorContext(ctx, "failed to send maintenance reminder", "event_id", event.ID, "error", err)
+			return err
+		}
+		slog.InfoContext(ctx, "maintenance reminder sent", "event_id", event.ID)
+		return nil
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to claim maintenance reminders", "error", err)
+	}
+}
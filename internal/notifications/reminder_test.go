@@ -0,0 +1,51 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockReminderNotifier struct {
+	reminded []string
+	err      error
+}
+
+func (m *mockReminderNotifier) OnMaintenanceReminder(_ context.Context, event *domain.Event) error {
+	m.reminded = append(m.reminded, event.ID)
+	return m.err
+}
+
+func TestReminderScheduler_SendReminders(t *testing.T) {
+	repo := newMockRepository()
+	repo.reminders = []domain.Event{{ID: "mnt-1"}, {ID: "mnt-2"}}
+	notifier := &mockReminderNotifier{}
+
+	s := NewReminderScheduler(time.Hour, 5*time.Minute, repo, notifier)
+	s.sendReminders(context.Background())
+	assert.Equal(t, []string{"mnt-1", "mnt-2"}, notifier.reminded)
+
+	// Claimed events are not reminded again
+	s.sendReminders(context.Background())
+	assert.Equal(t, []string{"mnt-1", "mnt-2"}, notifier.reminded)
+}
+
+func TestReminderScheduler_NotifierErrorRetries(t *testing.T) {
+	repo := newMockRepository()
+	repo.reminders = []domain.Event{{ID: "mnt-1"}, {ID: "mnt-2"}}
+	notifier := &mockReminderNotifier{err: errors.New("queue unavailable")}
+
+	s := NewReminderScheduler(time.Hour, 5*time.Minute, repo, notifier)
+	s.sendReminders(context.Background())
+	assert.Equal(t, []string{"mnt-1", "mnt-2"}, notifier.reminded)
+
+	// Failed reminders stay unclaimed and are sent on the next poll
+	notifier.err = nil
+	s.sendReminders(context.Background())
+	assert.Equal(t, []string{"mnt-1", "mnt-2", "mnt-1", "mnt-2"}, notifier.reminded)
+	assert.Empty(t, repo.reminders)
+}
@@ -3,6 +3,7 @@ package notifications
 import (
 	"bytes"
 	"embed"
+	"encoding/json"
 	"fmt"
 	"html"
 	"strings"
@@ -43,8 +44,8 @@ func NewRenderer() (*Renderer, error) {
 	}
 
 	// Load all templates
-	channelTypes := []string{"email", "telegram", "mattermost"}
-	messageTypes := []string{"initial", "update", "resolved", "completed", "cancelled"}
+	channelTypes := []string{"email", "telegram", "mattermost", "slack"}
+	messageTypes := []string{"initial", "update", "resolved", "completed", "cancelled", "reminder", "service_recovered"}
 
 	for _, channel := range channelTypes {
 		for _, msg := range messageTypes {
@@ -73,6 +74,15 @@ func NewRenderer() (*Renderer, error) {
 func (r *Renderer) Render(channelType domain.ChannelType, payload NotificationPayload) (subject, body string, err error) {
 	subject = r.renderSubject(payload)
 
+	// Webhook channels receive raw JSON instead of a rendered template
+	if channelType == domain.ChannelTypeWebhook {
+		data, err := json.Marshal(NewWebhookPayload(payload))
+		if err != nil {
+			return "", "", fmt.Errorf("marshal webhook payload: %w", err)
+		}
+		return subject, string(data), nil
+	}
+
 	templateName := fmt.Sprintf("%s_%s", channelType, payload.MessageType)
 	tmpl, ok := r.templates[templateName]
 	if !ok {
@@ -106,6 +116,14 @@ func (r *Renderer) renderSubject(payload NotificationPayload) string {
 		prefix = "Completed"
 	case MessageTypeCancelled:
 		prefix = "Cancelled"
+	case MessageTypeReminder:
+		prefix = "Reminder"
+	case MessageTypeServiceRecovered:
+		// The subject names the service; the event is the context
+		if payload.Recovery != nil {
+			return "[Recovered] " + payload.Recovery.Name
+		}
+		prefix = "Recovered"
 	default:
 		prefix = "Notification"
 	}
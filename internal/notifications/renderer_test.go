@@ -1,6 +1,7 @@
 package notifications
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
@@ -16,7 +17,7 @@ func TestNewRenderer(t *testing.T) {
 	require.NotNil(t, r)
 
 	// Should have all templates loaded
-	expectedCount := 3 * 5 // 3 channels * 5 message types
+	expectedCount := 4 * 7 // 4 channels * 7 message types
 	assert.Len(t, r.templates, expectedCount)
 }
 
@@ -126,6 +127,132 @@ func TestRenderer_RenderUpdate(t *testing.T) {
 	assert.Contains(t, body, "Root cause identified")
 }
 
+func TestRenderer_RenderUpdate_PostmortemURL(t *testing.T) {
+	r, err := NewRenderer()
+	require.NoError(t, err)
+
+	payload := NotificationPayload{
+		MessageType: MessageTypeUpdate,
+		Event: EventData{
+			ID:      "evt-123",
+			Title:   "Database connectivity issues",
+			Type:    "incident",
+			Status:  "resolved",
+			Message: "Post-mortem published: Connection pool exhaustion",
+		},
+		Changes:       &EventChanges{},
+		PostmortemURL: "https://status.example.com/events/evt-123/postmortem",
+		GeneratedAt:   time.Now(),
+	}
+
+	for _, ch := range []domain.ChannelType{
+		domain.ChannelTypeEmail,
+		domain.ChannelTypeTelegram,
+		domain.ChannelTypeMattermost,
+		domain.ChannelTypeSlack,
+	} {
+		t.Run(string(ch), func(t *testing.T) {
+			_, body, err := r.Render(ch, payload)
+			require.NoError(t, err)
+			assert.Contains(t, body, "https://status.example.com/events/evt-123/postmortem")
+		})
+	}
+
+	payload.PostmortemURL = ""
+	_, body, err := r.Render(domain.ChannelTypeEmail, payload)
+	require.NoError(t, err)
+	assert.NotContains(t, body, "Post-mortem:")
+}
+
+func TestRenderer_Impact(t *testing.T) {
+	r, err := NewRenderer()
+	require.NoError(t, err)
+
+	for _, msgType := range []MessageType{MessageTypeInitial, MessageTypeUpdate} {
+		payload := NotificationPayload{
+			MessageType: msgType,
+			Event: EventData{
+				ID:      "evt-123",
+				Title:   "Login failures",
+				Type:    "incident",
+				Status:  "investigating",
+				Message: "Investigating elevated error rates",
+				Impact:  "Customers cannot sign in & checkout",
+			},
+			Changes:     &EventChanges{},
+			GeneratedAt: time.Now(),
+		}
+
+		for _, ch := range []domain.ChannelType{
+			domain.ChannelTypeEmail,
+			domain.ChannelTypeMattermost,
+			domain.ChannelTypeSlack,
+		} {
+			t.Run(string(msgType)+"/"+string(ch), func(t *testing.T) {
+				_, body, err := r.Render(ch, payload)
+				require.NoError(t, err)
+				assert.Contains(t, body, "Customers cannot sign in & checkout")
+			})
+		}
+
+		t.Run(string(msgType)+"/telegram", func(t *testing.T) {
+			_, body, err := r.Render(domain.ChannelTypeTelegram, payload)
+			require.NoError(t, err)
+			assert.Contains(t, body, "Impact: Customers cannot sign in &amp; checkout")
+		})
+
+		payload.Event.Impact = ""
+		_, body, err := r.Render(domain.ChannelTypeEmail, payload)
+		require.NoError(t, err)
+		assert.NotContains(t, body, "Impact:")
+	}
+}
+
+func TestRenderer_OnCallTeam(t *testing.T) {
+	r, err := NewRenderer()
+	require.NoError(t, err)
+
+	for _, msgType := range []MessageType{MessageTypeInitial, MessageTypeUpdate} {
+		payload := NotificationPayload{
+			MessageType: msgType,
+			Event: EventData{
+				ID:         "evt-123",
+				Title:      "Payment errors",
+				Type:       "incident",
+				Status:     "identified",
+				Message:    "Escalated to Payments <SRE>",
+				OnCallTeam: "Payments <SRE>",
+			},
+			Changes:     &EventChanges{},
+			GeneratedAt: time.Now(),
+		}
+
+		for _, ch := range []domain.ChannelType{
+			domain.ChannelTypeEmail,
+			domain.ChannelTypeMattermost,
+			domain.ChannelTypeSlack,
+		} {
+			t.Run(string(msgType)+"/"+string(ch), func(t *testing.T) {
+				_, body, err := r.Render(ch, payload)
+				require.NoError(t, err)
+				assert.Contains(t, body, "On-call team:")
+				assert.Contains(t, body, "Payments <SRE>")
+			})
+		}
+
+		t.Run(string(msgType)+"/telegram", func(t *testing.T) {
+			_, body, err := r.Render(domain.ChannelTypeTelegram, payload)
+			require.NoError(t, err)
+			assert.Contains(t, body, "On-call team: Payments &lt;SRE&gt;")
+		})
+
+		payload.Event.OnCallTeam = ""
+		_, body, err := r.Render(domain.ChannelTypeEmail, payload)
+		require.NoError(t, err)
+		assert.NotContains(t, body, "On-call team:")
+	}
+}
+
 func TestRenderer_RenderResolved(t *testing.T) {
 	r, err := NewRenderer()
 	require.NoError(t, err)
@@ -222,6 +349,90 @@ func TestRenderer_RenderCancelled(t *testing.T) {
 	assert.Contains(t, body, "has been cancelled")
 }
 
+func TestRenderer_RenderReminder(t *testing.T) {
+	r, err := NewRenderer()
+	require.NoError(t, err)
+
+	scheduledStart := time.Date(2026, 3, 10, 2, 0, 0, 0, time.UTC)
+	scheduledEnd := scheduledStart.Add(2 * time.Hour)
+
+	payload := NotificationPayload{
+		MessageType: MessageTypeReminder,
+		Event: EventData{
+			ID:             "evt-789",
+			Title:          "Database upgrade",
+			Type:           "maintenance",
+			Status:         "scheduled",
+			Services:       []ServiceInfo{{ID: "svc-1", Name: "Database"}},
+			ScheduledStart: &scheduledStart,
+			ScheduledEnd:   &scheduledEnd,
+		},
+		EventURL:    "https://status.example.com/events/evt-789",
+		GeneratedAt: time.Now(),
+	}
+
+	subject, body, err := r.Render(domain.ChannelTypeEmail, payload)
+	require.NoError(t, err)
+
+	assert.Equal(t, "[Reminder] Database upgrade", subject)
+	assert.Contains(t, body, "Upcoming Maintenance: Database upgrade")
+	assert.Contains(t, body, "Starts: Mar 10, 2026 02:00 UTC")
+	assert.Contains(t, body, "Ends: Mar 10, 2026 04:00 UTC")
+	assert.Contains(t, body, "Database")
+	assert.Contains(t, body, "https://status.example.com/events/evt-789")
+
+	for _, ch := range []domain.ChannelType{domain.ChannelTypeTelegram, domain.ChannelTypeMattermost, domain.ChannelTypeSlack} {
+		_, body, err := r.Render(ch, payload)
+		require.NoError(t, err, ch)
+		assert.Contains(t, body, "Database upgrade", ch)
+	}
+}
+
+func TestRenderer_RenderServiceRecovered(t *testing.T) {
+	r, err := NewRenderer()
+	require.NoError(t, err)
+
+	payload := NewServiceRecoveredPayload(EventData{
+		ID:       "evt-321",
+		Title:    "Payments <outage>",
+		Type:     "incident",
+		Status:   "resolved",
+		Services: []ServiceInfo{{ID: "svc-1", Name: "Payments API"}},
+	}, ServiceStatusChange{
+		ID:         "svc-1",
+		Name:       "Payments API",
+		StatusFrom: "major_outage",
+		StatusTo:   "operational",
+	}, "https://status.example.com/events/evt-321")
+
+	subject, body, err := r.Render(domain.ChannelTypeEmail, payload)
+	require.NoError(t, err)
+
+	assert.Equal(t, "[Recovered] Payments API", subject)
+	assert.Contains(t, body, "Payments API is operational again (was major_outage)")
+	assert.Contains(t, body, "Resolved incident: Payments <outage>")
+	assert.Contains(t, body, "https://status.example.com/events/evt-321")
+
+	_, body, err = r.Render(domain.ChannelTypeTelegram, payload)
+	require.NoError(t, err)
+	assert.Contains(t, body, "Payments &lt;outage&gt;")
+
+	for _, ch := range []domain.ChannelType{domain.ChannelTypeMattermost, domain.ChannelTypeSlack} {
+		_, body, err := r.Render(ch, payload)
+		require.NoError(t, err, ch)
+		assert.Contains(t, body, "Payments API is operational again", ch)
+	}
+
+	_, body, err = r.Render(domain.ChannelTypeWebhook, payload)
+	require.NoError(t, err)
+	var got WebhookPayload
+	require.NoError(t, json.Unmarshal([]byte(body), &got))
+	assert.Equal(t, WebhookNotificationServiceRecovered, got.NotificationType)
+	require.NotNil(t, got.Recovery)
+	assert.Equal(t, "svc-1", got.Recovery.ID)
+	assert.Equal(t, "major_outage", got.Recovery.StatusFrom)
+}
+
 func TestRenderer_TelegramFormat(t *testing.T) {
 	r, err := NewRenderer()
 	require.NoError(t, err)
@@ -277,6 +488,101 @@ func TestRenderer_MattermostFormat(t *testing.T) {
 	assert.Contains(t, body, "[View details]")
 }
 
+func TestRenderer_SlackFormat(t *testing.T) {
+	r, err := NewRenderer()
+	require.NoError(t, err)
+
+	now := time.Now()
+	payload := NotificationPayload{
+		MessageType: MessageTypeInitial,
+		Event: EventData{
+			ID:       "evt-123",
+			Title:    "API Issues",
+			Type:     "incident",
+			Status:   "investigating",
+			Severity: "critical",
+			Services: []ServiceInfo{{ID: "svc-1", Name: "API", Status: "major_outage"}},
+		},
+		EventURL:    "https://status.example.com/events/evt-123",
+		GeneratedAt: now,
+	}
+
+	_, body, err := r.Render(domain.ChannelTypeSlack, payload)
+	require.NoError(t, err)
+
+	// Slack mrkdwn uses single * for bold and <url|text> for links
+	assert.Contains(t, body, "*Incident: API Issues*")
+	assert.NotContains(t, body, "**")
+	assert.Contains(t, body, "<https://status.example.com/events/evt-123|View details>")
+}
+
+func TestRenderer_WebhookJSON(t *testing.T) {
+	r, err := NewRenderer()
+	require.NoError(t, err)
+
+	started := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	resolved := started.Add(90 * time.Minute)
+	payload := NotificationPayload{
+		MessageType: MessageTypeResolved,
+		Event: EventData{
+			ID:        "evt-123",
+			Title:     "API Issues",
+			Type:      "incident",
+			Status:    "resolved",
+			Severity:  "critical",
+			Message:   "All systems operational",
+			Services:  []ServiceInfo{{ID: "svc-1", Name: "API", Status: "operational"}},
+			Groups:    []GroupInfo{{ID: "grp-1", Name: "Core"}},
+			StartedAt: &started,
+		},
+		Resolution:  &EventResolution{ResolvedAt: resolved, Duration: 90 * time.Minute},
+		EventURL:    "https://status.example.com/events/evt-123",
+		GeneratedAt: resolved,
+	}
+
+	subject, body, err := r.Render(domain.ChannelTypeWebhook, payload)
+	require.NoError(t, err)
+	assert.Equal(t, "[Resolved] API Issues", subject)
+
+	var got WebhookPayload
+	require.NoError(t, json.Unmarshal([]byte(body), &got))
+	assert.Equal(t, WebhookNotificationEventResolved, got.NotificationType)
+	assert.Equal(t, "evt-123", got.ID)
+	assert.Equal(t, "resolved", got.Status)
+	require.NotNil(t, got.Severity)
+	assert.Equal(t, "critical", *got.Severity)
+	assert.Equal(t, "All systems operational", got.Description)
+	assert.Equal(t, []string{"svc-1"}, got.ServiceIDs)
+	assert.Equal(t, []string{"grp-1"}, got.GroupIDs)
+	require.NotNil(t, got.ResolvedAt)
+	assert.True(t, resolved.Equal(*got.ResolvedAt))
+	assert.Equal(t, "https://status.example.com/events/evt-123", got.EventURL)
+}
+
+func TestNewWebhookPayload_NotificationType(t *testing.T) {
+	tests := []struct {
+		messageType MessageType
+		want        string
+	}{
+		{MessageTypeInitial, WebhookNotificationEventCreated},
+		{MessageTypeUpdate, WebhookNotificationEventUpdated},
+		{MessageTypeResolved, WebhookNotificationEventResolved},
+		{MessageTypeCompleted, WebhookNotificationEventResolved},
+		{MessageTypeCancelled, WebhookNotificationEventResolved},
+		{MessageTypeReminder, WebhookNotificationMaintenanceReminder},
+		{MessageTypeServiceRecovered, WebhookNotificationServiceRecovered},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.messageType), func(t *testing.T) {
+			got := NewWebhookPayload(NotificationPayload{MessageType: tt.messageType})
+			assert.Equal(t, tt.want, got.NotificationType)
+			assert.Nil(t, got.Severity, "severity is null when not set")
+			assert.NotNil(t, got.ServiceIDs, "service_ids is an empty array, not null")
+		})
+	}
+}
+
 func TestRenderer_UnknownTemplate(t *testing.T) {
 	r, err := NewRenderer()
 	require.NoError(t, err)
@@ -449,6 +755,12 @@ func TestBuilderFunctions(t *testing.T) {
 		assert.Equal(t, MessageTypeCancelled, p.MessageType)
 		assert.Empty(t, p.EventURL) // cancelled doesn't need URL
 	})
+
+	t.Run("NewReminderPayload", func(t *testing.T) {
+		p := NewReminderPayload(event, "http://example.com")
+		assert.Equal(t, MessageTypeReminder, p.MessageType)
+		assert.Equal(t, "http://example.com", p.EventURL)
+	})
 }
 
 func TestRenderer_AllChannelTypes(t *testing.T) {
@@ -471,6 +783,8 @@ func TestRenderer_AllChannelTypes(t *testing.T) {
 		domain.ChannelTypeEmail,
 		domain.ChannelTypeTelegram,
 		domain.ChannelTypeMattermost,
+		domain.ChannelTypeSlack,
+		domain.ChannelTypeWebhook,
 	}
 
 	for _, ch := range channels {
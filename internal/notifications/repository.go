@@ -15,18 +15,24 @@ type Repository interface {
 	GetChannelByID(ctx context.Context, id string) (*domain.NotificationChannel, error)
 	GetChannelByUserAndTarget(ctx context.Context, userID string, channelType domain.ChannelType, target string) (*domain.NotificationChannel, error)
 	ListUserChannels(ctx context.Context, userID string) ([]domain.NotificationChannel, error)
+	CountChannelsByUserID(ctx context.Context, userID string) (int, error)
 	UpdateChannel(ctx context.Context, channel *domain.NotificationChannel) error
 	DeleteChannel(ctx context.Context, id string) error
 
 	// Channel subscriptions
-	SetChannelSubscriptions(ctx context.Context, channelID string, subscribeAll bool, serviceIDs []string) error
+	SetChannelSubscriptions(ctx context.Context, channelID string, subscribeAll bool, serviceIDs []string, minSeverity *domain.Severity) error
 	GetChannelSubscriptions(ctx context.Context, channelID string) (subscribeAll bool, serviceIDs []string, err error)
 	GetUserChannelsWithSubscriptions(ctx context.Context, userID string) ([]ChannelWithSubscriptions, error)
+	// UnsubscribeAll clears subscriptions of every channel owned by the user and
+	// removes those channels from subscriber snapshots of ongoing events.
+	UnsubscribeAll(ctx context.Context, userID string) error
 
 	// Event subscribers
 	CreateEventSubscribers(ctx context.Context, eventID string, channelIDs []string) error
 	GetEventSubscribers(ctx context.Context, eventID string) ([]string, error)
 	AddEventSubscribers(ctx context.Context, eventID string, channelIDs []string) error
+	// ListEventSubscribers returns the channels snapshotted for an event (ErrEventNotFound for unknown events).
+	ListEventSubscribers(ctx context.Context, eventID string) ([]domain.NotificationChannel, error)
 
 	// Find subscribers for services (returns channels that are subscribed to any of the given services)
 	FindSubscribersForServices(ctx context.Context, serviceIDs []string) ([]ChannelInfo, error)
@@ -41,6 +47,29 @@ type Repository interface {
 	DeleteVerificationCode(ctx context.Context, channelID string) error
 	DeleteExpiredCodes(ctx context.Context) (int64, error)
 
+	// Public subscribers
+	GetSubscriberUserID(ctx context.Context) (string, error)
+	CreateSubscriberToken(ctx context.Context, channelID, token string) error
+	// CreatePendingSubscriberToken stores a token that manages nothing until ConfirmSubscriberToken.
+	CreatePendingSubscriberToken(ctx context.Context, channelID, token string) error
+	// ConfirmSubscriberToken confirms a token and deletes the other tokens of its channel.
+	ConfirmSubscriberToken(ctx context.Context, token string) error
+	GetSubscriberToken(ctx context.Context, token string) (*SubscriberToken, error)
+
+	// Telegram chats
+	// GetTelegramUserID returns the user linked to a chat, "" if the chat is not linked.
+	GetTelegramUserID(ctx context.Context, chatID int64) (string, error)
+	// LinkTelegramUser links a chat to a user, replacing a previous link.
+	LinkTelegramUser(ctx context.Context, chatID int64, userID string) error
+	// GetVerifiedChannelByTarget returns the oldest verified channel with the given
+	// type and target. Returns nil, nil if not found.
+	GetVerifiedChannelByTarget(ctx context.Context, channelType domain.ChannelType, target string) (*domain.NotificationChannel, error)
+
+	// ClaimMaintenanceReminders calls send for scheduled maintenance starting within
+	// window that has no reminder yet and marks the events send succeeded for as
+	// reminded. An event is sent successfully at most once; failed ones are retried.
+	ClaimMaintenanceReminders(ctx context.Context, window time.Duration, send func(*domain.Event) error) error
+
 	// Queue operations
 	EnqueueNotification(ctx context.Context, item *QueueItem) error
 	EnqueueBatch(ctx context.Context, items []*QueueItem) error
@@ -50,10 +79,28 @@ type Repository interface {
 	MarkAsFailed(ctx context.Context, id string, err error) error
 	MarkForRetry(ctx context.Context, id string, err error, nextAttempt time.Time) error
 
+	// Delivery receipts
+	CreateDelivery(ctx context.Context, delivery *Delivery) error
+	UpdateDelivery(ctx context.Context, delivery *Delivery) error
+	// ListDeliveries returns the deliveries of a queued notification (ErrNotificationNotFound for unknown ones).
+	ListDeliveries(ctx context.Context, notificationID string) ([]Delivery, error)
+
+	// Dead letters
+	// MoveToDeadLetter marks a notification as failed and copies it with its attempt
+	// timestamps to notification_dead_letters, in one transaction.
+	MoveToDeadLetter(ctx context.Context, id string, err error) error
+	// ListDeadLetters returns dead letters, newest first, and their total count.
+	ListDeadLetters(ctx context.Context, limit, offset int) ([]DeadLetter, int, error)
+	// RequeueDeadLetter resets the notification to pending with no attempts and deletes
+	// the dead letter (ErrDeadLetterNotFound for unknown ones).
+	RequeueDeadLetter(ctx context.Context, id string) error
+
 	// Queue management and recovery
 	GetFailedItems(ctx context.Context, limit int) ([]*QueueItem, error)
 	RetryFailedItem(ctx context.Context, id string) error
 	RecoverStuckProcessing(ctx context.Context, stuckFor time.Duration) (int64, error)
+	// ReleaseProcessing returns the given processing notifications to pending, e.g. on worker shutdown.
+	ReleaseProcessing(ctx context.Context, ids []string) (int64, error)
 	DeleteOldSentItems(ctx context.Context, olderThan time.Duration) (int64, error)
 	GetQueueStats(ctx context.Context) (*QueueStats, error)
 }
@@ -64,7 +111,10 @@ type ChannelInfo struct {
 	UserID   string
 	Type     domain.ChannelType
 	Target   string
+	Secret   string // Signing secret (webhook only)
 	Email    string // User's email (for context)
+
+	MinSeverity *domain.Severity // nil = notify on incidents of any severity
 }
 
 // ChannelWithSubscriptions contains channel with its subscription settings.
@@ -84,6 +134,15 @@ type VerificationCode struct {
 	CreatedAt time.Time
 }
 
+// SubscriberToken identifies the channel of a public subscriber.
+type SubscriberToken struct {
+	Token     string
+	ChannelID string
+	// Confirmed is false for a token issued to an already subscribed email until its code is entered.
+	Confirmed bool
+	CreatedAt time.Time
+}
+
 // QueueStats contains queue statistics.
 type QueueStats struct {
 	Pending    int64
@@ -8,9 +8,11 @@ import (
 
 // Notification represents a notification to be sent.
 type Notification struct {
-	To      string
-	Subject string
-	Body    string
+	To       string
+	Subject  string
+	Body     string
+	Secret   string // optional signing secret (webhook channels only)
+	Severity string // incident severity, empty otherwise; Mattermost colors messages by it
 }
 
 // Sender interface for different notification channels.
@@ -18,3 +20,9 @@ type Sender interface {
 	Send(ctx context.Context, notification Notification) error
 	Type() domain.ChannelType
 }
+
+// Verifier is implemented by senders that verify a channel differently
+// from a regular Send (e.g. without retries or with stricter checks).
+type Verifier interface {
+	Verify(ctx context.Context, notification Notification) error
+}
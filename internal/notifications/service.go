@@ -4,10 +4,13 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/subtle"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
 	"math/big"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,6 +23,7 @@ const (
 	verificationCodeTTL     = 24 * time.Hour
 	maxVerificationAttempts = 5
 	resendCooldown          = 60 * time.Second
+	subscriberTokenBytes    = 32 // hex-encoded = 64 chars
 )
 
 // Service errors.
@@ -38,6 +42,12 @@ type ServiceValidator interface {
 	ValidateServicesExist(ctx context.Context, ids []string) (missingIDs []string, err error)
 }
 
+// ChannelLimitProvider returns how many notification channels a non-admin user may have.
+// This interface is implemented by admin.Service.
+type ChannelLimitProvider interface {
+	GetMaxChannelsPerUser(ctx context.Context) (int, error)
+}
+
 // ChannelConfig describes which channel types are available.
 type ChannelConfig struct {
 	EmailEnabled        bool
@@ -85,8 +95,12 @@ func (s *Service) GetAvailableChannels() *AvailableChannelsResponse {
 		channels = append(channels, string(domain.ChannelTypeTelegram))
 	}
 
-	// Mattermost is always available (webhook URL is set per-channel by user)
-	channels = append(channels, string(domain.ChannelTypeMattermost))
+	// Mattermost, Slack and webhook are always available (URL is set per-channel by user)
+	channels = append(channels,
+		string(domain.ChannelTypeMattermost),
+		string(domain.ChannelTypeSlack),
+		string(domain.ChannelTypeWebhook),
+	)
 
 	resp := &AvailableChannelsResponse{
 		AvailableChannels: channels,
@@ -102,7 +116,12 @@ func (s *Service) GetAvailableChannels() *AvailableChannelsResponse {
 }
 
 // CreateChannel creates a new notification channel for user.
-func (s *Service) CreateChannel(ctx context.Context, userID string, channelType domain.ChannelType, target string) (*domain.NotificationChannel, error) {
+// secret is an optional HMAC signing secret, accepted for webhook channels only.
+func (s *Service) CreateChannel(ctx context.Context, userID string, channelType domain.ChannelType, target, secret string) (*domain.NotificationChannel, error) {
+	if secret != "" && channelType != domain.ChannelTypeWebhook {
+		return nil, ErrSecretNotSupported
+	}
+
 	// Check if channel type is enabled
 	if s.channelConfig != nil {
 		switch channelType {
@@ -114,14 +133,14 @@ func (s *Service) CreateChannel(ctx context.Context, userID string, channelType
 			if !s.channelConfig.TelegramEnabled {
 				return nil, ErrChannelTypeDisabled
 			}
-		// Mattermost is always available
+		// Mattermost, Slack and webhook are always available
 		}
 	}
 
-	// Check for duplicate email channel with same target
-	// Only email channels need duplicate check because:
-	// - Email: same address shouldn't have multiple channels
-	// - Telegram/Mattermost: target is external ID, duplicates are technically possible
+	target = normalizeChannelTarget(channelType, target)
+
+	// Check for duplicate email channel with same target up front, so no
+	// verification code is sent; the unique constraint covers the rest
 	if channelType == domain.ChannelTypeEmail {
 		existing, err := s.repo.GetChannelByUserAndTarget(ctx, userID, channelType, target)
 		if err != nil {
@@ -139,6 +158,7 @@ func (s *Service) CreateChannel(ctx context.Context, userID string, channelType
 		IsEnabled:              true,
 		IsVerified:             false,
 		SubscribeToAllServices: false,
+		Secret:                 secret,
 	}
 
 	if err := s.repo.CreateChannel(ctx, channel); err != nil {
@@ -148,7 +168,7 @@ func (s *Service) CreateChannel(ctx context.Context, userID string, channelType
 	// For email channels, send verification code
 	if channelType == domain.ChannelTypeEmail {
 		if err := s.sendVerificationCode(ctx, channel); err != nil {
-			slog.Error("failed to send verification code", "channel_id", channel.ID, "error", err)
+			slog.ErrorContext(ctx, "failed to send verification code", "channel_id", channel.ID, "error", err)
 			// Don't return error - channel is created, code can be resent
 		}
 	}
@@ -156,6 +176,20 @@ func (s *Service) CreateChannel(ctx context.Context, userID string, channelType
 	return channel, nil
 }
 
+// normalizeChannelTarget lowercases email addresses, which are case-insensitive.
+// Other targets are external IDs and URLs and are kept as-is.
+func normalizeChannelTarget(channelType domain.ChannelType, target string) string {
+	if channelType == domain.ChannelTypeEmail {
+		return strings.ToLower(target)
+	}
+	return target
+}
+
+// CountUserChannels returns the number of channels of a user.
+func (s *Service) CountUserChannels(ctx context.Context, userID string) (int, error) {
+	return s.repo.CountChannelsByUserID(ctx, userID)
+}
+
 // ListUserChannels returns all channels for a user.
 func (s *Service) ListUserChannels(ctx context.Context, userID string) ([]domain.NotificationChannel, error) {
 	return s.repo.ListUserChannels(ctx, userID)
@@ -221,7 +255,7 @@ func (s *Service) VerifyChannel(ctx context.Context, userID, channelID, inputCod
 		return s.verifyEmailCode(ctx, channel, inputCode)
 	}
 
-	// For Telegram/Mattermost - send test message (to be implemented separately)
+	// For Telegram/Mattermost/Slack/webhook - send test message
 	return s.verifyByTestMessage(ctx, channel)
 }
 
@@ -239,7 +273,7 @@ func (s *Service) verifyEmailCode(ctx context.Context, channel *domain.Notificat
 
 	// Increment attempt counter
 	if err := s.repo.IncrementCodeAttempts(ctx, channel.ID); err != nil {
-		slog.Error("failed to increment attempts", "error", err)
+		slog.ErrorContext(ctx, "failed to increment attempts", "error", err)
 	}
 
 	// Constant-time comparison to prevent timing attacks
@@ -256,7 +290,7 @@ func (s *Service) verifyEmailCode(ctx context.Context, channel *domain.Notificat
 	// Delete used code
 	_ = s.repo.DeleteVerificationCode(ctx, channel.ID)
 
-	slog.Info("channel verified", "channel_id", channel.ID)
+	slog.InfoContext(ctx, "channel verified", "channel_id", channel.ID)
 	return channel, nil
 }
 
@@ -276,10 +310,17 @@ func (s *Service) verifyByTestMessage(ctx context.Context, channel *domain.Notif
 		To:      channel.Target,
 		Subject: "Channel Verification",
 		Body:    "This is a test message to verify your notification channel. If you received this message, your channel is working correctly.",
+		Secret:  channel.Secret,
+	}
+
+	// Senders with their own verification (e.g. webhook) override the regular send
+	send := sender.Send
+	if v, ok := sender.(Verifier); ok {
+		send = v.Verify
 	}
 
-	if err := sender.Send(ctx, notification); err != nil {
-		slog.Warn("channel verification failed",
+	if err := send(ctx, notification); err != nil {
+		slog.WarnContext(ctx, "channel verification failed",
 			"channel_id", channel.ID, "type", channel.Type, "error", err)
 		msg := classifyVerificationError(channel.Type, err)
 		return nil, fmt.Errorf("%w: %s", ErrVerificationFailed, msg)
@@ -291,7 +332,7 @@ func (s *Service) verifyByTestMessage(ctx context.Context, channel *domain.Notif
 		return nil, fmt.Errorf("update channel: %w", err)
 	}
 
-	slog.Info("channel verified via test message", "channel_id", channel.ID, "type", channel.Type)
+	slog.InfoContext(ctx, "channel verified via test message", "channel_id", channel.ID, "type", channel.Type)
 	return channel, nil
 }
 
@@ -311,8 +352,10 @@ func classifyVerificationError(channelType domain.ChannelType, err error) string
 		default:
 			return "failed to send test message to Telegram — check the chat ID"
 		}
-	case domain.ChannelTypeMattermost:
+	case domain.ChannelTypeMattermost, domain.ChannelTypeSlack:
 		return "failed to send test message — check the webhook URL"
+	case domain.ChannelTypeWebhook:
+		return "webhook endpoint did not respond with 200 OK — check the URL"
 	default:
 		return "failed to send test message"
 	}
@@ -371,7 +414,7 @@ If you did not request this code, please ignore this email.`, code)
 	}
 
 	if s.dispatcher == nil {
-		slog.Warn("dispatcher not configured, verification email not sent", "channel_id", channel.ID)
+		slog.WarnContext(ctx, "dispatcher not configured, verification email not sent", "channel_id", channel.ID)
 		return nil
 	}
 
@@ -384,7 +427,7 @@ If you did not request this code, please ignore this email.`, code)
 		return fmt.Errorf("send verification email: %w", err)
 	}
 
-	slog.Info("verification code sent", "channel_id", channel.ID)
+	slog.InfoContext(ctx, "verification code sent", "channel_id", channel.ID)
 	return nil
 }
 
@@ -429,7 +472,8 @@ func (s *Service) GetSubscriptionsMatrix(ctx context.Context, userID string) (*S
 }
 
 // SetChannelSubscriptions sets subscription settings for a channel.
-func (s *Service) SetChannelSubscriptions(ctx context.Context, userID, channelID string, subscribeAll bool, serviceIDs []string) error {
+// minSeverity, if set, mutes incidents below that severity on the channel.
+func (s *Service) SetChannelSubscriptions(ctx context.Context, userID, channelID string, subscribeAll bool, serviceIDs []string, minSeverity *domain.Severity) error {
 	channel, err := s.repo.GetChannelByID(ctx, channelID)
 	if err != nil {
 		return err
@@ -457,7 +501,18 @@ func (s *Service) SetChannelSubscriptions(ctx context.Context, userID, channelID
 		}
 	}
 
-	return s.repo.SetChannelSubscriptions(ctx, channelID, subscribeAll, serviceIDs)
+	return s.repo.SetChannelSubscriptions(ctx, channelID, subscribeAll, serviceIDs, minSeverity)
+}
+
+// UnsubscribeAll stops all notifications for the user: channel subscriptions are
+// cleared and the user's channels leave subscriber lists of ongoing events.
+// Channels themselves are kept.
+func (s *Service) UnsubscribeAll(ctx context.Context, userID string) error {
+	if err := s.repo.UnsubscribeAll(ctx, userID); err != nil {
+		return fmt.Errorf("unsubscribe all: %w", err)
+	}
+	slog.InfoContext(ctx, "user unsubscribed from all notifications", "user_id", userID)
+	return nil
 }
 
 // GetChannelSubscriptions returns subscription settings for a channel.
@@ -465,11 +520,284 @@ func (s *Service) GetChannelSubscriptions(ctx context.Context, channelID string)
 	return s.repo.GetChannelSubscriptions(ctx, channelID)
 }
 
+// GetTelegramChannel returns the verified Telegram channel of a chat. A chat not
+// linked yet is linked to the owner of the oldest verified channel targeting it.
+// Returns ErrTelegramChatNotLinked if there is no such channel.
+func (s *Service) GetTelegramChannel(ctx context.Context, chatID int64) (*domain.NotificationChannel, error) {
+	target := strconv.FormatInt(chatID, 10)
+
+	userID, err := s.repo.GetTelegramUserID(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != "" {
+		channel, err := s.repo.GetChannelByUserAndTarget(ctx, userID, domain.ChannelTypeTelegram, target)
+		if err != nil {
+			return nil, err
+		}
+		if channel != nil && channel.IsVerified {
+			return channel, nil
+		}
+		// The linked user's channel is gone or unverified: link the chat again
+	}
+
+	channel, err := s.repo.GetVerifiedChannelByTarget(ctx, domain.ChannelTypeTelegram, target)
+	if err != nil {
+		return nil, err
+	}
+	if channel == nil {
+		return nil, ErrTelegramChatNotLinked
+	}
+
+	if err := s.repo.LinkTelegramUser(ctx, chatID, channel.UserID); err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "telegram chat linked", "chat_id", chatID, "user_id", channel.UserID)
+	return channel, nil
+}
+
+// SubscribeChannelToService adds a service to the subscriptions of a channel,
+// keeping its other services and severity threshold. Returns false if the channel
+// already receives notifications for the service.
+func (s *Service) SubscribeChannelToService(ctx context.Context, channel *domain.NotificationChannel, serviceID string) (bool, error) {
+	subscribeAll, serviceIDs, err := s.repo.GetChannelSubscriptions(ctx, channel.ID)
+	if err != nil {
+		return false, err
+	}
+	if subscribeAll || slices.Contains(serviceIDs, serviceID) {
+		return false, nil
+	}
+
+	serviceIDs = append(serviceIDs, serviceID)
+	if err := s.SetChannelSubscriptions(ctx, channel.UserID, channel.ID, false, serviceIDs, channel.MinSeverity); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UnsubscribeChannel clears all subscriptions of a channel, keeping its severity threshold.
+func (s *Service) UnsubscribeChannel(ctx context.Context, channel *domain.NotificationChannel) error {
+	return s.SetChannelSubscriptions(ctx, channel.UserID, channel.ID, false, nil, channel.MinSeverity)
+}
+
+// PublicSubscription is a subscription of a visitor without an account.
+type PublicSubscription struct {
+	Token      string   `json:"token"`
+	Email      string   `json:"email"`
+	ServiceIDs []string `json:"service_ids"`
+	IsVerified bool     `json:"is_verified"`
+}
+
+// Subscribe subscribes an email address to services without an account.
+// The channel is owned by the subscriber system user and stays unverified until
+// the emailed code is confirmed with the returned token. An already subscribed
+// email gets the services added and a pending token (see resubscribe); the
+// response is the same, so it doesn't tell whether the email was subscribed.
+func (s *Service) Subscribe(ctx context.Context, email string, serviceIDs []string) (*PublicSubscription, error) {
+	if s.serviceValidator == nil {
+		return nil, errors.New("service validator not configured")
+	}
+	missingIDs, err := s.serviceValidator.ValidateServicesExist(ctx, serviceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("validate services: %w", err)
+	}
+	if len(missingIDs) > 0 {
+		return nil, ErrServicesNotFound
+	}
+
+	userID, err := s.repo.GetSubscriberUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	target := normalizeChannelTarget(domain.ChannelTypeEmail, email)
+	channel, err := s.repo.GetChannelByUserAndTarget(ctx, userID, domain.ChannelTypeEmail, target)
+	if err != nil {
+		return nil, fmt.Errorf("check existing channel: %w", err)
+	}
+
+	var token string
+	if channel == nil {
+		channel, err = s.CreateChannel(ctx, userID, domain.ChannelTypeEmail, email, "")
+		switch {
+		case errors.Is(err, ErrChannelAlreadyExists):
+			// A concurrent request subscribed the email first
+			channel, err = s.repo.GetChannelByUserAndTarget(ctx, userID, domain.ChannelTypeEmail, target)
+			if err != nil {
+				return nil, fmt.Errorf("check existing channel: %w", err)
+			}
+			if channel == nil {
+				return nil, ErrChannelAlreadyExists
+			}
+		case err != nil:
+			return nil, err
+		default:
+			if token, err = s.createSubscription(ctx, channel.ID, serviceIDs); err != nil {
+				// Don't leave a channel that blocks subscribing the same email again
+				if delErr := s.repo.DeleteChannel(ctx, channel.ID); delErr != nil {
+					slog.ErrorContext(ctx, "failed to delete subscriber channel", "channel_id", channel.ID, "error", delErr)
+				}
+				return nil, err
+			}
+			slog.InfoContext(ctx, "public subscription created", "channel_id", channel.ID)
+		}
+	}
+
+	if token == "" {
+		if token, err = s.resubscribe(ctx, channel, serviceIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return &PublicSubscription{
+		Token:      token,
+		Email:      email,
+		ServiceIDs: serviceIDs,
+		IsVerified: false,
+	}, nil
+}
+
+// createSubscription stores channel subscriptions and a token for managing them.
+func (s *Service) createSubscription(ctx context.Context, channelID string, serviceIDs []string) (string, error) {
+	if err := s.repo.SetChannelSubscriptions(ctx, channelID, false, serviceIDs, nil); err != nil {
+		return "", err
+	}
+
+	token, err := generateSubscriberToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.repo.CreateSubscriberToken(ctx, channelID, token); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// resubscribe adds services to the channel of an already subscribed email and issues
+// a pending token for it. The token manages the subscription only after the re-sent
+// code confirms it, so subscribing somebody else's email can't take it over.
+func (s *Service) resubscribe(ctx context.Context, channel *domain.NotificationChannel, serviceIDs []string) (string, error) {
+	if s.channelConfig != nil && !s.channelConfig.EmailEnabled {
+		return "", ErrChannelTypeDisabled
+	}
+
+	subscribeAll, subscribed, err := s.repo.GetChannelSubscriptions(ctx, channel.ID)
+	if err != nil {
+		return "", err
+	}
+	if !subscribeAll {
+		for _, id := range serviceIDs {
+			if !slices.Contains(subscribed, id) {
+				subscribed = append(subscribed, id)
+			}
+		}
+		if err := s.repo.SetChannelSubscriptions(ctx, channel.ID, false, subscribed, channel.MinSeverity); err != nil {
+			return "", err
+		}
+	}
+
+	token, err := generateSubscriberToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.repo.CreatePendingSubscriberToken(ctx, channel.ID, token); err != nil {
+		return "", err
+	}
+
+	// A code sent within the cooldown stays valid for the new token; don't flood the inbox
+	existingCode, err := s.repo.GetVerificationCode(ctx, channel.ID)
+	if err != nil || time.Since(existingCode.CreatedAt) >= resendCooldown {
+		if err := s.sendVerificationCode(ctx, channel); err != nil {
+			slog.ErrorContext(ctx, "failed to send verification code", "channel_id", channel.ID, "error", err)
+		}
+	}
+
+	slog.InfoContext(ctx, "public subscription extended", "channel_id", channel.ID)
+	return token, nil
+}
+
+// VerifySubscription confirms a public subscription with the emailed code.
+// A pending token always needs the code, also for a verified channel.
+func (s *Service) VerifySubscription(ctx context.Context, token, inputCode string) (*PublicSubscription, error) {
+	st, channel, err := s.getSubscriberChannel(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !channel.IsVerified || !st.Confirmed {
+		if channel, err = s.verifyEmailCode(ctx, channel, inputCode); err != nil {
+			return nil, err
+		}
+	}
+	if !st.Confirmed {
+		if err := s.repo.ConfirmSubscriberToken(ctx, token); err != nil {
+			return nil, err
+		}
+	}
+
+	_, serviceIDs, err := s.repo.GetChannelSubscriptions(ctx, channel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublicSubscription{
+		Token:      token,
+		Email:      channel.Target,
+		ServiceIDs: serviceIDs,
+		IsVerified: channel.IsVerified,
+	}, nil
+}
+
+// Unsubscribe removes a public subscription together with its channel and token.
+func (s *Service) Unsubscribe(ctx context.Context, token string) error {
+	st, channel, err := s.getSubscriberChannel(ctx, token)
+	if err != nil {
+		return err
+	}
+	// A pending token manages nothing until its code is entered
+	if !st.Confirmed {
+		return ErrSubscriberTokenNotFound
+	}
+
+	if err := s.repo.DeleteChannel(ctx, channel.ID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "public subscription removed", "channel_id", channel.ID)
+	return nil
+}
+
+// getSubscriberChannel returns a subscriber token and the channel it belongs to.
+func (s *Service) getSubscriberChannel(ctx context.Context, token string) (*SubscriberToken, *domain.NotificationChannel, error) {
+	st, err := s.repo.GetSubscriberToken(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	channel, err := s.repo.GetChannelByID(ctx, st.ChannelID)
+	if errors.Is(err, ErrChannelNotFound) {
+		return nil, nil, ErrSubscriberTokenNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return st, channel, nil
+}
+
+// generateSubscriberToken generates a cryptographically secure subscriber token.
+func generateSubscriberToken() (string, error) {
+	b := make([]byte, subscriberTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate subscriber token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
 // OnUserCreated creates default email channel for newly registered user.
 // Implements identity.UserCreatedHandler interface.
 func (s *Service) OnUserCreated(ctx context.Context, user *domain.User) error {
 	if s.channelConfig != nil && !s.channelConfig.EmailEnabled {
-		slog.Warn("email channel disabled, skipping default channel creation",
+		slog.WarnContext(ctx, "email channel disabled, skipping default channel creation",
 			"user_id", user.ID,
 		)
 		return nil
@@ -478,7 +806,7 @@ func (s *Service) OnUserCreated(ctx context.Context, user *domain.User) error {
 	channel := &domain.NotificationChannel{
 		UserID:                 user.ID,
 		Type:                   domain.ChannelTypeEmail,
-		Target:                 user.Email,
+		Target:                 normalizeChannelTarget(domain.ChannelTypeEmail, user.Email),
 		IsEnabled:              true,
 		IsVerified:             true, // trusted — from registration
 		IsDefault:              true,
@@ -489,7 +817,7 @@ func (s *Service) OnUserCreated(ctx context.Context, user *domain.User) error {
 		return fmt.Errorf("create default email channel: %w", err)
 	}
 
-	slog.Info("created default email channel",
+	slog.InfoContext(ctx, "created default email channel",
 		"user_id", user.ID,
 		"channel_id", channel.ID,
 	)
@@ -0,0 +1,257 @@
+// Package slack provides Slack notification sending via Incoming Webhooks.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/notifications"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+)
+
+const (
+	defaultTimeout    = 10 * time.Second
+	defaultRetryAfter = 1 * time.Second
+
+	// maxHeaderLength is the Slack limit for plain_text in a header block.
+	maxHeaderLength = 150
+)
+
+// Config holds Slack sender configuration.
+// Note: webhook URL is stored in notification_channel.target,
+// so global configuration is minimal.
+// Like Mattermost, there is no Enabled flag - Slack sender
+// is always available since webhook URL is configured per-channel.
+type Config struct {
+	Timeout time.Duration // request timeout
+}
+
+// Sender implements Slack notification sender via Incoming Webhooks.
+type Sender struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// NewSender creates a new Slack sender.
+func NewSender(config Config) *Sender {
+	if config.Timeout == 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	return &Sender{
+		config: config,
+		httpClient: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: httputil.RequestIDTransport(nil),
+		},
+	}
+}
+
+// Type returns the channel type.
+func (s *Sender) Type() domain.ChannelType {
+	return domain.ChannelTypeSlack
+}
+
+// Send sends a notification to Slack.
+// notification.To contains the webhook URL.
+func (s *Sender) Send(ctx context.Context, notification notifications.Notification) error {
+	webhookURL := notification.To
+	if webhookURL == "" {
+		return &PermanentError{Message: "webhook URL is empty"}
+	}
+
+	body, err := json.Marshal(buildPayload(notification))
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return &RetryableError{Message: fmt.Sprintf("send request: %v", err)}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return s.handleResponse(resp, webhookURL)
+}
+
+// webhookPayload is a Slack Block Kit message.
+// Text is used as fallback for push notifications and clients without Block Kit support.
+type webhookPayload struct {
+	Text   string  `json:"text"`
+	Blocks []block `json:"blocks,omitempty"`
+}
+
+type block struct {
+	Type string     `json:"type"`
+	Text *textBlock `json:"text,omitempty"`
+}
+
+type textBlock struct {
+	Type  string `json:"type"`
+	Text  string `json:"text"`
+	Emoji bool   `json:"emoji,omitempty"`
+}
+
+// buildPayload mirrors the Mattermost message format:
+// subject as a header followed by the rendered body.
+func buildPayload(notification notifications.Notification) webhookPayload {
+	payload := webhookPayload{
+		Text: notification.Body,
+	}
+
+	if notification.Subject != "" {
+		payload.Text = fmt.Sprintf("%s\n\n%s", notification.Subject, notification.Body)
+		payload.Blocks = append(payload.Blocks, block{
+			Type: "header",
+			Text: &textBlock{
+				Type:  "plain_text",
+				Text:  truncate(notification.Subject, maxHeaderLength),
+				Emoji: true,
+			},
+		})
+	}
+
+	if notification.Body != "" {
+		payload.Blocks = append(payload.Blocks, block{
+			Type: "section",
+			Text: &textBlock{
+				Type: "mrkdwn",
+				Text: notification.Body,
+			},
+		})
+	}
+
+	return payload
+}
+
+func (s *Sender) handleResponse(resp *http.Response, webhookURL string) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		slog.Debug("slack message sent", "webhook", maskWebhookURL(webhookURL))
+		return nil
+
+	case http.StatusBadRequest:
+		return &PermanentError{
+			Code:    resp.StatusCode,
+			Message: fmt.Sprintf("bad request: %s", string(body)),
+		}
+
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &PermanentError{
+			Code:    resp.StatusCode,
+			Message: fmt.Sprintf("invalid or revoked webhook: %s", string(body)),
+		}
+
+	case http.StatusNotFound, http.StatusGone:
+		return &PermanentError{
+			Code:    resp.StatusCode,
+			Message: fmt.Sprintf("webhook not found: %s", string(body)),
+		}
+
+	case http.StatusTooManyRequests:
+		return &RateLimitError{
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			Message:    "rate limited",
+		}
+
+	default:
+		if resp.StatusCode >= 500 {
+			return &RetryableError{
+				Code:    resp.StatusCode,
+				Message: fmt.Sprintf("server error: %s", string(body)),
+			}
+		}
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+}
+
+// parseRetryAfter parses the Retry-After header value in seconds.
+// Falls back to defaultRetryAfter if the header is missing or invalid.
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return defaultRetryAfter
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// truncate shortens s to at most maxRunes characters.
+func truncate(s string, maxRunes int) string {
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+	return string(runes[:maxRunes-1]) + "…"
+}
+
+// maskWebhookURL hides part of the URL for logging.
+func maskWebhookURL(url string) string {
+	if len(url) > 40 {
+		return url[:20] + "..." + url[len(url)-10:]
+	}
+	return url
+}
+
+// RateLimitError indicates rate limit was exceeded.
+type RateLimitError struct {
+	RetryAfter time.Duration
+	Message    string
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("slack rate limited, retry after %v: %s", e.RetryAfter, e.Message)
+}
+
+// IsRetryable returns true as rate limit errors are temporary.
+func (e *RateLimitError) IsRetryable() bool { return true }
+
+// PermanentError indicates a permanent error that should not be retried.
+type PermanentError struct {
+	Code    int
+	Message string
+}
+
+func (e *PermanentError) Error() string {
+	if e.Code > 0 {
+		return fmt.Sprintf("slack error %d: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("slack error: %s", e.Message)
+}
+
+// IsRetryable returns false as permanent errors should not be retried.
+func (e *PermanentError) IsRetryable() bool { return false }
+
+// RetryableError indicates a temporary error that can be retried.
+type RetryableError struct {
+	Code    int
+	Message string
+}
+
+func (e *RetryableError) Error() string {
+	if e.Code > 0 {
+		return fmt.Sprintf("slack error %d: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("slack error: %s", e.Message)
+}
+
+// IsRetryable returns true as these errors are temporary.
+func (e *RetryableError) IsRetryable() bool { return true }
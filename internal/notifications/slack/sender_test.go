@@ -0,0 +1,265 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSender_Defaults(t *testing.T) {
+	sender := NewSender(Config{})
+
+	assert.Equal(t, defaultTimeout, sender.config.Timeout)
+	assert.NotNil(t, sender.httpClient)
+}
+
+func TestNewSender_CustomConfig(t *testing.T) {
+	sender := NewSender(Config{Timeout: 30 * time.Second})
+
+	assert.Equal(t, 30*time.Second, sender.config.Timeout)
+}
+
+func TestSender_Type(t *testing.T) {
+	sender := NewSender(Config{})
+	assert.Equal(t, domain.ChannelTypeSlack, sender.Type())
+}
+
+func TestSender_Send_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var payload webhookPayload
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		require.NoError(t, err)
+		assert.Equal(t, "Test message", payload.Text)
+		require.Len(t, payload.Blocks, 1)
+		assert.Equal(t, "section", payload.Blocks[0].Type)
+		assert.Equal(t, "mrkdwn", payload.Blocks[0].Text.Type)
+		assert.Equal(t, "Test message", payload.Blocks[0].Text.Text)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{})
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   server.URL,
+		Body: "Test message",
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestSender_Send_WithSubject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload webhookPayload
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Incident Alert\n\nService is down", payload.Text)
+		require.Len(t, payload.Blocks, 2)
+		assert.Equal(t, "header", payload.Blocks[0].Type)
+		assert.Equal(t, "plain_text", payload.Blocks[0].Text.Type)
+		assert.Equal(t, "Incident Alert", payload.Blocks[0].Text.Text)
+		assert.Equal(t, "section", payload.Blocks[1].Type)
+		assert.Equal(t, "Service is down", payload.Blocks[1].Text.Text)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{})
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:      server.URL,
+		Subject: "Incident Alert",
+		Body:    "Service is down",
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestSender_Send_LongSubjectTruncated(t *testing.T) {
+	subject := strings.Repeat("a", 200)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload webhookPayload
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		require.NoError(t, err)
+
+		require.NotEmpty(t, payload.Blocks)
+		assert.Len(t, []rune(payload.Blocks[0].Text.Text), maxHeaderLength)
+		assert.True(t, strings.HasPrefix(payload.Text, subject), "fallback text keeps full subject")
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{})
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:      server.URL,
+		Subject: subject,
+		Body:    "Body",
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestSender_Send_EmptyWebhook(t *testing.T) {
+	sender := NewSender(Config{})
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   "",
+		Body: "Test message",
+	})
+
+	require.Error(t, err)
+	var permErr *PermanentError
+	require.ErrorAs(t, err, &permErr)
+	assert.Contains(t, permErr.Message, "webhook URL is empty")
+	assert.False(t, permErr.IsRetryable())
+}
+
+func TestSender_Send_PermanentErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+	}{
+		{"bad request", http.StatusBadRequest, "invalid_payload", "bad request: invalid_payload"},
+		{"forbidden", http.StatusForbidden, "invalid_token", "invalid or revoked webhook"},
+		{"not found", http.StatusNotFound, "no_service", "webhook not found"},
+		{"gone", http.StatusGone, "channel_is_archived", "webhook not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			sender := NewSender(Config{})
+			err := sender.Send(context.Background(), notifications.Notification{
+				To:   server.URL,
+				Body: "Test message",
+			})
+
+			require.Error(t, err)
+			var permErr *PermanentError
+			require.ErrorAs(t, err, &permErr)
+			assert.Equal(t, tt.status, permErr.Code)
+			assert.Contains(t, permErr.Message, tt.wantMessage)
+			assert.False(t, permErr.IsRetryable())
+		})
+	}
+}
+
+func TestSender_Send_RateLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		want       time.Duration
+	}{
+		{"header present", "30", 30 * time.Second},
+		{"header missing", "", defaultRetryAfter},
+		{"header invalid", "soon", defaultRetryAfter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+			}))
+			defer server.Close()
+
+			sender := NewSender(Config{})
+			err := sender.Send(context.Background(), notifications.Notification{
+				To:   server.URL,
+				Body: "Test message",
+			})
+
+			require.Error(t, err)
+			var rateErr *RateLimitError
+			require.ErrorAs(t, err, &rateErr)
+			assert.Equal(t, tt.want, rateErr.RetryAfter)
+			assert.True(t, rateErr.IsRetryable())
+		})
+	}
+}
+
+func TestSender_Send_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("internal error"))
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{})
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   server.URL,
+		Body: "Test message",
+	})
+
+	require.Error(t, err)
+	var retryErr *RetryableError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, http.StatusInternalServerError, retryErr.Code)
+	assert.Contains(t, retryErr.Message, "server error")
+	assert.True(t, retryErr.IsRetryable())
+}
+
+func TestSender_Send_UnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte("I'm a teapot"))
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{})
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   server.URL,
+		Body: "Test message",
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status 418")
+}
+
+func TestSender_Send_NetworkError(t *testing.T) {
+	sender := NewSender(Config{
+		Timeout: 100 * time.Millisecond,
+	})
+
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   "http://localhost:59999", // Non-existent server
+		Body: "Test message",
+	})
+
+	require.Error(t, err)
+	var retryErr *RetryableError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Contains(t, retryErr.Message, "send request")
+	assert.True(t, retryErr.IsRetryable())
+}
+
+func TestErrors(t *testing.T) {
+	assert.Equal(t, "slack error 400: bad request", (&PermanentError{Code: 400, Message: "bad request"}).Error())
+	assert.Equal(t, "slack error: webhook URL is empty", (&PermanentError{Message: "webhook URL is empty"}).Error())
+	assert.Equal(t, "slack error 500: server error", (&RetryableError{Code: 500, Message: "server error"}).Error())
+	assert.Equal(t, "slack rate limited, retry after 5s: rate limited", (&RateLimitError{RetryAfter: 5 * time.Second, Message: "rate limited"}).Error())
+}
@@ -0,0 +1,49 @@
+package notifications
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// maskVisible is the number of characters kept at each end of a masked target.
+const maskVisible = 3
+
+// EventSubscriber is a channel that will be notified about an event, with its target masked.
+type EventSubscriber struct {
+	ChannelID    string             `json:"channel_id"`
+	ChannelType  domain.ChannelType `json:"channel_type"`
+	MaskedTarget string             `json:"masked_target"`
+	IsVerified   bool               `json:"is_verified"`
+}
+
+// ListEventSubscribers returns the channels snapshotted for an event when it was created
+// (plus those added with services later), so operators see who an update will reach.
+func (s *Service) ListEventSubscribers(ctx context.Context, eventID string) ([]EventSubscriber, error) {
+	channels, err := s.repo.ListEventSubscribers(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscribers := make([]EventSubscriber, 0, len(channels))
+	for _, ch := range channels {
+		subscribers = append(subscribers, EventSubscriber{
+			ChannelID:    ch.ID,
+			ChannelType:  ch.Type,
+			MaskedTarget: maskTarget(ch.Target),
+			IsVerified:   ch.IsVerified,
+		})
+	}
+	return subscribers, nil
+}
+
+// maskTarget keeps the first and last three characters of a target and hides the rest.
+// Targets too short to hide anything are masked completely.
+func maskTarget(target string) string {
+	runes := []rune(target)
+	if len(runes) <= 2*maskVisible {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:maskVisible]) + strings.Repeat("*", len(runes)-2*maskVisible) + string(runes[len(runes)-maskVisible:])
+}
@@ -0,0 +1,28 @@
+package notifications
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskTarget(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"user@example.com", "use**********com"},
+		{"https://hooks.slack.com/services/T000/B000/XXXX", "htt*****************************************XXX"},
+		{"1234567", "123*567"},
+		{"123456", "******"},
+		{"ab", "**"},
+		{"", ""},
+		{"пользователь", "пол******ель"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			assert.Equal(t, tt.want, maskTarget(tt.target))
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/notifications"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+)
+
+const (
+	defaultPollTimeout   = 30 * time.Second
+	defaultRetryInterval = 5 * time.Second
+	commandTimeout       = 10 * time.Second
+	maxStatusLength      = 4000 // bytes, below the 4096 characters limit of a message
+)
+
+// BotConfig holds Telegram bot command handler configuration.
+type BotConfig struct {
+	BotToken      string
+	APIUrl        string        // sendMessage URL template as in Config; the bot calls getUpdates next to it
+	PollTimeout   time.Duration // long polling timeout of getUpdates, default 30s
+	RetryInterval time.Duration // pause after a failed getUpdates, default 5s
+}
+
+// ChannelManager resolves chats to channels and manages their subscriptions
+// (implemented by notifications.Service).
+type ChannelManager interface {
+	GetTelegramChannel(ctx context.Context, chatID int64) (*domain.NotificationChannel, error)
+	SubscribeChannelToService(ctx context.Context, channel *domain.NotificationChannel, serviceID string) (bool, error)
+	UnsubscribeChannel(ctx context.Context, channel *domain.NotificationChannel) error
+}
+
+// ServiceCatalog looks up services (implemented by catalog.Service).
+type ServiceCatalog interface {
+	GetServiceBySlug(ctx context.Context, slug string) (*domain.Service, error)
+	ListServicesWithEffectiveStatus(ctx context.Context, filter catalog.ServiceFilter) ([]domain.ServiceWithEffectiveStatus, error)
+}
+
+// Bot answers /status, /subscribe <service-slug> and /unsubscribe commands,
+// receiving them via getUpdates long polling. Replies are sent with the Sender.
+// Chats are matched to users by their verified Telegram channel.
+type Bot struct {
+	config     BotConfig
+	channels   ChannelManager
+	services   ServiceCatalog
+	sender     notifications.Sender
+	httpClient *http.Client
+	updatesURL string
+	offset     int64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewBot creates a new Telegram bot command handler.
+func NewBot(config BotConfig, channels ChannelManager, services ServiceCatalog, sender notifications.Sender) *Bot {
+	if config.PollTimeout <= 0 {
+		config.PollTimeout = defaultPollTimeout
+	}
+	if config.RetryInterval <= 0 {
+		config.RetryInterval = defaultRetryInterval
+	}
+	apiURL := defaultAPIURL
+	if config.APIUrl != "" {
+		apiURL = config.APIUrl
+	}
+
+	return &Bot{
+		config:   config,
+		channels: channels,
+		services: services,
+		sender:   sender,
+		httpClient: &http.Client{
+			Timeout:   config.PollTimeout + requestTimeout,
+			Transport: httputil.RequestIDTransport(nil),
+		},
+		updatesURL: methodURL(apiURL, "getUpdates"),
+	}
+}
+
+// methodURL replaces the method in the last path segment of a Bot API URL template.
+func methodURL(apiURL, method string) string {
+	if i := strings.LastIndex(apiURL, "/"); i >= 0 {
+		return apiURL[:i+1] + method
+	}
+	return apiURL
+}
+
+// Start launches the polling goroutine.
+func (b *Bot) Start(ctx context.Context) {
+	slog.InfoContext(ctx, "starting telegram bot", "poll_timeout", b.config.PollTimeout)
+
+	ctx, b.cancel = context.WithCancel(ctx)
+	b.wg.Add(1)
+	go b.run(ctx)
+}
+
+// Stop stops polling, interrupting a pending getUpdates, and waits for the
+// command in progress. Updates not confirmed yet are delivered again on restart.
+func (b *Bot) Stop() {
+	if b.cancel != nil {
+		b.cancel()
+	}
+	b.wg.Wait()
+	slog.Info("telegram bot stopped")
+}
+
+func (b *Bot) run(ctx context.Context) {
+	defer b.wg.Done()
+
+	for {
+		updates, err := b.getUpdates(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to get telegram updates", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(b.config.RetryInterval):
+			}
+			continue
+		}
+
+		for _, u := range updates {
+			// Confirmed with the next getUpdates
+			b.offset = u.UpdateID + 1
+			if u.Message != nil {
+				b.handleMessage(ctx, u.Message)
+			}
+		}
+	}
+}
+
+type getUpdatesRequest struct {
+	Offset         int64    `json:"offset,omitempty"`
+	Timeout        int      `json:"timeout"`
+	AllowedUpdates []string `json:"allowed_updates"`
+}
+
+type getUpdatesResponse struct {
+	OK          bool     `json:"ok"`
+	Description string   `json:"description,omitempty"`
+	Result      []update `json:"result"`
+}
+
+type update struct {
+	UpdateID int64    `json:"update_id"`
+	Message  *message `json:"message,omitempty"`
+}
+
+type message struct {
+	Chat struct {
+		ID int64 `json:"id"`
+	} `json:"chat"`
+	Text string `json:"text"`
+}
+
+func (b *Bot) getUpdates(ctx context.Context) ([]update, error) {
+	body, err := json.Marshal(getUpdatesRequest{
+		Offset:         b.offset,
+		Timeout:        int(b.config.PollTimeout.Seconds()),
+		AllowedUpdates: []string{"message"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf(b.updatesURL, b.config.BotToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result getUpdatesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if !result.OK {
+		return nil, fmt.Errorf("telegram error %d: %s", resp.StatusCode, result.Description)
+	}
+	return result.Result, nil
+}
+
+// handleMessage runs a command and replies to the chat. Other messages get the help text.
+func (b *Bot) handleMessage(ctx context.Context, msg *message) {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	reply := b.runCommand(ctx, msg.Chat.ID, msg.Text)
+
+	err := b.sender.Send(ctx, notifications.Notification{
+		To:   strconv.FormatInt(msg.Chat.ID, 10),
+		Body: reply,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to reply to telegram command", "chat_id", msg.Chat.ID, "error", err)
+	}
+}
+
+const helpText = `Commands:
+/status - current status of all services
+/subscribe &lt;service-slug&gt; - notify this chat about a service
+/unsubscribe - stop notifications to this chat`
+
+const notLinkedText = "This chat is not linked to an account. " +
+	"Add it as a Telegram channel with chat ID <code>%d</code> in your notification settings and verify it."
+
+func (b *Bot) runCommand(ctx context.Context, chatID int64, text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return helpText
+	}
+	// Commands in groups may be addressed as /command@BotName
+	command, _, _ := strings.Cut(fields[0], "@")
+	args := fields[1:]
+
+	var (
+		reply string
+		err   error
+	)
+	switch command {
+	case "/status":
+		reply, err = b.status(ctx)
+	case "/subscribe":
+		if len(args) != 1 {
+			return "Usage: /subscribe &lt;service-slug&gt;"
+		}
+		reply, err = b.subscribe(ctx, chatID, args[0])
+	case "/unsubscribe":
+		reply, err = b.unsubscribe(ctx, chatID)
+	default:
+		return helpText
+	}
+
+	switch {
+	case errors.Is(err, notifications.ErrTelegramChatNotLinked):
+		return fmt.Sprintf(notLinkedText, chatID)
+	case err != nil:
+		slog.ErrorContext(ctx, "telegram command failed", "command", command, "chat_id", chatID, "error", err)
+		return "Something went wrong, please try again later."
+	}
+	return reply
+}
+
+func (b *Bot) status(ctx context.Context) (string, error) {
+	services, err := b.services.ListServicesWithEffectiveStatus(ctx, catalog.ServiceFilter{})
+	if err != nil {
+		return "", err
+	}
+	if len(services) == 0 {
+		return "No services.", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<b>Service status</b>")
+	for i, s := range services {
+		line := fmt.Sprintf("\n%s: %s", html.EscapeString(s.Name), strings.ReplaceAll(string(s.EffectiveStatus), "_", " "))
+		if sb.Len()+len(line) > maxStatusLength {
+			fmt.Fprintf(&sb, "\n… and %d more", len(services)-i)
+			break
+		}
+		sb.WriteString(line)
+	}
+	return sb.String(), nil
+}
+
+func (b *Bot) subscribe(ctx context.Context, chatID int64, slug string) (string, error) {
+	channel, err := b.channels.GetTelegramChannel(ctx, chatID)
+	if err != nil {
+		return "", err
+	}
+
+	service, err := b.services.GetServiceBySlug(ctx, slug)
+	if errors.Is(err, catalog.ErrServiceNotFound) {
+		return fmt.Sprintf("Service <code>%s</code> not found.", html.EscapeString(slug)), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	added, err := b.channels.SubscribeChannelToService(ctx, channel, service.ID)
+	if err != nil {
+		return "", err
+	}
+	if !added {
+		return fmt.Sprintf("Already subscribed to %s.", html.EscapeString(service.Name)), nil
+	}
+	return fmt.Sprintf("Subscribed to %s.", html.EscapeString(service.Name)), nil
+}
+
+func (b *Bot) unsubscribe(ctx context.Context, chatID int64) (string, error) {
+	channel, err := b.channels.GetTelegramChannel(ctx, chatID)
+	if err != nil {
+		return "", err
+	}
+	if err := b.channels.UnsubscribeChannel(ctx, channel); err != nil {
+		return "", err
+	}
+	return "Unsubscribed from all services.", nil
+}
@@ -0,0 +1,264 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubChannels struct {
+	channel      *domain.NotificationChannel // nil: chat not linked
+	subscribed   []string
+	unsubscribed bool
+}
+
+func (s *stubChannels) GetTelegramChannel(_ context.Context, _ int64) (*domain.NotificationChannel, error) {
+	if s.channel == nil {
+		return nil, notifications.ErrTelegramChatNotLinked
+	}
+	return s.channel, nil
+}
+
+func (s *stubChannels) SubscribeChannelToService(_ context.Context, _ *domain.NotificationChannel, serviceID string) (bool, error) {
+	for _, id := range s.subscribed {
+		if id == serviceID {
+			return false, nil
+		}
+	}
+	s.subscribed = append(s.subscribed, serviceID)
+	return true, nil
+}
+
+func (s *stubChannels) UnsubscribeChannel(_ context.Context, _ *domain.NotificationChannel) error {
+	s.unsubscribed = true
+	return nil
+}
+
+type stubCatalog struct {
+	services []domain.ServiceWithEffectiveStatus
+	err      error
+}
+
+func (s *stubCatalog) GetServiceBySlug(_ context.Context, slug string) (*domain.Service, error) {
+	for _, svc := range s.services {
+		if svc.Slug == slug {
+			return &svc.Service, nil
+		}
+	}
+	return nil, catalog.ErrServiceNotFound
+}
+
+func (s *stubCatalog) ListServicesWithEffectiveStatus(_ context.Context, _ catalog.ServiceFilter) ([]domain.ServiceWithEffectiveStatus, error) {
+	return s.services, s.err
+}
+
+func testCatalog() *stubCatalog {
+	return &stubCatalog{services: []domain.ServiceWithEffectiveStatus{
+		{Service: domain.Service{ID: "svc-api", Name: "API", Slug: "api"}, EffectiveStatus: domain.ServiceStatusOperational},
+		{Service: domain.Service{ID: "svc-db", Name: "Billing <DB>", Slug: "db"}, EffectiveStatus: domain.ServiceStatusPartialOutage},
+	}}
+}
+
+func TestMethodURL(t *testing.T) {
+	assert.Equal(t, "https://api.telegram.org/bot%s/getUpdates", methodURL(defaultAPIURL, "getUpdates"))
+	assert.Equal(t, "http://127.0.0.1:8080/bot%s/getUpdates", methodURL("http://127.0.0.1:8080/bot%s/sendMessage", "getUpdates"))
+}
+
+func TestBot_RunCommand(t *testing.T) {
+	linked := &domain.NotificationChannel{ID: "ch-1", UserID: "user-1"}
+
+	tests := []struct {
+		name     string
+		channel  *domain.NotificationChannel
+		text     string
+		want     string
+		contains []string
+	}{
+		{name: "status", text: "/status", contains: []string{"API: operational", "Billing &lt;DB&gt;: partial outage"}},
+		{name: "status addressed to the bot", text: "/status@StatusBot", contains: []string{"API: operational"}},
+		{name: "subscribe", channel: linked, text: "/subscribe api", want: "Subscribed to API."},
+		{name: "subscribe unknown service", channel: linked, text: "/subscribe <nope>", want: "Service <code>&lt;nope&gt;</code> not found."},
+		{name: "subscribe without slug", channel: linked, text: "/subscribe", want: "Usage: /subscribe &lt;service-slug&gt;"},
+		{name: "subscribe not linked", text: "/subscribe api", contains: []string{"not linked", "<code>42</code>"}},
+		{name: "unsubscribe", channel: linked, text: "/unsubscribe", want: "Unsubscribed from all services."},
+		{name: "unsubscribe not linked", text: "/unsubscribe", contains: []string{"not linked"}},
+		{name: "unknown command", text: "/start", want: helpText},
+		{name: "plain text", text: "hello", want: helpText},
+		{name: "empty", text: "", want: helpText},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot := NewBot(BotConfig{}, &stubChannels{channel: tt.channel}, testCatalog(), nil)
+
+			reply := bot.runCommand(context.Background(), 42, tt.text)
+			if tt.want != "" {
+				assert.Equal(t, tt.want, reply)
+			}
+			for _, s := range tt.contains {
+				assert.Contains(t, reply, s)
+			}
+		})
+	}
+}
+
+func TestBot_Subscribe_Twice(t *testing.T) {
+	channels := &stubChannels{channel: &domain.NotificationChannel{ID: "ch-1", UserID: "user-1"}}
+	bot := NewBot(BotConfig{}, channels, testCatalog(), nil)
+
+	assert.Equal(t, "Subscribed to API.", bot.runCommand(context.Background(), 42, "/subscribe api"))
+	assert.Equal(t, "Already subscribed to API.", bot.runCommand(context.Background(), 42, "/subscribe api"))
+	assert.Equal(t, []string{"svc-api"}, channels.subscribed)
+}
+
+func TestBot_RunCommand_Error(t *testing.T) {
+	bot := NewBot(BotConfig{}, &stubChannels{}, &stubCatalog{err: errors.New("db down")}, nil)
+
+	reply := bot.runCommand(context.Background(), 42, "/status")
+	assert.Contains(t, reply, "Something went wrong")
+	assert.NotContains(t, reply, "db down")
+}
+
+// mockBotAPI serves getUpdates from a queue of updates and records sendMessage calls.
+// Once the queue is drained, getUpdates waits for the request to be cancelled, like long polling does.
+type mockBotAPI struct {
+	mu      sync.Mutex
+	updates []update
+	offsets []int64
+	sent    []sendMessageRequest
+}
+
+func (m *mockBotAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/bottest-token/getUpdates":
+		var req getUpdatesRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		m.mu.Lock()
+		m.offsets = append(m.offsets, req.Offset)
+		updates := m.updates
+		m.updates = nil
+		m.mu.Unlock()
+
+		if len(updates) == 0 {
+			<-r.Context().Done()
+			return
+		}
+		_ = json.NewEncoder(w).Encode(getUpdatesResponse{OK: true, Result: updates})
+	case "/bottest-token/sendMessage":
+		var req sendMessageRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		m.mu.Lock()
+		m.sent = append(m.sent, req)
+		m.mu.Unlock()
+
+		_ = json.NewEncoder(w).Encode(telegramResponse{OK: true})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (m *mockBotAPI) sentMessages() []sendMessageRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]sendMessageRequest(nil), m.sent...)
+}
+
+func textUpdate(id, chatID int64, text string) update {
+	msg := &message{Text: text}
+	msg.Chat.ID = chatID
+	return update{UpdateID: id, Message: msg}
+}
+
+func TestBot_StartStop(t *testing.T) {
+	api := &mockBotAPI{updates: []update{
+		textUpdate(10, 42, "/status"),
+		{UpdateID: 11}, // not a message
+		textUpdate(12, 43, "/unsubscribe"),
+	}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	apiURL := server.URL + "/bot%s/sendMessage"
+	sender, err := NewSender(Config{Enabled: true, BotToken: "test-token", APIUrl: apiURL})
+	require.NoError(t, err)
+	bot := NewBot(BotConfig{BotToken: "test-token", APIUrl: apiURL},
+		&stubChannels{channel: &domain.NotificationChannel{ID: "ch-1"}}, testCatalog(), sender)
+
+	bot.Start(context.Background())
+
+	require.Eventually(t, func() bool { return len(api.sentMessages()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	sent := api.sentMessages()
+	assert.Equal(t, "42", sent[0].ChatID)
+	assert.Contains(t, sent[0].Text, "API: operational")
+	assert.Equal(t, "HTML", sent[0].ParseMode)
+	assert.Equal(t, "43", sent[1].ChatID)
+	assert.Equal(t, "Unsubscribed from all services.", sent[1].Text)
+
+	// Stop interrupts the pending long poll
+	stopped := make(chan struct{})
+	go func() {
+		bot.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop did not interrupt getUpdates")
+	}
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	assert.Equal(t, []int64{0, 13}, api.offsets, "processed updates are confirmed with the next offset")
+}
+
+func TestBot_RetryAfterError(t *testing.T) {
+	var calls int
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(getUpdatesResponse{OK: false, Description: "Unauthorized"})
+	}))
+	defer server.Close()
+
+	bot := NewBot(BotConfig{BotToken: "test-token", APIUrl: server.URL + "/bot%s/sendMessage", RetryInterval: 20 * time.Millisecond},
+		&stubChannels{}, testCatalog(), nil)
+	bot.Start(context.Background())
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls >= 2
+	}, 5*time.Second, 10*time.Millisecond, "getUpdates is retried")
+	bot.Stop()
+}
+
+func TestBot_Status_Truncated(t *testing.T) {
+	services := make([]domain.ServiceWithEffectiveStatus, 500)
+	for i := range services {
+		services[i] = domain.ServiceWithEffectiveStatus{
+			Service:         domain.Service{Name: "Service with a long enough name"},
+			EffectiveStatus: domain.ServiceStatusOperational,
+		}
+	}
+	bot := NewBot(BotConfig{}, &stubChannels{}, &stubCatalog{services: services}, nil)
+
+	reply := bot.runCommand(context.Background(), 42, "/status")
+	assert.LessOrEqual(t, len(reply), maxStatusLength+len("\n… and 500 more"))
+	assert.Regexp(t, `… and \d+ more$`, reply)
+}
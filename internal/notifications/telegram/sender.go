@@ -13,6 +13,7 @@ import (
 
 	"github.com/bissquit/incident-garden/internal/domain"
 	"github.com/bissquit/incident-garden/internal/notifications"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
 	"golang.org/x/time/rate"
 )
 
@@ -66,7 +67,8 @@ func NewSender(config Config) (*Sender, error) {
 	return &Sender{
 		config: config,
 		httpClient: &http.Client{
-			Timeout: requestTimeout,
+			Timeout:   requestTimeout,
+			Transport: httputil.RequestIDTransport(nil),
 		},
 		limiter: rate.NewLimiter(rate.Limit(rateLimit), defaultBurstSize),
 		apiURL:  apiURL,
@@ -81,7 +83,7 @@ func (s *Sender) Type() domain.ChannelType {
 // Send sends a Telegram notification.
 func (s *Sender) Send(ctx context.Context, notification notifications.Notification) error {
 	if !s.config.Enabled {
-		slog.Warn("telegram sender disabled, skipping send",
+		slog.WarnContext(ctx, "telegram sender disabled, skipping send",
 			"to", notification.To,
 		)
 		return nil
@@ -59,3 +59,23 @@ func TestConstantTimeCompare_Timing(t *testing.T) {
 		t.Error("different length codes should not match")
 	}
 }
+
+var subscriberTokenPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+func TestGenerateSubscriberToken(t *testing.T) {
+	tokens := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		token, err := generateSubscriberToken()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !subscriberTokenPattern.MatchString(token) {
+			t.Errorf("token does not match hex pattern: %s", token)
+		}
+		tokens[token] = true
+	}
+
+	if len(tokens) != 100 {
+		t.Errorf("expected 100 unique tokens, got %d", len(tokens))
+	}
+}
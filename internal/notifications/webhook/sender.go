@@ -0,0 +1,236 @@
+// Package webhook provides generic outgoing webhook notifications (raw JSON over HTTP POST).
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/notifications"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+)
+
+const (
+	defaultTimeout        = 10 * time.Second
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = 500 * time.Millisecond
+
+	// SignatureHeader carries the HMAC-SHA256 signature of the request body.
+	SignatureHeader = "X-Signature-256"
+
+	// maxErrorBodyLength limits how much of the response body is kept in error messages.
+	maxErrorBodyLength = 512
+)
+
+// Config holds webhook sender configuration.
+// Endpoint URL and signing secret are stored per-channel,
+// so there is no Enabled flag - webhook sender is always available.
+type Config struct {
+	Timeout        time.Duration // per-request timeout
+	MaxAttempts    int           // total attempts per Send, including the first one
+	InitialBackoff time.Duration // delay before the first retry, doubled on each next retry
+}
+
+// Sender implements outgoing webhook notification sender.
+type Sender struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// NewSender creates a new webhook sender.
+func NewSender(config Config) *Sender {
+	if config.Timeout == 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+	if config.InitialBackoff == 0 {
+		config.InitialBackoff = defaultInitialBackoff
+	}
+
+	return &Sender{
+		config: config,
+		httpClient: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: httputil.RequestIDTransport(nil),
+		},
+	}
+}
+
+// Type returns the channel type.
+func (s *Sender) Type() domain.ChannelType {
+	return domain.ChannelTypeWebhook
+}
+
+// Send posts notification.Body (a JSON document built by the renderer) to the webhook URL.
+// notification.To contains the URL, notification.Secret the optional signing secret.
+// 5xx responses are retried with exponential backoff up to MaxAttempts.
+func (s *Sender) Send(ctx context.Context, notification notifications.Notification) error {
+	if notification.To == "" {
+		return &PermanentError{Message: "webhook URL is empty"}
+	}
+
+	body := []byte(notification.Body)
+	backoff := s.config.InitialBackoff
+
+	var lastErr error
+	for attempt := 1; attempt <= s.config.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return &RetryableError{Message: fmt.Sprintf("context done: %v", ctx.Err())}
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		statusCode, respBody, err := s.post(ctx, notification.To, notification.Secret, body)
+		if err != nil {
+			return &RetryableError{Message: fmt.Sprintf("send request: %v", err)}
+		}
+
+		lastErr = classifyResponse(statusCode, respBody)
+		if lastErr == nil {
+			slog.DebugContext(ctx, "webhook delivered", "url", maskURL(notification.To), "attempt", attempt)
+			return nil
+		}
+		if statusCode < 500 {
+			return lastErr
+		}
+
+		slog.DebugContext(ctx, "webhook server error, retrying",
+			"url", maskURL(notification.To),
+			"attempt", attempt,
+			"max_attempts", s.config.MaxAttempts,
+			"status", statusCode,
+		)
+	}
+
+	return lastErr
+}
+
+// Verify sends a single test POST and requires HTTP 200 in response.
+// Unlike Send, it never retries so the user gets immediate feedback.
+func (s *Sender) Verify(ctx context.Context, notification notifications.Notification) error {
+	if notification.To == "" {
+		return &PermanentError{Message: "webhook URL is empty"}
+	}
+
+	body, err := json.Marshal(notifications.WebhookMessage{
+		NotificationType: notifications.WebhookNotificationVerification,
+		Subject:          notification.Subject,
+		Message:          notification.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	statusCode, respBody, err := s.post(ctx, notification.To, notification.Secret, body)
+	if err != nil {
+		return &RetryableError{Message: fmt.Sprintf("send request: %v", err)}
+	}
+	if statusCode != http.StatusOK {
+		return &PermanentError{
+			Code:    statusCode,
+			Message: fmt.Sprintf("verification expects status 200: %s", respBody),
+		}
+	}
+	return nil
+}
+
+// post performs a single signed POST request and returns status code and (truncated) body.
+func (s *Sender) post(ctx context.Context, url, secret string, body []byte) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "IncidentGarden-Webhook")
+	if secret != "" {
+		req.Header.Set(SignatureHeader, Sign(secret, body))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+	if err != nil {
+		return 0, "", fmt.Errorf("read response: %w", err)
+	}
+
+	return resp.StatusCode, string(respBody), nil
+}
+
+// classifyResponse maps an HTTP status to a sender error (nil on 2xx).
+func classifyResponse(statusCode int, body string) error {
+	switch {
+	case statusCode >= 200 && statusCode < 300:
+		return nil
+	case statusCode == http.StatusTooManyRequests:
+		return &RetryableError{Code: statusCode, Message: "rate limited"}
+	case statusCode >= 500:
+		return &RetryableError{Code: statusCode, Message: fmt.Sprintf("server error: %s", body)}
+	default:
+		return &PermanentError{Code: statusCode, Message: fmt.Sprintf("rejected: %s", body)}
+	}
+}
+
+// Sign returns the X-Signature-256 header value for body: "sha256=<hex hmac>".
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// maskURL hides part of the URL for logging.
+func maskURL(url string) string {
+	if len(url) > 40 {
+		return url[:20] + "..." + url[len(url)-10:]
+	}
+	return url
+}
+
+// PermanentError indicates a permanent error that should not be retried.
+type PermanentError struct {
+	Code    int
+	Message string
+}
+
+func (e *PermanentError) Error() string {
+	if e.Code > 0 {
+		return fmt.Sprintf("webhook error %d: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("webhook error: %s", e.Message)
+}
+
+// IsRetryable returns false as permanent errors should not be retried.
+func (e *PermanentError) IsRetryable() bool { return false }
+
+// RetryableError indicates a temporary error that can be retried.
+type RetryableError struct {
+	Code    int
+	Message string
+}
+
+func (e *RetryableError) Error() string {
+	if e.Code > 0 {
+		return fmt.Sprintf("webhook error %d: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("webhook error: %s", e.Message)
+}
+
+// IsRetryable returns true as these errors are temporary.
+func (e *RetryableError) IsRetryable() bool { return true }
@@ -0,0 +1,320 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSender(maxAttempts int) *Sender {
+	return NewSender(Config{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: time.Millisecond,
+	})
+}
+
+func TestNewSender_Defaults(t *testing.T) {
+	sender := NewSender(Config{})
+
+	assert.Equal(t, defaultTimeout, sender.config.Timeout)
+	assert.Equal(t, defaultMaxAttempts, sender.config.MaxAttempts)
+	assert.Equal(t, defaultInitialBackoff, sender.config.InitialBackoff)
+	assert.NotNil(t, sender.httpClient)
+}
+
+func TestNewSender_CustomConfig(t *testing.T) {
+	sender := NewSender(Config{
+		Timeout:        30 * time.Second,
+		MaxAttempts:    5,
+		InitialBackoff: 2 * time.Second,
+	})
+
+	assert.Equal(t, 30*time.Second, sender.config.Timeout)
+	assert.Equal(t, 5, sender.config.MaxAttempts)
+	assert.Equal(t, 2*time.Second, sender.config.InitialBackoff)
+}
+
+func TestSender_Type(t *testing.T) {
+	sender := NewSender(Config{})
+	assert.Equal(t, domain.ChannelTypeWebhook, sender.Type())
+}
+
+func TestSender_Send_Success(t *testing.T) {
+	body := `{"notification_type":"event_created","id":"evt-1"}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Empty(t, r.Header.Get(SignatureHeader), "no signature without secret")
+
+		got, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, body, string(got))
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sender := newTestSender(3)
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   server.URL,
+		Body: body,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestSender_Send_Signed(t *testing.T) {
+	const secret = "s3cr3t"
+	body := `{"notification_type":"event_updated"}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, Sign(secret, got), r.Header.Get(SignatureHeader))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := newTestSender(3)
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:     server.URL,
+		Body:   body,
+		Secret: secret,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestSign(t *testing.T) {
+	// Reference value: echo -n '{"a":1}' | openssl dgst -sha256 -hmac key
+	assert.Equal(t,
+		"sha256=88a67f24bbcdaed0e6c997404bb79a743baf44c6bab2f4c27328e3009d22e342",
+		Sign("key", []byte(`{"a":1}`)),
+	)
+}
+
+func TestSender_Send_EmptyURL(t *testing.T) {
+	sender := newTestSender(3)
+	err := sender.Send(context.Background(), notifications.Notification{
+		Body: "{}",
+	})
+
+	require.Error(t, err)
+	var permErr *PermanentError
+	require.ErrorAs(t, err, &permErr)
+	assert.Contains(t, permErr.Message, "webhook URL is empty")
+	assert.False(t, permErr.IsRetryable())
+}
+
+func TestSender_Send_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := newTestSender(3)
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   server.URL,
+		Body: "{}",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestSender_Send_MaxAttemptsExceeded(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("maintenance"))
+	}))
+	defer server.Close()
+
+	sender := newTestSender(2)
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   server.URL,
+		Body: "{}",
+	})
+
+	require.Error(t, err)
+	var retryErr *RetryableError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, http.StatusServiceUnavailable, retryErr.Code)
+	assert.Contains(t, retryErr.Message, "maintenance")
+	assert.True(t, retryErr.IsRetryable())
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestSender_Send_ClientErrorNotRetried(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("bad signature"))
+	}))
+	defer server.Close()
+
+	sender := newTestSender(3)
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   server.URL,
+		Body: "{}",
+	})
+
+	require.Error(t, err)
+	var permErr *PermanentError
+	require.ErrorAs(t, err, &permErr)
+	assert.Equal(t, http.StatusUnauthorized, permErr.Code)
+	assert.Contains(t, permErr.Message, "bad signature")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestSender_Send_RateLimitedNotRetriedInSender(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	sender := newTestSender(3)
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   server.URL,
+		Body: "{}",
+	})
+
+	require.Error(t, err)
+	var retryErr *RetryableError
+	require.ErrorAs(t, err, &retryErr)
+	assert.True(t, retryErr.IsRetryable(), "worker retries rate-limited items")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestSender_Send_ContextCancelledDuringBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{MaxAttempts: 3, InitialBackoff: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := sender.Send(ctx, notifications.Notification{
+		To:   server.URL,
+		Body: "{}",
+	})
+
+	require.Error(t, err)
+	var retryErr *RetryableError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Contains(t, retryErr.Message, "context done")
+}
+
+func TestSender_Send_NetworkError(t *testing.T) {
+	sender := NewSender(Config{
+		Timeout: 100 * time.Millisecond,
+	})
+
+	err := sender.Send(context.Background(), notifications.Notification{
+		To:   "http://localhost:59999", // Non-existent server
+		Body: "{}",
+	})
+
+	require.Error(t, err)
+	var retryErr *RetryableError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Contains(t, retryErr.Message, "send request")
+	assert.True(t, retryErr.IsRetryable())
+}
+
+func TestSender_Verify_Success(t *testing.T) {
+	const secret = "verify-secret"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, Sign(secret, got), r.Header.Get(SignatureHeader))
+
+		var msg notifications.WebhookMessage
+		require.NoError(t, json.Unmarshal(got, &msg))
+		assert.Equal(t, notifications.WebhookNotificationVerification, msg.NotificationType)
+		assert.Equal(t, "Channel Verification", msg.Subject)
+		assert.Equal(t, "test message", msg.Message)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := newTestSender(3)
+	err := sender.Verify(context.Background(), notifications.Notification{
+		To:      server.URL,
+		Subject: "Channel Verification",
+		Body:    "test message",
+		Secret:  secret,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestSender_Verify_RequiresStatus200(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{"no content", http.StatusNoContent},
+		{"server error", http.StatusInternalServerError},
+		{"not found", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			sender := newTestSender(3)
+			err := sender.Verify(context.Background(), notifications.Notification{
+				To: server.URL,
+			})
+
+			require.Error(t, err)
+			var permErr *PermanentError
+			require.ErrorAs(t, err, &permErr)
+			assert.Equal(t, tt.status, permErr.Code)
+			assert.Equal(t, int32(1), calls.Load(), "verification is a single request")
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	assert.Equal(t, "webhook error 400: rejected", (&PermanentError{Code: 400, Message: "rejected"}).Error())
+	assert.Equal(t, "webhook error: webhook URL is empty", (&PermanentError{Message: "webhook URL is empty"}).Error())
+	assert.Equal(t, "webhook error 500: server error", (&RetryableError{Code: 500, Message: "server error"}).Error())
+	assert.Equal(t, "webhook error: send request", (&RetryableError{Message: "send request"}).Error())
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
 )
 
 // WorkerConfig contains worker configuration.
@@ -19,8 +21,14 @@ type WorkerConfig struct {
 	MaxBackoff        time.Duration
 	BackoffMultiplier float64
 	NumWorkers        int
+	// ShutdownTimeout is how long Stop waits for in-flight sends before interrupting them;
+	// 0 interrupts them right away.
+	ShutdownTimeout time.Duration
 }
 
+// releaseTimeout bounds returning unfinished notifications to the queue on Stop.
+const releaseTimeout = 5 * time.Second
+
 // DefaultWorkerConfig returns default worker configuration.
 func DefaultWorkerConfig() WorkerConfig {
 	return WorkerConfig{
@@ -31,6 +39,7 @@ func DefaultWorkerConfig() WorkerConfig {
 		MaxBackoff:        5 * time.Minute,
 		BackoffMultiplier: 2.0,
 		NumWorkers:        5,
+		ShutdownTimeout:   5 * time.Second,
 	}
 }
 
@@ -43,42 +52,136 @@ type Worker struct {
 
 	stopCh chan struct{}
 	wg     sync.WaitGroup
+	active atomic.Int32 // worker goroutines currently running
+
+	// sendCtx outlives the Start context so that in-flight sends finish on Stop;
+	// cancelSends interrupts them once ShutdownTimeout is over.
+	sendCtx     context.Context
+	cancelSends context.CancelFunc
+
+	mu      sync.Mutex
+	claimed map[string]struct{} // fetched (processing) items not finished yet
 }
 
 // NewWorker creates a new notification worker.
 func NewWorker(config WorkerConfig, repo Repository, dispatcher *Dispatcher, renderer *Renderer) *Worker {
+	sendCtx, cancelSends := context.WithCancel(context.Background())
 	return &Worker{
-		config:     config,
-		repo:       repo,
-		dispatcher: dispatcher,
-		renderer:   renderer,
-		stopCh:     make(chan struct{}),
+		config:      config,
+		repo:        repo,
+		dispatcher:  dispatcher,
+		renderer:    renderer,
+		stopCh:      make(chan struct{}),
+		sendCtx:     sendCtx,
+		cancelSends: cancelSends,
+		claimed:     make(map[string]struct{}),
 	}
 }
 
 // Start launches worker goroutines.
 func (w *Worker) Start(ctx context.Context) {
-	slog.Info("starting notification worker",
+	slog.InfoContext(ctx, "starting notification worker",
 		"workers", w.config.NumWorkers,
 		"batch_size", w.config.BatchSize,
 		"poll_interval", w.config.PollInterval,
 	)
 
+	// Cancelling ctx stops fetching, not the sends in progress
+	w.sendCtx, w.cancelSends = context.WithCancel(context.WithoutCancel(ctx))
+
 	for i := 0; i < w.config.NumWorkers; i++ {
 		w.wg.Add(1)
+		w.active.Add(1)
 		go w.run(ctx, i)
 	}
 }
 
-// Stop gracefully stops all workers.
+// Running reports whether worker goroutines are processing the queue,
+// i.e. Start was called and neither Stop nor context cancellation ended them.
+func (w *Worker) Running() bool {
+	return w.active.Load() > 0
+}
+
+// Stop gracefully stops all workers: no new notifications are taken, in-flight
+// sends get up to ShutdownTimeout to finish and are interrupted after that.
+// Notifications left in processing are returned to pending.
 func (w *Worker) Stop() {
 	close(w.stopCh)
-	w.wg.Wait()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(w.config.ShutdownTimeout):
+		slog.Warn("notification worker shutdown timed out, interrupting in-flight sends",
+			"timeout", w.config.ShutdownTimeout)
+		w.cancelSends()
+		<-done
+	}
+	w.cancelSends()
+
+	w.releaseClaimed()
 	slog.Info("notification worker stopped")
 }
 
+// stopping reports whether the worker should not take new notifications.
+func (w *Worker) stopping(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	case <-w.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *Worker) claim(items []*QueueItem) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, item := range items {
+		w.claimed[item.ID] = struct{}{}
+	}
+}
+
+func (w *Worker) unclaim(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.claimed, id)
+}
+
+// releaseClaimed returns notifications fetched but not finished (skipped on stop,
+// or interrupted mid-send) to pending, so they are not lost until stuck recovery.
+func (w *Worker) releaseClaimed() {
+	w.mu.Lock()
+	ids := make([]string, 0, len(w.claimed))
+	for id := range w.claimed {
+		ids = append(ids, id)
+	}
+	w.claimed = make(map[string]struct{})
+	w.mu.Unlock()
+
+	if len(ids) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(w.sendCtx), releaseTimeout)
+	defer cancel()
+	released, err := w.repo.ReleaseProcessing(ctx, ids)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to release unfinished notifications", "count", len(ids), "error", err)
+		return
+	}
+	slog.InfoContext(ctx, "released unfinished notifications", "count", released)
+}
+
 func (w *Worker) run(ctx context.Context, workerID int) {
 	defer w.wg.Done()
+	defer w.active.Add(-1)
 
 	ticker := time.NewTicker(w.config.PollInterval)
 	defer ticker.Stop()
@@ -95,10 +198,12 @@ func (w *Worker) run(ctx context.Context, workerID int) {
 	}
 }
 
+// processBatch fetches with ctx and sends with sendCtx: once ctx is cancelled or
+// Stop is called the rest of the batch is left claimed for Stop to release.
 func (w *Worker) processBatch(ctx context.Context, workerID int) {
 	items, err := w.repo.FetchPendingNotifications(ctx, w.config.BatchSize)
 	if err != nil {
-		slog.Error("failed to fetch pending notifications", "worker", workerID, "error", err)
+		slog.ErrorContext(ctx, "failed to fetch pending notifications", "worker", workerID, "error", err)
 		return
 	}
 
@@ -106,33 +211,48 @@ func (w *Worker) processBatch(ctx context.Context, workerID int) {
 		return
 	}
 
-	slog.Debug("processing notifications", "worker", workerID, "count", len(items))
+	slog.DebugContext(ctx, "processing notifications", "worker", workerID, "count", len(items))
 	recordQueueProcessed(len(items))
 
+	w.claim(items)
 	for _, item := range items {
-		w.processItem(ctx, item)
+		if w.stopping(ctx) {
+			return
+		}
+		w.processItem(w.sendCtx, item)
+		// An interrupted send could not update the item: Stop releases it
+		if w.sendCtx.Err() == nil {
+			w.unclaim(item.ID)
+		}
 	}
 }
 
 func (w *Worker) processItem(ctx context.Context, item *QueueItem) {
 	start := time.Now()
+	// Senders forward the ID of the request that queued the notification
+	if item.RequestID != "" {
+		ctx = httputil.WithRequestID(ctx, item.RequestID)
+	}
 
 	// Get channel info
 	channel, err := w.repo.GetChannelByID(ctx, item.ChannelID)
 	if err != nil {
-		slog.Error("channel not found", "channel_id", item.ChannelID, "error", err)
+		slog.ErrorContext(ctx, "channel not found", "channel_id", item.ChannelID, "error", err)
 		if markErr := w.repo.MarkAsFailed(ctx, item.ID, err); markErr != nil {
-			slog.Error("failed to mark as failed", "item_id", item.ID, "error", markErr)
+			slog.ErrorContext(ctx, "failed to mark as failed", "item_id", item.ID, "error", markErr)
 		}
 		recordNotificationSent("unknown", "failed")
 		return
 	}
 
+	delivery := w.startDelivery(ctx, item)
+
 	// Skip unverified channels
 	if !channel.IsVerified {
-		slog.Debug("skipping unverified channel", "channel_id", item.ChannelID)
+		slog.DebugContext(ctx, "skipping unverified channel", "channel_id", item.ChannelID)
+		w.finishDelivery(ctx, delivery, fmt.Errorf("channel not verified"))
 		if markErr := w.repo.MarkAsFailed(ctx, item.ID, fmt.Errorf("channel not verified")); markErr != nil {
-			slog.Error("failed to mark as failed", "item_id", item.ID, "error", markErr)
+			slog.ErrorContext(ctx, "failed to mark as failed", "item_id", item.ID, "error", markErr)
 		}
 		recordNotificationSent(string(channel.Type), "skipped_unverified")
 		return
@@ -140,9 +260,10 @@ func (w *Worker) processItem(ctx context.Context, item *QueueItem) {
 
 	// Skip disabled channels
 	if !channel.IsEnabled {
-		slog.Debug("skipping disabled channel", "channel_id", item.ChannelID)
+		slog.DebugContext(ctx, "skipping disabled channel", "channel_id", item.ChannelID)
+		w.finishDelivery(ctx, delivery, fmt.Errorf("channel disabled"))
 		if markErr := w.repo.MarkAsFailed(ctx, item.ID, fmt.Errorf("channel disabled")); markErr != nil {
-			slog.Error("failed to mark as failed", "item_id", item.ID, "error", markErr)
+			slog.ErrorContext(ctx, "failed to mark as failed", "item_id", item.ID, "error", markErr)
 		}
 		recordNotificationSent(string(channel.Type), "skipped_disabled")
 		return
@@ -151,9 +272,10 @@ func (w *Worker) processItem(ctx context.Context, item *QueueItem) {
 	// Render message
 	subject, body, err := w.renderer.Render(channel.Type, item.Payload)
 	if err != nil {
-		slog.Error("failed to render", "item_id", item.ID, "error", err)
+		slog.ErrorContext(ctx, "failed to render", "item_id", item.ID, "error", err)
+		w.finishDelivery(ctx, delivery, err)
 		if markErr := w.repo.MarkAsFailed(ctx, item.ID, err); markErr != nil {
-			slog.Error("failed to mark as failed", "item_id", item.ID, "error", markErr)
+			slog.ErrorContext(ctx, "failed to mark as failed", "item_id", item.ID, "error", markErr)
 		}
 		recordNotificationSent(string(channel.Type), "failed")
 		return
@@ -161,13 +283,16 @@ func (w *Worker) processItem(ctx context.Context, item *QueueItem) {
 
 	// Send notification
 	notification := Notification{
-		To:      channel.Target,
-		Subject: subject,
-		Body:    body,
+		To:       channel.Target,
+		Subject:  subject,
+		Body:     body,
+		Secret:   channel.Secret,
+		Severity: item.Payload.Event.Severity,
 	}
 
 	err = w.dispatcher.SendToChannel(ctx, channel.Type, notification)
 	duration := time.Since(start)
+	w.finishDelivery(ctx, delivery, err)
 
 	if err != nil {
 		w.handleSendError(ctx, item, channel.Type, err)
@@ -176,21 +301,58 @@ func (w *Worker) processItem(ctx context.Context, item *QueueItem) {
 
 	// Success
 	if err := w.repo.MarkAsSent(ctx, item.ID); err != nil {
-		slog.Error("failed to mark as sent", "item_id", item.ID, "error", err)
+		slog.ErrorContext(ctx, "failed to mark as sent", "item_id", item.ID, "error", err)
 	}
 
-	recordNotificationSent(string(channel.Type), "success")
+	recordNotificationSent(string(channel.Type), statusSuccess)
 	recordNotificationDuration(string(channel.Type), duration)
 
-	slog.Debug("notification sent",
+	slog.DebugContext(ctx, "notification sent",
 		"item_id", item.ID,
 		"channel_type", channel.Type,
 		"duration", duration,
 	)
 }
 
+// startDelivery records a pending delivery for the attempt. Failures are logged
+// and never block sending: a missing receipt is better than a missing notification.
+func (w *Worker) startDelivery(ctx context.Context, item *QueueItem) *Delivery {
+	delivery := &Delivery{
+		NotificationID: item.ID,
+		ChannelID:      item.ChannelID,
+		Status:         DeliveryStatusPending,
+	}
+	if err := w.repo.CreateDelivery(ctx, delivery); err != nil {
+		slog.ErrorContext(ctx, "failed to create delivery", "item_id", item.ID, "error", err)
+		return nil
+	}
+	return delivery
+}
+
+// finishDelivery marks the delivery as delivered, or failed with the reason of sendErr.
+func (w *Worker) finishDelivery(ctx context.Context, delivery *Delivery, sendErr error) {
+	if delivery == nil {
+		return
+	}
+
+	now := time.Now()
+	if sendErr != nil {
+		reason := sendErr.Error()
+		delivery.Status = DeliveryStatusFailed
+		delivery.FailedAt = &now
+		delivery.FailureReason = &reason
+	} else {
+		delivery.Status = DeliveryStatusDelivered
+		delivery.SentAt = &now
+	}
+
+	if err := w.repo.UpdateDelivery(ctx, delivery); err != nil {
+		slog.ErrorContext(ctx, "failed to update delivery", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
 func (w *Worker) handleSendError(ctx context.Context, item *QueueItem, channelType domain.ChannelType, err error) {
-	slog.Warn("send failed",
+	slog.WarnContext(ctx, "send failed",
 		"item_id", item.ID,
 		"attempt", item.Attempts+1,
 		"max_attempts", item.MaxAttempts,
@@ -200,16 +362,16 @@ func (w *Worker) handleSendError(ctx context.Context, item *QueueItem, channelTy
 	// Check if error is retryable
 	if !isRetryable(err) {
 		if markErr := w.repo.MarkAsFailed(ctx, item.ID, err); markErr != nil {
-			slog.Error("failed to mark as failed", "item_id", item.ID, "error", markErr)
+			slog.ErrorContext(ctx, "failed to mark as failed", "item_id", item.ID, "error", markErr)
 		}
 		recordNotificationSent(string(channelType), "failed")
 		return
 	}
 
-	// Check attempt limit
+	// Check attempt limit: keep the notification for inspection and manual requeue
 	if item.Attempts+1 >= item.MaxAttempts {
-		if markErr := w.repo.MarkAsFailed(ctx, item.ID, fmt.Errorf("max attempts exceeded: %w", err)); markErr != nil {
-			slog.Error("failed to mark as failed", "item_id", item.ID, "error", markErr)
+		if moveErr := w.repo.MoveToDeadLetter(ctx, item.ID, fmt.Errorf("max attempts exceeded: %w", err)); moveErr != nil {
+			slog.ErrorContext(ctx, "failed to move to dead letters", "item_id", item.ID, "error", moveErr)
 		}
 		recordNotificationSent(string(channelType), "failed")
 		return
@@ -218,11 +380,11 @@ func (w *Worker) handleSendError(ctx context.Context, item *QueueItem, channelTy
 	// Schedule retry
 	nextAttempt := w.calculateNextAttempt(item.Attempts + 1)
 	if markErr := w.repo.MarkForRetry(ctx, item.ID, err, nextAttempt); markErr != nil {
-		slog.Error("failed to mark for retry", "item_id", item.ID, "error", markErr)
+		slog.ErrorContext(ctx, "failed to mark for retry", "item_id", item.ID, "error", markErr)
 	}
 	recordNotificationSent(string(channelType), "retry")
 
-	slog.Info("notification scheduled for retry",
+	slog.InfoContext(ctx, "notification scheduled for retry",
 		"item_id", item.ID,
 		"next_attempt", nextAttempt,
 	)
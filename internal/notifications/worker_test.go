@@ -1,11 +1,17 @@
 package notifications
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/bissquit/incident-garden/internal/domain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWorker_CalculateNextAttempt(t *testing.T) {
@@ -133,4 +139,332 @@ func TestDefaultWorkerConfig(t *testing.T) {
 	assert.Equal(t, 5*time.Minute, config.MaxBackoff)
 	assert.Equal(t, 2.0, config.BackoffMultiplier)
 	assert.Equal(t, 5, config.NumWorkers)
+	assert.Equal(t, 5*time.Second, config.ShutdownTimeout)
+}
+
+// deliveryRepository records deliveries on top of mockRepository.
+type deliveryRepository struct {
+	*mockRepository
+	channel    *domain.NotificationChannel
+	deliveries []*Delivery
+}
+
+func (r *deliveryRepository) GetChannelByID(_ context.Context, _ string) (*domain.NotificationChannel, error) {
+	return r.channel, nil
+}
+
+func (r *deliveryRepository) CreateDelivery(_ context.Context, delivery *Delivery) error {
+	delivery.ID = "delivery-1"
+	r.deliveries = append(r.deliveries, delivery)
+	return nil
+}
+
+// stubSender returns err for every send.
+type stubSender struct {
+	err error
+}
+
+func (s *stubSender) Send(_ context.Context, _ Notification) error { return s.err }
+func (s *stubSender) Type() domain.ChannelType                     { return domain.ChannelTypeTelegram }
+
+func TestWorker_ProcessItem_RecordsDelivery(t *testing.T) {
+	tests := []struct {
+		name       string
+		verified   bool
+		sendErr    error
+		wantStatus DeliveryStatus
+		wantReason string
+	}{
+		{"delivered", true, nil, DeliveryStatusDelivered, ""},
+		{"send failed", true, NewNonRetryableError(errors.New("chat not found")), DeliveryStatusFailed, "chat not found"},
+		{"unverified channel", false, nil, DeliveryStatusFailed, "channel not verified"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &deliveryRepository{
+				mockRepository: newMockRepository(),
+				channel: &domain.NotificationChannel{
+					ID: "channel-1", Type: domain.ChannelTypeTelegram, Target: "123456789",
+					IsEnabled: true, IsVerified: tt.verified,
+				},
+			}
+			renderer, err := NewRenderer()
+			require.NoError(t, err)
+			worker := NewWorker(DefaultWorkerConfig(), repo, NewDispatcher(repo, &stubSender{err: tt.sendErr}), renderer)
+
+			worker.processItem(context.Background(), &QueueItem{
+				ID:          "item-1",
+				ChannelID:   "channel-1",
+				MessageType: MessageTypeInitial,
+				Payload: NotificationPayload{
+					MessageType: MessageTypeInitial,
+					Event:       EventData{ID: "event-1", Title: "Outage"},
+					GeneratedAt: time.Now(),
+				},
+				MaxAttempts: 3,
+			})
+
+			require.Len(t, repo.deliveries, 1)
+			delivery := repo.deliveries[0]
+			assert.Equal(t, "item-1", delivery.NotificationID)
+			assert.Equal(t, "channel-1", delivery.ChannelID)
+			assert.Equal(t, tt.wantStatus, delivery.Status)
+			if tt.wantStatus == DeliveryStatusDelivered {
+				assert.NotNil(t, delivery.SentAt)
+				assert.Nil(t, delivery.FailureReason)
+			} else {
+				assert.NotNil(t, delivery.FailedAt)
+				require.NotNil(t, delivery.FailureReason)
+				assert.Equal(t, tt.wantReason, *delivery.FailureReason)
+			}
+		})
+	}
+}
+
+func TestWorker_Running(t *testing.T) {
+	repo := newMockRepository()
+	renderer, err := NewRenderer()
+	require.NoError(t, err)
+	config := DefaultWorkerConfig()
+	config.NumWorkers = 2
+
+	t.Run("stopped", func(t *testing.T) {
+		worker := NewWorker(config, repo, NewDispatcher(repo), renderer)
+		assert.False(t, worker.Running(), "not running before Start")
+
+		worker.Start(context.Background())
+		assert.True(t, worker.Running())
+
+		worker.Stop()
+		assert.False(t, worker.Running())
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		worker := NewWorker(config, repo, NewDispatcher(repo), renderer)
+		ctx, cancel := context.WithCancel(context.Background())
+		worker.Start(ctx)
+		assert.True(t, worker.Running())
+
+		cancel()
+		assert.Eventually(t, func() bool { return !worker.Running() }, time.Second, 10*time.Millisecond)
+	})
+}
+
+// deadLetterRepository records dead-lettered and retried items on top of deliveryRepository.
+type deadLetterRepository struct {
+	*deliveryRepository
+	deadLettered []string
+	retried      []string
+}
+
+func (r *deadLetterRepository) MoveToDeadLetter(_ context.Context, id string, _ error) error {
+	r.deadLettered = append(r.deadLettered, id)
+	return nil
+}
+
+func (r *deadLetterRepository) MarkForRetry(_ context.Context, id string, _ error, _ time.Time) error {
+	r.retried = append(r.retried, id)
+	return nil
+}
+
+func TestWorker_ProcessItem_DeadLettersExhaustedItems(t *testing.T) {
+	tests := []struct {
+		name             string
+		attempts         int
+		wantDeadLettered bool
+	}{
+		{"attempts left", 1, false},
+		{"last attempt", 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &deadLetterRepository{deliveryRepository: &deliveryRepository{
+				mockRepository: newMockRepository(),
+				channel: &domain.NotificationChannel{
+					ID: "channel-1", Type: domain.ChannelTypeTelegram, Target: "123456789",
+					IsEnabled: true, IsVerified: true,
+				},
+			}}
+			renderer, err := NewRenderer()
+			require.NoError(t, err)
+			sender := &stubSender{err: errors.New("telegram unavailable")}
+			worker := NewWorker(DefaultWorkerConfig(), repo, NewDispatcher(repo, sender), renderer)
+
+			worker.processItem(context.Background(), &QueueItem{
+				ID:          "item-1",
+				ChannelID:   "channel-1",
+				MessageType: MessageTypeInitial,
+				Payload: NotificationPayload{
+					MessageType: MessageTypeInitial,
+					Event:       EventData{ID: "event-1", Title: "Outage"},
+					GeneratedAt: time.Now(),
+				},
+				Attempts:    tt.attempts,
+				MaxAttempts: 3,
+			})
+
+			if tt.wantDeadLettered {
+				assert.Equal(t, []string{"item-1"}, repo.deadLettered)
+				assert.Empty(t, repo.retried)
+			} else {
+				assert.Empty(t, repo.deadLettered)
+				assert.Equal(t, []string{"item-1"}, repo.retried)
+			}
+		})
+	}
+}
+
+// shutdownRepository hands out one batch and records how its items end, safe for worker goroutines.
+type shutdownRepository struct {
+	*mockRepository
+	mu       sync.Mutex
+	batch    []*QueueItem
+	sent     []string
+	released []string
+}
+
+func (r *shutdownRepository) FetchPendingNotifications(_ context.Context, _ int) ([]*QueueItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	batch := r.batch
+	r.batch = nil
+	return batch, nil
+}
+
+func (r *shutdownRepository) GetChannelByID(_ context.Context, id string) (*domain.NotificationChannel, error) {
+	return &domain.NotificationChannel{
+		ID: id, Type: domain.ChannelTypeWebhook, Target: "https://hooks.example.com",
+		IsEnabled: true, IsVerified: true,
+	}, nil
+}
+
+func (r *shutdownRepository) MarkAsSent(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, id)
+	return nil
+}
+
+func (r *shutdownRepository) MarkForRetry(ctx context.Context, _ string, _ error, _ time.Time) error {
+	return ctx.Err()
+}
+
+func (r *shutdownRepository) ReleaseProcessing(_ context.Context, ids []string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.released = append(r.released, ids...)
+	return int64(len(ids)), nil
+}
+
+func (r *shutdownRepository) outcome() (sent, released []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sent, r.released
+}
+
+// httpSender posts every notification to url, like the real webhook sender.
+type httpSender struct {
+	url string
+}
+
+func (s *httpSender) Send(ctx context.Context, _ Notification) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (s *httpSender) Type() domain.ChannelType { return domain.ChannelTypeWebhook }
+
+// startSlowWorker starts a worker with one batch of items whose sends hit a server
+// that answers after delay; it returns once the first send is in flight, with the
+// cancel func of the Start context.
+func startSlowWorker(t *testing.T, delay, shutdownTimeout time.Duration, ids ...string) (*Worker, *shutdownRepository, context.CancelFunc) {
+	t.Helper()
+	received := make(chan struct{}, len(ids))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	repo := &shutdownRepository{mockRepository: newMockRepository()}
+	for _, id := range ids {
+		repo.batch = append(repo.batch, &QueueItem{
+			ID:          id,
+			ChannelID:   "channel-" + id,
+			MessageType: MessageTypeInitial,
+			Payload: NotificationPayload{
+				MessageType: MessageTypeInitial,
+				Event:       EventData{ID: "event-1", Title: "Outage"},
+				GeneratedAt: time.Now(),
+			},
+			MaxAttempts: 3,
+		})
+	}
+
+	renderer, err := NewRenderer()
+	require.NoError(t, err)
+	config := DefaultWorkerConfig()
+	config.NumWorkers = 1
+	config.PollInterval = 10 * time.Millisecond
+	config.ShutdownTimeout = shutdownTimeout
+	worker := NewWorker(config, repo, NewDispatcher(repo, &httpSender{url: server.URL}), renderer)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	worker.Start(ctx)
+
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no send started")
+	}
+	return worker, repo, cancel
+}
+
+func TestWorker_Stop_DrainsInFlightSend(t *testing.T) {
+	worker, repo, _ := startSlowWorker(t, 200*time.Millisecond, 5*time.Second, "item-1", "item-2")
+
+	worker.Stop()
+
+	sent, released := repo.outcome()
+	assert.Equal(t, []string{"item-1"}, sent, "the in-flight send finishes")
+	assert.Equal(t, []string{"item-2"}, released, "the rest of the batch goes back to pending")
+}
+
+func TestWorker_Stop_ReleasesInterruptedSend(t *testing.T) {
+	worker, repo, _ := startSlowWorker(t, time.Minute, 50*time.Millisecond, "item-1")
+
+	start := time.Now()
+	worker.Stop()
+	assert.Less(t, time.Since(start), 5*time.Second, "Stop does not wait for the send past the timeout")
+
+	sent, released := repo.outcome()
+	assert.Empty(t, sent)
+	assert.Equal(t, []string{"item-1"}, released)
+}
+
+func TestWorker_Stop_SendsOutliveStartContext(t *testing.T) {
+	worker, repo, cancelStart := startSlowWorker(t, 200*time.Millisecond, 5*time.Second, "item-1")
+
+	// The app cancels the Start context before Stop; the send must still finish
+	cancelStart()
+	worker.Stop()
+
+	sent, released := repo.outcome()
+	assert.Equal(t, []string{"item-1"}, sent)
+	assert.Empty(t, released)
 }
@@ -0,0 +1,25 @@
+package ctxlog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_AddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewTextHandler(&buf, nil))).With("component", "test")
+
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-1")
+	logger.InfoContext(ctx, "with request")
+	assert.Contains(t, buf.String(), "component=test")
+	assert.Contains(t, buf.String(), "request_id=req-1")
+
+	buf.Reset()
+	logger.InfoContext(context.Background(), "without request")
+	assert.NotContains(t, buf.String(), "request_id")
+}
@@ -0,0 +1,27 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicCORSMiddleware_OverridesCredentialedCORS(t *testing.T) {
+	handler := CORSMiddleware([]string{"https://app.example.com"})(
+		PublicCORSMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})),
+	)
+
+	for _, origin := range []string{"https://app.example.com", "https://other.example.org"} {
+		req := httptest.NewRequest(http.MethodGet, "/status", nil)
+		req.Header.Set("Origin", origin)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"), origin)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"), origin)
+	}
+}
@@ -15,19 +15,27 @@ type ErrorMapping struct {
 	Message string // if empty, uses err.Error()
 }
 
-// HandleError maps a domain error to an HTTP response using provided mappings.
-// If no mapping matches, logs the error and returns 500 Internal Server Error.
-func HandleError(ctx context.Context, w http.ResponseWriter, err error, mappings []ErrorMapping) {
+// MapError returns the status and message of the first mapping matching err.
+// If no mapping matches, it returns 500 with a generic message and false.
+func MapError(err error, mappings []ErrorMapping) (status int, message string, ok bool) {
 	for _, m := range mappings {
 		if errors.Is(err, m.Error) {
 			msg := m.Message
 			if msg == "" {
 				msg = err.Error()
 			}
-			Error(w, m.Status, msg)
-			return
+			return m.Status, msg, true
 		}
 	}
-	ctxlog.FromContext(ctx).Error("internal error", "error", err)
-	Error(w, http.StatusInternalServerError, "internal error")
+	return http.StatusInternalServerError, "internal error", false
+}
+
+// HandleError maps a domain error to an HTTP response using provided mappings.
+// If no mapping matches, logs the error and returns 500 Internal Server Error.
+func HandleError(ctx context.Context, w http.ResponseWriter, err error, mappings []ErrorMapping) {
+	status, msg, ok := MapError(err, mappings)
+	if !ok {
+		ctxlog.FromContext(ctx).Error("internal error", "error", err)
+	}
+	Error(w, status, msg)
 }
@@ -0,0 +1,98 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveETag(method, ifNoneMatch string, status int, body string) *httptest.ResponseRecorder {
+	handler := ETagMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+
+	req := httptest.NewRequest(method, "/api/v1/services/api", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestETagMiddleware_SetsETag(t *testing.T) {
+	rec := serveETag(http.MethodGet, "", http.StatusOK, `{"data":{"slug":"api"}}`)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"data":{"slug":"api"}}`, rec.Body.String())
+	etag := rec.Header().Get("ETag")
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+
+	same := serveETag(http.MethodGet, "", http.StatusOK, `{"data":{"slug":"api"}}`)
+	assert.Equal(t, etag, same.Header().Get("ETag"), "same body, same tag")
+
+	other := serveETag(http.MethodGet, "", http.StatusOK, `{"data":{"slug":"web"}}`)
+	assert.NotEqual(t, etag, other.Header().Get("ETag"))
+}
+
+func TestETagMiddleware_Matching(t *testing.T) {
+	body := `{"data":{"slug":"api"}}`
+	etag := serveETag(http.MethodGet, "", http.StatusOK, body).Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	for _, header := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		rec := serveETag(http.MethodGet, header, http.StatusOK, body)
+		assert.Equal(t, http.StatusNotModified, rec.Code, header)
+		assert.Empty(t, rec.Body.String(), header)
+		assert.Equal(t, etag, rec.Header().Get("ETag"), header)
+		assert.Empty(t, rec.Header().Get("Content-Type"), header)
+	}
+}
+
+func TestETagMiddleware_NotMatching(t *testing.T) {
+	body := `{"data":{"slug":"api"}}`
+	rec := serveETag(http.MethodGet, `"d41d8cd98f00b204e9800998ecf8427e"`, http.StatusOK, body)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, body, rec.Body.String())
+	assert.NotEmpty(t, rec.Header().Get("ETag"))
+}
+
+func TestETagMiddleware_PassesThrough(t *testing.T) {
+	// Errors are not tagged
+	rec := serveETag(http.MethodGet, "*", http.StatusNotFound, `{"error":{"message":"not found"}}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, `{"error":{"message":"not found"}}`, rec.Body.String())
+	assert.Empty(t, rec.Header().Get("ETag"))
+
+	// Nor are other methods
+	rec = serveETag(http.MethodPost, "*", http.StatusOK, `{"data":{}}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("ETag"))
+}
+
+func TestETagMatches(t *testing.T) {
+	assert.True(t, ETagMatches(`"abc"`, `"abc"`))
+	assert.True(t, ETagMatches(`W/"abc"`, `"abc"`))
+	assert.True(t, ETagMatches(`"x", "abc"`, `"abc"`))
+	assert.True(t, ETagMatches(`*`, `"abc"`))
+	assert.False(t, ETagMatches(``, `"abc"`))
+	assert.False(t, ETagMatches(`"abd"`, `"abc"`))
+	assert.False(t, ETagMatches(`abc`, `"abc"`))
+}
+
+func TestSetLastModified(t *testing.T) {
+	rec := httptest.NewRecorder()
+	SetLastModified(rec, time.Date(2026, 3, 10, 12, 0, 0, 0, time.FixedZone("CET", 3600)))
+	assert.Equal(t, "Tue, 10 Mar 2026 11:00:00 GMT", rec.Header().Get("Last-Modified"))
+
+	rec = httptest.NewRecorder()
+	SetLastModified(rec, time.Time{})
+	assert.Empty(t, rec.Header().Get("Last-Modified"))
+}
@@ -0,0 +1,106 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+const peerAddrKey contextKey = "peer_addr"
+
+// PeerAddrMiddleware keeps the address of the connecting peer in the context.
+// It must run before middleware that rewrites RemoteAddr from headers (chi's RealIP),
+// so IPAllowlistMiddleware sees the real TCP peer.
+func PeerAddrMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), peerAddrKey, r.RemoteAddr)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// parseCIDRs parses CIDR ranges. A bare IP address is a single-address range.
+func parseCIDRs(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if addr, err := netip.ParseAddr(cidr); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q", cidr)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// IPAllowlistMiddleware rejects requests whose client IP is outside allowedCIDRs with 403.
+// trustedProxies is the number of reverse proxies in front of the app: the client IP is
+// taken that many hops back along X-Forwarded-For, counting the connecting peer as the last
+// hop. With 0 the peer address is used and X-Forwarded-For is ignored.
+func IPAllowlistMiddleware(allowedCIDRs []string, trustedProxies int) (func(http.Handler) http.Handler, error) {
+	prefixes, err := parseCIDRs(allowedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+	if trustedProxies < 0 {
+		return nil, errors.New("trusted proxies must not be negative")
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip, ok := clientIP(r, trustedProxies)
+			if !ok || !containsIP(prefixes, ip) {
+				Error(w, http.StatusForbidden, "access denied")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// clientIP returns the address trustedProxies hops back from the peer in the
+// X-Forwarded-For chain. It fails when the chain is shorter or the address is malformed.
+func clientIP(r *http.Request, trustedProxies int) (netip.Addr, bool) {
+	peer, ok := r.Context().Value(peerAddrKey).(string)
+	if !ok {
+		peer = r.RemoteAddr
+	}
+	if host, _, err := net.SplitHostPort(peer); err == nil {
+		peer = host
+	}
+
+	var chain []string
+	if trustedProxies > 0 {
+		for _, header := range r.Header.Values("X-Forwarded-For") {
+			for _, hop := range strings.Split(header, ",") {
+				chain = append(chain, strings.TrimSpace(hop))
+			}
+		}
+	}
+	chain = append(chain, peer)
+
+	i := len(chain) - 1 - trustedProxies
+	if i < 0 {
+		return netip.Addr{}, false
+	}
+	addr, err := netip.ParseAddr(chain[i])
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.WithZone("").Unmap(), true
+}
+
+func containsIP(prefixes []netip.Prefix, ip netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package httputil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// doAllowlistRequest sends a request from remoteAddr with the given X-Forwarded-For headers.
+func doAllowlistRequest(t *testing.T, cidrs []string, trustedProxies int, remoteAddr string, xff ...string) *httptest.ResponseRecorder {
+	t.Helper()
+	mw, err := IPAllowlistMiddleware(cidrs, trustedProxies)
+	require.NoError(t, err)
+
+	handler := PeerAddrMiddleware(mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/cleanup", nil)
+	req.RemoteAddr = remoteAddr
+	for _, v := range xff {
+		req.Header.Add("X-Forwarded-For", v)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIPAllowlistMiddleware(t *testing.T) {
+	cidrs := []string{"10.0.0.0/8", "192.168.1.10", "2001:db8::/32", "::1"}
+
+	tests := []struct {
+		name           string
+		trustedProxies int
+		remoteAddr     string
+		xff            []string
+		want           int
+	}{
+		{name: "IPv4 in range", remoteAddr: "10.1.2.3:5000", want: http.StatusOK},
+		{name: "IPv4 single address", remoteAddr: "192.168.1.10:5000", want: http.StatusOK},
+		{name: "IPv4 next to single address", remoteAddr: "192.168.1.11:5000", want: http.StatusForbidden},
+		{name: "IPv4 out of range", remoteAddr: "11.0.0.1:5000", want: http.StatusForbidden},
+		{name: "IPv6 in range", remoteAddr: "[2001:db8:1::5]:5000", want: http.StatusOK},
+		{name: "IPv6 loopback", remoteAddr: "[::1]:5000", want: http.StatusOK},
+		{name: "IPv6 out of range", remoteAddr: "[2001:db9::1]:5000", want: http.StatusForbidden},
+		{name: "IPv4-mapped IPv6 matches IPv4 range", remoteAddr: "[::ffff:10.0.0.1]:5000", want: http.StatusOK},
+		{name: "IPv6 with zone", remoteAddr: "[2001:db8::1%eth0]:5000", want: http.StatusOK},
+		{name: "address without port", remoteAddr: "10.0.0.1", want: http.StatusOK},
+		{name: "malformed address", remoteAddr: "not-an-ip", want: http.StatusForbidden},
+		{name: "forwarded header ignored without trusted proxies", remoteAddr: "11.0.0.1:5000", xff: []string{"10.0.0.1"}, want: http.StatusForbidden},
+		{name: "one proxy, allowed client", trustedProxies: 1, remoteAddr: "172.16.0.1:5000", xff: []string{"10.0.0.1"}, want: http.StatusOK},
+		{name: "one proxy, denied client", trustedProxies: 1, remoteAddr: "10.0.0.2:5000", xff: []string{"11.0.0.1"}, want: http.StatusForbidden},
+		{name: "one proxy, spoofed leftmost entry", trustedProxies: 1, remoteAddr: "172.16.0.1:5000", xff: []string{"10.0.0.1, 11.0.0.1"}, want: http.StatusForbidden},
+		{name: "two proxies, repeated headers", trustedProxies: 2, remoteAddr: "172.16.0.2:5000", xff: []string{"11.0.0.1", "2001:db8::7, 172.16.0.1"}, want: http.StatusOK},
+		{name: "chain shorter than trusted proxies", trustedProxies: 2, remoteAddr: "172.16.0.1:5000", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doAllowlistRequest(t, cidrs, tt.trustedProxies, tt.remoteAddr, tt.xff...)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
+
+func TestIPAllowlistMiddleware_DeniedBody(t *testing.T) {
+	rec := doAllowlistRequest(t, []string{"10.0.0.0/8"}, 0, "11.0.0.1:5000")
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, "access denied", body.Error.Message)
+}
+
+func TestIPAllowlistMiddleware_UsesPeerBeforeRealIP(t *testing.T) {
+	mw, err := IPAllowlistMiddleware([]string{"10.0.0.0/8"}, 0)
+	require.NoError(t, err)
+
+	// A header-based rewrite of RemoteAddr after PeerAddrMiddleware must not be trusted
+	rewrite := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.RemoteAddr = r.Header.Get("X-Real-IP")
+			next.ServeHTTP(w, r)
+		})
+	}
+	handler := PeerAddrMiddleware(rewrite(mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "11.0.0.1:5000"
+	req.Header.Set("X-Real-IP", "10.0.0.1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestIPAllowlistMiddleware_InvalidConfig(t *testing.T) {
+	for _, cidrs := range [][]string{{"10.0.0.0/33"}, {"not-a-cidr"}, {"2001:db8::/129"}} {
+		_, err := IPAllowlistMiddleware(cidrs, 0)
+		assert.Error(t, err, cidrs)
+	}
+
+	_, err := IPAllowlistMiddleware([]string{"10.0.0.0/8"}, -1)
+	assert.Error(t, err)
+}
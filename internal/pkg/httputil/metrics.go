@@ -29,12 +29,10 @@ func MetricsMiddleware(next http.Handler) http.Handler {
 		}
 
 		duration := time.Since(start).Seconds()
+		statusCode := strconv.Itoa(wrapped.statusCode)
 
-		metrics.HTTPRequestDuration.WithLabelValues(
-			r.Method,
-			routePattern,
-			strconv.Itoa(wrapped.statusCode),
-		).Observe(duration)
+		metrics.HTTPRequestDuration.WithLabelValues(r.Method, routePattern, statusCode).Observe(duration)
+		metrics.HTTPRequestsTotal.WithLabelValues(r.Method, routePattern, statusCode).Inc()
 	})
 }
 
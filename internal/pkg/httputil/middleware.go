@@ -32,12 +32,13 @@ func CORSMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
 			if originsSet[origin] || originsSet["*"] {
 				w.Header().Set("Access-Control-Allow-Origin", origin)
 				w.Header().Set("Access-Control-Allow-Credentials", "true")
+				w.Header().Set("Access-Control-Expose-Headers", RequestIDHeader+", ETag, Last-Modified, Idempotent-Replayed")
 			}
 
 			// Handle preflight OPTIONS request
 			if r.Method == http.MethodOptions {
 				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-CSRF-Token")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-CSRF-Token, If-None-Match, Idempotency-Key, "+RequestIDHeader)
 				w.Header().Set("Access-Control-Max-Age", "86400")
 				w.WriteHeader(http.StatusNoContent)
 				return
@@ -48,6 +49,18 @@ func CORSMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
 	}
 }
 
+// PublicCORSMiddleware lets any origin read public data without credentials, e.g. the
+// embeddable status widget on third-party sites. It overrides CORSMiddleware, which only
+// reflects CORS_ALLOWED_ORIGINS and allows credentials.
+func PublicCORSMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Del("Access-Control-Allow-Credentials")
+		w.Header().Set("Access-Control-Expose-Headers", RequestIDHeader+", ETag, Last-Modified")
+		next.ServeHTTP(w, r)
+	})
+}
+
 type contextKey string
 
 // Context keys for storing user information.
@@ -67,53 +80,74 @@ type TokenValidator interface {
 func AuthMiddleware(validator TokenValidator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			var token string
-			var fromCookie bool
-
-			// Try cookie first
-			if cookie, err := r.Cookie(AccessTokenCookie); err == nil && cookie.Value != "" {
-				token = cookie.Value
-				fromCookie = true
-			}
-
-			// Fallback to Authorization header
-			if token == "" {
-				authHeader := r.Header.Get("Authorization")
-				if authHeader != "" {
-					parts := strings.SplitN(authHeader, " ", 2)
-					if len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" {
-						token = parts[1]
-					}
-				}
-			}
-
+			token, fromCookie := requestToken(r)
 			if token == "" {
 				Error(w, http.StatusUnauthorized, "missing authentication")
 				return
 			}
 
-			// CSRF check for cookie-based auth on state-changing methods
-			if fromCookie && isStateChangingMethod(r.Method) {
-				if !validateCSRF(r) {
-					Error(w, http.StatusForbidden, "invalid csrf token")
-					return
-				}
-			}
+			authenticate(w, r, next, validator, token, fromCookie)
+		})
+	}
+}
 
-			userID, role, err := validator.ValidateToken(r.Context(), token)
-			if err != nil {
-				Error(w, http.StatusUnauthorized, "invalid or expired token")
+// OptionalAuthMiddleware authenticates requests that carry a token, like AuthMiddleware,
+// and passes anonymous requests through without user ID and role in the context.
+// Handlers check GetRole themselves for operations that need it.
+func OptionalAuthMiddleware(validator TokenValidator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, fromCookie := requestToken(r)
+			if token == "" {
+				next.ServeHTTP(w, r)
 				return
 			}
 
-			ctx := context.WithValue(r.Context(), UserIDKey, userID)
-			ctx = context.WithValue(ctx, RoleKey, role)
-
-			next.ServeHTTP(w, r.WithContext(ctx))
+			authenticate(w, r, next, validator, token, fromCookie)
 		})
 	}
 }
 
+// requestToken returns the access token from the cookie or, failing that, the Authorization header.
+func requestToken(r *http.Request) (token string, fromCookie bool) {
+	// Try cookie first
+	if cookie, err := r.Cookie(AccessTokenCookie); err == nil && cookie.Value != "" {
+		return cookie.Value, true
+	}
+
+	// Fallback to Authorization header
+	authHeader := r.Header.Get("Authorization")
+	if authHeader != "" {
+		parts := strings.SplitN(authHeader, " ", 2)
+		if len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" {
+			return parts[1], false
+		}
+	}
+	return "", false
+}
+
+// authenticate validates the token and calls next with user ID and role in the context.
+func authenticate(w http.ResponseWriter, r *http.Request, next http.Handler, validator TokenValidator, token string, fromCookie bool) {
+	// CSRF check for cookie-based auth on state-changing methods
+	if fromCookie && isStateChangingMethod(r.Method) {
+		if !validateCSRF(r) {
+			Error(w, http.StatusForbidden, "invalid csrf token")
+			return
+		}
+	}
+
+	userID, role, err := validator.ValidateToken(r.Context(), token)
+	if err != nil {
+		Error(w, http.StatusUnauthorized, "invalid or expired token")
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), UserIDKey, userID)
+	ctx = context.WithValue(ctx, RoleKey, role)
+
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // isStateChangingMethod returns true for methods that modify state.
 func isStateChangingMethod(method string) bool {
 	switch method {
@@ -0,0 +1,145 @@
+package httputil
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"golang.org/x/time/rate"
+)
+
+// RateLimitIdleTimeout is how long an unused bucket is kept before eviction.
+const RateLimitIdleTimeout = 10 * time.Minute
+
+// userBucket is the token bucket of one user.
+type userBucket struct {
+	limiter  *rate.Limiter
+	role     domain.Role
+	mu       sync.Mutex
+	lastSeen time.Time
+}
+
+// RateLimiter limits state-changing requests per user with token buckets.
+// The per-minute limit of the user's role is both the bucket size and the refill rate.
+type RateLimiter struct {
+	limits  map[domain.Role]int
+	buckets sync.Map // user ID -> *userBucket
+	now     func() time.Time
+}
+
+// NewRateLimiter creates a rate limiter with per-minute limits by role.
+// Roles missing from limits, or with a non-positive limit, are not limited.
+func NewRateLimiter(limits map[domain.Role]int) *RateLimiter {
+	return &RateLimiter{
+		limits: limits,
+		now:    time.Now,
+	}
+}
+
+// Middleware rejects POST, PUT, PATCH and DELETE requests with 429 once the user's bucket is empty.
+// It must run after AuthMiddleware, which puts the user ID and role into the context.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isStateChangingMethod(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userID := GetUserID(r.Context())
+		if userID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if retryAfter, ok := l.allow(userID, GetRole(r.Context())); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			Error(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allow takes a token from the user's bucket. When the bucket is empty it returns
+// how long until the next token is available.
+func (l *RateLimiter) allow(userID string, role domain.Role) (time.Duration, bool) {
+	perMinute := l.limits[role]
+	if perMinute <= 0 {
+		return 0, true
+	}
+
+	now := l.now()
+	bucket := l.bucket(userID, role, perMinute, now)
+
+	bucket.mu.Lock()
+	bucket.lastSeen = now
+	bucket.mu.Unlock()
+
+	reservation := bucket.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return delay, false
+	}
+	return 0, true
+}
+
+// bucket returns the user's bucket, replacing it when the user's role has changed.
+func (l *RateLimiter) bucket(userID string, role domain.Role, perMinute int, now time.Time) *userBucket {
+	if existing, ok := l.buckets.Load(userID); ok {
+		b := existing.(*userBucket)
+		if b.role == role {
+			return b
+		}
+	}
+
+	b := &userBucket{
+		limiter:  rate.NewLimiter(rate.Limit(float64(perMinute)/60), perMinute),
+		role:     role,
+		lastSeen: now,
+	}
+	actual, loaded := l.buckets.LoadOrStore(userID, b)
+	if loaded && actual.(*userBucket).role == role {
+		return actual.(*userBucket)
+	}
+	if loaded {
+		l.buckets.Store(userID, b)
+	}
+	return b
+}
+
+// Run evicts idle buckets every interval until ctx is cancelled.
+func (l *RateLimiter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.evictIdle(l.now())
+		}
+	}
+}
+
+// evictIdle removes buckets not used for longer than RateLimitIdleTimeout.
+func (l *RateLimiter) evictIdle(now time.Time) int {
+	evicted := 0
+	l.buckets.Range(func(key, value any) bool {
+		b := value.(*userBucket)
+		b.mu.Lock()
+		idle := now.Sub(b.lastSeen) > RateLimitIdleTimeout
+		b.mu.Unlock()
+		if idle {
+			l.buckets.CompareAndDelete(key, value)
+			evicted++
+		}
+		return true
+	})
+	return evicted
+}
@@ -0,0 +1,122 @@
+package httputil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRateLimiter(now *time.Time) *RateLimiter {
+	limiter := NewRateLimiter(map[domain.Role]int{
+		domain.RoleAdmin:    6,
+		domain.RoleOperator: 3,
+		domain.RoleUser:     2,
+	})
+	limiter.now = func() time.Time { return *now }
+	return limiter
+}
+
+// doRequest sends a request through the limiter as the given user.
+func doRequest(limiter *RateLimiter, method, userID string, role domain.Role) *httptest.ResponseRecorder {
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(method, "/api/v1/events", nil)
+	ctx := context.WithValue(req.Context(), UserIDKey, userID)
+	ctx = context.WithValue(ctx, RoleKey, role)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req.WithContext(ctx))
+	return rec
+}
+
+func TestRateLimiter_PerRoleLimits(t *testing.T) {
+	tests := []struct {
+		role  domain.Role
+		limit int
+	}{
+		{domain.RoleAdmin, 6},
+		{domain.RoleOperator, 3},
+		{domain.RoleUser, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+			limiter := newTestRateLimiter(&now)
+
+			for i := 0; i < tt.limit; i++ {
+				rec := doRequest(limiter, http.MethodPost, "user-1", tt.role)
+				require.Equal(t, http.StatusOK, rec.Code, "request %d", i+1)
+			}
+
+			rec := doRequest(limiter, http.MethodPost, "user-1", tt.role)
+			assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+			assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+			assert.Equal(t, http.StatusOK, doRequest(limiter, http.MethodPost, "user-2", tt.role).Code,
+				"buckets are per user")
+		})
+	}
+}
+
+func TestRateLimiter_RetryAfterAndRefill(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	limiter := newTestRateLimiter(&now)
+
+	doRequest(limiter, http.MethodPost, "user-1", domain.RoleUser)
+	doRequest(limiter, http.MethodDelete, "user-1", domain.RoleUser)
+
+	rec := doRequest(limiter, http.MethodPatch, "user-1", domain.RoleUser)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"), "2 per minute refills a token every 30s")
+
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, http.StatusOK, doRequest(limiter, http.MethodPut, "user-1", domain.RoleUser).Code)
+}
+
+func TestRateLimiter_ReadsNotLimited(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	limiter := newTestRateLimiter(&now)
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, http.StatusOK, doRequest(limiter, http.MethodGet, "user-1", domain.RoleUser).Code)
+	}
+}
+
+func TestRateLimiter_RoleChangeResetsBucket(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	limiter := newTestRateLimiter(&now)
+
+	doRequest(limiter, http.MethodPost, "user-1", domain.RoleUser)
+	doRequest(limiter, http.MethodPost, "user-1", domain.RoleUser)
+	require.Equal(t, http.StatusTooManyRequests, doRequest(limiter, http.MethodPost, "user-1", domain.RoleUser).Code)
+
+	assert.Equal(t, http.StatusOK, doRequest(limiter, http.MethodPost, "user-1", domain.RoleOperator).Code,
+		"promoted user gets the operator bucket")
+}
+
+func TestRateLimiter_EvictIdle(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	limiter := newTestRateLimiter(&now)
+
+	doRequest(limiter, http.MethodPost, "idle", domain.RoleUser)
+	now = now.Add(5 * time.Minute)
+	doRequest(limiter, http.MethodPost, "active", domain.RoleUser)
+
+	assert.Equal(t, 0, limiter.evictIdle(now.Add(5*time.Minute)), "nothing idle for more than 10 minutes yet")
+
+	evicted := limiter.evictIdle(now.Add(6 * time.Minute))
+	assert.Equal(t, 1, evicted)
+
+	_, ok := limiter.buckets.Load("idle")
+	assert.False(t, ok)
+	_, ok = limiter.buckets.Load("active")
+	assert.True(t, ok)
+}
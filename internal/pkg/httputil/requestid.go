@@ -0,0 +1,62 @@
+package httputil
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the request ID on incoming requests, responses and outgoing calls.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware takes the request ID from the X-Request-ID header, or generates a UUID
+// if the header is missing or not a UUID, stores it in the context and returns it in the
+// response header. The ID is kept under chi's key, so middleware.GetReqID sees it too.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := uuid.NewString()
+		if parsed, err := uuid.Parse(r.Header.Get(RequestIDHeader)); err == nil {
+			id = parsed.String()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), id)))
+	})
+}
+
+// WithRequestID returns a context carrying the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, middleware.RequestIDKey, id)
+}
+
+// RequestIDFromContext returns the request ID of the context, or "" outside a request.
+func RequestIDFromContext(ctx context.Context) string {
+	return middleware.GetReqID(ctx)
+}
+
+// RequestIDTransport sets X-Request-ID on outgoing requests whose context carries a request ID,
+// unless the request already has the header. A nil base uses http.DefaultTransport.
+func RequestIDTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &requestIDTransport{base: base}
+}
+
+type requestIDTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper. The request is cloned: a RoundTripper must not modify it.
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := RequestIDFromContext(req.Context())
+	if id == "" || req.Header.Get(RequestIDHeader) != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set(RequestIDHeader, id)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,87 @@
+package httputil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveRequestID(t *testing.T, header string) (ctxID, responseID string) {
+	t.Helper()
+	handler := RequestIDMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		ctxID = RequestIDFromContext(r.Context())
+		assert.Equal(t, ctxID, middleware.GetReqID(r.Context()), "chi sees the same ID")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/services", nil)
+	if header != "" {
+		req.Header.Set(RequestIDHeader, header)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return ctxID, rec.Header().Get(RequestIDHeader)
+}
+
+func TestRequestIDMiddleware_Generates(t *testing.T) {
+	ctxID, responseID := serveRequestID(t, "")
+	_, err := uuid.Parse(ctxID)
+	require.NoError(t, err)
+	assert.Equal(t, ctxID, responseID)
+
+	other, _ := serveRequestID(t, "")
+	assert.NotEqual(t, ctxID, other, "every request gets its own ID")
+}
+
+func TestRequestIDMiddleware_Extracts(t *testing.T) {
+	const id = "0b7c3a5e-7f64-4a2e-9c1d-2f6e8b9a0c41"
+	ctxID, responseID := serveRequestID(t, id)
+	assert.Equal(t, id, ctxID)
+	assert.Equal(t, id, responseID)
+
+	ctxID, _ = serveRequestID(t, "0B7C3A5E-7F64-4A2E-9C1D-2F6E8B9A0C41")
+	assert.Equal(t, id, ctxID, "normalized to lower case")
+}
+
+func TestRequestIDMiddleware_ReplacesInvalid(t *testing.T) {
+	for _, header := range []string{"not-a-uuid", "abc\nrequest_id=forged"} {
+		ctxID, responseID := serveRequestID(t, header)
+		_, err := uuid.Parse(ctxID)
+		require.NoError(t, err, header)
+		assert.NotEqual(t, header, ctxID)
+		assert.Equal(t, ctxID, responseID)
+	}
+}
+
+func TestRequestIDTransport(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(RequestIDHeader))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: RequestIDTransport(nil)}
+	send := func(ctx context.Context, header string) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, nil)
+		require.NoError(t, err)
+		if header != "" {
+			req.Header.Set(RequestIDHeader, header)
+		}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, header, req.Header.Get(RequestIDHeader), "the caller's request is not modified")
+	}
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	send(ctx, "")
+	send(context.Background(), "")
+	send(ctx, "explicit")
+
+	assert.Equal(t, []string{"req-1", "", "explicit"}, received)
+}
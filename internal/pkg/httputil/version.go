@@ -0,0 +1,57 @@
+package httputil
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// API versions.
+const (
+	APIVersion1 = "v1"
+	APIVersion2 = "v2"
+)
+
+// APIVersionKey is the context key of the API version of a request.
+const APIVersionKey contextKey = "api_version"
+
+// APIVersion2MediaType requests API v2 in the Accept header on any route prefix.
+const APIVersion2MediaType = "application/vnd.incident-garden.v2+json"
+
+// VersionMiddleware sets the API version of a request in the context: v2 if
+// the Accept header lists APIVersion2MediaType, else the version of the route
+// prefix the middleware is mounted on (/api/v1 → APIVersion1).
+func VersionMiddleware(prefixVersion string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			version := prefixVersion
+			if acceptsMediaType(r.Header.Values("Accept"), APIVersion2MediaType) {
+				version = APIVersion2
+			}
+			ctx := context.WithValue(r.Context(), APIVersionKey, version)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// CurrentAPIVersion returns the API version of the request, APIVersion1 if none is set.
+func CurrentAPIVersion(ctx context.Context) string {
+	if version, ok := ctx.Value(APIVersionKey).(string); ok && version != "" {
+		return version
+	}
+	return APIVersion1
+}
+
+// acceptsMediaType reports whether any Accept header value lists mediaType, ignoring parameters.
+func acceptsMediaType(accept []string, mediaType string) bool {
+	for _, header := range accept {
+		for _, value := range strings.Split(header, ",") {
+			parsed, _, err := mime.ParseMediaType(strings.TrimSpace(value))
+			if err == nil && parsed == mediaType {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package httputil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		prefixVersion string
+		accept        string
+		want          string
+	}{
+		{"v1 prefix", APIVersion1, "", APIVersion1},
+		{"v2 prefix", APIVersion2, "", APIVersion2},
+		{"plain json", APIVersion1, "application/json", APIVersion1},
+		{"v2 media type", APIVersion1, APIVersion2MediaType, APIVersion2},
+		{"v2 media type in list", APIVersion1, "text/html, application/vnd.incident-garden.v2+json;q=0.9", APIVersion2},
+		{"v2 media type case-insensitive", APIVersion1, "Application/VND.Incident-Garden.V2+JSON", APIVersion2},
+		{"other vendor version", APIVersion1, "application/vnd.incident-garden.v3+json", APIVersion1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := VersionMiddleware(tt.prefixVersion)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = CurrentAPIVersion(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/services", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCurrentAPIVersion_DefaultsToV1(t *testing.T) {
+	assert.Equal(t, APIVersion1, CurrentAPIVersion(context.Background()))
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// eventTypes and serviceStatuses are always exported, with 0 if there is none.
+var (
+	eventTypes      = []domain.EventType{domain.EventTypeIncident, domain.EventTypeMaintenance}
+	serviceStatuses = []domain.ServiceStatus{
+		domain.ServiceStatusOperational,
+		domain.ServiceStatusDegraded,
+		domain.ServiceStatusPartialOutage,
+		domain.ServiceStatusMajorOutage,
+		domain.ServiceStatusMaintenance,
+	}
+)
+
+// Collector updates the ActiveEvents and Services gauges from the database.
+type Collector struct {
+	db *pgxpool.Pool
+}
+
+// NewCollector creates a new database gauge collector.
+func NewCollector(db *pgxpool.Pool) *Collector {
+	return &Collector{db: db}
+}
+
+// Collect queries event and service counts and updates the gauges.
+// Active events are those affecting effective status, as in v_service_effective_status.
+func (c *Collector) Collect(ctx context.Context) error {
+	activeEvents, err := c.countBy(ctx, `
+		SELECT type, COUNT(*) FROM events
+		WHERE status NOT IN ('resolved', 'completed', 'scheduled')
+		GROUP BY type
+	`)
+	if err != nil {
+		return fmt.Errorf("count active events: %w", err)
+	}
+
+	services, err := c.countBy(ctx, `
+		SELECT v.effective_status, COUNT(*)
+		FROM v_service_effective_status v
+		JOIN services s ON s.id = v.id
+		WHERE s.archived_at IS NULL
+		GROUP BY v.effective_status
+	`)
+	if err != nil {
+		return fmt.Errorf("count services: %w", err)
+	}
+
+	for _, t := range eventTypes {
+		ActiveEvents.WithLabelValues(string(t)).Set(float64(activeEvents[string(t)]))
+	}
+	for _, s := range serviceStatuses {
+		Services.WithLabelValues(string(s)).Set(float64(services[string(s)]))
+	}
+	return nil
+}
+
+// countBy runs a query returning (label, count) rows.
+func (c *Collector) countBy(ctx context.Context, query string) (map[string]int, error) {
+	rows, err := c.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var label string
+		var count int
+		if err := rows.Scan(&label, &count); err != nil {
+			return nil, err
+		}
+		counts[label] = count
+	}
+	return counts, rows.Err()
+}
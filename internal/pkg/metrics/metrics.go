@@ -8,6 +8,11 @@ import (
 
 const namespace = "incidentgarden"
 
+// apiNamespace prefixes the request and status metrics that are part of the
+// documented metrics contract; namespace is kept for the pre-existing series
+// so that existing dashboards keep working.
+const apiNamespace = "incident_garden"
+
 var (
 	// HTTPRequestDuration tracks HTTP request latency.
 	HTTPRequestDuration = promauto.NewHistogramVec(
@@ -21,6 +26,36 @@ var (
 		[]string{"method", "route", "status_code"},
 	)
 
+	// HTTPRequestsTotal counts HTTP requests.
+	HTTPRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: apiNamespace,
+			Name:      "api_requests_total",
+			Help:      "Total HTTP requests",
+		},
+		[]string{"method", "route", "status_code"},
+	)
+
+	// ActiveEvents tracks events affecting service status, see Collector.
+	ActiveEvents = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: apiNamespace,
+			Name:      "active_events_total",
+			Help:      "Number of active events (not scheduled, resolved or completed) by type",
+		},
+		[]string{"type"},
+	)
+
+	// Services tracks non-archived services by effective status, see Collector.
+	Services = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: apiNamespace,
+			Name:      "services_total",
+			Help:      "Number of non-archived services by effective status",
+		},
+		[]string{"status"},
+	)
+
 	// DBPoolConnections tracks database connection pool state.
 	DBPoolConnections = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -44,7 +44,7 @@ func Connect(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
 			lastErr = err
 			if attempt < attempts {
 				backoff := calcBackoff(attempt)
-				slog.Warn("failed to create connection pool, retrying",
+				slog.WarnContext(ctx, "failed to create connection pool, retrying",
 					"attempt", attempt,
 					"max_attempts", attempts,
 					"backoff", backoff,
@@ -62,7 +62,7 @@ func Connect(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
 			lastErr = err
 			if attempt < attempts {
 				backoff := calcBackoff(attempt)
-				slog.Warn("failed to ping database, retrying",
+				slog.WarnContext(ctx, "failed to ping database, retrying",
 					"attempt", attempt,
 					"max_attempts", attempts,
 					"backoff", backoff,
@@ -75,7 +75,7 @@ func Connect(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
 			continue
 		}
 
-		slog.Info("connected to database", "attempts", attempt)
+		slog.InfoContext(ctx, "connected to database", "attempts", attempt)
 		return pool, nil
 	}
 
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware starts a server span for each request, continuing the trace from
+// incoming traceparent headers. Requests to skipPaths (health checks, long-lived
+// streams) are not traced. Must be added to the root chi router.
+func Middleware(skipPaths ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return otelhttp.NewHandler(routeMiddleware(next), "http.request",
+			otelhttp.WithFilter(func(r *http.Request) bool {
+				for _, p := range skipPaths {
+					if r.URL.Path == p {
+						return false
+					}
+				}
+				return true
+			}),
+			otelhttp.WithSpanNameFormatter(spanName),
+		)
+	}
+}
+
+// spanName returns "METHOD /route/{pattern}", or just the method before the route is matched.
+func spanName(_ string, r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return r.Method + " " + pattern
+		}
+	}
+	return r.Method
+}
+
+// routeMiddleware adds http.route, event.id and service.slug span attributes
+// once the route is matched.
+func routeMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		rctx := chi.RouteContext(r.Context())
+		if rctx == nil {
+			return
+		}
+		pattern := rctx.RoutePattern()
+		if pattern == "" {
+			return
+		}
+
+		span := trace.SpanFromContext(r.Context())
+		span.SetAttributes(HTTPRouteKey.String(pattern))
+
+		route := strings.TrimPrefix(strings.TrimPrefix(pattern, "/api/v1"), "/api/v2")
+		switch {
+		case strings.HasPrefix(route, "/events/{id}"):
+			if id := rctx.URLParam("id"); id != "" {
+				span.SetAttributes(EventIDKey.String(id))
+			}
+		case strings.HasPrefix(route, "/services/{slug}"):
+			if slug := rctx.URLParam("slug"); slug != "" {
+				span.SetAttributes(ServiceSlugKey.String(slug))
+			}
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newRecorder installs a global tracer provider that records ended spans.
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func spanAttrs(span sdktrace.ReadOnlySpan) map[attribute.Key]string {
+	result := make(map[attribute.Key]string)
+	for _, kv := range span.Attributes() {
+		result[kv.Key] = kv.Value.Emit()
+	}
+	return result
+}
+
+func newTestRouter() *chi.Mux {
+	r := chi.NewRouter()
+	r.Use(Middleware("/healthz"))
+	r.Get("/healthz", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Get("/events/{id}/updates", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+		r.Route("/services", func(r chi.Router) {
+			r.Get("/{slug}", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNotFound) })
+		})
+	})
+	return r
+}
+
+func TestMiddleware_RouteAttributes(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		wantName  string
+		wantRoute string
+		wantAttrs map[attribute.Key]string
+		absent    []attribute.Key
+	}{
+		{
+			name:      "event route",
+			path:      "/api/v1/events/evt-1/updates",
+			wantName:  "GET /api/v1/events/{id}/updates",
+			wantRoute: "/api/v1/events/{id}/updates",
+			wantAttrs: map[attribute.Key]string{EventIDKey: "evt-1"},
+			absent:    []attribute.Key{ServiceSlugKey},
+		},
+		{
+			name:      "service route in subrouter",
+			path:      "/api/v1/services/api",
+			wantName:  "GET /api/v1/services/{slug}",
+			wantRoute: "/api/v1/services/{slug}",
+			wantAttrs: map[attribute.Key]string{ServiceSlugKey: "api"},
+			absent:    []attribute.Key{EventIDKey},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := newRecorder(t)
+			router := newTestRouter()
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, tt.wantName, span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+
+			attrs := spanAttrs(span)
+			assert.Equal(t, tt.wantRoute, attrs[HTTPRouteKey])
+			for key, want := range tt.wantAttrs {
+				assert.Equal(t, want, attrs[key], key)
+			}
+			for _, key := range tt.absent {
+				assert.NotContains(t, attrs, key)
+			}
+		})
+	}
+}
+
+func TestMiddleware_SkipPaths(t *testing.T) {
+	recorder := newRecorder(t)
+	router := newTestRouter()
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Empty(t, recorder.Ended())
+}
+
+func TestMiddleware_ContinuesIncomingTrace(t *testing.T) {
+	recorder := newRecorder(t)
+	_, err := Setup(t.Context(), Config{})
+	require.NoError(t, err)
+	router := newTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events/evt-1/updates", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+}
@@ -0,0 +1,103 @@
+// Package tracing provides OpenTelemetry tracing setup and span helpers.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span attribute keys.
+const (
+	DBSystemKey    = attribute.Key("db.system")
+	DBOperationKey = attribute.Key("db.operation")
+	DBTableKey     = attribute.Key("db.table")
+	HTTPRouteKey   = attribute.Key("http.route")
+	EventIDKey     = attribute.Key("event.id")
+	ServiceSlugKey = attribute.Key("service.slug")
+)
+
+// Database operations for the db.operation attribute.
+const (
+	OpSelect = "SELECT"
+	OpInsert = "INSERT"
+	OpUpdate = "UPDATE"
+	OpDelete = "DELETE"
+	OpBegin  = "BEGIN"
+)
+
+// Config contains tracing settings.
+type Config struct {
+	Endpoint    string // OTLP/HTTP collector URL, e.g. http://otel-collector:4318; empty disables export
+	ServiceName string
+}
+
+// Setup installs the global tracer provider and W3C trace context propagation.
+// With an empty endpoint nothing is exported and spans are no-ops.
+// The returned function flushes pending spans and must be called on shutdown.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if cfg.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("create otlp exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("create resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// StartDBSpan starts a client span for a repository call on a PostgreSQL table.
+// An empty table omits db.table, e.g. for BEGIN.
+func StartDBSpan(ctx context.Context, tracer trace.Tracer, name, operation, table string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		DBSystemKey.String("postgresql"),
+		DBOperationKey.String(operation),
+	)
+	if table != "" {
+		attrs = append(attrs, DBTableKey.String(table))
+	}
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// End records err, if any, on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
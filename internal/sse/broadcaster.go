@@ -0,0 +1,264 @@
+// Package sse provides Server-Sent Events streaming of live status updates.
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// Message types pushed to subscribers.
+const (
+	TypeEventCreated         = "event_created"
+	TypeEventUpdated         = "event_updated"
+	TypeServiceStatusChanged = "service_status_changed"
+)
+
+const (
+	defaultHeartbeatInterval = 30 * time.Second
+	defaultBufferSize        = 16
+)
+
+// Message is a single frame sent to subscribers as JSON in the "data:" field.
+type Message struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// ServiceStatusChange is the payload of a service_status_changed message.
+type ServiceStatusChange struct {
+	ServiceID       string               `json:"service_id"`
+	EffectiveStatus domain.ServiceStatus `json:"effective_status"`
+	PreviousStatus  domain.ServiceStatus `json:"previous_status"`
+}
+
+// StatusSnapshot maps service ID to its effective status at a point in time.
+type StatusSnapshot map[string]domain.ServiceStatus
+
+// StatusReader returns effective statuses of all active services.
+type StatusReader interface {
+	GetEffectiveStatuses(ctx context.Context) (map[string]domain.ServiceStatus, error)
+}
+
+// Publisher publishes live updates. Implemented by Broadcaster.
+// Handlers call it only after the corresponding transaction is committed.
+type Publisher interface {
+	Snapshot(ctx context.Context) StatusSnapshot
+	Publish(msgType string, data interface{})
+	PublishStatusChanges(before, after StatusSnapshot)
+}
+
+// Config holds broadcaster configuration.
+type Config struct {
+	HeartbeatInterval time.Duration // interval between keep-alive comments
+	BufferSize        int           // per-subscriber frame buffer
+}
+
+// Broadcaster fans out messages to all connected SSE clients.
+type Broadcaster struct {
+	config      Config
+	statuses    StatusReader
+	subscribers sync.Map // *subscriber -> struct{}
+	count       atomic.Int64
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+// subscriber is a single connected client.
+type subscriber struct {
+	frames   chan []byte
+	dropped  chan struct{}
+	dropOnce sync.Once
+}
+
+// NewBroadcaster creates a new broadcaster.
+// statuses may be nil, in which case service status changes are not published.
+func NewBroadcaster(config Config, statuses StatusReader) *Broadcaster {
+	if config.HeartbeatInterval == 0 {
+		config.HeartbeatInterval = defaultHeartbeatInterval
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = defaultBufferSize
+	}
+
+	return &Broadcaster{
+		config:   config,
+		statuses: statuses,
+		done:     make(chan struct{}),
+	}
+}
+
+// subscribe registers a new subscriber.
+func (b *Broadcaster) subscribe() *subscriber {
+	sub := &subscriber{
+		frames:  make(chan []byte, b.config.BufferSize),
+		dropped: make(chan struct{}),
+	}
+	b.subscribers.Store(sub, struct{}{})
+	b.count.Add(1)
+	return sub
+}
+
+// unsubscribe removes a subscriber. Safe to call more than once.
+func (b *Broadcaster) unsubscribe(sub *subscriber) {
+	if _, loaded := b.subscribers.LoadAndDelete(sub); loaded {
+		b.count.Add(-1)
+	}
+}
+
+// SubscriberCount returns the number of connected clients.
+func (b *Broadcaster) SubscriberCount() int {
+	return int(b.count.Load())
+}
+
+// Publish sends a message to all subscribers without blocking.
+// A subscriber whose buffer is full is disconnected: the client reconnects
+// and re-reads the current state instead of silently missing frames.
+func (b *Broadcaster) Publish(msgType string, data interface{}) {
+	if b.SubscriberCount() == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(Message{Type: msgType, Data: data})
+	if err != nil {
+		slog.Error("failed to marshal sse message", "type", msgType, "error", err)
+		return
+	}
+	frame := []byte(fmt.Sprintf("data: %s\n\n", payload))
+
+	b.subscribers.Range(func(key, _ interface{}) bool {
+		sub := key.(*subscriber)
+		select {
+		case sub.frames <- frame:
+		default:
+			slog.Warn("sse subscriber too slow, disconnecting", "type", msgType)
+			b.unsubscribe(sub)
+			sub.dropOnce.Do(func() { close(sub.dropped) })
+		}
+		return true
+	})
+}
+
+// Snapshot captures current effective statuses of all services.
+// Returns nil when nobody is listening or statuses are unavailable,
+// so callers don't pay for the query without subscribers.
+func (b *Broadcaster) Snapshot(ctx context.Context) StatusSnapshot {
+	if b.statuses == nil || b.SubscriberCount() == 0 {
+		return nil
+	}
+
+	statuses, err := b.statuses.GetEffectiveStatuses(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get effective statuses for sse", "error", err)
+		return nil
+	}
+	return statuses
+}
+
+// PublishStatusChanges publishes service_status_changed for every service
+// whose effective status differs between two snapshots.
+func (b *Broadcaster) PublishStatusChanges(before, after StatusSnapshot) {
+	if before == nil || after == nil {
+		return
+	}
+
+	ids := make([]string, 0, len(after))
+	for id := range after {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		prev, ok := before[id]
+		if !ok || prev == after[id] {
+			continue
+		}
+		b.Publish(TypeServiceStatusChanged, ServiceStatusChange{
+			ServiceID:       id,
+			EffectiveStatus: after[id],
+			PreviousStatus:  prev,
+		})
+	}
+}
+
+// Close stops all streams. Frames already buffered are flushed to clients
+// before their connections are closed. Safe to call more than once.
+func (b *Broadcaster) Close() {
+	b.closeOnce.Do(func() { close(b.done) })
+}
+
+// ServeHTTP handles GET /status/stream.
+func (b *Broadcaster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// Stream outlives the server write timeout
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		slog.Debug("failed to clear write deadline for sse stream", "error", err)
+	}
+
+	sub := b.subscribe()
+	defer b.unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write([]byte(": connected\n\n")); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(b.config.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case frame := <-sub.frames:
+			if _, err := w.Write(frame); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			if _, err := w.Write([]byte(": heartbeat\n\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-sub.dropped:
+			return
+		case <-r.Context().Done():
+			return
+		case <-b.done:
+			drain(w, sub)
+			flusher.Flush()
+			return
+		}
+	}
+}
+
+// drain writes frames still buffered for the subscriber.
+func drain(w http.ResponseWriter, sub *subscriber) {
+	for {
+		select {
+		case frame := <-sub.frames:
+			if _, err := w.Write(frame); err != nil {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
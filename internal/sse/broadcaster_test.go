@@ -0,0 +1,238 @@
+package sse
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubStatusReader struct {
+	statuses map[string]domain.ServiceStatus
+	err      error
+	calls    int
+}
+
+func (s *stubStatusReader) GetEffectiveStatuses(_ context.Context) (map[string]domain.ServiceStatus, error) {
+	s.calls++
+	return s.statuses, s.err
+}
+
+// newTestServer serves b. Registered before connect, so cleanups close
+// client connections first and server.Close doesn't wait on open streams.
+func newTestServer(t *testing.T, b *Broadcaster) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(b)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// connect opens a stream and returns a reader positioned after the initial comment.
+func connect(ctx context.Context, t *testing.T, url string) *bufio.Reader {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	reader := bufio.NewReader(resp.Body)
+	assert.Equal(t, ": connected", readFrame(t, reader))
+	return reader
+}
+
+// readFrame reads lines until a blank line and returns them joined.
+func readFrame(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			return strings.Join(lines, "\n")
+		}
+		lines = append(lines, line)
+	}
+}
+
+func decodeFrame(t *testing.T, frame string) Message {
+	t.Helper()
+	require.True(t, strings.HasPrefix(frame, "data: "), "unexpected frame: %q", frame)
+	var msg Message
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(frame, "data: ")), &msg))
+	return msg
+}
+
+func waitSubscribers(t *testing.T, b *Broadcaster, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool { return b.SubscriberCount() == n },
+		2*time.Second, 10*time.Millisecond, "expected %d subscribers", n)
+}
+
+func TestNewBroadcaster_Defaults(t *testing.T) {
+	b := NewBroadcaster(Config{}, nil)
+
+	assert.Equal(t, defaultHeartbeatInterval, b.config.HeartbeatInterval)
+	assert.Equal(t, defaultBufferSize, b.config.BufferSize)
+	assert.Equal(t, 0, b.SubscriberCount())
+}
+
+func TestBroadcaster_DeliversFrames(t *testing.T) {
+	b := NewBroadcaster(Config{}, nil)
+	server := newTestServer(t, b)
+
+	r1 := connect(context.Background(), t, server.URL)
+	r2 := connect(context.Background(), t, server.URL)
+	waitSubscribers(t, b, 2)
+
+	b.Publish(TypeEventCreated, map[string]string{"id": "evt-1"})
+
+	for _, reader := range []*bufio.Reader{r1, r2} {
+		msg := decodeFrame(t, readFrame(t, reader))
+		assert.Equal(t, TypeEventCreated, msg.Type)
+		assert.Equal(t, map[string]interface{}{"id": "evt-1"}, msg.Data)
+	}
+}
+
+func TestBroadcaster_Heartbeat(t *testing.T) {
+	b := NewBroadcaster(Config{HeartbeatInterval: 20 * time.Millisecond}, nil)
+	server := newTestServer(t, b)
+
+	reader := connect(context.Background(), t, server.URL)
+
+	assert.Equal(t, ": heartbeat", readFrame(t, reader))
+}
+
+func TestBroadcaster_ClientDisconnectCleanup(t *testing.T) {
+	b := NewBroadcaster(Config{}, nil)
+	server := newTestServer(t, b)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	connect(ctx, t, server.URL)
+	waitSubscribers(t, b, 1)
+
+	cancel()
+	waitSubscribers(t, b, 0)
+
+	// Publishing without subscribers is a no-op
+	b.Publish(TypeEventCreated, nil)
+}
+
+func TestBroadcaster_SlowSubscriberDisconnected(t *testing.T) {
+	b := NewBroadcaster(Config{BufferSize: 1}, nil)
+	sub := b.subscribe()
+
+	b.Publish(TypeEventUpdated, 1)
+	b.Publish(TypeEventUpdated, 2) // buffer full
+
+	assert.Equal(t, 0, b.SubscriberCount())
+	select {
+	case <-sub.dropped:
+	default:
+		t.Fatal("slow subscriber should be dropped")
+	}
+
+	// Further publishes don't panic on dropped subscriber
+	b.Publish(TypeEventUpdated, 3)
+}
+
+func TestBroadcaster_CloseDrainsBufferedFrames(t *testing.T) {
+	b := NewBroadcaster(Config{}, nil)
+	sub := b.subscribe()
+
+	b.Publish(TypeEventCreated, "first")
+	b.Publish(TypeEventUpdated, "second")
+
+	rec := httptest.NewRecorder()
+	b.Close()
+	drain(rec, sub)
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `"type":"event_created"`)
+	assert.Contains(t, body, `"type":"event_updated"`)
+	assert.Empty(t, sub.frames)
+}
+
+func TestBroadcaster_CloseEndsStreams(t *testing.T) {
+	b := NewBroadcaster(Config{}, nil)
+	server := newTestServer(t, b)
+
+	reader := connect(context.Background(), t, server.URL)
+	waitSubscribers(t, b, 1)
+
+	b.Close()
+	b.Close() // idempotent
+
+	_, err := reader.ReadString('\n')
+	assert.Error(t, err, "stream should end after Close")
+	waitSubscribers(t, b, 0)
+}
+
+func TestBroadcaster_Snapshot(t *testing.T) {
+	reader := &stubStatusReader{statuses: map[string]domain.ServiceStatus{"svc-1": domain.ServiceStatusOperational}}
+	b := NewBroadcaster(Config{}, reader)
+
+	assert.Nil(t, b.Snapshot(context.Background()), "no query without subscribers")
+	assert.Equal(t, 0, reader.calls)
+
+	b.subscribe()
+	assert.Equal(t, StatusSnapshot{"svc-1": domain.ServiceStatusOperational}, b.Snapshot(context.Background()))
+
+	reader.err = errors.New("db down")
+	assert.Nil(t, b.Snapshot(context.Background()))
+}
+
+func TestBroadcaster_PublishStatusChanges(t *testing.T) {
+	b := NewBroadcaster(Config{}, nil)
+	sub := b.subscribe()
+
+	before := StatusSnapshot{
+		"svc-a": domain.ServiceStatusOperational,
+		"svc-b": domain.ServiceStatusOperational,
+		"svc-c": domain.ServiceStatusDegraded,
+	}
+	after := StatusSnapshot{
+		"svc-a": domain.ServiceStatusMajorOutage,
+		"svc-b": domain.ServiceStatusOperational,
+		"svc-c": domain.ServiceStatusOperational,
+		"svc-d": domain.ServiceStatusOperational, // new service, not a change
+	}
+
+	b.PublishStatusChanges(before, after)
+	b.PublishStatusChanges(nil, after)
+	b.PublishStatusChanges(before, nil)
+
+	require.Len(t, sub.frames, 2)
+
+	var changes []ServiceStatusChange
+	for i := 0; i < 2; i++ {
+		frame := strings.TrimSpace(string(<-sub.frames))
+		var msg struct {
+			Type string              `json:"type"`
+			Data ServiceStatusChange `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(frame, "data: ")), &msg))
+		assert.Equal(t, TypeServiceStatusChanged, msg.Type)
+		changes = append(changes, msg.Data)
+	}
+
+	assert.Equal(t, []ServiceStatusChange{
+		{ServiceID: "svc-a", EffectiveStatus: domain.ServiceStatusMajorOutage, PreviousStatus: domain.ServiceStatusOperational},
+		{ServiceID: "svc-c", EffectiveStatus: domain.ServiceStatusOperational, PreviousStatus: domain.ServiceStatusDegraded},
+	}, changes)
+}
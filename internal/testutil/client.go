@@ -138,6 +138,11 @@ func (c *Client) POST(path string, body interface{}) (*http.Response, error) {
 	return c.do("POST", path, body)
 }
 
+// POSTWithHeaders performs a POST request with JSON body and extra request headers.
+func (c *Client) POSTWithHeaders(path string, body interface{}, headers map[string]string) (*http.Response, error) {
+	return c.doWithHeaders("POST", path, body, headers)
+}
+
 // PUT performs a PUT request with JSON body.
 func (c *Client) PUT(path string, body interface{}) (*http.Response, error) {
 	return c.do("PUT", path, body)
@@ -159,6 +164,10 @@ func (c *Client) DELETEWithBody(path string, body interface{}) (*http.Response,
 }
 
 func (c *Client) do(method, path string, body interface{}) (*http.Response, error) {
+	return c.doWithHeaders(method, path, body, nil)
+}
+
+func (c *Client) doWithHeaders(method, path string, body interface{}, headers map[string]string) (*http.Response, error) {
 	var bodyReader io.Reader
 	var bodyBytes []byte
 
@@ -177,6 +186,9 @@ func (c *Client) do(method, path string, body interface{}) (*http.Response, erro
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
 
 	// Use Authorization header if Token is set (backward compatibility for API clients)
 	if c.Token != "" {
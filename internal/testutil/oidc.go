@@ -0,0 +1,159 @@
+package testutil
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// OIDCServer is a mock OpenID Connect provider: discovery, token endpoint and JWKS.
+// Codes are issued directly with IssueCode; the authorization endpoint only answers 200.
+type OIDCServer struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	mu    sync.Mutex
+	key   *rsa.PrivateKey
+	kid   string
+	codes map[string]jwt.MapClaims
+}
+
+// NewOIDCServer starts a mock OIDC provider. Close it when done.
+func NewOIDCServer(clientID, clientSecret string) *OIDCServer {
+	s := &OIDCServer{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		codes:        make(map[string]jwt.MapClaims),
+	}
+	s.RotateKey()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", s.handleDiscovery)
+	mux.HandleFunc("GET /authorize", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	mux.HandleFunc("POST /token", s.handleToken)
+	mux.HandleFunc("GET /jwks", s.handleJWKS)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Issuer returns the issuer URL of the mock provider.
+func (s *OIDCServer) Issuer() string {
+	return s.URL
+}
+
+// RotateKey replaces the signing key; tokens issued afterwards carry a new kid.
+func (s *OIDCServer) RotateKey() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.key = key
+	s.kid = randomHex(8)
+}
+
+// IssueCode returns an authorization code redeemable once for an ID token with claims.
+// iss, aud, iat and exp default to valid values and can be overridden by claims.
+func (s *OIDCServer) IssueCode(claims map[string]interface{}) string {
+	idClaims := jwt.MapClaims{
+		"iss": s.Issuer(),
+		"aud": s.ClientID,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(5 * time.Minute).Unix(),
+	}
+	for k, v := range claims {
+		idClaims[k] = v
+	}
+
+	code := randomHex(16)
+	s.mu.Lock()
+	s.codes[code] = idClaims
+	s.mu.Unlock()
+	return code
+}
+
+func (s *OIDCServer) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	writeOIDCJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 s.Issuer(),
+		"authorization_endpoint": s.URL + "/authorize",
+		"token_endpoint":         s.URL + "/token",
+		"jwks_uri":               s.URL + "/jwks",
+	})
+}
+
+func (s *OIDCServer) handleToken(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.FormValue("client_id"), r.FormValue("client_secret")
+	}
+	if clientID != s.ClientID || clientSecret != s.ClientSecret {
+		writeOIDCJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	s.mu.Lock()
+	claims, found := s.codes[r.FormValue("code")]
+	delete(s.codes, r.FormValue("code"))
+	key, kid := s.key, s.kid
+	s.mu.Unlock()
+
+	if r.FormValue("grant_type") != "authorization_code" || !found {
+		writeOIDCJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	idToken, err := token.SignedString(key)
+	if err != nil {
+		writeOIDCJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeOIDCJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomHex(16),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func (s *OIDCServer) handleJWKS(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	key, kid := s.key, s.kid
+	s.mu.Unlock()
+
+	writeOIDCJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": kid,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+}
+
+func writeOIDCJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
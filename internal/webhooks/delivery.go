@@ -0,0 +1,230 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+)
+
+// Delivery processing statuses.
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusProcessed = "processed"
+	DeliveryStatusFailed    = "failed"
+)
+
+// Delivery errors.
+var (
+	ErrDeliveryNotFound      = errors.New("webhook delivery not found")
+	ErrInvalidDeliveryStatus = errors.New("invalid status: must be one of pending, processed, failed")
+	ErrDeliveryNotReplayable = errors.New("webhook delivery can't be replayed")
+)
+
+// maxErrorBodySize limits the part of a handler response kept to extract the error message.
+const maxErrorBodySize = 4 << 10
+
+// Delivery is an authenticated inbound webhook request and the outcome of its processing.
+type Delivery struct {
+	ID               string          `json:"id"`
+	Source           string          `json:"source"`
+	ReceivedAt       time.Time       `json:"received_at"`
+	Payload          json.RawMessage `json:"payload"` // nil if the body was not valid JSON
+	ProcessingStatus string          `json:"processing_status"`
+	ErrorMessage     string          `json:"error_message,omitempty"`
+}
+
+// DeliveryFilter selects deliveries; empty fields match all.
+type DeliveryFilter struct {
+	Source string
+	Status string
+	Limit  int
+	Offset int
+}
+
+// DeliveryRepository stores webhook deliveries.
+// This interface is implemented by postgres.Repository.
+type DeliveryRepository interface {
+	// CreateDelivery stores a pending delivery and sets its ID and ReceivedAt.
+	CreateDelivery(ctx context.Context, delivery *Delivery) error
+	// FinishDelivery sets the processing status and error message of a delivery.
+	FinishDelivery(ctx context.Context, id, status, errorMessage string) error
+	// GetDelivery returns ErrDeliveryNotFound for an unknown ID.
+	GetDelivery(ctx context.Context, id string) (*Delivery, error)
+	// ListDeliveries returns matching deliveries, newest first, with their total count.
+	ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]Delivery, int, error)
+}
+
+// DeliveryLog records inbound webhook requests and replays them.
+type DeliveryLog struct {
+	repo DeliveryRepository
+
+	mu       sync.RWMutex
+	handlers map[string]http.Handler // replay target by source
+}
+
+// NewDeliveryLog creates a new webhook delivery log.
+func NewDeliveryLog(repo DeliveryRepository) *DeliveryLog {
+	return &DeliveryLog{
+		repo:     repo,
+		handlers: make(map[string]http.Handler),
+	}
+}
+
+// Middleware records every request of a webhook route as a delivery of source
+// before passing it on, and its outcome after: processed for 2xx/3xx responses,
+// failed with the response error message otherwise. The wrapped handler becomes
+// the replay target of source, so the middleware must come after authentication.
+// A delivery that can't be stored is logged; the webhook is processed anyway.
+func (l *DeliveryLog) Middleware(source string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		l.mu.Lock()
+		l.handlers[source] = next
+		l.mu.Unlock()
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+			if err != nil {
+				httputil.Error(w, http.StatusBadRequest, "invalid body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			delivery := &Delivery{Source: source}
+			if json.Valid(body) {
+				delivery.Payload = body
+			}
+			if err := l.repo.CreateDelivery(r.Context(), delivery); err != nil {
+				slog.ErrorContext(r.Context(), "failed to record webhook delivery", "source", source, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+			l.finish(r.Context(), delivery.ID, rec)
+		})
+	}
+}
+
+// Replay submits the stored payload of a delivery to the handler of its source
+// again and records the new outcome on the same delivery.
+// Returns ErrDeliveryNotReplayable if the payload is missing or the source is not enabled.
+func (l *DeliveryLog) Replay(ctx context.Context, id string) (*Delivery, error) {
+	delivery, err := l.repo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.Payload == nil {
+		return nil, fmt.Errorf("%w: payload is not valid JSON", ErrDeliveryNotReplayable)
+	}
+
+	l.mu.RLock()
+	handler, ok := l.handlers[delivery.Source]
+	l.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: source %s is not enabled", ErrDeliveryNotReplayable, delivery.Source)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/webhooks/"+delivery.Source, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("build replay request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := &responseRecorder{}
+	handler.ServeHTTP(rec, req)
+	l.finish(ctx, delivery.ID, rec)
+
+	return l.repo.GetDelivery(ctx, id)
+}
+
+// ListDeliveries returns deliveries matching the filter with their total count.
+func (l *DeliveryLog) ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]Delivery, int, error) {
+	switch filter.Status {
+	case "", DeliveryStatusPending, DeliveryStatusProcessed, DeliveryStatusFailed:
+	default:
+		return nil, 0, ErrInvalidDeliveryStatus
+	}
+	return l.repo.ListDeliveries(ctx, filter)
+}
+
+// finish stores the outcome of a handler response.
+func (l *DeliveryLog) finish(ctx context.Context, id string, rec *responseRecorder) {
+	status, message := DeliveryStatusProcessed, ""
+	if rec.statusCode() >= http.StatusBadRequest {
+		status, message = DeliveryStatusFailed, rec.errorMessage()
+	}
+	if err := l.repo.FinishDelivery(context.WithoutCancel(ctx), id, status, message); err != nil {
+		slog.ErrorContext(ctx, "failed to record webhook delivery outcome", "delivery_id", id, "error", err)
+	}
+}
+
+// responseRecorder captures the status code and the beginning of the body of a response.
+// Without a ResponseWriter (replay) the response is only captured.
+type responseRecorder struct {
+	http.ResponseWriter
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *responseRecorder) Header() http.Header {
+	if rec.ResponseWriter != nil {
+		return rec.ResponseWriter.Header()
+	}
+	if rec.header == nil {
+		rec.header = make(http.Header)
+	}
+	return rec.header
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	if rec.ResponseWriter != nil {
+		rec.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	if room := maxErrorBodySize - rec.body.Len(); room > 0 {
+		rec.body.Write(b[:min(len(b), room)])
+	}
+	if rec.ResponseWriter != nil {
+		return rec.ResponseWriter.Write(b)
+	}
+	return len(b), nil
+}
+
+func (rec *responseRecorder) statusCode() int {
+	if rec.status == 0 {
+		return http.StatusOK
+	}
+	return rec.status
+}
+
+// errorMessage returns the message of an {"error":{"message":...}} response, else the status text.
+func (rec *responseRecorder) errorMessage() string {
+	var resp struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(rec.body.Bytes(), &resp); err == nil && resp.Error.Message != "" {
+		return resp.Error.Message
+	}
+	return fmt.Sprintf("webhook handler returned %d %s", rec.statusCode(), http.StatusText(rec.statusCode()))
+}
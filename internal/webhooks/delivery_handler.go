@@ -0,0 +1,96 @@
+package webhooks
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// Pagination of the delivery log.
+const (
+	DefaultDeliveriesLimit = 20
+	MaxDeliveriesLimit     = 100
+)
+
+var deliveryErrorMappings = []httputil.ErrorMapping{
+	{Error: ErrDeliveryNotFound, Status: http.StatusNotFound, Message: "webhook delivery not found"},
+	{Error: ErrInvalidDeliveryStatus, Status: http.StatusBadRequest},
+	{Error: ErrDeliveryNotReplayable, Status: http.StatusConflict},
+}
+
+// DeliveryHandler serves the webhook delivery log API.
+type DeliveryHandler struct {
+	log *DeliveryLog
+}
+
+// NewDeliveryHandler creates a new webhook delivery log handler.
+func NewDeliveryHandler(log *DeliveryLog) *DeliveryHandler {
+	return &DeliveryHandler{log: log}
+}
+
+// RegisterAdminRoutes registers routes for the delivery log (admin only).
+func (h *DeliveryHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/admin/webhooks/deliveries", h.ListDeliveries)
+	r.Patch("/admin/webhooks/deliveries/{id}/replay", h.ReplayDelivery)
+}
+
+// ListDeliveries handles GET /admin/webhooks/deliveries.
+func (h *DeliveryHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := DeliveryFilter{
+		Source: query.Get("source"),
+		Status: query.Get("status"),
+		Limit:  DefaultDeliveriesLimit,
+	}
+
+	if l := query.Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 {
+			httputil.Error(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		filter.Limit = min(parsed, MaxDeliveriesLimit)
+	}
+
+	if o := query.Get("offset"); o != "" {
+		parsed, err := strconv.Atoi(o)
+		if err != nil || parsed < 0 {
+			httputil.Error(w, http.StatusBadRequest, "offset must be a non-negative integer")
+			return
+		}
+		filter.Offset = parsed
+	}
+
+	deliveries, total, err := h.log.ListDeliveries(r.Context(), filter)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, deliveryErrorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, map[string]interface{}{
+		"deliveries": deliveries,
+		"total":      total,
+		"limit":      filter.Limit,
+		"offset":     filter.Offset,
+	})
+}
+
+// ReplayDelivery handles PATCH /admin/webhooks/deliveries/{id}/replay.
+func (h *DeliveryHandler) ReplayDelivery(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		httputil.Error(w, http.StatusNotFound, "webhook delivery not found")
+		return
+	}
+
+	delivery, err := h.log.Replay(r.Context(), id)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, deliveryErrorMappings)
+		return
+	}
+
+	httputil.Success(w, http.StatusOK, delivery)
+}
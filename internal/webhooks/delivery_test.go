@@ -0,0 +1,173 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubDeliveryRepo struct {
+	deliveries map[string]*Delivery
+	createErr  error
+}
+
+func newStubDeliveryRepo() *stubDeliveryRepo {
+	return &stubDeliveryRepo{deliveries: make(map[string]*Delivery)}
+}
+
+func (r *stubDeliveryRepo) CreateDelivery(_ context.Context, d *Delivery) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	d.ID = fmt.Sprintf("delivery-%d", len(r.deliveries)+1)
+	d.ProcessingStatus = DeliveryStatusPending
+	stored := *d
+	r.deliveries[d.ID] = &stored
+	return nil
+}
+
+func (r *stubDeliveryRepo) FinishDelivery(_ context.Context, id, status, errorMessage string) error {
+	d, ok := r.deliveries[id]
+	if !ok {
+		return ErrDeliveryNotFound
+	}
+	d.ProcessingStatus = status
+	d.ErrorMessage = errorMessage
+	return nil
+}
+
+func (r *stubDeliveryRepo) GetDelivery(_ context.Context, id string) (*Delivery, error) {
+	d, ok := r.deliveries[id]
+	if !ok {
+		return nil, ErrDeliveryNotFound
+	}
+	found := *d
+	return &found, nil
+}
+
+func (r *stubDeliveryRepo) ListDeliveries(_ context.Context, _ DeliveryFilter) ([]Delivery, int, error) {
+	return nil, 0, nil
+}
+
+// failingHandler fails requests with "fail" in the body and echoes the body it received.
+func failingHandler(received *[]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		*received = append(*received, string(b))
+		if strings.Contains(string(b), "fail") {
+			httputil.Error(w, http.StatusBadRequest, "unknown service")
+			return
+		}
+		httputil.Success(w, http.StatusOK, nil)
+	})
+}
+
+func serveDelivery(handler http.Handler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/test", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDeliveryLog_Middleware(t *testing.T) {
+	repo := newStubDeliveryRepo()
+	var received []string
+	handler := NewDeliveryLog(repo).Middleware("test")(failingHandler(&received))
+
+	tests := []struct {
+		name        string
+		body        string
+		wantCode    int
+		wantStatus  string
+		wantMessage string
+		wantPayload bool
+	}{
+		{"processed", `{"ok":true}`, http.StatusOK, DeliveryStatusProcessed, "", true},
+		{"failed", `{"fail":true}`, http.StatusBadRequest, DeliveryStatusFailed, "unknown service", true},
+		{"invalid json", `fail`, http.StatusBadRequest, DeliveryStatusFailed, "unknown service", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveDelivery(handler, tt.body)
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, tt.body, received[len(received)-1], "handler gets the full body")
+
+			d, err := repo.GetDelivery(context.Background(), fmt.Sprintf("delivery-%d", len(repo.deliveries)))
+			require.NoError(t, err)
+			assert.Equal(t, "test", d.Source)
+			assert.Equal(t, tt.wantStatus, d.ProcessingStatus)
+			assert.Equal(t, tt.wantMessage, d.ErrorMessage)
+			assert.Equal(t, tt.wantPayload, d.Payload != nil)
+		})
+	}
+}
+
+func TestDeliveryLog_Middleware_RepositoryError(t *testing.T) {
+	repo := newStubDeliveryRepo()
+	repo.createErr = errors.New("db down")
+	var received []string
+	handler := NewDeliveryLog(repo).Middleware("test")(failingHandler(&received))
+
+	rec := serveDelivery(handler, `{"ok":true}`)
+	assert.Equal(t, http.StatusOK, rec.Code, "webhook is processed without a delivery record")
+	assert.Equal(t, []string{`{"ok":true}`}, received)
+}
+
+func TestDeliveryLog_Replay(t *testing.T) {
+	repo := newStubDeliveryRepo()
+	log := NewDeliveryLog(repo)
+	fail := true
+	var received []string
+	handler := log.Middleware("test")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		received = append(received, string(b))
+		if fail {
+			httputil.Error(w, http.StatusBadRequest, "unknown service")
+			return
+		}
+		httputil.Success(w, http.StatusOK, nil)
+	}))
+
+	serveDelivery(handler, `{"alert":1}`)
+	require.Equal(t, DeliveryStatusFailed, repo.deliveries["delivery-1"].ProcessingStatus)
+
+	fail = false
+	d, err := log.Replay(context.Background(), "delivery-1")
+	require.NoError(t, err)
+	assert.Equal(t, DeliveryStatusProcessed, d.ProcessingStatus)
+	assert.Empty(t, d.ErrorMessage)
+	assert.Equal(t, []string{`{"alert":1}`, `{"alert":1}`}, received)
+	assert.Len(t, repo.deliveries, 1, "replay updates the same delivery")
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := log.Replay(context.Background(), "missing")
+		assert.ErrorIs(t, err, ErrDeliveryNotFound)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		serveDelivery(handler, `not json`)
+		_, err := log.Replay(context.Background(), "delivery-2")
+		assert.ErrorIs(t, err, ErrDeliveryNotReplayable)
+	})
+
+	t.Run("source not enabled", func(t *testing.T) {
+		repo.deliveries["other"] = &Delivery{ID: "other", Source: "disabled", Payload: []byte(`{}`)}
+		_, err := log.Replay(context.Background(), "other")
+		assert.ErrorIs(t, err, ErrDeliveryNotReplayable)
+	})
+}
+
+func TestDeliveryLog_ListDeliveries_InvalidStatus(t *testing.T) {
+	log := NewDeliveryLog(newStubDeliveryRepo())
+	_, _, err := log.ListDeliveries(context.Background(), DeliveryFilter{Status: "done"})
+	assert.ErrorIs(t, err, ErrInvalidDeliveryStatus)
+}
@@ -0,0 +1,207 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+)
+
+// maxBodySize limits inbound webhook bodies read for signature verification.
+const maxBodySize = 1 << 20
+
+// hmacAlgorithms maps supported algorithm names to hash constructors.
+var hmacAlgorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// RotationStore persists completed secret rotations, so every replica stops accepting
+// a previous secret that one of them retired. Secrets are identified by fingerprint.
+type RotationStore interface {
+	// RetireSecret records the previous secret as retired.
+	// Returns false if it already was.
+	RetireSecret(ctx context.Context, source, fingerprint string) (bool, error)
+	IsSecretRetired(ctx context.Context, source, fingerprint string) (bool, error)
+}
+
+// SigningSecrets holds the signing secret of a webhook source and, while the secret
+// is being rotated, the previous one. Safe for concurrent use.
+// Without a RotationStore a completed rotation is kept in memory of this instance only.
+type SigningSecrets struct {
+	mu       sync.RWMutex
+	primary  []byte
+	previous []byte
+	store    RotationStore
+	source   string
+}
+
+// NewSigningSecrets creates signing secrets; an empty previous secret means no rotation.
+func NewSigningSecrets(primary, previous []byte) *SigningSecrets {
+	return &SigningSecrets{primary: primary, previous: previous}
+}
+
+// WithRotationStore persists completed rotations of the source's secrets in store.
+func (s *SigningSecrets) WithRotationStore(store RotationStore, source string) *SigningSecrets {
+	s.store = store
+	s.source = source
+	return s
+}
+
+// RotationActive reports whether the previous secret is still accepted.
+func (s *SigningSecrets) RotationActive(ctx context.Context) (bool, error) {
+	_, previous := s.get()
+	if len(previous) == 0 {
+		return false, nil
+	}
+	retired, err := s.isRetired(ctx, previous)
+	if err != nil {
+		return false, err
+	}
+	return !retired, nil
+}
+
+// CompleteRotation stops accepting the previous secret.
+// Returns false if no rotation was active.
+func (s *SigningSecrets) CompleteRotation(ctx context.Context) (bool, error) {
+	_, previous := s.get()
+	if len(previous) == 0 {
+		return false, nil
+	}
+
+	retired := true
+	if s.store != nil {
+		var err error
+		retired, err = s.store.RetireSecret(ctx, s.source, fingerprint(previous))
+		if err != nil {
+			return false, fmt.Errorf("retire previous secret: %w", err)
+		}
+	}
+	s.forgetPrevious()
+	return retired, nil
+}
+
+// isRetired reports whether the previous secret was retired, by this or another instance.
+func (s *SigningSecrets) isRetired(ctx context.Context, previous []byte) (bool, error) {
+	if s.store == nil {
+		return false, nil
+	}
+	retired, err := s.store.IsSecretRetired(ctx, s.source, fingerprint(previous))
+	if err != nil {
+		return false, fmt.Errorf("check previous secret: %w", err)
+	}
+	if retired {
+		s.forgetPrevious()
+	}
+	return retired, nil
+}
+
+func (s *SigningSecrets) forgetPrevious() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.previous = nil
+}
+
+func (s *SigningSecrets) get() (primary, previous []byte) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.primary, s.previous
+}
+
+// fingerprint identifies a secret without storing it.
+func fingerprint(secret []byte) string {
+	sum := sha256.Sum256(secret)
+	return hex.EncodeToString(sum[:])
+}
+
+// HMACMiddleware authenticates inbound webhooks by an HMAC of the raw body.
+// The header holds hex signatures, comma-separated during secret rotation,
+// each prefixed with label and "=" (e.g. "v1=<hex>"), or bare when label is empty.
+// Signatures with another label are ignored.
+// Any signature matching the primary secret, or else the previous one during
+// rotation (unless it was retired, see RotationStore), passes; otherwise the
+// request gets 401. An empty secret never verifies. The body is restored for the next handler.
+// It panics on an unsupported algorithm, so that the mistake fails at startup
+// when routes are registered.
+func HMACMiddleware(secrets *SigningSecrets, headerName, algorithm, label string) func(http.Handler) http.Handler {
+	newHash, supported := hmacAlgorithms[algorithm]
+	if !supported {
+		panic(fmt.Sprintf("webhooks: unsupported HMAC algorithm %q", algorithm))
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+			if err != nil {
+				httputil.Error(w, http.StatusBadRequest, "invalid body")
+				return
+			}
+
+			primary, previous := secrets.get()
+			header := r.Header.Get(headerName)
+			if !verifyHMAC(newHash, primary, body, header, label) {
+				if !verifyHMAC(newHash, previous, body, header, label) {
+					httputil.Error(w, http.StatusUnauthorized, "invalid signature")
+					return
+				}
+				// Another instance may have completed the rotation
+				retired, err := secrets.isRetired(r.Context(), previous)
+				if err != nil {
+					slog.ErrorContext(r.Context(), "failed to check webhook secret rotation", "path", r.URL.Path, "error", err)
+					httputil.Error(w, http.StatusInternalServerError, "internal error")
+					return
+				}
+				if retired {
+					httputil.Error(w, http.StatusUnauthorized, "invalid signature")
+					return
+				}
+				slog.DebugContext(r.Context(), "webhook signed with the previous secret", "path", r.URL.Path)
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func verifyHMAC(newHash func() hash.Hash, secret, body []byte, header, label string) bool {
+	if len(secret) == 0 || header == "" {
+		return false
+	}
+
+	mac := hmac.New(newHash, secret)
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	for _, sig := range strings.Split(header, ",") {
+		value := strings.TrimSpace(sig)
+		if label != "" {
+			var found bool
+			value, found = strings.CutPrefix(value, label+"=")
+			if !found {
+				continue
+			}
+		}
+		decoded, err := hex.DecodeString(value)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(decoded, expected) {
+			return true
+		}
+	}
+	return false
+}
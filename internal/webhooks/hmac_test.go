@@ -0,0 +1,219 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testHMACHeader = "X-Signature"
+	testHMACBody   = `{"a":1}`
+	// Reference value: echo -n '{"a":1}' | openssl dgst -sha256 -hmac key
+	testHMACSignature = "88a67f24bbcdaed0e6c997404bb79a743baf44c6bab2f4c27328e3009d22e342"
+)
+
+// serveSigned sends body with the signature header through the middleware
+// and returns the response and the body seen by the next handler.
+func serveSigned(secret, algorithm, body, header string) (*httptest.ResponseRecorder, string) {
+	var received string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		received = string(b)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/test", strings.NewReader(body))
+	if header != "" {
+		req.Header.Set(testHMACHeader, header)
+	}
+	rec := httptest.NewRecorder()
+	HMACMiddleware(NewSigningSecrets([]byte(secret), nil), testHMACHeader, algorithm, "v1")(next).ServeHTTP(rec, req)
+	return rec, received
+}
+
+func TestHMACMiddleware_ValidSignature(t *testing.T) {
+	for _, header := range []string{
+		"v1=" + testHMACSignature,
+		" v1=" + testHMACSignature + " ",
+		"v1=deadbeef, v1=" + testHMACSignature, // secret rotation
+		"v0=" + testHMACSignature + ",v1=" + testHMACSignature,
+	} {
+		t.Run(header, func(t *testing.T) {
+			rec, received := serveSigned("key", "sha256", testHMACBody, header)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, testHMACBody, received, "body is restored for the next handler")
+		})
+	}
+}
+
+func TestHMACMiddleware_Rejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret string
+		body   string
+		header string
+	}{
+		{"tampered body", "key", `{"a":2}`, "v1=" + testHMACSignature},
+		{"missing header", "key", testHMACBody, ""},
+		{"wrong secret", "other", testHMACBody, "v1=" + testHMACSignature},
+		{"not hex", "key", testHMACBody, "v1=zz"},
+		{"other label", "key", testHMACBody, "sha256=" + testHMACSignature},
+		{"no label", "key", testHMACBody, testHMACSignature},
+		{"empty secret", "", testHMACBody, "v1=" + testHMACSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, received := serveSigned(tt.secret, "sha256", tt.body, tt.header)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Empty(t, received, "next handler must not run")
+		})
+	}
+}
+
+func TestHMACMiddleware_OtherAlgorithms(t *testing.T) {
+	// Reference values: echo -n '{"a":1}' | openssl dgst -sha1|-sha512 -hmac key
+	signatures := map[string]string{
+		"sha1":   "b5557a4b8f3cc308d19eb4f69f336392a31eef7b",
+		"sha512": "dc7d8a6a7926059e98697ef54a65f745d924fc3e13c1176879dcdc84eac480bae377fa06d1ebcc6fab1375cd9bd611a1899694cc4ca7dc0f629d8b8133c5c83d",
+	}
+	for algorithm, signature := range signatures {
+		t.Run(algorithm, func(t *testing.T) {
+			rec, _ := serveSigned("key", algorithm, testHMACBody, "v1="+signature)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			// A SHA-256 signature does not pass another algorithm
+			rec, _ = serveSigned("key", algorithm, testHMACBody, "v1="+testHMACSignature)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		})
+	}
+}
+
+func TestHMACMiddleware_Unlabeled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	middleware := HMACMiddleware(NewSigningSecrets([]byte("key"), nil), testHMACHeader, "sha256", "")
+	for header, want := range map[string]int{
+		testHMACSignature:         http.StatusOK,
+		"v1=" + testHMACSignature: http.StatusUnauthorized,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/test", strings.NewReader(testHMACBody))
+		req.Header.Set(testHMACHeader, header)
+		rec := httptest.NewRecorder()
+		middleware(next).ServeHTTP(rec, req)
+		assert.Equal(t, want, rec.Code, header)
+	}
+}
+
+func TestHMACMiddleware_UnsupportedAlgorithm(t *testing.T) {
+	// Fails when routes are registered, not on the first request
+	assert.Panics(t, func() {
+		HMACMiddleware(NewSigningSecrets([]byte("key"), nil), testHMACHeader, "md5", "v1")
+	})
+}
+
+func TestHMACMiddleware_SecretRotation(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	serve := func(secrets *SigningSecrets, header string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/test", strings.NewReader(testHMACBody))
+		req.Header.Set(testHMACHeader, header)
+		rec := httptest.NewRecorder()
+		HMACMiddleware(secrets, testHMACHeader, "sha256", "v1")(next).ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("signed with the new secret", func(t *testing.T) {
+		secrets := NewSigningSecrets([]byte("key"), []byte("old"))
+		assert.Equal(t, http.StatusOK, serve(secrets, "v1="+testHMACSignature))
+	})
+
+	t.Run("signed with the previous secret", func(t *testing.T) {
+		ctx := context.Background()
+		secrets := NewSigningSecrets([]byte("new"), []byte("key"))
+		assertRotationActive(t, secrets, true)
+		assert.Equal(t, http.StatusOK, serve(secrets, "v1="+testHMACSignature))
+
+		// Rejected once the rotation is completed
+		completed, err := secrets.CompleteRotation(ctx)
+		require.NoError(t, err)
+		assert.True(t, completed)
+		assertRotationActive(t, secrets, false)
+		assert.Equal(t, http.StatusUnauthorized, serve(secrets, "v1="+testHMACSignature))
+		completed, err = secrets.CompleteRotation(ctx)
+		require.NoError(t, err)
+		assert.False(t, completed, "nothing left to complete")
+	})
+
+	t.Run("rotation completed by another instance", func(t *testing.T) {
+		store := newStubRotationStore()
+		completing := NewSigningSecrets([]byte("new"), []byte("key")).WithRotationStore(store, "test")
+		other := NewSigningSecrets([]byte("new"), []byte("key")).WithRotationStore(store, "test")
+		assert.Equal(t, http.StatusOK, serve(other, "v1="+testHMACSignature))
+
+		completed, err := completing.CompleteRotation(context.Background())
+		require.NoError(t, err)
+		assert.True(t, completed)
+
+		assert.Equal(t, http.StatusUnauthorized, serve(other, "v1="+testHMACSignature))
+		assertRotationActive(t, other, false)
+
+		// A restart with the previous secret still configured keeps rejecting it
+		restarted := NewSigningSecrets([]byte("new"), []byte("key")).WithRotationStore(store, "test")
+		assertRotationActive(t, restarted, false)
+		completed, err = restarted.CompleteRotation(context.Background())
+		require.NoError(t, err)
+		assert.False(t, completed, "already retired")
+	})
+
+	t.Run("signed with neither secret", func(t *testing.T) {
+		secrets := NewSigningSecrets([]byte("new"), []byte("old"))
+		assert.Equal(t, http.StatusUnauthorized, serve(secrets, "v1="+testHMACSignature))
+	})
+
+	t.Run("no rotation", func(t *testing.T) {
+		secrets := NewSigningSecrets([]byte("key"), nil)
+		assertRotationActive(t, secrets, false)
+		completed, err := secrets.CompleteRotation(context.Background())
+		require.NoError(t, err)
+		assert.False(t, completed)
+		assert.Equal(t, http.StatusOK, serve(secrets, "v1="+testHMACSignature))
+	})
+}
+
+func assertRotationActive(t *testing.T, secrets *SigningSecrets, want bool) {
+	t.Helper()
+	active, err := secrets.RotationActive(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, want, active)
+}
+
+// stubRotationStore is a RotationStore shared by several SigningSecrets, like replicas sharing a database.
+type stubRotationStore struct {
+	retired map[string]bool
+}
+
+func newStubRotationStore() *stubRotationStore {
+	return &stubRotationStore{retired: make(map[string]bool)}
+}
+
+func (s *stubRotationStore) RetireSecret(_ context.Context, source, fingerprint string) (bool, error) {
+	if s.retired[source+"/"+fingerprint] {
+		return false, nil
+	}
+	s.retired[source+"/"+fingerprint] = true
+	return true, nil
+}
+
+func (s *stubRotationStore) IsSecretRetired(_ context.Context, source, fingerprint string) (bool, error) {
+	return s.retired[source+"/"+fingerprint], nil
+}
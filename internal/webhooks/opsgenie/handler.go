@@ -0,0 +1,243 @@
+// Package opsgenie ingests OpsGenie alert webhooks as incidents.
+package opsgenie
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/bissquit/incident-garden/internal/sse"
+	"github.com/bissquit/incident-garden/internal/webhooks"
+	"github.com/go-chi/chi/v5"
+)
+
+// Source identifies OpsGenie alerts in external incident links.
+const Source = "opsgenie"
+
+// TokenHeader carries the static token configured as a custom header of the OpsGenie webhook integration.
+const TokenHeader = "X-OG-Delivery-Token"
+
+// OpsGenie alert actions.
+const (
+	ActionCreate      = "Create"
+	ActionAcknowledge = "Acknowledge"
+	ActionClose       = "Close"
+)
+
+var errorMappings = []httputil.ErrorMapping{
+	{Error: webhooks.ErrUnknownService, Status: http.StatusBadRequest},
+	{Error: events.ErrAffectedServiceNotFound, Status: http.StatusBadRequest},
+}
+
+// Config holds OpsGenie webhook settings.
+type Config struct {
+	Token     string // expected X-OG-Delivery-Token value
+	TagPrefix string // alert tags with this prefix name affected service slugs, e.g. "ig_service:"
+}
+
+// Payload is an OpsGenie webhook integration body.
+type Payload struct {
+	Action string    `json:"action"`
+	Alert  AlertData `json:"alert"`
+}
+
+// AlertData is the alert of a webhook action.
+type AlertData struct {
+	AlertID     string   `json:"alertId"`
+	TinyID      string   `json:"tinyId"`
+	Message     string   `json:"message"`
+	Description string   `json:"description"`
+	Priority    string   `json:"priority"` // P1..P5
+	Tags        []string `json:"tags"`
+	CreatedAt   int64    `json:"createdAt"` // Unix milliseconds
+}
+
+// WebhookHandler handles OpsGenie webhook deliveries.
+type WebhookHandler struct {
+	service   *webhooks.Service
+	config    Config
+	publisher sse.Publisher
+}
+
+// NewWebhookHandler creates a new OpsGenie webhook handler.
+// publisher may be nil, in which case no live updates are pushed.
+func NewWebhookHandler(service *webhooks.Service, config Config, publisher sse.Publisher) *WebhookHandler {
+	return &WebhookHandler{
+		service:   service,
+		config:    config,
+		publisher: publisher,
+	}
+}
+
+// RegisterRoutes registers the webhook route. Authentication is done by static token, not by session.
+// middlewares run after the token is verified.
+func (h *WebhookHandler) RegisterRoutes(r chi.Router, middlewares ...func(http.Handler) http.Handler) {
+	r.With(h.authenticate).
+		With(middlewares...).
+		Post("/webhooks/opsgenie", h.HandleWebhook)
+}
+
+// authenticate rejects requests without the configured token.
+func (h *WebhookHandler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !VerifyToken(h.config.Token, r.Header.Get(TokenHeader)) {
+			httputil.Error(w, http.StatusUnauthorized, "invalid token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// VerifyToken checks a TokenHeader value against the configured token.
+// An empty token never verifies.
+func VerifyToken(token, header string) bool {
+	if token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(header)), []byte(token)) == 1
+}
+
+// HandleWebhook handles POST /webhooks/opsgenie.
+// The token is verified by the route middleware.
+func (h *WebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	var payload Payload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	alert, ok := ToAlert(payload, h.config.TagPrefix)
+	if !ok {
+		httputil.Success(w, http.StatusOK, webhooks.Result{Action: webhooks.ActionIgnored})
+		return
+	}
+	if alert.ExternalID == "" {
+		httputil.Error(w, http.StatusBadRequest, "alert id is required")
+		return
+	}
+
+	before := h.snapshot(r.Context())
+	result, err := h.service.Apply(r.Context(), alert)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	switch result.Action {
+	case webhooks.ActionCreated:
+		h.publish(r.Context(), before, sse.TypeEventCreated, result.Event)
+	case webhooks.ActionUpdated:
+		h.publish(r.Context(), before, sse.TypeEventUpdated, result.Update)
+	}
+
+	httputil.Success(w, http.StatusOK, result)
+}
+
+// ToAlert converts a webhook payload to an alert; affected service slugs come from
+// tags starting with tagPrefix. Acknowledge posts an update that keeps the incident status.
+// Returns false for actions that don't affect the incident lifecycle.
+func ToAlert(payload Payload, tagPrefix string) (webhooks.Alert, bool) {
+	var status domain.EventStatus
+	var message string
+	var keepStatus bool
+
+	switch payload.Action {
+	case ActionCreate:
+		status, message = domain.EventStatusInvestigating, "Alert created in OpsGenie"
+	case ActionAcknowledge:
+		status, message = domain.EventStatusInvestigating, "Alert acknowledged in OpsGenie"
+		keepStatus = true
+	case ActionClose:
+		status, message = domain.EventStatusResolved, "Alert closed in OpsGenie"
+	default:
+		return webhooks.Alert{}, false
+	}
+
+	data := payload.Alert
+	var startedAt *time.Time
+	if data.CreatedAt > 0 {
+		t := time.UnixMilli(data.CreatedAt)
+		startedAt = &t
+	}
+
+	title := data.Message
+	if title == "" {
+		title = "OpsGenie alert " + data.AlertID
+	}
+
+	return webhooks.Alert{
+		Source:       Source,
+		ExternalID:   data.AlertID,
+		Title:        title,
+		Description:  data.Description,
+		Severity:     PriorityToSeverity(data.Priority),
+		StartedAt:    startedAt,
+		ServiceSlugs: parseServiceSlugs(data.Tags, tagPrefix),
+		Status:       status,
+		Message:      message,
+		KeepStatus:   keepStatus,
+	}, true
+}
+
+// PriorityToSeverity maps OpsGenie priority to incident severity.
+// P1 is critical, P2 is major, anything else (including no priority) is minor.
+func PriorityToSeverity(priority string) domain.Severity {
+	switch strings.ToUpper(strings.TrimSpace(priority)) {
+	case "P1":
+		return domain.SeverityCritical
+	case "P2":
+		return domain.SeverityMajor
+	default:
+		return domain.SeverityMinor
+	}
+}
+
+// parseServiceSlugs extracts unique slugs from tags starting with prefix.
+// Without a prefix no tag names a service.
+func parseServiceSlugs(tags []string, prefix string) []string {
+	if prefix == "" {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var slugs []string
+	for _, tag := range tags {
+		slug, ok := strings.CutPrefix(strings.TrimSpace(tag), prefix)
+		if !ok {
+			continue
+		}
+		slug = strings.TrimSpace(slug)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		slugs = append(slugs, slug)
+	}
+	return slugs
+}
+
+// snapshot captures service statuses before a change (nil without publisher or subscribers).
+func (h *WebhookHandler) snapshot(ctx context.Context) sse.StatusSnapshot {
+	if h.publisher == nil {
+		return nil
+	}
+	return h.publisher.Snapshot(ctx)
+}
+
+// publish pushes a live update for a committed change followed by
+// effective status changes of services since the before snapshot.
+func (h *WebhookHandler) publish(ctx context.Context, before sse.StatusSnapshot, msgType string, data interface{}) {
+	if h.publisher == nil {
+		return
+	}
+	h.publisher.Publish(msgType, data)
+	if before != nil {
+		h.publisher.PublishStatusChanges(before, h.publisher.Snapshot(ctx))
+	}
+}
@@ -0,0 +1,127 @@
+package opsgenie
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriorityToSeverity(t *testing.T) {
+	assert.Equal(t, domain.SeverityCritical, PriorityToSeverity("P1"))
+	assert.Equal(t, domain.SeverityMajor, PriorityToSeverity("p2"))
+	assert.Equal(t, domain.SeverityMinor, PriorityToSeverity("P3"))
+	assert.Equal(t, domain.SeverityMinor, PriorityToSeverity(""))
+}
+
+func TestToAlert(t *testing.T) {
+	raw := `{
+		"action": "Create",
+		"alert": {
+			"alertId": "70413a06-38d6-4c85-92b8-5ebc900d42e2",
+			"tinyId": "1791",
+			"message": "Checkout is failing",
+			"description": "Error rate above 5%",
+			"priority": "P1",
+			"tags": ["ig_service:api", "team:payments", " ig_service:checkout", "ig_service:api", "ig_service:"],
+			"createdAt": 1773144000000
+		},
+		"source": {"name": "", "type": "web"},
+		"integrationName": "Incident Garden"
+	}`
+	var payload Payload
+	require.NoError(t, json.Unmarshal([]byte(raw), &payload))
+
+	alert, ok := ToAlert(payload, "ig_service:")
+	require.True(t, ok)
+
+	assert.Equal(t, Source, alert.Source)
+	assert.Equal(t, "70413a06-38d6-4c85-92b8-5ebc900d42e2", alert.ExternalID)
+	assert.Equal(t, "Checkout is failing", alert.Title)
+	assert.Equal(t, "Error rate above 5%", alert.Description)
+	assert.Equal(t, domain.SeverityCritical, alert.Severity)
+	assert.Equal(t, domain.EventStatusInvestigating, alert.Status)
+	assert.Equal(t, []string{"api", "checkout"}, alert.ServiceSlugs)
+	require.NotNil(t, alert.StartedAt)
+	assert.Equal(t, "2026-03-10T12:00:00Z", alert.StartedAt.UTC().Format("2006-01-02T15:04:05Z"))
+}
+
+func TestToAlert_Lifecycle(t *testing.T) {
+	tests := []struct {
+		action     string
+		want       domain.EventStatus
+		keepStatus bool
+		ok         bool
+	}{
+		{ActionCreate, domain.EventStatusInvestigating, false, true},
+		{ActionAcknowledge, domain.EventStatusInvestigating, true, true},
+		{ActionClose, domain.EventStatusResolved, false, true},
+		{"AddNote", "", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			payload := Payload{Action: tt.action, Alert: AlertData{AlertID: "a-1"}}
+
+			alert, ok := ToAlert(payload, "ig_service:")
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, alert.Status)
+			assert.Equal(t, tt.keepStatus, alert.KeepStatus)
+		})
+	}
+}
+
+func TestToAlert_NoTagPrefix(t *testing.T) {
+	payload := Payload{Action: ActionCreate, Alert: AlertData{AlertID: "a-1", Tags: []string{"api"}}}
+
+	alert, ok := ToAlert(payload, "")
+	require.True(t, ok)
+	assert.Empty(t, alert.ServiceSlugs)
+	assert.Equal(t, "OpsGenie alert a-1", alert.Title)
+	assert.Nil(t, alert.StartedAt)
+}
+
+func TestVerifyToken(t *testing.T) {
+	assert.True(t, VerifyToken("secret", "secret"))
+	assert.True(t, VerifyToken("secret", " secret "))
+	assert.False(t, VerifyToken("secret", "other"))
+	assert.False(t, VerifyToken("secret", ""))
+	assert.False(t, VerifyToken("", ""))
+}
+
+// serveWebhook posts body with the token header to the webhook route.
+func serveWebhook(token, body string) *httptest.ResponseRecorder {
+	r := chi.NewRouter()
+	NewWebhookHandler(nil, Config{Token: "secret", TagPrefix: "ig_service:"}, nil).RegisterRoutes(r)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/opsgenie", strings.NewReader(body))
+	if token != "" {
+		req.Header.Set(TokenHeader, token)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandleWebhook_InvalidToken(t *testing.T) {
+	assert.Equal(t, http.StatusUnauthorized, serveWebhook("", `{"action":"Create"}`).Code)
+	assert.Equal(t, http.StatusUnauthorized, serveWebhook("wrong", `{"action":"Create"}`).Code)
+}
+
+func TestHandleWebhook_IgnoredAction(t *testing.T) {
+	rec := serveWebhook("secret", `{"action":"AddNote","alert":{"alertId":"a-1"}}`)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"action":"ignored"`)
+}
+
+func TestHandleWebhook_MissingAlertID(t *testing.T) {
+	rec := serveWebhook("secret", `{"action":"Create","alert":{"message":"No id"}}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
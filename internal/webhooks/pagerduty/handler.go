@@ -0,0 +1,241 @@
+// Package pagerduty ingests PagerDuty V3 webhooks as incidents.
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/bissquit/incident-garden/internal/sse"
+	"github.com/bissquit/incident-garden/internal/webhooks"
+	"github.com/go-chi/chi/v5"
+)
+
+// Source identifies PagerDuty incidents in external incident links.
+const Source = "pagerduty"
+
+const (
+	// SignatureHeader carries "v1=<hex hmac-sha256 of body>" signatures, comma-separated during secret rotation.
+	SignatureHeader = "X-PagerDuty-Signature"
+	// SignatureLabel is the scheme label of PagerDuty V3 signatures.
+	SignatureLabel = "v1"
+	// ServicesDetailKey is the custom_details key with comma-separated affected service slugs.
+	ServicesDetailKey = "incident_garden_services"
+)
+
+// PagerDuty V3 webhook event types.
+const (
+	EventTypeTriggered    = "incident.triggered"
+	EventTypeAcknowledged = "incident.acknowledged"
+	EventTypeResolved     = "incident.resolved"
+)
+
+var errorMappings = []httputil.ErrorMapping{
+	{Error: webhooks.ErrUnknownService, Status: http.StatusBadRequest},
+	{Error: events.ErrAffectedServiceNotFound, Status: http.StatusBadRequest},
+}
+
+// Config holds PagerDuty webhook settings.
+type Config struct {
+	Secret         string // signing secret of the PagerDuty webhook subscription
+	PreviousSecret string // previous signing secret, accepted during rotation
+	// Rotations persists completed rotations; nil keeps them in memory of this instance.
+	Rotations webhooks.RotationStore
+}
+
+// Payload is a PagerDuty V3 webhook body.
+type Payload struct {
+	Event struct {
+		ID         string       `json:"id"`
+		EventType  string       `json:"event_type"`
+		OccurredAt *time.Time   `json:"occurred_at"`
+		Data       IncidentData `json:"data"`
+	} `json:"event"`
+}
+
+// IncidentData is the incident resource of a webhook event.
+type IncidentData struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	HTMLURL  string `json:"html_url"`
+	Status   string `json:"status"`
+	Priority *struct {
+		Summary string `json:"summary"` // P1..P5
+	} `json:"priority"`
+	CreatedAt     *time.Time             `json:"created_at"`
+	CustomDetails map[string]interface{} `json:"custom_details"`
+}
+
+// WebhookHandler handles PagerDuty webhook deliveries.
+type WebhookHandler struct {
+	service   *webhooks.Service
+	secrets   *webhooks.SigningSecrets
+	publisher sse.Publisher
+}
+
+// NewWebhookHandler creates a new PagerDuty webhook handler.
+// publisher may be nil, in which case no live updates are pushed.
+func NewWebhookHandler(service *webhooks.Service, config Config, publisher sse.Publisher) *WebhookHandler {
+	secrets := webhooks.NewSigningSecrets([]byte(config.Secret), []byte(config.PreviousSecret))
+	if config.Rotations != nil {
+		secrets.WithRotationStore(config.Rotations, Source)
+	}
+	return &WebhookHandler{
+		service:   service,
+		secrets:   secrets,
+		publisher: publisher,
+	}
+}
+
+// Secrets returns the signing secrets, for completing a secret rotation.
+func (h *WebhookHandler) Secrets() *webhooks.SigningSecrets {
+	return h.secrets
+}
+
+// RegisterRoutes registers the webhook route. Authentication is done by signature, not by session.
+// middlewares run after the signature is verified.
+func (h *WebhookHandler) RegisterRoutes(r chi.Router, middlewares ...func(http.Handler) http.Handler) {
+	r.With(webhooks.HMACMiddleware(h.secrets, SignatureHeader, "sha256", SignatureLabel)).
+		With(middlewares...).
+		Post("/webhooks/pagerduty", h.HandleWebhook)
+}
+
+// HandleWebhook handles POST /webhooks/pagerduty.
+// The signature is verified by the route middleware.
+func (h *WebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	var payload Payload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	alert, ok := ToAlert(payload)
+	if !ok {
+		httputil.Success(w, http.StatusOK, webhooks.Result{Action: webhooks.ActionIgnored})
+		return
+	}
+	if alert.ExternalID == "" {
+		httputil.Error(w, http.StatusBadRequest, "incident id is required")
+		return
+	}
+
+	before := h.snapshot(r.Context())
+	result, err := h.service.Apply(r.Context(), alert)
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, errorMappings)
+		return
+	}
+
+	switch result.Action {
+	case webhooks.ActionCreated:
+		h.publish(r.Context(), before, sse.TypeEventCreated, result.Event)
+	case webhooks.ActionUpdated:
+		h.publish(r.Context(), before, sse.TypeEventUpdated, result.Update)
+	}
+
+	httputil.Success(w, http.StatusOK, result)
+}
+
+// ToAlert converts a webhook payload to an alert.
+// Returns false for event types that don't affect the incident lifecycle.
+func ToAlert(payload Payload) (webhooks.Alert, bool) {
+	var status domain.EventStatus
+	var message string
+
+	switch payload.Event.EventType {
+	case EventTypeTriggered:
+		status, message = domain.EventStatusInvestigating, "Incident triggered in PagerDuty"
+	case EventTypeAcknowledged:
+		status, message = domain.EventStatusIdentified, "Incident acknowledged in PagerDuty"
+	case EventTypeResolved:
+		status, message = domain.EventStatusResolved, "Incident resolved in PagerDuty"
+	default:
+		return webhooks.Alert{}, false
+	}
+
+	incident := payload.Event.Data
+	startedAt := incident.CreatedAt
+	if startedAt == nil {
+		startedAt = payload.Event.OccurredAt
+	}
+
+	var priority string
+	if incident.Priority != nil {
+		priority = incident.Priority.Summary
+	}
+
+	title := incident.Title
+	if title == "" {
+		title = "PagerDuty incident " + incident.ID
+	}
+
+	return webhooks.Alert{
+		Source:       Source,
+		ExternalID:   incident.ID,
+		Title:        title,
+		Description:  incident.HTMLURL,
+		Severity:     PriorityToSeverity(priority),
+		StartedAt:    startedAt,
+		ServiceSlugs: parseServiceSlugs(incident.CustomDetails),
+		Status:       status,
+		Message:      message,
+	}, true
+}
+
+// PriorityToSeverity maps PagerDuty priority to incident severity.
+// P1 is critical, P2 is major, anything else (including no priority) is minor.
+func PriorityToSeverity(priority string) domain.Severity {
+	switch strings.ToUpper(strings.TrimSpace(priority)) {
+	case "P1":
+		return domain.SeverityCritical
+	case "P2":
+		return domain.SeverityMajor
+	default:
+		return domain.SeverityMinor
+	}
+}
+
+// parseServiceSlugs extracts unique slugs from the comma-separated ServicesDetailKey value.
+func parseServiceSlugs(details map[string]interface{}) []string {
+	raw, ok := details[ServicesDetailKey].(string)
+	if !ok {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var slugs []string
+	for _, slug := range strings.Split(raw, ",") {
+		slug = strings.TrimSpace(slug)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		slugs = append(slugs, slug)
+	}
+	return slugs
+}
+
+// snapshot captures service statuses before a change (nil without publisher or subscribers).
+func (h *WebhookHandler) snapshot(ctx context.Context) sse.StatusSnapshot {
+	if h.publisher == nil {
+		return nil
+	}
+	return h.publisher.Snapshot(ctx)
+}
+
+// publish pushes a live update for a committed change followed by
+// effective status changes of services since the before snapshot.
+func (h *WebhookHandler) publish(ctx context.Context, before sse.StatusSnapshot, msgType string, data interface{}) {
+	if h.publisher == nil {
+		return
+	}
+	h.publisher.Publish(msgType, data)
+	if before != nil {
+		h.publisher.PublishStatusChanges(before, h.publisher.Snapshot(ctx))
+	}
+}
@@ -0,0 +1,110 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Reference value: echo -n '{"a":1}' | openssl dgst -sha256 -hmac key
+const testSignature = "88a67f24bbcdaed0e6c997404bb79a743baf44c6bab2f4c27328e3009d22e342"
+
+func TestPriorityToSeverity(t *testing.T) {
+	assert.Equal(t, domain.SeverityCritical, PriorityToSeverity("P1"))
+	assert.Equal(t, domain.SeverityMajor, PriorityToSeverity("P2"))
+	assert.Equal(t, domain.SeverityMinor, PriorityToSeverity("P3"))
+	assert.Equal(t, domain.SeverityMinor, PriorityToSeverity("P4"))
+	assert.Equal(t, domain.SeverityMinor, PriorityToSeverity(""))
+}
+
+func TestToAlert(t *testing.T) {
+	raw := `{
+		"event": {
+			"id": "01DEN4HPBQAAAG05V5QQYBRZMF",
+			"event_type": "incident.triggered",
+			"occurred_at": "2026-03-10T12:00:00Z",
+			"data": {
+				"id": "PGR0VU2",
+				"title": "Checkout is failing",
+				"html_url": "https://acme.pagerduty.com/incidents/PGR0VU2",
+				"status": "triggered",
+				"priority": {"summary": "P1"},
+				"custom_details": {"incident_garden_services": "api, checkout,,api"}
+			}
+		}
+	}`
+	var payload Payload
+	require.NoError(t, json.Unmarshal([]byte(raw), &payload))
+
+	alert, ok := ToAlert(payload)
+	require.True(t, ok)
+
+	assert.Equal(t, Source, alert.Source)
+	assert.Equal(t, "PGR0VU2", alert.ExternalID)
+	assert.Equal(t, "Checkout is failing", alert.Title)
+	assert.Equal(t, "https://acme.pagerduty.com/incidents/PGR0VU2", alert.Description)
+	assert.Equal(t, domain.SeverityCritical, alert.Severity)
+	assert.Equal(t, domain.EventStatusInvestigating, alert.Status)
+	assert.Equal(t, []string{"api", "checkout"}, alert.ServiceSlugs)
+	require.NotNil(t, alert.StartedAt)
+	assert.Equal(t, "2026-03-10T12:00:00Z", alert.StartedAt.UTC().Format("2006-01-02T15:04:05Z"))
+}
+
+func TestToAlert_Lifecycle(t *testing.T) {
+	tests := []struct {
+		eventType string
+		want      domain.EventStatus
+		ok        bool
+	}{
+		{EventTypeTriggered, domain.EventStatusInvestigating, true},
+		{EventTypeAcknowledged, domain.EventStatusIdentified, true},
+		{EventTypeResolved, domain.EventStatusResolved, true},
+		{"incident.annotated", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			var payload Payload
+			payload.Event.EventType = tt.eventType
+			payload.Event.Data.ID = "P1"
+
+			alert, ok := ToAlert(payload)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, alert.Status)
+		})
+	}
+}
+
+// newTestRouter registers the webhook route with its signature middleware.
+func newTestRouter() http.Handler {
+	r := chi.NewRouter()
+	NewWebhookHandler(nil, Config{Secret: "key"}, nil).RegisterRoutes(r)
+	return r
+}
+
+func TestHandleWebhook_InvalidSignature(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/pagerduty", strings.NewReader(`{"a":1}`))
+	req.Header.Set(SignatureHeader, "v1=deadbeef")
+	rec := httptest.NewRecorder()
+	newTestRouter().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestHandleWebhook_IgnoredEventType(t *testing.T) {
+	body := `{"a":1}`
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/pagerduty", strings.NewReader(body))
+	req.Header.Set(SignatureHeader, "v1="+testSignature)
+	rec := httptest.NewRecorder()
+	newTestRouter().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"action":"ignored"`)
+}
@@ -0,0 +1,277 @@
+// Package postgres provides PostgreSQL implementation of webhooks repository.
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/webhooks"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// systemUserEmail identifies the user created by migration 000024.
+const systemUserEmail = "webhooks@incident-garden.local"
+
+// reservationTimeout is how long a reservation without an event blocks other deliveries.
+const reservationTimeout = 10 * time.Minute
+
+// Repository implements webhooks.Repository using PostgreSQL.
+type Repository struct {
+	db *pgxpool.Pool
+}
+
+// NewRepository creates a new PostgreSQL repository.
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{db: db}
+}
+
+// GetLinkedEventID returns the event created for an external incident.
+func (r *Repository) GetLinkedEventID(ctx context.Context, source, externalID string) (string, error) {
+	query := `
+		SELECT event_id FROM external_incidents
+		WHERE source = $1 AND external_id = $2 AND event_id IS NOT NULL
+	`
+
+	var eventID string
+	err := r.db.QueryRow(ctx, query, source, externalID).Scan(&eventID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", webhooks.ErrExternalIncidentNotFound
+		}
+		return "", fmt.Errorf("get linked event: %w", err)
+	}
+	return eventID, nil
+}
+
+// ReserveExternalIncident reserves an external incident before its event is created.
+// Reservations older than reservationTimeout without an event are taken over,
+// so an incident whose creation was interrupted is not blocked forever.
+func (r *Repository) ReserveExternalIncident(ctx context.Context, source, externalID string) (bool, error) {
+	query := `
+		INSERT INTO external_incidents (source, external_id)
+		VALUES ($1, $2)
+		ON CONFLICT (source, external_id) DO UPDATE SET created_at = NOW()
+		WHERE external_incidents.event_id IS NULL
+			AND external_incidents.created_at < NOW() - make_interval(secs => $3)
+		RETURNING true
+	`
+
+	var reserved bool
+	err := r.db.QueryRow(ctx, query, source, externalID, reservationTimeout.Seconds()).Scan(&reserved)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("reserve external incident: %w", err)
+	}
+	return reserved, nil
+}
+
+// ReleaseExternalIncident removes a reservation that has no event.
+func (r *Repository) ReleaseExternalIncident(ctx context.Context, source, externalID string) error {
+	query := `DELETE FROM external_incidents WHERE source = $1 AND external_id = $2 AND event_id IS NULL`
+
+	if _, err := r.db.Exec(ctx, query, source, externalID); err != nil {
+		return fmt.Errorf("release external incident: %w", err)
+	}
+	return nil
+}
+
+// LinkExternalIncident links a reserved external incident to an event.
+func (r *Repository) LinkExternalIncident(ctx context.Context, source, externalID, eventID string) error {
+	query := `UPDATE external_incidents SET event_id = $3 WHERE source = $1 AND external_id = $2`
+
+	if _, err := r.db.Exec(ctx, query, source, externalID, eventID); err != nil {
+		return fmt.Errorf("link external incident: %w", err)
+	}
+	return nil
+}
+
+// GetSystemUserID returns the ID of the user that owns webhook-created events.
+func (r *Repository) GetSystemUserID(ctx context.Context) (string, error) {
+	query := `SELECT id FROM users WHERE email = $1`
+
+	var id string
+	if err := r.db.QueryRow(ctx, query, systemUserEmail).Scan(&id); err != nil {
+		return "", fmt.Errorf("get webhook system user: %w", err)
+	}
+	return id, nil
+}
+
+// SaveFiringAlert records a firing alert for a service.
+func (r *Repository) SaveFiringAlert(ctx context.Context, source, fingerprint, serviceID string, status domain.ServiceStatus) error {
+	query := `
+		INSERT INTO firing_alerts (source, fingerprint, service_id, status)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (source, fingerprint) DO UPDATE
+		SET service_id = EXCLUDED.service_id, status = EXCLUDED.status, updated_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query, source, fingerprint, serviceID, status); err != nil {
+		return fmt.Errorf("save firing alert: %w", err)
+	}
+	return nil
+}
+
+// DeleteFiringAlert removes a firing alert and returns the service it targeted.
+func (r *Repository) DeleteFiringAlert(ctx context.Context, source, fingerprint string) (string, error) {
+	query := `DELETE FROM firing_alerts WHERE source = $1 AND fingerprint = $2 RETURNING service_id`
+
+	var serviceID string
+	err := r.db.QueryRow(ctx, query, source, fingerprint).Scan(&serviceID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", webhooks.ErrFiringAlertNotFound
+		}
+		return "", fmt.Errorf("delete firing alert: %w", err)
+	}
+	return serviceID, nil
+}
+
+// GetWorstFiringStatus returns the most severe status of firing alerts of a service.
+func (r *Repository) GetWorstFiringStatus(ctx context.Context, serviceID string) (domain.ServiceStatus, bool, error) {
+	query := `
+		SELECT status FROM firing_alerts
+		WHERE service_id = $1
+		ORDER BY service_status_priority(status) DESC
+		LIMIT 1
+	`
+
+	var status domain.ServiceStatus
+	err := r.db.QueryRow(ctx, query, serviceID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("get worst firing status: %w", err)
+	}
+	return status, true, nil
+}
+
+// CreateDelivery stores a pending webhook delivery and sets its ID and ReceivedAt.
+func (r *Repository) CreateDelivery(ctx context.Context, delivery *webhooks.Delivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (source, payload)
+		VALUES ($1, $2)
+		RETURNING id, received_at, processing_status
+	`
+
+	var payload []byte
+	if delivery.Payload != nil {
+		payload = delivery.Payload
+	}
+	err := r.db.QueryRow(ctx, query, delivery.Source, payload).
+		Scan(&delivery.ID, &delivery.ReceivedAt, &delivery.ProcessingStatus)
+	if err != nil {
+		return fmt.Errorf("create webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// FinishDelivery sets the processing status and error message of a delivery.
+func (r *Repository) FinishDelivery(ctx context.Context, id, status, errorMessage string) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET processing_status = $2, error_message = NULLIF($3, '')
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, id, status, errorMessage)
+	if err != nil {
+		return fmt.Errorf("finish webhook delivery: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return webhooks.ErrDeliveryNotFound
+	}
+	return nil
+}
+
+// GetDelivery returns a webhook delivery by ID.
+func (r *Repository) GetDelivery(ctx context.Context, id string) (*webhooks.Delivery, error) {
+	query := `
+		SELECT id, source, received_at, payload, processing_status, COALESCE(error_message, '')
+		FROM webhook_deliveries
+		WHERE id = $1
+	`
+
+	var d webhooks.Delivery
+	err := r.db.QueryRow(ctx, query, id).
+		Scan(&d.ID, &d.Source, &d.ReceivedAt, &d.Payload, &d.ProcessingStatus, &d.ErrorMessage)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, webhooks.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("get webhook delivery: %w", err)
+	}
+	return &d, nil
+}
+
+// ListDeliveries returns matching webhook deliveries, newest first, with their total count.
+func (r *Repository) ListDeliveries(ctx context.Context, filter webhooks.DeliveryFilter) ([]webhooks.Delivery, int, error) {
+	where := `
+		WHERE ($1 = '' OR source = $1)
+		  AND ($2 = '' OR processing_status = $2)
+	`
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM webhook_deliveries` + where
+	if err := r.db.QueryRow(ctx, countQuery, filter.Source, filter.Status).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count webhook deliveries: %w", err)
+	}
+
+	query := `
+		SELECT id, source, received_at, payload, processing_status, COALESCE(error_message, '')
+		FROM webhook_deliveries` + where + `
+		ORDER BY received_at DESC, id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Query(ctx, query, filter.Source, filter.Status, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]webhooks.Delivery, 0)
+	for rows.Next() {
+		var d webhooks.Delivery
+		if err := rows.Scan(&d.ID, &d.Source, &d.ReceivedAt, &d.Payload, &d.ProcessingStatus, &d.ErrorMessage); err != nil {
+			return nil, 0, fmt.Errorf("scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate webhook deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
+
+// RetireSecret records a previous signing secret as retired.
+func (r *Repository) RetireSecret(ctx context.Context, source, fingerprint string) (bool, error) {
+	query := `
+		INSERT INTO webhook_retired_secrets (source, fingerprint)
+		VALUES ($1, $2)
+		ON CONFLICT (source, fingerprint) DO NOTHING
+	`
+
+	tag, err := r.db.Exec(ctx, query, source, fingerprint)
+	if err != nil {
+		return false, fmt.Errorf("retire secret: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// IsSecretRetired reports whether a previous signing secret was retired.
+func (r *Repository) IsSecretRetired(ctx context.Context, source, fingerprint string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM webhook_retired_secrets WHERE source = $1 AND fingerprint = $2)`
+
+	var retired bool
+	if err := r.db.QueryRow(ctx, query, source, fingerprint).Scan(&retired); err != nil {
+		return false, fmt.Errorf("check retired secret: %w", err)
+	}
+	return retired, nil
+}
@@ -0,0 +1,242 @@
+// Package prometheus ingests Prometheus Alertmanager webhooks as service statuses.
+package prometheus
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/bissquit/incident-garden/internal/sse"
+	"github.com/bissquit/incident-garden/internal/webhooks"
+	"github.com/go-chi/chi/v5"
+)
+
+// Source identifies Alertmanager alerts in firing alert records.
+const Source = "prometheus"
+
+const (
+	// AlertStatusFiring and AlertStatusResolved are Alertmanager alert statuses.
+	AlertStatusFiring   = "firing"
+	AlertStatusResolved = "resolved"
+
+	alertNameLabel = "alertname"
+	maxBodySize    = 1 << 20
+)
+
+// Config holds Alertmanager webhook settings.
+type Config struct {
+	Token         string                          // expected Bearer token
+	ServiceLabel  string                          // alert label with the service slug
+	SeverityLabel string                          // alert label with the alert severity
+	SeverityMap   map[string]domain.ServiceStatus // severity label value -> service status
+	DefaultStatus domain.ServiceStatus            // status for severities missing from SeverityMap
+}
+
+// Payload is an Alertmanager webhook body (version 4).
+type Payload struct {
+	Version  string  `json:"version"`
+	Status   string  `json:"status"`
+	Receiver string  `json:"receiver"`
+	Alerts   []Alert `json:"alerts"`
+}
+
+// Alert is a single alert of an Alertmanager notification.
+type Alert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// Response is the webhook response with one result per alert.
+type Response struct {
+	Results []*webhooks.ServiceAlertResult `json:"results"`
+}
+
+// WebhookHandler handles Alertmanager webhook deliveries.
+type WebhookHandler struct {
+	service   *webhooks.Service
+	config    Config
+	publisher sse.Publisher
+}
+
+// NewWebhookHandler creates a new Alertmanager webhook handler.
+// Severities are matched case-insensitively.
+// publisher may be nil, in which case no live updates are pushed.
+func NewWebhookHandler(service *webhooks.Service, config Config, publisher sse.Publisher) *WebhookHandler {
+	severityMap := make(map[string]domain.ServiceStatus, len(config.SeverityMap))
+	for severity, status := range config.SeverityMap {
+		severityMap[strings.ToLower(severity)] = status
+	}
+	config.SeverityMap = severityMap
+
+	return &WebhookHandler{
+		service:   service,
+		config:    config,
+		publisher: publisher,
+	}
+}
+
+// RegisterRoutes registers the webhook route. Authentication is done by static token, not by session.
+// middlewares run after the token is verified.
+func (h *WebhookHandler) RegisterRoutes(r chi.Router, middlewares ...func(http.Handler) http.Handler) {
+	r.With(h.authenticate).
+		With(middlewares...).
+		Post("/webhooks/prometheus", h.processWebhook)
+}
+
+// HandleWebhook handles POST /webhooks/prometheus.
+func (h *WebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	h.authenticate(http.HandlerFunc(h.processWebhook)).ServeHTTP(w, r)
+}
+
+// authenticate rejects requests without the configured Bearer token.
+func (h *WebhookHandler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !VerifyToken(h.config.Token, r.Header.Get("Authorization")) {
+			httputil.Error(w, http.StatusUnauthorized, "invalid token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// processWebhook applies the alerts of an authenticated request.
+func (h *WebhookHandler) processWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	before := h.snapshot(r.Context())
+	response := Response{Results: make([]*webhooks.ServiceAlertResult, 0, len(payload.Alerts))}
+	updated := false
+
+	for _, alert := range payload.Alerts {
+		serviceAlert, ok := h.ToServiceAlert(alert)
+		if !ok {
+			response.Results = append(response.Results, &webhooks.ServiceAlertResult{
+				Fingerprint: serviceAlert.Fingerprint,
+				Action:      webhooks.ActionIgnored,
+			})
+			continue
+		}
+
+		result, err := h.service.ApplyServiceAlert(r.Context(), serviceAlert)
+		if err != nil {
+			httputil.HandleError(r.Context(), w, err, nil)
+			return
+		}
+		if result.Action == webhooks.ActionUpdated {
+			updated = true
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	if updated {
+		h.publishStatusChanges(r.Context(), before)
+	}
+
+	httputil.Success(w, http.StatusOK, response)
+}
+
+// VerifyToken checks an Authorization header against the configured Bearer token.
+// An empty token never verifies.
+func VerifyToken(token, header string) bool {
+	if token == "" {
+		return false
+	}
+	value, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(value)), []byte(token)) == 1
+}
+
+// ToServiceAlert converts an Alertmanager alert to a service alert.
+// Returns false for alerts without the service label or with an unknown status.
+func (h *WebhookHandler) ToServiceAlert(alert Alert) (webhooks.ServiceAlert, bool) {
+	fingerprint := alert.Fingerprint
+	if fingerprint == "" {
+		fingerprint = labelsFingerprint(alert.Labels)
+	}
+	result := webhooks.ServiceAlert{Source: Source, Fingerprint: fingerprint}
+
+	slug := strings.TrimSpace(alert.Labels[h.config.ServiceLabel])
+	if slug == "" {
+		return result, false
+	}
+	result.ServiceSlug = slug
+
+	name := alert.Labels[alertNameLabel]
+	if name == "" {
+		name = fingerprint
+	}
+
+	switch alert.Status {
+	case AlertStatusFiring:
+		result.Firing = true
+		result.Status = h.SeverityToStatus(alert.Labels[h.config.SeverityLabel])
+		result.Reason = "Prometheus alert firing: " + name
+	case AlertStatusResolved:
+		result.Reason = "Prometheus alert resolved: " + name
+	default:
+		return result, false
+	}
+	return result, true
+}
+
+// SeverityToStatus maps a severity label value to a service status.
+func (h *WebhookHandler) SeverityToStatus(severity string) domain.ServiceStatus {
+	if status, ok := h.config.SeverityMap[strings.ToLower(strings.TrimSpace(severity))]; ok {
+		return status
+	}
+	return h.config.DefaultStatus
+}
+
+// labelsFingerprint identifies an alert by its label set when Alertmanager didn't send a fingerprint.
+func labelsFingerprint(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, k := range keys {
+		hash.Write([]byte(k + "=" + labels[k] + "\n"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// snapshot captures service statuses before a change (nil without publisher or subscribers).
+func (h *WebhookHandler) snapshot(ctx context.Context) sse.StatusSnapshot {
+	if h.publisher == nil {
+		return nil
+	}
+	return h.publisher.Snapshot(ctx)
+}
+
+// publishStatusChanges pushes effective status changes of services since the before snapshot.
+func (h *WebhookHandler) publishStatusChanges(ctx context.Context, before sse.StatusSnapshot) {
+	if h.publisher == nil || before == nil {
+		return
+	}
+	h.publisher.PublishStatusChanges(before, h.publisher.Snapshot(ctx))
+}
@@ -0,0 +1,130 @@
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() Config {
+	return Config{
+		Token:         "token",
+		ServiceLabel:  "service_slug",
+		SeverityLabel: "severity",
+		SeverityMap: map[string]domain.ServiceStatus{
+			"critical": domain.ServiceStatusMajorOutage,
+			"warning":  domain.ServiceStatusDegraded,
+		},
+		DefaultStatus: domain.ServiceStatusDegraded,
+	}
+}
+
+func TestVerifyToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		header string
+		want   bool
+	}{
+		{"valid", "token", "Bearer token", true},
+		{"wrong token", "token", "Bearer other", false},
+		{"missing scheme", "token", "token", false},
+		{"basic scheme", "token", "Basic token", false},
+		{"empty header", "token", "", false},
+		{"empty token", "", "Bearer ", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, VerifyToken(tt.token, tt.header))
+		})
+	}
+}
+
+func TestSeverityToStatus(t *testing.T) {
+	h := NewWebhookHandler(nil, testConfig(), nil)
+
+	assert.Equal(t, domain.ServiceStatusMajorOutage, h.SeverityToStatus("critical"))
+	assert.Equal(t, domain.ServiceStatusMajorOutage, h.SeverityToStatus(" Critical "))
+	assert.Equal(t, domain.ServiceStatusDegraded, h.SeverityToStatus("warning"))
+	assert.Equal(t, domain.ServiceStatusDegraded, h.SeverityToStatus("info"))
+	assert.Equal(t, domain.ServiceStatusDegraded, h.SeverityToStatus(""))
+}
+
+func TestToServiceAlert(t *testing.T) {
+	h := NewWebhookHandler(nil, testConfig(), nil)
+
+	alert, ok := h.ToServiceAlert(Alert{
+		Status:      AlertStatusFiring,
+		Labels:      map[string]string{"alertname": "HighErrorRate", "service_slug": "api", "severity": "critical"},
+		Fingerprint: "abc123",
+	})
+	require.True(t, ok)
+	assert.Equal(t, Source, alert.Source)
+	assert.Equal(t, "abc123", alert.Fingerprint)
+	assert.Equal(t, "api", alert.ServiceSlug)
+	assert.True(t, alert.Firing)
+	assert.Equal(t, domain.ServiceStatusMajorOutage, alert.Status)
+	assert.Equal(t, "Prometheus alert firing: HighErrorRate", alert.Reason)
+
+	alert, ok = h.ToServiceAlert(Alert{
+		Status:      AlertStatusResolved,
+		Labels:      map[string]string{"alertname": "HighErrorRate", "service_slug": "api"},
+		Fingerprint: "abc123",
+	})
+	require.True(t, ok)
+	assert.False(t, alert.Firing)
+	assert.Equal(t, "Prometheus alert resolved: HighErrorRate", alert.Reason)
+}
+
+func TestToServiceAlert_Ignored(t *testing.T) {
+	h := NewWebhookHandler(nil, testConfig(), nil)
+
+	_, ok := h.ToServiceAlert(Alert{Status: AlertStatusFiring, Labels: map[string]string{"alertname": "NoService"}})
+	assert.False(t, ok)
+
+	_, ok = h.ToServiceAlert(Alert{Status: "pending", Labels: map[string]string{"service_slug": "api"}})
+	assert.False(t, ok)
+}
+
+func TestToServiceAlert_FingerprintFallback(t *testing.T) {
+	h := NewWebhookHandler(nil, testConfig(), nil)
+	labels := map[string]string{"alertname": "HighErrorRate", "service_slug": "api"}
+
+	first, _ := h.ToServiceAlert(Alert{Status: AlertStatusFiring, Labels: labels})
+	second, _ := h.ToServiceAlert(Alert{Status: AlertStatusResolved, Labels: labels})
+	other, _ := h.ToServiceAlert(Alert{Status: AlertStatusFiring, Labels: map[string]string{"service_slug": "db"}})
+
+	assert.NotEmpty(t, first.Fingerprint)
+	assert.Equal(t, first.Fingerprint, second.Fingerprint)
+	assert.NotEqual(t, first.Fingerprint, other.Fingerprint)
+}
+
+func TestHandleWebhook_InvalidToken(t *testing.T) {
+	h := NewWebhookHandler(nil, testConfig(), nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/prometheus", strings.NewReader(`{"alerts":[]}`))
+	req.Header.Set("Authorization", "Bearer wrong")
+	rec := httptest.NewRecorder()
+	h.HandleWebhook(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestHandleWebhook_AlertsWithoutServiceIgnored(t *testing.T) {
+	h := NewWebhookHandler(nil, testConfig(), nil)
+
+	body := `{"version":"4","status":"firing","alerts":[{"status":"firing","labels":{"alertname":"Watchdog"},"fingerprint":"f1"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/prometheus", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	h.HandleWebhook(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"fingerprint":"f1","action":"ignored"`)
+}
@@ -0,0 +1,32 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+)
+
+// Repository defines the interface for webhook ingest storage.
+type Repository interface {
+	// GetLinkedEventID returns the event created for an external incident.
+	// Returns ErrExternalIncidentNotFound if the incident is not linked.
+	GetLinkedEventID(ctx context.Context, source, externalID string) (string, error)
+	// ReserveExternalIncident reserves an external incident before its event is created.
+	// Returns false if the incident is already reserved or linked.
+	ReserveExternalIncident(ctx context.Context, source, externalID string) (bool, error)
+	// ReleaseExternalIncident removes a reservation whose event could not be created.
+	ReleaseExternalIncident(ctx context.Context, source, externalID string) error
+	// LinkExternalIncident links a reserved external incident to its event.
+	LinkExternalIncident(ctx context.Context, source, externalID, eventID string) error
+	// GetSystemUserID returns the ID of the user that owns webhook-created events.
+	GetSystemUserID(ctx context.Context) (string, error)
+
+	// SaveFiringAlert records a firing alert for a service, replacing a previous record of the same alert.
+	SaveFiringAlert(ctx context.Context, source, fingerprint, serviceID string, status domain.ServiceStatus) error
+	// DeleteFiringAlert removes a firing alert and returns the service it targeted.
+	// Returns ErrFiringAlertNotFound if the alert is not firing.
+	DeleteFiringAlert(ctx context.Context, source, fingerprint string) (string, error)
+	// GetWorstFiringStatus returns the most severe status of firing alerts of a service.
+	// Returns false if no alerts are firing.
+	GetWorstFiringStatus(ctx context.Context, serviceID string) (domain.ServiceStatus, bool, error)
+}
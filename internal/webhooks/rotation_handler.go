@@ -0,0 +1,71 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/go-chi/chi/v5"
+)
+
+// Audit log action and target type of completing a secret rotation.
+const (
+	auditActionCompleteRotation = "webhooks.complete_rotation"
+	auditTargetWebhooks         = "webhooks"
+)
+
+// AuditRecorder records admin actions in the audit log.
+// This interface is implemented by admin.AuditLogger.
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, details map[string]interface{})
+}
+
+// RotationStatus reports whether the previous signing secret is still accepted.
+type RotationStatus struct {
+	SecondarySecretActive bool `json:"secondary_secret_active"`
+}
+
+// RotationHandler serves the signing secret rotation API.
+// A completed rotation is persisted by the RotationStore of the secrets, so every
+// instance rejects the previous secret, also after a restart with it still configured.
+type RotationHandler struct {
+	secrets *SigningSecrets
+	source  string
+	audit   AuditRecorder // nil if admin actions are not audited
+}
+
+// NewRotationHandler creates a new secret rotation handler for the signing secrets of a source.
+func NewRotationHandler(secrets *SigningSecrets, source string, audit AuditRecorder) *RotationHandler {
+	return &RotationHandler{secrets: secrets, source: source, audit: audit}
+}
+
+// RegisterAdminRoutes registers routes for the secret rotation (admin only).
+func (h *RotationHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/admin/webhooks/secret-rotation-status", h.GetRotationStatus)
+	r.Post("/admin/webhooks/complete-rotation", h.CompleteRotation)
+}
+
+// GetRotationStatus handles GET /admin/webhooks/secret-rotation-status.
+func (h *RotationHandler) GetRotationStatus(w http.ResponseWriter, r *http.Request) {
+	active, err := h.secrets.RotationActive(r.Context())
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, nil)
+		return
+	}
+	httputil.Success(w, http.StatusOK, RotationStatus{SecondarySecretActive: active})
+}
+
+// CompleteRotation handles POST /admin/webhooks/complete-rotation.
+// Signatures made with the previous secret are rejected from now on.
+// Completing an inactive rotation is a no-op.
+func (h *RotationHandler) CompleteRotation(w http.ResponseWriter, r *http.Request) {
+	completed, err := h.secrets.CompleteRotation(r.Context())
+	if err != nil {
+		httputil.HandleError(r.Context(), w, err, nil)
+		return
+	}
+	if completed && h.audit != nil {
+		h.audit.Record(r.Context(), auditActionCompleteRotation, auditTargetWebhooks, h.source, nil)
+	}
+	httputil.Success(w, http.StatusOK, RotationStatus{SecondarySecretActive: false})
+}
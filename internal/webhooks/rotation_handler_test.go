@@ -0,0 +1,58 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedAudit struct {
+	action, targetType, targetID string
+}
+
+type stubAudit struct {
+	entries []recordedAudit
+}
+
+func (s *stubAudit) Record(_ context.Context, action, targetType, targetID string, _ map[string]interface{}) {
+	s.entries = append(s.entries, recordedAudit{action, targetType, targetID})
+}
+
+func rotationRequest(t *testing.T, r chi.Router, method, path string) RotationStatus {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Data RotationStatus `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	return resp.Data
+}
+
+func TestRotationHandler(t *testing.T) {
+	audit := &stubAudit{}
+	r := chi.NewRouter()
+	NewRotationHandler(NewSigningSecrets([]byte("new"), []byte("old")), "pagerduty", audit).RegisterAdminRoutes(r)
+
+	status := rotationRequest(t, r, http.MethodGet, "/admin/webhooks/secret-rotation-status")
+	assert.True(t, status.SecondarySecretActive)
+
+	status = rotationRequest(t, r, http.MethodPost, "/admin/webhooks/complete-rotation")
+	assert.False(t, status.SecondarySecretActive)
+	assert.Equal(t, []recordedAudit{{"webhooks.complete_rotation", "webhooks", "pagerduty"}}, audit.entries)
+
+	status = rotationRequest(t, r, http.MethodGet, "/admin/webhooks/secret-rotation-status")
+	assert.False(t, status.SecondarySecretActive)
+
+	// Completing again is a no-op and is not audited
+	rotationRequest(t, r, http.MethodPost, "/admin/webhooks/complete-rotation")
+	assert.Len(t, audit.entries, 1)
+}
@@ -0,0 +1,298 @@
+// Package webhooks turns incidents of external alerting systems into events
+// and alerts of monitoring systems into service statuses.
+//
+// Source-specific handlers (e.g. pagerduty) authenticate and parse payloads
+// into an Alert; Service creates the linked event or moves it through its lifecycle.
+// Monitoring handlers (e.g. prometheus) parse payloads into a ServiceAlert;
+// Service sets the stored status of the service to the worst of its firing alerts.
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+)
+
+// Webhook errors.
+var (
+	ErrExternalIncidentNotFound = errors.New("external incident not found")
+	ErrUnknownService           = errors.New("unknown service")
+	ErrFiringAlertNotFound      = errors.New("firing alert not found")
+)
+
+// Actions reported in Result.
+const (
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+	ActionIgnored = "ignored"
+	// ActionUnchanged means the alert was recorded but the service status stayed the same.
+	ActionUnchanged = "unchanged"
+)
+
+// EventsService is the subset of events.Service used by webhooks.
+type EventsService interface {
+	CreateEvent(ctx context.Context, input events.CreateEventInput, createdBy string) (*domain.Event, error)
+	GetEvent(ctx context.Context, id string) (*domain.Event, error)
+	AddUpdate(ctx context.Context, input events.CreateEventUpdateInput, createdBy string) (*domain.EventUpdate, error)
+	ListEventUpdates(ctx context.Context, eventID string, limit, offset int) ([]*domain.EventUpdate, int, error)
+}
+
+// CatalogService is the subset of catalog.Service used by webhooks.
+type CatalogService interface {
+	GetServiceBySlug(ctx context.Context, slug string) (*domain.Service, error)
+	SetServiceStatus(ctx context.Context, serviceID string, status domain.ServiceStatus, source domain.StatusLogSourceType, reason, changedBy string) (bool, error)
+}
+
+// Alert is an incident of an external system normalized for ingest.
+type Alert struct {
+	Source       string // e.g. "pagerduty"
+	ExternalID   string // incident ID in the external system
+	Title        string
+	Description  string
+	Severity     domain.Severity
+	StartedAt    *time.Time
+	ServiceSlugs []string
+	Status       domain.EventStatus // target incident status
+	Message      string             // update message when the status changes
+	// KeepStatus posts Message to a linked event without changing its status;
+	// Status is only used when the alert creates the event.
+	KeepStatus bool
+}
+
+// statusChange returns the status log source of the changes the alert makes.
+func (a Alert) statusChange() events.StatusChangeContext {
+	return events.StatusChangeContext{SourceType: domain.StatusLogSourceWebhook, WebhookSource: a.Source}
+}
+
+// ServiceAlert is a firing or resolved alert of a monitoring system normalized for ingest.
+type ServiceAlert struct {
+	Source      string // e.g. "prometheus"
+	Fingerprint string // identifies the alert across firing and resolved notifications
+	ServiceSlug string
+	Firing      bool
+	Status      domain.ServiceStatus // service status while the alert is firing
+	Reason      string               // status log reason
+}
+
+// ServiceAlertResult describes what was done with a service alert.
+type ServiceAlertResult struct {
+	Fingerprint string               `json:"fingerprint"`
+	Action      string               `json:"action"`
+	ServiceID   string               `json:"service_id,omitempty"`
+	Status      domain.ServiceStatus `json:"status,omitempty"`
+}
+
+// Result describes what was done with an alert.
+type Result struct {
+	Action  string              `json:"action"`
+	EventID string              `json:"event_id,omitempty"`
+	Event   *domain.Event       `json:"-"`
+	Update  *domain.EventUpdate `json:"-"`
+}
+
+// Service applies alerts to events.
+type Service struct {
+	repo     Repository
+	events   EventsService
+	services CatalogService
+}
+
+// NewService creates a new webhooks service.
+func NewService(repo Repository, eventsService EventsService, services CatalogService) *Service {
+	return &Service{
+		repo:     repo,
+		events:   eventsService,
+		services: services,
+	}
+}
+
+// Apply creates an event for a new external incident or moves the linked event to alert.Status.
+// Alerts for resolved events, repeated statuses and resolutions of unknown incidents are ignored,
+// so redelivered webhooks are safe. KeepStatus alerts are posted once as the latest update.
+func (s *Service) Apply(ctx context.Context, alert Alert) (*Result, error) {
+	eventID, err := s.repo.GetLinkedEventID(ctx, alert.Source, alert.ExternalID)
+	if errors.Is(err, ErrExternalIncidentNotFound) {
+		if alert.Status.IsResolved() {
+			return &Result{Action: ActionIgnored}, nil
+		}
+		return s.create(ctx, alert)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.events.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("get linked event: %w", err)
+	}
+	if event.Status.IsResolved() {
+		return &Result{Action: ActionIgnored, EventID: eventID}, nil
+	}
+	status := alert.Status
+	if alert.KeepStatus {
+		status = event.Status
+		posted, err := s.isLatestUpdate(ctx, eventID, alert.Message)
+		if err != nil {
+			return nil, err
+		}
+		if posted {
+			return &Result{Action: ActionIgnored, EventID: eventID}, nil
+		}
+	} else if event.Status == alert.Status {
+		return &Result{Action: ActionIgnored, EventID: eventID}, nil
+	}
+
+	userID, err := s.repo.GetSystemUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	update, err := s.events.AddUpdate(ctx, events.CreateEventUpdateInput{
+		EventID:           eventID,
+		Status:            status,
+		Message:           alert.Message,
+		NotifySubscribers: event.NotifySubscribers,
+		StatusChange:      alert.statusChange(),
+	}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Action: ActionUpdated, EventID: eventID, Update: update}, nil
+}
+
+// isLatestUpdate reports whether the newest update of the event has the given message.
+func (s *Service) isLatestUpdate(ctx context.Context, eventID, message string) (bool, error) {
+	updates, _, err := s.events.ListEventUpdates(ctx, eventID, 1, 0)
+	if err != nil {
+		return false, fmt.Errorf("list event updates: %w", err)
+	}
+	return len(updates) > 0 && updates[0].Message == message, nil
+}
+
+func (s *Service) create(ctx context.Context, alert Alert) (*Result, error) {
+	status := domain.SeverityToServiceStatus(domain.EventTypeIncident, &alert.Severity)
+
+	affected := make([]domain.AffectedService, 0, len(alert.ServiceSlugs))
+	for _, slug := range alert.ServiceSlugs {
+		service, err := s.services.GetServiceBySlug(ctx, slug)
+		if err != nil {
+			if errors.Is(err, catalog.ErrServiceNotFound) {
+				return nil, fmt.Errorf("%w: %s", ErrUnknownService, slug)
+			}
+			return nil, fmt.Errorf("get service %s: %w", slug, err)
+		}
+		affected = append(affected, domain.AffectedService{ServiceID: service.ID, Status: status})
+	}
+
+	userID, err := s.repo.GetSystemUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reserving the incident first makes redelivered or concurrent alerts a no-op
+	// instead of creating duplicate events.
+	reserved, err := s.repo.ReserveExternalIncident(ctx, alert.Source, alert.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+	if !reserved {
+		return &Result{Action: ActionIgnored}, nil
+	}
+
+	severity := alert.Severity
+	event, err := s.events.CreateEvent(ctx, events.CreateEventInput{
+		Title:             alert.Title,
+		Type:              domain.EventTypeIncident,
+		Status:            alert.Status,
+		Severity:          &severity,
+		Description:       alert.Description,
+		StartedAt:         alert.StartedAt,
+		NotifySubscribers: true,
+		AffectedServices:  affected,
+		StatusChange:      alert.statusChange(),
+	}, userID)
+	if err != nil {
+		if releaseErr := s.repo.ReleaseExternalIncident(ctx, alert.Source, alert.ExternalID); releaseErr != nil {
+			slog.ErrorContext(ctx, "failed to release external incident",
+				"source", alert.Source, "external_id", alert.ExternalID, "error", releaseErr)
+		}
+		return nil, err
+	}
+
+	// The event exists either way; an unlinked reservation still blocks redeliveries.
+	if err := s.repo.LinkExternalIncident(ctx, alert.Source, alert.ExternalID, event.ID); err != nil {
+		slog.ErrorContext(ctx, "failed to link external incident",
+			"source", alert.Source, "external_id", alert.ExternalID, "event_id", event.ID, "error", err)
+	}
+
+	return &Result{Action: ActionCreated, EventID: event.ID, Event: event}, nil
+}
+
+// ApplyServiceAlert records a firing alert or forgets a resolved one and sets the stored
+// status of the affected service to the worst status of its firing alerts
+// (operational when none are left). Alerts for unknown or archived services and
+// resolutions of alerts that aren't firing are ignored, so redelivered webhooks are safe.
+func (s *Service) ApplyServiceAlert(ctx context.Context, alert ServiceAlert) (*ServiceAlertResult, error) {
+	result := &ServiceAlertResult{Fingerprint: alert.Fingerprint, Action: ActionIgnored}
+
+	var serviceID string
+	if alert.Firing {
+		service, err := s.services.GetServiceBySlug(ctx, alert.ServiceSlug)
+		if errors.Is(err, catalog.ErrServiceNotFound) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get service %s: %w", alert.ServiceSlug, err)
+		}
+		if service.IsArchived() {
+			return result, nil
+		}
+		serviceID = service.ID
+
+		if err := s.repo.SaveFiringAlert(ctx, alert.Source, alert.Fingerprint, serviceID, alert.Status); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		serviceID, err = s.repo.DeleteFiringAlert(ctx, alert.Source, alert.Fingerprint)
+		if errors.Is(err, ErrFiringAlertNotFound) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	status, firing, err := s.repo.GetWorstFiringStatus(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if !firing {
+		status = domain.ServiceStatusOperational
+	}
+
+	userID, err := s.repo.GetSystemUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := s.services.SetServiceStatus(ctx, serviceID, status, domain.StatusLogSourceWebhook, alert.Reason, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result.ServiceID = serviceID
+	result.Status = status
+	result.Action = ActionUnchanged
+	if changed {
+		result.Action = ActionUpdated
+	}
+	return result, nil
+}
@@ -0,0 +1,355 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubRepo struct {
+	links  map[string]string
+	alerts map[string]firingAlert
+}
+
+type firingAlert struct {
+	serviceID string
+	status    domain.ServiceStatus
+}
+
+func (r *stubRepo) GetLinkedEventID(_ context.Context, source, externalID string) (string, error) {
+	id := r.links[source+"/"+externalID]
+	if id == "" {
+		return "", ErrExternalIncidentNotFound
+	}
+	return id, nil
+}
+
+// ReserveExternalIncident stores reservations as links without an event.
+func (r *stubRepo) ReserveExternalIncident(_ context.Context, source, externalID string) (bool, error) {
+	if _, ok := r.links[source+"/"+externalID]; ok {
+		return false, nil
+	}
+	r.links[source+"/"+externalID] = ""
+	return true, nil
+}
+
+func (r *stubRepo) ReleaseExternalIncident(_ context.Context, source, externalID string) error {
+	if r.links[source+"/"+externalID] == "" {
+		delete(r.links, source+"/"+externalID)
+	}
+	return nil
+}
+
+func (r *stubRepo) LinkExternalIncident(_ context.Context, source, externalID, eventID string) error {
+	r.links[source+"/"+externalID] = eventID
+	return nil
+}
+
+func (r *stubRepo) GetSystemUserID(_ context.Context) (string, error) {
+	return "system-user", nil
+}
+
+func (r *stubRepo) SaveFiringAlert(_ context.Context, source, fingerprint, serviceID string, status domain.ServiceStatus) error {
+	r.alerts[source+"/"+fingerprint] = firingAlert{serviceID: serviceID, status: status}
+	return nil
+}
+
+func (r *stubRepo) DeleteFiringAlert(_ context.Context, source, fingerprint string) (string, error) {
+	alert, ok := r.alerts[source+"/"+fingerprint]
+	if !ok {
+		return "", ErrFiringAlertNotFound
+	}
+	delete(r.alerts, source+"/"+fingerprint)
+	return alert.serviceID, nil
+}
+
+func (r *stubRepo) GetWorstFiringStatus(_ context.Context, serviceID string) (domain.ServiceStatus, bool, error) {
+	priority := map[domain.ServiceStatus]int{
+		domain.ServiceStatusMaintenance:   1,
+		domain.ServiceStatusDegraded:      2,
+		domain.ServiceStatusPartialOutage: 3,
+		domain.ServiceStatusMajorOutage:   4,
+	}
+	var worst domain.ServiceStatus
+	for _, alert := range r.alerts {
+		if alert.serviceID == serviceID && priority[alert.status] > priority[worst] {
+			worst = alert.status
+		}
+	}
+	return worst, worst != "", nil
+}
+
+type stubEvents struct {
+	events     map[string]*domain.Event
+	created    []events.CreateEventInput
+	updates    []events.CreateEventUpdateInput
+	failCreate bool
+}
+
+func (e *stubEvents) CreateEvent(_ context.Context, input events.CreateEventInput, createdBy string) (*domain.Event, error) {
+	if e.failCreate {
+		return nil, errors.New("create failed")
+	}
+	e.created = append(e.created, input)
+	event := &domain.Event{ID: "evt-1", Status: input.Status, CreatedBy: createdBy, NotifySubscribers: input.NotifySubscribers}
+	e.events[event.ID] = event
+	return event, nil
+}
+
+func (e *stubEvents) GetEvent(_ context.Context, id string) (*domain.Event, error) {
+	return e.events[id], nil
+}
+
+func (e *stubEvents) AddUpdate(_ context.Context, input events.CreateEventUpdateInput, createdBy string) (*domain.EventUpdate, error) {
+	e.updates = append(e.updates, input)
+	e.events[input.EventID].Status = input.Status
+	return &domain.EventUpdate{EventID: input.EventID, Status: input.Status, CreatedBy: createdBy}, nil
+}
+
+// ListEventUpdates returns the newest update only.
+func (e *stubEvents) ListEventUpdates(_ context.Context, eventID string, _, _ int) ([]*domain.EventUpdate, int, error) {
+	for i := len(e.updates) - 1; i >= 0; i-- {
+		if e.updates[i].EventID == eventID {
+			latest := e.updates[i]
+			return []*domain.EventUpdate{{EventID: eventID, Status: latest.Status, Message: latest.Message}}, 1, nil
+		}
+	}
+	return nil, 0, nil
+}
+
+type stubCatalog struct {
+	slugs    map[string]string
+	statuses map[string]domain.ServiceStatus
+	reasons  []string
+}
+
+func (c *stubCatalog) GetServiceBySlug(_ context.Context, slug string) (*domain.Service, error) {
+	id, ok := c.slugs[slug]
+	if !ok {
+		return nil, catalog.ErrServiceNotFound
+	}
+	return &domain.Service{ID: id, Slug: slug}, nil
+}
+
+func (c *stubCatalog) SetServiceStatus(_ context.Context, serviceID string, status domain.ServiceStatus, _ domain.StatusLogSourceType, reason, _ string) (bool, error) {
+	if c.statuses[serviceID] == status {
+		return false, nil
+	}
+	c.statuses[serviceID] = status
+	c.reasons = append(c.reasons, reason)
+	return true, nil
+}
+
+func newStubCatalog() *stubCatalog {
+	return &stubCatalog{
+		slugs:    map[string]string{"api": "svc-api"},
+		statuses: map[string]domain.ServiceStatus{"svc-api": domain.ServiceStatusOperational},
+	}
+}
+
+func newTestService() (*Service, *stubEvents) {
+	ev := &stubEvents{events: make(map[string]*domain.Event)}
+	svc := NewService(&stubRepo{links: make(map[string]string)}, ev, newStubCatalog())
+	return svc, ev
+}
+
+func newTestAlertService() (*Service, *stubCatalog) {
+	cat := newStubCatalog()
+	svc := NewService(&stubRepo{alerts: make(map[string]firingAlert)}, nil, cat)
+	return svc, cat
+}
+
+func serviceAlert(fingerprint string, firing bool, status domain.ServiceStatus) ServiceAlert {
+	return ServiceAlert{
+		Source:      "test",
+		Fingerprint: fingerprint,
+		ServiceSlug: "api",
+		Firing:      firing,
+		Status:      status,
+		Reason:      "alert " + fingerprint,
+	}
+}
+
+func testAlert(status domain.EventStatus) Alert {
+	return Alert{
+		Source:       "test",
+		ExternalID:   "INC-1",
+		Title:        "API down",
+		Severity:     domain.SeverityCritical,
+		ServiceSlugs: []string{"api"},
+		Status:       status,
+		Message:      "status changed",
+	}
+}
+
+func TestService_Apply_Lifecycle(t *testing.T) {
+	svc, ev := newTestService()
+	ctx := context.Background()
+
+	result, err := svc.Apply(ctx, testAlert(domain.EventStatusInvestigating))
+	require.NoError(t, err)
+	assert.Equal(t, ActionCreated, result.Action)
+	require.Len(t, ev.created, 1)
+	assert.Equal(t, []domain.AffectedService{{ServiceID: "svc-api", Status: domain.ServiceStatusMajorOutage}},
+		ev.created[0].AffectedServices)
+	assert.Equal(t, "system-user", ev.events["evt-1"].CreatedBy)
+
+	// Redelivery is a no-op
+	result, err = svc.Apply(ctx, testAlert(domain.EventStatusInvestigating))
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, result.Action)
+
+	result, err = svc.Apply(ctx, testAlert(domain.EventStatusIdentified))
+	require.NoError(t, err)
+	assert.Equal(t, ActionUpdated, result.Action)
+	assert.Equal(t, "evt-1", result.EventID)
+
+	result, err = svc.Apply(ctx, testAlert(domain.EventStatusResolved))
+	require.NoError(t, err)
+	assert.Equal(t, ActionUpdated, result.Action)
+
+	// Resolved events are not reopened
+	result, err = svc.Apply(ctx, testAlert(domain.EventStatusInvestigating))
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, result.Action)
+
+	assert.Len(t, ev.created, 1)
+	assert.Len(t, ev.updates, 2)
+
+	// Status changes are logged as made by the webhook
+	want := events.StatusChangeContext{SourceType: domain.StatusLogSourceWebhook, WebhookSource: "test"}
+	assert.Equal(t, want, ev.created[0].StatusChange)
+	for _, update := range ev.updates {
+		assert.Equal(t, want, update.StatusChange)
+	}
+}
+
+func TestService_Apply_KeepStatus(t *testing.T) {
+	svc, ev := newTestService()
+	ctx := context.Background()
+
+	_, err := svc.Apply(ctx, testAlert(domain.EventStatusInvestigating))
+	require.NoError(t, err)
+	_, err = svc.Apply(ctx, testAlert(domain.EventStatusIdentified))
+	require.NoError(t, err)
+
+	ack := testAlert(domain.EventStatusInvestigating)
+	ack.Message = "acknowledged"
+	ack.KeepStatus = true
+
+	result, err := svc.Apply(ctx, ack)
+	require.NoError(t, err)
+	assert.Equal(t, ActionUpdated, result.Action)
+	require.Len(t, ev.updates, 2)
+	assert.Equal(t, domain.EventStatusIdentified, ev.updates[1].Status)
+	assert.Equal(t, "acknowledged", ev.updates[1].Message)
+
+	// Redelivery is a no-op
+	result, err = svc.Apply(ctx, ack)
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, result.Action)
+	assert.Len(t, ev.updates, 2)
+}
+
+func TestService_Apply_ResolveUnknownIncident(t *testing.T) {
+	svc, ev := newTestService()
+
+	result, err := svc.Apply(context.Background(), testAlert(domain.EventStatusResolved))
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, result.Action)
+	assert.Empty(t, ev.created)
+}
+
+func TestService_Apply_UnknownService(t *testing.T) {
+	svc, ev := newTestService()
+
+	alert := testAlert(domain.EventStatusInvestigating)
+	alert.ServiceSlugs = []string{"api", "missing"}
+
+	_, err := svc.Apply(context.Background(), alert)
+	require.ErrorIs(t, err, ErrUnknownService)
+	assert.Contains(t, err.Error(), "missing")
+	assert.Empty(t, ev.created)
+}
+
+func TestService_Apply_ReservedIncident(t *testing.T) {
+	repo := &stubRepo{links: make(map[string]string)}
+	ev := &stubEvents{events: make(map[string]*domain.Event), failCreate: true}
+	svc := NewService(repo, ev, newStubCatalog())
+	ctx := context.Background()
+
+	// A failed create releases the reservation
+	_, err := svc.Apply(ctx, testAlert(domain.EventStatusInvestigating))
+	require.Error(t, err)
+	assert.Empty(t, repo.links)
+
+	// A delivery racing with the creation of the event is ignored
+	ev.failCreate = false
+	reserved, err := repo.ReserveExternalIncident(ctx, "test", "INC-1")
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	result, err := svc.Apply(ctx, testAlert(domain.EventStatusInvestigating))
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, result.Action)
+	assert.Empty(t, ev.created)
+}
+
+func TestService_ApplyServiceAlert_WorstFiringStatus(t *testing.T) {
+	svc, cat := newTestAlertService()
+	ctx := context.Background()
+
+	result, err := svc.ApplyServiceAlert(ctx, serviceAlert("a", true, domain.ServiceStatusDegraded))
+	require.NoError(t, err)
+	assert.Equal(t, ActionUpdated, result.Action)
+	assert.Equal(t, "svc-api", result.ServiceID)
+	assert.Equal(t, domain.ServiceStatusDegraded, cat.statuses["svc-api"])
+
+	result, err = svc.ApplyServiceAlert(ctx, serviceAlert("b", true, domain.ServiceStatusMajorOutage))
+	require.NoError(t, err)
+	assert.Equal(t, ActionUpdated, result.Action)
+	assert.Equal(t, domain.ServiceStatusMajorOutage, cat.statuses["svc-api"])
+
+	// Redelivery keeps the status
+	result, err = svc.ApplyServiceAlert(ctx, serviceAlert("a", true, domain.ServiceStatusDegraded))
+	require.NoError(t, err)
+	assert.Equal(t, ActionUnchanged, result.Action)
+
+	// Resolving the worst alert falls back to the remaining one
+	result, err = svc.ApplyServiceAlert(ctx, serviceAlert("b", false, ""))
+	require.NoError(t, err)
+	assert.Equal(t, ActionUpdated, result.Action)
+	assert.Equal(t, domain.ServiceStatusDegraded, cat.statuses["svc-api"])
+
+	result, err = svc.ApplyServiceAlert(ctx, serviceAlert("a", false, ""))
+	require.NoError(t, err)
+	assert.Equal(t, ActionUpdated, result.Action)
+	assert.Equal(t, domain.ServiceStatusOperational, result.Status)
+	assert.Equal(t, domain.ServiceStatusOperational, cat.statuses["svc-api"])
+
+	assert.Equal(t, []string{"alert a", "alert b", "alert b", "alert a"}, cat.reasons)
+}
+
+func TestService_ApplyServiceAlert_Ignored(t *testing.T) {
+	svc, cat := newTestAlertService()
+	ctx := context.Background()
+
+	unknown := serviceAlert("a", true, domain.ServiceStatusDegraded)
+	unknown.ServiceSlug = "missing"
+	result, err := svc.ApplyServiceAlert(ctx, unknown)
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, result.Action)
+
+	result, err = svc.ApplyServiceAlert(ctx, serviceAlert("never-fired", false, ""))
+	require.NoError(t, err)
+	assert.Equal(t, ActionIgnored, result.Action)
+	assert.Equal(t, "never-fired", result.Fingerprint)
+
+	assert.Empty(t, cat.reasons)
+}
@@ -0,0 +1,248 @@
+// Package slack answers Slack slash commands with service status.
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/bissquit/incident-garden/internal/pkg/ctxlog"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// SignatureHeader carries "v0=<hex hmac-sha256 of v0:timestamp:body>".
+	SignatureHeader = "X-Slack-Signature"
+	// TimestampHeader carries the request timestamp in Unix seconds.
+	TimestampHeader = "X-Slack-Request-Timestamp"
+
+	// maxClockSkew rejects signed requests older or newer than this to prevent replays.
+	maxClockSkew = 5 * time.Minute
+	// recentEventsLimit is the number of latest events shown for a service.
+	recentEventsLimit = 3
+	// maxBodySize limits slash command bodies read for signature verification.
+	maxBodySize = 1 << 20
+)
+
+// Slack response visibility.
+const (
+	ResponseInChannel = "in_channel"
+	ResponseEphemeral = "ephemeral"
+)
+
+// ServiceReader looks up services with their effective status.
+type ServiceReader interface {
+	GetServiceBySlugWithEffectiveStatus(ctx context.Context, slug string) (*domain.ServiceWithEffectiveStatus, error)
+}
+
+// EventsReader lists events of a service.
+type EventsReader interface {
+	ListEventsByServiceID(ctx context.Context, serviceID string, filter events.ServiceEventFilter) ([]*domain.Event, int, error)
+}
+
+// Config holds Slack slash command settings.
+type Config struct {
+	SigningSecret string // signing secret of the Slack app
+}
+
+// Response is a Slack slash command response message.
+type Response struct {
+	ResponseType string  `json:"response_type"`
+	Text         string  `json:"text"`
+	Blocks       []Block `json:"blocks,omitempty"`
+}
+
+// Block is a Block Kit layout block.
+type Block struct {
+	Type     string `json:"type"`
+	Text     *Text  `json:"text,omitempty"`
+	Fields   []Text `json:"fields,omitempty"`
+	Elements []Text `json:"elements,omitempty"`
+}
+
+// Text is a Block Kit text object.
+type Text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SlashCommandHandler handles Slack slash command deliveries.
+type SlashCommandHandler struct {
+	services ServiceReader
+	events   EventsReader
+	secret   []byte
+	now      func() time.Time
+}
+
+// NewSlashCommandHandler creates a new Slack slash command handler.
+func NewSlashCommandHandler(services ServiceReader, events EventsReader, config Config) *SlashCommandHandler {
+	return &SlashCommandHandler{
+		services: services,
+		events:   events,
+		secret:   []byte(config.SigningSecret),
+		now:      time.Now,
+	}
+}
+
+// RegisterRoutes registers the slash command route. Authentication is done by signature, not by session.
+func (h *SlashCommandHandler) RegisterRoutes(r chi.Router) {
+	r.Post("/webhooks/slack/command", h.HandleCommand)
+}
+
+// HandleCommand handles POST /webhooks/slack/command.
+// The form body carries the command text: "/status <service-slug>".
+func (h *SlashCommandHandler) HandleCommand(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	if !h.verify(r.Header.Get(TimestampHeader), r.Header.Get(SignatureHeader), body) {
+		httputil.Error(w, http.StatusUnauthorized, "invalid signature")
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid form body")
+		return
+	}
+
+	writeResponse(w, h.respond(r.Context(), form.Get("command"), form.Get("text")))
+}
+
+// respond builds the reply for a slash command with the given text.
+func (h *SlashCommandHandler) respond(ctx context.Context, command, text string) Response {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		if command == "" {
+			command = "/status"
+		}
+		return ephemeral(fmt.Sprintf("Usage: `%s <service-slug>`", command))
+	}
+	slug := strings.ToLower(fields[0])
+
+	service, err := h.services.GetServiceBySlugWithEffectiveStatus(ctx, slug)
+	if errors.Is(err, catalog.ErrServiceNotFound) {
+		return ephemeral(fmt.Sprintf("Service `%s` not found.", slug))
+	}
+	if err != nil {
+		ctxlog.FromContext(ctx).Error("slack command: get service", "slug", slug, "error", err)
+		return ephemeral("Something went wrong, please try again later.")
+	}
+
+	recent, _, err := h.events.ListEventsByServiceID(ctx, service.ID, events.ServiceEventFilter{Limit: recentEventsLimit})
+	if err != nil {
+		ctxlog.FromContext(ctx).Error("slack command: list service events", "service_id", service.ID, "error", err)
+		return ephemeral("Something went wrong, please try again later.")
+	}
+
+	return StatusResponse(service, recent)
+}
+
+// StatusResponse renders a service status with its recent events as Block Kit blocks.
+func StatusResponse(service *domain.ServiceWithEffectiveStatus, recent []*domain.Event) Response {
+	summary := fmt.Sprintf("%s is %s", service.Name, statusLabel(service.EffectiveStatus))
+
+	blocks := []Block{
+		{Type: "header", Text: &Text{Type: "plain_text", Text: service.Name}},
+		{Type: "section", Fields: []Text{
+			{Type: "mrkdwn", Text: "*Status*\n" + statusLabel(service.Status)},
+			{Type: "mrkdwn", Text: "*Effective status*\n" + statusLabel(service.EffectiveStatus)},
+		}},
+	}
+
+	if len(recent) == 0 {
+		blocks = append(blocks, Block{Type: "context", Elements: []Text{{Type: "mrkdwn", Text: "No events yet."}}})
+	} else {
+		lines := make([]string, 0, len(recent))
+		for _, event := range recent {
+			lines = append(lines, fmt.Sprintf("• *%s* — %s, %s", event.Title, event.Status, eventTime(event).UTC().Format("2006-01-02 15:04 UTC")))
+		}
+		blocks = append(blocks, Block{Type: "section", Text: &Text{Type: "mrkdwn", Text: "*Recent events*\n" + strings.Join(lines, "\n")}})
+	}
+
+	return Response{ResponseType: ResponseInChannel, Text: summary, Blocks: blocks}
+}
+
+// verify checks the Slack request signature and rejects stale timestamps.
+func (h *SlashCommandHandler) verify(timestamp, signature string, body []byte) bool {
+	if len(h.secret) == 0 || timestamp == "" || signature == "" {
+		return false
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	skew := h.now().Sub(time.Unix(ts, 0))
+	if skew > maxClockSkew || skew < -maxClockSkew {
+		return false
+	}
+
+	hexSig, found := strings.CutPrefix(signature, "v0=")
+	if !found {
+		return false
+	}
+	decoded, err := hex.DecodeString(hexSig)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(decoded, Sign(h.secret, timestamp, body))
+}
+
+// Sign computes the raw Slack v0 signature of a request body.
+func Sign(secret []byte, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+func ephemeral(text string) Response {
+	return Response{
+		ResponseType: ResponseEphemeral,
+		Text:         text,
+		Blocks:       []Block{{Type: "section", Text: &Text{Type: "mrkdwn", Text: text}}},
+	}
+}
+
+// statusLabel renders a service status for humans ("partial_outage" → "partial outage").
+func statusLabel(status domain.ServiceStatus) string {
+	return strings.ReplaceAll(string(status), "_", " ")
+}
+
+// eventTime returns when an event started, falling back to its scheduled start and creation time.
+func eventTime(event *domain.Event) time.Time {
+	switch {
+	case event.StartedAt != nil:
+		return *event.StartedAt
+	case event.ScheduledStartAt != nil:
+		return *event.ScheduledStartAt
+	default:
+		return event.CreatedAt
+	}
+}
+
+// writeResponse writes a Slack message. Slack expects the bare message, not the API envelope.
+func writeResponse(w http.ResponseWriter, resp Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
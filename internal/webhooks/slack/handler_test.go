@@ -0,0 +1,188 @@
+package slack
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+type fakeServices struct {
+	services map[string]*domain.ServiceWithEffectiveStatus
+	err      error
+}
+
+func (f *fakeServices) GetServiceBySlugWithEffectiveStatus(_ context.Context, slug string) (*domain.ServiceWithEffectiveStatus, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	service, ok := f.services[slug]
+	if !ok {
+		return nil, catalog.ErrServiceNotFound
+	}
+	return service, nil
+}
+
+type fakeEvents struct {
+	events []*domain.Event
+	filter events.ServiceEventFilter
+}
+
+func (f *fakeEvents) ListEventsByServiceID(_ context.Context, _ string, filter events.ServiceEventFilter) ([]*domain.Event, int, error) {
+	f.filter = filter
+	return f.events, len(f.events), nil
+}
+
+// postCommand sends a signed slash command with the given text.
+func postCommand(t *testing.T, handler *SlashCommandHandler, text string, signedAt time.Time) *httptest.ResponseRecorder {
+	t.Helper()
+	body := url.Values{
+		"command":      {"/status"},
+		"text":         {text},
+		"user_id":      {"U2147483697"},
+		"response_url": {"https://hooks.slack.com/commands/1234/5678"},
+	}.Encode()
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/slack/command", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "v0="+hex.EncodeToString(Sign([]byte(testSecret), timestamp, []byte(body))))
+
+	r := chi.NewRouter()
+	handler.RegisterRoutes(r)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeResponse(t *testing.T, rec *httptest.ResponseRecorder) Response {
+	t.Helper()
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp
+}
+
+func newTestHandler(services *fakeServices, eventsReader *fakeEvents) *SlashCommandHandler {
+	return NewSlashCommandHandler(services, eventsReader, Config{SigningSecret: testSecret})
+}
+
+func TestHandleCommand_ServiceFound(t *testing.T) {
+	started := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	service := &domain.ServiceWithEffectiveStatus{
+		Service:         domain.Service{ID: "svc-1", Name: "API Gateway", Slug: "api", Status: domain.ServiceStatusOperational},
+		EffectiveStatus: domain.ServiceStatusPartialOutage,
+		HasActiveEvents: true,
+	}
+	eventsReader := &fakeEvents{events: []*domain.Event{
+		{Title: "Elevated error rate", Status: domain.EventStatusInvestigating, StartedAt: &started},
+		{Title: "Database upgrade", Status: domain.EventStatusCompleted, CreatedAt: started.Add(-48 * time.Hour)},
+	}}
+	handler := newTestHandler(&fakeServices{services: map[string]*domain.ServiceWithEffectiveStatus{"api": service}}, eventsReader)
+
+	resp := decodeResponse(t, postCommand(t, handler, " API ", time.Now()))
+
+	assert.Equal(t, ResponseInChannel, resp.ResponseType)
+	assert.Equal(t, "API Gateway is partial outage", resp.Text)
+	assert.Equal(t, 3, eventsReader.filter.Limit, "last 3 events are requested")
+	require.Len(t, resp.Blocks, 3)
+
+	assert.Equal(t, "header", resp.Blocks[0].Type)
+	assert.Equal(t, "API Gateway", resp.Blocks[0].Text.Text)
+
+	require.Len(t, resp.Blocks[1].Fields, 2)
+	assert.Equal(t, "*Status*\noperational", resp.Blocks[1].Fields[0].Text)
+	assert.Equal(t, "*Effective status*\npartial outage", resp.Blocks[1].Fields[1].Text)
+
+	recent := resp.Blocks[2].Text.Text
+	assert.Contains(t, recent, "*Elevated error rate* — investigating, 2026-03-10 12:00 UTC")
+	assert.Contains(t, recent, "*Database upgrade* — completed, 2026-03-08 12:00 UTC")
+}
+
+func TestHandleCommand_NoEvents(t *testing.T) {
+	service := &domain.ServiceWithEffectiveStatus{
+		Service:         domain.Service{ID: "svc-1", Name: "API Gateway", Slug: "api", Status: domain.ServiceStatusOperational},
+		EffectiveStatus: domain.ServiceStatusOperational,
+	}
+	handler := newTestHandler(&fakeServices{services: map[string]*domain.ServiceWithEffectiveStatus{"api": service}}, &fakeEvents{})
+
+	resp := decodeResponse(t, postCommand(t, handler, "api", time.Now()))
+
+	assert.Equal(t, ResponseInChannel, resp.ResponseType)
+	require.Len(t, resp.Blocks, 3)
+	assert.Equal(t, "context", resp.Blocks[2].Type)
+	assert.Equal(t, "No events yet.", resp.Blocks[2].Elements[0].Text)
+}
+
+func TestHandleCommand_ServiceNotFound(t *testing.T) {
+	handler := newTestHandler(&fakeServices{}, &fakeEvents{})
+
+	resp := decodeResponse(t, postCommand(t, handler, "missing", time.Now()))
+
+	assert.Equal(t, ResponseEphemeral, resp.ResponseType)
+	assert.Equal(t, "Service `missing` not found.", resp.Text)
+}
+
+func TestHandleCommand_MissingSlug(t *testing.T) {
+	handler := newTestHandler(&fakeServices{}, &fakeEvents{})
+
+	resp := decodeResponse(t, postCommand(t, handler, "  ", time.Now()))
+
+	assert.Equal(t, ResponseEphemeral, resp.ResponseType)
+	assert.Equal(t, "Usage: `/status <service-slug>`", resp.Text)
+}
+
+func TestHandleCommand_LookupError(t *testing.T) {
+	handler := newTestHandler(&fakeServices{err: errors.New("connection refused")}, &fakeEvents{})
+
+	resp := decodeResponse(t, postCommand(t, handler, "api", time.Now()))
+
+	assert.Equal(t, ResponseEphemeral, resp.ResponseType)
+	assert.NotContains(t, resp.Text, "connection refused", "internal errors are not leaked")
+}
+
+func TestHandleCommand_InvalidSignature(t *testing.T) {
+	handler := newTestHandler(&fakeServices{}, &fakeEvents{})
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/slack/command", strings.NewReader("text=api"))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "v0=deadbeef")
+	rec := httptest.NewRecorder()
+	handler.HandleCommand(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestHandleCommand_StaleTimestamp(t *testing.T) {
+	handler := newTestHandler(&fakeServices{}, &fakeEvents{})
+
+	rec := postCommand(t, handler, "api", time.Now().Add(-10*time.Minute))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, "replayed requests are rejected")
+}
+
+func TestHandleCommand_EmptySecret(t *testing.T) {
+	handler := NewSlashCommandHandler(&fakeServices{}, &fakeEvents{}, Config{})
+
+	rec := postCommand(t, handler, "api", time.Now())
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
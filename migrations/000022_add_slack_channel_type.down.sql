@@ -0,0 +1,8 @@
+DELETE FROM notification_channels WHERE type = 'slack';
+
+-- Restore previous constraint (without slack)
+ALTER TABLE notification_channels
+DROP CONSTRAINT check_channel_type;
+
+ALTER TABLE notification_channels
+ADD CONSTRAINT check_channel_type CHECK (type IN ('email', 'telegram', 'mattermost'));
@@ -0,0 +1,6 @@
+-- Update constraint to include 'slack'
+ALTER TABLE notification_channels
+DROP CONSTRAINT check_channel_type;
+
+ALTER TABLE notification_channels
+ADD CONSTRAINT check_channel_type CHECK (type IN ('email', 'telegram', 'mattermost', 'slack'));
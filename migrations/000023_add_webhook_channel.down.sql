@@ -0,0 +1,11 @@
+ALTER TABLE notification_channels
+DROP COLUMN secret;
+
+DELETE FROM notification_channels WHERE type = 'webhook';
+
+-- Restore previous constraint (without webhook)
+ALTER TABLE notification_channels
+DROP CONSTRAINT check_channel_type;
+
+ALTER TABLE notification_channels
+ADD CONSTRAINT check_channel_type CHECK (type IN ('email', 'telegram', 'mattermost', 'slack'));
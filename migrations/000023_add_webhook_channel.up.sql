@@ -0,0 +1,10 @@
+-- Update constraint to include 'webhook'
+ALTER TABLE notification_channels
+DROP CONSTRAINT check_channel_type;
+
+ALTER TABLE notification_channels
+ADD CONSTRAINT check_channel_type CHECK (type IN ('email', 'telegram', 'mattermost', 'slack', 'webhook'));
+
+-- Optional HMAC-SHA256 signing secret (webhook channels only)
+ALTER TABLE notification_channels
+ADD COLUMN secret TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS external_incidents;
+
+-- Events and updates of the system user are removed by CASCADE
+DELETE FROM service_status_log
+WHERE created_by = (SELECT id FROM users WHERE email = 'webhooks@incident-garden.local');
+
+DELETE FROM users WHERE email = 'webhooks@incident-garden.local';
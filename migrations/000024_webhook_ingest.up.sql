@@ -0,0 +1,23 @@
+-- Links incidents of external alerting systems (PagerDuty, ...) to events
+CREATE TABLE external_incidents (
+    source VARCHAR(50) NOT NULL,
+    external_id VARCHAR(255) NOT NULL,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (source, external_id)
+);
+
+CREATE INDEX idx_external_incidents_event_id ON external_incidents(event_id);
+
+-- System user that owns events created by incoming webhooks.
+-- Inactive and without a valid password hash: cannot log in.
+INSERT INTO users (email, password_hash, first_name, last_name, role, is_active)
+VALUES (
+    'webhooks@incident-garden.local',
+    '!',
+    'Webhook',
+    'Integration',
+    'operator',
+    false
+) ON CONFLICT (email) DO NOTHING;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS firing_alerts;
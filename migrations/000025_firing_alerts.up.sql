@@ -0,0 +1,15 @@
+-- Firing alerts of monitoring systems (Prometheus Alertmanager) per service.
+-- Stored status of a service is the worst status of its firing alerts, operational when none.
+CREATE TABLE firing_alerts (
+    source VARCHAR(50) NOT NULL,
+    fingerprint VARCHAR(255) NOT NULL,
+    service_id UUID NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    status VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (source, fingerprint),
+    CONSTRAINT check_firing_alert_status CHECK (status IN ('degraded', 'partial_outage', 'major_outage', 'maintenance'))
+);
+
+CREATE INDEX idx_firing_alerts_service_id ON firing_alerts(service_id);
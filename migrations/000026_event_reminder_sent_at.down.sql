@@ -0,0 +1,6 @@
+DELETE FROM notification_queue WHERE message_type = 'reminder';
+ALTER TABLE notification_queue DROP CONSTRAINT check_queue_message_type;
+ALTER TABLE notification_queue ADD CONSTRAINT check_queue_message_type
+    CHECK (message_type IN ('initial', 'update', 'resolved', 'completed', 'cancelled'));
+
+ALTER TABLE events DROP COLUMN IF EXISTS reminder_sent_at;
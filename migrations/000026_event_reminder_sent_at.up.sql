@@ -0,0 +1,7 @@
+-- When the scheduled-start reminder of a maintenance was sent; prevents duplicate reminders.
+ALTER TABLE events ADD COLUMN reminder_sent_at TIMESTAMP;
+
+-- Allow maintenance reminders in the notification queue.
+ALTER TABLE notification_queue DROP CONSTRAINT check_queue_message_type;
+ALTER TABLE notification_queue ADD CONSTRAINT check_queue_message_type
+    CHECK (message_type IN ('initial', 'update', 'resolved', 'completed', 'cancelled', 'reminder'));
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS subscriber_tokens;
+
+-- Channels of public subscribers are removed by CASCADE
+DELETE FROM users WHERE email = 'subscribers@incident-garden.local';
@@ -0,0 +1,18 @@
+-- System user that owns channels of public subscribers (visitors without an account).
+-- Inactive and without a valid password hash: cannot log in.
+INSERT INTO users (email, password_hash, first_name, last_name, role, is_active)
+VALUES (
+    'subscribers@incident-garden.local',
+    '!',
+    'Public',
+    'Subscriber',
+    'user',
+    false
+) ON CONFLICT (email) DO NOTHING;
+
+-- Tokens returned to public subscribers for confirming and cancelling a subscription
+CREATE TABLE subscriber_tokens (
+    token VARCHAR(64) PRIMARY KEY,
+    channel_id UUID NOT NULL UNIQUE REFERENCES notification_channels(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1 @@
+ALTER TABLE event_updates DROP COLUMN IF EXISTS changes;
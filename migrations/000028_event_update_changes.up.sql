@@ -0,0 +1,2 @@
+-- Event fields changed by an update: {"field": {"from": ..., "to": ...}}
+ALTER TABLE event_updates ADD COLUMN changes JSONB;
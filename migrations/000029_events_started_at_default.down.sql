@@ -0,0 +1 @@
+ALTER TABLE events ALTER COLUMN started_at DROP DEFAULT;
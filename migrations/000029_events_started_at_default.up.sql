@@ -0,0 +1,2 @@
+-- started_at stays nullable (scheduled maintenance has not started); new rows default to creation time
+ALTER TABLE events ALTER COLUMN started_at SET DEFAULT NOW();
@@ -0,0 +1,16 @@
+-- One row per delivery attempt of a queued notification
+CREATE TABLE notification_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    notification_id UUID NOT NULL REFERENCES notification_queue(id) ON DELETE CASCADE,
+    channel_id UUID NOT NULL REFERENCES notification_channels(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    sent_at TIMESTAMP,
+    failed_at TIMESTAMP,
+    failure_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT check_delivery_status CHECK (status IN ('pending', 'delivered', 'failed'))
+);
+
+CREATE INDEX idx_notification_deliveries_notification
+    ON notification_deliveries(notification_id, created_at);
@@ -0,0 +1,2 @@
+-- Escalation updates of the system user are removed by CASCADE
+DELETE FROM users WHERE email = 'escalation@incident-garden.local';
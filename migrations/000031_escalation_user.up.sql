@@ -0,0 +1,11 @@
+-- System user that authors automatic severity escalation updates.
+-- Inactive and without a valid password hash: cannot log in.
+INSERT INTO users (email, password_hash, first_name, last_name, role, is_active)
+VALUES (
+    'escalation@incident-garden.local',
+    '!',
+    'Automatic',
+    'Escalation',
+    'operator',
+    false
+) ON CONFLICT (email) DO NOTHING;
@@ -0,0 +1,10 @@
+-- Post-mortem document of a resolved event, one per event
+CREATE TABLE event_postmortems (
+    event_id UUID PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    title VARCHAR(500) NOT NULL,
+    body TEXT NOT NULL,
+    published_at TIMESTAMPTZ,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1 @@
+ALTER TABLE notification_channels DROP COLUMN IF EXISTS min_severity;
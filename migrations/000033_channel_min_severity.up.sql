@@ -0,0 +1,3 @@
+-- Lowest incident severity a channel is notified about, NULL = any
+ALTER TABLE notification_channels
+    ADD COLUMN min_severity VARCHAR(20) CHECK (min_severity IN ('minor', 'major', 'critical'));
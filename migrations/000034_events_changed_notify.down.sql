@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS events_changed_notify ON events;
+DROP FUNCTION IF EXISTS notify_events_changed();
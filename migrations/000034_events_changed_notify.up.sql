@@ -0,0 +1,18 @@
+-- Publishes event row changes for the watch stream.
+-- The payload carries only the ID to stay well below the 8000-byte NOTIFY limit;
+-- listeners load the current event themselves.
+CREATE OR REPLACE FUNCTION notify_events_changed()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM pg_notify('events_changed', json_build_object('op', TG_OP, 'id', OLD.id)::text);
+        RETURN OLD;
+    END IF;
+    PERFORM pg_notify('events_changed', json_build_object('op', TG_OP, 'id', NEW.id)::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER events_changed_notify
+    AFTER INSERT OR UPDATE OR DELETE ON events
+    FOR EACH ROW EXECUTE FUNCTION notify_events_changed();
@@ -0,0 +1,4 @@
+-- Display name, defaults to first and last name of existing users
+ALTER TABLE users ADD COLUMN name VARCHAR(100) NOT NULL DEFAULT '';
+
+UPDATE users SET name = LEFT(TRIM(CONCAT_WS(' ', first_name, last_name)), 100);
@@ -0,0 +1,6 @@
+ALTER TABLE users
+    ADD COLUMN last_login_at TIMESTAMP,
+    ADD COLUMN deactivated_at TIMESTAMP;
+
+-- Best guess for users deactivated before the column existed
+UPDATE users SET deactivated_at = updated_at WHERE NOT is_active;
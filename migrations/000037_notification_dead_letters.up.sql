@@ -0,0 +1,17 @@
+-- Notifications that exhausted their attempts, kept for inspection and manual requeue
+CREATE TABLE notification_dead_letters (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    notification_id UUID NOT NULL UNIQUE REFERENCES notification_queue(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    channel_id UUID NOT NULL,
+    message_type VARCHAR(20) NOT NULL,
+    payload JSONB NOT NULL,
+    last_error TEXT NOT NULL,
+    attempts INT NOT NULL,
+    -- Start of every delivery attempt, from notification_deliveries
+    attempted_at TIMESTAMP[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_notification_dead_letters_created
+    ON notification_dead_letters(created_at DESC);
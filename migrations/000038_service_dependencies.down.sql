@@ -0,0 +1,11 @@
+-- Dependency-opened incidents stay in the log as regular event changes
+UPDATE service_status_log SET source_type = 'event' WHERE source_type = 'dependency';
+
+ALTER TABLE service_status_log
+DROP CONSTRAINT check_source_type;
+
+ALTER TABLE service_status_log
+ADD CONSTRAINT check_source_type CHECK (source_type IN ('manual', 'event', 'webhook'));
+
+DROP TABLE IF EXISTS service_dependencies;
+DROP TYPE IF EXISTS dependency_type;
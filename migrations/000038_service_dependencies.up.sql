@@ -0,0 +1,22 @@
+-- Service dependency mapping: dependent_service_id depends on dependency_service_id
+CREATE TYPE dependency_type AS ENUM ('hard', 'soft');
+
+CREATE TABLE service_dependencies (
+    dependent_service_id UUID NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    dependency_service_id UUID NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    dependency_type dependency_type NOT NULL DEFAULT 'hard',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (dependent_service_id, dependency_service_id),
+
+    CONSTRAINT check_not_self_dependency CHECK (dependent_service_id <> dependency_service_id)
+);
+
+-- Downstream lookups (who depends on a service in outage)
+CREATE INDEX idx_service_dependencies_dependency ON service_dependencies(dependency_service_id);
+
+-- Incidents opened by an outage of a dependency
+ALTER TABLE service_status_log
+DROP CONSTRAINT check_source_type;
+
+ALTER TABLE service_status_log
+ADD CONSTRAINT check_source_type CHECK (source_type IN ('manual', 'event', 'webhook', 'dependency'));
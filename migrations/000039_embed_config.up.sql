@@ -0,0 +1,16 @@
+-- Settings of the embeddable status badge (GET /embed/widget.js); a single row
+CREATE TABLE embed_config (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE,
+    position VARCHAR(20) NOT NULL DEFAULT 'bottom-right',
+    operational_color VARCHAR(7) NOT NULL DEFAULT '#2e7d32',
+    incident_color VARCHAR(7) NOT NULL DEFAULT '#c62828',
+    maintenance_color VARCHAR(7) NOT NULL DEFAULT '#1565c0',
+    status_page_url TEXT NOT NULL DEFAULT '',
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT check_single_row CHECK (id),
+    CONSTRAINT check_position CHECK (position IN ('bottom-right', 'bottom-left', 'top-right', 'top-left'))
+);
+
+INSERT INTO embed_config DEFAULT VALUES;
@@ -0,0 +1,2 @@
+-- Subject of the OIDC identity linked to a user (POST /auth/oidc/callback)
+ALTER TABLE users ADD COLUMN oidc_subject VARCHAR(255) UNIQUE;
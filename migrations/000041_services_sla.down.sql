@@ -0,0 +1,4 @@
+ALTER TABLE services
+    DROP CONSTRAINT IF EXISTS check_sla_uptime_target,
+    DROP COLUMN IF EXISTS sla_breach_notified_month,
+    DROP COLUMN IF EXISTS sla_uptime_target;
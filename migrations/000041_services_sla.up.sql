@@ -0,0 +1,5 @@
+-- Monthly uptime target in percent (NULL = no SLA) and the month a breach was last notified
+ALTER TABLE services
+    ADD COLUMN sla_uptime_target NUMERIC(6, 3),
+    ADD COLUMN sla_breach_notified_month DATE,
+    ADD CONSTRAINT check_sla_uptime_target CHECK (sla_uptime_target > 0 AND sla_uptime_target <= 100);
@@ -0,0 +1,7 @@
+-- Admin-editable key/value settings, e.g. status page branding (GET/PATCH /admin/settings)
+CREATE TABLE admin_settings (
+    key VARCHAR(100) PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
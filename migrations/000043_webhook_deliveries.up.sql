@@ -0,0 +1,14 @@
+-- Authenticated inbound webhook requests (PagerDuty, Prometheus) for inspection and replay
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source VARCHAR(50) NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    payload JSONB, -- NULL if the body was not valid JSON
+    processing_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error_message TEXT,
+
+    CONSTRAINT check_processing_status CHECK (processing_status IN ('pending', 'processed', 'failed'))
+);
+
+CREATE INDEX idx_webhook_deliveries_received_at ON webhook_deliveries(received_at DESC);
+CREATE INDEX idx_webhook_deliveries_source_status ON webhook_deliveries(source, processing_status);
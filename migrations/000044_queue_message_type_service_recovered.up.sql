@@ -0,0 +1,4 @@
+-- Allow service recovery notifications in the queue.
+ALTER TABLE notification_queue DROP CONSTRAINT check_queue_message_type;
+ALTER TABLE notification_queue ADD CONSTRAINT check_queue_message_type
+    CHECK (message_type IN ('initial', 'update', 'resolved', 'completed', 'cancelled', 'reminder', 'service_recovered'));
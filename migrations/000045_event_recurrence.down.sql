@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_events_parent_occurrence;
+ALTER TABLE events DROP COLUMN IF EXISTS parent_event_id;
+ALTER TABLE events DROP COLUMN IF EXISTS recurrence_end_date;
+ALTER TABLE events DROP COLUMN IF EXISTS recurrence_rule;
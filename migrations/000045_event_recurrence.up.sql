@@ -0,0 +1,9 @@
+-- Recurring maintenance: the parent event carries the rule, occurrences created
+-- by the recurrence scheduler link back to it.
+ALTER TABLE events ADD COLUMN recurrence_rule VARCHAR(255);
+ALTER TABLE events ADD COLUMN recurrence_end_date TIMESTAMP;
+ALTER TABLE events ADD COLUMN parent_event_id UUID REFERENCES events(id) ON DELETE SET NULL;
+
+-- One occurrence per start; a second scheduler instance fails to insert a duplicate.
+CREATE UNIQUE INDEX idx_events_parent_occurrence ON events(parent_event_id, scheduled_start_at)
+    WHERE parent_event_id IS NOT NULL;
@@ -0,0 +1,8 @@
+-- Old slugs of renamed services, so links to them keep working
+CREATE TABLE service_slug_redirects (
+    old_slug VARCHAR(255) PRIMARY KEY,
+    new_slug VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_service_slug_redirects_new_slug ON service_slug_redirects(new_slug);
@@ -0,0 +1 @@
+ALTER TABLE notification_channels DROP CONSTRAINT IF EXISTS uq_notification_channels_user_type_target;
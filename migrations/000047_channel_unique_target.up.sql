@@ -0,0 +1,60 @@
+-- Email targets are case-insensitive: store them lowercased
+UPDATE notification_channels
+SET target = LOWER(target)
+WHERE type = 'email' AND target <> LOWER(target);
+
+-- Duplicates are merged into one kept channel: the one with the newest subscriber token
+-- (public subscribers keep the token they were sent), then the default, verified and oldest.
+-- Service and event subscriptions, subscribe_to_all_services and the lowest min_severity
+-- (NULL = all severities) move to it before the duplicates are deleted.
+CREATE TEMP TABLE channel_duplicates AS
+SELECT nc.id, nc.subscribe_to_all_services, FIRST_VALUE(nc.id) OVER (
+    PARTITION BY nc.user_id, nc.type, nc.target
+    ORDER BY st.created_at DESC NULLS LAST, nc.is_default DESC, nc.is_verified DESC, nc.created_at, nc.id
+) AS kept_id
+FROM notification_channels nc
+LEFT JOIN subscriber_tokens st ON st.channel_id = nc.id;
+
+INSERT INTO channel_subscriptions (channel_id, service_id, created_at)
+SELECT d.kept_id, cs.service_id, MIN(cs.created_at)
+FROM channel_subscriptions cs
+JOIN channel_duplicates d ON d.id = cs.channel_id AND d.id <> d.kept_id
+GROUP BY d.kept_id, cs.service_id
+ON CONFLICT (channel_id, service_id) DO NOTHING;
+
+INSERT INTO event_subscribers (event_id, channel_id, created_at)
+SELECT es.event_id, d.kept_id, MIN(es.created_at)
+FROM event_subscribers es
+JOIN channel_duplicates d ON d.id = es.channel_id AND d.id <> d.kept_id
+GROUP BY es.event_id, d.kept_id
+ON CONFLICT (event_id, channel_id) DO NOTHING;
+
+UPDATE notification_channels nc
+SET subscribe_to_all_services = true
+FROM channel_duplicates d
+WHERE nc.id = d.kept_id AND d.id <> d.kept_id
+    AND d.subscribe_to_all_services AND NOT nc.subscribe_to_all_services;
+
+WITH merged AS (
+    SELECT d.kept_id,
+        CASE WHEN BOOL_OR(nc.min_severity IS NULL) THEN NULL
+        ELSE (ARRAY['minor', 'major', 'critical'])[MIN(ARRAY_POSITION(ARRAY['minor', 'major', 'critical'], nc.min_severity::text))]
+        END AS min_severity
+    FROM channel_duplicates d
+    JOIN notification_channels nc ON nc.id = d.id
+    GROUP BY d.kept_id
+    HAVING COUNT(*) > 1
+)
+UPDATE notification_channels nc
+SET min_severity = m.min_severity
+FROM merged m
+WHERE nc.id = m.kept_id AND nc.min_severity IS DISTINCT FROM m.min_severity;
+
+DELETE FROM notification_channels nc
+USING channel_duplicates d
+WHERE nc.id = d.id AND d.id <> d.kept_id;
+
+DROP TABLE channel_duplicates;
+
+ALTER TABLE notification_channels
+ADD CONSTRAINT uq_notification_channels_user_type_target UNIQUE (user_id, type, target);
@@ -0,0 +1,3 @@
+ALTER TABLE services
+DROP COLUMN IF EXISTS external_url,
+DROP COLUMN IF EXISTS documentation_url;
@@ -0,0 +1,4 @@
+-- Links to external resources of a service (dashboard, runbook); NULL = none
+ALTER TABLE services
+ADD COLUMN external_url VARCHAR(2048),
+ADD COLUMN documentation_url VARCHAR(2048);
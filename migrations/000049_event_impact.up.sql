@@ -0,0 +1,2 @@
+-- Customer-facing impact summary, separate from the technical description
+ALTER TABLE events ADD COLUMN impact VARCHAR(500) NOT NULL DEFAULT '';
@@ -0,0 +1,13 @@
+-- Audit log of admin actions: role changes, event purges, bulk archives, settings changes
+CREATE TABLE admin_audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50) NOT NULL,
+    target_id VARCHAR(255),
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_admin_audit_log_created_at ON admin_audit_log(created_at DESC);
+CREATE INDEX idx_admin_audit_log_action_created_at ON admin_audit_log(action, created_at DESC);
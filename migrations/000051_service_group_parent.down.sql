@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_service_groups_parent_group_id;
+
+ALTER TABLE service_groups DROP COLUMN IF EXISTS parent_group_id;
@@ -0,0 +1,4 @@
+-- One level of group nesting: a sub-group points to its top-level parent
+ALTER TABLE service_groups ADD COLUMN parent_group_id UUID NULL REFERENCES service_groups(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_service_groups_parent_group_id ON service_groups(parent_group_id) WHERE parent_group_id IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Free-text on-call team the event is escalated to, shown in notifications
+ALTER TABLE events ADD COLUMN oncall_team VARCHAR(100) NOT NULL DEFAULT '';
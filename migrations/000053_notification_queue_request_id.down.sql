@@ -0,0 +1 @@
+ALTER TABLE notification_queue DROP COLUMN IF EXISTS request_id;
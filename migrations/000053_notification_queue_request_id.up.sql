@@ -0,0 +1,2 @@
+-- Request that queued the notification, forwarded as X-Request-ID on delivery ('' outside requests)
+ALTER TABLE notification_queue ADD COLUMN request_id VARCHAR(64) NOT NULL DEFAULT '';
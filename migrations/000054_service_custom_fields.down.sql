@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_services_custom_fields;
+ALTER TABLE services DROP COLUMN IF EXISTS custom_fields;
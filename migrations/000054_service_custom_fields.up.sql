@@ -0,0 +1,3 @@
+-- Free-form key/value metadata of a service (team, cost center, ...), filterable via @>
+ALTER TABLE services ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}';
+CREATE INDEX idx_services_custom_fields ON services USING GIN (custom_fields);
@@ -0,0 +1,7 @@
+-- Telegram chats linked to users by the bot, so that commands resolve the user's channel
+CREATE TABLE telegram_users (
+    chat_id BIGINT PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_telegram_users_user_id ON telegram_users(user_id);
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS service_health_checks;
+
+-- Events and updates of the system user are removed by CASCADE
+DELETE FROM service_status_log
+WHERE created_by = (SELECT id FROM users WHERE email = 'health-checks@incident-garden.local');
+
+DELETE FROM users WHERE email = 'health-checks@incident-garden.local';
+
+ALTER TABLE service_status_log
+DROP CONSTRAINT check_source_type;
+
+ALTER TABLE service_status_log
+ADD CONSTRAINT check_source_type CHECK (source_type IN ('manual', 'event', 'webhook', 'dependency'));
+
+ALTER TABLE services
+    DROP COLUMN IF EXISTS health_check_interval_seconds,
+    DROP COLUMN IF EXISTS health_check_url;
@@ -0,0 +1,37 @@
+-- Optional HTTP health check of a service; interval 0 = disabled
+ALTER TABLE services
+    ADD COLUMN health_check_url TEXT,
+    ADD COLUMN health_check_interval_seconds INTEGER NOT NULL DEFAULT 0
+        CONSTRAINT check_health_check_interval CHECK (health_check_interval_seconds >= 0);
+
+-- State of health checks: when a service is due next, its consecutive failures
+-- and the incident opened for them (NULL while healthy)
+CREATE TABLE service_health_checks (
+    service_id UUID PRIMARY KEY REFERENCES services(id) ON DELETE CASCADE,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    last_checked_at TIMESTAMP,
+    next_check_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    event_id UUID REFERENCES events(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_service_health_checks_next_check_at ON service_health_checks(next_check_at);
+
+-- System user that owns incidents opened by health checks.
+-- Inactive and without a valid password hash: cannot log in.
+INSERT INTO users (email, password_hash, first_name, last_name, role, is_active)
+VALUES (
+    'health-checks@incident-garden.local',
+    '!',
+    'Health',
+    'Check',
+    'operator',
+    false
+) ON CONFLICT (email) DO NOTHING;
+
+-- Incidents opened by failed health checks
+ALTER TABLE service_status_log
+DROP CONSTRAINT check_source_type;
+
+ALTER TABLE service_status_log
+ADD CONSTRAINT check_source_type CHECK (source_type IN ('manual', 'event', 'webhook', 'dependency', 'health_check'));
@@ -0,0 +1,16 @@
+-- Responses to requests sent with an Idempotency-Key, replayed for retries until expires_at.
+-- status_code and response_body are NULL while the first request is in progress.
+CREATE TABLE idempotency_keys (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    idempotency_key UUID NOT NULL,
+    request_path TEXT NOT NULL,
+    status_code INTEGER,
+    response_body BYTEA,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (user_id, idempotency_key)
+);
+
+-- Eviction of expired keys
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
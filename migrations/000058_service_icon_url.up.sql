@@ -0,0 +1,3 @@
+-- Logo of a service shown on status pages; NULL = none
+ALTER TABLE services
+ADD COLUMN icon_url VARCHAR(2048);
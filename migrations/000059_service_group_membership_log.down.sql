@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS service_group_membership_log;
+DROP TYPE IF EXISTS membership_action;
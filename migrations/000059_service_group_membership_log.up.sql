@@ -0,0 +1,14 @@
+-- History of services added to and removed from groups
+CREATE TYPE membership_action AS ENUM ('added', 'removed');
+
+CREATE TABLE service_group_membership_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    service_id UUID NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    group_id UUID NOT NULL REFERENCES service_groups(id) ON DELETE CASCADE,
+    action membership_action NOT NULL,
+    actor_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_service_group_membership_log_service_created_at
+    ON service_group_membership_log(service_id, created_at DESC);
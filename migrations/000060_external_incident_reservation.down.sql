@@ -0,0 +1,3 @@
+DELETE FROM external_incidents WHERE event_id IS NULL;
+
+ALTER TABLE external_incidents ALTER COLUMN event_id SET NOT NULL;
@@ -0,0 +1,4 @@
+-- An external incident is reserved before its event is created, so redelivered
+-- or concurrent webhooks for the same incident create a single event.
+-- Reserved incidents have no event yet.
+ALTER TABLE external_incidents ALTER COLUMN event_id DROP NOT NULL;
@@ -0,0 +1,9 @@
+-- Previous webhook signing secrets retired by POST /admin/webhooks/complete-rotation.
+-- Shared by all replicas; a secret is identified by the hex SHA-256 of its value.
+CREATE TABLE webhook_retired_secrets (
+    source VARCHAR(50) NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    retired_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (source, fingerprint)
+);
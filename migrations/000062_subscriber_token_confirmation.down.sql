@@ -0,0 +1,10 @@
+-- Keep one token per channel: the newest confirmed one
+DELETE FROM subscriber_tokens WHERE NOT confirmed;
+DELETE FROM subscriber_tokens st
+USING subscriber_tokens newer
+WHERE newer.channel_id = st.channel_id
+    AND (newer.created_at, newer.token) > (st.created_at, st.token);
+
+DROP INDEX IF EXISTS idx_subscriber_tokens_channel_id;
+ALTER TABLE subscriber_tokens DROP COLUMN IF EXISTS confirmed;
+ALTER TABLE subscriber_tokens ADD CONSTRAINT subscriber_tokens_channel_id_key UNIQUE (channel_id);
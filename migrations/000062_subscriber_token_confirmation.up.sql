@@ -0,0 +1,6 @@
+-- Subscribing an already subscribed email issues another token for its channel.
+-- The token is pending until the emailed code confirms it, then it replaces the others.
+ALTER TABLE subscriber_tokens DROP CONSTRAINT subscriber_tokens_channel_id_key;
+ALTER TABLE subscriber_tokens ADD COLUMN confirmed BOOLEAN NOT NULL DEFAULT true;
+
+CREATE INDEX idx_subscriber_tokens_channel_id ON subscriber_tokens(channel_id);
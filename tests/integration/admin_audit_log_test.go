@@ -0,0 +1,185 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type auditEntry struct {
+	ID          string                 `json:"id"`
+	ActorUserID *string                `json:"actor_user_id"`
+	Action      string                 `json:"action"`
+	TargetType  string                 `json:"target_type"`
+	TargetID    string                 `json:"target_id"`
+	Details     map[string]interface{} `json:"details"`
+	CreatedAt   string                 `json:"created_at"`
+}
+
+func listAuditLog(t *testing.T, client *testutil.Client, query url.Values) []auditEntry {
+	t.Helper()
+	resp, err := client.GET("/api/v1/admin/audit-log?" + query.Encode())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data struct {
+			Entries []auditEntry `json:"entries"`
+			Total   int          `json:"total"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data.Entries
+}
+
+// findAuditEntry returns the newest entry of action matching match, failing the test if there is none.
+func findAuditEntry(t *testing.T, client *testutil.Client, action string, match func(auditEntry) bool) auditEntry {
+	t.Helper()
+	entries := listAuditLog(t, client, url.Values{"action": {action}, "limit": {"100"}})
+	for _, e := range entries {
+		assert.Equal(t, action, e.Action)
+		if match(e) {
+			return e
+		}
+	}
+	require.Failf(t, "audit entry not found", "action %s", action)
+	return auditEntry{}
+}
+
+func TestAdminAuditLog_UserRoleChange(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+	adminID := getAdminID(t, client)
+
+	userID := adminCreateTestUser(t, client, testutil.RandomEmail(), "password123", "user")
+
+	resp, err := client.PATCH("/api/v1/users/"+userID, map[string]interface{}{"role": "operator"})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	entry := findAuditEntry(t, client, "user.update", func(e auditEntry) bool { return e.TargetID == userID })
+	require.NotNil(t, entry.ActorUserID)
+	assert.Equal(t, adminID, *entry.ActorUserID)
+	assert.Equal(t, "user", entry.TargetType)
+	assert.Equal(t, map[string]interface{}{
+		"role": map[string]interface{}{"from": "user", "to": "operator"},
+	}, entry.Details)
+
+	// Time range: from is inclusive, to is exclusive
+	inRange := listAuditLog(t, client, url.Values{"action": {"user.update"}, "from": {entry.CreatedAt}, "limit": {"100"}})
+	assert.Contains(t, auditEntryIDs(inRange), entry.ID)
+	before := listAuditLog(t, client, url.Values{"action": {"user.update"}, "to": {entry.CreatedAt}, "limit": {"100"}})
+	assert.NotContains(t, auditEntryIDs(before), entry.ID)
+
+	// An update without changes is not recorded
+	resp, err = client.PATCH("/api/v1/users/"+userID, map[string]interface{}{"role": "operator"})
+	require.NoError(t, err)
+	resp.Body.Close()
+	latest := findAuditEntry(t, client, "user.update", func(e auditEntry) bool { return e.TargetID == userID })
+	assert.Equal(t, entry.ID, latest.ID)
+}
+
+func TestAdminAuditLog_EventsPurge(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	// A retention no event reaches: the purge deletes nothing but is still an admin action
+	const olderThan = "36500d"
+	countPurges := func() int {
+		n := 0
+		for _, e := range listAuditLog(t, client, url.Values{"action": {"events.purge"}, "limit": {"100"}}) {
+			if e.Details["older_than"] == olderThan {
+				n++
+			}
+		}
+		return n
+	}
+	before := countPurges()
+
+	purgeEvents(t, client, "older_than="+olderThan+"&dry_run=true")
+	assert.Equal(t, before, countPurges(), "dry runs are not recorded")
+
+	purgeEvents(t, client, "older_than="+olderThan)
+	assert.Equal(t, before+1, countPurges())
+
+	entry := findAuditEntry(t, client, "events.purge", func(e auditEntry) bool {
+		return e.Details["older_than"] == olderThan
+	})
+	assert.Equal(t, "events", entry.TargetType)
+	assert.Equal(t, float64(0), entry.Details["deleted_events"])
+	assert.Equal(t, float64(0), entry.Details["deleted_updates"])
+}
+
+func TestAdminAuditLog_ServicesBulkArchive(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Audit Archive")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	result := bulkArchiveServices(t, client, []string{serviceID})
+	require.Equal(t, []string{serviceID}, result.Data.Archived)
+
+	entry := findAuditEntry(t, client, "services.bulk_archive", func(e auditEntry) bool {
+		ids, _ := e.Details["archived_ids"].([]interface{})
+		return len(ids) == 1 && ids[0] == serviceID
+	})
+	assert.Equal(t, "services", entry.TargetType)
+	assert.Equal(t, []interface{}{serviceID}, entry.Details["requested_ids"])
+}
+
+func TestAdminAuditLog_SettingsChange(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+	restoreAdminSettings(t, client)
+
+	original := getAdminSettings(t, client)
+	title := "Audit Status " + randomSuffix()
+	resp := patchAdminSettings(t, client, map[string]interface{}{"status_page_title": title})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	entry := findAuditEntry(t, client, "settings.update", func(e auditEntry) bool {
+		change, _ := e.Details["status_page_title"].(map[string]interface{})
+		return change["to"] == title
+	})
+	assert.Equal(t, "settings", entry.TargetType)
+	assert.Equal(t, map[string]interface{}{
+		"status_page_title": map[string]interface{}{"from": original.StatusPageTitle, "to": title},
+	}, entry.Details)
+}
+
+func TestAdminAuditLog_InvalidParams(t *testing.T) {
+	client := newTestClientWithoutValidation()
+	client.LoginAsAdmin(t)
+
+	for _, query := range []string{"from=yesterday", "to=2026-01-01", "from=2026-02-01T00:00:00Z&to=2026-01-01T00:00:00Z", "limit=0", "offset=-1"} {
+		resp, err := client.GET("/api/v1/admin/audit-log?" + query)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+func TestAdminAuditLog_RequiresAdmin(t *testing.T) {
+	operator := newTestClient(t)
+	operator.LoginAsOperator(t)
+	resp, err := operator.GET("/api/v1/admin/audit-log")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func auditEntryIDs(entries []auditEntry) []string {
+	ids := make([]string, len(entries))
+	for i, e := range entries {
+		ids[i] = e.ID
+	}
+	return ids
+}
@@ -0,0 +1,108 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/app"
+	"github.com/bissquit/incident-garden/internal/config"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAllowlistedServer starts a separate app that restricts admin endpoints by client IP.
+func newAllowlistedServer(t *testing.T, admin config.AdminConfig) *httptest.Server {
+	t.Helper()
+
+	cfg := *testConfig
+	cfg.Admin = admin
+	application, err := app.New(&cfg)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(application.Router())
+	t.Cleanup(func() {
+		server.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = application.Shutdown(ctx)
+	})
+	return server
+}
+
+// getForwarded sends an authenticated GET with the given X-Forwarded-For header.
+func getForwarded(t *testing.T, client *testutil.Client, path, forwardedFor string) int {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, client.BaseURL+path, nil)
+	require.NoError(t, err)
+	if client.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+client.Token)
+	}
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+
+	resp, err := client.HTTPClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestAdminIPAllowlist_PeerAddress(t *testing.T) {
+	t.Run("peer outside allowlist", func(t *testing.T) {
+		server := newAllowlistedServer(t, config.AdminConfig{IPAllowlist: []string{"192.0.2.0/24"}})
+		admin := testutil.NewClient(server.URL)
+		admin.LoginAsAdmin(t)
+
+		resp, err := admin.GET("/api/v1/users")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		var body struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		testutil.DecodeJSON(t, resp, &body)
+		assert.Equal(t, "access denied", body.Error.Message)
+
+		assert.Equal(t, http.StatusForbidden, getForwarded(t, admin, "/api/v1/users", "192.0.2.10"),
+			"X-Forwarded-For is ignored without trusted proxies")
+		assert.Equal(t, http.StatusOK, getForwarded(t, admin, "/api/v1/me", ""),
+			"non-admin endpoints are not restricted")
+	})
+
+	t.Run("peer inside allowlist", func(t *testing.T) {
+		server := newAllowlistedServer(t, config.AdminConfig{IPAllowlist: []string{"127.0.0.1", "::1"}})
+		admin := testutil.NewClient(server.URL)
+		admin.LoginAsAdmin(t)
+
+		assert.Equal(t, http.StatusOK, getForwarded(t, admin, "/api/v1/users", ""))
+	})
+}
+
+func TestAdminIPAllowlist_TrustedProxy(t *testing.T) {
+	server := newAllowlistedServer(t, config.AdminConfig{
+		IPAllowlist:    []string{"192.0.2.0/24", "2001:db8::/32"},
+		TrustedProxies: 1,
+	})
+	admin := testutil.NewClient(server.URL)
+	admin.LoginAsAdmin(t)
+
+	assert.Equal(t, http.StatusOK, getForwarded(t, admin, "/api/v1/users", "192.0.2.10"))
+	assert.Equal(t, http.StatusOK, getForwarded(t, admin, "/api/v1/users", "2001:db8::10"))
+	assert.Equal(t, http.StatusForbidden, getForwarded(t, admin, "/api/v1/users", "198.51.100.1"))
+	assert.Equal(t, http.StatusForbidden, getForwarded(t, admin, "/api/v1/users", "192.0.2.10, 198.51.100.1"),
+		"only the hop appended by the trusted proxy counts")
+	assert.Equal(t, http.StatusForbidden, getForwarded(t, admin, "/api/v1/users", ""),
+		"request that bypassed the proxy")
+
+	operator := testutil.NewClient(server.URL)
+	operator.LoginAsOperator(t)
+	assert.Equal(t, http.StatusForbidden, getForwarded(t, operator, "/api/v1/users", "192.0.2.10"),
+		"role check still applies inside the allowlist")
+}
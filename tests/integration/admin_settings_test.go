@@ -0,0 +1,180 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type statusPageSettings struct {
+	StatusPageTitle string `json:"status_page_title"`
+	LogoURL         string `json:"logo_url"`
+	FaviconURL      string `json:"favicon_url"`
+	CustomCSS       string `json:"custom_css"`
+}
+
+func getAdminSettings(t *testing.T, client *testutil.Client) statusPageSettings {
+	t.Helper()
+	resp, err := client.GET("/api/v1/admin/settings")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data statusPageSettings `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+// patchAdminSettings applies a partial update and returns the response.
+func patchAdminSettings(t *testing.T, client *testutil.Client, patch map[string]interface{}) *http.Response {
+	t.Helper()
+	resp, err := client.PATCH("/api/v1/admin/settings", patch)
+	require.NoError(t, err)
+	return resp
+}
+
+// restoreAdminSettings puts back the settings found before the test.
+func restoreAdminSettings(t *testing.T, client *testutil.Client) {
+	t.Helper()
+	resp, err := client.GET("/api/v1/admin/settings")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var original struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &original)
+
+	t.Cleanup(func() {
+		resp := patchAdminSettings(t, client, original.Data)
+		resp.Body.Close()
+	})
+}
+
+func TestAdminSettings_CRUD(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+	restoreAdminSettings(t, client)
+
+	resp := patchAdminSettings(t, client, map[string]interface{}{
+		"status_page_title": "Acme Status",
+		"logo_url":          "https://cdn.example.com/logo.png",
+		"favicon_url":       "https://cdn.example.com/favicon.ico",
+		"custom_css":        "header { background: #0b3d91; }",
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var updated struct {
+		Data statusPageSettings `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &updated)
+
+	want := statusPageSettings{
+		StatusPageTitle: "Acme Status",
+		LogoURL:         "https://cdn.example.com/logo.png",
+		FaviconURL:      "https://cdn.example.com/favicon.ico",
+		CustomCSS:       "header { background: #0b3d91; }",
+	}
+	assert.Equal(t, want, updated.Data)
+	assert.Equal(t, want, getAdminSettings(t, client))
+
+	t.Run("partial update", func(t *testing.T) {
+		resp := patchAdminSettings(t, client, map[string]interface{}{
+			"status_page_title": "Acme Platform Status",
+			"logo_url":          nil,
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+
+		got := getAdminSettings(t, client)
+		assert.Equal(t, "Acme Platform Status", got.StatusPageTitle)
+		assert.Equal(t, want.LogoURL, got.LogoURL, "null leaves the setting unchanged")
+		assert.Equal(t, want.CustomCSS, got.CustomCSS)
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		resp := patchAdminSettings(t, client, map[string]interface{}{"favicon_url": ""})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+		assert.Empty(t, getAdminSettings(t, client).FaviconURL)
+	})
+
+	t.Run("custom css sanitized", func(t *testing.T) {
+		resp := patchAdminSettings(t, client, map[string]interface{}{
+			"custom_css": "body { color: #333; }<script>alert(document.cookie)</script><SCRIPT src=x.js></SCRIPT>",
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+
+		css := getAdminSettings(t, client).CustomCSS
+		assert.Equal(t, "body { color: #333; }", css)
+		assert.NotContains(t, strings.ToLower(css), "<script")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		before := getAdminSettings(t, client)
+		raw := client.WithoutValidation()
+		for name, patch := range map[string]map[string]interface{}{
+			"logo url scheme":   {"logo_url": "javascript:alert(1)"},
+			"relative favicon":  {"favicon_url": "/favicon.ico"},
+			"title too long":    {"status_page_title": strings.Repeat("a", 201)},
+			"valid with broken": {"status_page_title": "Never stored", "logo_url": "ftp://cdn.example.com/logo.png"},
+		} {
+			resp := patchAdminSettings(t, raw, patch)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, name)
+		}
+		assert.Equal(t, before, getAdminSettings(t, client), "invalid settings are not stored")
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		operator := newTestClient(t)
+		operator.LoginAsOperator(t)
+		resp, err := operator.GET("/api/v1/admin/settings")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		resp = patchAdminSettings(t, operator, map[string]interface{}{"status_page_title": "Operator"})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		resp, err = newTestClient(t).GET("/api/v1/admin/settings")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestAdminSettings_PublicStatus(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+	restoreAdminSettings(t, client)
+
+	resp := patchAdminSettings(t, client, map[string]interface{}{
+		"status_page_title": "Branded Status Page",
+		"logo_url":          "https://cdn.example.com/brand.png",
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp, err := newTestClient(t).GET("/api/v1/status")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var status struct {
+		Data struct {
+			Events   []interface{}       `json:"events"`
+			Settings *statusPageSettings `json:"settings"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &status)
+	require.NotNil(t, status.Data.Settings)
+	assert.Equal(t, "Branded Status Page", status.Data.Settings.StatusPageTitle)
+	assert.Equal(t, "https://cdn.example.com/brand.png", status.Data.Settings.LogoURL)
+}
@@ -0,0 +1,123 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getWithAccept requests path with an optional Accept header, bypassing OpenAPI validation.
+func getWithAccept(t *testing.T, path, accept string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, testServer.URL+path, nil)
+	require.NoError(t, err)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestAPIVersion_Prefixes(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "api-version")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	for _, prefix := range []string{"/api/v1", "/api/v2"} {
+		t.Run(prefix, func(t *testing.T) {
+			resp := getWithAccept(t, prefix+"/services", "")
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			resp = getWithAccept(t, prefix+"/services/"+slug, "")
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			var result struct {
+				Data struct {
+					Slug string `json:"slug"`
+				} `json:"data"`
+			}
+			testutil.DecodeJSON(t, resp, &result)
+			assert.Equal(t, slug, result.Data.Slug)
+		})
+	}
+}
+
+func TestAPIVersion_AcceptHeader(t *testing.T) {
+	resp := getWithAccept(t, "/api/v1/services", httputil.APIVersion2MediaType)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = getWithAccept(t, "/api/v1/services", "application/json, "+httputil.APIVersion2MediaType+";q=0.9")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestAPIVersion_SlugRedirectKeepsPrefix(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, oldSlug := createTestService(t, client, "api-version-old")
+	newSlug := testutil.RandomSlug("api-version-new")
+	t.Cleanup(func() { deleteService(t, client, newSlug) })
+	renameService(t, client, oldSlug, newSlug)
+
+	httpClient := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := httpClient.Get(testServer.URL + "/api/v2/services/" + oldSlug)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+	assert.Equal(t, "/api/v2/services/"+newSlug, resp.Header.Get("Location"))
+}
+
+func TestAPIVersion_Streams(t *testing.T) {
+	contentTypes := map[string]string{
+		"/status/stream": "text/event-stream",
+		"/watch/events":  "application/x-ndjson",
+	}
+	for _, prefix := range []string{"/api/v1", "/api/v2"} {
+		for path, contentType := range contentTypes {
+			t.Run(prefix+path, func(t *testing.T) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, testServer.URL+prefix+path, nil)
+				require.NoError(t, err)
+
+				resp, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+				defer resp.Body.Close()
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, contentType, resp.Header.Get("Content-Type"))
+			})
+		}
+	}
+}
+
+func TestAPIVersion_RefreshCookieSharedByVersions(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	resp, err := client.WithoutValidation().POST("/api/v2/auth/refresh", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	var refreshPaths []string
+	for _, c := range resp.Cookies() {
+		if c.Name == httputil.RefreshTokenCookie && c.MaxAge > 0 {
+			refreshPaths = append(refreshPaths, c.Path)
+		}
+	}
+	assert.Equal(t, []string{"/api"}, refreshPaths)
+}
@@ -0,0 +1,271 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/app"
+	"github.com/bissquit/incident-garden/internal/config"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const oidcRedirectURL = "https://status.example.com/auth/oidc/callback"
+
+// newOIDCApp starts a mock OIDC provider and a separate app using it, so the shared app keeps OIDC disabled.
+func newOIDCApp(t *testing.T) (*httptest.Server, *testutil.OIDCServer) {
+	t.Helper()
+
+	idp := testutil.NewOIDCServer("incident-garden", "oidc-secret")
+	t.Cleanup(idp.Close)
+
+	cfg := *testConfig
+	cfg.OIDC = config.OIDCConfig{
+		Issuer:       idp.Issuer(),
+		ClientID:     idp.ClientID,
+		ClientSecret: idp.ClientSecret,
+		RedirectURL:  oidcRedirectURL,
+	}
+	application, err := app.New(&cfg)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(application.Router())
+	t.Cleanup(func() {
+		server.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = application.Shutdown(ctx)
+	})
+	return server, idp
+}
+
+// newOIDCClient returns a validating client that doesn't follow the redirect to the provider.
+func newOIDCClient(t *testing.T, baseURL string) *testutil.Client {
+	t.Helper()
+	client := testutil.NewClientWithValidator(baseURL, testValidator)
+	client.SetT(t)
+	client.HTTPClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return client
+}
+
+// startOIDCLogin calls /auth/oidc/login and returns the state and nonce sent to the provider.
+func startOIDCLogin(t *testing.T, client *testutil.Client) (state, nonce string) {
+	t.Helper()
+	resp, err := client.GET("/api/v1/auth/oidc/login")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	return location.Query().Get("state"), location.Query().Get("nonce")
+}
+
+type oidcLoginResult struct {
+	Data struct {
+		User struct {
+			ID    string `json:"id"`
+			Email string `json:"email"`
+			Name  string `json:"name"`
+			Role  string `json:"role"`
+		} `json:"user"`
+	} `json:"data"`
+}
+
+// oidcLogin runs the whole flow for an identity and returns the callback response.
+func oidcLogin(t *testing.T, client *testutil.Client, idp *testutil.OIDCServer, claims map[string]interface{}) *http.Response {
+	t.Helper()
+	state, nonce := startOIDCLogin(t, client)
+	claims["nonce"] = nonce
+
+	resp, err := client.POST("/api/v1/auth/oidc/callback", map[string]string{
+		"code":  idp.IssueCode(claims),
+		"state": state,
+	})
+	require.NoError(t, err)
+	return resp
+}
+
+func TestOIDC_LoginRedirect(t *testing.T) {
+	server, idp := newOIDCApp(t)
+	client := newOIDCClient(t, server.URL)
+
+	resp, err := client.GET("/api/v1/auth/oidc/login")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, idp.URL+"/authorize", location.Scheme+"://"+location.Host+location.Path)
+	assert.Equal(t, "incident-garden", location.Query().Get("client_id"))
+	assert.Equal(t, oidcRedirectURL, location.Query().Get("redirect_uri"))
+	assert.NotEmpty(t, location.Query().Get("state"))
+	assert.NotEmpty(t, location.Query().Get("nonce"))
+
+	var stateCookie *http.Cookie
+	for _, c := range resp.Cookies() {
+		if c.Name == "oidc_state" {
+			stateCookie = c
+		}
+	}
+	require.NotNil(t, stateCookie)
+	assert.True(t, stateCookie.HttpOnly)
+}
+
+func TestOIDC_Login_CreatesUser(t *testing.T) {
+	server, idp := newOIDCApp(t)
+	client := newOIDCClient(t, server.URL)
+	email := testutil.RandomEmail()
+
+	resp := oidcLogin(t, client, idp, map[string]interface{}{
+		"sub":            "oidc-new-" + email,
+		"email":          email,
+		"email_verified": true,
+		"given_name":     "Grace",
+		"family_name":    "Hopper",
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result oidcLoginResult
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, email, result.Data.User.Email)
+	assert.Equal(t, "Grace Hopper", result.Data.User.Name)
+	assert.Equal(t, "user", result.Data.User.Role)
+
+	meResp, err := client.GET("/api/v1/me")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, meResp.StatusCode, "auth cookies are set")
+	var me struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, meResp, &me)
+	assert.Equal(t, result.Data.User.ID, me.Data.ID)
+
+	again := newOIDCClient(t, server.URL)
+	resp = oidcLogin(t, again, idp, map[string]interface{}{"sub": "oidc-new-" + email})
+	require.Equal(t, http.StatusOK, resp.StatusCode, "linked subject logs in without an email claim")
+	var second oidcLoginResult
+	testutil.DecodeJSON(t, resp, &second)
+	assert.Equal(t, result.Data.User.ID, second.Data.User.ID)
+}
+
+func TestOIDC_Login_LinksExistingUser(t *testing.T) {
+	server, idp := newOIDCApp(t)
+
+	local := testutil.NewClient(server.URL)
+	email := testutil.RandomEmail()
+	resp, err := local.POST("/api/v1/auth/register", map[string]string{
+		"email":    email,
+		"password": "password123",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var registered struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &registered)
+
+	client := newOIDCClient(t, server.URL)
+	resp = oidcLogin(t, client, idp, map[string]interface{}{
+		"sub":            "oidc-link-" + email,
+		"email":          email,
+		"email_verified": true,
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result oidcLoginResult
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, registered.Data.ID, result.Data.User.ID, "linked to the existing user")
+
+	// Password login keeps working for a linked account.
+	local.LoginAs(t, email, "password123")
+}
+
+func TestOIDC_Callback_Rejects(t *testing.T) {
+	server, idp := newOIDCApp(t)
+
+	t.Run("state mismatch", func(t *testing.T) {
+		client := newOIDCClient(t, server.URL)
+		_, nonce := startOIDCLogin(t, client)
+		resp, err := client.POST("/api/v1/auth/oidc/callback", map[string]string{
+			"code":  idp.IssueCode(map[string]interface{}{"sub": "x", "nonce": nonce}),
+			"state": "forged",
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("without login cookie", func(t *testing.T) {
+		client := newOIDCClient(t, server.URL)
+		resp, err := client.POST("/api/v1/auth/oidc/callback", map[string]string{
+			"code":  idp.IssueCode(map[string]interface{}{"sub": "x"}),
+			"state": "any",
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("state is single-use", func(t *testing.T) {
+		client := newOIDCClient(t, server.URL)
+		state, _ := startOIDCLogin(t, client)
+		for i := 0; i < 2; i++ {
+			resp, err := client.POST("/api/v1/auth/oidc/callback", map[string]string{"code": "unknown", "state": state})
+			require.NoError(t, err)
+			resp.Body.Close()
+			if i == 0 {
+				assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "unknown code")
+			} else {
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "state already used")
+			}
+		}
+	})
+
+	t.Run("unverified email", func(t *testing.T) {
+		client := newOIDCClient(t, server.URL)
+		resp := oidcLogin(t, client, idp, map[string]interface{}{
+			"sub":   "oidc-unverified",
+			"email": testutil.RandomEmail(),
+		})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("token for another client", func(t *testing.T) {
+		client := newOIDCClient(t, server.URL)
+		resp := oidcLogin(t, client, idp, map[string]interface{}{
+			"sub":            "oidc-other-aud",
+			"aud":            "another-app",
+			"email":          testutil.RandomEmail(),
+			"email_verified": true,
+		})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestOIDC_DisabledByDefault(t *testing.T) {
+	client := newTestClientWithoutValidation()
+	client.HTTPClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	resp, err := client.GET("/api/v1/auth/oidc/login")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
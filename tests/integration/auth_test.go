@@ -3,9 +3,11 @@
 package integration
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
+	"github.com/bissquit/incident-garden/internal/identity"
 	"github.com/bissquit/incident-garden/internal/pkg/httputil"
 	"github.com/bissquit/incident-garden/internal/testutil"
 	"github.com/stretchr/testify/assert"
@@ -248,6 +250,94 @@ func TestAuth_Refresh_UpdatesCookies(t *testing.T) {
 	resp.Body.Close()
 }
 
+// loginForRefreshToken logs in and returns the refresh token set as a cookie.
+func loginForRefreshToken(t *testing.T, email, password string) string {
+	t.Helper()
+	resp, err := newTestClient(t).POST("/api/v1/auth/login", map[string]string{
+		"email":    email,
+		"password": password,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	for _, c := range resp.Cookies() {
+		if c.Name == httputil.RefreshTokenCookie {
+			return c.Value
+		}
+	}
+	t.Fatal("refresh_token cookie not set")
+	return ""
+}
+
+// refreshWithBody calls POST /auth/refresh as an API client, without cookies.
+func refreshWithBody(t *testing.T, refreshToken string) (int, *identity.TokenPair) {
+	t.Helper()
+	resp, err := newTestClient(t).POST("/api/v1/auth/refresh", map[string]string{
+		"refresh_token": refreshToken,
+	})
+	require.NoError(t, err)
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	var result struct {
+		Data identity.TokenPair `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return resp.StatusCode, &result.Data
+}
+
+func TestAuth_Refresh_WithBody(t *testing.T) {
+	refreshToken := loginForRefreshToken(t, "user@example.com", "user123")
+
+	status, tokens := refreshWithBody(t, refreshToken)
+	require.Equal(t, http.StatusOK, status)
+	assert.NotEmpty(t, tokens.AccessToken)
+	assert.NotEqual(t, refreshToken, tokens.RefreshToken, "refresh token is rotated")
+	assert.Equal(t, int64(15*60), tokens.ExpiresIn)
+
+	apiClient := newTestClient(t)
+	apiClient.Token = tokens.AccessToken
+	resp, err := apiClient.GET("/api/v1/me")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	status, _ = refreshWithBody(t, refreshToken)
+	assert.Equal(t, http.StatusUnauthorized, status, "used refresh token is rejected")
+
+	status, _ = refreshWithBody(t, tokens.RefreshToken)
+	assert.Equal(t, http.StatusOK, status, "rotated refresh token works")
+}
+
+func TestAuth_Refresh_ExpiredToken(t *testing.T) {
+	token := "expired-" + testutil.RandomEmail()
+	_, err := testDB.Exec(context.Background(), `
+		INSERT INTO refresh_tokens (user_id, token, expires_at)
+		VALUES ((SELECT id FROM users WHERE email = 'user@example.com'), $1, NOW() - INTERVAL '1 minute')
+	`, token)
+	require.NoError(t, err)
+
+	status, _ := refreshWithBody(t, token)
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
+
+func TestAuth_Refresh_RevokedByLogout(t *testing.T) {
+	refreshToken := loginForRefreshToken(t, "user@example.com", "user123")
+
+	resp, err := newTestClient(t).POST("/api/v1/auth/logout", map[string]string{
+		"refresh_token": refreshToken,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	status, _ := refreshWithBody(t, refreshToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
+
 func TestAuth_Logout_ClearsCookies(t *testing.T) {
 	client := newTestClient(t)
 	client.LoginAsAdmin(t)
@@ -132,6 +132,114 @@ func TestCatalog_Service_RestoreNotArchived(t *testing.T) {
 	resp, err := client.POST("/api/v1/services/"+slug+"/restore", nil)
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	var errorResult struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	testutil.DecodeJSON(t, resp, &errorResult)
+	assert.Equal(t, "service is not archived", errorResult.Error.Message)
+}
+
+func TestCatalog_Service_Restore_ByOperator_ResetsStatus(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "Restore Resets Status", withStatus("degraded"))
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		deleteService(t, client, slug)
+	})
+
+	resp, err := client.DELETE("/api/v1/services/" + slug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	// Operator is enough to restore
+	client.LoginAsOperator(t)
+	resp, err = client.POST("/api/v1/services/"+slug+"/restore", nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var restoreResult struct {
+		Data struct {
+			Status          string  `json:"status"`
+			EffectiveStatus string  `json:"effective_status"`
+			ArchivedAt      *string `json:"archived_at"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &restoreResult)
+	assert.Nil(t, restoreResult.Data.ArchivedAt)
+	assert.Equal(t, "operational", restoreResult.Data.Status)
+	assert.Equal(t, "operational", restoreResult.Data.EffectiveStatus)
+
+	// Reappears in default list
+	resp, err = client.GET("/api/v1/services")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var listResult struct {
+		Data []struct {
+			Slug string `json:"slug"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &listResult)
+
+	found := false
+	for _, svc := range listResult.Data {
+		if svc.Slug == slug {
+			found = true
+			break
+		}
+	}
+	assert.True(t, found, "restored service should appear in default list")
+
+	// Status reset is recorded as a manual change
+	resp, err = client.GET("/api/v1/services/" + slug + "/status-log")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var logResult struct {
+		Data struct {
+			Entries []struct {
+				OldStatus  *string `json:"old_status"`
+				NewStatus  string  `json:"new_status"`
+				SourceType string  `json:"source_type"`
+				Reason     string  `json:"reason"`
+			} `json:"entries"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &logResult)
+
+	var restoreEntryFound bool
+	for _, entry := range logResult.Data.Entries {
+		if entry.SourceType == "manual" && entry.Reason == "Service restored from archive" {
+			restoreEntryFound = true
+			require.NotNil(t, entry.OldStatus)
+			assert.Equal(t, "degraded", *entry.OldStatus)
+			assert.Equal(t, "operational", entry.NewStatus)
+		}
+	}
+	assert.True(t, restoreEntryFound, "restore should be recorded in status log")
+}
+
+func TestCatalog_Service_Restore_UserForbidden(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "Restore Forbidden Service")
+
+	resp, err := client.DELETE("/api/v1/services/" + slug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	client.LoginAsUser(t)
+	resp, err = client.POST("/api/v1/services/"+slug+"/restore", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
 	resp.Body.Close()
 }
 
@@ -219,7 +327,77 @@ func TestCatalog_Group_RestoreNotArchived(t *testing.T) {
 	resp, err := client.POST("/api/v1/groups/"+slug+"/restore", nil)
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	var errorResult struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	testutil.DecodeJSON(t, resp, &errorResult)
+	assert.Equal(t, "group is not archived", errorResult.Error.Message)
+}
+
+func TestCatalog_Group_Restore_ByOperator(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestGroup(t, client, "Restore Group By Operator")
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		deleteGroup(t, client, slug)
+	})
+
+	resp, err := client.DELETE("/api/v1/groups/" + slug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
 	resp.Body.Close()
+
+	client.LoginAsOperator(t)
+	resp, err = client.POST("/api/v1/groups/"+slug+"/restore", nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var restoreResult struct {
+		Data struct {
+			Slug       string  `json:"slug"`
+			ArchivedAt *string `json:"archived_at"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &restoreResult)
+	assert.Equal(t, slug, restoreResult.Data.Slug)
+	assert.Nil(t, restoreResult.Data.ArchivedAt)
+}
+
+func TestCatalog_Group_Restore_UserForbidden(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestGroup(t, client, "Restore Group Forbidden")
+
+	resp, err := client.DELETE("/api/v1/groups/" + slug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	client.LoginAsUser(t)
+	resp, err = client.POST("/api/v1/groups/"+slug+"/restore", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	// Group stays archived
+	client.LoginAsAdmin(t)
+	resp, err = client.GET("/api/v1/groups/" + slug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			ArchivedAt *string `json:"archived_at"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.NotNil(t, result.Data.ArchivedAt)
 }
 
 func TestCatalog_Group_ArchiveWithServices_Blocked(t *testing.T) {
@@ -0,0 +1,153 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bulkArchiveResult struct {
+	Data struct {
+		Archived []string `json:"archived"`
+		Failed   []struct {
+			ID     string `json:"id"`
+			Reason string `json:"reason"`
+		} `json:"failed"`
+	} `json:"data"`
+}
+
+func bulkArchiveServices(t *testing.T, client *testutil.Client, ids []string) bulkArchiveResult {
+	t.Helper()
+	resp, err := client.POST("/api/v1/services/archive", map[string]interface{}{
+		"ids": ids,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result bulkArchiveResult
+	testutil.DecodeJSON(t, resp, &result)
+	return result
+}
+
+func assertServiceArchived(t *testing.T, client *testutil.Client, slug string, want bool) {
+	t.Helper()
+	resp, err := client.GET("/api/v1/services/" + slug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			ArchivedAt *string `json:"archived_at"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	if want {
+		assert.NotNil(t, result.Data.ArchivedAt, "service %s should be archived", slug)
+	} else {
+		assert.Nil(t, result.Data.ArchivedAt, "service %s should not be archived", slug)
+	}
+}
+
+func TestCatalog_BulkArchiveServices_Success(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	id1, slug1 := createTestService(t, client, "Bulk Archive One")
+	id2, slug2 := createTestService(t, client, "Bulk Archive Two")
+
+	result := bulkArchiveServices(t, client, []string{id1, id2})
+
+	assert.ElementsMatch(t, []string{id1, id2}, result.Data.Archived)
+	assert.Empty(t, result.Data.Failed)
+
+	assertServiceArchived(t, client, slug1, true)
+	assertServiceArchived(t, client, slug2, true)
+}
+
+func TestCatalog_BulkArchiveServices_AlreadyArchived(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	id, slug := createTestService(t, client, "Bulk Archive Already Archived")
+
+	resp, err := client.DELETE("/api/v1/services/" + slug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	result := bulkArchiveServices(t, client, []string{id})
+
+	assert.Equal(t, []string{id}, result.Data.Archived)
+	assert.Empty(t, result.Data.Failed)
+}
+
+func TestCatalog_BulkArchiveServices_PartialFailure(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	activeID, activeSlug := createTestService(t, client, "Bulk Archive Active Event")
+	t.Cleanup(func() { deleteService(t, client, activeSlug) })
+	okID, okSlug := createTestService(t, client, "Bulk Archive Idle")
+
+	eventID := createTestIncident(t, client, "Bulk Archive Incident", []AffectedService{
+		{ServiceID: activeID, Status: "degraded"},
+	}, nil)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	missingID := "00000000-0000-0000-0000-000000000000"
+
+	result := bulkArchiveServices(t, client, []string{activeID, okID, missingID})
+
+	assert.Equal(t, []string{okID}, result.Data.Archived)
+	require.Len(t, result.Data.Failed, 2)
+
+	reasons := make(map[string]string)
+	for _, f := range result.Data.Failed {
+		reasons[f.ID] = f.Reason
+	}
+	assert.Equal(t, "has active events", reasons[activeID])
+	assert.Equal(t, "not found", reasons[missingID])
+
+	assertServiceArchived(t, client, okSlug, true)
+	assertServiceArchived(t, client, activeSlug, false)
+}
+
+func TestCatalog_BulkArchiveServices_Validation(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	tests := []struct {
+		name string
+		body interface{}
+	}{
+		{"missing ids", map[string]interface{}{}},
+		{"empty ids", map[string]interface{}{"ids": []string{}}},
+		{"invalid uuid", map[string]interface{}{"ids": []string{"not-a-uuid"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.POST("/api/v1/services/archive", tt.body)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestCatalog_BulkArchiveServices_Forbidden(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	resp, err := client.POST("/api/v1/services/archive", map[string]interface{}{
+		"ids": []string{"00000000-0000-0000-0000-000000000000"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+}
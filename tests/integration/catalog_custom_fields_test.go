@@ -0,0 +1,159 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	catalogpostgres "github.com/bissquit/incident-garden/internal/catalog/postgres"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withCustomFields(fields map[string]string) serviceOption {
+	return func(m map[string]interface{}) {
+		m["custom_fields"] = fields
+	}
+}
+
+func getServiceCustomFields(t *testing.T, client *testutil.Client, slug string) map[string]string {
+	t.Helper()
+	resp, err := client.GET("/api/v1/services/" + slug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data struct {
+			CustomFields map[string]string `json:"custom_fields"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data.CustomFields
+}
+
+func listServiceIDs(t *testing.T, client *testutil.Client, query string) []string {
+	t.Helper()
+	resp, err := client.GET("/api/v1/services?" + query)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	ids := make([]string, len(result.Data))
+	for i, s := range result.Data {
+		ids[i] = s.ID
+	}
+	return ids
+}
+
+func TestCatalog_Service_CustomFields(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	fields := map[string]string{"team": "platform", "cost_center": "cc-42"}
+	_, slug := createTestService(t, client, "custom-fields", withCustomFields(fields))
+	t.Cleanup(func() { deleteService(t, client, slug) })
+	assert.Equal(t, fields, getServiceCustomFields(t, client, slug))
+
+	_, plainSlug := createTestService(t, client, "custom-fields-plain")
+	t.Cleanup(func() { deleteService(t, client, plainSlug) })
+	assert.Equal(t, map[string]string{}, getServiceCustomFields(t, client, plainSlug))
+
+	// Omitted custom_fields keep their values
+	resp, err := client.PATCH("/api/v1/services/"+slug, map[string]interface{}{
+		"name":   "custom-fields",
+		"slug":   slug,
+		"status": "operational",
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, fields, getServiceCustomFields(t, client, slug))
+
+	// custom_fields are replaced as a whole, {} clears them
+	for _, replacement := range []map[string]string{{"team": "payments"}, {}} {
+		resp, err = client.PATCH("/api/v1/services/"+slug, map[string]interface{}{
+			"name":          "custom-fields",
+			"slug":          slug,
+			"status":        "operational",
+			"custom_fields": replacement,
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, replacement, getServiceCustomFields(t, client, slug))
+	}
+}
+
+func TestCatalog_Service_CustomFieldFilter(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	team := "team_" + randomSuffix()
+	platformID, platformSlug := createTestService(t, client, "cf-platform", withCustomFields(map[string]string{"team": team, "tier": "1"}))
+	t.Cleanup(func() { deleteService(t, client, platformSlug) })
+	otherTierID, otherTierSlug := createTestService(t, client, "cf-other-tier", withCustomFields(map[string]string{"team": team, "tier": "2"}))
+	t.Cleanup(func() { deleteService(t, client, otherTierSlug) })
+	_, otherTeamSlug := createTestService(t, client, "cf-other-team", withCustomFields(map[string]string{"team": team + "_other"}))
+	t.Cleanup(func() { deleteService(t, client, otherTeamSlug) })
+
+	assert.ElementsMatch(t, []string{platformID, otherTierID}, listServiceIDs(t, client, "custom_field[team]="+team))
+	assert.Equal(t, []string{platformID}, listServiceIDs(t, client, "custom_field[team]="+team+"&custom_field[tier]=1"))
+	assert.Empty(t, listServiceIDs(t, client, "custom_field[team]="+team+"&custom_field[tier]=3"))
+
+	repo := catalogpostgres.NewRepository(testDB)
+	services, err := repo.GetServicesByCustomField(context.Background(), "team", team)
+	require.NoError(t, err)
+	ids := make([]string, len(services))
+	for i, s := range services {
+		ids[i] = s.ID
+		assert.Equal(t, team, s.CustomFields["team"])
+	}
+	assert.ElementsMatch(t, []string{platformID, otherTierID}, ids)
+}
+
+func TestCatalog_Service_CustomFields_Invalid(t *testing.T) {
+	client := newTestClientWithoutValidation()
+	client.LoginAsAdmin(t)
+
+	for name, fields := range map[string]map[string]string{
+		"uppercase key":  {"Team": "platform"},
+		"leading digit":  {"1team": "platform"},
+		"hyphen in key":  {"cost-center": "cc-42"},
+		"key too long":   {"a" + strings.Repeat("b", 50): "x"},
+		"value too long": {"team": strings.Repeat("x", 501)},
+	} {
+		resp, err := client.POST("/api/v1/services", map[string]interface{}{
+			"name":          "Invalid custom field",
+			"slug":          testutil.RandomSlug("invalid-cf"),
+			"custom_fields": fields,
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, name)
+	}
+
+	_, slug := createTestService(t, client, "invalid-cf-update")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	resp, err := client.PATCH("/api/v1/services/"+slug, map[string]interface{}{
+		"name":          "Invalid custom field",
+		"slug":          slug,
+		"status":        "operational",
+		"custom_fields": map[string]string{"_team": "platform"},
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = client.GET("/api/v1/services?custom_field[]=platform")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
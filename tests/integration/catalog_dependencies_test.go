@@ -0,0 +1,204 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type serviceDependency struct {
+	ServiceID string `json:"service_id"`
+	Slug      string `json:"slug"`
+	Type      string `json:"type"`
+}
+
+// putDependencies replaces the dependencies of a service and returns the status code.
+func putDependencies(t *testing.T, client *testutil.Client, slug string, deps []map[string]string) int {
+	t.Helper()
+	resp, err := client.PUT("/api/v1/services/"+slug+"/dependencies", deps)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func listDependencies(t *testing.T, client *testutil.Client, path string) []serviceDependency {
+	t.Helper()
+	resp, err := client.GET(path)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data []serviceDependency `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+type dependencyIncident struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Severity string `json:"severity"`
+}
+
+// activeIncidents returns the active events of a service and schedules their deletion.
+func activeIncidents(t *testing.T, client *testutil.Client, slug string) []dependencyIncident {
+	t.Helper()
+	resp, err := client.GET("/api/v1/services/" + slug + "/events?status=active")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			Events []dependencyIncident `json:"events"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	for _, event := range result.Data.Events {
+		eventID := event.ID
+		t.Cleanup(func() { deleteEvent(t, client, eventID) })
+	}
+	return result.Data.Events
+}
+
+func TestServiceDependencies(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	apiID, apiSlug := createTestService(t, client, "Deps API")
+	t.Cleanup(func() { deleteService(t, client, apiSlug) })
+	dbID, dbSlug := createTestService(t, client, "Deps Database")
+	t.Cleanup(func() { deleteService(t, client, dbSlug) })
+	cacheID, cacheSlug := createTestService(t, client, "Deps Cache")
+	t.Cleanup(func() { deleteService(t, client, cacheSlug) })
+
+	require.Equal(t, http.StatusOK, putDependencies(t, client, apiSlug, []map[string]string{
+		{"service_id": dbID, "type": "hard"},
+		{"service_id": cacheID, "type": "soft"},
+	}))
+
+	public := newTestClient(t)
+
+	t.Run("upstream and downstream", func(t *testing.T) {
+		deps := listDependencies(t, public, "/api/v1/services/"+apiSlug+"/dependencies")
+		require.Len(t, deps, 2)
+		assert.Equal(t, serviceDependency{ServiceID: cacheID, Slug: cacheSlug, Type: "soft"}, deps[0])
+		assert.Equal(t, serviceDependency{ServiceID: dbID, Slug: dbSlug, Type: "hard"}, deps[1])
+
+		dependents := listDependencies(t, public, "/api/v1/services/"+dbSlug+"/dependents")
+		require.Len(t, dependents, 1)
+		assert.Equal(t, serviceDependency{ServiceID: apiID, Slug: apiSlug, Type: "hard"}, dependents[0])
+
+		assert.Empty(t, listDependencies(t, public, "/api/v1/services/"+apiSlug+"/dependents"))
+	})
+
+	t.Run("invalid dependencies", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, putDependencies(t, client, apiSlug, []map[string]string{
+			{"service_id": apiID, "type": "hard"},
+		}), "self dependency")
+		assert.Equal(t, http.StatusBadRequest, putDependencies(t, client, apiSlug, []map[string]string{
+			{"service_id": dbID, "type": "optional"},
+		}), "unknown type")
+		assert.Equal(t, http.StatusBadRequest, putDependencies(t, client, apiSlug, []map[string]string{
+			{"service_id": "00000000-0000-0000-0000-000000000000", "type": "hard"},
+		}), "unknown service")
+		assert.Equal(t, http.StatusConflict, putDependencies(t, client, dbSlug, []map[string]string{
+			{"service_id": apiID, "type": "soft"},
+		}), "cycle")
+
+		assert.Len(t, listDependencies(t, public, "/api/v1/services/"+apiSlug+"/dependencies"), 2, "rejected requests change nothing")
+	})
+
+	t.Run("requires admin", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, putDependencies(t, public, apiSlug, []map[string]string{}))
+	})
+
+	t.Run("empty list removes all", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, putDependencies(t, client, apiSlug, []map[string]string{}))
+		assert.Empty(t, listDependencies(t, public, "/api/v1/services/"+apiSlug+"/dependencies"))
+		assert.Empty(t, listDependencies(t, public, "/api/v1/services/"+dbSlug+"/dependents"))
+	})
+}
+
+func TestServiceDependencies_MajorOutageCascade(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	dbID, dbSlug := createTestService(t, client, "Cascade Database")
+	t.Cleanup(func() { deleteService(t, client, dbSlug) })
+	_, apiSlug := createTestService(t, client, "Cascade API")
+	t.Cleanup(func() { deleteService(t, client, apiSlug) })
+	_, reportsSlug := createTestService(t, client, "Cascade Reports")
+	t.Cleanup(func() { deleteService(t, client, reportsSlug) })
+	busyID, busySlug := createTestService(t, client, "Cascade Busy")
+	t.Cleanup(func() { deleteService(t, client, busySlug) })
+
+	require.Equal(t, http.StatusOK, putDependencies(t, client, apiSlug, []map[string]string{{"service_id": dbID, "type": "hard"}}))
+	require.Equal(t, http.StatusOK, putDependencies(t, client, reportsSlug, []map[string]string{{"service_id": dbID, "type": "soft"}}))
+	require.Equal(t, http.StatusOK, putDependencies(t, client, busySlug, []map[string]string{{"service_id": dbID, "type": "hard"}}))
+
+	// Already covered by its own incident
+	busyEventID := createTestIncident(t, client, "Busy own incident",
+		[]AffectedService{{ServiceID: busyID, Status: "degraded"}}, nil)
+	t.Cleanup(func() { deleteEvent(t, client, busyEventID) })
+
+	// Degraded dependency doesn't cascade, major outage does
+	eventID := createTestIncident(t, client, "Database down",
+		[]AffectedService{{ServiceID: dbID, Status: "degraded"}}, nil)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+	assert.Empty(t, activeIncidents(t, client, apiSlug))
+
+	resp, err := client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+		"status":          "identified",
+		"message":         "Primary lost",
+		"service_updates": []map[string]string{{"service_id": dbID, "status": "major_outage"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	api := activeIncidents(t, client, apiSlug)
+	require.Len(t, api, 1)
+	assert.Equal(t, "minor", api[0].Severity)
+	assert.Equal(t, "Cascade API affected by Cascade Database outage", api[0].Title)
+	assert.Equal(t, "partial_outage", getServiceEffectiveStatus(t, client, apiSlug), "hard dependency")
+
+	require.Len(t, activeIncidents(t, client, reportsSlug), 1)
+	assert.Equal(t, "degraded", getServiceEffectiveStatus(t, client, reportsSlug), "soft dependency")
+
+	busy := activeIncidents(t, client, busySlug)
+	require.Len(t, busy, 1)
+	assert.Equal(t, busyEventID, busy[0].ID, "no incident for a service with an active event")
+
+	t.Run("status log source is dependency", func(t *testing.T) {
+		resp, err := client.GET("/api/v1/services/" + apiSlug + "/status-log")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result struct {
+			Data struct {
+				Entries []struct {
+					SourceType string  `json:"source_type"`
+					EventID    *string `json:"event_id"`
+				} `json:"entries"`
+			} `json:"data"`
+		}
+		testutil.DecodeJSON(t, resp, &result)
+		require.NotEmpty(t, result.Data.Entries)
+		assert.Equal(t, "dependency", result.Data.Entries[0].SourceType)
+		require.NotNil(t, result.Data.Entries[0].EventID)
+		assert.Equal(t, api[0].ID, *result.Data.Entries[0].EventID)
+	})
+
+	t.Run("new incident with major outage cascades once", func(t *testing.T) {
+		secondID := createTestIncident(t, client, "Database down again",
+			[]AffectedService{{ServiceID: dbID, Status: "major_outage"}}, nil)
+		t.Cleanup(func() { deleteEvent(t, client, secondID) })
+
+		assert.Len(t, activeIncidents(t, client, apiSlug), 1, "API is still covered by the first cascade")
+	})
+}
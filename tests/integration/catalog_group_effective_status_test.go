@@ -0,0 +1,123 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type groupEffectiveStatus struct {
+	Slug            string `json:"slug"`
+	EffectiveStatus string `json:"effective_status"`
+	HasActiveEvents bool   `json:"has_active_events"`
+}
+
+// getGroupEffectiveStatus returns the computed status of a group from GET /groups/{slug}.
+func getGroupEffectiveStatus(t *testing.T, client *testutil.Client, slug string) groupEffectiveStatus {
+	t.Helper()
+	resp, err := client.GET("/api/v1/groups/" + slug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data groupEffectiveStatus `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+// listGroupEffectiveStatus returns the computed status of a group from GET /groups.
+func listGroupEffectiveStatus(t *testing.T, client *testutil.Client, slug string) groupEffectiveStatus {
+	t.Helper()
+	resp, err := client.GET("/api/v1/groups")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data []groupEffectiveStatus `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	for _, g := range result.Data {
+		if g.Slug == slug {
+			return g
+		}
+	}
+	t.Fatalf("group %s not found in list", slug)
+	return groupEffectiveStatus{}
+}
+
+func assertGroupEffectiveStatus(t *testing.T, client *testutil.Client, slug, status string, hasActiveEvents bool) {
+	t.Helper()
+	for _, g := range []groupEffectiveStatus{
+		getGroupEffectiveStatus(t, client, slug),
+		listGroupEffectiveStatus(t, client, slug),
+	} {
+		assert.Equal(t, status, g.EffectiveStatus)
+		assert.Equal(t, hasActiveEvents, g.HasActiveEvents)
+	}
+}
+
+func TestCatalog_Group_EffectiveStatus_Empty(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestGroup(t, client, "Empty Status Group")
+	t.Cleanup(func() { deleteGroup(t, client, slug) })
+
+	assertGroupEffectiveStatus(t, client, slug, "operational", false)
+}
+
+func TestCatalog_Group_EffectiveStatus_WorstOfServices(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	groupID, groupSlug := createTestGroup(t, client, "Worst Status Group")
+	t.Cleanup(func() { deleteGroup(t, client, groupSlug) })
+
+	apiID, apiSlug := createTestService(t, client, "Group Status API", withGroupIDs([]string{groupID}))
+	t.Cleanup(func() { deleteService(t, client, apiSlug) })
+	_, dbSlug := createTestService(t, client, "Group Status DB",
+		withGroupIDs([]string{groupID}), withStatus("degraded"))
+	t.Cleanup(func() { deleteService(t, client, dbSlug) })
+
+	// Stored statuses only
+	assertGroupEffectiveStatus(t, client, groupSlug, "degraded", false)
+
+	// An active incident raises the group to the worst service status
+	eventID := createTestIncident(t, client, "Group Status Incident",
+		[]AffectedService{{ServiceID: apiID, Status: "major_outage"}}, nil)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	assertGroupEffectiveStatus(t, client, groupSlug, "major_outage", true)
+
+	// Resolving the incident falls back to stored statuses
+	resolveEvent(t, client, eventID)
+	assertGroupEffectiveStatus(t, client, groupSlug, "degraded", false)
+}
+
+func TestCatalog_Group_EffectiveStatus_IgnoresArchivedServices(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	groupID, groupSlug := createTestGroup(t, client, "Archived Status Group")
+	t.Cleanup(func() { deleteGroup(t, client, groupSlug) })
+
+	_, activeSlug := createTestService(t, client, "Group Status Active", withGroupIDs([]string{groupID}))
+	t.Cleanup(func() { deleteService(t, client, activeSlug) })
+	_, outageSlug := createTestService(t, client, "Group Status Outage",
+		withGroupIDs([]string{groupID}), withStatus("major_outage"))
+
+	assertGroupEffectiveStatus(t, client, groupSlug, "major_outage", false)
+
+	resp, err := client.DELETE("/api/v1/services/" + outageSlug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	assertGroupEffectiveStatus(t, client, groupSlug, "operational", false)
+}
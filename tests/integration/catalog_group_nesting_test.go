@@ -0,0 +1,240 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nestedGroup struct {
+	ID            string        `json:"id"`
+	Slug          string        `json:"slug"`
+	ParentGroupID *string       `json:"parent_group_id"`
+	Children      []nestedGroup `json:"children"`
+}
+
+func withParentGroup(parentID string) groupOption {
+	return func(m map[string]interface{}) {
+		m["parent_group_id"] = parentID
+	}
+}
+
+func getNestedGroup(t *testing.T, client *testutil.Client, slug string) nestedGroup {
+	t.Helper()
+	resp, err := client.GET("/api/v1/groups/" + slug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data nestedGroup `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+// patchGroupParent sets the parent of a group ("" makes it top-level) and returns the status code.
+func patchGroupParent(t *testing.T, client *testutil.Client, slug, parentID string) int {
+	t.Helper()
+	resp, err := client.PATCH("/api/v1/groups/"+slug, map[string]interface{}{
+		"name":            "Nesting " + slug,
+		"slug":            slug,
+		"parent_group_id": parentID,
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func childIDs(group nestedGroup) []string {
+	ids := make([]string, len(group.Children))
+	for i, c := range group.Children {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+func TestGroupNesting(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	parentID, parentSlug := createTestGroup(t, client, "nesting-parent")
+	t.Cleanup(func() { deleteGroup(t, client, parentSlug) })
+	childID, childSlug := createTestGroup(t, client, "nesting-child", withParentGroup(parentID), withGroupOrder(1))
+	t.Cleanup(func() { deleteGroup(t, client, childSlug) })
+	otherID, otherSlug := createTestGroup(t, client, "nesting-other", withGroupOrder(0))
+	t.Cleanup(func() { deleteGroup(t, client, otherSlug) })
+
+	parent := getNestedGroup(t, client, parentSlug)
+	assert.Nil(t, parent.ParentGroupID)
+	require.Equal(t, []string{childID}, childIDs(parent))
+	assert.Empty(t, parent.Children[0].Children)
+
+	child := getNestedGroup(t, client, childSlug)
+	require.NotNil(t, child.ParentGroupID)
+	assert.Equal(t, parentID, *child.ParentGroupID)
+	assert.NotNil(t, child.Children)
+	assert.Empty(t, child.Children)
+
+	// PATCH moves a top-level group under the parent; children keep their order
+	require.Equal(t, http.StatusOK, patchGroupParent(t, client, otherSlug, parentID))
+	assert.Equal(t, []string{otherID, childID}, childIDs(getNestedGroup(t, client, parentSlug)))
+
+	// The flat list carries parents and children
+	resp, err := client.GET("/api/v1/groups")
+	require.NoError(t, err)
+	var list struct {
+		Data []nestedGroup `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &list)
+	found := 0
+	for _, g := range list.Data {
+		switch g.ID {
+		case parentID:
+			assert.Equal(t, []string{otherID, childID}, childIDs(g))
+			found++
+		case childID:
+			require.NotNil(t, g.ParentGroupID)
+			assert.Equal(t, parentID, *g.ParentGroupID)
+			found++
+		}
+	}
+	assert.Equal(t, 2, found)
+
+	// "" makes the group top-level again, omitting the field keeps the parent
+	require.Equal(t, http.StatusOK, patchGroupParent(t, client, otherSlug, ""))
+	assert.Nil(t, getNestedGroup(t, client, otherSlug).ParentGroupID)
+
+	resp, err = client.PATCH("/api/v1/groups/"+childSlug, map[string]interface{}{"name": "Renamed child", "slug": childSlug})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{childID}, childIDs(getNestedGroup(t, client, parentSlug)))
+}
+
+func TestGroupNesting_Cycles(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	parentID, parentSlug := createTestGroup(t, client, "cycle-parent")
+	t.Cleanup(func() { deleteGroup(t, client, parentSlug) })
+	childID, childSlug := createTestGroup(t, client, "cycle-child", withParentGroup(parentID))
+	t.Cleanup(func() { deleteGroup(t, client, childSlug) })
+	_, otherSlug := createTestGroup(t, client, "cycle-other")
+	t.Cleanup(func() { deleteGroup(t, client, otherSlug) })
+
+	// A group can't be its own parent, nor the child of its sub-group
+	assert.Equal(t, http.StatusConflict, patchGroupParent(t, client, parentSlug, parentID))
+	assert.Equal(t, http.StatusConflict, patchGroupParent(t, client, parentSlug, childID))
+
+	// One level only: no grandchildren, and a parent can't become a sub-group
+	assert.Equal(t, http.StatusConflict, patchGroupParent(t, client, otherSlug, childID))
+	otherID := getNestedGroup(t, client, otherSlug).ID
+	assert.Equal(t, http.StatusConflict, patchGroupParent(t, client, parentSlug, otherID))
+
+	resp, err := client.POST("/api/v1/groups", map[string]interface{}{
+		"name":            "Cycle grandchild",
+		"parent_group_id": childID,
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	assert.Nil(t, getNestedGroup(t, client, parentSlug).ParentGroupID)
+}
+
+func TestGroupNesting_ArchivedParent(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	parentID, parentSlug := createTestGroup(t, client, "archived-parent")
+	t.Cleanup(func() { deleteGroup(t, client, parentSlug) })
+	_, childSlug := createTestGroup(t, client, "archived-child", withParentGroup(parentID))
+	t.Cleanup(func() { deleteGroup(t, client, childSlug) })
+
+	// A parent with sub-groups can't be archived
+	resp, err := client.DELETE("/api/v1/groups/" + parentSlug)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// Archived sub-groups are not listed as children
+	resp, err = client.DELETE("/api/v1/groups/" + childSlug)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Empty(t, getNestedGroup(t, client, parentSlug).Children)
+
+	resp, err = client.DELETE("/api/v1/groups/" + parentSlug)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// An archived group can't become a parent
+	resp, err = client.POST("/api/v1/groups", map[string]interface{}{
+		"name":            "Under archived parent",
+		"parent_group_id": parentID,
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// A sub-group is restored only after its parent
+	resp, err = client.POST("/api/v1/groups/"+childSlug+"/restore", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	for _, slug := range []string{parentSlug, childSlug} {
+		resp, err = client.POST("/api/v1/groups/"+slug+"/restore", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode, slug)
+	}
+	assert.Len(t, getNestedGroup(t, client, parentSlug).Children, 1)
+}
+
+func TestGroupNesting_ArchivedChildKeepsDepth(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	parentID, parentSlug := createTestGroup(t, client, "depth-parent")
+	t.Cleanup(func() { deleteGroup(t, client, parentSlug) })
+	_, childSlug := createTestGroup(t, client, "depth-child", withParentGroup(parentID))
+	t.Cleanup(func() { deleteGroup(t, client, childSlug) })
+	topID, topSlug := createTestGroup(t, client, "depth-top")
+	t.Cleanup(func() { deleteGroup(t, client, topSlug) })
+
+	resp, err := client.DELETE("/api/v1/groups/" + childSlug)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// Restoring the archived child would make it a grandchild of depth-top
+	assert.Equal(t, http.StatusConflict, patchGroupParent(t, client, parentSlug, topID))
+	assert.Nil(t, getNestedGroup(t, client, parentSlug).ParentGroupID)
+
+	resp, err = client.POST("/api/v1/groups/"+childSlug+"/restore", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, getNestedGroup(t, client, parentSlug).Children, 1)
+}
+
+func TestGroupNesting_InvalidParent(t *testing.T) {
+	client := newTestClientWithoutValidation()
+	client.LoginAsAdmin(t)
+
+	for _, parent := range []string{"not-a-uuid", "00000000-0000-0000-0000-000000000000"} {
+		resp, err := client.POST("/api/v1/groups", map[string]interface{}{
+			"name":            "Invalid parent",
+			"parent_group_id": parent,
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, parent)
+	}
+}
@@ -0,0 +1,217 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type importResult struct {
+	Created int `json:"created"`
+	Skipped int `json:"skipped"`
+	Errors  []struct {
+		Row     int    `json:"row"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// importServicesCSV uploads csv as the file of a multipart service import request.
+func importServicesCSV(t *testing.T, client *testutil.Client, csv string) *http.Response {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "services.csv")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(csv))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req, err := http.NewRequest(http.MethodPost, client.BaseURL+"/api/v1/admin/services/import", &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if client.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+client.Token)
+	}
+	if client.CSRFToken != "" {
+		req.Header.Set(httputil.CSRFTokenHeader, client.CSRFToken)
+	}
+
+	resp, err := client.HTTPClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestCatalogImport_ValidCSV(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	groupID, groupSlug := createTestGroup(t, client, "import-group")
+	apiSlug := testutil.RandomSlug("import-api")
+	webSlug := testutil.RandomSlug("import-web")
+	t.Cleanup(func() {
+		deleteService(t, client, apiSlug)
+		deleteService(t, client, webSlug)
+		deleteGroup(t, client, groupSlug)
+	})
+
+	csv := "name,slug,description,group_slugs,tags\n" +
+		"Import API," + apiSlug + ",Public API," + groupSlug + ",team=payments;tier=1\n" +
+		"\"Import Web, site\"," + webSlug + ",,,\n"
+	resp := importServicesCSV(t, client, csv)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data importResult `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, 2, result.Data.Created)
+	assert.Equal(t, 0, result.Data.Skipped)
+	assert.Empty(t, result.Data.Errors)
+
+	resp, err := client.GET("/api/v1/services/" + apiSlug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var service struct {
+		Data struct {
+			Name        string   `json:"name"`
+			Description string   `json:"description"`
+			Status      string   `json:"status"`
+			GroupIDs    []string `json:"group_ids"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &service)
+	assert.Equal(t, "Import API", service.Data.Name)
+	assert.Equal(t, "Public API", service.Data.Description)
+	assert.Equal(t, "operational", service.Data.Status)
+	assert.Equal(t, []string{groupID}, service.Data.GroupIDs)
+
+	resp, err = client.GET("/api/v1/services/" + apiSlug + "/tags")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var tags struct {
+		Data struct {
+			Tags map[string]string `json:"tags"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &tags)
+	assert.Equal(t, map[string]string{"team": "payments", "tier": "1"}, tags.Data.Tags)
+
+	resp, err = client.GET("/api/v1/services/" + webSlug)
+	require.NoError(t, err)
+	testutil.DecodeJSON(t, resp, &service)
+	assert.Equal(t, "Import Web, site", service.Data.Name)
+}
+
+func TestCatalogImport_GeneratedSlug(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	name := testutil.RandomSlug("Import Unnamed")
+	resp := importServicesCSV(t, client, "name,slug\n"+name+",\n")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data importResult `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	require.Equal(t, 1, result.Data.Created)
+
+	var slug string
+	err := testDB.QueryRow(context.Background(), `SELECT slug FROM services WHERE name = $1`, name).Scan(&slug)
+	require.NoError(t, err)
+	t.Cleanup(func() { deleteService(t, client, slug) })
+	assert.Regexp(t, `^import-unnamed-[0-9]+-[0-9a-f]{4}$`, slug)
+}
+
+func TestCatalogImport_DuplicateSlug(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, existingSlug := createTestService(t, client, "import-existing")
+	newSlug := testutil.RandomSlug("import-new")
+	t.Cleanup(func() {
+		deleteService(t, client, existingSlug)
+		deleteService(t, client, newSlug)
+	})
+
+	csv := "name,slug\n" +
+		"Existing," + existingSlug + "\n" +
+		"New," + newSlug + "\n" +
+		"New again," + newSlug + "\n"
+	resp := importServicesCSV(t, client, csv)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data importResult `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, 1, result.Data.Created)
+	assert.Equal(t, 2, result.Data.Skipped)
+	require.Len(t, result.Data.Errors, 2)
+	assert.Equal(t, 2, result.Data.Errors[0].Row)
+	assert.Equal(t, "duplicate slug", result.Data.Errors[0].Message)
+	assert.Equal(t, 4, result.Data.Errors[1].Row)
+	assert.Equal(t, "duplicate slug", result.Data.Errors[1].Message)
+
+	resp, err := client.GET("/api/v1/services/" + newSlug)
+	require.NoError(t, err)
+	var service struct {
+		Data struct {
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &service)
+	assert.Equal(t, "New", service.Data.Name, "the first row with a slug wins")
+}
+
+func TestCatalogImport_InvalidGroupSlug(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	slug := testutil.RandomSlug("import-nogroup")
+	csv := "name,slug,group_slugs\n" +
+		"No group," + slug + ",missing-group-" + slug + "\n"
+	resp := importServicesCSV(t, client, csv)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data importResult `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, 0, result.Data.Created)
+	assert.Equal(t, 1, result.Data.Skipped)
+	require.Len(t, result.Data.Errors, 1)
+	assert.Equal(t, 2, result.Data.Errors[0].Row)
+	assert.Equal(t, "unknown group slug: missing-group-"+slug, result.Data.Errors[0].Message)
+
+	resp, err := client.GET("/api/v1/services/" + slug)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestCatalogImport_Validation(t *testing.T) {
+	client := newTestClient(t)
+
+	t.Run("requires admin", func(t *testing.T) {
+		client.LoginAsOperator(t)
+		resp := importServicesCSV(t, client, "name,slug\nX,x\n")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		client.LoginAsAdmin(t)
+		resp := importServicesCSV(t, client, "name,description\nX,x\n")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,228 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getOrder returns the order of a service or group from GET /{collection}/{slug}.
+func getOrder(t *testing.T, client *testutil.Client, collection, slug string) int {
+	t.Helper()
+	resp, err := client.GET("/api/v1/" + collection + "/" + slug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			Order int `json:"order"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data.Order
+}
+
+// getMaxOrder returns the highest order in table (services or service_groups).
+func getMaxOrder(t *testing.T, table string) int {
+	t.Helper()
+	var maxOrder int
+	err := testDB.QueryRow(context.Background(),
+		`SELECT COALESCE(MAX("order"), -1) FROM `+table).Scan(&maxOrder)
+	require.NoError(t, err)
+	return maxOrder
+}
+
+func TestCatalog_Service_Order_AutoAssign(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, firstSlug := createTestService(t, client, "Order Auto First")
+	t.Cleanup(func() { deleteService(t, client, firstSlug) })
+	_, secondSlug := createTestService(t, client, "Order Auto Second")
+	t.Cleanup(func() { deleteService(t, client, secondSlug) })
+
+	first := getOrder(t, client, "services", firstSlug)
+	second := getOrder(t, client, "services", secondSlug)
+	assert.Equal(t, first+1, second)
+	assert.Equal(t, second, getMaxOrder(t, "services"))
+}
+
+func TestCatalog_Service_Order_Explicit(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	order := getMaxOrder(t, "services") + 10
+	_, slug := createTestService(t, client, "Order Explicit", withOrder(order))
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	assert.Equal(t, order, getOrder(t, client, "services", slug))
+}
+
+func TestCatalog_Service_Order_ConflictShifts(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, firstSlug := createTestService(t, client, "Order Conflict First")
+	t.Cleanup(func() { deleteService(t, client, firstSlug) })
+	_, secondSlug := createTestService(t, client, "Order Conflict Second")
+	t.Cleanup(func() { deleteService(t, client, secondSlug) })
+
+	taken := getOrder(t, client, "services", firstSlug)
+
+	_, newSlug := createTestService(t, client, "Order Conflict New", withOrder(taken))
+	t.Cleanup(func() { deleteService(t, client, newSlug) })
+
+	assert.Equal(t, taken, getOrder(t, client, "services", newSlug))
+	assert.Equal(t, taken+1, getOrder(t, client, "services", firstSlug))
+	assert.Equal(t, taken+2, getOrder(t, client, "services", secondSlug))
+}
+
+func TestCatalog_Group_Order_AutoAssign(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, firstSlug := createTestGroup(t, client, "Order Auto Group First")
+	t.Cleanup(func() { deleteGroup(t, client, firstSlug) })
+	_, secondSlug := createTestGroup(t, client, "Order Auto Group Second")
+	t.Cleanup(func() { deleteGroup(t, client, secondSlug) })
+
+	first := getOrder(t, client, "groups", firstSlug)
+	second := getOrder(t, client, "groups", secondSlug)
+	assert.Equal(t, first+1, second)
+	assert.Equal(t, second, getMaxOrder(t, "service_groups"))
+}
+
+func TestCatalog_Group_Order_Explicit(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	order := getMaxOrder(t, "service_groups") + 10
+	_, slug := createTestGroup(t, client, "Order Explicit Group", withGroupOrder(order))
+	t.Cleanup(func() { deleteGroup(t, client, slug) })
+
+	assert.Equal(t, order, getOrder(t, client, "groups", slug))
+}
+
+func TestCatalog_Group_Order_ConflictShifts(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, firstSlug := createTestGroup(t, client, "Order Conflict Group First")
+	t.Cleanup(func() { deleteGroup(t, client, firstSlug) })
+	_, secondSlug := createTestGroup(t, client, "Order Conflict Group Second")
+	t.Cleanup(func() { deleteGroup(t, client, secondSlug) })
+
+	taken := getOrder(t, client, "groups", firstSlug)
+
+	_, newSlug := createTestGroup(t, client, "Order Conflict Group New", withGroupOrder(taken))
+	t.Cleanup(func() { deleteGroup(t, client, newSlug) })
+
+	assert.Equal(t, taken, getOrder(t, client, "groups", newSlug))
+	assert.Equal(t, taken+1, getOrder(t, client, "groups", firstSlug))
+	assert.Equal(t, taken+2, getOrder(t, client, "groups", secondSlug))
+}
+
+func TestCatalog_Service_Reorder(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	base := getMaxOrder(t, "services") + 10
+	firstID, firstSlug := createTestService(t, client, "Reorder First", withOrder(base))
+	t.Cleanup(func() { deleteService(t, client, firstSlug) })
+	secondID, secondSlug := createTestService(t, client, "Reorder Second", withOrder(base+1))
+	t.Cleanup(func() { deleteService(t, client, secondSlug) })
+
+	resp, err := client.PUT("/api/v1/services/order", []map[string]interface{}{
+		{"id": firstID, "order": base + 1},
+		{"id": secondID, "order": base},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data []struct {
+			ID    string `json:"id"`
+			Order int    `json:"order"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+
+	orders := make(map[string]int, len(result.Data))
+	for _, s := range result.Data {
+		orders[s.ID] = s.Order
+	}
+	assert.Equal(t, base+1, orders[firstID])
+	assert.Equal(t, base, orders[secondID])
+
+	assert.Equal(t, base+1, getOrder(t, client, "services", firstSlug))
+	assert.Equal(t, base, getOrder(t, client, "services", secondSlug))
+}
+
+func TestCatalog_Service_Reorder_Errors(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	base := getMaxOrder(t, "services") + 10
+	id, slug := createTestService(t, client, "Reorder Errors", withOrder(base))
+	t.Cleanup(func() { deleteService(t, client, slug) })
+	otherID, otherSlug := createTestService(t, client, "Reorder Errors Other", withOrder(base+1))
+	t.Cleanup(func() { deleteService(t, client, otherSlug) })
+
+	t.Run("duplicate order", func(t *testing.T) {
+		resp, err := client.PUT("/api/v1/services/order", []map[string]interface{}{
+			{"id": id, "order": base + 5},
+			{"id": otherID, "order": base + 5},
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("unknown service leaves orders unchanged", func(t *testing.T) {
+		resp, err := client.PUT("/api/v1/services/order", []map[string]interface{}{
+			{"id": id, "order": base + 5},
+			{"id": "00000000-0000-0000-0000-000000000000", "order": base + 6},
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, base, getOrder(t, client, "services", slug))
+	})
+
+	t.Run("archived service", func(t *testing.T) {
+		archivedID, archivedSlug := createTestService(t, client, "Reorder Archived")
+		deleteService(t, client, archivedSlug)
+
+		resp, err := client.PUT("/api/v1/services/order", []map[string]interface{}{
+			{"id": archivedID, "order": base + 7},
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		resp, err := client.PUT("/api/v1/services/order", []map[string]interface{}{})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("operator forbidden", func(t *testing.T) {
+		operator := newTestClient(t)
+		operator.LoginAsOperator(t)
+
+		resp, err := operator.PUT("/api/v1/services/order", []map[string]interface{}{
+			{"id": id, "order": base + 5},
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
@@ -0,0 +1,165 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type groupHistoryEntry struct {
+	ID          string  `json:"id"`
+	ServiceID   string  `json:"service_id"`
+	GroupID     string  `json:"group_id"`
+	GroupSlug   string  `json:"group_slug"`
+	Action      string  `json:"action"`
+	ActorUserID *string `json:"actor_user_id"`
+}
+
+type groupHistoryPage struct {
+	Entries []groupHistoryEntry `json:"entries"`
+	Total   int                 `json:"total"`
+	Limit   int                 `json:"limit"`
+	Offset  int                 `json:"offset"`
+}
+
+func getGroupHistory(t *testing.T, client *testutil.Client, slug, query string) groupHistoryPage {
+	t.Helper()
+	resp, err := client.GET("/api/v1/services/" + slug + "/group-history" + query)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data groupHistoryPage `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+func TestCatalog_ServiceGroupHistory(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	groupAID, groupASlug := createTestGroup(t, client, "History Group A")
+	t.Cleanup(func() { deleteGroup(t, client, groupASlug) })
+	groupBID, groupBSlug := createTestGroup(t, client, "History Group B")
+	t.Cleanup(func() { deleteGroup(t, client, groupBSlug) })
+
+	serviceID, serviceSlug := createTestService(t, client, "History Service", withGroupIDs([]string{groupAID}))
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	// Move the service from group A to group B
+	resp, err := client.PATCH("/api/v1/services/"+serviceSlug, map[string]interface{}{
+		"name":      "History Service",
+		"slug":      serviceSlug,
+		"status":    "operational",
+		"group_ids": []string{groupBID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	// Add the service back to group A via the group
+	resp, err = client.PATCH("/api/v1/groups/"+groupASlug, map[string]interface{}{
+		"name":        "History Group A",
+		"slug":        groupASlug,
+		"service_ids": []string{serviceID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	// Unchanged memberships are not logged
+	resp, err = client.PATCH("/api/v1/groups/"+groupASlug, map[string]interface{}{
+		"name":        "History Group A",
+		"slug":        groupASlug,
+		"service_ids": []string{serviceID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	adminID := adminUserID(t)
+
+	t.Run("lists changes newest first", func(t *testing.T) {
+		page := getGroupHistory(t, client, serviceSlug, "")
+		assert.Equal(t, 4, page.Total)
+		assert.Equal(t, 50, page.Limit)
+		assert.Equal(t, 0, page.Offset)
+		require.Len(t, page.Entries, 4)
+
+		for _, entry := range page.Entries {
+			assert.Equal(t, serviceID, entry.ServiceID)
+			require.NotNil(t, entry.ActorUserID)
+			assert.Equal(t, adminID, *entry.ActorUserID)
+		}
+
+		// Group PATCH
+		assert.Equal(t, "added", page.Entries[0].Action)
+		assert.Equal(t, groupAID, page.Entries[0].GroupID)
+		assert.Equal(t, groupASlug, page.Entries[0].GroupSlug)
+
+		// Service PATCH: both changes share the transaction timestamp
+		moved := map[string]string{
+			page.Entries[1].GroupID: page.Entries[1].Action,
+			page.Entries[2].GroupID: page.Entries[2].Action,
+		}
+		assert.Equal(t, map[string]string{groupAID: "removed", groupBID: "added"}, moved)
+
+		// Service creation
+		assert.Equal(t, "added", page.Entries[3].Action)
+		assert.Equal(t, groupAID, page.Entries[3].GroupID)
+	})
+
+	t.Run("paginates", func(t *testing.T) {
+		first := getGroupHistory(t, client, serviceSlug, "?limit=1")
+		assert.Equal(t, 4, first.Total)
+		assert.Equal(t, 1, first.Limit)
+		require.Len(t, first.Entries, 1)
+		assert.Equal(t, groupAID, first.Entries[0].GroupID)
+
+		last := getGroupHistory(t, client, serviceSlug, "?limit=3&offset=3")
+		assert.Equal(t, 3, last.Offset)
+		require.Len(t, last.Entries, 1)
+		assert.Equal(t, groupAID, last.Entries[0].GroupID)
+		assert.Equal(t, "added", last.Entries[0].Action)
+	})
+
+	t.Run("invalid pagination", func(t *testing.T) {
+		resp, err := client.GET("/api/v1/services/" + serviceSlug + "/group-history?limit=0")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp.Body.Close()
+	})
+
+	t.Run("unknown service", func(t *testing.T) {
+		resp, err := client.GET("/api/v1/services/missing-history-service/group-history")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		resp.Body.Close()
+	})
+
+	t.Run("operator only", func(t *testing.T) {
+		operator := newTestClient(t)
+		operator.LoginAsOperator(t)
+		page := getGroupHistory(t, operator, serviceSlug, "")
+		assert.Equal(t, 4, page.Total)
+
+		user := newTestClient(t)
+		user.LoginAsUser(t)
+		resp, err := user.GET("/api/v1/services/" + serviceSlug + "/group-history")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		resp.Body.Close()
+
+		anonymous := newTestClient(t)
+		resp, err = anonymous.GET("/api/v1/services/" + serviceSlug + "/group-history")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		resp.Body.Close()
+	})
+}
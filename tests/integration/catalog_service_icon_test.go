@@ -0,0 +1,153 @@
+//go:build integration
+
+package integration
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// allowLoopbackIcons lets the app under test check icons on httptest servers;
+// every other address goes through catalog.RefuseInternalAddress.
+func allowLoopbackIcons(network, address string, c syscall.RawConn) error {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+			return nil
+		}
+	}
+	return catalog.RefuseInternalAddress(network, address, c)
+}
+
+// newIconServer serves /logo.png as an image and /page as HTML.
+func newIconServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/logo.png":
+			w.Header().Set("Content-Type", "image/png")
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func getServiceIconURL(t *testing.T, client *testutil.Client, slug string) string {
+	t.Helper()
+	resp, err := client.GET("/api/v1/services/" + slug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data struct {
+			IconURL string `json:"icon_url"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data.IconURL
+}
+
+func TestCatalog_Service_Icon(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+	icons := newIconServer(t)
+	iconURL := icons.URL + "/logo.png"
+
+	slug := testutil.RandomSlug("icon-service")
+	resp, err := client.POST("/api/v1/services", map[string]interface{}{
+		"name":     "Icon Service",
+		"slug":     slug,
+		"icon_url": iconURL,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	assert.Equal(t, iconURL, getServiceIconURL(t, client, slug))
+
+	feed := getFeed(t, newTestClient(t), "/api/v1/services/"+slug+"/feed")
+	assert.Equal(t, iconURL, feed.Logo)
+
+	// Omitted keeps the icon, empty string clears it
+	resp, err = client.PATCH("/api/v1/services/"+slug, map[string]interface{}{
+		"name":   "Icon Service",
+		"slug":   slug,
+		"status": "operational",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	assert.Equal(t, iconURL, getServiceIconURL(t, client, slug))
+
+	resp, err = client.PATCH("/api/v1/services/"+slug, map[string]interface{}{
+		"name":     "Icon Service",
+		"slug":     slug,
+		"status":   "operational",
+		"icon_url": "",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	assert.Empty(t, getServiceIconURL(t, client, slug))
+
+	feed = getFeed(t, newTestClient(t), "/api/v1/services/"+slug+"/feed")
+	assert.Empty(t, feed.Logo)
+}
+
+func TestCatalog_Service_Icon_InvalidURL(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	for _, invalid := range []string{"cdn.example.com/logo.png", "ftp://cdn.example.com/logo.png", "data:image/png;base64,AAAA"} {
+		resp, err := client.POST("/api/v1/services", map[string]string{
+			"name":     "Invalid Icon",
+			"slug":     testutil.RandomSlug("invalid-icon"),
+			"icon_url": invalid,
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, invalid)
+	}
+}
+
+func TestCatalog_Service_Icon_NotImage(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+	icons := newIconServer(t)
+
+	for _, notImage := range []string{icons.URL + "/page", icons.URL + "/missing.png"} {
+		resp, err := client.POST("/api/v1/services", map[string]string{
+			"name":     "Not Image Icon",
+			"slug":     testutil.RandomSlug("not-image-icon"),
+			"icon_url": notImage,
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, notImage)
+	}
+
+	_, slug := createTestService(t, client, "Not Image Update")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	resp, err := client.PATCH("/api/v1/services/"+slug, map[string]interface{}{
+		"name":     "Not Image Update",
+		"slug":     slug,
+		"status":   "operational",
+		"icon_url": icons.URL + "/page",
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Empty(t, getServiceIconURL(t, client, slug))
+}
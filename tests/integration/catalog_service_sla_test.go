@@ -0,0 +1,190 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	catalogpostgres "github.com/bissquit/incident-garden/internal/catalog/postgres"
+	"github.com/bissquit/incident-garden/internal/notifications"
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type slaResponse struct {
+	Data struct {
+		Target        *float64 `json:"sla_uptime_target"`
+		UptimePercent float64  `json:"uptime_percent"`
+		Breached      bool     `json:"breached"`
+	} `json:"data"`
+}
+
+func setServiceSLA(t *testing.T, client *testutil.Client, slug string, target interface{}) *http.Response {
+	t.Helper()
+	resp, err := client.PUT("/api/v1/services/"+slug+"/sla", map[string]interface{}{"sla_uptime_target": target})
+	require.NoError(t, err)
+	return resp
+}
+
+func getServiceSLA(t *testing.T, client *testutil.Client, slug string) slaResponse {
+	t.Helper()
+	resp, err := client.GET("/api/v1/services/" + slug + "/sla")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result slaResponse
+	testutil.DecodeJSON(t, resp, &result)
+	return result
+}
+
+// slaBreachesFor returns sent notifications that are SLA breach alerts about serviceName.
+func slaBreachesFor(sent []SentNotification, serviceName string) []SentNotification {
+	var result []SentNotification
+	for _, n := range sent {
+		if n.Subject == "[SLA breach] "+serviceName {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func TestServiceSLA_SetAndGet(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "SLA Target Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	// No target yet: public endpoint, never breached
+	result := getServiceSLA(t, newTestClient(t), slug)
+	assert.Nil(t, result.Data.Target)
+	assert.False(t, result.Data.Breached)
+
+	resp := setServiceSLA(t, client, slug, 99.9)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &result)
+	require.NotNil(t, result.Data.Target)
+	assert.Equal(t, 99.9, *result.Data.Target)
+	assert.Equal(t, 100.0, result.Data.UptimePercent)
+	assert.False(t, result.Data.Breached)
+
+	resp, err := client.GET("/api/v1/services/" + slug)
+	require.NoError(t, err)
+	var service struct {
+		Data struct {
+			SLAUptimeTarget *float64 `json:"sla_uptime_target"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &service)
+	require.NotNil(t, service.Data.SLAUptimeTarget)
+	assert.Equal(t, 99.9, *service.Data.SLAUptimeTarget)
+
+	// Removing the target
+	resp = setServiceSLA(t, client, slug, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	assert.Nil(t, getServiceSLA(t, client, slug).Data.Target)
+}
+
+func TestServiceSLA_Rejects(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "SLA Rejects Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	raw := client.WithoutValidation()
+	for _, target := range []float64{0, -5, 100.5} {
+		resp := setServiceSLA(t, raw, slug, target)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "target %v", target)
+	}
+
+	resp := setServiceSLA(t, client, "sla-missing-service", 99.9)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	operator := newTestClient(t)
+	operator.LoginAsOperator(t)
+	resp = setServiceSLA(t, operator, slug, 99.9)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestServiceSLA_BreachNotification(t *testing.T) {
+	ctx := context.Background()
+	repo := notificationspostgres.NewRepository(testDB)
+	catalogService := catalog.NewService(catalogpostgres.NewRepository(testDB), nil)
+	mocks := NewMockSenderRegistry()
+
+	dispatcher := notifications.NewDispatcher(repo, mocks.GetSenders()...)
+	renderer, err := notifications.NewRenderer()
+	require.NoError(t, err)
+	notifier := notifications.NewNotifier(repo, renderer, dispatcher, catalogService, "https://status.example.com")
+
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	breachedName := "SLA Breached " + testutil.RandomSlug("svc")
+	breachedID, breachedSlug := createTestService(t, client, breachedName)
+	t.Cleanup(func() { deleteService(t, client, breachedSlug) })
+	healthyName := "SLA Healthy " + testutil.RandomSlug("svc")
+	healthyID, healthySlug := createTestService(t, client, healthyName)
+	t.Cleanup(func() { deleteService(t, client, healthySlug) })
+
+	channelID := createAndVerifyEmailChannel(t, client)
+	t.Cleanup(func() { deleteChannel(t, client, channelID) })
+	setChannelSubscription(t, client, channelID, []string{breachedID, healthyID})
+
+	client.LoginAsAdmin(t)
+
+	// Major outage since the start of the month: 0% uptime whatever day it is
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	seedStatusLog(t, breachedID, "operational", "major_outage", monthStart.Add(-time.Minute))
+
+	for _, slug := range []string{breachedSlug, healthySlug} {
+		resp := setServiceSLA(t, client, slug, 99.9)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	status := getServiceSLA(t, client, breachedSlug)
+	assert.True(t, status.Data.Breached)
+	assert.Equal(t, 0.0, status.Data.UptimePercent)
+
+	checker := catalog.NewSLAChecker(catalog.SLAConfig{PollInterval: 100 * time.Millisecond}, catalogService, notifier)
+	runCtx, cancel := context.WithCancel(ctx)
+	checker.Start(runCtx)
+	defer func() {
+		cancel()
+		checker.Stop()
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(slaBreachesFor(mocks.Email.GetSent(), breachedName)) > 0
+	}, 5*time.Second, 100*time.Millisecond, "subscriber should receive an SLA breach alert")
+
+	alert := slaBreachesFor(mocks.Email.GetSent(), breachedName)[0]
+	assert.Contains(t, alert.Body, "uptime this month is 0%")
+	assert.Contains(t, alert.Body, "SLA target of 99.9%")
+
+	// Several more polls: one alert per month, nothing for the healthy service
+	time.Sleep(500 * time.Millisecond)
+	assert.Len(t, slaBreachesFor(mocks.Email.GetSent(), breachedName), 1)
+	assert.Empty(t, slaBreachesFor(mocks.Email.GetSent(), healthyName))
+
+	// A new target allows a new alert in the same month
+	resp := setServiceSLA(t, client, breachedSlug, 95)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	require.Eventually(t, func() bool {
+		return len(slaBreachesFor(mocks.Email.GetSent(), breachedName)) == 2
+	}, 5*time.Second, 100*time.Millisecond, "changed target should alert again")
+}
@@ -0,0 +1,115 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listServiceSlugs calls GET /api/v1/services with the given query and returns slugs.
+func listServiceSlugs(t *testing.T, client *testutil.Client, query url.Values) []string {
+	t.Helper()
+
+	resp, err := client.GET("/api/v1/services?" + query.Encode())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data []struct {
+			Slug string `json:"slug"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	require.NotNil(t, result.Data, "data should be an array, not null")
+
+	slugs := make([]string, 0, len(result.Data))
+	for _, s := range result.Data {
+		slugs = append(slugs, s.Slug)
+	}
+	return slugs
+}
+
+func TestCatalog_Service_List_FilterBySingleTag(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	team := testutil.RandomSlug("team")
+	_, taggedSlug := createTestService(t, client, "tag-filter-single", withTags(map[string]string{"team": team}))
+	t.Cleanup(func() { deleteService(t, client, taggedSlug) })
+	_, otherSlug := createTestService(t, client, "tag-filter-other", withTags(map[string]string{"team": team + "-other"}))
+	t.Cleanup(func() { deleteService(t, client, otherSlug) })
+
+	slugs := listServiceSlugs(t, newTestClient(t), url.Values{"tag[team]": {team}})
+
+	assert.Equal(t, []string{taggedSlug}, slugs)
+}
+
+func TestCatalog_Service_List_FilterByMultipleTags_AND(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	team := testutil.RandomSlug("team")
+	_, bothSlug := createTestService(t, client, "tag-filter-both",
+		withTags(map[string]string{"team": team, "tier": "1"}))
+	t.Cleanup(func() { deleteService(t, client, bothSlug) })
+	_, teamOnlySlug := createTestService(t, client, "tag-filter-team-only",
+		withTags(map[string]string{"team": team, "tier": "2"}))
+	t.Cleanup(func() { deleteService(t, client, teamOnlySlug) })
+
+	slugs := listServiceSlugs(t, newTestClient(t), url.Values{
+		"tag[team]": {team},
+		"tag[tier]": {"1"},
+	})
+
+	assert.Equal(t, []string{bothSlug}, slugs)
+}
+
+func TestCatalog_Service_List_FilterByTag_NoMatches(t *testing.T) {
+	slugs := listServiceSlugs(t, newTestClient(t), url.Values{
+		"tag[team]": {testutil.RandomSlug("nonexistent-team")},
+	})
+
+	assert.Empty(t, slugs)
+}
+
+func TestCatalog_Service_List_FilterByTagAndStatus(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	team := testutil.RandomSlug("team")
+	_, degradedSlug := createTestService(t, client, "tag-filter-degraded",
+		withStatus("degraded"), withTags(map[string]string{"team": team}))
+	t.Cleanup(func() { deleteService(t, client, degradedSlug) })
+	_, operationalSlug := createTestService(t, client, "tag-filter-operational",
+		withTags(map[string]string{"team": team}))
+	t.Cleanup(func() { deleteService(t, client, operationalSlug) })
+
+	publicClient := newTestClient(t)
+
+	slugs := listServiceSlugs(t, publicClient, url.Values{
+		"tag[team]": {team},
+		"status":    {"degraded"},
+	})
+	assert.Equal(t, []string{degradedSlug}, slugs)
+
+	slugs = listServiceSlugs(t, publicClient, url.Values{
+		"tag[team]": {team},
+		"status":    {"major_outage"},
+	})
+	assert.Empty(t, slugs)
+}
+
+func TestCatalog_Service_List_FilterByTag_EmptyKey(t *testing.T) {
+	client := newTestClientWithoutValidation()
+
+	resp, err := client.GET("/api/v1/services?" + url.Values{"tag[]": {"x"}}.Encode())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -128,3 +128,99 @@ func TestCatalog_DuplicateSlug(t *testing.T) {
 	assert.Equal(t, http.StatusConflict, resp.StatusCode)
 	resp.Body.Close()
 }
+
+type serviceLinks struct {
+	ExternalURL      string `json:"external_url"`
+	DocumentationURL string `json:"documentation_url"`
+}
+
+func getServiceLinks(t *testing.T, client *testutil.Client, slug string) serviceLinks {
+	t.Helper()
+	resp, err := client.GET("/api/v1/services/" + slug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data serviceLinks `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+func TestCatalog_Service_Links(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	slug := testutil.RandomSlug("links")
+	resp, err := client.POST("/api/v1/services", map[string]string{
+		"name":              "Linked Service",
+		"slug":              slug,
+		"external_url":      "https://grafana.example.com/d/api",
+		"documentation_url": "http://wiki.example.com/runbooks/api",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created struct {
+		Data serviceLinks `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &created)
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	want := serviceLinks{
+		ExternalURL:      "https://grafana.example.com/d/api",
+		DocumentationURL: "http://wiki.example.com/runbooks/api",
+	}
+	assert.Equal(t, want, created.Data)
+	assert.Equal(t, want, getServiceLinks(t, client, slug))
+
+	// Omitted fields keep their values
+	resp, err = client.PATCH("/api/v1/services/"+slug, map[string]interface{}{
+		"name":   "Linked Service",
+		"slug":   slug,
+		"status": "operational",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	assert.Equal(t, want, getServiceLinks(t, client, slug))
+
+	// Empty string clears a field
+	resp, err = client.PATCH("/api/v1/services/"+slug, map[string]interface{}{
+		"name":         "Linked Service",
+		"slug":         slug,
+		"status":       "operational",
+		"external_url": "",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	assert.Equal(t, serviceLinks{DocumentationURL: want.DocumentationURL}, getServiceLinks(t, client, slug))
+}
+
+func TestCatalog_Service_Links_InvalidURL(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	for _, invalid := range []string{"grafana.example.com/d/api", "ftp://example.com/runbook", "https://", "javascript:alert(1)"} {
+		resp, err := client.POST("/api/v1/services", map[string]string{
+			"name":         "Invalid Link",
+			"slug":         testutil.RandomSlug("invalid-link"),
+			"external_url": invalid,
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, invalid)
+	}
+
+	_, slug := createTestService(t, client, "invalid-link-update")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	resp, err := client.PATCH("/api/v1/services/"+slug, map[string]interface{}{
+		"name":              "Invalid Link",
+		"slug":              slug,
+		"status":            "operational",
+		"documentation_url": "not a url",
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,118 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type timelineEntry struct {
+	At         time.Time `json:"at"`
+	From       *string   `json:"from"`
+	To         string    `json:"to"`
+	EventID    *string   `json:"event_id"`
+	EventTitle string    `json:"event_title"`
+	Message    string    `json:"message"`
+}
+
+func getServiceTimeline(t *testing.T, client *testutil.Client, slug, window string) []timelineEntry {
+	t.Helper()
+	path := "/api/v1/services/" + slug + "/timeline"
+	if window != "" {
+		path += "?window=" + window
+	}
+	resp, err := client.GET(path)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data []timelineEntry `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+func TestGetServiceTimeline_EventTransitions(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Timeline Event Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	eventID := createTestIncident(t, client, "Timeline DB outage",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	addEventUpdate(t, client, eventID, "identified", "Root cause found")
+	resolveEvent(t, client, eventID)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	// Public endpoint
+	timeline := getServiceTimeline(t, newTestClient(t), slug, "")
+	require.Len(t, timeline, 2)
+
+	opened := timeline[0]
+	require.NotNil(t, opened.From)
+	assert.Equal(t, "operational", *opened.From)
+	assert.Equal(t, "degraded", opened.To)
+	require.NotNil(t, opened.EventID)
+	assert.Equal(t, eventID, *opened.EventID)
+	assert.Equal(t, "Timeline DB outage", opened.EventTitle)
+	assert.Equal(t, "Event created: Timeline DB outage", opened.Message, "no update yet, falls back to the log reason")
+
+	closed := timeline[1]
+	require.NotNil(t, closed.From)
+	assert.Equal(t, "degraded", *closed.From)
+	assert.Equal(t, "operational", closed.To)
+	assert.Equal(t, "Timeline DB outage", closed.EventTitle)
+	assert.Equal(t, "Fixed", closed.Message, "message of the resolving update")
+	assert.False(t, closed.At.Before(opened.At), "chronological order")
+}
+
+func TestGetServiceTimeline_Window(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Timeline Window Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	now := time.Now().UTC()
+	seedStatusLog(t, serviceID, "operational", "major_outage", now.AddDate(0, 0, -40))
+	seedStatusLog(t, serviceID, "major_outage", "operational", now.AddDate(0, 0, -40).Add(time.Hour))
+	seedStatusLog(t, serviceID, "operational", "degraded", now.AddDate(0, 0, -2))
+
+	timeline := getServiceTimeline(t, client, slug, "30d")
+	require.Len(t, timeline, 1)
+	assert.Equal(t, "degraded", timeline[0].To)
+	assert.Nil(t, timeline[0].EventID)
+	assert.Empty(t, timeline[0].EventTitle)
+	assert.Equal(t, "seeded", timeline[0].Message)
+
+	timeline = getServiceTimeline(t, client, slug, "90d")
+	require.Len(t, timeline, 3)
+	assert.Equal(t, "major_outage", timeline[0].To)
+	assert.Equal(t, "operational", timeline[1].To)
+	assert.Equal(t, "degraded", timeline[2].To)
+}
+
+func TestGetServiceTimeline_Errors(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "Timeline Errors Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	resp, err := client.GET("/api/v1/services/" + slug + "/timeline?window=14d")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = client.GET("/api/v1/services/nonexistent-timeline-service/timeline")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
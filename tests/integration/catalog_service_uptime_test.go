@@ -0,0 +1,135 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type uptimeResponse struct {
+	Data struct {
+		Window          string  `json:"window"`
+		UptimePercent   float64 `json:"uptime_percent"`
+		DowntimeSeconds int64   `json:"downtime_seconds"`
+		Daily           []struct {
+			Date            string  `json:"date"`
+			UptimePercent   float64 `json:"uptime_percent"`
+			DowntimeSeconds int64   `json:"downtime_seconds"`
+		} `json:"daily"`
+	} `json:"data"`
+}
+
+// seedStatusLog inserts a status log entry with an explicit timestamp.
+func seedStatusLog(t *testing.T, serviceID, oldStatus, newStatus string, at time.Time) {
+	t.Helper()
+	_, err := testDB.Exec(context.Background(), `
+		INSERT INTO service_status_log (service_id, old_status, new_status, source_type, reason, created_by, created_at)
+		VALUES ($1, $2, $3, 'manual', 'seeded', (SELECT id FROM users WHERE email = 'admin@example.com'), $4)
+	`, serviceID, oldStatus, newStatus, at.UTC())
+	require.NoError(t, err)
+}
+
+func getServiceUptime(t *testing.T, client *testutil.Client, slug, window string) uptimeResponse {
+	t.Helper()
+	path := "/api/v1/services/" + slug + "/uptime"
+	if window != "" {
+		path += "?window=" + window
+	}
+	resp, err := client.GET(path)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result uptimeResponse
+	testutil.DecodeJSON(t, resp, &result)
+	return result
+}
+
+func TestGetServiceUptime_NoHistory(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "Uptime No History Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	// Public endpoint, default window is 30d
+	result := getServiceUptime(t, newTestClient(t), slug, "")
+
+	assert.Equal(t, "30d", result.Data.Window)
+	assert.Equal(t, 100.0, result.Data.UptimePercent)
+	assert.Equal(t, int64(0), result.Data.DowntimeSeconds)
+	require.Len(t, result.Data.Daily, 30)
+	assert.Equal(t, time.Now().UTC().Format("2006-01-02"), result.Data.Daily[29].Date)
+}
+
+func TestGetServiceUptime_SeededOutages(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Uptime Seeded Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	threeDaysAgo := today.AddDate(0, 0, -3)
+	yesterday := today.AddDate(0, 0, -1)
+
+	// 6h major outage three days ago: 75% uptime that day
+	seedStatusLog(t, serviceID, "operational", "major_outage", threeDaysAgo.Add(6*time.Hour))
+	seedStatusLog(t, serviceID, "major_outage", "operational", threeDaysAgo.Add(12*time.Hour))
+	// Degradation spanning midnight: 2h two days ago, 4h yesterday
+	seedStatusLog(t, serviceID, "operational", "degraded", yesterday.Add(-2*time.Hour))
+	seedStatusLog(t, serviceID, "degraded", "operational", yesterday.Add(4*time.Hour))
+
+	result := getServiceUptime(t, client, slug, "7d")
+
+	assert.Equal(t, "7d", result.Data.Window)
+	require.Len(t, result.Data.Daily, 7)
+
+	daily := result.Data.Daily
+	assert.Equal(t, threeDaysAgo.Format("2006-01-02"), daily[3].Date)
+	assert.Equal(t, 75.0, daily[3].UptimePercent)
+	assert.Equal(t, int64(6*3600), daily[3].DowntimeSeconds)
+
+	assert.Equal(t, 91.67, daily[4].UptimePercent)
+	assert.Equal(t, int64(2*3600), daily[4].DowntimeSeconds)
+
+	assert.Equal(t, yesterday.Format("2006-01-02"), daily[5].Date)
+	assert.Equal(t, 83.33, daily[5].UptimePercent)
+	assert.Equal(t, int64(4*3600), daily[5].DowntimeSeconds)
+
+	assert.Equal(t, 100.0, daily[6].UptimePercent)
+	assert.Equal(t, int64(0), daily[0].DowntimeSeconds)
+
+	assert.Equal(t, int64(12*3600), result.Data.DowntimeSeconds)
+	windowSeconds := now.Sub(today.AddDate(0, 0, -6)).Seconds()
+	assert.InDelta(t, (windowSeconds-12*3600)/windowSeconds*100, result.Data.UptimePercent, 0.01)
+}
+
+func TestGetServiceUptime_InvalidWindow(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "Uptime Invalid Window Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	resp, err := client.GET("/api/v1/services/" + slug + "/uptime?window=14d")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetServiceUptime_NotFound(t *testing.T) {
+	client := newTestClient(t)
+
+	resp, err := client.GET("/api/v1/services/nonexistent-uptime-service/uptime")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
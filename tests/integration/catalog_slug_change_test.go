@@ -0,0 +1,88 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// patchServiceSlug changes the slug of a service with an optional query and returns the response.
+func patchServiceSlug(t *testing.T, client *testutil.Client, slug, newSlug, query string) *http.Response {
+	t.Helper()
+	resp, err := client.PATCH("/api/v1/services/"+slug+query, map[string]interface{}{
+		"name":   "Slug Change " + newSlug,
+		"slug":   newSlug,
+		"status": "operational",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestCatalog_SlugChange_ActiveEvents(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "slug-change-active")
+	newSlug := testutil.RandomSlug("slug-change-new")
+	t.Cleanup(func() { deleteService(t, client, newSlug) })
+	eventID := createTestIncident(t, client, "Slug change blocked",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	resp := patchServiceSlug(t, client, slug, newSlug, "")
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	var errResult struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	testutil.DecodeJSON(t, resp, &errResult)
+	assert.Equal(t, "cannot change slug: service has active events", errResult.Error.Message)
+
+	// Other fields can still change
+	resp, err := client.PATCH("/api/v1/services/"+slug, map[string]interface{}{
+		"name":   "Slug Change Renamed",
+		"slug":   slug,
+		"status": "operational",
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = patchServiceSlug(t, client, slug, newSlug, "?force=false")
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = patchServiceSlug(t, client, slug, newSlug, "?force=true")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = client.GET("/api/v1/services/" + newSlug)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestCatalog_SlugChange_ResolvedEvents(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "slug-change-resolved")
+	newSlug := testutil.RandomSlug("slug-change-free")
+	t.Cleanup(func() { deleteService(t, client, newSlug) })
+	eventID := createTestIncident(t, client, "Slug change allowed",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	resolveEvent(t, client, eventID)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	resp := patchServiceSlug(t, client, slug, newSlug, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
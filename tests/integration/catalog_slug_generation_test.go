@@ -0,0 +1,69 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postForSlug creates a service or group and returns the slug from the response.
+func postForSlug(t *testing.T, client *testutil.Client, path string, payload map[string]interface{}) string {
+	t.Helper()
+	resp, err := client.POST(path, payload)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var result struct {
+		Data struct {
+			Slug string `json:"slug"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data.Slug
+}
+
+func TestCatalog_GeneratedSlug(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	name := "Generated Slug " + randomSuffix()
+	pattern := regexp.MustCompile(`^generated-slug-[a-z0-9]+-[0-9a-f]{4}$`)
+
+	omitted := postForSlug(t, client, "/api/v1/services", map[string]interface{}{"name": name})
+	t.Cleanup(func() { deleteService(t, client, omitted) })
+	assert.Regexp(t, pattern, omitted)
+
+	empty := postForSlug(t, client, "/api/v1/services", map[string]interface{}{"name": name, "slug": ""})
+	t.Cleanup(func() { deleteService(t, client, empty) })
+	assert.Regexp(t, pattern, empty)
+	assert.NotEqual(t, omitted, empty, "same name gets distinct slugs")
+
+	resp, err := client.GET("/api/v1/services/" + omitted)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	group := postForSlug(t, client, "/api/v1/groups", map[string]interface{}{"name": name})
+	t.Cleanup(func() { deleteGroup(t, client, group) })
+	assert.Regexp(t, pattern, group)
+}
+
+func TestCatalog_ExplicitSlugStillValidated(t *testing.T) {
+	client := newTestClientWithoutValidation()
+	client.LoginAsAdmin(t)
+
+	for _, path := range []string{"/api/v1/services", "/api/v1/groups"} {
+		resp, err := client.POST(path, map[string]interface{}{
+			"name": "Bad Slug",
+			"slug": "Bad Slug!",
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, path)
+	}
+}
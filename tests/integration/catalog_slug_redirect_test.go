@@ -0,0 +1,142 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// renameService changes the slug of a service.
+func renameService(t *testing.T, client *testutil.Client, slug, newSlug string) {
+	t.Helper()
+	resp, err := client.PATCH("/api/v1/services/"+slug, map[string]interface{}{
+		"name":   "Renamed " + newSlug,
+		"slug":   newSlug,
+		"status": "operational",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
+
+// getServiceNoRedirect requests a service without following redirects.
+func getServiceNoRedirect(t *testing.T, slug string) *http.Response {
+	t.Helper()
+	httpClient := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := httpClient.Get(testServer.URL + "/api/v1/services/" + slug)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestCatalog_SlugRedirect(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, oldSlug := createTestService(t, client, "redirect-old")
+	newSlug := testutil.RandomSlug("redirect-new")
+	t.Cleanup(func() { deleteService(t, client, newSlug) })
+
+	renameService(t, client, oldSlug, newSlug)
+
+	resp := getServiceNoRedirect(t, oldSlug)
+	assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+	assert.Equal(t, "/api/v1/services/"+newSlug, resp.Header.Get("Location"))
+
+	resp = getServiceNoRedirect(t, newSlug)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Clients following redirects land on the renamed service
+	public := newTestClient(t)
+	resp, err := public.GET("/api/v1/services/" + oldSlug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data struct {
+			ID   string `json:"id"`
+			Slug string `json:"slug"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, serviceID, result.Data.ID)
+	assert.Equal(t, newSlug, result.Data.Slug)
+}
+
+func TestCatalog_SlugRedirect_Chain(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, first := createTestService(t, client, "redirect-first")
+	second := testutil.RandomSlug("redirect-second")
+	third := testutil.RandomSlug("redirect-third")
+	t.Cleanup(func() { deleteService(t, client, third) })
+
+	renameService(t, client, first, second)
+	renameService(t, client, second, third)
+
+	// Every old slug redirects straight to the current one
+	for _, slug := range []string{first, second} {
+		resp := getServiceNoRedirect(t, slug)
+		assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode, slug)
+		assert.Equal(t, "/api/v1/services/"+third, resp.Header.Get("Location"), slug)
+	}
+}
+
+func TestCatalog_SlugRedirect_RenameBack(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, original := createTestService(t, client, "redirect-back")
+	temporary := testutil.RandomSlug("redirect-tmp")
+	t.Cleanup(func() { deleteService(t, client, original) })
+
+	renameService(t, client, original, temporary)
+	renameService(t, client, temporary, original)
+
+	resp := getServiceNoRedirect(t, original)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = getServiceNoRedirect(t, temporary)
+	assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+	assert.Equal(t, "/api/v1/services/"+original, resp.Header.Get("Location"))
+}
+
+func TestCatalog_SlugRedirect_UnknownSlug(t *testing.T) {
+	resp := getServiceNoRedirect(t, testutil.RandomSlug("never-existed"))
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestCatalog_SlugRedirect_OldSlugReused(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	renamedID, oldSlug := createTestService(t, client, "redirect-reused")
+	newSlug := testutil.RandomSlug("redirect-moved")
+	t.Cleanup(func() { deleteService(t, client, newSlug) })
+	renameService(t, client, oldSlug, newSlug)
+
+	// A new service may take the old slug; it wins over the redirect
+	reusedID, _ := createTestService(t, client, "redirect-reused", withSlug(oldSlug))
+	t.Cleanup(func() { deleteService(t, client, oldSlug) })
+	require.NotEqual(t, renamedID, reusedID)
+
+	resp, err := client.GET("/api/v1/services/" + oldSlug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, reusedID, result.Data.ID)
+}
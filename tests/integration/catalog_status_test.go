@@ -481,6 +481,71 @@ func TestStatusLog_ManualChange(t *testing.T) {
 	assert.True(t, foundManual, "should find manual status change entry")
 }
 
+type lastStatusChange struct {
+	OldStatus  *string `json:"old_status"`
+	NewStatus  string  `json:"new_status"`
+	SourceType string  `json:"source_type"`
+	Reason     string  `json:"reason"`
+	CreatedBy  string  `json:"created_by"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+// patchServiceStatus sets the status of a service and returns last_status_change from the response.
+func patchServiceStatus(t *testing.T, client *testutil.Client, slug, status, reason string) *lastStatusChange {
+	t.Helper()
+	resp, err := client.PATCH("/api/v1/services/"+slug, map[string]interface{}{
+		"name":   "Status Log Response",
+		"slug":   slug,
+		"status": status,
+		"reason": reason,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data struct {
+			Slug             string            `json:"slug"`
+			Status           string            `json:"status"`
+			LastStatusChange *lastStatusChange `json:"last_status_change"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, slug, result.Data.Slug)
+	assert.Equal(t, status, result.Data.Status)
+	return result.Data.LastStatusChange
+}
+
+func TestStatusLog_PatchResponseHasLastChange(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "Status Log Response")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	// No status change yet
+	assert.Nil(t, patchServiceStatus(t, client, slug, "operational", ""))
+
+	change := patchServiceStatus(t, client, slug, "partial_outage", "Disk full on db-1")
+	require.NotNil(t, change)
+	require.NotNil(t, change.OldStatus)
+	assert.Equal(t, "operational", *change.OldStatus)
+	assert.Equal(t, "partial_outage", change.NewStatus)
+	assert.Equal(t, "manual", change.SourceType)
+	assert.Equal(t, "Disk full on db-1", change.Reason)
+	assert.NotEmpty(t, change.CreatedBy)
+	assert.NotEmpty(t, change.CreatedAt)
+
+	// Unchanged status keeps the previous entry
+	same := patchServiceStatus(t, client, slug, "partial_outage", "Ignored reason")
+	require.NotNil(t, same)
+	assert.Equal(t, *change, *same)
+
+	next := patchServiceStatus(t, client, slug, "operational", "Disk cleaned up")
+	require.NotNil(t, next)
+	assert.Equal(t, "partial_outage", *next.OldStatus)
+	assert.Equal(t, "operational", next.NewStatus)
+	assert.Equal(t, "Disk cleaned up", next.Reason)
+}
+
 func TestStatusLog_EventChange(t *testing.T) {
 	client := newTestClient(t)
 	client.LoginAsAdmin(t)
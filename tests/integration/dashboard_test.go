@@ -0,0 +1,133 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dashboardSummary struct {
+	ActiveIncidentsCount        int `json:"active_incidents_count"`
+	ActiveMaintenancesCount     int `json:"active_maintenances_count"`
+	ServicesNotOperationalCount int `json:"services_not_operational_count"`
+	RecentEvents                []struct {
+		ID string `json:"id"`
+	} `json:"recent_events"`
+	UnverifiedChannelsCount int `json:"unverified_channels_count"`
+}
+
+func getDashboard(t *testing.T, client *testutil.Client) dashboardSummary {
+	t.Helper()
+	resp, err := client.GET("/api/v1/dashboard")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data dashboardSummary `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+func TestDashboard(t *testing.T) {
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	// A fresh operator, so that only the channels created here are theirs
+	operator := newTestOperator(t, admin)
+
+	before := getDashboard(t, operator)
+
+	serviceID, serviceSlug := createTestService(t, admin, "dashboard-outage")
+	t.Cleanup(func() { deleteService(t, admin, serviceSlug) })
+	_, degradedSlug := createTestService(t, admin, "dashboard-degraded", withStatus("degraded"))
+	t.Cleanup(func() { deleteService(t, admin, degradedSlug) })
+	_, operationalSlug := createTestService(t, admin, "dashboard-operational")
+	t.Cleanup(func() { deleteService(t, admin, operationalSlug) })
+
+	incident := createTestIncident(t, admin, "Dashboard incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "major_outage"}}, nil)
+	t.Cleanup(func() {
+		resolveEvent(t, admin, incident)
+		deleteEvent(t, admin, incident)
+	})
+
+	resolved := createTestIncident(t, admin, "Dashboard resolved incident", nil, nil)
+	resolveEvent(t, admin, resolved)
+	t.Cleanup(func() { deleteEvent(t, admin, resolved) })
+
+	maintenance := createTestMaintenance(t, admin, "Dashboard maintenance", nil)
+	t.Cleanup(func() {
+		completeMaintenance(t, admin, maintenance)
+		deleteEvent(t, admin, maintenance)
+	})
+
+	resp, err := admin.POST("/api/v1/events", map[string]interface{}{
+		"title":              "Dashboard scheduled maintenance",
+		"type":               "maintenance",
+		"status":             "scheduled",
+		"description":        "Not active yet",
+		"scheduled_start_at": "2099-01-01T00:00:00Z",
+		"scheduled_end_at":   "2099-01-01T04:00:00Z",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var scheduled struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &scheduled)
+	t.Cleanup(func() { deleteEvent(t, admin, scheduled.Data.ID) })
+
+	createEmailChannel(t, operator)
+	createEmailChannel(t, operator)
+
+	after := getDashboard(t, operator)
+
+	assert.Equal(t, 1, after.ActiveIncidentsCount-before.ActiveIncidentsCount, "resolved incidents are not active")
+	assert.Equal(t, 1, after.ActiveMaintenancesCount-before.ActiveMaintenancesCount, "scheduled maintenance is not active")
+	assert.Equal(t, 2, after.ServicesNotOperationalCount-before.ServicesNotOperationalCount,
+		"the stored degraded status and the incident's major outage count")
+	assert.Equal(t, 2, after.UnverifiedChannelsCount-before.UnverifiedChannelsCount)
+
+	require.Len(t, after.RecentEvents, 5)
+	recentIDs := make([]string, len(after.RecentEvents))
+	for i, e := range after.RecentEvents {
+		recentIDs[i] = e.ID
+	}
+	assert.Equal(t, []string{scheduled.Data.ID, maintenance, resolved, incident}, recentIDs[:4], "newest first")
+
+	// Unverified channels are counted for the caller only
+	assert.Equal(t, before.UnverifiedChannelsCount, getDashboard(t, newTestOperator(t, admin)).UnverifiedChannelsCount)
+}
+
+// newTestOperator creates an operator and returns a client logged in as them.
+func newTestOperator(t *testing.T, admin *testutil.Client) *testutil.Client {
+	t.Helper()
+	email := testutil.RandomEmail()
+	adminCreateTestUser(t, admin, email, "password123", "operator")
+	client := newTestClient(t)
+	client.LoginAs(t, email, "password123")
+	return client
+}
+
+func TestDashboard_RequiresOperator(t *testing.T) {
+	user := newTestClient(t)
+	user.LoginAsUser(t)
+
+	resp, err := user.GET("/api/v1/dashboard")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	anonymous := newTestClient(t)
+	resp, err = anonymous.GET("/api/v1/dashboard")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
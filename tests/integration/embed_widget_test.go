@@ -0,0 +1,138 @@
+//go:build integration
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type embedConfig struct {
+	Position         string `json:"position"`
+	OperationalColor string `json:"operational_color"`
+	IncidentColor    string `json:"incident_color"`
+	MaintenanceColor string `json:"maintenance_color"`
+	StatusPageURL    string `json:"status_page_url"`
+}
+
+// getWidget fetches the widget script.
+func getWidget(t *testing.T, client *testutil.Client) string {
+	t.Helper()
+	resp, err := client.GET("/api/v1/embed/widget.js")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/javascript; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "public, max-age=300", resp.Header.Get("Cache-Control"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Less(t, len(body), 200*1024, "widget must stay under 200 KB")
+	return string(body)
+}
+
+func getEmbedConfig(t *testing.T, client *testutil.Client) embedConfig {
+	t.Helper()
+	resp, err := client.GET("/api/v1/embed/config")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data embedConfig `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+// putEmbedConfig replaces the widget settings and returns the status code.
+func putEmbedConfig(t *testing.T, client *testutil.Client, cfg embedConfig) int {
+	t.Helper()
+	resp, err := client.PUT("/api/v1/embed/config", cfg)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestEmbed_Widget(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	original := getEmbedConfig(t, client)
+	t.Cleanup(func() { putEmbedConfig(t, client, original) })
+
+	require.Equal(t, http.StatusOK, putEmbedConfig(t, client, embedConfig{
+		Position:         "top-left",
+		OperationalColor: "#00aa00",
+		IncidentColor:    "#aa0000",
+		MaintenanceColor: "#0000aa",
+		StatusPageURL:    "https://status.example.com",
+	}))
+
+	anonymous := newTestClient(t)
+	js := getWidget(t, anonymous)
+	assert.Contains(t, js, `"position":"top-left"`)
+	assert.Contains(t, js, `"incident_color":"#aa0000"`)
+	assert.Contains(t, js, `"status_page_url":"https://status.example.com"`)
+	assert.Contains(t, js, "/api/v1/status")
+}
+
+func TestEmbed_CrossOriginData(t *testing.T) {
+	for _, path := range []string{"/api/v1/embed/widget.js", "/api/v1/status", "/api/v1/status/history"} {
+		t.Run(path, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, testServer.URL+path, nil)
+			require.NoError(t, err)
+			req.Header.Set("Origin", "https://customer.example.org")
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+			assert.Empty(t, resp.Header.Get("Access-Control-Allow-Credentials"))
+		})
+	}
+}
+
+func TestEmbed_Config(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	original := getEmbedConfig(t, client)
+	t.Cleanup(func() { putEmbedConfig(t, client, original) })
+
+	updated := embedConfig{
+		Position:         "bottom-left",
+		OperationalColor: "#112233",
+		IncidentColor:    "#445566",
+		MaintenanceColor: "#778899",
+	}
+	require.Equal(t, http.StatusOK, putEmbedConfig(t, client, updated))
+	assert.Equal(t, updated, getEmbedConfig(t, client))
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, cfg := range map[string]embedConfig{
+			"position":   {Position: "center", OperationalColor: "#112233", IncidentColor: "#445566", MaintenanceColor: "#778899"},
+			"color":      {Position: "bottom-left", OperationalColor: "green", IncidentColor: "#445566", MaintenanceColor: "#778899"},
+			"url scheme": {Position: "bottom-left", OperationalColor: "#112233", IncidentColor: "#445566", MaintenanceColor: "#778899", StatusPageURL: "javascript:alert(1)"},
+		} {
+			assert.Equal(t, http.StatusBadRequest, putEmbedConfig(t, client, cfg), name)
+		}
+		assert.Equal(t, updated, getEmbedConfig(t, client), "invalid settings are not stored")
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		operator := newTestClient(t)
+		operator.LoginAsOperator(t)
+		resp, err := operator.GET("/api/v1/embed/config")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Equal(t, http.StatusForbidden, putEmbedConfig(t, operator, updated))
+	})
+}
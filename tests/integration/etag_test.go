@@ -0,0 +1,96 @@
+//go:build integration
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conditionalGET requests path with an optional If-None-Match header and returns the response with its body read.
+func conditionalGET(t *testing.T, path, ifNoneMatch string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, testServer.URL+path, nil)
+	require.NoError(t, err)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestETag_Service(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "ETag Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+	path := "/api/v1/services/" + slug
+
+	resp, body := conditionalGET(t, path, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEmpty(t, body)
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+	_, err := time.Parse(http.TimeFormat, resp.Header.Get("Last-Modified"))
+	assert.NoError(t, err)
+
+	resp, body = conditionalGET(t, path, etag)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Empty(t, body)
+	assert.Equal(t, etag, resp.Header.Get("ETag"))
+
+	resp, _ = conditionalGET(t, path, `"stale"`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// An incident changes the effective status, so the old tag no longer matches
+	eventID := createTestIncident(t, client, "ETag Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	resp, body = conditionalGET(t, path, etag)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, `"effective_status":"degraded"`)
+	assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+
+	resolveEvent(t, client, eventID)
+}
+
+func TestETag_Event(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	eventID := createTestIncident(t, client, "ETag Event", nil, nil)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+	path := "/api/v1/events/" + eventID
+
+	resp, _ := conditionalGET(t, path, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.NotEmpty(t, resp.Header.Get("Last-Modified"))
+
+	resp, body := conditionalGET(t, path, etag)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Empty(t, body)
+
+	resolveEvent(t, client, eventID)
+
+	resp, _ = conditionalGET(t, path, etag)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "an update changes the tag")
+	assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+
+	// Errors carry no tag
+	resp, _ = conditionalGET(t, "/api/v1/events/00000000-0000-0000-0000-000000000000", "*")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("ETag"))
+}
@@ -0,0 +1,219 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bulkResult struct {
+	Index  int    `json:"index"`
+	Status int    `json:"status"`
+	Error  string `json:"error"`
+	Event  *struct {
+		ID         string     `json:"id"`
+		Title      string     `json:"title"`
+		Status     string     `json:"status"`
+		StartedAt  *time.Time `json:"started_at"`
+		ResolvedAt *time.Time `json:"resolved_at"`
+	} `json:"event"`
+}
+
+// postBulk posts events to /events/bulk and returns the status and, for 207, the results.
+func postBulk(t *testing.T, client *testutil.Client, items []map[string]interface{}) (int, []bulkResult) {
+	t.Helper()
+	resp, err := client.POST("/api/v1/events/bulk", items)
+	require.NoError(t, err)
+	if resp.StatusCode != http.StatusMultiStatus {
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	var result struct {
+		Data []bulkResult `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return resp.StatusCode, result.Data
+}
+
+// importedIncident is a resolved historical incident for a bulk import.
+func importedIncident(title, serviceID string, startedAt time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"title":             title,
+		"type":              "incident",
+		"status":            "resolved",
+		"severity":          "major",
+		"description":       "Imported from the old status page",
+		"started_at":        startedAt.Format(time.RFC3339),
+		"resolved_at":       startedAt.Add(90 * time.Minute).Format(time.RFC3339),
+		"affected_services": []map[string]string{{"service_id": serviceID, "status": "partial_outage"}},
+	}
+}
+
+// countEventsByTitle returns the number of events matching a full-text query.
+func countEventsByTitle(t *testing.T, client *testutil.Client, query string) int {
+	t.Helper()
+	resp, err := client.GET("/api/v1/events?q=" + url.QueryEscape(query))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data []struct{} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return len(result.Data)
+}
+
+func TestEvents_Bulk(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Bulk Import Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	startedAt := time.Date(2023, 5, 10, 8, 0, 0, 0, time.UTC)
+	maintenanceStart := time.Date(2023, 6, 1, 22, 0, 0, 0, time.UTC)
+
+	status, results := postBulk(t, client, []map[string]interface{}{
+		importedIncident("Bulkimport database failover", serviceID, startedAt),
+		{
+			"title":              "Bulkimport network upgrade",
+			"type":               "maintenance",
+			"status":             "completed",
+			"description":        "Imported maintenance",
+			"started_at":         maintenanceStart.Format(time.RFC3339),
+			"resolved_at":        maintenanceStart.Add(2 * time.Hour).Format(time.RFC3339),
+			"scheduled_start_at": maintenanceStart.Format(time.RFC3339),
+			"scheduled_end_at":   maintenanceStart.Add(2 * time.Hour).Format(time.RFC3339),
+			"affected_services":  []map[string]string{{"service_id": serviceID, "status": "maintenance"}},
+		},
+	})
+	require.Equal(t, http.StatusMultiStatus, status)
+	require.Len(t, results, 2)
+	for i, res := range results {
+		assert.Equal(t, i, res.Index)
+		assert.Equal(t, http.StatusCreated, res.Status, res.Error)
+		require.NotNil(t, res.Event)
+		eventID := res.Event.ID
+		t.Cleanup(func() { deleteEvent(t, client, eventID) })
+	}
+
+	incident := results[0].Event
+	assert.Equal(t, "resolved", incident.Status)
+	require.NotNil(t, incident.StartedAt)
+	assert.True(t, startedAt.Equal(*incident.StartedAt), "started_at kept")
+	require.NotNil(t, incident.ResolvedAt)
+	assert.True(t, startedAt.Add(90*time.Minute).Equal(*incident.ResolvedAt), "resolved_at kept")
+
+	assert.Equal(t, "completed", results[1].Event.Status)
+	assert.Equal(t, "operational", getServiceEffectiveStatus(t, client, slug), "history doesn't change the current status")
+}
+
+func TestEvents_Bulk_HistoryKeepsUptime(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Bulk Uptime Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	before := getServiceUptime(t, client, slug, "7d")
+
+	// A past outage inside the uptime window
+	startedAt := time.Now().UTC().Add(-48 * time.Hour).Truncate(time.Second)
+	status, results := postBulk(t, client, []map[string]interface{}{
+		importedIncident("Bulkimport uptime outage", serviceID, startedAt),
+	})
+	require.Equal(t, http.StatusMultiStatus, status)
+	require.Len(t, results, 1)
+	require.NotNil(t, results[0].Event, results[0].Error)
+	eventID := results[0].Event.ID
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	var logEntries int
+	err := testDB.QueryRow(context.Background(),
+		"SELECT COUNT(*) FROM service_status_log WHERE service_id = $1 AND event_id = $2", serviceID, eventID).Scan(&logEntries)
+	require.NoError(t, err)
+	assert.Zero(t, logEntries, "history writes no status log entries")
+
+	after := getServiceUptime(t, client, slug, "7d")
+	assert.Equal(t, before.Data.UptimePercent, after.Data.UptimePercent)
+	assert.Equal(t, before.Data.DowntimeSeconds, after.Data.DowntimeSeconds)
+	assert.Equal(t, "operational", getServiceEffectiveStatus(t, client, slug))
+}
+
+func TestEvents_Bulk_ValidationFailureRejectsBatch(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Bulk Reject Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	startedAt := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("request validation", func(t *testing.T) {
+		invalid := importedIncident("", serviceID, startedAt)
+		status, results := postBulk(t, client, []map[string]interface{}{
+			importedIncident("Bulkreject first", serviceID, startedAt),
+			invalid,
+		})
+		require.Equal(t, http.StatusMultiStatus, status)
+		require.Len(t, results, 2)
+		assert.Equal(t, http.StatusFailedDependency, results[0].Status)
+		assert.Nil(t, results[0].Event)
+		assert.Equal(t, http.StatusBadRequest, results[1].Status)
+		assert.Contains(t, results[1].Error, "Title")
+	})
+
+	t.Run("service validation", func(t *testing.T) {
+		unresolved := importedIncident("Bulkreject second", serviceID, startedAt)
+		delete(unresolved, "resolved_at")
+		unknownService := importedIncident("Bulkreject third", "00000000-0000-0000-0000-000000000000", startedAt)
+
+		status, results := postBulk(t, client, []map[string]interface{}{
+			importedIncident("Bulkreject first", serviceID, startedAt),
+			unresolved,
+			unknownService,
+		})
+		require.Equal(t, http.StatusMultiStatus, status)
+		require.Len(t, results, 3)
+		assert.Equal(t, http.StatusFailedDependency, results[0].Status)
+		assert.Equal(t, http.StatusBadRequest, results[1].Status)
+		assert.Contains(t, results[1].Error, "resolved_at")
+		assert.Equal(t, http.StatusBadRequest, results[2].Status)
+		assert.Contains(t, results[2].Error, "affected service not found")
+	})
+
+	assert.Zero(t, countEventsByTitle(t, client, "Bulkreject"), "nothing of a rejected batch is stored")
+}
+
+func TestEvents_Bulk_Limits(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Bulk Limit Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	items := make([]map[string]interface{}, 101)
+	for i := range items {
+		items[i] = importedIncident("Bulklimit incident", serviceID, time.Date(2023, 8, 1, 0, i, 0, 0, time.UTC))
+	}
+
+	status, _ := postBulk(t, client, items)
+	assert.Equal(t, http.StatusBadRequest, status, "over 100 events")
+	status, _ = postBulk(t, client, []map[string]interface{}{})
+	assert.Equal(t, http.StatusBadRequest, status, "empty batch")
+	assert.Zero(t, countEventsByTitle(t, client, "Bulklimit"))
+
+	operator := newTestClient(t)
+	operator.LoginAsOperator(t)
+	status, _ = postBulk(t, operator, items[:1])
+	assert.Equal(t, http.StatusForbidden, status, "admin only")
+}
@@ -414,14 +414,16 @@ func TestAddUpdate_CannotUpdateResolvedEvent(t *testing.T) {
 	testutil.DecodeJSON(t, resp, &resolveResult)
 	assert.NotEmpty(t, resolveResult.Data.ID)
 
-	// Try to update resolved event - should fail with 409
-	resp, err = client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
-		"status":  "investigating",
-		"message": "Reopening...",
-	})
-	require.NoError(t, err)
-	assert.Equal(t, http.StatusConflict, resp.StatusCode)
-	resp.Body.Close()
+	// Resolved is terminal: any update is an invalid transition (422)
+	for _, status := range []string{"investigating", "identified", "monitoring", "resolved"} {
+		resp, err = client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+			"status":  status,
+			"message": "Reopening...",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, status)
+		resp.Body.Close()
+	}
 
 	// Cleanup
 	client.LoginAsAdmin(t)
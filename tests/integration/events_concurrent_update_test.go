@@ -0,0 +1,61 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventUpdate_ConcurrentTransitions(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "concurrent-update-service")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	for i := 0; i < 5; i++ {
+		eventID := createTestIncident(t, client, "Concurrently updated incident",
+			[]AffectedService{{ServiceID: serviceID, Status: "major_outage"}}, nil)
+		t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+		// resolved -> monitoring is not a valid transition, so whichever update wins
+		// the other one must either follow it legally or be rejected
+		statuses := []string{"resolved", "monitoring"}
+		codes := make([]int, len(statuses))
+		var wg sync.WaitGroup
+		for j, status := range statuses {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp, err := client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+					"status":  status,
+					"message": "Concurrent " + status,
+				})
+				if err != nil {
+					t.Errorf("post %s update: %v", status, err)
+					return
+				}
+				codes[j] = resp.StatusCode
+				resp.Body.Close()
+			}()
+		}
+		wg.Wait()
+
+		event := getEvent(t, client, eventID)
+		assert.Equal(t, http.StatusCreated, codes[0], "resolving is valid from any active status")
+		// monitoring either went first or was rejected after the resolution
+		assert.Contains(t, []int{http.StatusCreated, http.StatusUnprocessableEntity}, codes[1])
+		assert.Equal(t, domain.EventStatusResolved, event.Status)
+		assert.NotNil(t, event.ResolvedAt)
+		assert.Equal(t, 0, countRows(t, `
+			SELECT COUNT(*) FROM event_updates u
+			JOIN event_updates r ON r.event_id = u.event_id AND r.status = 'resolved'
+			WHERE u.event_id = $1 AND u.status = 'monitoring' AND u.created_at > r.created_at`, eventID),
+			"no update follows the resolution")
+	}
+}
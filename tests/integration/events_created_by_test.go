@@ -0,0 +1,97 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvents_CreatedByName(t *testing.T) {
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, admin, "Created By Service")
+	t.Cleanup(func() { deleteService(t, admin, slug) })
+
+	email := testutil.RandomEmail()
+	operatorID := adminCreateTestUser(t, admin, email, "password1234", "operator")
+	operator := newTestClient(t)
+	operator.LoginAs(t, email, "password1234")
+
+	resp, err := operator.PATCH("/api/v1/me", map[string]interface{}{"name": "Fox Mulder"})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	eventID := createTestIncident(t, operator, "Created by incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	t.Cleanup(func() { deleteEvent(t, admin, eventID) })
+	addEventUpdate(t, operator, eventID, "identified", "Root cause found")
+
+	public := newTestClient(t)
+
+	t.Run("single event", func(t *testing.T) {
+		resp, err := public.GET("/api/v1/events/" + eventID)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result struct {
+			Data struct {
+				CreatedBy     string `json:"created_by"`
+				CreatedByName string `json:"created_by_name"`
+			} `json:"data"`
+		}
+		testutil.DecodeJSON(t, resp, &result)
+		assert.Equal(t, operatorID, result.Data.CreatedBy)
+		assert.Equal(t, "Fox Mulder", result.Data.CreatedByName)
+	})
+
+	t.Run("event list", func(t *testing.T) {
+		resp, err := public.GET("/api/v1/events?limit=100")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result struct {
+			Data []struct {
+				ID            string `json:"id"`
+				CreatedByName string `json:"created_by_name"`
+			} `json:"data"`
+		}
+		testutil.DecodeJSON(t, resp, &result)
+
+		var found bool
+		for _, event := range result.Data {
+			if event.ID == eventID {
+				found = true
+				assert.Equal(t, "Fox Mulder", event.CreatedByName)
+			}
+		}
+		assert.True(t, found, "event should be listed")
+	})
+
+	t.Run("event updates", func(t *testing.T) {
+		resp, err := public.GET("/api/v1/events/" + eventID + "/updates")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result struct {
+			Data struct {
+				Updates []struct {
+					CreatedBy     string `json:"created_by"`
+					CreatedByName string `json:"created_by_name"`
+				} `json:"updates"`
+			} `json:"data"`
+		}
+		testutil.DecodeJSON(t, resp, &result)
+		require.NotEmpty(t, result.Data.Updates)
+		for _, update := range result.Data.Updates {
+			assert.Equal(t, operatorID, update.CreatedBy)
+			assert.Equal(t, "Fox Mulder", update.CreatedByName)
+		}
+	})
+}
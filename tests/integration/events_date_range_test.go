@@ -0,0 +1,107 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dateRangeQuery builds a GET /events query for events matching search started within [from, to].
+func dateRangeQuery(search string, from, to time.Time) string {
+	return url.Values{
+		"q":    {search},
+		"from": {from.Format(time.RFC3339)},
+		"to":   {to.Format(time.RFC3339)},
+	}.Encode()
+}
+
+func TestEvents_DateRange(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	search := testutil.RandomSlug("date-range")
+	base := time.Now().Add(-30 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	created := make(map[string]string)
+	for _, tc := range []struct {
+		name   string
+		offset time.Duration
+	}{
+		{"before", -time.Second},
+		{"on-from", 0},
+		{"inside", time.Hour},
+		{"on-to", 2 * time.Hour},
+		{"after", 2*time.Hour + time.Second},
+	} {
+		startedAt := base.Add(tc.offset)
+		created[tc.name] = createTestIncident(t, client, search+" "+tc.name, nil, nil,
+			func(m map[string]interface{}) { m["started_at"] = startedAt.Format(time.RFC3339) })
+	}
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		for _, id := range created {
+			resolveEvent(t, client, id)
+			deleteEvent(t, client, id)
+		}
+	})
+
+	page := getEventsPage(t, client, dateRangeQuery(search, base, base.Add(2*time.Hour)))
+
+	ids := make([]string, 0, len(page.Events))
+	for _, event := range page.Events {
+		ids = append(ids, event.ID)
+	}
+	assert.ElementsMatch(t, []string{created["on-from"], created["inside"], created["on-to"]}, ids,
+		"both bounds are inclusive")
+	assert.Equal(t, 3, page.Total)
+
+	t.Run("single bound", func(t *testing.T) {
+		query := url.Values{"q": {search}, "from": {base.Add(time.Hour).Format(time.RFC3339)}}.Encode()
+		page := getEventsPage(t, client, query)
+		assert.Equal(t, 3, page.Total, "inside, on-to and after")
+
+		query = url.Values{"q": {search}, "to": {base.Add(time.Hour).Format(time.RFC3339)}}.Encode()
+		page = getEventsPage(t, client, query)
+		assert.Equal(t, 3, page.Total, "before, on-from and inside")
+	})
+
+	t.Run("single bound is capped to a year", func(t *testing.T) {
+		query := url.Values{"q": {search}, "to": {base.Add(time.Hour).AddDate(1, 0, 0).Format(time.RFC3339)}}.Encode()
+		page := getEventsPage(t, client, query)
+		assert.Equal(t, 3, page.Total, "from is a year before to: inside, on-to and after")
+	})
+}
+
+func TestEvents_DateRange_Validation(t *testing.T) {
+	client := newTestClient(t)
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{"from after to", dateRangeQuery("x", base, base.Add(-time.Hour)), http.StatusBadRequest},
+		{"from equals to", dateRangeQuery("x", base, base), http.StatusBadRequest},
+		{"longer than a year", dateRangeQuery("x", base, base.AddDate(1, 0, 0).Add(time.Second)), http.StatusBadRequest},
+		{"exactly a year", dateRangeQuery("x", base, base.AddDate(1, 0, 0)), http.StatusOK},
+		{"invalid from", "from=2026-03-10", http.StatusBadRequest},
+		{"invalid to", "to=yesterday", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.GET("/api/v1/events?" + tt.query)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, tt.want, resp.StatusCode)
+		})
+	}
+}
@@ -244,10 +244,12 @@ func TestDeleteEvent_CascadeDeletesEventUpdates(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 
 	var updatesResult struct {
-		Data []interface{} `json:"data"`
+		Data struct {
+			Updates []interface{} `json:"updates"`
+		} `json:"data"`
 	}
 	testutil.DecodeJSON(t, resp, &updatesResult)
-	require.GreaterOrEqual(t, len(updatesResult.Data), 3, "should have at least 3 updates")
+	require.GreaterOrEqual(t, len(updatesResult.Data.Updates), 3, "should have at least 3 updates")
 
 	// Delete event
 	client.LoginAsAdmin(t)
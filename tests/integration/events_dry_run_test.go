@@ -0,0 +1,128 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEvent_DryRun(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	groupID, groupSlug := createTestGroup(t, client, "dry-run-group")
+	t.Cleanup(func() { deleteGroup(t, client, groupSlug) })
+	memberID, memberSlug := createTestService(t, client, "dry-run-member", withGroupIDs([]string{groupID}))
+	t.Cleanup(func() { deleteService(t, client, memberSlug) })
+	serviceID, serviceSlug := createTestService(t, client, "dry-run-service")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	title := "Dry run incident " + randomSuffix()
+	client.LoginAsOperator(t)
+	resp, err := client.POST("/api/v1/events?dry_run=true", map[string]interface{}{
+		"title":              title,
+		"type":               "incident",
+		"status":             "investigating",
+		"severity":           "major",
+		"description":        "Checking the payload",
+		"notify_subscribers": true,
+		"affected_services":  []map[string]string{{"service_id": serviceID, "status": "major_outage"}},
+		"affected_groups":    []map[string]string{{"group_id": groupID, "status": "degraded"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			ID          string   `json:"id"`
+			Title       string   `json:"title"`
+			ServiceIDs  []string `json:"service_ids"`
+			GroupIDs    []string `json:"group_ids"`
+			DryRun      bool     `json:"dry_run"`
+			WouldNotify int      `json:"would_notify"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+
+	assert.True(t, result.Data.DryRun)
+	assert.Equal(t, title, result.Data.Title)
+	assert.ElementsMatch(t, []string{serviceID, memberID}, result.Data.ServiceIDs, "groups are expanded")
+	assert.Equal(t, []string{groupID}, result.Data.GroupIDs)
+	// Notifications are disabled in the test app, so nothing would be notified
+	assert.Zero(t, result.Data.WouldNotify)
+
+	// Nothing is stored
+	var n int
+	require.NoError(t, testDB.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM events WHERE title = $1`, title).Scan(&n))
+	assert.Zero(t, n)
+	assert.Zero(t, countRows(t, `SELECT COUNT(*) FROM service_status_log WHERE service_id = $1`, serviceID))
+
+	resp, err = client.GET("/api/v1/events/" + result.Data.ID)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	for _, slug := range []string{serviceSlug, memberSlug} {
+		resp, err = client.GET("/api/v1/services/" + slug)
+		require.NoError(t, err)
+		var service struct {
+			Data struct {
+				EffectiveStatus string `json:"effective_status"`
+				HasActiveEvents bool   `json:"has_active_events"`
+			} `json:"data"`
+		}
+		testutil.DecodeJSON(t, resp, &service)
+		assert.Equal(t, "operational", service.Data.EffectiveStatus, slug)
+		assert.False(t, service.Data.HasActiveEvents, slug)
+	}
+}
+
+func TestCreateEvent_DryRunValidation(t *testing.T) {
+	client := newTestClientWithoutValidation()
+	client.LoginAsOperator(t)
+
+	tests := []struct {
+		name    string
+		query   string
+		payload map[string]interface{}
+	}{
+		{
+			name:  "incident without severity",
+			query: "?dry_run=true",
+			payload: map[string]interface{}{
+				"title": "Dry run without severity", "type": "incident", "status": "investigating",
+			},
+		},
+		{
+			name:  "unknown affected service",
+			query: "?dry_run=true",
+			payload: map[string]interface{}{
+				"title": "Dry run unknown service", "type": "incident", "status": "investigating", "severity": "minor",
+				"affected_services": []map[string]string{{"service_id": "00000000-0000-0000-0000-000000000000", "status": "degraded"}},
+			},
+		},
+		{
+			name:  "invalid dry_run",
+			query: "?dry_run=maybe",
+			payload: map[string]interface{}{
+				"title": "Dry run invalid flag", "type": "incident", "status": "investigating", "severity": "minor",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.POST("/api/v1/events"+tt.query, tt.payload)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
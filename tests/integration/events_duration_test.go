@@ -0,0 +1,90 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type eventDurationResponse struct {
+	Data struct {
+		ID              string `json:"id"`
+		Status          string `json:"status"`
+		DurationSeconds *int64 `json:"duration_seconds"`
+	} `json:"data"`
+}
+
+func getEventDuration(t *testing.T, client *testutil.Client, eventID string) *int64 {
+	t.Helper()
+	resp, err := client.GET("/api/v1/events/" + eventID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result eventDurationResponse
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data.DurationSeconds
+}
+
+func TestEventDuration_ResolvedIncident(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	startedAt := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+	eventID := createTestIncident(t, client, "Duration Resolved Incident", nil, nil,
+		func(m map[string]interface{}) { m["started_at"] = startedAt })
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		deleteEvent(t, client, eventID)
+	})
+
+	// Active incident counts until now
+	active := getEventDuration(t, client, eventID)
+	require.NotNil(t, active)
+	assert.GreaterOrEqual(t, *active, int64(2*60*60-60))
+
+	resolveEvent(t, client, eventID)
+
+	resolved := getEventDuration(t, client, eventID)
+	require.NotNil(t, resolved)
+	assert.Positive(t, *resolved)
+	assert.GreaterOrEqual(t, *resolved, int64(2*60*60-60))
+
+	// Resolved duration is fixed
+	time.Sleep(1100 * time.Millisecond)
+	assert.Equal(t, *resolved, *getEventDuration(t, client, eventID))
+
+}
+
+func TestEventDuration_ScheduledMaintenanceIsNull(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	resp, err := client.POST("/api/v1/events", map[string]interface{}{
+		"title":              "Duration Scheduled Maintenance",
+		"type":               "maintenance",
+		"status":             "scheduled",
+		"description":        "Planned work",
+		"scheduled_start_at": "2099-01-01T00:00:00Z",
+		"scheduled_end_at":   "2099-01-01T04:00:00Z",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var created eventDurationResponse
+	testutil.DecodeJSON(t, resp, &created)
+	eventID := created.Data.ID
+	t.Cleanup(func() {
+		completeMaintenance(t, client, eventID)
+		client.LoginAsAdmin(t)
+		deleteEvent(t, client, eventID)
+	})
+
+	assert.Nil(t, created.Data.DurationSeconds)
+	assert.Nil(t, getEventDuration(t, client, eventID))
+}
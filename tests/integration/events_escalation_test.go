@@ -0,0 +1,122 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	catalogpostgres "github.com/bissquit/incident-garden/internal/catalog/postgres"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	eventspostgres "github.com/bissquit/incident-garden/internal/events/postgres"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getEventSeverity(t *testing.T, client *testutil.Client, eventID string) string {
+	t.Helper()
+	resp, err := client.GET("/api/v1/events/" + eventID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			Severity string `json:"severity"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data.Severity
+}
+
+// backdateEvent moves the creation of an event into the past.
+func backdateEvent(t *testing.T, eventID string, age time.Duration) {
+	t.Helper()
+	_, err := testDB.Exec(context.Background(),
+		`UPDATE events SET created_at = NOW() - make_interval(secs => $2) WHERE id = $1`,
+		eventID, age.Seconds())
+	require.NoError(t, err)
+}
+
+func TestEscalation_RaisesSeverity(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	staleID := createTestIncident(t, client, "Escalation Stale Incident", nil, nil)
+	freshID := createTestIncident(t, client, "Escalation Fresh Incident", nil, nil)
+	monitoringID := createTestIncident(t, client, "Escalation Monitoring Incident", nil, nil,
+		func(m map[string]interface{}) { m["status"] = "monitoring" })
+	for _, id := range []string{staleID, freshID, monitoringID} {
+		id := id
+		t.Cleanup(func() {
+			client.LoginAsAdmin(t)
+			resolveEvent(t, client, id)
+			deleteEvent(t, client, id)
+		})
+	}
+	backdateEvent(t, staleID, 31*time.Minute)
+	backdateEvent(t, monitoringID, 31*time.Minute)
+
+	catalogService := catalog.NewService(catalogpostgres.NewRepository(testDB), nil)
+	eventsRepo := eventspostgres.NewRepository(testDB)
+	eventsService := events.NewService(eventsRepo, catalogService, catalogService, nil, nil, nil)
+	checker := events.NewEscalationChecker(events.EscalationConfig{
+		Thresholds: map[domain.Severity]time.Duration{
+			domain.SeverityMinor: 30 * time.Minute,
+			domain.SeverityMajor: 15 * time.Minute,
+		},
+		PollInterval: 100 * time.Millisecond,
+	}, eventsRepo, eventsService)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	checker.Start(ctx)
+	defer func() {
+		cancel()
+		checker.Stop()
+	}()
+
+	require.Eventually(t, func() bool {
+		return getEventSeverity(t, client, staleID) == "major"
+	}, 5*time.Second, 100*time.Millisecond, "stale minor incident should become major")
+
+	resp, err := client.GET("/api/v1/events/" + staleID + "/updates")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var updates struct {
+		Data struct {
+			Updates []struct {
+				Status  string                 `json:"status"`
+				Message string                 `json:"message"`
+				Changes map[string]fieldChange `json:"changes"`
+			} `json:"updates"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &updates)
+	require.Len(t, updates.Data.Updates, 1, "one system update per escalation")
+	update := updates.Data.Updates[0]
+	assert.Equal(t, "investigating", update.Status)
+	assert.Contains(t, update.Message, "automatically escalated from minor to major")
+	require.Contains(t, update.Changes, "severity")
+	require.NotNil(t, update.Changes["severity"].From)
+	assert.Equal(t, "minor", *update.Changes["severity"].From)
+	assert.Equal(t, "major", update.Changes["severity"].To)
+
+	// Several more polls: the major threshold counts from the escalation, not from creation
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, "major", getEventSeverity(t, client, staleID))
+	assert.Equal(t, "minor", getEventSeverity(t, client, freshID), "below threshold")
+	assert.Equal(t, "minor", getEventSeverity(t, client, monitoringID), "monitoring is not escalated")
+
+	_, err = testDB.Exec(context.Background(),
+		`UPDATE event_updates SET created_at = NOW() - interval '16 minutes' WHERE event_id = $1`, staleID)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return getEventSeverity(t, client, staleID) == "critical"
+	}, 5*time.Second, 100*time.Millisecond, "major incident should become critical after its threshold")
+}
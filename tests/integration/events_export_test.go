@@ -0,0 +1,120 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvents_Export_CSV(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "Export Svc")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	resolvedID := createTestIncident(t, client, "Export Resolved Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil, withSeverity("critical"))
+	resolveEvent(t, client, resolvedID)
+	t.Cleanup(func() { deleteEvent(t, client, resolvedID) })
+
+	maintenanceID := createTestMaintenance(t, client, "Export Maintenance", nil)
+	t.Cleanup(func() {
+		completeMaintenance(t, client, maintenanceID)
+		deleteEvent(t, client, maintenanceID)
+	})
+
+	today := time.Now().UTC().Format("2006-01-02")
+	resp, err := client.GET("/api/v1/events/export?format=csv&from=" + today + "&to=" + today)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="events.csv"`, resp.Header.Get("Content-Disposition"))
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err, "export must be valid CSV")
+	require.NotEmpty(t, records)
+
+	assert.Equal(t, []string{
+		"id", "title", "type", "status", "severity",
+		"started_at", "resolved_at", "duration_seconds", "affected_services",
+	}, records[0])
+
+	var expected int
+	err = testDB.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM events WHERE created_at >= $1::date AND created_at < $1::date + 1`, today).Scan(&expected)
+	require.NoError(t, err)
+	assert.Len(t, records[1:], expected, "one row per event created in the range")
+
+	rows := make(map[string][]string, len(records)-1)
+	for _, record := range records[1:] {
+		require.Len(t, record, 9)
+		rows[record[0]] = record
+	}
+
+	require.Contains(t, rows, resolvedID)
+	resolved := rows[resolvedID]
+	assert.Equal(t, "Export Resolved Incident", resolved[1])
+	assert.Equal(t, "incident", resolved[2])
+	assert.Equal(t, "resolved", resolved[3])
+	assert.Equal(t, "critical", resolved[4])
+	assert.NotEmpty(t, resolved[6], "resolved_at")
+	assert.NotEmpty(t, resolved[7], "duration_seconds")
+	assert.Equal(t, serviceSlug, resolved[8])
+
+	require.Contains(t, rows, maintenanceID)
+	assert.Equal(t, "maintenance", rows[maintenanceID][2])
+	assert.Empty(t, rows[maintenanceID][4], "maintenance has no severity")
+	assert.Empty(t, rows[maintenanceID][8])
+}
+
+func TestEvents_Export_EmptyRange(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	resp, err := client.GET("/api/v1/events/export?from=2000-01-01&to=2000-01-31")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, records, 1, "header only")
+}
+
+func TestEvents_Export_Errors(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	for _, query := range []string{
+		"format=json",
+		"from=2024-13-01",
+		"to=yesterday",
+		"from=2024-02-01&to=2024-01-01",
+	} {
+		t.Run(query, func(t *testing.T) {
+			resp, err := client.GET("/api/v1/events/export?" + query)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+
+	t.Run("operator is forbidden", func(t *testing.T) {
+		operator := newTestClient(t)
+		operator.LoginAsOperator(t)
+
+		resp, err := operator.GET("/api/v1/events/export")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
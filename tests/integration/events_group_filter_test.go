@@ -0,0 +1,70 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvents_GroupFilter(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	groupID, groupSlug := createTestGroup(t, client, "Group Filter Group")
+	t.Cleanup(func() { deleteGroup(t, client, groupSlug) })
+	otherGroupID, otherGroupSlug := createTestGroup(t, client, "Group Filter Other Group")
+	t.Cleanup(func() { deleteGroup(t, client, otherGroupSlug) })
+
+	memberID, memberSlug := createTestService(t, client, "Group Filter Member", withGroupIDs([]string{groupID}))
+	t.Cleanup(func() { deleteService(t, client, memberSlug) })
+	outsiderID, outsiderSlug := createTestService(t, client, "Group Filter Outsider", withGroupIDs([]string{otherGroupID}))
+	t.Cleanup(func() { deleteService(t, client, outsiderSlug) })
+
+	memberEventID := createTestIncident(t, client, "Group Filter Member Incident",
+		[]AffectedService{{ServiceID: memberID, Status: "degraded"}}, nil)
+	outsiderEventID := createTestIncident(t, client, "Group Filter Outsider Incident",
+		[]AffectedService{{ServiceID: outsiderID, Status: "degraded"}}, nil)
+	bothEventID := createTestIncident(t, client, "Group Filter Both Incident",
+		[]AffectedService{{ServiceID: memberID, Status: "degraded"}, {ServiceID: outsiderID, Status: "degraded"}}, nil)
+	t.Cleanup(func() {
+		for _, id := range []string{memberEventID, outsiderEventID, bothEventID} {
+			resolveEvent(t, client, id)
+			deleteEvent(t, client, id)
+		}
+	})
+
+	publicClient := newTestClient(t)
+
+	t.Run("events affecting group members", func(t *testing.T) {
+		page := getEventsPage(t, publicClient, "group_id="+groupID)
+		assert.ElementsMatch(t, []string{memberEventID, bothEventID}, eventIDs(page.Events))
+		assert.Equal(t, 2, page.Total)
+	})
+
+	t.Run("other group", func(t *testing.T) {
+		page := getEventsPage(t, publicClient, "group_id="+otherGroupID)
+		assert.ElementsMatch(t, []string{outsiderEventID, bothEventID}, eventIDs(page.Events))
+	})
+
+	t.Run("combined with other filters", func(t *testing.T) {
+		page := getEventsPage(t, publicClient, "type=maintenance&group_id="+groupID)
+		assert.Empty(t, page.Events)
+		assert.Equal(t, 0, page.Total)
+	})
+
+	t.Run("unknown group", func(t *testing.T) {
+		page := getEventsPage(t, publicClient, "group_id=00000000-0000-0000-0000-000000000000")
+		assert.Empty(t, page.Events)
+	})
+
+	t.Run("invalid uuid", func(t *testing.T) {
+		resp, err := publicClient.GET("/api/v1/events?group_id=not-a-uuid")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
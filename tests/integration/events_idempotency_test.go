@@ -0,0 +1,89 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postIdempotentUpdate adds an update to an event with the given Idempotency-Key
+// and returns the response with its body read.
+func postIdempotentUpdate(t *testing.T, client *testutil.Client, eventID, key, message string) (*http.Response, string) {
+	t.Helper()
+	resp, err := client.POSTWithHeaders("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+		"status":  "identified",
+		"message": message,
+	}, map[string]string{"Idempotency-Key": key})
+	require.NoError(t, err)
+	return resp, testutil.ReadBody(t, resp)
+}
+
+func countEventUpdates(t *testing.T, eventID string) int {
+	t.Helper()
+	var count int
+	err := testDB.QueryRow(context.Background(),
+		"SELECT COUNT(*) FROM event_updates WHERE event_id = $1", eventID).Scan(&count)
+	require.NoError(t, err)
+	return count
+}
+
+func TestEvents_IdempotencyKey(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Idempotency Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+	eventID := createTestIncident(t, client, "Idempotency Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	key := uuid.NewString()
+	before := countEventUpdates(t, eventID)
+
+	t.Run("first call creates the update", func(t *testing.T) {
+		resp, body := postIdempotentUpdate(t, client, eventID, key, "Root cause found")
+		require.Equal(t, http.StatusCreated, resp.StatusCode, body)
+		assert.Empty(t, resp.Header.Get("Idempotent-Replayed"))
+		assert.Equal(t, before+1, countEventUpdates(t, eventID))
+
+		t.Run("retry with same key replays the response", func(t *testing.T) {
+			retry, retryBody := postIdempotentUpdate(t, client, eventID, key, "Root cause found")
+			require.Equal(t, http.StatusOK, retry.StatusCode, retryBody)
+			assert.Equal(t, "true", retry.Header.Get("Idempotent-Replayed"))
+			assert.JSONEq(t, body, retryBody)
+			assert.Equal(t, before+1, countEventUpdates(t, eventID))
+		})
+
+		t.Run("expired key creates a new update", func(t *testing.T) {
+			_, err := testDB.Exec(context.Background(),
+				"UPDATE idempotency_keys SET expires_at = NOW() - INTERVAL '1 hour' WHERE idempotency_key = $1", key)
+			require.NoError(t, err)
+
+			fresh, freshBody := postIdempotentUpdate(t, client, eventID, key, "Root cause found")
+			require.Equal(t, http.StatusCreated, fresh.StatusCode, freshBody)
+			assert.NotEqual(t, body, freshBody)
+			assert.Equal(t, before+2, countEventUpdates(t, eventID))
+		})
+	})
+
+	t.Run("same key on another event is rejected", func(t *testing.T) {
+		otherID := createTestIncident(t, client, "Other Idempotency Incident",
+			[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+		t.Cleanup(func() { deleteEvent(t, client, otherID) })
+
+		resp, body := postIdempotentUpdate(t, client, otherID, key, "Root cause found")
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, body)
+	})
+
+	t.Run("invalid key is rejected", func(t *testing.T) {
+		resp, body := postIdempotentUpdate(t, client, eventID, "not-a-uuid", "Root cause found")
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, body)
+	})
+}
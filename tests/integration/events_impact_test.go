@@ -0,0 +1,132 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withImpact sets the impact statement of an incident.
+func withImpact(impact string) incidentOption {
+	return func(m map[string]interface{}) {
+		m["impact"] = impact
+	}
+}
+
+func getEventImpact(t *testing.T, client *testutil.Client, eventID string) string {
+	t.Helper()
+	resp, err := client.GET("/api/v1/events/" + eventID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data struct {
+			Impact string `json:"impact"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data.Impact
+}
+
+func addImpactUpdate(t *testing.T, client *testutil.Client, eventID, impact string) domain.EventUpdate {
+	t.Helper()
+	resp, err := client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+		"status":  "identified",
+		"message": "Impact revised",
+		"impact":  impact,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var result struct {
+		Data domain.EventUpdate `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+func TestEvent_Impact_RoundTrip(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	const impact = "Customers in EU cannot check out"
+	eventID := createTestIncident(t, client, "Impact incident", nil, nil, withImpact(impact))
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		deleteEvent(t, client, eventID)
+	})
+
+	public := newTestClient(t)
+	assert.Equal(t, impact, getEventImpact(t, public, eventID))
+
+	resp, err := public.GET("/api/v1/status")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var status struct {
+		Data struct {
+			Events []struct {
+				ID     string `json:"id"`
+				Impact string `json:"impact"`
+			} `json:"events"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &status)
+	found := false
+	for _, e := range status.Data.Events {
+		if e.ID == eventID {
+			found = true
+			assert.Equal(t, impact, e.Impact)
+		}
+	}
+	assert.True(t, found, "event is on the status page")
+
+	update := addImpactUpdate(t, client, eventID, "Checkout is slow in EU")
+	require.Contains(t, update.Changes, "impact")
+	assert.Equal(t, impact, update.Changes["impact"].From)
+	assert.Equal(t, "Checkout is slow in EU", update.Changes["impact"].To)
+	assert.Equal(t, "Checkout is slow in EU", getEventImpact(t, public, eventID))
+
+	// Updates without impact keep it
+	addEventUpdate(t, client, eventID, "monitoring", "Fix deployed")
+	assert.Equal(t, "Checkout is slow in EU", getEventImpact(t, public, eventID))
+
+	update = addImpactUpdate(t, client, eventID, "")
+	assert.Equal(t, "", update.Changes["impact"].To)
+	assert.Empty(t, getEventImpact(t, public, eventID))
+}
+
+func TestEvent_Impact_TooLong(t *testing.T) {
+	client := newTestClientWithoutValidation()
+	client.LoginAsOperator(t)
+
+	resp, err := client.POST("/api/v1/events", map[string]interface{}{
+		"title":    "Impact too long",
+		"type":     "incident",
+		"status":   "investigating",
+		"severity": "minor",
+		"impact":   strings.Repeat("x", 501),
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	eventID := createTestIncident(t, client, "Impact update too long", nil, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		deleteEvent(t, client, eventID)
+	})
+
+	resp, err = client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+		"status":  "identified",
+		"message": "Too long",
+		"impact":  strings.Repeat("x", 501),
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,174 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type maintenanceConflictResponse struct {
+	Error struct {
+		Message   string `json:"message"`
+		Conflicts []struct {
+			EventID string `json:"event_id"`
+			Title   string `json:"title"`
+		} `json:"conflicts"`
+	} `json:"error"`
+}
+
+// postMaintenance creates a scheduled maintenance of serviceIDs in the window [start, end).
+// The response is returned unread; a created event is completed and deleted on cleanup.
+func postMaintenance(t *testing.T, client *testutil.Client, title, start, end string, serviceIDs ...string) *http.Response {
+	t.Helper()
+	services := make([]map[string]interface{}, 0, len(serviceIDs))
+	for _, id := range serviceIDs {
+		services = append(services, map[string]interface{}{"service_id": id, "status": "maintenance"})
+	}
+	resp, err := client.POST("/api/v1/events", map[string]interface{}{
+		"title":              title,
+		"type":               "maintenance",
+		"status":             "scheduled",
+		"description":        "Planned work",
+		"scheduled_start_at": start,
+		"scheduled_end_at":   end,
+		"affected_services":  services,
+	})
+	require.NoError(t, err)
+	return resp
+}
+
+// createMaintenance creates a scheduled maintenance and returns its ID.
+func createMaintenance(t *testing.T, client *testutil.Client, title, start, end string, serviceIDs ...string) string {
+	t.Helper()
+	resp := postMaintenance(t, client, title, start, end, serviceIDs...)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	eventID := result.Data.ID
+	t.Cleanup(func() {
+		completeMaintenance(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+	return eventID
+}
+
+func TestMaintenance_Overlap(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID1, slug1 := createTestService(t, client, "overlap-svc-1")
+	t.Cleanup(func() { deleteService(t, client, slug1) })
+	serviceID2, slug2 := createTestService(t, client, "overlap-svc-2")
+	t.Cleanup(func() { deleteService(t, client, slug2) })
+
+	existingID := createMaintenance(t, client, "Database upgrade",
+		"2031-03-10T02:00:00Z", "2031-03-10T04:00:00Z", serviceID1)
+
+	t.Run("overlapping window on a shared service", func(t *testing.T) {
+		resp := postMaintenance(t, client, "Network work",
+			"2031-03-10T03:00:00Z", "2031-03-10T05:00:00Z", serviceID2, serviceID1)
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		var result maintenanceConflictResponse
+		testutil.DecodeJSON(t, resp, &result)
+		assert.Equal(t, "overlapping maintenance window", result.Error.Message)
+		require.Len(t, result.Error.Conflicts, 1)
+		assert.Equal(t, existingID, result.Error.Conflicts[0].EventID)
+		assert.Equal(t, "Database upgrade", result.Error.Conflicts[0].Title)
+	})
+
+	t.Run("window inside the existing one", func(t *testing.T) {
+		resp := postMaintenance(t, client, "Short restart",
+			"2031-03-10T02:30:00Z", "2031-03-10T02:45:00Z", serviceID1)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("adjacent windows do not conflict", func(t *testing.T) {
+		createMaintenance(t, client, "Before", "2031-03-10T00:00:00Z", "2031-03-10T02:00:00Z", serviceID1)
+		createMaintenance(t, client, "After", "2031-03-10T04:00:00Z", "2031-03-10T06:00:00Z", serviceID1)
+	})
+
+	t.Run("same window on another service", func(t *testing.T) {
+		createMaintenance(t, client, "Other service", "2031-03-10T02:00:00Z", "2031-03-10T04:00:00Z", serviceID2)
+	})
+}
+
+func TestMaintenance_Overlap_CompletedDoesNotConflict(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "overlap-completed-svc")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	eventID := createMaintenance(t, client, "Done early", "2031-04-01T02:00:00Z", "2031-04-01T04:00:00Z", serviceID)
+	completeMaintenance(t, client, eventID)
+
+	createMaintenance(t, client, "Rescheduled", "2031-04-01T03:00:00Z", "2031-04-01T05:00:00Z", serviceID)
+}
+
+func TestMaintenance_Overlap_Update(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	groupID, groupSlug := createTestGroup(t, client, "overlap-group")
+	t.Cleanup(func() { deleteGroup(t, client, groupSlug) })
+	serviceID1, slug1 := createTestService(t, client, "overlap-upd-svc-1", withGroupIDs([]string{groupID}))
+	t.Cleanup(func() { deleteService(t, client, slug1) })
+	_, slug2 := createTestService(t, client, "overlap-upd-svc-2", withGroupIDs([]string{groupID}))
+	t.Cleanup(func() { deleteService(t, client, slug2) })
+	serviceID3, slug3 := createTestService(t, client, "overlap-upd-svc-3")
+	t.Cleanup(func() { deleteService(t, client, slug3) })
+
+	eventID := createMaintenance(t, client, "Storage migration",
+		"2031-05-01T02:00:00Z", "2031-05-01T04:00:00Z", serviceID1)
+	otherID := createMaintenance(t, client, "Firewall change",
+		"2031-05-01T03:00:00Z", "2031-05-01T05:00:00Z", serviceID3)
+
+	t.Run("event does not conflict with itself", func(t *testing.T) {
+		// The group brings service 1 again, which is already in this event's window
+		resp, err := client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+			"status":     "scheduled",
+			"message":    "Group added",
+			"add_groups": []map[string]string{{"group_id": groupID, "status": "maintenance"}},
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("added service with an overlapping maintenance", func(t *testing.T) {
+		resp, err := client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+			"status":       "scheduled",
+			"message":      "Firewall affected too",
+			"add_services": []map[string]string{{"service_id": serviceID3, "status": "maintenance"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		var result maintenanceConflictResponse
+		testutil.DecodeJSON(t, resp, &result)
+		require.Len(t, result.Error.Conflicts, 1)
+		assert.Equal(t, otherID, result.Error.Conflicts[0].EventID)
+	})
+
+	t.Run("status update without new services", func(t *testing.T) {
+		resp, err := client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+			"status":  "in_progress",
+			"message": "Started",
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+}
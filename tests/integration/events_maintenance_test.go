@@ -596,3 +596,22 @@ func TestServiceEvents_ActiveFilterExcludesScheduled(t *testing.T) {
 	assert.Equal(t, 2, eventsResult.Data.Total,
 		"without filter should return all events including scheduled")
 }
+
+func TestMaintenance_CannotReturnToScheduled(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	eventID := createTestMaintenance(t, client, "Reschedule In Progress Maintenance", nil)
+	t.Cleanup(func() {
+		completeMaintenance(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	resp, err := client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+		"status":  "scheduled",
+		"message": "Postponing",
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
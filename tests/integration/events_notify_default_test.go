@@ -0,0 +1,139 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createEventNotify creates an event from payload and returns its ID and notify_subscribers.
+func createEventNotify(t *testing.T, client *testutil.Client, payload map[string]interface{}) (string, bool) {
+	t.Helper()
+	resp, err := client.POST("/api/v1/events", payload)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			ID                string `json:"id"`
+			NotifySubscribers bool   `json:"notify_subscribers"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data.ID, result.Data.NotifySubscribers
+}
+
+// addUpdateNotify posts an update and returns its notify_subscribers.
+func addUpdateNotify(t *testing.T, client *testutil.Client, eventID string, payload map[string]interface{}) bool {
+	t.Helper()
+	resp, err := client.POST("/api/v1/events/"+eventID+"/updates", payload)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			NotifySubscribers bool `json:"notify_subscribers"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data.NotifySubscribers
+}
+
+func TestEvents_NotifyDefault_Incident(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	incident := func(title string) map[string]interface{} {
+		return map[string]interface{}{
+			"title":       title,
+			"type":        "incident",
+			"status":      "investigating",
+			"severity":    "minor",
+			"description": "Notify default test",
+		}
+	}
+
+	eventID, notify := createEventNotify(t, client, incident("Notify Default Incident"))
+	t.Cleanup(func() {
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+	assert.True(t, notify, "incidents notify by default")
+
+	assert.True(t, addUpdateNotify(t, client, eventID, map[string]interface{}{
+		"status":  "identified",
+		"message": "Found it",
+	}), "incident updates notify by default")
+	assert.False(t, addUpdateNotify(t, client, eventID, map[string]interface{}{
+		"status":             "monitoring",
+		"message":            "Quiet update",
+		"notify_subscribers": false,
+	}), "explicit false overrides the default")
+
+	payload := incident("Notify Override Incident")
+	payload["notify_subscribers"] = false
+	silentID, notify := createEventNotify(t, client, payload)
+	t.Cleanup(func() {
+		resolveEvent(t, client, silentID)
+		deleteEvent(t, client, silentID)
+	})
+	assert.False(t, notify, "explicit false overrides the default")
+}
+
+func TestEvents_NotifyDefault_Maintenance(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	maintenance := func(title string) map[string]interface{} {
+		return map[string]interface{}{
+			"title":       title,
+			"type":        "maintenance",
+			"status":      "in_progress",
+			"description": "Notify default test",
+		}
+	}
+
+	eventID, notify := createEventNotify(t, client, maintenance("Notify Default Maintenance"))
+	t.Cleanup(func() {
+		completeMaintenance(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+	assert.False(t, notify, "maintenance does not notify by default")
+
+	assert.False(t, addUpdateNotify(t, client, eventID, map[string]interface{}{
+		"status":  "in_progress",
+		"message": "Halfway there",
+	}), "maintenance updates do not notify by default")
+	assert.True(t, addUpdateNotify(t, client, eventID, map[string]interface{}{
+		"status":             "in_progress",
+		"message":            "Announced update",
+		"notify_subscribers": true,
+	}), "explicit true overrides the default")
+
+	payload := maintenance("Notify Override Maintenance")
+	payload["notify_subscribers"] = true
+	announcedID, notify := createEventNotify(t, client, payload)
+	t.Cleanup(func() {
+		completeMaintenance(t, client, announcedID)
+		deleteEvent(t, client, announcedID)
+	})
+	assert.True(t, notify, "explicit true overrides the default")
+}
+
+func TestEvents_NotifyDefault_UpdateUnknownEvent(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	resp, err := client.POST("/api/v1/events/00000000-0000-0000-0000-000000000000/updates", map[string]interface{}{
+		"status":  "identified",
+		"message": "No such event",
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
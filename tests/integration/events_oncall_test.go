@@ -0,0 +1,231 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/notifications"
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withOnCallTeam sets the on-call team of an incident.
+func withOnCallTeam(team string) incidentOption {
+	return func(m map[string]interface{}) {
+		m["oncall_team"] = team
+	}
+}
+
+func getEvent(t *testing.T, client *testutil.Client, eventID string) domain.Event {
+	t.Helper()
+	resp, err := client.GET("/api/v1/events/" + eventID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data domain.Event `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+// escalateEvent posts an escalation and returns the status code and the update on success.
+func escalateEvent(t *testing.T, client *testutil.Client, eventID, team string) (int, domain.EventUpdate) {
+	t.Helper()
+	resp, err := client.POST("/api/v1/events/"+eventID+"/escalate", map[string]interface{}{"oncall_team": team})
+	require.NoError(t, err)
+	var result struct {
+		Data domain.EventUpdate `json:"data"`
+	}
+	if resp.StatusCode != http.StatusCreated {
+		resp.Body.Close()
+		return resp.StatusCode, result.Data
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return resp.StatusCode, result.Data
+}
+
+func TestEvent_OnCallTeam_RoundTrip(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	eventID := createTestIncident(t, client, "On-call incident", nil, nil, withOnCallTeam("Core SRE"))
+	t.Cleanup(func() {
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+	assert.Equal(t, "Core SRE", getEvent(t, client, eventID).OnCallTeam)
+
+	// Updates change the team; omitting it keeps, "" clears
+	resp, err := client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+		"status":      "identified",
+		"message":     "Handing over",
+		"oncall_team": "Database team",
+	})
+	require.NoError(t, err)
+	var result struct {
+		Data domain.EventUpdate `json:"data"`
+	}
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, domain.FieldChange{From: "Core SRE", To: "Database team"}, result.Data.Changes["oncall_team"])
+
+	resp, err = client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+		"status":  "monitoring",
+		"message": "Fix deployed",
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "Database team", getEvent(t, client, eventID).OnCallTeam)
+
+	resp, err = client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+		"status":      "monitoring",
+		"message":     "Back to the default rotation",
+		"oncall_team": "",
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Empty(t, getEvent(t, client, eventID).OnCallTeam)
+}
+
+func TestEvent_Escalate(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	eventID := createTestIncident(t, client, "Escalate incident", nil, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		deleteEvent(t, client, eventID)
+	})
+
+	resp, err := client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+		"status":  "identified",
+		"message": "Root cause found",
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	status, update := escalateEvent(t, client, eventID, "  Payments SRE ")
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "Escalated to Payments SRE", update.Message)
+	assert.Equal(t, domain.EventStatusIdentified, update.Status, "escalation keeps the status")
+	assert.Equal(t, map[string]domain.FieldChange{
+		"oncall_team": {From: "", To: "Payments SRE"},
+	}, update.Changes)
+
+	event := getEvent(t, client, eventID)
+	assert.Equal(t, "Payments SRE", event.OnCallTeam)
+	assert.Equal(t, domain.EventStatusIdentified, event.Status)
+
+	resp, err = client.GET("/api/v1/events/" + eventID + "/updates")
+	require.NoError(t, err)
+	var updates struct {
+		Data struct {
+			Updates []domain.EventUpdate `json:"updates"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &updates)
+	require.NotEmpty(t, updates.Data.Updates)
+	assert.Equal(t, update.ID, updates.Data.Updates[0].ID, "the escalation is the latest update")
+
+	// A resolved event can't be escalated
+	resolveEvent(t, client, eventID)
+	status, _ = escalateEvent(t, client, eventID, "Another team")
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "Payments SRE", getEvent(t, client, eventID).OnCallTeam)
+}
+
+func TestEvent_Escalate_Validation(t *testing.T) {
+	client := newTestClientWithoutValidation()
+	client.LoginAsOperator(t)
+
+	eventID := createTestIncident(t, client, "Escalate validation", nil, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	for _, team := range []string{"", "   ", strings.Repeat("x", 101)} {
+		status, _ := escalateEvent(t, client, eventID, team)
+		assert.Equal(t, http.StatusBadRequest, status, "team of length %d", len(team))
+	}
+
+	status, _ := escalateEvent(t, client, "00000000-0000-0000-0000-000000000000", "Payments SRE")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	user := newTestClientWithoutValidation()
+	status, _ = escalateEvent(t, user, eventID, "Payments SRE")
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
+
+func TestEvent_Escalate_Notification(t *testing.T) {
+	ctx := context.Background()
+	repo := notificationspostgres.NewRepository(testDB)
+	dispatcher := notifications.NewDispatcher(repo, NewMockSenderRegistry().GetSenders()...)
+	renderer, err := notifications.NewRenderer()
+	require.NoError(t, err)
+	notifier := notifications.NewNotifier(repo, renderer, dispatcher, nil, "https://status.example.com")
+
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Escalation Notify Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	email := testutil.RandomEmail()
+	resp, err := client.POST("/api/v1/auth/register", map[string]string{
+		"email":    email,
+		"password": "password123",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	user := newTestClient(t)
+	user.LoginAs(t, email, "password123")
+	channelID := createTelegramChannel(t, user, "987654321")
+	verifyTelegramChannel(t, user, channelID)
+	setChannelSubscription(t, user, channelID, []string{serviceID})
+
+	eventID := createTestIncident(t, client, "Escalation Notify Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	t.Cleanup(func() {
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	status, update := escalateEvent(t, client, eventID, "Payments <SRE>")
+	require.Equal(t, http.StatusCreated, status)
+	require.True(t, update.NotifySubscribers, "incidents notify subscribers by default")
+
+	// Notifications are disabled in the test app: deliver the escalation through a notifier
+	event := getEvent(t, client, eventID)
+	require.NoError(t, repo.AddEventSubscribers(ctx, eventID, []string{channelID}))
+	require.NoError(t, notifier.OnEventUpdated(ctx, &event, &update, nil))
+
+	var raw []byte
+	err = testDB.QueryRow(ctx,
+		`SELECT payload FROM notification_queue WHERE channel_id = $1 ORDER BY created_at DESC LIMIT 1`,
+		channelID).Scan(&raw)
+	require.NoError(t, err)
+
+	var payload notifications.NotificationPayload
+	require.NoError(t, json.Unmarshal(raw, &payload))
+	assert.Equal(t, "Payments <SRE>", payload.Event.OnCallTeam)
+
+	_, body, err := renderer.Render(domain.ChannelTypeTelegram, payload)
+	require.NoError(t, err)
+	assert.Contains(t, body, "On-call team: Payments &lt;SRE&gt;")
+	assert.Contains(t, body, "Escalated to Payments &lt;SRE&gt;")
+}
@@ -0,0 +1,316 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/notifications"
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type postmortemResponse struct {
+	EventID     string     `json:"event_id"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	PublishedAt *time.Time `json:"published_at"`
+	CreatedBy   string     `json:"created_by"`
+}
+
+// putPostmortem saves a post-mortem and returns the response status code.
+func putPostmortem(t *testing.T, client *testutil.Client, eventID string, payload map[string]interface{}) (int, postmortemResponse) {
+	t.Helper()
+	resp, err := client.PUT("/api/v1/events/"+eventID+"/postmortem", payload)
+	require.NoError(t, err)
+
+	var result struct {
+		Data postmortemResponse `json:"data"`
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return resp.StatusCode, result.Data
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return resp.StatusCode, result.Data
+}
+
+func TestEvents_Postmortem_Lifecycle(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Postmortem Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	eventID := createTestIncident(t, client, "Postmortem DB outage",
+		[]AffectedService{{ServiceID: serviceID, Status: "major_outage"}}, nil)
+
+	// Active events cannot have a post-mortem
+	status, _ := putPostmortem(t, client, eventID, map[string]interface{}{
+		"title": "DB outage post-mortem",
+		"body":  "Too early",
+	})
+	assert.Equal(t, http.StatusConflict, status)
+
+	resolveEvent(t, client, eventID)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	// Draft: saved, not public
+	status, draft := putPostmortem(t, client, eventID, map[string]interface{}{
+		"title": "DB outage post-mortem",
+		"body":  "Draft analysis",
+	})
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, eventID, draft.EventID)
+	assert.Nil(t, draft.PublishedAt)
+	assert.NotEmpty(t, draft.CreatedBy)
+
+	public := newTestClient(t)
+	resp, err := public.GET("/api/v1/events/" + eventID + "/postmortem")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "drafts are not public")
+
+	// Publish: replaces the draft
+	publishedAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	status, published := putPostmortem(t, client, eventID, map[string]interface{}{
+		"title":        "DB outage post-mortem",
+		"body":         "Connection pool exhaustion after a config change.",
+		"published_at": publishedAt.Format(time.RFC3339),
+	})
+	require.Equal(t, http.StatusOK, status)
+	require.NotNil(t, published.PublishedAt)
+	assert.True(t, publishedAt.Equal(*published.PublishedAt))
+
+	resp, err = public.GET("/api/v1/events/" + eventID + "/postmortem")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data postmortemResponse `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, "DB outage post-mortem", result.Data.Title)
+	assert.Equal(t, "Connection pool exhaustion after a config change.", result.Data.Body)
+	assert.Equal(t, draft.CreatedBy, result.Data.CreatedBy)
+
+	// Scheduled for later: hidden again
+	status, _ = putPostmortem(t, client, eventID, map[string]interface{}{
+		"title":        "DB outage post-mortem",
+		"body":         "Embargoed",
+		"published_at": time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339),
+	})
+	require.Equal(t, http.StatusOK, status)
+
+	resp, err = public.GET("/api/v1/events/" + eventID + "/postmortem")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "future published_at is not public yet")
+}
+
+func TestEvents_Postmortem_Errors(t *testing.T) {
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	eventID := createTestIncident(t, admin, "Postmortem Errors Incident", nil, nil)
+	resolveEvent(t, admin, eventID)
+	t.Cleanup(func() { deleteEvent(t, admin, eventID) })
+
+	status, _ := putPostmortem(t, admin, eventID, map[string]interface{}{"title": "No body"})
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = putPostmortem(t, admin, "00000000-0000-0000-0000-000000000000", map[string]interface{}{
+		"title": "Unknown",
+		"body":  "Unknown event",
+	})
+	assert.Equal(t, http.StatusNotFound, status)
+
+	operator := newTestClient(t)
+	operator.LoginAsOperator(t)
+	status, _ = putPostmortem(t, operator, eventID, map[string]interface{}{
+		"title": "Operator",
+		"body":  "Not allowed",
+	})
+	assert.Equal(t, http.StatusForbidden, status)
+
+	status, _ = putPostmortem(t, newTestClient(t), eventID, map[string]interface{}{
+		"title": "Anonymous",
+		"body":  "Not allowed",
+	})
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	resp, err := newTestClient(t).GET("/api/v1/events/" + eventID + "/postmortem")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestEvents_Postmortem_Notification(t *testing.T) {
+	ctx := context.Background()
+	repo := notificationspostgres.NewRepository(testDB)
+	dispatcher := notifications.NewDispatcher(repo, NewMockSenderRegistry().GetSenders()...)
+	renderer, err := notifications.NewRenderer()
+	require.NoError(t, err)
+	notifier := notifications.NewNotifier(repo, renderer, dispatcher, nil, "https://status.example.com")
+
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Postmortem Notify Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	email := testutil.RandomEmail()
+	resp, err := client.POST("/api/v1/auth/register", map[string]string{
+		"email":    email,
+		"password": "password123",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	user := newTestClient(t)
+	user.LoginAs(t, email, "password123")
+	channelID := createTelegramChannel(t, user, "987654321")
+	verifyTelegramChannel(t, user, channelID)
+	setChannelSubscription(t, user, channelID, []string{serviceID})
+
+	eventID := createTestIncident(t, client, "Postmortem Notify Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	resolveEvent(t, client, eventID)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	event := &domain.Event{
+		ID:                eventID,
+		Title:             "Postmortem Notify Incident",
+		Type:              domain.EventTypeIncident,
+		Status:            domain.EventStatusResolved,
+		NotifySubscribers: true,
+		ServiceIDs:        []string{serviceID},
+	}
+	require.NoError(t, repo.AddEventSubscribers(ctx, eventID, []string{channelID}))
+	before := countQueued(t, channelID)
+
+	err = notifier.OnPostmortemPublished(ctx, event, &domain.EventPostmortem{EventID: eventID, Title: "What happened"})
+	require.NoError(t, err)
+
+	assert.Equal(t, before+1, countQueued(t, channelID))
+
+	var payload []byte
+	err = testDB.QueryRow(ctx,
+		`SELECT payload FROM notification_queue WHERE channel_id = $1 ORDER BY created_at DESC LIMIT 1`,
+		channelID).Scan(&payload)
+	require.NoError(t, err)
+	assert.Contains(t, string(payload), "https://status.example.com/events/"+eventID+"/postmortem")
+}
+
+// listEventPostmortemFlags returns has_post_mortem of the events matching the query, keyed by title.
+func listEventPostmortemFlags(t *testing.T, client *testutil.Client, query string) map[string]bool {
+	t.Helper()
+	resp, err := client.GET("/api/v1/events?" + query)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			Events []struct {
+				Title         string `json:"title"`
+				HasPostMortem *bool  `json:"has_post_mortem"`
+			} `json:"events"`
+			Total int `json:"total"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	require.Equal(t, len(result.Data.Events), result.Data.Total)
+
+	flags := make(map[string]bool, len(result.Data.Events))
+	for _, e := range result.Data.Events {
+		require.NotNil(t, e.HasPostMortem, "has_post_mortem is set in lists")
+		flags[e.Title] = *e.HasPostMortem
+	}
+	return flags
+}
+
+func TestEvents_List_HasPostMortemFilter(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Postmortem Filter Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+	services := []AffectedService{{ServiceID: serviceID, Status: "degraded"}}
+
+	tag := testutil.RandomSlug("pmfilter")
+	published := createTestIncident(t, client, tag+" published", services, nil)
+	draft := createTestIncident(t, client, tag+" draft", services, nil)
+	none := createTestIncident(t, client, tag+" none", services, nil)
+	active := createTestIncident(t, client, tag+" active", services, nil)
+	t.Cleanup(func() {
+		resolveEvent(t, client, active)
+		for _, id := range []string{published, draft, none, active} {
+			deleteEvent(t, client, id)
+		}
+	})
+
+	for _, id := range []string{published, draft, none} {
+		resolveEvent(t, client, id)
+	}
+	status, _ := putPostmortem(t, client, published, map[string]interface{}{
+		"title":        "Published post-mortem",
+		"body":         "Root cause",
+		"published_at": time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+	})
+	require.Equal(t, http.StatusOK, status)
+	status, _ = putPostmortem(t, client, draft, map[string]interface{}{
+		"title": "Draft post-mortem",
+		"body":  "Work in progress",
+	})
+	require.Equal(t, http.StatusOK, status)
+
+	public := newTestClient(t)
+
+	t.Run("no filter", func(t *testing.T) {
+		assert.Equal(t, map[string]bool{
+			tag + " published": true,
+			tag + " draft":     false,
+			tag + " none":      false,
+			tag + " active":    false,
+		}, listEventPostmortemFlags(t, public, "q="+tag))
+	})
+
+	t.Run("true", func(t *testing.T) {
+		assert.Equal(t, map[string]bool{
+			tag + " published": true,
+		}, listEventPostmortemFlags(t, public, "q="+tag+"&has_post_mortem=true"))
+	})
+
+	t.Run("false counts drafts as none", func(t *testing.T) {
+		assert.Equal(t, map[string]bool{
+			tag + " draft":  false,
+			tag + " none":   false,
+			tag + " active": false,
+		}, listEventPostmortemFlags(t, public, "q="+tag+"&has_post_mortem=false"))
+	})
+
+	t.Run("false with status", func(t *testing.T) {
+		assert.Equal(t, map[string]bool{
+			tag + " draft": false,
+			tag + " none":  false,
+		}, listEventPostmortemFlags(t, public, "q="+tag+"&has_post_mortem=false&status=resolved"))
+	})
+
+	t.Run("true with status", func(t *testing.T) {
+		assert.Empty(t, listEventPostmortemFlags(t, public, "q="+tag+"&has_post_mortem=true&status=investigating"))
+		assert.Len(t, listEventPostmortemFlags(t, public, "q="+tag+"&has_post_mortem=true&status=resolved"), 1)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		resp, err := public.GET("/api/v1/events?has_post_mortem=yes")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -3,6 +3,7 @@
 package integration
 
 import (
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -59,12 +60,14 @@ func TestEvents_PublicRead_NoAuth(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode, "GET /events should be public")
 
 	var eventsList struct {
-		Data []struct {
-			ID string `json:"id"`
+		Data struct {
+			Events []struct {
+				ID string `json:"id"`
+			} `json:"events"`
 		} `json:"data"`
 	}
 	testutil.DecodeJSON(t, resp, &eventsList)
-	assert.NotNil(t, eventsList.Data, "data should be array")
+	assert.NotNil(t, eventsList.Data.Events, "events should be array")
 
 	// GET /events/{id} — should be 200 without auth
 	resp, err = publicClient.GET("/api/v1/events/" + eventID)
@@ -85,10 +88,12 @@ func TestEvents_PublicRead_NoAuth(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode, "GET /events/{id}/updates should be public")
 
 	var updates struct {
-		Data []interface{} `json:"data"`
+		Data struct {
+			Updates []interface{} `json:"updates"`
+		} `json:"data"`
 	}
 	testutil.DecodeJSON(t, resp, &updates)
-	assert.NotNil(t, updates.Data, "updates should be array")
+	assert.NotNil(t, updates.Data.Updates, "updates should be array")
 
 	// GET /events/{id}/changes — should be 200 without auth
 	resp, err = publicClient.GET("/api/v1/events/" + eventID + "/changes")
@@ -158,3 +163,162 @@ func TestEvents_WriteOperations_RequireAuth(t *testing.T) {
 	client.LoginAsAdmin(t)
 	deleteEvent(t, client, eventID)
 }
+
+type eventListItem struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Severity string `json:"severity"`
+}
+
+func listEventsWithQuery(t *testing.T, client *testutil.Client, query string) []eventListItem {
+	t.Helper()
+	resp, err := client.GET("/api/v1/events?" + query)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			Events []eventListItem `json:"events"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data.Events
+}
+
+func eventIDs(items []eventListItem) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func TestEvents_ListFilterBySeverity(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	criticalID := createTestIncident(t, client, "Severity Filter Critical", nil, nil, withSeverity("critical"))
+	minorID := createTestIncident(t, client, "Severity Filter Minor", nil, nil, withSeverity("minor"))
+	maintenanceID := createTestMaintenance(t, client, "Severity Filter Maintenance", nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		for _, id := range []string{criticalID, minorID} {
+			resolveEvent(t, client, id)
+			deleteEvent(t, client, id)
+		}
+		completeMaintenance(t, client, maintenanceID)
+		deleteEvent(t, client, maintenanceID)
+	})
+
+	publicClient := newTestClient(t)
+
+	t.Run("severity only", func(t *testing.T) {
+		items := listEventsWithQuery(t, publicClient, "severity=critical")
+		for _, item := range items {
+			assert.Equal(t, "critical", item.Severity)
+		}
+		ids := eventIDs(items)
+		assert.Contains(t, ids, criticalID)
+		assert.NotContains(t, ids, minorID)
+		assert.NotContains(t, ids, maintenanceID)
+	})
+
+	t.Run("combined with type", func(t *testing.T) {
+		items := listEventsWithQuery(t, publicClient, "type=incident&severity=minor")
+		for _, item := range items {
+			assert.Equal(t, "incident", item.Type)
+			assert.Equal(t, "minor", item.Severity)
+		}
+		ids := eventIDs(items)
+		assert.Contains(t, ids, minorID)
+		assert.NotContains(t, ids, criticalID)
+
+		assert.Empty(t, listEventsWithQuery(t, publicClient, "type=maintenance&severity=critical"))
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		resp, err := publicClient.GET("/api/v1/events?severity=catastrophic")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+type eventsPage struct {
+	Events []eventListItem `json:"events"`
+	Total  int             `json:"total"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
+func getEventsPage(t *testing.T, client *testutil.Client, query string) eventsPage {
+	t.Helper()
+	resp, err := client.GET("/api/v1/events?" + query)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data eventsPage `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+func TestEvents_ListTotal(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	// Tests run sequentially against a shared database, so compare with a baseline
+	const activeQuery = "type=incident&status=investigating&severity=major"
+	const resolvedQuery = "type=incident&status=resolved&severity=major"
+	activeBefore := getEventsPage(t, client, activeQuery).Total
+	resolvedBefore := getEventsPage(t, client, resolvedQuery).Total
+
+	ids := make([]string, 3)
+	for i := range ids {
+		ids[i] = createTestIncident(t, client, fmt.Sprintf("Total Count %d", i), nil, nil, withSeverity("major"))
+	}
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		for _, id := range ids {
+			deleteEvent(t, client, id)
+		}
+	})
+
+	page := getEventsPage(t, client, activeQuery)
+	assert.Equal(t, activeBefore+3, page.Total)
+
+	resolveEvent(t, client, ids[0])
+
+	assert.Equal(t, activeBefore+2, getEventsPage(t, client, activeQuery).Total)
+	assert.Equal(t, resolvedBefore+1, getEventsPage(t, client, resolvedQuery).Total)
+
+	// Pagination doesn't change the total
+	page = getEventsPage(t, client, activeQuery+"&limit=1&offset=1")
+	assert.Len(t, page.Events, 1)
+	assert.Equal(t, activeBefore+2, page.Total)
+	assert.Equal(t, 1, page.Limit)
+	assert.Equal(t, 1, page.Offset)
+
+	resolveEvent(t, client, ids[1])
+	resolveEvent(t, client, ids[2])
+	assert.Equal(t, activeBefore, getEventsPage(t, client, activeQuery).Total)
+	assert.Equal(t, resolvedBefore+3, getEventsPage(t, client, resolvedQuery).Total)
+}
+
+func TestEvents_ListPaginationDefaults(t *testing.T) {
+	client := newTestClient(t)
+
+	page := getEventsPage(t, client, "")
+	assert.Equal(t, 20, page.Limit)
+	assert.Equal(t, 0, page.Offset)
+	assert.LessOrEqual(t, len(page.Events), 20)
+	assert.GreaterOrEqual(t, page.Total, len(page.Events))
+
+	for _, query := range []string{"limit=0", "limit=abc", "offset=-1"} {
+		resp, err := client.GET("/api/v1/events?" + query)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+		resp.Body.Close()
+	}
+}
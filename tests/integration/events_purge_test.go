@@ -0,0 +1,141 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type purgeResponse struct {
+	Data struct {
+		DeletedEvents  int  `json:"deleted_events"`
+		DeletedUpdates int  `json:"deleted_updates"`
+		DryRun         bool `json:"dry_run"`
+	} `json:"data"`
+}
+
+func purgeEvents(t *testing.T, client *testutil.Client, query string) purgeResponse {
+	t.Helper()
+	resp, err := client.DELETE("/api/v1/admin/cleanup?" + query)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result purgeResponse
+	testutil.DecodeJSON(t, resp, &result)
+	return result
+}
+
+func countRows(t *testing.T, query, eventID string) int {
+	t.Helper()
+	var n int
+	require.NoError(t, testDB.QueryRow(context.Background(), query, eventID).Scan(&n))
+	return n
+}
+
+func TestPurgeOldEvents(t *testing.T) {
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, admin, "Purge Service")
+	t.Cleanup(func() { deleteService(t, admin, serviceSlug) })
+
+	oldID := createTestIncident(t, admin, "Old resolved incident", []AffectedService{
+		{ServiceID: serviceID, Status: "major_outage"},
+	}, nil)
+	resolveEvent(t, admin, oldID)
+
+	recentID := createTestIncident(t, admin, "Recently resolved incident", nil, nil)
+	resolveEvent(t, admin, recentID)
+	t.Cleanup(func() { deleteEvent(t, admin, recentID) })
+
+	activeID := createTestIncident(t, admin, "Active incident", nil, nil)
+	t.Cleanup(func() {
+		resolveEvent(t, admin, activeID)
+		deleteEvent(t, admin, activeID)
+	})
+
+	// Nothing else in the test database is resolved this long ago
+	_, err := testDB.Exec(context.Background(),
+		`UPDATE events SET resolved_at = NOW() - INTERVAL '400 days' WHERE id = $1`, oldID)
+	require.NoError(t, err)
+
+	updates := countRows(t, `SELECT COUNT(*) FROM event_updates WHERE event_id = $1`, oldID)
+	require.Positive(t, updates)
+	require.Positive(t, countRows(t, `SELECT COUNT(*) FROM service_status_log WHERE event_id = $1`, oldID))
+
+	t.Run("dry run counts without deleting", func(t *testing.T) {
+		result := purgeEvents(t, admin, "older_than=365d&dry_run=true")
+
+		assert.True(t, result.Data.DryRun)
+		assert.Equal(t, 1, result.Data.DeletedEvents)
+		assert.Equal(t, updates, result.Data.DeletedUpdates)
+
+		resp, err := admin.GET("/api/v1/events/" + oldID)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("purge deletes old resolved events with their data", func(t *testing.T) {
+		result := purgeEvents(t, admin, "older_than=365d")
+
+		assert.False(t, result.Data.DryRun)
+		assert.Equal(t, 1, result.Data.DeletedEvents)
+		assert.Equal(t, updates, result.Data.DeletedUpdates)
+
+		resp, err := admin.GET("/api/v1/events/" + oldID)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		assert.Zero(t, countRows(t, `SELECT COUNT(*) FROM event_updates WHERE event_id = $1`, oldID))
+		assert.Zero(t, countRows(t, `SELECT COUNT(*) FROM event_services WHERE event_id = $1`, oldID))
+		assert.Zero(t, countRows(t, `SELECT COUNT(*) FROM service_status_log WHERE event_id = $1`, oldID))
+
+		for _, id := range []string{recentID, activeID} {
+			resp, err := admin.GET("/api/v1/events/" + id)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode, "recent and active events are kept")
+		}
+	})
+
+	t.Run("nothing left to purge", func(t *testing.T) {
+		result := purgeEvents(t, admin, "older_than=365d")
+		assert.Zero(t, result.Data.DeletedEvents)
+		assert.Zero(t, result.Data.DeletedUpdates)
+	})
+}
+
+func TestPurgeOldEvents_Validation(t *testing.T) {
+	admin := newTestClientWithoutValidation()
+	admin.LoginAsAdmin(t)
+
+	for _, query := range []string{"", "older_than=soon", "older_than=0d", "older_than=90d&dry_run=maybe"} {
+		resp, err := admin.DELETE("/api/v1/admin/cleanup?" + query)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+func TestPurgeOldEvents_RequiresAdmin(t *testing.T) {
+	operator := newTestClient(t)
+	operator.LoginAsOperator(t)
+
+	resp, err := operator.DELETE("/api/v1/admin/cleanup?older_than=90d&dry_run=true")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp, err = newTestClient(t).DELETE("/api/v1/admin/cleanup?older_than=90d&dry_run=true")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,242 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	catalogpostgres "github.com/bissquit/incident-garden/internal/catalog/postgres"
+	"github.com/bissquit/incident-garden/internal/events"
+	eventspostgres "github.com/bissquit/incident-garden/internal/events/postgres"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rruleDays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// createRecurringMaintenance creates a two-hour maintenance starting at start that
+// recurs by rule, optionally until end, and returns its ID.
+func createRecurringMaintenance(t *testing.T, client *testutil.Client, serviceID string, start time.Time, rule string, end *time.Time) string {
+	t.Helper()
+	body := map[string]interface{}{
+		"title":              "Recurring " + testutil.RandomSlug("mnt"),
+		"type":               "maintenance",
+		"status":             "scheduled",
+		"description":        "Weekly database maintenance",
+		"scheduled_start_at": start.Format(time.RFC3339),
+		"scheduled_end_at":   start.Add(2 * time.Hour).Format(time.RFC3339),
+		"recurrence_rule":    rule,
+		"affected_services": []map[string]interface{}{
+			{"service_id": serviceID, "status": "maintenance"},
+		},
+	}
+	if end != nil {
+		body["recurrence_end_date"] = end.Format(time.RFC3339)
+	}
+	resp, err := client.POST("/api/v1/events", body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			ID             string `json:"id"`
+			RecurrenceRule string `json:"recurrence_rule"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	require.Equal(t, rule, result.Data.RecurrenceRule)
+
+	id := result.Data.ID
+	t.Cleanup(func() {
+		for _, o := range listOccurrences(t, id) {
+			deleteEvent(t, client, o.ID)
+		}
+		deleteEvent(t, client, id)
+	})
+	return id
+}
+
+type occurrence struct {
+	ID    string
+	Start time.Time
+}
+
+func listOccurrences(t *testing.T, parentID string) []occurrence {
+	t.Helper()
+	rows, err := testDB.Query(context.Background(),
+		`SELECT id, scheduled_start_at FROM events WHERE parent_event_id = $1 ORDER BY scheduled_start_at`, parentID)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var result []occurrence
+	for rows.Next() {
+		var o occurrence
+		require.NoError(t, rows.Scan(&o.ID, &o.Start))
+		result = append(result, o)
+	}
+	require.NoError(t, rows.Err())
+	return result
+}
+
+// runRecurrenceScheduler runs a scheduler with the given lead time for a few polls.
+func runRecurrenceScheduler(t *testing.T, leadTime time.Duration) {
+	t.Helper()
+	catalogService := catalog.NewService(catalogpostgres.NewRepository(testDB), nil)
+	eventsRepo := eventspostgres.NewRepository(testDB)
+	eventsService := events.NewService(eventsRepo, catalogService, catalogService, nil, nil, nil)
+	scheduler := events.NewRecurrenceScheduler(events.RecurrenceConfig{
+		LeadTime:     leadTime,
+		PollInterval: 100 * time.Millisecond,
+	}, eventsRepo, eventsService)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.Start(ctx)
+	time.Sleep(500 * time.Millisecond)
+	cancel()
+	scheduler.Stop()
+}
+
+func TestRecurrence_Weekly(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "recurrence-weekly")
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		deleteService(t, client, serviceSlug)
+	})
+
+	start := time.Now().UTC().Add(time.Hour).Truncate(time.Minute)
+	rule := fmt.Sprintf("FREQ=WEEKLY;BYDAY=%s;BYHOUR=%d;BYMINUTE=%d", rruleDays[start.Weekday()], start.Hour(), start.Minute())
+	parentID := createRecurringMaintenance(t, client, serviceID, start, rule, nil)
+
+	// Only the occurrence a week later is within the lead time; repeated polls don't duplicate it
+	runRecurrenceScheduler(t, 8*24*time.Hour)
+
+	occurrences := listOccurrences(t, parentID)
+	require.Len(t, occurrences, 1)
+	assert.True(t, start.AddDate(0, 0, 7).Equal(occurrences[0].Start), "got %s", occurrences[0].Start)
+
+	resp, err := client.GET("/api/v1/events/" + occurrences[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data struct {
+			Status         string    `json:"status"`
+			ParentEventID  string    `json:"parent_event_id"`
+			RecurrenceRule string    `json:"recurrence_rule"`
+			ScheduledEndAt time.Time `json:"scheduled_end_at"`
+			ServiceIDs     []string  `json:"service_ids"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, "scheduled", result.Data.Status)
+	assert.Equal(t, parentID, result.Data.ParentEventID)
+	assert.Empty(t, result.Data.RecurrenceRule, "occurrences don't recur themselves")
+	assert.True(t, start.AddDate(0, 0, 7).Add(2*time.Hour).Equal(result.Data.ScheduledEndAt))
+	assert.Equal(t, []string{serviceID}, result.Data.ServiceIDs)
+}
+
+func TestRecurrence_Biweekly(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "recurrence-biweekly")
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		deleteService(t, client, serviceSlug)
+	})
+
+	start := time.Now().UTC().Add(time.Hour).Truncate(time.Minute)
+	parentID := createRecurringMaintenance(t, client, serviceID, start, "FREQ=WEEKLY;INTERVAL=2", nil)
+
+	// A week later is skipped, two weeks later is the next occurrence
+	runRecurrenceScheduler(t, 15*24*time.Hour)
+
+	occurrences := listOccurrences(t, parentID)
+	require.Len(t, occurrences, 1)
+	assert.True(t, start.AddDate(0, 0, 14).Equal(occurrences[0].Start), "got %s", occurrences[0].Start)
+}
+
+func TestRecurrence_EndDate(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "recurrence-end")
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		deleteService(t, client, serviceSlug)
+	})
+
+	start := time.Now().UTC().Add(time.Hour).Truncate(time.Minute)
+	end := start.AddDate(0, 0, 10)
+	parentID := createRecurringMaintenance(t, client, serviceID, start, "FREQ=WEEKLY", &end)
+
+	// Two weeks are within the lead time, but the series ends after the first week
+	runRecurrenceScheduler(t, 15*24*time.Hour)
+
+	occurrences := listOccurrences(t, parentID)
+	require.Len(t, occurrences, 1)
+	assert.True(t, start.AddDate(0, 0, 7).Equal(occurrences[0].Start), "got %s", occurrences[0].Start)
+}
+
+func TestRecurrence_Validation(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	start := time.Now().UTC().Add(time.Hour)
+	tests := []struct {
+		name string
+		body map[string]interface{}
+	}{
+		{
+			name: "incident",
+			body: map[string]interface{}{
+				"title": "Recurring incident", "type": "incident", "status": "investigating",
+				"severity": "minor", "description": "x", "recurrence_rule": "FREQ=WEEKLY",
+			},
+		},
+		{
+			name: "maintenance without window",
+			body: map[string]interface{}{
+				"title": "Recurring maintenance", "type": "maintenance", "status": "scheduled",
+				"description": "x", "recurrence_rule": "FREQ=WEEKLY",
+			},
+		},
+		{
+			name: "unsupported rule",
+			body: map[string]interface{}{
+				"title": "Recurring maintenance", "type": "maintenance", "status": "scheduled",
+				"description":        "x",
+				"scheduled_start_at": start.Format(time.RFC3339),
+				"scheduled_end_at":   start.Add(time.Hour).Format(time.RFC3339),
+				"recurrence_rule":    "FREQ=DAILY",
+			},
+		},
+		{
+			name: "end date without rule",
+			body: map[string]interface{}{
+				"title": "Recurring maintenance", "type": "maintenance", "status": "scheduled",
+				"description":         "x",
+				"scheduled_start_at":  start.Format(time.RFC3339),
+				"scheduled_end_at":    start.Add(time.Hour).Format(time.RFC3339),
+				"recurrence_end_date": start.AddDate(0, 1, 0).Format(time.RFC3339),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.POST("/api/v1/events", tt.body)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
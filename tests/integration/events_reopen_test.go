@@ -0,0 +1,104 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reopenEvent posts a reopen and returns the status code and the event on success.
+func reopenEvent(t *testing.T, client *testutil.Client, eventID string) (int, domain.Event) {
+	t.Helper()
+	resp, err := client.POST("/api/v1/events/"+eventID+"/reopen", nil)
+	require.NoError(t, err)
+	var result struct {
+		Data domain.Event `json:"data"`
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return resp.StatusCode, result.Data
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return resp.StatusCode, result.Data
+}
+
+func TestEventReopen(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "reopen-service")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	eventID := createTestIncident(t, client, "Reopened incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "partial_outage"}}, nil)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) }) // resolved again at the end
+
+	// An active incident is not reopenable
+	code, _ := reopenEvent(t, client, eventID)
+	assert.Equal(t, http.StatusConflict, code)
+
+	resolveEvent(t, client, eventID)
+	require.Equal(t, "operational", getServiceEffectiveStatus(t, client, serviceSlug))
+
+	code, event := reopenEvent(t, client, eventID)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, domain.EventStatusInvestigating, event.Status)
+	assert.Nil(t, event.ResolvedAt)
+
+	assert.Equal(t, "partial_outage", getServiceEffectiveStatus(t, client, serviceSlug), "the reopened incident affects its services again")
+
+	updates := listEventUpdates(t, client, eventID, "")
+	messages := make([]string, len(updates.Updates))
+	for i, u := range updates.Updates {
+		messages[i] = u.Message
+	}
+	assert.Contains(t, messages, "Event reopened")
+
+	assert.Equal(t, 1, countRows(t, `
+		SELECT COUNT(*) FROM service_status_log
+		WHERE event_id = $1 AND new_status = 'partial_outage' AND reason LIKE 'Event reopened:%'`, eventID))
+
+	// Reopened once: it's active now
+	code, _ = reopenEvent(t, client, eventID)
+	assert.Equal(t, http.StatusConflict, code)
+
+	// The reopened incident can be resolved again
+	resolveEvent(t, client, eventID)
+	assert.Equal(t, "operational", getServiceEffectiveStatus(t, client, serviceSlug))
+	assert.NotNil(t, getEvent(t, client, eventID).ResolvedAt)
+}
+
+func TestEventReopen_Maintenance(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	eventID := createTestMaintenance(t, client, "Not reopenable maintenance", nil)
+	completeMaintenance(t, client, eventID)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	code, _ := reopenEvent(t, client, eventID)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestEventReopen_Errors(t *testing.T) {
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	eventID := createTestIncident(t, admin, "Reopen permissions", nil, nil)
+	resolveEvent(t, admin, eventID)
+	t.Cleanup(func() { deleteEvent(t, admin, eventID) })
+
+	operator := newTestClient(t)
+	operator.LoginAsOperator(t)
+	code, _ := reopenEvent(t, operator, eventID)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, _ = reopenEvent(t, admin, "00000000-0000-0000-0000-000000000000")
+	assert.Equal(t, http.StatusNotFound, code)
+}
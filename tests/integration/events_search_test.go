@@ -0,0 +1,67 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvents_Search(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	// Unique marker keeps results independent of other events in the shared database
+	marker := fmt.Sprintf("srch%d", time.Now().UnixNano())
+
+	titleID := createTestIncident(t, client, "Checkout "+marker+" outage", nil, nil)
+	partialID := createTestIncident(t, client, "Payments "+strings.ToUpper(marker)+"X latency", nil, nil)
+	descriptionID := createTestIncident(t, client, "Unrelated title", nil, nil,
+		withEventDescription("Root cause in "+marker+" cluster"))
+	otherID := createTestIncident(t, client, "Search other "+time.Now().Format("150405"), nil, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		for _, id := range []string{titleID, partialID, descriptionID, otherID} {
+			resolveEvent(t, client, id)
+			deleteEvent(t, client, id)
+		}
+	})
+
+	publicClient := newTestClient(t)
+
+	t.Run("matches title and description case-insensitively", func(t *testing.T) {
+		page := getEventsPage(t, publicClient, "q="+url.QueryEscape(marker))
+		assert.ElementsMatch(t, []string{titleID, partialID, descriptionID}, eventIDs(page.Events))
+		assert.Equal(t, 3, page.Total)
+	})
+
+	t.Run("partial match in title", func(t *testing.T) {
+		page := getEventsPage(t, publicClient, "q="+url.QueryEscape("checkout "+marker))
+		assert.Equal(t, []string{titleID}, eventIDs(page.Events))
+	})
+
+	t.Run("combined with other filters", func(t *testing.T) {
+		page := getEventsPage(t, publicClient, "type=maintenance&q="+url.QueryEscape(marker))
+		assert.Empty(t, page.Events)
+		assert.Equal(t, 0, page.Total)
+	})
+
+	t.Run("wildcards match literally", func(t *testing.T) {
+		page := getEventsPage(t, publicClient, "q="+url.QueryEscape(marker+"%"))
+		assert.Empty(t, page.Events)
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		resp, err := publicClient.GET("/api/v1/events?q=" + strings.Repeat("a", 201))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,84 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type eventStartResponse struct {
+	Data struct {
+		ID        string     `json:"id"`
+		StartedAt *time.Time `json:"started_at"`
+		CreatedAt time.Time  `json:"created_at"`
+	} `json:"data"`
+}
+
+func getEventStart(t *testing.T, client *testutil.Client, eventID string) eventStartResponse {
+	t.Helper()
+	resp, err := client.GET("/api/v1/events/" + eventID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result eventStartResponse
+	testutil.DecodeJSON(t, resp, &result)
+	return result
+}
+
+func TestEvents_StartedAt_Past(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	startedAt := time.Now().Add(-72 * time.Hour).UTC().Truncate(time.Second)
+	eventID := createTestIncident(t, client, "Started At Past Incident", nil, nil,
+		func(m map[string]interface{}) { m["started_at"] = startedAt.Format(time.RFC3339) })
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	event := getEventStart(t, client, eventID)
+	require.NotNil(t, event.Data.StartedAt)
+	assert.True(t, startedAt.Equal(*event.Data.StartedAt), "imported start is stored as given")
+	assert.True(t, event.Data.CreatedAt.After(*event.Data.StartedAt))
+}
+
+func TestEvents_StartedAt_FutureRejected(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	resp, err := client.POST("/api/v1/events", map[string]interface{}{
+		"title":       "Started At Future Incident",
+		"type":        "incident",
+		"status":      "investigating",
+		"severity":    "minor",
+		"description": "Not yet",
+		"started_at":  time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestEvents_StartedAt_OmittedDefaultsToCreation(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	eventID := createTestIncident(t, client, "Started At Omitted Incident", nil, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	event := getEventStart(t, client, eventID)
+	require.NotNil(t, event.Data.StartedAt)
+	assert.WithinDuration(t, event.Data.CreatedAt, *event.Data.StartedAt, time.Second)
+}
@@ -0,0 +1,136 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type eventStats struct {
+	Window string `json:"window"`
+	Total  int    `json:"total"`
+	ByType struct {
+		Incident    int `json:"incident"`
+		Maintenance int `json:"maintenance"`
+	} `json:"by_type"`
+	BySeverity struct {
+		Critical int `json:"critical"`
+		Major    int `json:"major"`
+		Minor    int `json:"minor"`
+	} `json:"by_severity"`
+	ResolvedIncidents        int     `json:"resolved_incidents"`
+	MeanTimeToResolveSeconds float64 `json:"mean_time_to_resolve_seconds"`
+}
+
+func getEventStats(t *testing.T, client *testutil.Client, window string) eventStats {
+	t.Helper()
+	resp, err := client.GET("/api/v1/stats/events?window=" + window)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data eventStats `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+// backdateStatsEvent moves an event daysAgo days into the past; a resolved event
+// is resolved resolvedAfterSeconds after it started.
+func backdateStatsEvent(t *testing.T, eventID string, daysAgo, resolvedAfterSeconds int) {
+	t.Helper()
+	_, err := testDB.Exec(context.Background(), `
+		UPDATE events SET
+			created_at = NOW() - $2 * INTERVAL '1 day',
+			started_at = NOW() - $2 * INTERVAL '1 day',
+			resolved_at = CASE WHEN resolved_at IS NULL THEN NULL
+				ELSE NOW() - $2 * INTERVAL '1 day' + $3 * INTERVAL '1 second' END
+		WHERE id = $1`, eventID, daysAgo, resolvedAfterSeconds)
+	require.NoError(t, err)
+}
+
+func TestEventStats(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	critical := createTestIncident(t, client, "Stats critical", nil, nil, withSeverity("critical"))
+	resolveEvent(t, client, critical)
+	t.Cleanup(func() { deleteEvent(t, client, critical) })
+
+	minor := createTestIncident(t, client, "Stats minor", nil, nil, withSeverity("minor"))
+	resolveEvent(t, client, minor)
+	t.Cleanup(func() { deleteEvent(t, client, minor) })
+
+	major := createTestIncident(t, client, "Stats major", nil, nil, withSeverity("major"))
+	t.Cleanup(func() {
+		resolveEvent(t, client, major)
+		deleteEvent(t, client, major)
+	})
+
+	maintenance := createTestMaintenance(t, client, "Stats maintenance", nil)
+	t.Cleanup(func() {
+		completeMaintenance(t, client, maintenance)
+		deleteEvent(t, client, maintenance)
+	})
+
+	// Every other event in the test database was created within the last 30 days,
+	// so the difference between the 90d and 30d windows is exactly the seeded events.
+	backdateStatsEvent(t, critical, 60, 7200)
+	backdateStatsEvent(t, minor, 50, 14400)
+	backdateStatsEvent(t, major, 45, 0)
+	backdateStatsEvent(t, maintenance, 70, 0)
+
+	recent := getEventStats(t, client, "30d")
+	all := getEventStats(t, client, "90d")
+	assert.Equal(t, "90d", all.Window)
+
+	assert.Equal(t, 4, all.Total-recent.Total)
+	assert.Equal(t, 3, all.ByType.Incident-recent.ByType.Incident)
+	assert.Equal(t, 1, all.ByType.Maintenance-recent.ByType.Maintenance)
+	assert.Equal(t, 1, all.BySeverity.Critical-recent.BySeverity.Critical)
+	assert.Equal(t, 1, all.BySeverity.Major-recent.BySeverity.Major)
+	assert.Equal(t, 1, all.BySeverity.Minor-recent.BySeverity.Minor)
+	require.Equal(t, 2, all.ResolvedIncidents-recent.ResolvedIncidents, "the open incident has no resolution time")
+
+	seededTotal := all.MeanTimeToResolveSeconds*float64(all.ResolvedIncidents) -
+		recent.MeanTimeToResolveSeconds*float64(recent.ResolvedIncidents)
+	assert.InDelta(t, 7200+14400, seededTotal, 0.01)
+	assert.InDelta(t, 10800, seededTotal/2, 0.01)
+}
+
+func TestEventStats_DefaultWindow(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	stats := getEventStats(t, client, "")
+	assert.Equal(t, "30d", stats.Window)
+	assert.Equal(t, stats.Total, stats.ByType.Incident+stats.ByType.Maintenance)
+}
+
+func TestEventStats_InvalidWindow(t *testing.T) {
+	client := newTestClientWithoutValidation()
+	client.LoginAsAdmin(t)
+
+	for _, window := range []string{"month", "0d", "-7d"} {
+		resp, err := client.GET("/api/v1/stats/events?window=" + window)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, window)
+	}
+}
+
+func TestEventStats_RequiresAdmin(t *testing.T) {
+	operator := newTestClient(t)
+	operator.LoginAsOperator(t)
+
+	resp, err := operator.GET("/api/v1/stats/events")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
@@ -0,0 +1,84 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getStatusEventIDs(t *testing.T, client *testutil.Client, query string) []string {
+	t.Helper()
+	resp, err := client.GET("/api/v1/status" + query)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			Events []struct {
+				ID string `json:"id"`
+			} `json:"events"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	ids := make([]string, len(result.Data.Events))
+	for i, e := range result.Data.Events {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+func TestPublicStatus_RecentResolved(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	active := createTestIncident(t, client, "Status active", nil, nil)
+	t.Cleanup(func() {
+		resolveEvent(t, client, active)
+		deleteEvent(t, client, active)
+	})
+	earlier := createTestIncident(t, client, "Status resolved earlier", nil, nil)
+	resolveEvent(t, client, earlier)
+	t.Cleanup(func() { deleteEvent(t, client, earlier) })
+	recent := createTestIncident(t, client, "Status resolved recently", nil, nil)
+	resolveEvent(t, client, recent)
+	t.Cleanup(func() { deleteEvent(t, client, recent) })
+
+	_, err := testDB.Exec(context.Background(),
+		`UPDATE events SET resolved_at = NOW() - INTERVAL '48 hours' WHERE id = $1`, earlier)
+	require.NoError(t, err)
+
+	public := newTestClient(t)
+
+	ids := getStatusEventIDs(t, public, "")
+	assert.Contains(t, ids, active)
+	assert.NotContains(t, ids, recent, "resolved events are omitted by default")
+	assert.NotContains(t, ids, earlier)
+
+	assert.Equal(t, ids, getStatusEventIDs(t, public, "?include_recent_resolved_hours=0"))
+
+	ids = getStatusEventIDs(t, public, "?include_recent_resolved_hours=24")
+	assert.Contains(t, ids, active)
+	assert.Contains(t, ids, recent)
+	assert.NotContains(t, ids, earlier, "resolved outside the window")
+
+	ids = getStatusEventIDs(t, public, "?include_recent_resolved_hours=72")
+	assert.Contains(t, ids, recent)
+	assert.Contains(t, ids, earlier)
+}
+
+func TestPublicStatus_InvalidRecentResolved(t *testing.T) {
+	client := newTestClientWithoutValidation()
+
+	for _, hours := range []string{"-1", "day", "1.5"} {
+		resp, err := client.GET("/api/v1/status?include_recent_resolved_hours=" + hours)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, hours)
+	}
+}
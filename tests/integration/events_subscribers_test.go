@@ -0,0 +1,116 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type eventSubscribersResponse struct {
+	Data []struct {
+		ChannelID    string `json:"channel_id"`
+		ChannelType  string `json:"channel_type"`
+		MaskedTarget string `json:"masked_target"`
+		IsVerified   bool   `json:"is_verified"`
+	} `json:"data"`
+}
+
+func listEventSubscribers(t *testing.T, client *testutil.Client, eventID string) eventSubscribersResponse {
+	t.Helper()
+	resp, err := client.GET("/api/v1/events/" + eventID + "/subscribers")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result eventSubscribersResponse
+	testutil.DecodeJSON(t, resp, &result)
+	return result
+}
+
+func TestEventSubscribers_List(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "Event Subscribers Svc")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	eventID := createTestIncident(t, client, "Event Subscribers Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	client.LoginAsUser(t)
+	channelID := createTelegramChannel(t, client, "123456789")
+	t.Cleanup(func() {
+		client.LoginAsUser(t)
+		deleteChannel(t, client, channelID)
+	})
+	verifyTelegramChannel(t, client, channelID)
+
+	// Snapshot taken by the notifier at event creation
+	repo := notificationspostgres.NewRepository(testDB)
+	require.NoError(t, repo.CreateEventSubscribers(context.Background(), eventID, []string{channelID}))
+
+	client.LoginAsOperator(t)
+	result := listEventSubscribers(t, client, eventID)
+	require.Len(t, result.Data, 1)
+	subscriber := result.Data[0]
+	assert.Equal(t, channelID, subscriber.ChannelID)
+	assert.Equal(t, "telegram", subscriber.ChannelType)
+	assert.Equal(t, "123***789", subscriber.MaskedTarget)
+	assert.True(t, subscriber.IsVerified)
+}
+
+func TestEventSubscribers_NoSnapshot_EmptyArray(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	eventID := createTestIncident(t, client, "Event Subscribers Empty Incident", nil, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	result := listEventSubscribers(t, client, eventID)
+	assert.NotNil(t, result.Data)
+	assert.Empty(t, result.Data)
+}
+
+func TestEventSubscribers_Errors(t *testing.T) {
+	t.Run("unknown event", func(t *testing.T) {
+		client := newTestClient(t)
+		client.LoginAsOperator(t)
+
+		resp, err := client.GET("/api/v1/events/00000000-0000-0000-0000-000000000000/subscribers")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("user is forbidden", func(t *testing.T) {
+		client := newTestClient(t)
+		client.LoginAsUser(t)
+
+		resp, err := client.GET("/api/v1/events/00000000-0000-0000-0000-000000000000/subscribers")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("requires auth", func(t *testing.T) {
+		resp, err := newTestClient(t).GET("/api/v1/events/00000000-0000-0000-0000-000000000000/subscribers")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
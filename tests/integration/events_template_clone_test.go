@@ -0,0 +1,94 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type templateResponse struct {
+	Data struct {
+		ID            string `json:"id"`
+		Slug          string `json:"slug"`
+		Type          string `json:"type"`
+		TitleTemplate string `json:"title_template"`
+		BodyTemplate  string `json:"body_template"`
+	} `json:"data"`
+}
+
+func TestTemplateClone_Success(t *testing.T) {
+	slug := createTestTemplate(t, "{{.ServiceName}} maintenance", "Planned work on {{.ServiceName}}")
+
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	newSlug := testutil.RandomSlug("tmpl-clone")
+	resp, err := client.POST("/api/v1/templates/"+slug+"/clone", map[string]string{"new_slug": newSlug})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var cloned templateResponse
+	testutil.DecodeJSON(t, resp, &cloned)
+	t.Cleanup(func() {
+		admin := newTestClient(t)
+		admin.LoginAsAdmin(t)
+		resp, err := admin.DELETE("/api/v1/templates/" + cloned.Data.ID)
+		if err == nil {
+			resp.Body.Close()
+		}
+	})
+
+	assert.NotEmpty(t, cloned.Data.ID)
+	assert.Equal(t, newSlug, cloned.Data.Slug)
+	assert.Equal(t, "incident", cloned.Data.Type)
+	assert.Equal(t, "{{.ServiceName}} maintenance", cloned.Data.TitleTemplate)
+	assert.Equal(t, "Planned work on {{.ServiceName}}", cloned.Data.BodyTemplate)
+
+	// Clone is usable on its own
+	resp, err = client.POST("/api/v1/templates/"+newSlug+"/preview", map[string]string{"service_name": "DB"})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestTemplateClone_SourceNotFound(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	resp, err := client.POST("/api/v1/templates/nonexistent-template/clone", map[string]string{
+		"new_slug": testutil.RandomSlug("tmpl-clone"),
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestTemplateClone_DuplicateSlug(t *testing.T) {
+	slug := createTestTemplate(t, "Title", "Body")
+	otherSlug := createTestTemplate(t, "Other title", "Other body")
+
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	resp, err := client.POST("/api/v1/templates/"+slug+"/clone", map[string]string{"new_slug": otherSlug})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestTemplateClone_MissingNewSlug(t *testing.T) {
+	slug := createTestTemplate(t, "Title", "Body")
+
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	resp, err := client.POST("/api/v1/templates/"+slug+"/clone", map[string]string{})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
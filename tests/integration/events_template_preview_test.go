@@ -0,0 +1,94 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestTemplate creates a template as admin and returns its slug.
+func createTestTemplate(t *testing.T, titleTemplate, bodyTemplate string) string {
+	t.Helper()
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	slug := testutil.RandomSlug("tmpl")
+	resp, err := admin.POST("/api/v1/templates", map[string]string{
+		"slug":           slug,
+		"type":           "incident",
+		"title_template": titleTemplate,
+		"body_template":  bodyTemplate,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+
+	t.Cleanup(func() {
+		resp, err := admin.DELETE("/api/v1/templates/" + result.Data.ID)
+		if err == nil {
+			resp.Body.Close()
+		}
+	})
+	return slug
+}
+
+func TestTemplatePreview_OperatorRendersVariables(t *testing.T) {
+	slug := createTestTemplate(t, "{{.ServiceName}} outage", "Affected region: {{.Variables.region}}")
+
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	resp, err := client.POST("/api/v1/templates/"+slug+"/preview", map[string]interface{}{
+		"service_name": "API",
+		"variables":    map[string]string{"region": "eu-west-1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			Title string `json:"title"`
+			Body  string `json:"body"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, "API outage", result.Data.Title)
+	assert.Equal(t, "Affected region: eu-west-1", result.Data.Body)
+}
+
+func TestTemplatePreview_MissingVariable(t *testing.T) {
+	slug := createTestTemplate(t, "Outage", "Affected region: {{.Variables.region}}")
+
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	resp, err := client.POST("/api/v1/templates/"+slug+"/preview", map[string]interface{}{
+		"variables": map[string]string{"zone": "a"},
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestTemplatePreview_UserForbidden(t *testing.T) {
+	slug := createTestTemplate(t, "Outage", "Body")
+
+	client := newTestClient(t)
+	client.LoginAsUser(t)
+
+	resp, err := client.POST("/api/v1/templates/"+slug+"/preview", map[string]interface{}{})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
@@ -0,0 +1,157 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type eventTimelineEntry struct {
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Update    *struct {
+		ID      string `json:"id"`
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"update"`
+	ServiceChange *struct {
+		ID        string  `json:"id"`
+		Action    string  `json:"action"`
+		ServiceID *string `json:"service_id"`
+	} `json:"service_change"`
+	Postmortem *struct {
+		Title       string     `json:"title"`
+		PublishedAt *time.Time `json:"published_at"`
+	} `json:"postmortem"`
+}
+
+func getEventTimeline(t *testing.T, client *testutil.Client, eventID string) []eventTimelineEntry {
+	t.Helper()
+	resp, err := client.GET("/api/v1/events/" + eventID + "/timeline")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data []eventTimelineEntry `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+// countList returns the number of items of a {"data": [...]} list endpoint.
+func countList(t *testing.T, client *testutil.Client, path string) int {
+	t.Helper()
+	resp, err := client.GET(path)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data []struct{} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return len(result.Data)
+}
+
+func TestEvents_Timeline(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID1, slug1 := createTestService(t, client, "Timeline Service 1")
+	t.Cleanup(func() { deleteService(t, client, slug1) })
+	serviceID2, slug2 := createTestService(t, client, "Timeline Service 2")
+	t.Cleanup(func() { deleteService(t, client, slug2) })
+
+	eventID := createTestIncident(t, client, "Timeline incident",
+		[]AffectedService{{ServiceID: serviceID1, Status: "degraded"}}, nil)
+
+	addEventUpdate(t, client, eventID, "identified", "Root cause found")
+
+	resp, err := client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+		"status":       "monitoring",
+		"message":      "Second service affected",
+		"add_services": []map[string]string{{"service_id": serviceID2, "status": "partial_outage"}},
+		"reason":       "Spread to second service",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	resolveEvent(t, client, eventID)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	public := newTestClient(t)
+
+	t.Run("updates and service changes in order", func(t *testing.T) {
+		timeline := getEventTimeline(t, public, eventID)
+
+		updates := countList(t, public, "/api/v1/events/"+eventID+"/updates")
+		changes := countList(t, public, "/api/v1/events/"+eventID+"/changes")
+		require.Len(t, timeline, updates+changes)
+
+		var messages []string
+		var addedServices []string
+		for i, entry := range timeline {
+			if i > 0 {
+				assert.False(t, entry.CreatedAt.Before(timeline[i-1].CreatedAt), "entry %d is out of order", i)
+			}
+			switch entry.Type {
+			case "update":
+				require.NotNil(t, entry.Update)
+				assert.Nil(t, entry.ServiceChange)
+				assert.NotEmpty(t, entry.Update.ID)
+				messages = append(messages, entry.Update.Message)
+			case "service_change":
+				require.NotNil(t, entry.ServiceChange)
+				assert.Nil(t, entry.Update)
+				assert.Equal(t, "added", entry.ServiceChange.Action)
+				require.NotNil(t, entry.ServiceChange.ServiceID)
+				addedServices = append(addedServices, *entry.ServiceChange.ServiceID)
+			default:
+				t.Errorf("unexpected entry type %q", entry.Type)
+			}
+		}
+
+		assert.Equal(t, []string{"Root cause found", "Second service affected", "Fixed"}, messages[len(messages)-3:])
+		assert.Equal(t, []string{serviceID1, serviceID2}, addedServices)
+	})
+
+	t.Run("published post-mortem is the last entry", func(t *testing.T) {
+		status, _ := putPostmortem(t, client, eventID, map[string]interface{}{
+			"title": "Timeline post-mortem",
+			"body":  "Draft",
+		})
+		require.Equal(t, http.StatusOK, status)
+
+		for _, entry := range getEventTimeline(t, public, eventID) {
+			assert.NotEqual(t, "postmortem", entry.Type, "drafts are not on the timeline")
+		}
+
+		status, _ = putPostmortem(t, client, eventID, map[string]interface{}{
+			"title":        "Timeline post-mortem",
+			"body":         "Final analysis",
+			"published_at": time.Now().UTC().Format(time.RFC3339Nano),
+		})
+		require.Equal(t, http.StatusOK, status)
+
+		timeline := getEventTimeline(t, public, eventID)
+		last := timeline[len(timeline)-1]
+		assert.Equal(t, "postmortem", last.Type)
+		require.NotNil(t, last.Postmortem)
+		assert.Equal(t, "Timeline post-mortem", last.Postmortem.Title)
+		require.NotNil(t, last.Postmortem.PublishedAt)
+		assert.True(t, last.CreatedAt.Equal(*last.Postmortem.PublishedAt), "post-mortem entry is placed at its publication")
+	})
+}
+
+func TestEvents_Timeline_NotFound(t *testing.T) {
+	resp, err := newTestClient(t).GET("/api/v1/events/00000000-0000-0000-0000-000000000000/timeline")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
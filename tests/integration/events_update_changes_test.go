@@ -0,0 +1,150 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fieldChange struct {
+	From *string `json:"from"`
+	To   string  `json:"to"`
+}
+
+type eventUpdateWithChanges struct {
+	ID      string                 `json:"id"`
+	Status  string                 `json:"status"`
+	Changes map[string]fieldChange `json:"changes"`
+}
+
+// postEventUpdate posts an update and returns it with its changes.
+func postEventUpdate(t *testing.T, client *testutil.Client, eventID string, body map[string]interface{}) eventUpdateWithChanges {
+	t.Helper()
+	resp, err := client.POST("/api/v1/events/"+eventID+"/updates", body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result struct {
+		Data eventUpdateWithChanges `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+func TestEvents_UpdateChanges_SeverityEscalation(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "Update Changes Svc")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	eventID := createTestIncident(t, client, "Update Changes Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil, withSeverity("minor"))
+	t.Cleanup(func() {
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	escalation := postEventUpdate(t, client, eventID, map[string]interface{}{
+		"status":   "investigating",
+		"severity": "major",
+		"message":  "Impact is wider than expected",
+	})
+	require.Contains(t, escalation.Changes, "severity")
+	require.NotNil(t, escalation.Changes["severity"].From)
+	assert.Equal(t, "minor", *escalation.Changes["severity"].From)
+	assert.Equal(t, "major", escalation.Changes["severity"].To)
+	assert.NotContains(t, escalation.Changes, "status")
+
+	identified := postEventUpdate(t, client, eventID, map[string]interface{}{
+		"status":  "identified",
+		"message": "Root cause found",
+	})
+	require.Contains(t, identified.Changes, "status")
+	assert.Equal(t, "investigating", *identified.Changes["status"].From)
+	assert.Equal(t, "identified", identified.Changes["status"].To)
+	assert.NotContains(t, identified.Changes, "severity")
+
+	// The diff is persisted and returned by the updates list
+	resp, err := client.GET("/api/v1/events/" + eventID + "/updates")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var list struct {
+		Data struct {
+			Updates []eventUpdateWithChanges `json:"updates"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &list)
+
+	byID := make(map[string]eventUpdateWithChanges, len(list.Data.Updates))
+	for _, u := range list.Data.Updates {
+		byID[u.ID] = u
+	}
+	require.Contains(t, byID, escalation.ID)
+	assert.Equal(t, escalation.Changes, byID[escalation.ID].Changes)
+	assert.Equal(t, identified.Changes, byID[identified.ID].Changes)
+
+	// The event itself is escalated
+	resp, err = client.GET("/api/v1/events/" + eventID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var event struct {
+		Data struct {
+			Severity string `json:"severity"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &event)
+	assert.Equal(t, "major", event.Data.Severity)
+}
+
+func TestEvents_UpdateChanges_NoChanges(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "Update No Changes Svc")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	eventID := createTestIncident(t, client, "Update No Changes Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	t.Cleanup(func() {
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	update := postEventUpdate(t, client, eventID, map[string]interface{}{
+		"status":  "investigating",
+		"message": "Still looking",
+	})
+	assert.Empty(t, update.Changes)
+}
+
+func TestEvents_UpdateChanges_SeverityOnMaintenance(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "Update Severity Maint Svc")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	eventID := createTestMaintenance(t, client, "Update Severity Maintenance",
+		[]AffectedService{{ServiceID: serviceID, Status: "maintenance"}})
+	t.Cleanup(func() {
+		completeMaintenance(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	resp, err := client.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+		"status":   "in_progress",
+		"severity": "major",
+		"message":  "Escalate",
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
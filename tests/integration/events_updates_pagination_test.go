@@ -0,0 +1,97 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type eventUpdatesPage struct {
+	Updates []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	} `json:"updates"`
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+func listEventUpdates(t *testing.T, client *testutil.Client, eventID, query string) eventUpdatesPage {
+	t.Helper()
+	resp, err := client.GET("/api/v1/events/" + eventID + "/updates" + query)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data eventUpdatesPage `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+func TestEventUpdates_Pagination(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	eventID := createTestIncident(t, client, "Long-lived incident", nil, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		deleteEvent(t, client, eventID)
+	})
+
+	const added = 25
+	statuses := []string{"identified", "monitoring"}
+	for i := 1; i <= added; i++ {
+		addEventUpdate(t, client, eventID, statuses[i%2], fmt.Sprintf("Update %d", i))
+	}
+
+	first := listEventUpdates(t, client, eventID, "")
+	require.GreaterOrEqual(t, first.Total, added)
+	assert.Equal(t, 20, first.Limit)
+	assert.Equal(t, 0, first.Offset)
+	require.Len(t, first.Updates, 20)
+	assert.Equal(t, fmt.Sprintf("Update %d", added), first.Updates[0].Message, "newest first")
+	assert.Equal(t, fmt.Sprintf("Update %d", added-1), first.Updates[1].Message)
+
+	second := listEventUpdates(t, client, eventID, "?limit=20&offset=20")
+	assert.Equal(t, first.Total, second.Total)
+	require.Len(t, second.Updates, first.Total-20)
+
+	seen := make(map[string]bool, first.Total)
+	for _, u := range append(first.Updates, second.Updates...) {
+		assert.False(t, seen[u.ID], "update %s on both pages", u.ID)
+		seen[u.ID] = true
+	}
+	assert.Len(t, seen, first.Total)
+
+	small := listEventUpdates(t, client, eventID, "?limit=5&offset=1")
+	require.Len(t, small.Updates, 5)
+	assert.Equal(t, fmt.Sprintf("Update %d", added-1), small.Updates[0].Message)
+
+	beyond := listEventUpdates(t, client, eventID, "?offset=1000")
+	assert.Empty(t, beyond.Updates)
+	assert.Equal(t, first.Total, beyond.Total)
+}
+
+func TestEventUpdates_Pagination_InvalidParams(t *testing.T) {
+	client := newTestClientWithoutValidation()
+	client.LoginAsOperator(t)
+
+	eventID := createTestIncident(t, client, "Pagination params", nil, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		deleteEvent(t, client, eventID)
+	})
+
+	for _, query := range []string{"?limit=0", "?limit=abc", "?offset=-1"} {
+		resp, err := client.GET("/api/v1/events/" + eventID + "/updates" + query)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
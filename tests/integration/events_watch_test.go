@@ -0,0 +1,115 @@
+//go:build integration
+
+package integration
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type watchLine struct {
+	Type   string `json:"type"`
+	Object struct {
+		ID     string `json:"id"`
+		Title  string `json:"title"`
+		Status string `json:"status"`
+	} `json:"object"`
+}
+
+func TestEvents_Watch(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Watch Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	resp, err := http.Get(testServer.URL + "/api/v1/watch/events")
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	// Consumer: collects lines of the stream, the modifier's event is picked out below
+	lines := make(chan watchLine, 64)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			var line watchLine
+			if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+				t.Errorf("invalid watch line %q: %v", scanner.Text(), err)
+				return
+			}
+			lines <- line
+		}
+	}()
+
+	// Modifier: creates, updates and deletes an event. Each step waits until the
+	// consumer has seen the previous one, since the stream reports the current state
+	// of the event and a later step could otherwise overtake an earlier one.
+	eventIDs := make(chan string, 1)
+	seen := make(chan struct{})
+	go func() {
+		eventID := createTestIncident(t, client, "Watched incident",
+			[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+		eventIDs <- eventID
+		steps := []func(){
+			func() { addEventUpdate(t, client, eventID, "identified", "Root cause found") },
+			func() { resolveEvent(t, client, eventID) },
+			func() { deleteEvent(t, client, eventID) },
+		}
+		for _, step := range steps {
+			if _, ok := <-seen; !ok {
+				return
+			}
+			step()
+		}
+	}()
+
+	var eventID string
+	select {
+	case eventID = <-eventIDs:
+	case <-time.After(10 * time.Second):
+		t.Fatal("event was not created")
+	}
+
+	expected := []struct{ typ, status string }{
+		{"ADDED", "investigating"},
+		{"MODIFIED", "identified"},
+		{"MODIFIED", "resolved"},
+		{"DELETED", ""},
+	}
+	var got []watchLine
+	timeout := time.After(20 * time.Second)
+	for next := 0; next < len(expected); {
+		select {
+		case line, ok := <-lines:
+			require.True(t, ok, "stream closed early, got %+v", got)
+			if line.Object.ID != eventID {
+				continue
+			}
+			got = append(got, line)
+			if line.Type == expected[next].typ && line.Object.Status == expected[next].status {
+				next++
+				if next < len(expected) {
+					seen <- struct{}{}
+				}
+			}
+		case <-timeout:
+			close(seen)
+			t.Fatalf("expected %+v next, got %+v", expected[next], got)
+		}
+	}
+
+	for _, line := range got[:len(got)-1] {
+		assert.NotEqual(t, "DELETED", line.Type)
+		assert.Equal(t, "Watched incident", line.Object.Title, "%s carries the full event", line.Type)
+	}
+	assert.Empty(t, got[len(got)-1].Object.Title, "deleted event carries only its ID")
+}
@@ -0,0 +1,138 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Logo    string      `xml:"logo"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID         string `xml:"id"`
+	Title      string `xml:"title"`
+	Published  string `xml:"published"`
+	Categories []struct {
+		Term  string `xml:"term,attr"`
+		Label string `xml:"label,attr"`
+	} `xml:"category"`
+	Link struct {
+		Href string `xml:"href,attr"`
+	} `xml:"link"`
+}
+
+// getFeed fetches and parses an Atom feed.
+func getFeed(t *testing.T, client *testutil.Client, path string) atomFeed {
+	t.Helper()
+	resp, err := client.GET(path)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/atom+xml; charset=utf-8", resp.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var feed atomFeed
+	require.NoError(t, xml.Unmarshal(body, &feed), "feed must be valid Atom XML")
+	return feed
+}
+
+// entryIndex returns the position of the event in the feed, or -1.
+func entryIndex(feed atomFeed, eventID string) int {
+	for i, e := range feed.Entries {
+		if e.ID == "urn:uuid:"+eventID {
+			return i
+		}
+	}
+	return -1
+}
+
+func assertReverseChronological(t *testing.T, feed atomFeed) {
+	t.Helper()
+	for i := 1; i < len(feed.Entries); i++ {
+		assert.GreaterOrEqual(t, feed.Entries[i-1].Published, feed.Entries[i].Published,
+			"entries must be newest first")
+	}
+}
+
+func TestFeed_Global(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "Feed Svc")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	firstID := createTestIncident(t, client, "Feed First Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil, withSeverity("major"))
+	resolveEvent(t, client, firstID)
+	t.Cleanup(func() { deleteEvent(t, client, firstID) })
+
+	secondID := createTestIncident(t, client, "Feed Second Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	resolveEvent(t, client, secondID)
+	t.Cleanup(func() { deleteEvent(t, client, secondID) })
+
+	feed := getFeed(t, newTestClient(t), "/api/v1/feed")
+	assert.Equal(t, "urn:incident-garden:feed", feed.ID)
+	assert.LessOrEqual(t, len(feed.Entries), 50)
+	assertReverseChronological(t, feed)
+
+	first, second := entryIndex(feed, firstID), entryIndex(feed, secondID)
+	require.NotEqual(t, -1, first)
+	require.NotEqual(t, -1, second)
+	assert.Less(t, second, first, "newer event comes first")
+
+	entry := feed.Entries[first]
+	assert.Equal(t, "Feed First Incident", entry.Title)
+	assert.Contains(t, entry.Link.Href, firstID)
+
+	terms := make(map[string]string)
+	for _, c := range entry.Categories {
+		terms[c.Label] = c.Term
+	}
+	assert.Equal(t, map[string]string{"type": "incident", "status": "resolved", "severity": "major"}, terms)
+}
+
+func TestFeed_Service(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "Feed Scoped Svc")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+	otherID, otherSlug := createTestService(t, client, "Feed Other Svc")
+	t.Cleanup(func() { deleteService(t, client, otherSlug) })
+
+	ownID := createTestIncident(t, client, "Feed Scoped Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	resolveEvent(t, client, ownID)
+	t.Cleanup(func() { deleteEvent(t, client, ownID) })
+
+	foreignID := createTestIncident(t, client, "Feed Foreign Incident",
+		[]AffectedService{{ServiceID: otherID, Status: "degraded"}}, nil)
+	resolveEvent(t, client, foreignID)
+	t.Cleanup(func() { deleteEvent(t, client, foreignID) })
+
+	feed := getFeed(t, newTestClient(t), "/api/v1/services/"+serviceSlug+"/feed")
+	require.Len(t, feed.Entries, 1)
+	assert.Equal(t, "urn:uuid:"+ownID, feed.Entries[0].ID)
+	assert.Equal(t, "urn:incident-garden:feed:service:"+serviceID, feed.ID)
+
+	resp, err := newTestClient(t).GET("/api/v1/services/missing-" + serviceSlug + "/feed")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
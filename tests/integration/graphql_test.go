@@ -0,0 +1,169 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type graphqlResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphqlQuery posts a GraphQL request and decodes the response document.
+func graphqlQuery(t *testing.T, client *testutil.Client, query string, variables map[string]interface{}) graphqlResponse {
+	t.Helper()
+	payload := map[string]interface{}{"query": query}
+	if variables != nil {
+		payload["variables"] = variables
+	}
+	resp, err := client.POST("/api/v1/graphql", payload)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result graphqlResponse
+	testutil.DecodeJSON(t, resp, &result)
+	return result
+}
+
+func TestGraphQL_MultiLevelQuery(t *testing.T) {
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	apiID, apiSlug := createTestService(t, admin, "GraphQL API")
+	t.Cleanup(func() { deleteService(t, admin, apiSlug) })
+	dbID, dbSlug := createTestService(t, admin, "GraphQL DB")
+	t.Cleanup(func() { deleteService(t, admin, dbSlug) })
+
+	eventID := createTestIncident(t, admin, "GraphQL multi-level incident", []AffectedService{
+		{ServiceID: apiID, Status: "major_outage"},
+		{ServiceID: dbID, Status: "degraded"},
+	}, nil)
+	t.Cleanup(func() {
+		resolveEvent(t, admin, eventID)
+		deleteEvent(t, admin, eventID)
+	})
+
+	resp, err := admin.POST("/api/v1/events/"+eventID+"/updates", map[string]interface{}{
+		"status":  "identified",
+		"message": "Root cause found",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	// Anonymous clients can query: service → events → updates, and back to services
+	result := graphqlQuery(t, newTestClient(t), `query($slug: String!) {
+		service(slug: $slug) {
+			id
+			name
+			effectiveStatus
+			events(status: "active") {
+				id
+				title
+				status
+				updates { status message }
+				services { slug effectiveStatus }
+			}
+		}
+	}`, map[string]interface{}{"slug": apiSlug})
+	require.Empty(t, result.Errors)
+
+	service := result.Data["service"].(map[string]interface{})
+	assert.Equal(t, apiID, service["id"])
+	assert.Equal(t, "major_outage", service["effectiveStatus"])
+
+	serviceEvents := service["events"].([]interface{})
+	require.Len(t, serviceEvents, 1)
+	event := serviceEvents[0].(map[string]interface{})
+	assert.Equal(t, eventID, event["id"])
+	assert.Equal(t, "identified", event["status"])
+
+	updates := event["updates"].([]interface{})
+	require.NotEmpty(t, updates)
+	assert.Equal(t, "Root cause found", updates[len(updates)-1].(map[string]interface{})["message"])
+
+	slugs := make([]string, 0)
+	for _, s := range event["services"].([]interface{}) {
+		slugs = append(slugs, s.(map[string]interface{})["slug"].(string))
+	}
+	assert.ElementsMatch(t, []string{apiSlug, dbSlug}, slugs)
+
+	// Top-level list with nested events
+	result = graphqlQuery(t, newTestClient(t), `{ services { slug events(limit: 5) { id } } }`, nil)
+	require.Empty(t, result.Errors)
+
+	found := map[string]bool{}
+	for _, s := range result.Data["services"].([]interface{}) {
+		svc := s.(map[string]interface{})
+		for _, e := range svc["events"].([]interface{}) {
+			if e.(map[string]interface{})["id"] == eventID {
+				found[svc["slug"].(string)] = true
+			}
+		}
+	}
+	assert.True(t, found[apiSlug])
+	assert.True(t, found[dbSlug])
+
+	// Unknown event resolves to null
+	result = graphqlQuery(t, newTestClient(t), `{ event(id: "00000000-0000-0000-0000-000000000000") { id } }`, nil)
+	require.Empty(t, result.Errors)
+	assert.Nil(t, result.Data["event"])
+}
+
+func TestGraphQL_MutationRequiresOperator(t *testing.T) {
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	eventID := createTestIncident(t, admin, "GraphQL mutation incident", nil, nil)
+	t.Cleanup(func() {
+		resolveEvent(t, admin, eventID)
+		deleteEvent(t, admin, eventID)
+	})
+
+	const mutation = `mutation($id: ID!) {
+		addEventUpdate(eventId: $id, input: {status: "identified", message: "Via GraphQL"}) {
+			eventId
+			status
+			message
+		}
+	}`
+	variables := map[string]interface{}{"id": eventID}
+
+	result := graphqlQuery(t, newTestClient(t), mutation, variables)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "unauthorized", result.Errors[0].Message)
+
+	user := newTestClient(t)
+	user.LoginAsUser(t)
+	result = graphqlQuery(t, user, mutation, variables)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "insufficient permissions", result.Errors[0].Message)
+
+	operator := newTestClient(t)
+	operator.LoginAsOperator(t)
+	result = graphqlQuery(t, operator, mutation, variables)
+	require.Empty(t, result.Errors)
+	update := result.Data["addEventUpdate"].(map[string]interface{})
+	assert.Equal(t, eventID, update["eventId"])
+	assert.Equal(t, "identified", update["status"])
+
+	// The update is visible through REST
+	resp, err := operator.GET("/api/v1/events/" + eventID)
+	require.NoError(t, err)
+	var event struct {
+		Data struct {
+			Status string `json:"status"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &event)
+	assert.Equal(t, "identified", event.Data.Status)
+}
@@ -0,0 +1,142 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/app"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type healthBody struct {
+	Status              string `json:"status"`
+	DB                  string `json:"db"`
+	NotificationsWorker string `json:"notifications_worker"`
+	Version             string `json:"version"`
+}
+
+func getHealth(t *testing.T, client *testutil.Client, path string) (int, healthBody) {
+	t.Helper()
+	resp, err := client.GET(path)
+	require.NoError(t, err)
+	var body healthBody
+	testutil.DecodeJSON(t, resp, &body)
+	return resp.StatusCode, body
+}
+
+// tcpProxy forwards connections to target until closed, so a test can cut an app off its database.
+type tcpProxy struct {
+	listener net.Listener
+	target   string
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func newTCPProxy(t *testing.T, target string) *tcpProxy {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	p := &tcpProxy{listener: listener, target: target}
+	go p.serve()
+	t.Cleanup(p.Close)
+	return p
+}
+
+func (p *tcpProxy) serve() {
+	for {
+		client, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		upstream, err := net.Dial("tcp", p.target)
+		if err != nil {
+			client.Close()
+			continue
+		}
+		p.mu.Lock()
+		p.conns = append(p.conns, client, upstream)
+		p.mu.Unlock()
+
+		go func() { _, _ = io.Copy(upstream, client); upstream.Close() }()
+		go func() { _, _ = io.Copy(client, upstream); client.Close() }()
+	}
+}
+
+// Close stops accepting connections and drops the open ones.
+func (p *tcpProxy) Close() {
+	_ = p.listener.Close()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, conn := range p.conns {
+		_ = conn.Close()
+	}
+	p.conns = nil
+}
+
+func TestHealthz_Healthy(t *testing.T) {
+	status, body := getHealth(t, newTestClient(t), "/healthz")
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", body.Status)
+	assert.Equal(t, "ok", body.DB)
+	assert.NotEmpty(t, body.Version)
+}
+
+func TestReadyz_Healthy(t *testing.T) {
+	status, body := getHealth(t, newTestClient(t), "/readyz")
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", body.Status)
+	assert.Equal(t, "ok", body.DB)
+	assert.Equal(t, "disabled", body.NotificationsWorker, "notifications are disabled in the test app")
+}
+
+func TestHealth_DatabaseUnreachable(t *testing.T) {
+	dbURL, err := url.Parse(testConfig.Database.URL)
+	require.NoError(t, err)
+	proxy := newTCPProxy(t, dbURL.Host)
+	dbURL.Host = proxy.listener.Addr().String()
+
+	cfg := *testConfig
+	cfg.Database.URL = dbURL.String()
+	application, err := app.New(&cfg, app.WithBuildVersion("abc1234"))
+	require.NoError(t, err)
+	server := httptest.NewServer(application.Router())
+	t.Cleanup(func() {
+		server.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = application.Shutdown(ctx)
+	})
+	client := testutil.NewClient(server.URL)
+
+	status, body := getHealth(t, client, "/healthz")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "abc1234", body.Version, "build version is set by WithBuildVersion")
+
+	proxy.Close()
+
+	status, body = getHealth(t, client, "/healthz")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "degraded", body.Status)
+	assert.Equal(t, "error", body.DB, "the reason is logged, not returned")
+	assert.Equal(t, "abc1234", body.Version)
+
+	status, body = getHealth(t, client, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "degraded", body.Status)
+	assert.Equal(t, "error", body.DB, "the reason is logged, not returned")
+}
@@ -0,0 +1,216 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withHealthCheck(url string, intervalSeconds int) serviceOption {
+	return func(m map[string]interface{}) {
+		m["health_check_url"] = url
+		m["health_check_interval_seconds"] = intervalSeconds
+	}
+}
+
+type healthCheckState struct {
+	ConsecutiveFailures int
+	EventID             *string
+}
+
+// getHealthCheckState returns the stored health check state of a service (zero before the first check).
+func getHealthCheckState(t *testing.T, serviceID string) healthCheckState {
+	t.Helper()
+	var state healthCheckState
+	err := testDB.QueryRow(context.Background(), `
+		SELECT COALESCE(MAX(consecutive_failures), 0), MAX(event_id::text)
+		FROM service_health_checks WHERE service_id = $1`, serviceID).
+		Scan(&state.ConsecutiveFailures, &state.EventID)
+	require.NoError(t, err)
+	return state
+}
+
+// makeHealthCheckDue lets the checker poll a service on its next run instead of waiting for the interval.
+func makeHealthCheckDue(t *testing.T, serviceID string) {
+	t.Helper()
+	_, err := testDB.Exec(context.Background(),
+		`UPDATE service_health_checks SET next_check_at = NOW() WHERE service_id = $1`, serviceID)
+	require.NoError(t, err)
+}
+
+// waitForHealthCheck waits until the stored state of a service satisfies cond.
+func waitForHealthCheck(t *testing.T, serviceID string, cond func(healthCheckState) bool) healthCheckState {
+	t.Helper()
+	var state healthCheckState
+	require.Eventually(t, func() bool {
+		state = getHealthCheckState(t, serviceID)
+		return cond(state)
+	}, 10*time.Second, 100*time.Millisecond)
+	return state
+}
+
+func TestHealthCheck_FailureAndRecovery(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	var healthy atomic.Bool
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(target.Close)
+
+	serviceID, slug := createTestService(t, client, "Health Checked", withHealthCheck(target.URL, 60))
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	// The first check runs right away; one failure doesn't change the status
+	waitForHealthCheck(t, serviceID, func(s healthCheckState) bool { return s.ConsecutiveFailures == 1 })
+	assert.Equal(t, "operational", getServiceEffectiveStatus(t, client, slug))
+
+	// The second consecutive failure opens an incident degrading the service
+	makeHealthCheckDue(t, serviceID)
+	state := waitForHealthCheck(t, serviceID, func(s healthCheckState) bool { return s.EventID != nil })
+	eventID := *state.EventID
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	assert.Equal(t, 2, state.ConsecutiveFailures)
+	assert.Equal(t, "degraded", getServiceEffectiveStatus(t, client, slug))
+	event := getEvent(t, client, eventID)
+	assert.Equal(t, domain.EventTypeIncident, event.Type)
+	assert.Equal(t, domain.EventStatusInvestigating, event.Status)
+	assert.Equal(t, "Health Checked health check failing", event.Title)
+
+	// The public incident doesn't reveal the URL or the error; the error is stored
+	assert.NotContains(t, event.Description, target.URL)
+	assert.NotContains(t, event.Description, "503")
+	var lastError string
+	err := testDB.QueryRow(context.Background(),
+		`SELECT last_error FROM service_health_checks WHERE service_id = $1`, serviceID).Scan(&lastError)
+	require.NoError(t, err)
+	assert.Contains(t, lastError, "503")
+
+	// Recovery resolves the incident
+	healthy.Store(true)
+	makeHealthCheckDue(t, serviceID)
+	state = waitForHealthCheck(t, serviceID, func(s healthCheckState) bool { return s.EventID == nil })
+	assert.Equal(t, 0, state.ConsecutiveFailures)
+
+	assert.Equal(t, domain.EventStatusResolved, getEvent(t, client, eventID).Status)
+	assert.Equal(t, "operational", getServiceEffectiveStatus(t, client, slug))
+
+	log := eventStatusLog(t, eventID)
+	require.Len(t, log, 2)
+	for _, entry := range log {
+		assert.Equal(t, "health_check", entry.SourceType)
+	}
+	assert.Equal(t, "degraded", log[0].NewStatus)
+	assert.Equal(t, "operational", log[1].NewStatus)
+}
+
+func TestHealthCheck_Disabled(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	var requests atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(target.Close)
+
+	// A URL without an interval is not polled
+	serviceID, slug := createTestService(t, client, "Health Check Disabled", withHealthCheck(target.URL, 0))
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	time.Sleep(time.Second)
+	assert.Zero(t, requests.Load())
+	assert.Zero(t, countRows(t, `SELECT COUNT(*) FROM service_health_checks WHERE service_id = $1`, serviceID))
+}
+
+func TestHealthCheck_ServiceFields(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "Health Check Fields",
+		withHealthCheck("https://api.example.com/health", 0))
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	// The URL may be internal: public responses omit it
+	resp, err := newTestClient(t).GET("/api/v1/services/" + slug)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var public struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &public)
+	assert.NotContains(t, public.Data, "health_check_url")
+	assert.EqualValues(t, 0, public.Data["health_check_interval_seconds"])
+
+	// Admin update responses include it
+	type adminService struct {
+		HealthCheckURL             string `json:"health_check_url"`
+		HealthCheckIntervalSeconds int    `json:"health_check_interval_seconds"`
+	}
+	update := func(fields map[string]interface{}) (int, adminService) {
+		payload := map[string]interface{}{
+			"name":   "Health Check Fields",
+			"slug":   slug,
+			"status": "operational",
+		}
+		for k, v := range fields {
+			payload[k] = v
+		}
+		resp, err := client.PATCH("/api/v1/services/"+slug, payload)
+		require.NoError(t, err)
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return resp.StatusCode, adminService{}
+		}
+		var result struct {
+			Data adminService `json:"data"`
+		}
+		testutil.DecodeJSON(t, resp, &result)
+		return resp.StatusCode, result.Data
+	}
+
+	// Omitted fields are kept
+	status, service := update(map[string]interface{}{"health_check_interval_seconds": 120})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "https://api.example.com/health", service.HealthCheckURL)
+	assert.Equal(t, 120, service.HealthCheckIntervalSeconds)
+
+	tests := []struct {
+		name   string
+		fields map[string]interface{}
+	}{
+		{"interval too short", map[string]interface{}{"health_check_interval_seconds": 5}},
+		{"negative interval", map[string]interface{}{"health_check_interval_seconds": -1}},
+		{"enabled without url", map[string]interface{}{"health_check_url": ""}},
+		{"not http", map[string]interface{}{"health_check_url": "ftp://api.example.com/health"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, _ := update(tt.fields)
+			assert.Equal(t, http.StatusBadRequest, status)
+		})
+	}
+
+	// Clearing the URL together with the interval disables the check
+	status, service = update(map[string]interface{}{"health_check_url": "", "health_check_interval_seconds": 0})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, service.HealthCheckURL)
+	assert.Equal(t, 0, service.HealthCheckIntervalSeconds)
+}
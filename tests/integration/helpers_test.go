@@ -91,6 +91,18 @@ func withDescription(description string) serviceOption {
 	}
 }
 
+func withTags(tags map[string]string) serviceOption {
+	return func(m map[string]interface{}) {
+		m["tags"] = tags
+	}
+}
+
+func withOrder(order int) serviceOption {
+	return func(m map[string]interface{}) {
+		m["order"] = order
+	}
+}
+
 // createTestGroup creates a group and returns its ID and slug.
 func createTestGroup(t *testing.T, client *testutil.Client, name string, opts ...groupOption) (id, slug string) {
 	t.Helper()
@@ -138,6 +150,12 @@ func withGroupDescription(description string) groupOption {
 	}
 }
 
+func withGroupOrder(order int) groupOption {
+	return func(m map[string]interface{}) {
+		m["order"] = order
+	}
+}
+
 // AffectedService describes a service for event creation.
 type AffectedService struct {
 	ServiceID string
@@ -438,6 +456,20 @@ func withNotifySubscribers() incidentOption {
 	}
 }
 
+// withSeverity sets incident severity.
+func withSeverity(severity string) incidentOption {
+	return func(m map[string]interface{}) {
+		m["severity"] = severity
+	}
+}
+
+// withEventDescription sets incident description.
+func withEventDescription(description string) incidentOption {
+	return func(m map[string]interface{}) {
+		m["description"] = description
+	}
+}
+
 // addEventUpdate adds a status update to an event.
 func addEventUpdate(t *testing.T, client *testutil.Client, eventID, status, message string, notifySubscribers ...bool) {
 	t.Helper()
@@ -11,7 +11,9 @@ import (
 	"time"
 
 	"github.com/bissquit/incident-garden/internal/app"
+	"github.com/bissquit/incident-garden/internal/catalog"
 	"github.com/bissquit/incident-garden/internal/config"
+	"github.com/bissquit/incident-garden/internal/sse"
 	"github.com/bissquit/incident-garden/internal/testutil"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -25,6 +27,12 @@ var (
 	testValidator *testutil.OpenAPIValidator
 	testDB        *pgxpool.Pool
 
+	// Config of the app under test (tests that need their own app copy it)
+	testConfig *config.Config
+
+	// Live status broadcaster of the app under test (SSE stream tests)
+	testBroadcaster *sse.Broadcaster
+
 	// Mailpit for E2E email testing
 	mailpitContainer *testutil.MailpitContainer
 	mailpitClient    *MailpitClient
@@ -140,9 +148,37 @@ func TestMain(m *testing.M) {
 				Enabled: true,
 			},
 		},
+		Webhooks: config.WebhooksConfig{
+			PagerDuty: config.PagerDutyConfig{
+				Secret:         testPagerDutySecret,
+				SecretPrevious: testPagerDutyPreviousSecret,
+			},
+			OpsGenie: config.OpsGenieConfig{
+				Token:     testOpsGenieToken,
+				TagPrefix: "ig_service:",
+			},
+			Prometheus: config.PrometheusConfig{
+				Token:         testPrometheusToken,
+				ServiceLabel:  "service_slug",
+				SeverityLabel: "severity",
+				SeverityMap:   map[string]string{"critical": "major_outage", "warning": "degraded"},
+				DefaultStatus: "degraded",
+			},
+		},
+		// Frequent polling so health checks made due by tests run quickly
+		HealthCheck: config.HealthCheckConfig{
+			Timeout:      2 * time.Second,
+			PollInterval: 200 * time.Millisecond,
+		},
+		// Rate limiting DISABLED so tests are not throttled; ratelimit_test.go starts its own app.
+		RateLimit: config.RateLimitConfig{
+			Enabled: false,
+		},
 	}
+	testConfig = cfg
 
-	application, err := app.New(cfg)
+	application, err := app.New(cfg, app.WithIconValidator(
+		catalog.NewHTTPIconValidatorWithControl(catalog.DefaultIconCheckTimeout, allowLoopbackIcons)))
 	if err != nil {
 		log.Fatalf("create app: %v", err)
 	}
@@ -154,6 +190,7 @@ func TestMain(m *testing.M) {
 	}
 
 	testServer = httptest.NewServer(application.Router())
+	testBroadcaster = application.Broadcaster()
 
 	// Load OpenAPI validator
 	testValidator, err = testutil.LoadOpenAPIValidator(openAPISpecPath)
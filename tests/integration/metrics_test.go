@@ -0,0 +1,187 @@
+//go:build integration
+
+package integration
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/notifications"
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/bissquit/incident-garden/internal/notifications/webhook"
+	"github.com/bissquit/incident-garden/internal/pkg/metrics"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scrapeMetric returns the value of a series from the metrics endpoint, 0 if it is not exported yet.
+// series is the metric name with labels in alphabetical order, as in the exposition format.
+func scrapeMetric(t *testing.T, series string) float64 {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	promhttp.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		value, ok := strings.CutPrefix(scanner.Text(), series+" ")
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		require.NoError(t, err)
+		return v
+	}
+	require.NoError(t, scanner.Err())
+	return 0
+}
+
+func collectMetrics(t *testing.T) {
+	t.Helper()
+	require.NoError(t, metrics.NewCollector(testDB).Collect(context.Background()))
+}
+
+func TestMetrics_APIRequests(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "metrics-requests")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	okSeries := `incident_garden_api_requests_total{method="GET",route="/api/v1/services/{slug}",status_code="200"}`
+	notFoundSeries := `incident_garden_api_requests_total{method="GET",route="/api/v1/services/{slug}",status_code="404"}`
+	okBefore := scrapeMetric(t, okSeries)
+	notFoundBefore := scrapeMetric(t, notFoundSeries)
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.GET("/api/v1/services/" + slug)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	resp, err := client.GET("/api/v1/services/metrics-missing-" + uuid.NewString()[:8])
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, okBefore+2, scrapeMetric(t, okSeries))
+	assert.Equal(t, notFoundBefore+1, scrapeMetric(t, notFoundSeries))
+}
+
+func TestMetrics_ActiveEventsAndServices(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsOperator(t)
+
+	serviceID, slug := createTestService(t, client, "metrics-gauges")
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		deleteService(t, client, slug)
+	})
+
+	const incidents = `incident_garden_active_events_total{type="incident"}`
+	const majorOutage = `incident_garden_services_total{status="major_outage"}`
+	collectMetrics(t)
+	incidentsBefore := scrapeMetric(t, incidents)
+	majorOutageBefore := scrapeMetric(t, majorOutage)
+
+	eventID := createTestIncident(t, client, "Metrics incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "major_outage"}}, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		deleteEvent(t, client, eventID)
+	})
+
+	collectMetrics(t)
+	assert.Equal(t, incidentsBefore+1, scrapeMetric(t, incidents))
+	assert.Equal(t, majorOutageBefore+1, scrapeMetric(t, majorOutage))
+
+	resolveEvent(t, client, eventID)
+
+	collectMetrics(t)
+	assert.Equal(t, incidentsBefore, scrapeMetric(t, incidents))
+	assert.Equal(t, majorOutageBefore, scrapeMetric(t, majorOutage))
+}
+
+func TestMetrics_NotificationsSent(t *testing.T) {
+	ctx := context.Background()
+	repo := notificationspostgres.NewRepository(testDB)
+	rcv := newWebhookReceiver(t, http.StatusOK)
+
+	dispatcher := notifications.NewDispatcher(repo, webhook.NewSender(webhook.Config{
+		InitialBackoff: 10 * time.Millisecond,
+	}))
+	renderer, err := notifications.NewRenderer()
+	require.NoError(t, err)
+	worker := notifications.NewWorker(notifications.WorkerConfig{
+		BatchSize:         10,
+		PollInterval:      100 * time.Millisecond,
+		MaxAttempts:       3,
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        1 * time.Second,
+		BackoffMultiplier: 2.0,
+		NumWorkers:        1,
+	}, repo, dispatcher, renderer)
+
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+	serviceID, serviceSlug := createTestService(t, client, "metrics-notify-svc")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+	eventID := createTestIncident(t, client, "Metrics notification",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	client.LoginAsUser(t)
+	channelID := createWebhookChannel(t, client, rcv.URL, "")
+	t.Cleanup(func() {
+		client.LoginAsUser(t)
+		deleteChannel(t, client, channelID)
+	})
+	_, err = testDB.Exec(ctx, `UPDATE notification_channels SET is_verified = true WHERE id = $1`, channelID)
+	require.NoError(t, err)
+
+	const sent = `incidentgarden_notifications_sent_total{channel_type="webhook",status="success"}`
+	const delivered = `incident_garden_notifications_sent_total{channel_type="webhook"}`
+	before := scrapeMetric(t, sent)
+	deliveredBefore := scrapeMetric(t, delivered)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, repo.EnqueueNotification(ctx, &notifications.QueueItem{
+			ID:          uuid.New().String(),
+			EventID:     eventID,
+			ChannelID:   channelID,
+			MessageType: notifications.MessageTypeInitial,
+			Payload: notifications.NotificationPayload{
+				MessageType: notifications.MessageTypeInitial,
+				Event: notifications.EventData{
+					ID:     eventID,
+					Title:  "Metrics notification",
+					Type:   "incident",
+					Status: "investigating",
+				},
+				GeneratedAt: time.Now(),
+			},
+			MaxAttempts: 3,
+		}))
+	}
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	worker.Start(workerCtx)
+	defer func() {
+		cancel()
+		worker.Stop()
+	}()
+
+	require.Eventually(t, func() bool { return scrapeMetric(t, sent) >= before+2 }, 3*time.Second, 50*time.Millisecond)
+	assert.Equal(t, deliveredBefore+2, scrapeMetric(t, delivered))
+	assert.Len(t, rcv.Requests(), 2)
+}
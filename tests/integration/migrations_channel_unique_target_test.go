@@ -0,0 +1,96 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMigrationDB creates a scratch database migrated up to version and returns
+// its pool and migrator; both are closed and the database dropped on cleanup.
+func newMigrationDB(t *testing.T, version uint) (*pgxpool.Pool, *migrate.Migrate) {
+	t.Helper()
+	ctx := context.Background()
+
+	name := strings.ReplaceAll(testutil.RandomSlug("migration"), "-", "_")
+	_, err := testDB.Exec(ctx, `CREATE DATABASE "`+name+`"`)
+	require.NoError(t, err)
+
+	dsn, err := url.Parse(testConfig.Database.URL)
+	require.NoError(t, err)
+	dsn.Path = "/" + name
+
+	migrator, err := migrate.New("file://../../migrations", dsn.String())
+	require.NoError(t, err)
+	require.NoError(t, migrator.Migrate(version))
+
+	pool, err := pgxpool.New(ctx, dsn.String())
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		pool.Close()
+		_, _ = migrator.Close()
+		_, err := testDB.Exec(context.Background(), `DROP DATABASE "`+name+`" WITH (FORCE)`)
+		assert.NoError(t, err)
+	})
+	return pool, migrator
+}
+
+func TestMigration_ChannelUniqueTarget_KeepsSubscriberToken(t *testing.T) {
+	ctx := context.Background()
+	db, migrator := newMigrationDB(t, 46)
+
+	var userID, serviceID, olderID, tokenID string
+	require.NoError(t, db.QueryRow(ctx,
+		`SELECT id FROM users WHERE email = 'subscribers@incident-garden.local'`).Scan(&userID))
+	require.NoError(t, db.QueryRow(ctx,
+		`INSERT INTO services (name, slug) VALUES ('Migrated', 'migrated') RETURNING id`).Scan(&serviceID))
+
+	// The older verified duplicate would win without the token rule
+	require.NoError(t, db.QueryRow(ctx, `
+		INSERT INTO notification_channels (user_id, type, target, is_verified, min_severity, created_at)
+		VALUES ($1, 'email', 'Visitor@Example.com', true, 'critical', NOW() - INTERVAL '1 day')
+		RETURNING id`, userID).Scan(&olderID))
+	require.NoError(t, db.QueryRow(ctx, `
+		INSERT INTO notification_channels (user_id, type, target, min_severity)
+		VALUES ($1, 'email', 'visitor@example.com', 'major')
+		RETURNING id`, userID).Scan(&tokenID))
+	_, err := db.Exec(ctx, `INSERT INTO subscriber_tokens (token, channel_id) VALUES ('migration-token', $1)`, tokenID)
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO channel_subscriptions (channel_id, service_id) VALUES ($1, $2)`, olderID, serviceID)
+	require.NoError(t, err)
+
+	require.NoError(t, migrator.Migrate(47))
+
+	var channels int
+	require.NoError(t, db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM notification_channels WHERE user_id = $1 AND target = 'visitor@example.com'`,
+		userID).Scan(&channels))
+	assert.Equal(t, 1, channels)
+
+	var keptID string
+	var minSeverity *string
+	require.NoError(t, db.QueryRow(ctx, `
+		SELECT nc.id, nc.min_severity
+		FROM subscriber_tokens st
+		JOIN notification_channels nc ON nc.id = st.channel_id
+		WHERE st.token = 'migration-token'`).Scan(&keptID, &minSeverity))
+	assert.Equal(t, tokenID, keptID, "the channel with the subscriber token is kept")
+	require.NotNil(t, minSeverity)
+	assert.Equal(t, "major", *minSeverity, "the lowest min_severity of the duplicates")
+
+	var subscribed bool
+	require.NoError(t, db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM channel_subscriptions WHERE channel_id = $1 AND service_id = $2)`,
+		keptID, serviceID).Scan(&subscribed))
+	assert.True(t, subscribed, "subscriptions of the deleted duplicate move to the kept channel")
+}
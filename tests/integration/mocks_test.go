@@ -140,6 +140,8 @@ type MockSenderRegistry struct {
 	Email      *MockSender
 	Telegram   *MockSender
 	Mattermost *MockSender
+	Slack      *MockSender
+	Webhook    *MockSender
 }
 
 // NewMockSenderRegistry creates a new registry with mock senders.
@@ -148,12 +150,14 @@ func NewMockSenderRegistry() *MockSenderRegistry {
 		Email:      NewMockSender(domain.ChannelTypeEmail),
 		Telegram:   NewMockSender(domain.ChannelTypeTelegram),
 		Mattermost: NewMockSender(domain.ChannelTypeMattermost),
+		Slack:      NewMockSender(domain.ChannelTypeSlack),
+		Webhook:    NewMockSender(domain.ChannelTypeWebhook),
 	}
 }
 
 // GetSenders returns all mock senders as a slice.
 func (r *MockSenderRegistry) GetSenders() []notifications.Sender {
-	return []notifications.Sender{r.Email, r.Telegram, r.Mattermost}
+	return []notifications.Sender{r.Email, r.Telegram, r.Mattermost, r.Slack, r.Webhook}
 }
 
 // Reset resets all mock senders.
@@ -161,11 +165,13 @@ func (r *MockSenderRegistry) Reset() {
 	r.Email.Reset()
 	r.Telegram.Reset()
 	r.Mattermost.Reset()
+	r.Slack.Reset()
+	r.Webhook.Reset()
 }
 
 // TotalSentCount returns total sent count across all senders.
 func (r *MockSenderRegistry) TotalSentCount() int {
-	return r.Email.SentCount() + r.Telegram.SentCount() + r.Mattermost.SentCount()
+	return r.Email.SentCount() + r.Telegram.SentCount() + r.Mattermost.SentCount() + r.Slack.SentCount() + r.Webhook.SentCount()
 }
 
 // WaitForAnyNotification waits until any notification is sent.
@@ -0,0 +1,136 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setChannelLimit sets the max_channels_per_user admin setting for the duration of the test.
+func setChannelLimit(t *testing.T, limit int) {
+	t.Helper()
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+	restoreAdminSettings(t, admin)
+
+	resp := patchAdminSettings(t, admin, map[string]interface{}{"max_channels_per_user": limit})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
+
+// postMattermostChannel creates a mattermost channel and returns the response.
+func postMattermostChannel(t *testing.T, client *testutil.Client, n int) *http.Response {
+	t.Helper()
+	resp, err := client.POST("/api/v1/me/channels", map[string]interface{}{
+		"type":   "mattermost",
+		"target": fmt.Sprintf("https://mattermost.example.com/hooks/limit-%d", n),
+	})
+	require.NoError(t, err)
+	return resp
+}
+
+func countUserChannels(t *testing.T, client *testutil.Client) int {
+	t.Helper()
+	resp, err := client.GET("/api/v1/me/channels")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return len(result.Data)
+}
+
+func TestChannelLimit_Enforced(t *testing.T) {
+	setChannelLimit(t, 3)
+
+	client := newTestClient(t)
+	registerAndLoginUser(t, client, "channel-limit")
+
+	// The default email channel created on registration counts too
+	existing := countUserChannels(t, client)
+	require.Less(t, existing, 3)
+
+	var channelIDs []string
+	for n := existing; n < 3; n++ {
+		resp := postMattermostChannel(t, client, n)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var created struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		testutil.DecodeJSON(t, resp, &created)
+		channelIDs = append(channelIDs, created.Data.ID)
+	}
+
+	resp := postMattermostChannel(t, client, 100)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	var errResp struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	testutil.DecodeJSON(t, resp, &errResp)
+	assert.Equal(t, "channel limit reached", errResp.Error.Message)
+	assert.Equal(t, 3, countUserChannels(t, client))
+
+	t.Run("deleting a channel frees a slot", func(t *testing.T) {
+		require.NotEmpty(t, channelIDs)
+		resp, err := client.DELETE("/api/v1/me/channels/" + channelIDs[0])
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp = postMattermostChannel(t, client, 101)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		resp.Body.Close()
+
+		resp = postMattermostChannel(t, client, 102)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		resp.Body.Close()
+	})
+
+	t.Run("raising the limit", func(t *testing.T) {
+		setChannelLimit(t, 4)
+		resp := postMattermostChannel(t, client, 103)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		resp.Body.Close()
+	})
+}
+
+func TestChannelLimit_AdminBypass(t *testing.T) {
+	setChannelLimit(t, 1)
+
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+	require.GreaterOrEqual(t, countUserChannels(t, admin), 1, "admin has a default channel")
+
+	resp := postMattermostChannel(t, admin, 200)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &created)
+	t.Cleanup(func() { deleteChannel(t, admin, created.Data.ID) })
+}
+
+func TestChannelLimit_InvalidSetting(t *testing.T) {
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	resp := patchAdminSettings(t, admin.WithoutValidation(), map[string]interface{}{"max_channels_per_user": 0})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
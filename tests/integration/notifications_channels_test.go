@@ -3,7 +3,9 @@
 package integration
 
 import (
+	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/bissquit/incident-garden/internal/testutil"
@@ -107,6 +109,44 @@ func TestChannels_Create_Mattermost_Success(t *testing.T) {
 	t.Cleanup(func() { deleteChannel(t, client, result.Data.ID) })
 }
 
+func TestChannels_Create_Slack_Success(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsUser(t)
+
+	resp, err := client.POST("/api/v1/me/channels", map[string]interface{}{
+		"type":   "slack",
+		"target": "https://hooks.slack.com/services/T000/B000/abc123",
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			ID         string `json:"id"`
+			Type       string `json:"type"`
+			Target     string `json:"target"`
+			IsVerified bool   `json:"is_verified"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+
+	assert.NotEmpty(t, result.Data.ID)
+	assert.Equal(t, "slack", result.Data.Type)
+	assert.Equal(t, "https://hooks.slack.com/services/T000/B000/abc123", result.Data.Target)
+	assert.False(t, result.Data.IsVerified)
+
+	t.Cleanup(func() { deleteChannel(t, client, result.Data.ID) })
+
+	// Slack channels are verified via test message, no code is generated
+	var count int
+	err = testDB.QueryRow(context.Background(), `
+		SELECT COUNT(*) FROM channel_verification_codes WHERE channel_id = $1
+	`, result.Data.ID).Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count, "no verification code for slack")
+}
+
 func TestChannels_Create_InvalidType_BadRequest(t *testing.T) {
 	client := newTestClientWithoutValidation()
 	client.LoginAsUser(t)
@@ -145,6 +185,100 @@ func TestChannels_Create_RequiresAuth(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
 }
 
+func TestChannels_Create_Duplicate_Conflict(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsUser(t)
+
+	email := "dup-" + randomSuffix() + "@example.com"
+	mixedCaseEmail := "dup-ci-" + randomSuffix() + "@example.com"
+	hook := "https://mattermost.example.com/hooks/dup-" + randomSuffix()
+
+	tests := []struct {
+		name      string
+		channel   string
+		target    string
+		duplicate string
+	}{
+		{"email", "email", email, email},
+		{"email case-insensitive", "email", mixedCaseEmail, strings.ToUpper(mixedCaseEmail)},
+		{"mattermost", "mattermost", hook, hook},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.POST("/api/v1/me/channels", map[string]interface{}{
+				"type":   tt.channel,
+				"target": tt.target,
+			})
+			require.NoError(t, err)
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+			var created struct {
+				Data struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			}
+			testutil.DecodeJSON(t, resp, &created)
+			t.Cleanup(func() {
+				client.LoginAsUser(t)
+				deleteChannel(t, client, created.Data.ID)
+			})
+
+			resp, err = client.POST("/api/v1/me/channels", map[string]interface{}{
+				"type":   tt.channel,
+				"target": tt.duplicate,
+			})
+			require.NoError(t, err)
+			require.Equal(t, http.StatusConflict, resp.StatusCode)
+			var errorResult struct {
+				Error struct {
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			testutil.DecodeJSON(t, resp, &errorResult)
+			assert.Equal(t, "channel already exists", errorResult.Error.Message)
+		})
+	}
+}
+
+func TestChannels_Create_Email_StoredLowercase(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsUser(t)
+
+	suffix := randomSuffix()
+	resp, err := client.POST("/api/v1/me/channels", map[string]interface{}{
+		"type":   "email",
+		"target": "Mixed-Case-" + suffix + "@Example.COM",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var result struct {
+		Data struct {
+			ID     string `json:"id"`
+			Target string `json:"target"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	t.Cleanup(func() { deleteChannel(t, client, result.Data.ID) })
+
+	assert.Equal(t, "mixed-case-"+suffix+"@example.com", result.Data.Target)
+}
+
+func TestChannels_UniqueConstraint(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsUser(t)
+
+	channelID := createEmailChannel(t, client)
+	t.Cleanup(func() { deleteChannel(t, client, channelID) })
+
+	// The constraint holds even if the service-level check is bypassed
+	_, err := testDB.Exec(context.Background(), `
+		INSERT INTO notification_channels (user_id, type, target)
+		SELECT user_id, type, target FROM notification_channels WHERE id = $1
+	`, channelID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "uq_notification_channels_user_type_target")
+}
+
 // =============================================================================
 // List Channels Tests
 // =============================================================================
@@ -0,0 +1,178 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type deadLetter struct {
+	ID             string      `json:"id"`
+	NotificationID string      `json:"notification_id"`
+	EventID        string      `json:"event_id"`
+	ChannelID      string      `json:"channel_id"`
+	MessageType    string      `json:"message_type"`
+	LastError      string      `json:"last_error"`
+	Attempts       int         `json:"attempts"`
+	AttemptedAt    []time.Time `json:"attempted_at"`
+	Payload        struct {
+		Event struct {
+			Title string `json:"title"`
+		} `json:"event"`
+	} `json:"payload"`
+}
+
+type deadLettersResponse struct {
+	Data struct {
+		DeadLetters []deadLetter `json:"dead_letters"`
+		Total       int          `json:"total"`
+		Limit       int          `json:"limit"`
+		Offset      int          `json:"offset"`
+	} `json:"data"`
+}
+
+func listDeadLetters(t *testing.T, client *testutil.Client, query string) deadLettersResponse {
+	t.Helper()
+	resp, err := client.GET("/api/v1/admin/notifications/dead-letters" + query)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result deadLettersResponse
+	testutil.DecodeJSON(t, resp, &result)
+	return result
+}
+
+// findDeadLetter returns the dead letter of a notification, nil if there is none.
+func findDeadLetter(t *testing.T, client *testutil.Client, notificationID string) *deadLetter {
+	t.Helper()
+	for _, dl := range listDeadLetters(t, client, "?limit=100").Data.DeadLetters {
+		if dl.NotificationID == notificationID {
+			return &dl
+		}
+	}
+	return nil
+}
+
+func queueStatus(t *testing.T, itemID string) string {
+	t.Helper()
+	var status string
+	require.NoError(t, testDB.QueryRow(context.Background(),
+		`SELECT status FROM notification_queue WHERE id = $1`, itemID).Scan(&status))
+	return status
+}
+
+func TestDeadLetters_ExhaustedNotification(t *testing.T) {
+	repo := notificationspostgres.NewRepository(testDB)
+
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	eventID := createTestIncident(t, client, "Dead Letter Incident", nil, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	client.LoginAsUser(t)
+	channelID := createTelegramChannel(t, client, "555000333")
+	t.Cleanup(func() {
+		client.LoginAsUser(t)
+		deleteChannel(t, client, channelID)
+	})
+	verifyTelegramChannel(t, client, channelID)
+
+	mocks := NewMockSenderRegistry()
+	mocks.Telegram.FailNext(errors.New("telegram unavailable"))
+	itemID := enqueueTestNotification(t, repo, eventID, channelID)
+	processQueue(t, repo, mocks, itemID)
+	require.Equal(t, "failed", queueStatus(t, itemID))
+
+	client.LoginAsAdmin(t)
+	dl := findDeadLetter(t, client, itemID)
+	require.NotNil(t, dl, "exhausted notification should be dead-lettered")
+	assert.Equal(t, eventID, dl.EventID)
+	assert.Equal(t, channelID, dl.ChannelID)
+	assert.Equal(t, "initial", dl.MessageType)
+	assert.Equal(t, "Delivery Test", dl.Payload.Event.Title)
+	assert.Contains(t, dl.LastError, "max attempts exceeded")
+	assert.Contains(t, dl.LastError, "telegram unavailable")
+	assert.Equal(t, 1, dl.Attempts)
+	assert.Len(t, dl.AttemptedAt, 1)
+
+	t.Run("pagination", func(t *testing.T) {
+		result := listDeadLetters(t, client, "?limit=1")
+		assert.Len(t, result.Data.DeadLetters, 1)
+		assert.GreaterOrEqual(t, result.Data.Total, 1)
+		assert.Equal(t, 1, result.Data.Limit)
+		assert.Equal(t, 0, result.Data.Offset)
+	})
+
+	t.Run("retry requeues the notification", func(t *testing.T) {
+		resp, err := client.PATCH("/api/v1/admin/notifications/dead-letters/"+dl.ID+"/retry", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Equal(t, "pending", queueStatus(t, itemID))
+		assert.Nil(t, findDeadLetter(t, client, itemID), "requeued notification should leave the dead letters")
+
+		processQueue(t, repo, mocks, itemID)
+		assert.Equal(t, "sent", queueStatus(t, itemID))
+		assert.Equal(t, 1, mocks.Telegram.SentCount())
+	})
+
+	t.Run("retry twice", func(t *testing.T) {
+		resp, err := client.PATCH("/api/v1/admin/notifications/dead-letters/"+dl.ID+"/retry", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestDeadLetters_Errors(t *testing.T) {
+	t.Run("unknown dead letter", func(t *testing.T) {
+		client := newTestClient(t)
+		client.LoginAsAdmin(t)
+
+		resp, err := client.PATCH("/api/v1/admin/notifications/dead-letters/"+uuid.New().String()+"/retry", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		client := newTestClient(t)
+		client.LoginAsAdmin(t)
+
+		resp, err := client.GET("/api/v1/admin/notifications/dead-letters?limit=0")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("operator is forbidden", func(t *testing.T) {
+		client := newTestClient(t)
+		client.LoginAsOperator(t)
+
+		resp, err := client.GET("/api/v1/admin/notifications/dead-letters")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		resp, err = client.PATCH("/api/v1/admin/notifications/dead-letters/"+uuid.New().String()+"/retry", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
@@ -0,0 +1,173 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/notifications"
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type deliveriesResponse struct {
+	Data []struct {
+		ID             string  `json:"id"`
+		NotificationID string  `json:"notification_id"`
+		ChannelID      string  `json:"channel_id"`
+		Status         string  `json:"status"`
+		SentAt         *string `json:"sent_at"`
+		FailedAt       *string `json:"failed_at"`
+		FailureReason  *string `json:"failure_reason"`
+	} `json:"data"`
+}
+
+func listDeliveries(t *testing.T, client *testutil.Client, notificationID string) deliveriesResponse {
+	t.Helper()
+	resp, err := client.GET("/api/v1/notifications/" + notificationID + "/deliveries")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result deliveriesResponse
+	testutil.DecodeJSON(t, resp, &result)
+	return result
+}
+
+// processQueue runs a worker with mock senders until the queue item leaves pending.
+func processQueue(t *testing.T, repo *notificationspostgres.Repository, mocks *MockSenderRegistry, itemID string) {
+	t.Helper()
+	dispatcher := notifications.NewDispatcher(repo, mocks.GetSenders()...)
+	renderer, err := notifications.NewRenderer()
+	require.NoError(t, err)
+
+	worker := notifications.NewWorker(notifications.WorkerConfig{
+		BatchSize:         10,
+		PollInterval:      100 * time.Millisecond,
+		MaxAttempts:       1,
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        time.Second,
+		BackoffMultiplier: 2.0,
+		NumWorkers:        1,
+	}, repo, dispatcher, renderer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	worker.Start(ctx)
+	defer func() {
+		cancel()
+		worker.Stop()
+	}()
+
+	require.Eventually(t, func() bool {
+		var status string
+		err := testDB.QueryRow(context.Background(),
+			`SELECT status FROM notification_queue WHERE id = $1`, itemID).Scan(&status)
+		return err == nil && status != "pending" && status != "processing"
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
+func enqueueTestNotification(t *testing.T, repo *notificationspostgres.Repository, eventID, channelID string) string {
+	t.Helper()
+	item := &notifications.QueueItem{
+		ID:          uuid.New().String(),
+		EventID:     eventID,
+		ChannelID:   channelID,
+		MessageType: notifications.MessageTypeInitial,
+		Payload: notifications.NotificationPayload{
+			MessageType: notifications.MessageTypeInitial,
+			Event:       notifications.EventData{ID: eventID, Title: "Delivery Test"},
+			GeneratedAt: time.Now(),
+		},
+		MaxAttempts: 1,
+	}
+	require.NoError(t, repo.EnqueueNotification(context.Background(), item))
+	return item.ID
+}
+
+func TestDeliveries_RecordedByWorker(t *testing.T) {
+	repo := notificationspostgres.NewRepository(testDB)
+
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	eventID := createTestIncident(t, client, "Deliveries Incident", nil, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	client.LoginAsUser(t)
+	verifiedID := createTelegramChannel(t, client, "555000111")
+	t.Cleanup(func() {
+		client.LoginAsUser(t)
+		deleteChannel(t, client, verifiedID)
+	})
+	verifyTelegramChannel(t, client, verifiedID)
+
+	unverifiedID := createTelegramChannel(t, client, "555000222")
+	t.Cleanup(func() {
+		client.LoginAsUser(t)
+		deleteChannel(t, client, unverifiedID)
+	})
+
+	t.Run("delivered", func(t *testing.T) {
+		mocks := NewMockSenderRegistry()
+		itemID := enqueueTestNotification(t, repo, eventID, verifiedID)
+		processQueue(t, repo, mocks, itemID)
+
+		client.LoginAsAdmin(t)
+		result := listDeliveries(t, client, itemID)
+		require.Len(t, result.Data, 1)
+		delivery := result.Data[0]
+		assert.Equal(t, itemID, delivery.NotificationID)
+		assert.Equal(t, verifiedID, delivery.ChannelID)
+		assert.Equal(t, "delivered", delivery.Status)
+		assert.NotNil(t, delivery.SentAt)
+		assert.Nil(t, delivery.FailedAt)
+		assert.Nil(t, delivery.FailureReason)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mocks := NewMockSenderRegistry()
+		itemID := enqueueTestNotification(t, repo, eventID, unverifiedID)
+		processQueue(t, repo, mocks, itemID)
+
+		client.LoginAsAdmin(t)
+		result := listDeliveries(t, client, itemID)
+		require.Len(t, result.Data, 1)
+		delivery := result.Data[0]
+		assert.Equal(t, "failed", delivery.Status)
+		assert.Nil(t, delivery.SentAt)
+		assert.NotNil(t, delivery.FailedAt)
+		require.NotNil(t, delivery.FailureReason)
+		assert.Equal(t, "channel not verified", *delivery.FailureReason)
+	})
+}
+
+func TestDeliveries_Errors(t *testing.T) {
+	t.Run("unknown notification", func(t *testing.T) {
+		client := newTestClient(t)
+		client.LoginAsAdmin(t)
+
+		resp, err := client.GET("/api/v1/notifications/" + uuid.New().String() + "/deliveries")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("operator is forbidden", func(t *testing.T) {
+		client := newTestClient(t)
+		client.LoginAsOperator(t)
+
+		resp, err := client.GET("/api/v1/notifications/" + uuid.New().String() + "/deliveries")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
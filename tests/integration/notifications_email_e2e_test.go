@@ -150,6 +150,38 @@ func TestEmail_E2E_MIMEHeaders(t *testing.T) {
 	assert.Equal(t, "Incident #123: Database connection timeout", fullMsg.Subject)
 }
 
+func TestEmail_E2E_MultipartHTML(t *testing.T) {
+	// Verify both text and HTML alternatives arrive
+	ctx := context.Background()
+	require.NoError(t, mailpitClient.DeleteAllMessages())
+
+	sender, err := email.NewSender(email.Config{
+		Enabled:     true,
+		SMTPHost:    mailpitContainer.SMTPHost,
+		SMTPPort:    mailpitContainer.SMTPPort,
+		FromAddress: "status@example.com",
+	})
+	require.NoError(t, err)
+
+	err = sender.Send(ctx, notifications.Notification{
+		To:      "user@example.com",
+		Subject: "Multipart Alert",
+		Body:    "Service <Payments> is degraded.\nLatency & errors are elevated.",
+	})
+	require.NoError(t, err)
+
+	messages, err := mailpitClient.WaitForMessages(1, 5*time.Second)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	fullMsg, err := mailpitClient.GetMessageByID(messages[0].ID)
+	require.NoError(t, err)
+	assert.Contains(t, fullMsg.Text, "Service <Payments> is degraded.")
+	assert.Contains(t, fullMsg.Text, "Latency & errors are elevated.")
+	assert.Contains(t, fullMsg.HTML, "Service &lt;Payments&gt; is degraded.")
+	assert.Contains(t, fullMsg.HTML, "Latency &amp; errors are elevated.")
+}
+
 // -----------------------------------------------------------------------------
 // Full Integration Tests (database + worker + SMTP)
 // -----------------------------------------------------------------------------
@@ -168,7 +200,7 @@ func setupE2ENotificationInfra(t *testing.T) *e2eNotificationInfra {
 
 	repo := notificationspostgres.NewRepository(testDB)
 	catalogRepo := catalogpostgres.NewRepository(testDB)
-	catalogService := catalog.NewService(catalogRepo)
+	catalogService := catalog.NewService(catalogRepo, nil)
 
 	// Create email sender pointing to Mailpit
 	emailSender, err := email.NewSender(email.Config{
@@ -26,7 +26,7 @@ func TestEvents_Create_NotifiesSubscribers(t *testing.T) {
 	ctx := context.Background()
 	repo := notificationspostgres.NewRepository(testDB)
 	catalogRepo := catalogpostgres.NewRepository(testDB)
-	catalogService := catalog.NewService(catalogRepo)
+	catalogService := catalog.NewService(catalogRepo, nil)
 	mocks := NewMockSenderRegistry()
 
 	dispatcher := notifications.NewDispatcher(repo, mocks.GetSenders()...)
@@ -119,7 +119,7 @@ func TestEvents_Create_NotifyFalse_NoNotifications(t *testing.T) {
 	ctx := context.Background()
 	repo := notificationspostgres.NewRepository(testDB)
 	catalogRepo := catalogpostgres.NewRepository(testDB)
-	catalogService := catalog.NewService(catalogRepo)
+	catalogService := catalog.NewService(catalogRepo, nil)
 	mocks := NewMockSenderRegistry()
 
 	dispatcher := notifications.NewDispatcher(repo, mocks.GetSenders()...)
@@ -173,7 +173,7 @@ func TestEvents_Create_NoSubscribers_NoNotifications(t *testing.T) {
 	ctx := context.Background()
 	repo := notificationspostgres.NewRepository(testDB)
 	catalogRepo := catalogpostgres.NewRepository(testDB)
-	catalogService := catalog.NewService(catalogRepo)
+	catalogService := catalog.NewService(catalogRepo, nil)
 	mocks := NewMockSenderRegistry()
 
 	dispatcher := notifications.NewDispatcher(repo, mocks.GetSenders()...)
@@ -222,7 +222,7 @@ func TestEvents_Create_SubscribeToAll_ReceivesNotification(t *testing.T) {
 	ctx := context.Background()
 	repo := notificationspostgres.NewRepository(testDB)
 	catalogRepo := catalogpostgres.NewRepository(testDB)
-	catalogService := catalog.NewService(catalogRepo)
+	catalogService := catalog.NewService(catalogRepo, nil)
 	mocks := NewMockSenderRegistry()
 
 	dispatcher := notifications.NewDispatcher(repo, mocks.GetSenders()...)
@@ -299,7 +299,7 @@ func TestEvents_Update_NotifiesEventSubscribers(t *testing.T) {
 	ctx := context.Background()
 	repo := notificationspostgres.NewRepository(testDB)
 	catalogRepo := catalogpostgres.NewRepository(testDB)
-	catalogService := catalog.NewService(catalogRepo)
+	catalogService := catalog.NewService(catalogRepo, nil)
 	mocks := NewMockSenderRegistry()
 
 	dispatcher := notifications.NewDispatcher(repo, mocks.GetSenders()...)
@@ -393,7 +393,7 @@ func TestEvents_Resolve_NotifiesSubscribers(t *testing.T) {
 	ctx := context.Background()
 	repo := notificationspostgres.NewRepository(testDB)
 	catalogRepo := catalogpostgres.NewRepository(testDB)
-	catalogService := catalog.NewService(catalogRepo)
+	catalogService := catalog.NewService(catalogRepo, nil)
 	mocks := NewMockSenderRegistry()
 
 	dispatcher := notifications.NewDispatcher(repo, mocks.GetSenders()...)
@@ -490,7 +490,7 @@ func TestEvents_Complete_NotifiesSubscribers(t *testing.T) {
 	ctx := context.Background()
 	repo := notificationspostgres.NewRepository(testDB)
 	catalogRepo := catalogpostgres.NewRepository(testDB)
-	catalogService := catalog.NewService(catalogRepo)
+	catalogService := catalog.NewService(catalogRepo, nil)
 	mocks := NewMockSenderRegistry()
 
 	dispatcher := notifications.NewDispatcher(repo, mocks.GetSenders()...)
@@ -579,7 +579,7 @@ func TestEvents_Subscribers_FixedAtCreation(t *testing.T) {
 	ctx := context.Background()
 	repo := notificationspostgres.NewRepository(testDB)
 	catalogRepo := catalogpostgres.NewRepository(testDB)
-	catalogService := catalog.NewService(catalogRepo)
+	catalogService := catalog.NewService(catalogRepo, nil)
 	mocks := NewMockSenderRegistry()
 
 	dispatcher := notifications.NewDispatcher(repo, mocks.GetSenders()...)
@@ -674,7 +674,7 @@ func TestEvents_Cancel_NotifiesSubscribers(t *testing.T) {
 	ctx := context.Background()
 	repo := notificationspostgres.NewRepository(testDB)
 	catalogRepo := catalogpostgres.NewRepository(testDB)
-	catalogService := catalog.NewService(catalogRepo)
+	catalogService := catalog.NewService(catalogRepo, nil)
 	mocks := NewMockSenderRegistry()
 
 	dispatcher := notifications.NewDispatcher(repo, mocks.GetSenders()...)
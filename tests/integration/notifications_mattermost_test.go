@@ -0,0 +1,114 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/notifications"
+	"github.com/bissquit/incident-garden/internal/notifications/mattermost"
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mattermostReceiver is a fake Mattermost incoming webhook recording message texts.
+type mattermostReceiver struct {
+	*httptest.Server
+	mu    sync.Mutex
+	texts []string
+}
+
+func newMattermostReceiver(t *testing.T, status int, body string) *mattermostReceiver {
+	t.Helper()
+	rcv := &mattermostReceiver{}
+	rcv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Text string `json:"text"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		rcv.mu.Lock()
+		rcv.texts = append(rcv.texts, payload.Text)
+		rcv.mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(rcv.Close)
+	return rcv
+}
+
+func (r *mattermostReceiver) Texts() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]string, len(r.texts))
+	copy(result, r.texts)
+	return result
+}
+
+func setupMattermostVerificationService() *notifications.Service {
+	repo := notificationspostgres.NewRepository(testDB)
+	sender := mattermost.NewSender(mattermost.Config{InitialBackoff: 10 * time.Millisecond})
+	return notifications.NewService(repo, notifications.NewDispatcher(repo, sender), nil, nil)
+}
+
+func TestVerification_MattermostChannel_RealSender_Success(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsUser(t)
+	userID := getUserID(t, client)
+
+	rcv := newMattermostReceiver(t, http.StatusOK, "ok")
+	channelID := createMattermostChannel(t, client, rcv.URL+"/hooks/verify")
+	t.Cleanup(func() { deleteChannel(t, client, channelID) })
+
+	verified, err := setupMattermostVerificationService().VerifyChannel(context.Background(), userID, channelID, "")
+	require.NoError(t, err)
+	assert.True(t, verified.IsVerified)
+
+	texts := rcv.Texts()
+	require.Len(t, texts, 1)
+	assert.Contains(t, texts[0], "### Channel Verification")
+}
+
+func TestVerification_MattermostChannel_RealSender_InvalidWebhook(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsUser(t)
+	userID := getUserID(t, client)
+
+	rcv := newMattermostReceiver(t, http.StatusNotFound,
+		`{"id":"web.incoming_webhook.invalid.app_error","message":"Invalid webhook.","status_code":404}`)
+	channelID := createMattermostChannel(t, client, rcv.URL+"/hooks/unknown")
+	t.Cleanup(func() { deleteChannel(t, client, channelID) })
+
+	_, err := setupMattermostVerificationService().VerifyChannel(context.Background(), userID, channelID, "")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, notifications.ErrVerificationFailed))
+	assert.Len(t, rcv.Texts(), 1, "4xx is not retried")
+
+	var isVerified bool
+	err = testDB.QueryRow(context.Background(),
+		`SELECT is_verified FROM notification_channels WHERE id = $1`, channelID).Scan(&isVerified)
+	require.NoError(t, err)
+	assert.False(t, isVerified)
+}
+
+func TestVerification_MattermostChannel_RealSender_RetriesServerError(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsUser(t)
+	userID := getUserID(t, client)
+
+	rcv := newMattermostReceiver(t, http.StatusServiceUnavailable, "maintenance")
+	channelID := createMattermostChannel(t, client, rcv.URL+"/hooks/down")
+	t.Cleanup(func() { deleteChannel(t, client, channelID) })
+
+	_, err := setupMattermostVerificationService().VerifyChannel(context.Background(), userID, channelID, "")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, notifications.ErrVerificationFailed))
+	assert.Len(t, rcv.Texts(), 3, "5xx is retried up to the default 3 attempts")
+}
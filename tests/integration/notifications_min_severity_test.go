@@ -0,0 +1,195 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	catalogpostgres "github.com/bissquit/incident-garden/internal/catalog/postgres"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/notifications"
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setChannelMinSeverity(t *testing.T, client *testutil.Client, channelID string, serviceIDs []string, minSeverity string) {
+	t.Helper()
+	resp, err := client.PUT("/api/v1/me/channels/"+channelID+"/subscriptions", map[string]interface{}{
+		"subscribe_to_all_services": false,
+		"service_ids":               serviceIDs,
+		"min_severity":              minSeverity,
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			MinSeverity *string `json:"min_severity"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	require.NotNil(t, result.Data.MinSeverity)
+	assert.Equal(t, minSeverity, *result.Data.MinSeverity)
+}
+
+func TestSubscriptions_MinSeverity_SkipsLowerSeverityIncidents(t *testing.T) {
+	ctx := context.Background()
+	repo := notificationspostgres.NewRepository(testDB)
+	catalogService := catalog.NewService(catalogpostgres.NewRepository(testDB), nil)
+	mocks := NewMockSenderRegistry()
+
+	dispatcher := notifications.NewDispatcher(repo, mocks.GetSenders()...)
+	renderer, err := notifications.NewRenderer()
+	require.NoError(t, err)
+
+	notifier := notifications.NewNotifier(repo, renderer, dispatcher, catalogService, "https://status.example.com")
+
+	worker := notifications.NewWorker(notifications.WorkerConfig{
+		BatchSize:         10,
+		PollInterval:      100 * time.Millisecond,
+		MaxAttempts:       3,
+		InitialBackoff:    50 * time.Millisecond,
+		MaxBackoff:        500 * time.Millisecond,
+		BackoffMultiplier: 2.0,
+		NumWorkers:        1,
+	}, repo, dispatcher, renderer)
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	worker.Start(workerCtx)
+	defer func() {
+		cancel()
+		worker.Stop()
+	}()
+
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "min-severity-svc")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	channelID := createAndVerifyEmailChannel(t, client)
+	t.Cleanup(func() { deleteChannel(t, client, channelID) })
+
+	setChannelMinSeverity(t, client, channelID, []string{serviceID}, "major")
+
+	createIncident := func(title string, severity domain.Severity) *domain.Event {
+		eventID := createTestIncident(t, client, title,
+			[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil, withSeverity(string(severity)))
+		t.Cleanup(func() {
+			client.LoginAsAdmin(t)
+			resolveEvent(t, client, eventID)
+			deleteEvent(t, client, eventID)
+		})
+
+		now := time.Now()
+		return &domain.Event{
+			ID:                eventID,
+			Title:             title,
+			Type:              domain.EventTypeIncident,
+			Status:            domain.EventStatusInvestigating,
+			Severity:          &severity,
+			NotifySubscribers: true,
+			CreatedAt:         now,
+			StartedAt:         &now,
+			ServiceIDs:        []string{serviceID},
+		}
+	}
+
+	minor := createIncident("Minor Severity Incident", domain.SeverityMinor)
+	require.NoError(t, notifier.OnEventCreated(ctx, minor, []string{serviceID}))
+
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, 0, mocks.Email.SentCount(), "minor incident is below the channel threshold")
+
+	subscribers, err := repo.ListEventSubscribers(ctx, minor.ID)
+	require.NoError(t, err)
+	assert.Empty(t, subscribers, "filtered channel is not snapshotted for later updates")
+
+	major := createIncident("Major Severity Incident", domain.SeverityMajor)
+	require.NoError(t, notifier.OnEventCreated(ctx, major, []string{serviceID}))
+
+	require.True(t, mocks.Email.WaitForNotifications(1, 2*time.Second), "major incident reaches the channel")
+	assert.Contains(t, mocks.Email.GetSent()[0].Body, "Major Severity Incident")
+}
+
+func TestSubscriptions_MinSeverity_ShownInMatrix(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "min-severity-matrix-svc")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	channelID := createAndVerifyEmailChannel(t, client)
+	t.Cleanup(func() { deleteChannel(t, client, channelID) })
+
+	setChannelMinSeverity(t, client, channelID, []string{serviceID}, "critical")
+
+	resp, err := client.GET("/api/v1/me/subscriptions")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			Channels []struct {
+				Channel struct {
+					ID          string  `json:"id"`
+					MinSeverity *string `json:"min_severity"`
+				} `json:"channel"`
+			} `json:"channels"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+
+	var found bool
+	for _, ch := range result.Data.Channels {
+		if ch.Channel.ID == channelID {
+			require.NotNil(t, ch.Channel.MinSeverity)
+			assert.Equal(t, "critical", *ch.Channel.MinSeverity)
+			found = true
+		}
+	}
+	assert.True(t, found, "channel should be in matrix")
+
+	// Omitting min_severity clears the threshold
+	setChannelSubscription(t, client, channelID, []string{serviceID})
+
+	resp, err = client.GET("/api/v1/me/channels")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var channels struct {
+		Data []struct {
+			ID          string  `json:"id"`
+			MinSeverity *string `json:"min_severity"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &channels)
+	for _, ch := range channels.Data {
+		if ch.ID == channelID {
+			assert.Nil(t, ch.MinSeverity)
+		}
+	}
+}
+
+func TestSubscriptions_MinSeverity_Invalid_Fails(t *testing.T) {
+	client := newTestClientWithoutValidation()
+	client.LoginAsUser(t)
+
+	channelID := createAndVerifyEmailChannel(t, client)
+	t.Cleanup(func() { deleteChannel(t, client, channelID) })
+
+	resp, err := client.PUT("/api/v1/me/channels/"+channelID+"/subscriptions", map[string]interface{}{
+		"subscribe_to_all_services": true,
+		"min_severity":              "severe",
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,274 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type publicSubscription struct {
+	Token      string   `json:"token"`
+	Email      string   `json:"email"`
+	ServiceIDs []string `json:"service_ids"`
+	IsVerified bool     `json:"is_verified"`
+}
+
+// subscribePublic creates a public subscription for a random email and returns it.
+func subscribePublic(t *testing.T, client *testutil.Client, serviceIDs []string) publicSubscription {
+	t.Helper()
+	resp, err := client.POST("/api/v1/subscribe", map[string]interface{}{
+		"email":       fmt.Sprintf("subscriber-%s@example.com", randomSuffix()),
+		"service_ids": serviceIDs,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result struct {
+		Data publicSubscription `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+func unsubscribePublic(t *testing.T, client *testutil.Client, token string) {
+	t.Helper()
+	resp, err := client.DELETE("/api/v1/unsubscribe?token=" + token)
+	if err != nil {
+		t.Logf("cleanup warning (subscription): %v", err)
+		return
+	}
+	resp.Body.Close()
+}
+
+// getSubscriberChannelID returns the channel a subscriber token belongs to.
+func getSubscriberChannelID(t *testing.T, token string) string {
+	t.Helper()
+	var channelID string
+	err := testDB.QueryRow(context.Background(),
+		`SELECT channel_id FROM subscriber_tokens WHERE token = $1`, token).Scan(&channelID)
+	require.NoError(t, err)
+	return channelID
+}
+
+func TestNotifications_PublicSubscribe_Lifecycle(t *testing.T) {
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, admin, "public-sub-svc")
+	t.Cleanup(func() { deleteService(t, admin, serviceSlug) })
+
+	client := newTestClient(t)
+
+	sub := subscribePublic(t, client, []string{serviceID})
+	assert.Len(t, sub.Token, 64)
+	assert.Equal(t, []string{serviceID}, sub.ServiceIDs)
+	assert.False(t, sub.IsVerified)
+
+	channelID := getSubscriberChannelID(t, sub.Token)
+
+	var isVerified bool
+	var ownerEmail string
+	err := testDB.QueryRow(context.Background(), `
+		SELECT nc.is_verified, u.email
+		FROM notification_channels nc
+		JOIN users u ON u.id = nc.user_id
+		WHERE nc.id = $1
+	`, channelID).Scan(&isVerified, &ownerEmail)
+	require.NoError(t, err)
+	assert.False(t, isVerified)
+	assert.Equal(t, "subscribers@incident-garden.local", ownerEmail)
+
+	t.Run("wrong code is rejected", func(t *testing.T) {
+		resp, err := client.POST("/api/v1/subscribe/verify", map[string]interface{}{
+			"token": sub.Token,
+			"code":  "000000",
+		})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		// The stored code can be 000000 with probability 1e-6; tolerate it
+		if resp.StatusCode != http.StatusOK {
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		}
+	})
+
+	t.Run("verify with emailed code", func(t *testing.T) {
+		code := getVerificationCode(t, channelID)
+		resp, err := client.POST("/api/v1/subscribe/verify", map[string]interface{}{
+			"token": sub.Token,
+			"code":  code,
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result struct {
+			Data publicSubscription `json:"data"`
+		}
+		testutil.DecodeJSON(t, resp, &result)
+		assert.True(t, result.Data.IsVerified)
+		assert.Equal(t, []string{serviceID}, result.Data.ServiceIDs)
+	})
+
+	t.Run("unsubscribe removes channel and token", func(t *testing.T) {
+		resp, err := client.DELETE("/api/v1/unsubscribe?token=" + sub.Token)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		var count int
+		err = testDB.QueryRow(context.Background(),
+			`SELECT COUNT(*) FROM notification_channels WHERE id = $1`, channelID).Scan(&count)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		resp, err = client.DELETE("/api/v1/unsubscribe?token=" + sub.Token)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestNotifications_PublicSubscribe_Repeated(t *testing.T) {
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	firstID, firstSlug := createTestService(t, admin, "public-sub-first")
+	t.Cleanup(func() { deleteService(t, admin, firstSlug) })
+	secondID, secondSlug := createTestService(t, admin, "public-sub-second")
+	t.Cleanup(func() { deleteService(t, admin, secondSlug) })
+
+	client := newTestClient(t)
+	email := fmt.Sprintf("subscriber-again-%s@example.com", randomSuffix())
+
+	subscribe := func(serviceID string) publicSubscription {
+		resp, err := client.POST("/api/v1/subscribe", map[string]interface{}{
+			"email":       email,
+			"service_ids": []string{serviceID},
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var result struct {
+			Data publicSubscription `json:"data"`
+		}
+		testutil.DecodeJSON(t, resp, &result)
+		return result.Data
+	}
+
+	first := subscribe(firstID)
+	channelID := getSubscriberChannelID(t, first.Token)
+	t.Cleanup(func() {
+		_, _ = testDB.Exec(context.Background(), `DELETE FROM notification_channels WHERE id = $1`, channelID)
+	})
+
+	// Same response shape, nothing about the existing subscription
+	second := subscribe(secondID)
+	assert.NotEqual(t, first.Token, second.Token)
+	assert.Equal(t, []string{secondID}, second.ServiceIDs)
+	assert.False(t, second.IsVerified)
+	assert.Equal(t, channelID, getSubscriberChannelID(t, second.Token))
+
+	var subscribed int
+	require.NoError(t, testDB.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM channel_subscriptions WHERE channel_id = $1`, channelID).Scan(&subscribed))
+	assert.Equal(t, 2, subscribed, "the services are added to the existing subscription")
+
+	t.Run("pending token cannot unsubscribe", func(t *testing.T) {
+		resp, err := client.DELETE("/api/v1/unsubscribe?token=" + second.Token)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("code confirms the new token and retires the old one", func(t *testing.T) {
+		resp, err := client.POST("/api/v1/subscribe/verify", map[string]interface{}{
+			"token": second.Token,
+			"code":  getVerificationCode(t, channelID),
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var result struct {
+			Data publicSubscription `json:"data"`
+		}
+		testutil.DecodeJSON(t, resp, &result)
+		assert.True(t, result.Data.IsVerified)
+		assert.ElementsMatch(t, []string{firstID, secondID}, result.Data.ServiceIDs)
+
+		resp, err = client.DELETE("/api/v1/unsubscribe?token=" + first.Token)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp, err = client.DELETE("/api/v1/unsubscribe?token=" + second.Token)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
+
+func TestNotifications_PublicSubscribe_Validation(t *testing.T) {
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, admin, "public-sub-validation")
+	t.Cleanup(func() { deleteService(t, admin, serviceSlug) })
+
+	client := newTestClientWithoutValidation()
+
+	t.Run("unknown service", func(t *testing.T) {
+		resp, err := client.POST("/api/v1/subscribe", map[string]interface{}{
+			"email":       "subscriber-unknown@example.com",
+			"service_ids": []string{"00000000-0000-0000-0000-000000000000"},
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		resp, err := client.POST("/api/v1/subscribe", map[string]interface{}{
+			"email":       "not-an-email",
+			"service_ids": []string{serviceID},
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("no services", func(t *testing.T) {
+		resp, err := client.POST("/api/v1/subscribe", map[string]interface{}{
+			"email":       "subscriber-empty@example.com",
+			"service_ids": []string{},
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("unsubscribe without token", func(t *testing.T) {
+		resp, err := client.DELETE("/api/v1/unsubscribe")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		resp, err := client.DELETE("/api/v1/unsubscribe?token=unknown")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp, err = client.POST("/api/v1/subscribe/verify", map[string]interface{}{
+			"token": "unknown",
+			"code":  "123456",
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,140 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	catalogpostgres "github.com/bissquit/incident-garden/internal/catalog/postgres"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/notifications"
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createScheduledMaintenance creates a scheduled maintenance starting at start and returns its ID.
+func createScheduledMaintenance(t *testing.T, client *testutil.Client, title, serviceID string, start time.Time) string {
+	t.Helper()
+	resp, err := client.POST("/api/v1/events", map[string]interface{}{
+		"title":              title,
+		"type":               "maintenance",
+		"status":             "scheduled",
+		"description":        "Planned maintenance window",
+		"scheduled_start_at": start.UTC().Format(time.RFC3339),
+		"scheduled_end_at":   start.Add(2 * time.Hour).UTC().Format(time.RFC3339),
+		"notify_subscribers": true,
+		"affected_services": []map[string]interface{}{
+			{"service_id": serviceID, "status": "maintenance"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data.ID
+}
+
+func getReminderSentAt(t *testing.T, eventID string) *time.Time {
+	t.Helper()
+	var sentAt *time.Time
+	err := testDB.QueryRow(context.Background(),
+		`SELECT reminder_sent_at FROM events WHERE id = $1`, eventID).Scan(&sentAt)
+	require.NoError(t, err)
+	return sentAt
+}
+
+// remindersFor returns sent notifications that are reminders about title.
+func remindersFor(sent []SentNotification, title string) []SentNotification {
+	var result []SentNotification
+	for _, n := range sent {
+		if n.Subject == "[Reminder] "+title {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func TestNotifications_MaintenanceReminder(t *testing.T) {
+	ctx := context.Background()
+	repo := notificationspostgres.NewRepository(testDB)
+	catalogService := catalog.NewService(catalogpostgres.NewRepository(testDB), nil)
+	mocks := NewMockSenderRegistry()
+
+	dispatcher := notifications.NewDispatcher(repo, mocks.GetSenders()...)
+	renderer, err := notifications.NewRenderer()
+	require.NoError(t, err)
+	notifier := notifications.NewNotifier(repo, renderer, dispatcher, catalogService, "https://status.example.com")
+
+	worker := notifications.NewWorker(notifications.WorkerConfig{
+		BatchSize:         10,
+		PollInterval:      100 * time.Millisecond,
+		MaxAttempts:       3,
+		InitialBackoff:    50 * time.Millisecond,
+		MaxBackoff:        500 * time.Millisecond,
+		BackoffMultiplier: 2.0,
+		NumWorkers:        1,
+	}, repo, dispatcher, renderer)
+
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "reminder-svc")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	channelID := createAndVerifyEmailChannel(t, client)
+	t.Cleanup(func() { deleteChannel(t, client, channelID) })
+	setChannelSubscription(t, client, channelID, []string{serviceID})
+
+	client.LoginAsAdmin(t)
+
+	soonTitle := "Reminder Soon " + testutil.RandomSlug("mnt")
+	soonID := createScheduledMaintenance(t, client, soonTitle, serviceID, time.Now().Add(30*time.Minute))
+	t.Cleanup(func() { deleteEvent(t, client, soonID) })
+
+	laterTitle := "Reminder Later " + testutil.RandomSlug("mnt")
+	laterID := createScheduledMaintenance(t, client, laterTitle, serviceID, time.Now().Add(3*time.Hour))
+	t.Cleanup(func() { deleteEvent(t, client, laterID) })
+
+	// Register event subscribers (app-level notifier is disabled in tests)
+	for _, id := range []string{soonID, laterID} {
+		err := notifier.OnEventCreated(ctx, &domain.Event{ID: id, Title: "created", NotifySubscribers: true}, []string{serviceID})
+		require.NoError(t, err)
+	}
+	mocks.Email.Reset()
+
+	scheduler := notifications.NewReminderScheduler(time.Hour, 100*time.Millisecond, repo, notifier)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	worker.Start(runCtx)
+	scheduler.Start(runCtx)
+	defer func() {
+		cancel()
+		scheduler.Stop()
+		worker.Stop()
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(remindersFor(mocks.Email.GetSent(), soonTitle)) > 0
+	}, 5*time.Second, 100*time.Millisecond, "subscriber should receive a reminder")
+
+	reminder := remindersFor(mocks.Email.GetSent(), soonTitle)[0]
+	assert.Contains(t, reminder.Body, "Upcoming Maintenance")
+	assert.NotNil(t, getReminderSentAt(t, soonID))
+
+	// Several more polls: no duplicate reminder, nothing outside the window
+	time.Sleep(500 * time.Millisecond)
+	assert.Len(t, remindersFor(mocks.Email.GetSent(), soonTitle), 1)
+	assert.Empty(t, remindersFor(mocks.Email.GetSent(), laterTitle))
+	assert.Nil(t, getReminderSentAt(t, laterID))
+}
@@ -0,0 +1,180 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	catalogpostgres "github.com/bissquit/incident-garden/internal/catalog/postgres"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/events"
+	eventspostgres "github.com/bissquit/incident-garden/internal/events/postgres"
+	"github.com/bissquit/incident-garden/internal/notifications"
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newNotifyingEventsService returns an events service that queues notifications,
+// unlike the app under test which runs with notifications disabled.
+func newNotifyingEventsService(t *testing.T) *events.Service {
+	t.Helper()
+	repo := notificationspostgres.NewRepository(testDB)
+	catalogService := catalog.NewService(catalogpostgres.NewRepository(testDB), nil)
+	renderer, err := notifications.NewRenderer()
+	require.NoError(t, err)
+	dispatcher := notifications.NewDispatcher(repo, NewMockSenderRegistry().GetSenders()...)
+	notifier := notifications.NewNotifier(repo, renderer, dispatcher, catalogService, "https://status.example.com")
+	return events.NewService(eventspostgres.NewRepository(testDB), catalogService, catalogService, notifier, nil, nil)
+}
+
+func adminUserID(t *testing.T) string {
+	t.Helper()
+	var id string
+	err := testDB.QueryRow(context.Background(),
+		`SELECT id FROM users WHERE email = 'admin@example.com'`).Scan(&id)
+	require.NoError(t, err)
+	return id
+}
+
+// resolveWithNotifications resolves an event through service with notify_subscribers.
+func resolveWithNotifications(t *testing.T, service *events.Service, eventID string) {
+	t.Helper()
+	_, err := service.AddUpdate(context.Background(), events.CreateEventUpdateInput{
+		EventID:           eventID,
+		Status:            domain.EventStatusResolved,
+		Message:           "Fixed",
+		NotifySubscribers: true,
+	}, adminUserID(t))
+	require.NoError(t, err)
+}
+
+// queuedRecoveries returns the service_recovered payloads queued for a channel about an event.
+func queuedRecoveries(t *testing.T, channelID, eventID string) []notifications.NotificationPayload {
+	t.Helper()
+	rows, err := testDB.Query(context.Background(), `
+		SELECT payload FROM notification_queue
+		WHERE channel_id = $1 AND event_id = $2 AND message_type = 'service_recovered'
+	`, channelID, eventID)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var payloads []notifications.NotificationPayload
+	for rows.Next() {
+		var raw []byte
+		require.NoError(t, rows.Scan(&raw))
+		var payload notifications.NotificationPayload
+		require.NoError(t, json.Unmarshal(raw, &payload))
+		payloads = append(payloads, payload)
+	}
+	require.NoError(t, rows.Err())
+	return payloads
+}
+
+// subscribedChannel registers a user with a verified email channel subscribed to serviceID.
+func subscribedChannel(t *testing.T, serviceID string) string {
+	t.Helper()
+	user := newTestClient(t)
+	registerAndLoginUser(t, user, "recovered")
+	channelID := createAndVerifyEmailChannel(t, user)
+	t.Cleanup(func() { deleteChannel(t, user, channelID) })
+	setChannelSubscription(t, user, channelID, []string{serviceID})
+	return channelID
+}
+
+func TestNotifications_ServiceRecovered(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+	eventsService := newNotifyingEventsService(t)
+
+	serviceID, slug := createTestService(t, client, "Recovered Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+	channelID := subscribedChannel(t, serviceID)
+
+	eventID := createTestIncident(t, client, "Recovered Service Outage",
+		[]AffectedService{{ServiceID: serviceID, Status: "partial_outage"}}, nil)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+	require.Equal(t, "partial_outage", getServiceEffectiveStatus(t, client, slug))
+
+	resolveWithNotifications(t, eventsService, eventID)
+	assert.Equal(t, "operational", getServiceEffectiveStatus(t, client, slug))
+
+	var recoveries []notifications.NotificationPayload
+	require.Eventually(t, func() bool {
+		recoveries = queuedRecoveries(t, channelID, eventID)
+		return len(recoveries) > 0
+	}, 5*time.Second, 100*time.Millisecond, "service_recovered should be queued")
+
+	require.Len(t, recoveries, 1)
+	recovery := recoveries[0]
+	assert.Equal(t, notifications.MessageTypeServiceRecovered, recovery.MessageType)
+	require.NotNil(t, recovery.Recovery)
+	assert.Equal(t, serviceID, recovery.Recovery.ID)
+	assert.Equal(t, "partial_outage", recovery.Recovery.StatusFrom)
+	assert.Equal(t, "operational", recovery.Recovery.StatusTo)
+	assert.Equal(t, "Recovered Service Outage", recovery.Event.Title)
+}
+
+func TestNotifications_ServiceRecovered_OnlyAfterLastActiveEvent(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+	eventsService := newNotifyingEventsService(t)
+
+	serviceID, slug := createTestService(t, client, "Recovered Twice Affected")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+	channelID := subscribedChannel(t, serviceID)
+
+	firstID := createTestIncident(t, client, "Recovered First Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	t.Cleanup(func() { deleteEvent(t, client, firstID) })
+	secondID := createTestIncident(t, client, "Recovered Second Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "major_outage"}}, nil)
+	t.Cleanup(func() { deleteEvent(t, client, secondID) })
+
+	// The second incident still affects the service: no recovery
+	resolveWithNotifications(t, eventsService, firstID)
+	assert.Equal(t, "major_outage", getServiceEffectiveStatus(t, client, slug))
+
+	resolveWithNotifications(t, eventsService, secondID)
+	require.Eventually(t, func() bool {
+		return len(queuedRecoveries(t, channelID, secondID)) == 1
+	}, 5*time.Second, 100*time.Millisecond, "service_recovered should be queued for the last incident")
+	assert.Empty(t, queuedRecoveries(t, channelID, firstID))
+}
+
+// Recoveries are service notifications: they are queued even when the resolution,
+// e.g. by a webhook or health check of an event created without notifications, doesn't notify.
+func TestNotifications_ServiceRecovered_NotifyDisabled(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+	eventsService := newNotifyingEventsService(t)
+
+	serviceID, slug := createTestService(t, client, "Recovered Silently")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+	channelID := subscribedChannel(t, serviceID)
+
+	eventID := createTestIncident(t, client, "Recovered Silent Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	_, err := eventsService.AddUpdate(context.Background(), events.CreateEventUpdateInput{
+		EventID:      eventID,
+		Status:       domain.EventStatusResolved,
+		Message:      "Health check is passing again",
+		StatusChange: events.StatusChangeContext{SourceType: domain.StatusLogSourceHealthCheck},
+	}, adminUserID(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, "operational", getServiceEffectiveStatus(t, client, slug))
+	var recoveries []notifications.NotificationPayload
+	require.Eventually(t, func() bool {
+		recoveries = queuedRecoveries(t, channelID, eventID)
+		return len(recoveries) > 0
+	}, 5*time.Second, 100*time.Millisecond, "service_recovered should be queued")
+	require.Len(t, recoveries, 1)
+	assert.Equal(t, "degraded", recoveries[0].Recovery.StatusFrom)
+}
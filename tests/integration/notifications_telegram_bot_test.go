@@ -0,0 +1,194 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	catalogpostgres "github.com/bissquit/incident-garden/internal/catalog/postgres"
+	"github.com/bissquit/incident-garden/internal/notifications"
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/bissquit/incident-garden/internal/notifications/telegram"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTelegramAPI is a Bot API server: getUpdates returns the messages queued
+// with send, sendMessage records the replies.
+type mockTelegramAPI struct {
+	mu       sync.Mutex
+	updateID int64
+	queued   []map[string]interface{}
+	notify   chan struct{}
+	replies  map[string][]string // chat ID -> texts
+}
+
+func newMockTelegramAPI(t *testing.T) (*mockTelegramAPI, string) {
+	t.Helper()
+	api := &mockTelegramAPI{notify: make(chan struct{}, 1), replies: make(map[string][]string)}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	return api, server.URL + "/bot%s/sendMessage"
+}
+
+func (m *mockTelegramAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/bottest-token/getUpdates":
+		for {
+			m.mu.Lock()
+			updates := m.queued
+			m.queued = nil
+			m.mu.Unlock()
+			if len(updates) > 0 {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": updates})
+				return
+			}
+			select {
+			case <-m.notify:
+			case <-r.Context().Done():
+				return
+			}
+		}
+	case "/bottest-token/sendMessage":
+		var req struct {
+			ChatID string `json:"chat_id"`
+			Text   string `json:"text"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		m.mu.Lock()
+		m.replies[req.ChatID] = append(m.replies[req.ChatID], req.Text)
+		m.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// send queues a message from a chat.
+func (m *mockTelegramAPI) send(chatID int64, text string) {
+	m.mu.Lock()
+	m.updateID++
+	m.queued = append(m.queued, map[string]interface{}{
+		"update_id": m.updateID,
+		"message":   map[string]interface{}{"chat": map[string]interface{}{"id": chatID}, "text": text},
+	})
+	m.mu.Unlock()
+	select {
+	case m.notify <- struct{}{}:
+	default:
+	}
+}
+
+// command sends a message from a chat and waits for the bot's reply.
+func (m *mockTelegramAPI) command(t *testing.T, chatID int64, text string) string {
+	t.Helper()
+	key := strconv.FormatInt(chatID, 10)
+	m.mu.Lock()
+	before := len(m.replies[key])
+	m.mu.Unlock()
+
+	m.send(chatID, text)
+
+	var reply string
+	require.Eventually(t, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if len(m.replies[key]) > before {
+			reply = m.replies[key][before]
+			return true
+		}
+		return false
+	}, 5*time.Second, 20*time.Millisecond, "no reply to %q", text)
+	return reply
+}
+
+func startTelegramBot(t *testing.T, apiURL string) {
+	t.Helper()
+	catalogService := catalog.NewService(catalogpostgres.NewRepository(testDB), nil)
+	notificationsService := notifications.NewService(notificationspostgres.NewRepository(testDB), nil, catalogService, nil)
+
+	sender, err := telegram.NewSender(telegram.Config{Enabled: true, BotToken: "test-token", APIUrl: apiURL})
+	require.NoError(t, err)
+	bot := telegram.NewBot(telegram.BotConfig{BotToken: "test-token", APIUrl: apiURL, PollTimeout: time.Second},
+		notificationsService, catalogService, sender)
+	bot.Start(context.Background())
+	t.Cleanup(bot.Stop)
+}
+
+func channelServiceIDs(t *testing.T, channelID string) []string {
+	t.Helper()
+	rows, err := testDB.Query(context.Background(),
+		`SELECT service_id FROM channel_subscriptions WHERE channel_id = $1`, channelID)
+	require.NoError(t, err)
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		require.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	require.NoError(t, rows.Err())
+	return ids
+}
+
+func TestTelegramBot_Commands(t *testing.T) {
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	email := testutil.RandomEmail()
+	userID := adminCreateTestUser(t, admin, email, "password123", "user")
+	user := newTestClient(t)
+	user.LoginAs(t, email, "password123")
+
+	chatID := time.Now().UnixNano()
+	channelID := createTelegramChannel(t, user, strconv.FormatInt(chatID, 10))
+	verifyTelegramChannel(t, user, channelID)
+
+	serviceID, serviceSlug := createTestService(t, admin, "telegram-bot")
+	t.Cleanup(func() { deleteService(t, admin, serviceSlug) })
+
+	api, apiURL := newMockTelegramAPI(t)
+	startTelegramBot(t, apiURL)
+
+	assert.Contains(t, api.command(t, chatID, "/status"), "telegram-bot")
+
+	// /subscribe links the chat to the channel owner and adds the service
+	assert.Contains(t, api.command(t, chatID, "/subscribe "+serviceSlug), "Subscribed to")
+	assert.Equal(t, []string{serviceID}, channelServiceIDs(t, channelID))
+	assert.Equal(t, 1, countRows(t, `SELECT COUNT(*) FROM telegram_users WHERE user_id = $1`, userID))
+
+	assert.Contains(t, api.command(t, chatID, "/subscribe "+serviceSlug), "Already subscribed")
+	assert.Contains(t, api.command(t, chatID, "/subscribe no-such-service"), "not found")
+	assert.Equal(t, []string{serviceID}, channelServiceIDs(t, channelID))
+
+	assert.Equal(t, "Unsubscribed from all services.", api.command(t, chatID, "/unsubscribe"))
+	assert.Empty(t, channelServiceIDs(t, channelID))
+}
+
+func TestTelegramBot_UnlinkedChat(t *testing.T) {
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	// An unverified channel doesn't link the chat
+	chatID := time.Now().UnixNano()
+	createTelegramChannel(t, newTestOperator(t, admin), strconv.FormatInt(chatID, 10))
+
+	_, serviceSlug := createTestService(t, admin, "telegram-bot-unlinked")
+	t.Cleanup(func() { deleteService(t, admin, serviceSlug) })
+
+	api, apiURL := newMockTelegramAPI(t)
+	startTelegramBot(t, apiURL)
+
+	assert.Contains(t, api.command(t, chatID, "/subscribe "+serviceSlug), "not linked")
+	assert.Contains(t, api.command(t, chatID, "/unsubscribe"), "not linked")
+	assert.Equal(t, 0, countRows(t, `SELECT COUNT(*) FROM telegram_users WHERE chat_id = $1::bigint`, strconv.FormatInt(chatID, 10)))
+}
@@ -0,0 +1,158 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/catalog"
+	catalogpostgres "github.com/bissquit/incident-garden/internal/catalog/postgres"
+	"github.com/bissquit/incident-garden/internal/domain"
+	"github.com/bissquit/incident-garden/internal/notifications"
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countQueued returns the number of queued notifications for the channels.
+func countQueued(t *testing.T, channelIDs ...string) int {
+	t.Helper()
+	var count int
+	err := testDB.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM notification_queue WHERE channel_id = ANY($1)`, channelIDs).Scan(&count)
+	require.NoError(t, err)
+	return count
+}
+
+func TestSubscriptions_UnsubscribeAll(t *testing.T) {
+	ctx := context.Background()
+	repo := notificationspostgres.NewRepository(testDB)
+	catalogService := catalog.NewService(catalogpostgres.NewRepository(testDB), nil)
+	dispatcher := notifications.NewDispatcher(repo, NewMockSenderRegistry().GetSenders()...)
+	renderer, err := notifications.NewRenderer()
+	require.NoError(t, err)
+	notifier := notifications.NewNotifier(repo, renderer, dispatcher, catalogService, "https://status.example.com")
+
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, admin, "unsubscribe-all-svc")
+	t.Cleanup(func() { deleteService(t, admin, serviceSlug) })
+
+	// A fresh user with two channels: the default one on all services and
+	// a telegram channel on the test service.
+	email := testutil.RandomEmail()
+	resp, err := admin.POST("/api/v1/auth/register", map[string]string{
+		"email":    email,
+		"password": "password123",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	user := newTestClient(t)
+	user.LoginAs(t, email, "password123")
+
+	resp, err = user.GET("/api/v1/me/channels")
+	require.NoError(t, err)
+	var channels struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &channels)
+	require.Len(t, channels.Data, 1)
+	defaultID := channels.Data[0].ID
+
+	resp, err = user.PUT("/api/v1/me/channels/"+defaultID+"/subscriptions", map[string]interface{}{
+		"subscribe_to_all_services": true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	telegramID := createTelegramChannel(t, user, "123456789")
+	verifyTelegramChannel(t, user, telegramID)
+	setChannelSubscription(t, user, telegramID, []string{serviceID})
+
+	// Ongoing event snapshots both channels
+	eventID := createTestIncident(t, admin, "Unsubscribe All Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	t.Cleanup(func() {
+		resolveEvent(t, admin, eventID)
+		deleteEvent(t, admin, eventID)
+	})
+
+	now := time.Now()
+	event := &domain.Event{
+		ID:                eventID,
+		Title:             "Unsubscribe All Incident",
+		Type:              domain.EventTypeIncident,
+		Status:            domain.EventStatusInvestigating,
+		NotifySubscribers: true,
+		CreatedAt:         now,
+		StartedAt:         &now,
+		ServiceIDs:        []string{serviceID},
+	}
+	require.NoError(t, notifier.OnEventCreated(ctx, event, []string{serviceID}))
+
+	subscribers, err := repo.GetEventSubscribers(ctx, eventID)
+	require.NoError(t, err)
+	require.Contains(t, subscribers, defaultID)
+	require.Contains(t, subscribers, telegramID)
+	queued := countQueued(t, defaultID, telegramID)
+	require.Equal(t, 2, queued)
+
+	resp, err = user.DELETE("/api/v1/me/subscriptions")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	for _, channelID := range []string{defaultID, telegramID} {
+		subscribeAll, serviceIDs, err := repo.GetChannelSubscriptions(ctx, channelID)
+		require.NoError(t, err)
+		assert.False(t, subscribeAll)
+		assert.Empty(t, serviceIDs)
+	}
+
+	subscribers, err = repo.GetEventSubscribers(ctx, eventID)
+	require.NoError(t, err)
+	assert.NotContains(t, subscribers, defaultID)
+	assert.NotContains(t, subscribers, telegramID)
+
+	// Updates of the ongoing event and new events queue nothing for the user
+	event.Status = domain.EventStatusIdentified
+	update := &domain.EventUpdate{Message: "Root cause found", NotifySubscribers: true}
+	require.NoError(t, notifier.OnEventUpdated(ctx, event, update, &notifications.EventUpdateChanges{
+		StatusFrom: "investigating",
+		StatusTo:   "identified",
+	}))
+
+	secondID := createTestIncident(t, admin, "Unsubscribe All Second Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	t.Cleanup(func() {
+		resolveEvent(t, admin, secondID)
+		deleteEvent(t, admin, secondID)
+	})
+	event.ID = secondID
+	require.NoError(t, notifier.OnEventCreated(ctx, event, []string{serviceID}))
+
+	assert.Equal(t, queued, countQueued(t, defaultID, telegramID), "no notifications queued after unsubscribe")
+
+	// Channels are kept
+	resp, err = user.GET("/api/v1/me/channels")
+	require.NoError(t, err)
+	testutil.DecodeJSON(t, resp, &channels)
+	assert.Len(t, channels.Data, 2)
+}
+
+func TestSubscriptions_UnsubscribeAll_RequiresAuth(t *testing.T) {
+	resp, err := newTestClient(t).DELETE("/api/v1/me/subscriptions")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
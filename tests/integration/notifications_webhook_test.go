@@ -0,0 +1,274 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/notifications"
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/bissquit/incident-garden/internal/notifications/webhook"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookRequest is a request captured by webhookReceiver.
+type webhookRequest struct {
+	Body      []byte
+	Signature string
+	RequestID string
+}
+
+// webhookReceiver is an HTTP endpoint that records incoming webhook requests.
+type webhookReceiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []webhookRequest
+	status   int
+}
+
+func newWebhookReceiver(t *testing.T, status int) *webhookReceiver {
+	t.Helper()
+	rcv := &webhookReceiver{status: status}
+	rcv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rcv.mu.Lock()
+		rcv.requests = append(rcv.requests, webhookRequest{
+			Body:      body,
+			Signature: r.Header.Get(webhook.SignatureHeader),
+			RequestID: r.Header.Get(httputil.RequestIDHeader),
+		})
+		rcv.mu.Unlock()
+		w.WriteHeader(rcv.status)
+	}))
+	t.Cleanup(rcv.Close)
+	return rcv
+}
+
+func (r *webhookReceiver) Requests() []webhookRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]webhookRequest, len(r.requests))
+	copy(result, r.requests)
+	return result
+}
+
+// createWebhookChannel creates a webhook channel via API and returns its ID.
+func createWebhookChannel(t *testing.T, client *testutil.Client, target, secret string) string {
+	t.Helper()
+	body := map[string]interface{}{
+		"type":   "webhook",
+		"target": target,
+	}
+	if secret != "" {
+		body["secret"] = secret
+	}
+
+	resp, err := client.POST("/api/v1/me/channels", body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	require.NotEmpty(t, result.Data.ID)
+	return result.Data.ID
+}
+
+func TestChannels_Create_Webhook_Success(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsUser(t)
+
+	resp, err := client.POST("/api/v1/me/channels", map[string]interface{}{
+		"type":   "webhook",
+		"target": "https://hooks.example.com/incident-garden",
+		"secret": "top-secret",
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+
+	channelID, _ := result.Data["id"].(string)
+	require.NotEmpty(t, channelID)
+	t.Cleanup(func() { deleteChannel(t, client, channelID) })
+
+	assert.Equal(t, "webhook", result.Data["type"])
+	assert.Equal(t, "https://hooks.example.com/incident-garden", result.Data["target"])
+	assert.Equal(t, false, result.Data["is_verified"])
+	assert.NotContains(t, result.Data, "secret", "secret must never be returned")
+
+	// Secret is stored alongside the channel
+	var secret string
+	err = testDB.QueryRow(context.Background(),
+		`SELECT secret FROM notification_channels WHERE id = $1`, channelID).Scan(&secret)
+	require.NoError(t, err)
+	assert.Equal(t, "top-secret", secret)
+}
+
+func TestChannels_Create_SecretForNonWebhook_BadRequest(t *testing.T) {
+	client := newTestClientWithoutValidation()
+	client.LoginAsUser(t)
+
+	resp, err := client.POST("/api/v1/me/channels", map[string]interface{}{
+		"type":   "slack",
+		"target": "https://hooks.slack.com/services/T000/B000/abc123",
+		"secret": "not-allowed",
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestVerification_WebhookChannel_VerifyByTestPost_Success(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsUser(t)
+	userID := getUserID(t, client)
+
+	rcv := newWebhookReceiver(t, http.StatusOK)
+	channelID := createWebhookChannel(t, client, rcv.URL, "verify-secret")
+	t.Cleanup(func() { deleteChannel(t, client, channelID) })
+
+	repo := notificationspostgres.NewRepository(testDB)
+	dispatcher := notifications.NewDispatcher(repo, webhook.NewSender(webhook.Config{}))
+	svc := notifications.NewService(repo, dispatcher, nil, nil)
+
+	verified, err := svc.VerifyChannel(context.Background(), userID, channelID, "")
+	require.NoError(t, err)
+	assert.True(t, verified.IsVerified)
+
+	requests := rcv.Requests()
+	require.Len(t, requests, 1, "verification is a single POST")
+	assert.Equal(t, webhook.Sign("verify-secret", requests[0].Body), requests[0].Signature)
+	assert.Contains(t, string(requests[0].Body), `"notification_type":"verification"`)
+}
+
+func TestVerification_WebhookChannel_Non200_Fails(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsUser(t)
+	userID := getUserID(t, client)
+
+	rcv := newWebhookReceiver(t, http.StatusNoContent)
+	channelID := createWebhookChannel(t, client, rcv.URL, "")
+	t.Cleanup(func() { deleteChannel(t, client, channelID) })
+
+	repo := notificationspostgres.NewRepository(testDB)
+	dispatcher := notifications.NewDispatcher(repo, webhook.NewSender(webhook.Config{}))
+	svc := notifications.NewService(repo, dispatcher, nil, nil)
+
+	_, err := svc.VerifyChannel(context.Background(), userID, channelID, "")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, notifications.ErrVerificationFailed))
+	assert.Len(t, rcv.Requests(), 1)
+
+	var isVerified bool
+	err = testDB.QueryRow(context.Background(),
+		`SELECT is_verified FROM notification_channels WHERE id = $1`, channelID).Scan(&isVerified)
+	require.NoError(t, err)
+	assert.False(t, isVerified)
+}
+
+func TestDispatch_Webhook_SignedJSONPayload(t *testing.T) {
+	ctx := context.Background()
+	repo := notificationspostgres.NewRepository(testDB)
+
+	rcv := newWebhookReceiver(t, http.StatusOK)
+
+	dispatcher := notifications.NewDispatcher(repo, webhook.NewSender(webhook.Config{
+		InitialBackoff: 10 * time.Millisecond,
+	}))
+	renderer, err := notifications.NewRenderer()
+	require.NoError(t, err)
+
+	worker := notifications.NewWorker(notifications.WorkerConfig{
+		BatchSize:         10,
+		PollInterval:      100 * time.Millisecond,
+		MaxAttempts:       3,
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        1 * time.Second,
+		BackoffMultiplier: 2.0,
+		NumWorkers:        1,
+	}, repo, dispatcher, renderer)
+
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "dispatch-webhook-svc")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	eventID := createTestIncident(t, client, "Dispatch Webhook Test",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	client.LoginAsUser(t)
+	channelID := createWebhookChannel(t, client, rcv.URL, "dispatch-secret")
+	t.Cleanup(func() {
+		client.LoginAsUser(t)
+		deleteChannel(t, client, channelID)
+	})
+	_, err = testDB.Exec(ctx, `UPDATE notification_channels SET is_verified = true WHERE id = $1`, channelID)
+	require.NoError(t, err)
+
+	item := &notifications.QueueItem{
+		ID:          uuid.New().String(),
+		EventID:     eventID,
+		ChannelID:   channelID,
+		MessageType: notifications.MessageTypeInitial,
+		Payload: notifications.NotificationPayload{
+			MessageType: notifications.MessageTypeInitial,
+			Event: notifications.EventData{
+				ID:       eventID,
+				Title:    "Dispatch Webhook Test",
+				Type:     "incident",
+				Status:   "investigating",
+				Services: []notifications.ServiceInfo{{ID: serviceID, Name: "dispatch-webhook-svc"}},
+			},
+			GeneratedAt: time.Now(),
+		},
+		MaxAttempts: 3,
+	}
+	require.NoError(t, repo.EnqueueNotification(ctx, item))
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	worker.Start(workerCtx)
+	defer func() {
+		cancel()
+		worker.Stop()
+	}()
+
+	require.Eventually(t, func() bool { return len(rcv.Requests()) >= 1 }, 3*time.Second, 50*time.Millisecond,
+		"webhook should be delivered")
+
+	req := rcv.Requests()[0]
+	assert.Equal(t, webhook.Sign("dispatch-secret", req.Body), req.Signature)
+
+	var payload notifications.WebhookPayload
+	require.NoError(t, json.Unmarshal(req.Body, &payload))
+	assert.Equal(t, notifications.WebhookNotificationEventCreated, payload.NotificationType)
+	assert.Equal(t, eventID, payload.ID)
+	assert.Equal(t, "Dispatch Webhook Test", payload.Title)
+	assert.Equal(t, []string{serviceID}, payload.ServiceIDs)
+}
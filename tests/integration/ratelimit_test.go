@@ -0,0 +1,136 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/app"
+	"github.com/bissquit/incident-garden/internal/config"
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRateLimitedServer starts a separate app with low write limits, so the shared app stays unthrottled.
+func newRateLimitedServer(t *testing.T, limits config.RateLimitConfig) *httptest.Server {
+	t.Helper()
+
+	cfg := *testConfig
+	cfg.RateLimit = limits
+	application, err := app.New(&cfg)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(application.Router())
+	t.Cleanup(func() {
+		server.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = application.Shutdown(ctx)
+	})
+	return server
+}
+
+// registerAndLogin registers a fresh user, so unsubscribing does not touch shared seed users.
+func registerAndLogin(t *testing.T, baseURL string) *testutil.Client {
+	t.Helper()
+	client := testutil.NewClient(baseURL)
+	email := testutil.RandomEmail()
+	resp, err := client.POST("/api/v1/auth/register", map[string]string{
+		"email":    email,
+		"password": "password123",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	client.LoginAs(t, email, "password123")
+	return client
+}
+
+func TestRateLimit_WriteRequestsPerUser(t *testing.T) {
+	server := newRateLimitedServer(t, config.RateLimitConfig{
+		Enabled:           true,
+		AdminPerMinute:    5,
+		OperatorPerMinute: 3,
+		UserPerMinute:     2,
+	})
+
+	user := registerAndLogin(t, server.URL)
+
+	for i := 0; i < 2; i++ {
+		resp, err := user.DELETE("/api/v1/me/subscriptions")
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNoContent, resp.StatusCode, "request %d", i+1)
+	}
+
+	resp, err := user.DELETE("/api/v1/me/subscriptions")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	require.NoError(t, err)
+	assert.Greater(t, retryAfter, 0)
+	assert.LessOrEqual(t, retryAfter, 30)
+
+	// Reads are not limited
+	resp, err = user.GET("/api/v1/me/channels")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Other users have their own bucket
+	other := registerAndLogin(t, server.URL)
+	resp, err = other.DELETE("/api/v1/me/subscriptions")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestRateLimit_GraphQL(t *testing.T) {
+	server := newRateLimitedServer(t, config.RateLimitConfig{
+		Enabled:       true,
+		UserPerMinute: 2,
+	})
+	query := map[string]string{"query": "{ services { slug } }"}
+
+	user := registerAndLogin(t, server.URL)
+	for i := 0; i < 2; i++ {
+		resp, err := user.POST("/api/v1/graphql", query)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode, "request %d", i+1)
+	}
+
+	resp, err := user.POST("/api/v1/graphql", query)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+
+	// Anonymous requests have no user bucket
+	anonymous := testutil.NewClient(server.URL)
+	for i := 0; i < 3; i++ {
+		resp, err := anonymous.POST("/api/v1/graphql", query)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestRateLimit_DisabledByConfig(t *testing.T) {
+	user := registerAndLogin(t, testServer.URL)
+
+	for i := 0; i < 5; i++ {
+		resp, err := user.DELETE("/api/v1/me/subscriptions")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+}
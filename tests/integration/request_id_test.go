@@ -0,0 +1,129 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/notifications"
+	notificationspostgres "github.com/bissquit/incident-garden/internal/notifications/postgres"
+	"github.com/bissquit/incident-garden/internal/notifications/webhook"
+	"github.com/bissquit/incident-garden/internal/pkg/httputil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sendWithRequestID(t *testing.T, method, path, requestID string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, testServer.URL+path, nil)
+	require.NoError(t, err)
+	if requestID != "" {
+		req.Header.Set(httputil.RequestIDHeader, requestID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp
+}
+
+func TestRequestID_ResponseHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+	}{
+		{"health", http.MethodGet, "/healthz", http.StatusOK},
+		{"public api", http.MethodGet, "/api/v1/services", http.StatusOK},
+		{"unauthenticated", http.MethodGet, "/api/v1/me", http.StatusUnauthorized},
+		{"not found", http.MethodGet, "/api/v1/no-such-route", http.StatusNotFound},
+		{"preflight", http.MethodOptions, "/api/v1/services", http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := sendWithRequestID(t, tt.method, tt.path, "")
+			assert.Equal(t, tt.status, resp.StatusCode)
+			_, err := uuid.Parse(resp.Header.Get(httputil.RequestIDHeader))
+			assert.NoError(t, err, "generated request ID is a UUID")
+		})
+	}
+}
+
+func TestRequestID_Echoed(t *testing.T) {
+	id := uuid.NewString()
+	resp := sendWithRequestID(t, http.MethodGet, "/api/v1/services", id)
+	assert.Equal(t, id, resp.Header.Get(httputil.RequestIDHeader))
+
+	resp = sendWithRequestID(t, http.MethodGet, "/api/v1/services", "not-a-uuid")
+	got := resp.Header.Get(httputil.RequestIDHeader)
+	assert.NotEqual(t, "not-a-uuid", got)
+	_, err := uuid.Parse(got)
+	assert.NoError(t, err, "an invalid ID is replaced")
+}
+
+func TestRequestID_PropagatedToWebhookDelivery(t *testing.T) {
+	ctx := context.Background()
+	repo := notificationspostgres.NewRepository(testDB)
+	rcv := newWebhookReceiver(t, http.StatusOK)
+
+	dispatcher := notifications.NewDispatcher(repo, webhook.NewSender(webhook.Config{}))
+	renderer, err := notifications.NewRenderer()
+	require.NoError(t, err)
+	notifier := notifications.NewNotifier(repo, renderer, dispatcher, nil, "https://status.example.com")
+	worker := notifications.NewWorker(notifications.WorkerConfig{
+		BatchSize:         10,
+		PollInterval:      100 * time.Millisecond,
+		MaxAttempts:       3,
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        1 * time.Second,
+		BackoffMultiplier: 2.0,
+		NumWorkers:        1,
+	}, repo, dispatcher, renderer)
+
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "request-id-svc")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	client.LoginAsUser(t)
+	channelID := createWebhookChannel(t, client, rcv.URL, "")
+	t.Cleanup(func() {
+		client.LoginAsUser(t)
+		deleteChannel(t, client, channelID)
+	})
+	_, err = testDB.Exec(ctx, `UPDATE notification_channels SET is_verified = true WHERE id = $1`, channelID)
+	require.NoError(t, err)
+
+	client.LoginAsAdmin(t)
+	eventID := createTestMaintenance(t, client, "Request ID maintenance",
+		[]AffectedService{{ServiceID: serviceID, Status: "maintenance"}})
+	t.Cleanup(func() {
+		client.LoginAsAdmin(t)
+		completeMaintenance(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+	require.NoError(t, repo.AddEventSubscribers(ctx, eventID, []string{channelID}))
+
+	// The notification is queued within a request and delivered later by the worker
+	requestID := uuid.NewString()
+	event := getEvent(t, client, eventID)
+	err = notifier.OnMaintenanceReminder(httputil.WithRequestID(ctx, requestID), &event)
+	require.NoError(t, err)
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	worker.Start(workerCtx)
+	defer func() {
+		cancel()
+		worker.Stop()
+	}()
+
+	require.Eventually(t, func() bool { return len(rcv.Requests()) >= 1 }, 3*time.Second, 50*time.Millisecond,
+		"webhook should be delivered")
+	assert.Equal(t, requestID, rcv.Requests()[0].RequestID)
+}
@@ -0,0 +1,112 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type statusLogRow struct {
+	SourceType string
+	NewStatus  string
+	Reason     string
+}
+
+// eventStatusLog returns the status log entries of an event, oldest first.
+func eventStatusLog(t *testing.T, eventID string) []statusLogRow {
+	t.Helper()
+	rows, err := testDB.Query(context.Background(), `
+		SELECT source_type, new_status, COALESCE(reason, '') FROM service_status_log
+		WHERE event_id = $1 ORDER BY created_at, id`, eventID)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var entries []statusLogRow
+	for rows.Next() {
+		var e statusLogRow
+		require.NoError(t, rows.Scan(&e.SourceType, &e.NewStatus, &e.Reason))
+		entries = append(entries, e)
+	}
+	require.NoError(t, rows.Err())
+	return entries
+}
+
+func TestStatusLogSource_Event(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Log Source Event")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	eventID := createTestIncident(t, client, "Log Source Event Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "partial_outage"}}, nil)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+	resolveEvent(t, client, eventID)
+
+	assert.Equal(t, []statusLogRow{
+		{"event", "partial_outage", "Event created: Log Source Event Incident"},
+		{"event", "operational", "Event resolved, no other active events"},
+	}, eventStatusLog(t, eventID))
+}
+
+func TestStatusLogSource_PagerDutyWebhook(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "Log Source PagerDuty")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	fixture := newPagerDutyFixture("Log Source PD Incident", "P1", slug)
+	triggered := sendPagerDutyFixture(t, "incident_triggered", fixture)
+	require.Equal(t, "created", triggered.Data.Action)
+	t.Cleanup(func() { deleteEvent(t, client, triggered.Data.EventID) })
+	sendPagerDutyFixture(t, "incident_resolved", fixture)
+
+	assert.Equal(t, []statusLogRow{
+		{"webhook", "major_outage", "Event created: Log Source PD Incident (pagerduty webhook)"},
+		{"webhook", "operational", "Event resolved, no other active events (pagerduty webhook)"},
+	}, eventStatusLog(t, triggered.Data.EventID))
+}
+
+func TestStatusLogSource_OpsGenieWebhook(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "Log Source OpsGenie")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	fixture := newOpsGenieFixture("Log Source OG Alert", "P3", slug)
+	created := sendOpsGenieFixture(t, "alert_create", fixture)
+	require.Equal(t, "created", created.Data.Action)
+	t.Cleanup(func() { deleteEvent(t, client, created.Data.EventID) })
+	sendOpsGenieFixture(t, "alert_close", fixture)
+
+	assert.Equal(t, []statusLogRow{
+		{"webhook", "degraded", "Event created: Log Source OG Alert (opsgenie webhook)"},
+		{"webhook", "operational", "Event resolved, no other active events (opsgenie webhook)"},
+	}, eventStatusLog(t, created.Data.EventID))
+}
+
+func TestStatusLogSource_ManualUpdateOfWebhookIncident(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "Log Source Manual Resolve")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	// A webhook incident resolved by an operator logs the resolution as an event change
+	fixture := newPagerDutyFixture("Log Source Manual Resolve", "P2", slug)
+	triggered := sendPagerDutyFixture(t, "incident_triggered", fixture)
+	require.Equal(t, "created", triggered.Data.Action)
+	t.Cleanup(func() { deleteEvent(t, client, triggered.Data.EventID) })
+	resolveEvent(t, client, triggered.Data.EventID)
+
+	entries := eventStatusLog(t, triggered.Data.EventID)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "webhook", entries[0].SourceType)
+	assert.Equal(t, statusLogRow{"event", "operational", "Event resolved, no other active events"}, entries[1])
+}
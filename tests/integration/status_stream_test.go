@@ -0,0 +1,194 @@
+//go:build integration
+
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/sse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamFrame is a decoded "data:" frame of the status stream.
+type streamFrame struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// openStatusStream connects to GET /status/stream and returns a channel of decoded frames.
+// Heartbeat comments are skipped. The connection is closed via the returned cancel func.
+func openStatusStream(t *testing.T) (<-chan streamFrame, context.CancelFunc) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, testServer.URL+"/api/v1/status/stream", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	frames := make(chan streamFrame, 64)
+	go func() {
+		defer close(frames)
+		defer resp.Body.Close()
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+			if !ok {
+				continue
+			}
+			var frame streamFrame
+			if err := json.Unmarshal([]byte(data), &frame); err == nil {
+				frames <- frame
+			}
+		}
+	}()
+
+	t.Cleanup(cancel)
+	return frames, cancel
+}
+
+// waitForFrame reads frames until match returns true or timeout expires.
+func waitForFrame(t *testing.T, frames <-chan streamFrame, match func(streamFrame) bool) streamFrame {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case frame, ok := <-frames:
+			require.True(t, ok, "stream closed before expected frame")
+			if match(frame) {
+				return frame
+			}
+		case <-timeout:
+			t.Fatal("expected frame was not received")
+		}
+	}
+}
+
+func TestStatusStream_EventCreated(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "Stream Created Service")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	frames, _ := openStatusStream(t)
+	require.Eventually(t, func() bool { return testBroadcaster.SubscriberCount() > 0 },
+		2*time.Second, 10*time.Millisecond)
+
+	eventID := createTestIncident(t, client, "Stream Created Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "degraded"}}, nil)
+	t.Cleanup(func() {
+		resolveEvent(t, client, eventID)
+		deleteEvent(t, client, eventID)
+	})
+
+	created := waitForFrame(t, frames, func(f streamFrame) bool {
+		if f.Type != sse.TypeEventCreated {
+			return false
+		}
+		var event struct {
+			ID string `json:"id"`
+		}
+		return json.Unmarshal(f.Data, &event) == nil && event.ID == eventID
+	})
+	assert.Contains(t, string(created.Data), `"title":"Stream Created Incident"`)
+
+	changed := waitForFrame(t, frames, func(f streamFrame) bool {
+		var change sse.ServiceStatusChange
+		return f.Type == sse.TypeServiceStatusChanged &&
+			json.Unmarshal(f.Data, &change) == nil && change.ServiceID == serviceID
+	})
+	var change sse.ServiceStatusChange
+	require.NoError(t, json.Unmarshal(changed.Data, &change))
+	assert.Equal(t, "operational", string(change.PreviousStatus))
+	assert.Equal(t, "degraded", string(change.EffectiveStatus))
+}
+
+func TestStatusStream_EventResolved(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "Stream Resolved Service")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	eventID := createTestIncident(t, client, "Stream Resolved Incident",
+		[]AffectedService{{ServiceID: serviceID, Status: "major_outage"}}, nil)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	frames, _ := openStatusStream(t)
+	require.Eventually(t, func() bool { return testBroadcaster.SubscriberCount() > 0 },
+		2*time.Second, 10*time.Millisecond)
+
+	resolveEvent(t, client, eventID)
+
+	updated := waitForFrame(t, frames, func(f streamFrame) bool {
+		var update struct {
+			EventID string `json:"event_id"`
+		}
+		return f.Type == sse.TypeEventUpdated &&
+			json.Unmarshal(f.Data, &update) == nil && update.EventID == eventID
+	})
+	assert.Contains(t, string(updated.Data), `"status":"resolved"`)
+
+	changed := waitForFrame(t, frames, func(f streamFrame) bool {
+		var change sse.ServiceStatusChange
+		return f.Type == sse.TypeServiceStatusChanged &&
+			json.Unmarshal(f.Data, &change) == nil && change.ServiceID == serviceID
+	})
+	var change sse.ServiceStatusChange
+	require.NoError(t, json.Unmarshal(changed.Data, &change))
+	assert.Equal(t, "major_outage", string(change.PreviousStatus))
+	assert.Equal(t, "operational", string(change.EffectiveStatus))
+}
+
+func TestStatusStream_ManualServiceStatusChange(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, serviceSlug := createTestService(t, client, "Stream Manual Service")
+	t.Cleanup(func() { deleteService(t, client, serviceSlug) })
+
+	frames, _ := openStatusStream(t)
+	require.Eventually(t, func() bool { return testBroadcaster.SubscriberCount() > 0 },
+		2*time.Second, 10*time.Millisecond)
+
+	resp, err := client.PATCH("/api/v1/services/"+serviceSlug, map[string]interface{}{
+		"name":   "Stream Manual Service",
+		"slug":   serviceSlug,
+		"status": "maintenance",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	changed := waitForFrame(t, frames, func(f streamFrame) bool {
+		var change sse.ServiceStatusChange
+		return f.Type == sse.TypeServiceStatusChanged &&
+			json.Unmarshal(f.Data, &change) == nil && change.ServiceID == serviceID
+	})
+	var change sse.ServiceStatusChange
+	require.NoError(t, json.Unmarshal(changed.Data, &change))
+	assert.Equal(t, "maintenance", string(change.EffectiveStatus))
+}
+
+func TestStatusStream_ClientDisconnectCleanup(t *testing.T) {
+	baseline := testBroadcaster.SubscriberCount()
+
+	_, cancel := openStatusStream(t)
+	require.Eventually(t, func() bool { return testBroadcaster.SubscriberCount() == baseline+1 },
+		2*time.Second, 10*time.Millisecond, "subscriber should be registered")
+
+	cancel()
+	require.Eventually(t, func() bool { return testBroadcaster.SubscriberCount() == baseline },
+		2*time.Second, 10*time.Millisecond, "subscriber should be removed after disconnect")
+}
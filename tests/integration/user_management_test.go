@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -388,6 +389,148 @@ func TestIdentity_ProfileUpdate_RoleAndEmailUnchanged(t *testing.T) {
 	assert.Equal(t, "user", result.Data.Role)
 }
 
+// getMe returns the current user's profile as a raw JSON object.
+func getMe(t *testing.T, client *testutil.Client) map[string]interface{} {
+	t.Helper()
+	resp, err := client.GET("/api/v1/me")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data
+}
+
+func TestIdentity_Me_ResponseShape(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	email := testutil.RandomEmail()
+	userID := adminCreateTestUser(t, client, email, "password1234", "operator")
+
+	userClient := newTestClient(t)
+	userClient.LoginAs(t, email, "password1234")
+
+	me := getMe(t, userClient)
+	assert.Equal(t, userID, me["id"])
+	assert.Equal(t, email, me["email"])
+	assert.Equal(t, "operator", me["role"])
+	assert.Contains(t, me, "name")
+	assert.NotContains(t, me, "password_hash")
+}
+
+func TestIdentity_ProfileUpdate_NameAndPassword(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	email := testutil.RandomEmail()
+	adminCreateTestUser(t, client, email, "password1234", "user")
+
+	userClient := newTestClient(t)
+	userClient.LoginAs(t, email, "password1234")
+
+	resp, err := userClient.PATCH("/api/v1/me", map[string]interface{}{
+		"name":             "  Dana Scully  ",
+		"password":         "newpassword5678",
+		"current_password": "password1234",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	for _, c := range resp.Cookies() {
+		if c.Name == httputil.AccessTokenCookie || c.Name == httputil.RefreshTokenCookie {
+			assert.True(t, c.MaxAge < 0, "cookie %s should be cleared", c.Name)
+		}
+	}
+
+	var result struct {
+		Data struct {
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, "Dana Scully", result.Data.Name)
+
+	resp, err = newTestClient(t).WithoutValidation().POST("/api/v1/auth/login", map[string]string{
+		"email":    email,
+		"password": "password1234",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "old password no longer works")
+	resp.Body.Close()
+
+	newClient := newTestClient(t)
+	newClient.LoginAs(t, email, "newpassword5678")
+	assert.Equal(t, "Dana Scully", getMe(t, newClient)["name"])
+}
+
+func TestIdentity_ProfileUpdate_WrongCurrentPassword(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	email := testutil.RandomEmail()
+	adminCreateTestUser(t, client, email, "password1234", "user")
+
+	userClient := newTestClient(t)
+	userClient.LoginAs(t, email, "password1234")
+	before := getMe(t, userClient)["name"]
+
+	for _, body := range []map[string]interface{}{
+		{"name": "Changed", "password": "newpassword5678", "current_password": "wrongpassword"},
+		{"name": "Changed", "password": "newpassword5678"},
+	} {
+		resp, err := userClient.PATCH("/api/v1/me", body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, before, getMe(t, userClient)["name"], "nothing is changed")
+	newTestClient(t).LoginAs(t, email, "password1234")
+}
+
+func TestIdentity_ProfileUpdate_InvalidName(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	email := testutil.RandomEmail()
+	adminCreateTestUser(t, client, email, "password1234", "user")
+
+	userClient := newTestClient(t)
+	userClient.LoginAs(t, email, "password1234")
+
+	for _, name := range []string{"", "   ", strings.Repeat("a", 101)} {
+		resp, err := userClient.PATCH("/api/v1/me", map[string]interface{}{"name": name})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "name %q", name)
+		resp.Body.Close()
+	}
+}
+
+func TestIdentity_ProfileUpdate_SQLInjectionInName(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	email := testutil.RandomEmail()
+	adminCreateTestUser(t, client, email, "password1234", "user")
+
+	userClient := newTestClient(t)
+	userClient.LoginAs(t, email, "password1234")
+
+	name := "Robert'); DROP TABLE users;--"
+	resp, err := userClient.PATCH("/api/v1/me", map[string]interface{}{"name": name})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	assert.Equal(t, name, getMe(t, userClient)["name"], "stored verbatim")
+
+	var count int
+	require.NoError(t, testDB.QueryRow(context.Background(), "SELECT COUNT(*) FROM users WHERE email = $1", email).Scan(&count))
+	assert.Equal(t, 1, count, "users table intact")
+}
+
 // =============================================================================
 // Group 3: Forgot Password (POST /auth/forgot-password)
 // =============================================================================
@@ -611,14 +754,14 @@ func TestIdentity_Login_DeactivatedUser(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 	resp.Body.Close()
 
-	// Login should fail with 403
+	// Login should fail with 401
 	loginClient := newTestClient(t)
 	resp, err = loginClient.WithoutValidation().POST("/api/v1/auth/login", map[string]string{
 		"email":    email,
 		"password": password,
 	})
 	require.NoError(t, err)
-	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
 	resp.Body.Close()
 }
 
@@ -1025,6 +1168,123 @@ func TestIdentity_AdminUpdateUser_ModifySelf(t *testing.T) {
 	resp.Body.Close()
 }
 
+func TestIdentity_AdminDeactivateUser_CannotLogin(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	email := testutil.RandomEmail()
+	password := "password1234"
+	userID := adminCreateTestUser(t, client, email, password, "operator")
+
+	userClient := newTestClient(t)
+	userClient.LoginAs(t, email, password)
+
+	resp, err := client.DELETE("/api/v1/users/" + userID)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	resp, err = newTestClient(t).WithoutValidation().POST("/api/v1/auth/login", map[string]string{
+		"email":    email,
+		"password": password,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+
+	resp, err = client.GET("/api/v1/users/" + userID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Data struct {
+			Role          string     `json:"role"`
+			IsActive      bool       `json:"is_active"`
+			LastLoginAt   *time.Time `json:"last_login_at"`
+			DeactivatedAt *time.Time `json:"deactivated_at"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.False(t, result.Data.IsActive)
+	assert.Equal(t, "operator", result.Data.Role, "role is kept")
+	assert.NotNil(t, result.Data.LastLoginAt, "login before deactivation is recorded")
+	assert.NotNil(t, result.Data.DeactivatedAt)
+
+	// Reactivation clears deactivated_at
+	resp, err = client.PATCH("/api/v1/users/"+userID, map[string]interface{}{"is_active": true})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &result)
+	assert.True(t, result.Data.IsActive)
+	assert.Nil(t, result.Data.DeactivatedAt)
+	newTestClient(t).LoginAs(t, email, password)
+}
+
+func TestIdentity_AdminDeactivateUser_Self(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	resp, err := client.DELETE("/api/v1/users/" + getAdminID(t, client))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestIdentity_AdminDeactivateUser_NotFoundAndForbidden(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	resp, err := client.DELETE("/api/v1/users/00000000-0000-0000-0000-000000000000")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	email := testutil.RandomEmail()
+	userID := adminCreateTestUser(t, client, email, "password1234", "user")
+
+	operator := newTestClient(t)
+	operator.LoginAsOperator(t)
+	resp, err = operator.DELETE("/api/v1/users/" + userID)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestIdentity_AdminListUsers_LastLogin(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	email := testutil.RandomEmail()
+	userID := adminCreateTestUser(t, client, email, "password1234", "user")
+
+	findUser := func() map[string]interface{} {
+		resp, err := client.GET("/api/v1/users?limit=100")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var result struct {
+			Data struct {
+				Users []map[string]interface{} `json:"users"`
+			} `json:"data"`
+		}
+		testutil.DecodeJSON(t, resp, &result)
+		for _, u := range result.Data.Users {
+			if u["id"] == userID {
+				return u
+			}
+		}
+		t.Fatalf("user %s not listed", userID)
+		return nil
+	}
+
+	user := findUser()
+	for _, field := range []string{"id", "email", "name", "role", "created_at", "last_login_at"} {
+		assert.Contains(t, user, field)
+	}
+	assert.Nil(t, user["last_login_at"], "never logged in")
+
+	newTestClient(t).LoginAs(t, email, "password1234")
+	assert.NotNil(t, findUser()["last_login_at"])
+}
+
 func TestIdentity_AdminUpdateUser_NotFound(t *testing.T) {
 	client := newTestClient(t)
 	client.LoginAsAdmin(t)
@@ -0,0 +1,209 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookDelivery struct {
+	ID               string          `json:"id"`
+	Source           string          `json:"source"`
+	Payload          json.RawMessage `json:"payload"`
+	ProcessingStatus string          `json:"processing_status"`
+	ErrorMessage     string          `json:"error_message"`
+}
+
+type webhookDeliveriesResponse struct {
+	Data struct {
+		Deliveries []webhookDelivery `json:"deliveries"`
+		Total      int               `json:"total"`
+		Limit      int               `json:"limit"`
+		Offset     int               `json:"offset"`
+	} `json:"data"`
+}
+
+func listWebhookDeliveries(t *testing.T, client *testutil.Client, query string) webhookDeliveriesResponse {
+	t.Helper()
+	resp, err := client.GET("/api/v1/admin/webhooks/deliveries?" + query)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result webhookDeliveriesResponse
+	testutil.DecodeJSON(t, resp, &result)
+	return result
+}
+
+// findWebhookDelivery returns the newest delivery of source whose payload contains marker.
+func findWebhookDelivery(t *testing.T, client *testutil.Client, source, marker string) webhookDelivery {
+	t.Helper()
+	result := listWebhookDeliveries(t, client, "limit=100&source="+source)
+	for _, d := range result.Data.Deliveries {
+		if strings.Contains(string(d.Payload), marker) {
+			return d
+		}
+	}
+	require.Failf(t, "delivery not found", "no %s delivery with %q", source, marker)
+	return webhookDelivery{}
+}
+
+func replayWebhookDelivery(t *testing.T, client *testutil.Client, id string) *http.Response {
+	t.Helper()
+	resp, err := client.PATCH("/api/v1/admin/webhooks/deliveries/"+id+"/replay", nil)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestWebhookDeliveries_RecordedWithOutcome(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "Delivery Log Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	alert := newAlertmanagerAlert("firing", "DeliveryLogged", slug, "warning")
+	sendAlerts(t, alert)
+	t.Cleanup(func() { sendAlerts(t, alert.withStatus("resolved")) })
+
+	processed := findWebhookDelivery(t, client, "prometheus", alert.Fingerprint)
+	assert.Equal(t, "prometheus", processed.Source)
+	assert.Equal(t, "processed", processed.ProcessingStatus)
+	assert.Empty(t, processed.ErrorMessage)
+
+	t.Run("failed", func(t *testing.T) {
+		fixture := newPagerDutyFixture("Delivery Log Failure", "P1", "delivery-nonexistent-service")
+		body := loadPagerDutyFixture(t, "incident_triggered", fixture)
+		resp := postPagerDutyWebhook(t, body, signPagerDuty(testPagerDutySecret, body))
+		resp.Body.Close()
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		failed := findWebhookDelivery(t, client, "pagerduty", fixture.IncidentID)
+		assert.Equal(t, "failed", failed.ProcessingStatus)
+		assert.NotEmpty(t, failed.ErrorMessage)
+	})
+
+	t.Run("unauthenticated requests are not recorded", func(t *testing.T) {
+		rejected := newAlertmanagerAlert("firing", "DeliveryRejected", slug, "warning")
+		resp := postPrometheusWebhook(t, "wrong-token", rejected)
+		resp.Body.Close()
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		for _, d := range listWebhookDeliveries(t, client, "limit=100&source=prometheus").Data.Deliveries {
+			assert.NotContains(t, string(d.Payload), rejected.Fingerprint)
+		}
+	})
+}
+
+func TestWebhookDeliveries_Filters(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	alert := newAlertmanagerAlert("resolved", "DeliveryFilter", "delivery-filter", "warning")
+	sendAlerts(t, alert)
+
+	for _, d := range listWebhookDeliveries(t, client, "source=prometheus&status=processed").Data.Deliveries {
+		assert.Equal(t, "prometheus", d.Source)
+		assert.Equal(t, "processed", d.ProcessingStatus)
+	}
+	for _, d := range listWebhookDeliveries(t, client, "status=failed").Data.Deliveries {
+		assert.Equal(t, "failed", d.ProcessingStatus)
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+		sendAlerts(t, alert)
+		page := listWebhookDeliveries(t, client, "source=prometheus&limit=1")
+		assert.Len(t, page.Data.Deliveries, 1)
+		assert.Equal(t, 1, page.Data.Limit)
+		assert.GreaterOrEqual(t, page.Data.Total, 2)
+
+		next := listWebhookDeliveries(t, client, "source=prometheus&limit=1&offset=1")
+		require.Len(t, next.Data.Deliveries, 1)
+		assert.NotEqual(t, page.Data.Deliveries[0].ID, next.Data.Deliveries[0].ID)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		raw := client.WithoutValidation()
+		for _, query := range []string{"status=done", "limit=0", "offset=-1"} {
+			resp, err := raw.GET("/api/v1/admin/webhooks/deliveries?" + query)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+		}
+	})
+}
+
+func TestWebhookDeliveries_Replay(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	// The incident names a service that doesn't exist yet, so the delivery fails
+	slug := testutil.RandomSlug("delivery-replay")
+	fixture := newPagerDutyFixture("Delivery Replay Incident", "P2", slug)
+	body := loadPagerDutyFixture(t, "incident_triggered", fixture)
+	resp := postPagerDutyWebhook(t, body, signPagerDuty(testPagerDutySecret, body))
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	failed := findWebhookDelivery(t, client, "pagerduty", fixture.IncidentID)
+	require.Equal(t, "failed", failed.ProcessingStatus)
+
+	// Replaying without fixing the cause fails again
+	resp = replayWebhookDelivery(t, client, failed.ID)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var replayed struct {
+		Data webhookDelivery `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &replayed)
+	assert.Equal(t, failed.ID, replayed.Data.ID)
+	assert.Equal(t, "failed", replayed.Data.ProcessingStatus)
+
+	_, _ = createTestService(t, client, "Delivery Replay Service", withSlug(slug))
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	resp = replayWebhookDelivery(t, client, failed.ID)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &replayed)
+	assert.Equal(t, failed.ID, replayed.Data.ID, "replay updates the same delivery")
+	assert.Equal(t, "processed", replayed.Data.ProcessingStatus)
+	assert.Empty(t, replayed.Data.ErrorMessage)
+
+	// The replayed trigger created the incident
+	resolved := sendPagerDutyFixture(t, "incident_resolved", fixture)
+	assert.Equal(t, "updated", resolved.Data.Action)
+	t.Cleanup(func() { deleteEvent(t, client, resolved.Data.EventID) })
+
+	t.Run("not found", func(t *testing.T) {
+		raw := client.WithoutValidation()
+		for _, id := range []string{"00000000-0000-0000-0000-000000000000", "not-a-uuid"} {
+			resp := replayWebhookDelivery(t, raw, id)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode, id)
+		}
+	})
+}
+
+func TestWebhookDeliveries_AdminOnly(t *testing.T) {
+	operator := newTestClient(t)
+	operator.LoginAsOperator(t)
+
+	resp, err := operator.GET("/api/v1/admin/webhooks/deliveries")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = replayWebhookDelivery(t, operator, "00000000-0000-0000-0000-000000000000")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp, err = newTestClient(t).GET("/api/v1/admin/webhooks/deliveries")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,177 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/bissquit/incident-garden/internal/webhooks/opsgenie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testOpsGenieToken is the delivery token configured for the app under test.
+const testOpsGenieToken = "test-opsgenie-token"
+
+type opsGenieFixture struct {
+	AlertID  string
+	Message  string
+	Priority string
+	Slugs    []string
+}
+
+// newOpsGenieFixture returns fixture values with a unique alert ID.
+func newOpsGenieFixture(message, priority string, slugs ...string) opsGenieFixture {
+	return opsGenieFixture{
+		AlertID:  fmt.Sprintf("og-%d", time.Now().UnixNano()),
+		Message:  message,
+		Priority: priority,
+		Slugs:    slugs,
+	}
+}
+
+// loadOpsGenieFixture reads testdata/opsgenie/<name>.json and fills placeholders.
+// Services are tagged "ig_service:<slug>" next to an unrelated tag.
+func loadOpsGenieFixture(t *testing.T, name string, f opsGenieFixture) []byte {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/opsgenie/" + name + ".json")
+	require.NoError(t, err)
+
+	tags := []string{`"team:payments"`}
+	for _, slug := range f.Slugs {
+		tags = append(tags, `"ig_service:`+slug+`"`)
+	}
+
+	return []byte(strings.NewReplacer(
+		"{{ALERT_ID}}", f.AlertID,
+		"{{MESSAGE}}", f.Message,
+		"{{PRIORITY}}", f.Priority,
+		"{{TAGS}}", strings.Join(tags, ", "),
+	).Replace(string(raw)))
+}
+
+func postOpsGenieWebhook(t *testing.T, body []byte, token string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, testServer.URL+"/api/v1/webhooks/opsgenie", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set(opsgenie.TokenHeader, token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+// sendOpsGenieFixture posts a fixture with the token and expects 200.
+func sendOpsGenieFixture(t *testing.T, name string, f opsGenieFixture) pagerDutyResponse {
+	t.Helper()
+	resp := postOpsGenieWebhook(t, loadOpsGenieFixture(t, name, f), testOpsGenieToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result pagerDutyResponse
+	testutil.DecodeJSON(t, resp, &result)
+	return result
+}
+
+func TestOpsGenieWebhook_Lifecycle(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	apiID, apiSlug := createTestService(t, client, "OG Lifecycle API")
+	t.Cleanup(func() { deleteService(t, client, apiSlug) })
+	dbID, dbSlug := createTestService(t, client, "OG Lifecycle DB")
+	t.Cleanup(func() { deleteService(t, client, dbSlug) })
+
+	fixture := newOpsGenieFixture("OG Lifecycle Alert", "P2", apiSlug, dbSlug)
+
+	// Create opens an incident on the tagged services
+	created := sendOpsGenieFixture(t, "alert_create", fixture)
+	assert.Equal(t, "created", created.Data.Action)
+	eventID := created.Data.EventID
+	require.NotEmpty(t, eventID)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	event := getWebhookEvent(t, client, eventID)
+	assert.Equal(t, "OG Lifecycle Alert", event.Data.Title)
+	assert.Equal(t, "incident", event.Data.Type)
+	assert.Equal(t, "investigating", event.Data.Status)
+	assert.Equal(t, "major", event.Data.Severity)
+	assert.Equal(t, "Error rate above 5% for 10 minutes", event.Data.Description)
+	assert.ElementsMatch(t, []string{apiID, dbID}, event.Data.ServiceIDs)
+	assert.Equal(t, "partial_outage", getServiceEffectiveStatus(t, client, apiSlug))
+
+	// Acknowledge posts an update that keeps the incident investigating
+	acked := sendOpsGenieFixture(t, "alert_acknowledge", fixture)
+	assert.Equal(t, "updated", acked.Data.Action)
+	assert.Equal(t, eventID, acked.Data.EventID)
+	assert.Equal(t, "investigating", getWebhookEvent(t, client, eventID).Data.Status)
+	updates := listEventUpdates(t, client, eventID, "?limit=1")
+	require.Len(t, updates.Updates, 1)
+	assert.Equal(t, "Alert acknowledged in OpsGenie", updates.Updates[0].Message)
+
+	// Redelivered acknowledge is ignored
+	ackedAgain := sendOpsGenieFixture(t, "alert_acknowledge", fixture)
+	assert.Equal(t, "ignored", ackedAgain.Data.Action)
+	assert.Equal(t, updates.Total, listEventUpdates(t, client, eventID, "").Total)
+
+	// Close resolves the incident and restores services
+	closed := sendOpsGenieFixture(t, "alert_close", fixture)
+	assert.Equal(t, "updated", closed.Data.Action)
+	assert.Equal(t, "resolved", getWebhookEvent(t, client, eventID).Data.Status)
+	assert.Equal(t, "operational", getServiceEffectiveStatus(t, client, apiSlug))
+	assert.Equal(t, "operational", getServiceEffectiveStatus(t, client, dbSlug))
+
+	// Redelivered close is ignored
+	again := sendOpsGenieFixture(t, "alert_close", fixture)
+	assert.Equal(t, "ignored", again.Data.Action)
+}
+
+func TestOpsGenieWebhook_AcknowledgeUnknownAlertCreates(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	fixture := newOpsGenieFixture("OG Missed Create", "P1")
+
+	result := sendOpsGenieFixture(t, "alert_acknowledge", fixture)
+	assert.Equal(t, "created", result.Data.Action)
+	t.Cleanup(func() { deleteEvent(t, client, result.Data.EventID) })
+
+	event := getWebhookEvent(t, client, result.Data.EventID)
+	assert.Equal(t, "investigating", event.Data.Status)
+	assert.Equal(t, "critical", event.Data.Severity)
+
+	sendOpsGenieFixture(t, "alert_close", fixture)
+}
+
+func TestOpsGenieWebhook_CloseUnknownAlertIgnored(t *testing.T) {
+	result := sendOpsGenieFixture(t, "alert_close", newOpsGenieFixture("OG Unknown Alert", "P1"))
+	assert.Equal(t, "ignored", result.Data.Action)
+	assert.Empty(t, result.Data.EventID)
+}
+
+func TestOpsGenieWebhook_InvalidToken(t *testing.T) {
+	body := loadOpsGenieFixture(t, "alert_create", newOpsGenieFixture("OG Bad Token", "P1"))
+
+	for _, token := range []string{"", "wrong-token"} {
+		resp := postOpsGenieWebhook(t, body, token)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "token %q", token)
+	}
+}
+
+func TestOpsGenieWebhook_UnknownServiceTag(t *testing.T) {
+	body := loadOpsGenieFixture(t, "alert_create", newOpsGenieFixture("OG Unknown Service", "P1", "no-such-service"))
+
+	resp := postOpsGenieWebhook(t, body, testOpsGenieToken)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
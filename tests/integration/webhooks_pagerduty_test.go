@@ -0,0 +1,256 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/bissquit/incident-garden/internal/webhooks/pagerduty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPagerDutySecret is the webhook signing secret configured for the app under test.
+const testPagerDutySecret = "test-pagerduty-secret"
+
+// testPagerDutyPreviousSecret is accepted until TestWebhookSecretRotation completes the rotation.
+const testPagerDutyPreviousSecret = "test-pagerduty-previous-secret"
+
+type pagerDutyFixture struct {
+	IncidentID string
+	Title      string
+	Priority   string
+	Services   string // comma-separated service slugs
+}
+
+type pagerDutyResponse struct {
+	Data struct {
+		Action  string `json:"action"`
+		EventID string `json:"event_id"`
+	} `json:"data"`
+}
+
+// newPagerDutyFixture returns fixture values with a unique incident ID.
+func newPagerDutyFixture(title, priority string, slugs ...string) pagerDutyFixture {
+	return pagerDutyFixture{
+		IncidentID: fmt.Sprintf("PD%d", time.Now().UnixNano()),
+		Title:      title,
+		Priority:   priority,
+		Services:   strings.Join(slugs, ", "),
+	}
+}
+
+// loadPagerDutyFixture reads testdata/pagerduty/<name>.json and fills placeholders.
+func loadPagerDutyFixture(t *testing.T, name string, f pagerDutyFixture) []byte {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/pagerduty/" + name + ".json")
+	require.NoError(t, err)
+
+	return []byte(strings.NewReplacer(
+		"{{INCIDENT_ID}}", f.IncidentID,
+		"{{TITLE}}", f.Title,
+		"{{PRIORITY}}", f.Priority,
+		"{{SERVICES}}", f.Services,
+	).Replace(string(raw)))
+}
+
+func signPagerDuty(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func postPagerDutyWebhook(t *testing.T, body []byte, signature string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, testServer.URL+"/api/v1/webhooks/pagerduty", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(pagerduty.SignatureHeader, signature)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+// sendPagerDutyFixture posts a signed fixture and expects 200.
+func sendPagerDutyFixture(t *testing.T, name string, f pagerDutyFixture) pagerDutyResponse {
+	t.Helper()
+	body := loadPagerDutyFixture(t, name, f)
+	resp := postPagerDutyWebhook(t, body, signPagerDuty(testPagerDutySecret, body))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result pagerDutyResponse
+	testutil.DecodeJSON(t, resp, &result)
+	return result
+}
+
+type webhookEventResponse struct {
+	Data struct {
+		ID          string   `json:"id"`
+		Title       string   `json:"title"`
+		Type        string   `json:"type"`
+		Status      string   `json:"status"`
+		Severity    string   `json:"severity"`
+		Description string   `json:"description"`
+		ServiceIDs  []string `json:"service_ids"`
+	} `json:"data"`
+}
+
+func getWebhookEvent(t *testing.T, client *testutil.Client, eventID string) webhookEventResponse {
+	t.Helper()
+	resp, err := client.GET("/api/v1/events/" + eventID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result webhookEventResponse
+	testutil.DecodeJSON(t, resp, &result)
+	return result
+}
+
+func TestPagerDutyWebhook_Lifecycle(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	apiID, apiSlug := createTestService(t, client, "PD Lifecycle API")
+	t.Cleanup(func() { deleteService(t, client, apiSlug) })
+	dbID, dbSlug := createTestService(t, client, "PD Lifecycle DB")
+	t.Cleanup(func() { deleteService(t, client, dbSlug) })
+
+	fixture := newPagerDutyFixture("PD Lifecycle Incident", "P1", apiSlug, dbSlug)
+
+	// Trigger creates an incident
+	triggered := sendPagerDutyFixture(t, "incident_triggered", fixture)
+	assert.Equal(t, "created", triggered.Data.Action)
+	eventID := triggered.Data.EventID
+	require.NotEmpty(t, eventID)
+	t.Cleanup(func() { deleteEvent(t, client, eventID) })
+
+	event := getWebhookEvent(t, client, eventID)
+	assert.Equal(t, "PD Lifecycle Incident", event.Data.Title)
+	assert.Equal(t, "incident", event.Data.Type)
+	assert.Equal(t, "investigating", event.Data.Status)
+	assert.Equal(t, "critical", event.Data.Severity)
+	assert.Equal(t, "https://acme.pagerduty.com/incidents/"+fixture.IncidentID, event.Data.Description)
+	assert.ElementsMatch(t, []string{apiID, dbID}, event.Data.ServiceIDs)
+	assert.Equal(t, "major_outage", getServiceEffectiveStatus(t, client, apiSlug))
+	assert.Equal(t, "major_outage", getServiceEffectiveStatus(t, client, dbSlug))
+
+	// Redelivered trigger is ignored
+	again := sendPagerDutyFixture(t, "incident_triggered", fixture)
+	assert.Equal(t, "ignored", again.Data.Action)
+	assert.Equal(t, eventID, again.Data.EventID)
+
+	// Acknowledge moves to identified
+	acked := sendPagerDutyFixture(t, "incident_acknowledged", fixture)
+	assert.Equal(t, "updated", acked.Data.Action)
+	assert.Equal(t, eventID, acked.Data.EventID)
+	assert.Equal(t, "identified", getWebhookEvent(t, client, eventID).Data.Status)
+
+	// Resolve closes the incident and restores services
+	resolved := sendPagerDutyFixture(t, "incident_resolved", fixture)
+	assert.Equal(t, "updated", resolved.Data.Action)
+	assert.Equal(t, "resolved", getWebhookEvent(t, client, eventID).Data.Status)
+	assert.Equal(t, "operational", getServiceEffectiveStatus(t, client, apiSlug))
+	assert.Equal(t, "operational", getServiceEffectiveStatus(t, client, dbSlug))
+}
+
+func TestPagerDutyWebhook_SeverityMapping(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "PD Severity Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	tests := []struct {
+		priority      string
+		severity      string
+		serviceStatus string
+	}{
+		{"P2", "major", "partial_outage"},
+		{"P3", "minor", "degraded"},
+		{"P4", "minor", "degraded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.priority, func(t *testing.T) {
+			fixture := newPagerDutyFixture("PD Severity "+tt.priority, tt.priority, slug)
+
+			result := sendPagerDutyFixture(t, "incident_triggered", fixture)
+			require.Equal(t, "created", result.Data.Action)
+			eventID := result.Data.EventID
+
+			assert.Equal(t, tt.severity, getWebhookEvent(t, client, eventID).Data.Severity)
+			assert.Equal(t, tt.serviceStatus, getServiceEffectiveStatus(t, client, slug))
+
+			sendPagerDutyFixture(t, "incident_resolved", fixture)
+			deleteEvent(t, client, eventID)
+		})
+	}
+}
+
+func TestPagerDutyWebhook_AcknowledgeUnknownIncidentCreates(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "PD Ack First Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	fixture := newPagerDutyFixture("PD Ack First Incident", "P2", slug)
+
+	result := sendPagerDutyFixture(t, "incident_acknowledged", fixture)
+	require.Equal(t, "created", result.Data.Action)
+	t.Cleanup(func() {
+		sendPagerDutyFixture(t, "incident_resolved", fixture)
+		deleteEvent(t, client, result.Data.EventID)
+	})
+
+	assert.Equal(t, "identified", getWebhookEvent(t, client, result.Data.EventID).Data.Status)
+}
+
+func TestPagerDutyWebhook_ResolveUnknownIncidentIgnored(t *testing.T) {
+	fixture := newPagerDutyFixture("PD Unknown Incident", "P1")
+
+	result := sendPagerDutyFixture(t, "incident_resolved", fixture)
+	assert.Equal(t, "ignored", result.Data.Action)
+	assert.Empty(t, result.Data.EventID)
+}
+
+func TestPagerDutyWebhook_InvalidSignature(t *testing.T) {
+	body := loadPagerDutyFixture(t, "incident_triggered", newPagerDutyFixture("PD Bad Signature", "P1"))
+
+	tests := []struct {
+		name      string
+		signature string
+	}{
+		{"missing", ""},
+		{"wrong secret", signPagerDuty("wrong-secret", body)},
+		{"malformed", "sha256=abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := postPagerDutyWebhook(t, body, tt.signature)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		})
+	}
+}
+
+func TestPagerDutyWebhook_UnknownServiceSlug(t *testing.T) {
+	fixture := newPagerDutyFixture("PD Unknown Service", "P1", "pd-nonexistent-service")
+	body := loadPagerDutyFixture(t, "incident_triggered", fixture)
+
+	resp := postPagerDutyWebhook(t, body, signPagerDuty(testPagerDutySecret, body))
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
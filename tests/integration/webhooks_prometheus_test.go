@@ -0,0 +1,209 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPrometheusToken is the Alertmanager Bearer token configured for the app under test.
+const testPrometheusToken = "test-prometheus-token"
+
+type alertmanagerAlert struct {
+	Status      string            `json:"status"`
+	Labels      map[string]string `json:"labels"`
+	Fingerprint string            `json:"fingerprint"`
+}
+
+type prometheusResponse struct {
+	Data struct {
+		Results []struct {
+			Fingerprint string `json:"fingerprint"`
+			Action      string `json:"action"`
+			ServiceID   string `json:"service_id"`
+			Status      string `json:"status"`
+		} `json:"results"`
+	} `json:"data"`
+}
+
+// newAlertmanagerAlert returns an alert with a unique fingerprint.
+func newAlertmanagerAlert(status, name, slug, severity string) alertmanagerAlert {
+	return alertmanagerAlert{
+		Status: status,
+		Labels: map[string]string{
+			"alertname":    name,
+			"service_slug": slug,
+			"severity":     severity,
+		},
+		Fingerprint: fmt.Sprintf("fp%d", time.Now().UnixNano()),
+	}
+}
+
+// withStatus returns a copy of the alert in another status (same fingerprint).
+func (a alertmanagerAlert) withStatus(status string) alertmanagerAlert {
+	a.Status = status
+	return a
+}
+
+func postPrometheusWebhook(t *testing.T, token string, alerts ...alertmanagerAlert) *http.Response {
+	t.Helper()
+	body, err := json.Marshal(map[string]interface{}{
+		"version":  "4",
+		"status":   alerts[0].Status,
+		"receiver": "incident-garden",
+		"alerts":   alerts,
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, testServer.URL+"/api/v1/webhooks/prometheus", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+// sendAlerts posts alerts with the configured token and expects 200.
+func sendAlerts(t *testing.T, alerts ...alertmanagerAlert) prometheusResponse {
+	t.Helper()
+	resp := postPrometheusWebhook(t, testPrometheusToken, alerts...)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result prometheusResponse
+	testutil.DecodeJSON(t, resp, &result)
+	return result
+}
+
+func TestPrometheusWebhook_FiringAndResolved(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	serviceID, slug := createTestService(t, client, "Prom Lifecycle Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	warning := newAlertmanagerAlert("firing", "HighLatency", slug, "warning")
+	critical := newAlertmanagerAlert("firing", "HighErrorRate", slug, "critical")
+
+	result := sendAlerts(t, warning)
+	require.Len(t, result.Data.Results, 1)
+	assert.Equal(t, "updated", result.Data.Results[0].Action)
+	assert.Equal(t, serviceID, result.Data.Results[0].ServiceID)
+	assert.Equal(t, "degraded", getServiceEffectiveStatus(t, client, slug))
+
+	result = sendAlerts(t, critical)
+	assert.Equal(t, "updated", result.Data.Results[0].Action)
+	assert.Equal(t, "major_outage", getServiceEffectiveStatus(t, client, slug))
+
+	// Repeated notification for a firing alert keeps the status
+	result = sendAlerts(t, warning)
+	assert.Equal(t, "unchanged", result.Data.Results[0].Action)
+	assert.Equal(t, "major_outage", getServiceEffectiveStatus(t, client, slug))
+
+	// Resolving the critical alert falls back to the remaining warning
+	result = sendAlerts(t, critical.withStatus("resolved"))
+	assert.Equal(t, "updated", result.Data.Results[0].Action)
+	assert.Equal(t, "degraded", getServiceEffectiveStatus(t, client, slug))
+
+	result = sendAlerts(t, warning.withStatus("resolved"))
+	assert.Equal(t, "updated", result.Data.Results[0].Action)
+	assert.Equal(t, "operational", result.Data.Results[0].Status)
+	assert.Equal(t, "operational", getServiceEffectiveStatus(t, client, slug))
+}
+
+func TestPrometheusWebhook_StatusLog(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "Prom Status Log Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	alert := newAlertmanagerAlert("firing", "DiskFull", slug, "critical")
+	sendAlerts(t, alert)
+	sendAlerts(t, alert.withStatus("resolved"))
+
+	resp, err := client.GET("/api/v1/services/" + slug + "/status-log")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var logResult struct {
+		Data struct {
+			Entries []struct {
+				NewStatus  string `json:"new_status"`
+				SourceType string `json:"source_type"`
+				Reason     string `json:"reason"`
+			} `json:"entries"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &logResult)
+
+	reasons := make(map[string]string)
+	for _, entry := range logResult.Data.Entries {
+		if entry.SourceType == "webhook" {
+			reasons[entry.NewStatus] = entry.Reason
+		}
+	}
+	assert.Equal(t, "Prometheus alert firing: DiskFull", reasons["major_outage"])
+	assert.Equal(t, "Prometheus alert resolved: DiskFull", reasons["operational"])
+}
+
+func TestPrometheusWebhook_BatchWithUnknownService(t *testing.T) {
+	client := newTestClient(t)
+	client.LoginAsAdmin(t)
+
+	_, slug := createTestService(t, client, "Prom Batch Service")
+	t.Cleanup(func() { deleteService(t, client, slug) })
+
+	known := newAlertmanagerAlert("firing", "HighLatency", slug, "warning")
+	unknown := newAlertmanagerAlert("firing", "HighLatency", "prom-nonexistent-service", "critical")
+	noService := newAlertmanagerAlert("firing", "Watchdog", "", "none")
+	t.Cleanup(func() { sendAlerts(t, known.withStatus("resolved")) })
+
+	result := sendAlerts(t, known, unknown, noService)
+	require.Len(t, result.Data.Results, 3)
+	assert.Equal(t, "updated", result.Data.Results[0].Action)
+	assert.Equal(t, "ignored", result.Data.Results[1].Action)
+	assert.Equal(t, "ignored", result.Data.Results[2].Action)
+	assert.Equal(t, "degraded", getServiceEffectiveStatus(t, client, slug))
+}
+
+func TestPrometheusWebhook_ResolveUnknownAlertIgnored(t *testing.T) {
+	alert := newAlertmanagerAlert("resolved", "NeverFired", "prom-unknown", "critical")
+
+	result := sendAlerts(t, alert)
+	require.Len(t, result.Data.Results, 1)
+	assert.Equal(t, "ignored", result.Data.Results[0].Action)
+	assert.Equal(t, alert.Fingerprint, result.Data.Results[0].Fingerprint)
+}
+
+func TestPrometheusWebhook_InvalidToken(t *testing.T) {
+	alert := newAlertmanagerAlert("firing", "HighLatency", "prom-any", "warning")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"missing", ""},
+		{"wrong token", "wrong-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := postPrometheusWebhook(t, tt.token, alert)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bissquit/incident-garden/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rotationStatus(t *testing.T, client *testutil.Client, method, path string) bool {
+	t.Helper()
+	var resp *http.Response
+	var err error
+	if method == http.MethodPost {
+		resp, err = client.POST(path, nil)
+	} else {
+		resp, err = client.GET(path)
+	}
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			SecondarySecretActive bool `json:"secondary_secret_active"`
+		} `json:"data"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	return result.Data.SecondarySecretActive
+}
+
+func TestWebhookSecretRotation(t *testing.T) {
+	admin := newTestClient(t)
+	admin.LoginAsAdmin(t)
+
+	// A resolve of an unknown incident is accepted and ignored, so it doesn't create events
+	body := loadPagerDutyFixture(t, "incident_resolved", newPagerDutyFixture("PD Secret Rotation", "P1"))
+	postSigned := func(secret string) int {
+		resp := postPagerDutyWebhook(t, body, signPagerDuty(secret, body))
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	require.True(t, rotationStatus(t, admin, http.MethodGet, "/api/v1/admin/webhooks/secret-rotation-status"))
+	assert.Equal(t, http.StatusOK, postSigned(testPagerDutySecret))
+	assert.Equal(t, http.StatusOK, postSigned(testPagerDutyPreviousSecret))
+	assert.Equal(t, http.StatusUnauthorized, postSigned("wrong-secret"))
+
+	operator := newTestClient(t)
+	operator.LoginAsOperator(t)
+	resp, err := operator.POST("/api/v1/admin/webhooks/complete-rotation", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	assert.False(t, rotationStatus(t, admin, http.MethodPost, "/api/v1/admin/webhooks/complete-rotation"))
+	assert.False(t, rotationStatus(t, admin, http.MethodGet, "/api/v1/admin/webhooks/secret-rotation-status"))
+	assert.Equal(t, 1, countRows(t, `
+		SELECT COUNT(*) FROM admin_audit_log
+		WHERE action = 'webhooks.complete_rotation' AND target_id = $1`, "pagerduty"))
+	// Persisted, so other replicas and restarts reject the previous secret too
+	assert.Equal(t, 1, countRows(t, `SELECT COUNT(*) FROM webhook_retired_secrets WHERE source = $1`, "pagerduty"))
+
+	assert.Equal(t, http.StatusOK, postSigned(testPagerDutySecret))
+	assert.Equal(t, http.StatusUnauthorized, postSigned(testPagerDutyPreviousSecret))
+
+	// Completing again is a no-op
+	assert.False(t, rotationStatus(t, admin, http.MethodPost, "/api/v1/admin/webhooks/complete-rotation"))
+}